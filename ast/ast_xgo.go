@@ -236,6 +236,7 @@ func (p *KwargExpr) exprNode() {}
 //	domainTag`> arg1, arg2, ...
 //	  ...
 //	`
+//	domainTag`... ${expr} ...`
 type DomainTextLit struct {
 	Domain   *Ident    // domain name
 	ValuePos token.Pos // literal position
@@ -248,6 +249,7 @@ type DomainTextLitEx struct {
 	Args   []Expr    // domain text arguments; or nil
 	RawPos token.Pos // position of the first character of the raw string
 	Raw    string    // raw string without backquote
+	Parts  []any     // string and Expr parts of Raw if it has ${expr} placeholders; or nil
 }
 
 // Pos returns position of first character belonging to the node.
@@ -92,6 +92,11 @@ func Walk(v Visitor, node Node) {
 		Walk(v, n.Domain)
 		if e, ok := n.Extra.(*DomainTextLitEx); ok {
 			walkList(v, e.Args)
+			for _, part := range e.Parts {
+				if x, ok := part.(Expr); ok {
+					Walk(v, x)
+				}
+			}
 		}
 
 	case *Ellipsis:
@@ -0,0 +1,13 @@
+package main
+
+import (
+	"fmt"
+	"github.com/goplus/xgo/encoding/csv"
+)
+
+func main() {
+	fmt.Println(csv.New(`name,age
+ken,18
+`))
+	fmt.Println(csv.New("name;age\nken;18\n", ';'))
+}
@@ -0,0 +1,31 @@
+package main
+
+import (
+	"fmt"
+	"github.com/goplus/xgo/encoding/html"
+	"github.com/goplus/xgo/encoding/json"
+	"github.com/goplus/xgo/encoding/sql"
+	"github.com/goplus/xgo/encoding/yaml"
+	"github.com/qiniu/x/errors"
+)
+
+func main() {
+	id, name := 1, "ken"
+	q := func() (_xgo_ret sql.Object) {
+		var _xgo_err error
+		_xgo_ret, _xgo_err = sql.NewEx([]string{"SELECT * FROM users WHERE id = ", " AND name = ", ""}, id, name)
+		if _xgo_err != nil {
+			_xgo_err = errors.NewFrame(_xgo_err, "sql`SELECT * FROM users WHERE id = ${id} AND name = ${name}`", "cl/_testxgo/domaintext-interp/in.xgo", 2, "main.main")
+			panic(_xgo_err)
+		}
+		return
+	}()
+	fmt.Println(q.SQL, q.Args)
+	fmt.Println(json.NewEx([]string{"{\"id\": ", ", \"name\": ", "}"}, id, name))
+	fmt.Println(json.NewEx([]string{"{\"price\": \"${price}\", \"id\": ", "}"}, id+1))
+	fmt.Println(yaml.New(`name: ${name}`))
+	fmt.Println(json.NewEx([]string{"{\"p\": \"${x}\"}"}))
+	fmt.Println(yaml.New(`home: ${HOME:-/tmp}`))
+	fmt.Println(html.New(`<p>${undefinedName}</p>`))
+	fmt.Println(json.NewEx([]string{"{\"greeting\": \"hello ", "!\"}"}, name))
+}
@@ -0,0 +1,15 @@
+package main
+
+import (
+	"fmt"
+	"github.com/goplus/xgo/encoding/toml"
+)
+
+func main() {
+	fmt.Println(toml.New(`
+title = "demo"
+
+[owner]
+name = "Tom"
+`))
+}
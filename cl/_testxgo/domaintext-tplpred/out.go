@@ -0,0 +1,27 @@
+package main
+
+import (
+	"fmt"
+	"github.com/goplus/xgo/tpl"
+	"github.com/qiniu/x/errors"
+)
+
+func main() {
+	maxVersion := 2
+	cl := func() (_xgo_ret tpl.Compiler) {
+		var _xgo_err error
+		_xgo_ret, _xgo_err = tpl.NewEx(`
+stmt = "yield" &{ maxVersion >= 2 } INT | IDENT !{ self[0].(*tpl.Token).Lit == "yield" }
+`, "cl/_testxgo/domaintext-tplpred/in.xgo", 5, 10, "stmt/1", func(self []interface{}) bool {
+			return maxVersion >= 2
+		}, "stmt/2", func(self []interface{}) bool {
+			return self[0].(*tpl.Token).Lit == "yield"
+		})
+		if _xgo_err != nil {
+			_xgo_err = errors.NewFrame(_xgo_err, "tpl`\nstmt = \"yield\" &{ maxVersion >= 2 } INT | IDENT !{ self[0].(*tpl.Token).Lit == \"yield\" }\n`", "cl/_testxgo/domaintext-tplpred/in.xgo", 5, "main.main")
+			panic(_xgo_err)
+		}
+		return
+	}()
+	fmt.Println(cl.ParseExpr("yield 1", nil))
+}
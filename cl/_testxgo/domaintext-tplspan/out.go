@@ -0,0 +1,33 @@
+package main
+
+import (
+	"fmt"
+	"github.com/goplus/xgo/tpl"
+	"github.com/qiniu/x/errors"
+)
+
+func main() {
+	cl := func() (_xgo_ret tpl.Compiler) {
+		var _xgo_err error
+		_xgo_ret, _xgo_err = tpl.NewEx(`
+expr = INT % "+" => {
+	if len(self[1].([]any)) > 1 {
+		tpl.panic span.Pos, "too many operands"
+	}
+	return self
+}
+`, "cl/_testxgo/domaintext-tplspan/in.xgo", 3, 10, "expr", func(self []interface{}, span tpl.Span) interface{} {
+			if len(self[1].([]interface{})) > 1 {
+				tpl.Panic(span.Pos, "too many operands")
+			}
+			return self
+		})
+		if _xgo_err != nil {
+			_xgo_err = errors.NewFrame(_xgo_err, "tpl`\nexpr = INT % \"+\" => {\n\tif len(self[1].([]any)) > 1 {\n\t\ttpl.panic span.Pos, \"too many operands\"\n\t}\n\treturn self\n}\n`", "cl/_testxgo/domaintext-tplspan/in.xgo", 3, "main.main")
+			panic(_xgo_err)
+		}
+		return
+	}()
+	fmt.Println(cl.ParseExpr("1 + 2", nil))
+	fmt.Println(cl.ParseExpr("1 + 2 + 3", nil))
+}
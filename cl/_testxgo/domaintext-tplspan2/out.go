@@ -0,0 +1,35 @@
+package main
+
+import (
+	"fmt"
+	"github.com/goplus/xgo/tpl"
+	"github.com/qiniu/x/errors"
+)
+
+func main() {
+	span := "outer"
+	cl := func() (_xgo_ret tpl.Compiler) {
+		var _xgo_err error
+		_xgo_ret, _xgo_err = tpl.NewEx(`
+expr = term % "+" => {
+	return [span, self]
+}
+
+term = INT => {
+	span := len(self.(*tpl.Token).Lit)
+	return span
+}
+`, "cl/_testxgo/domaintext-tplspan2/in.xgo", 4, 10, "expr", func(self []interface{}) interface{} {
+			return []interface{}{span, self}
+		}, "term", func(self interface{}) interface{} {
+			span := len(self.(*tpl.Token).Lit)
+			return span
+		})
+		if _xgo_err != nil {
+			_xgo_err = errors.NewFrame(_xgo_err, "tpl`\nexpr = term % \"+\" => {\n\treturn [span, self]\n}\n\nterm = INT => {\n\tspan := len(self.(*tpl.Token).Lit)\n\treturn span\n}\n`", "cl/_testxgo/domaintext-tplspan2/in.xgo", 4, "main.main")
+			panic(_xgo_err)
+		}
+		return
+	}()
+	fmt.Println(cl.ParseExpr("1 + 2", nil))
+}
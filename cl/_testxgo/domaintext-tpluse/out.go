@@ -0,0 +1,35 @@
+package main
+
+import (
+	"fmt"
+	"github.com/goplus/xgo/tpl"
+	"github.com/qiniu/x/errors"
+)
+
+func main() {
+	base := func() (_xgo_ret tpl.Compiler) {
+		var _xgo_err error
+		_xgo_ret, _xgo_err = tpl.NewEx(`
+expr = INT % ("+" | "-")
+`, "cl/_testxgo/domaintext-tpluse/in.xgo", 1, 12)
+		if _xgo_err != nil {
+			_xgo_err = errors.NewFrame(_xgo_err, "tpl`\nexpr = INT % (\"+\" | \"-\")\n`", "cl/_testxgo/domaintext-tpluse/in.xgo", 1, "main.main")
+			panic(_xgo_err)
+		}
+		return
+	}()
+	calc := func() (_xgo_ret tpl.Compiler) {
+		var _xgo_err error
+		_xgo_ret, _xgo_err = tpl.NewEx(`
+use base
+
+stmts = *(expr ";")
+`, "cl/_testxgo/domaintext-tpluse/in.xgo", 5, 12, "use/base", base)
+		if _xgo_err != nil {
+			_xgo_err = errors.NewFrame(_xgo_err, "tpl`\nuse base\n\nstmts = *(expr \";\")\n`", "cl/_testxgo/domaintext-tpluse/in.xgo", 5, "main.main")
+			panic(_xgo_err)
+		}
+		return
+	}()
+	fmt.Println(calc.ParseExpr("1+2; 3-4;", nil))
+}
@@ -0,0 +1,17 @@
+package main
+
+import (
+	"fmt"
+	"github.com/goplus/xgo/encoding/yaml"
+)
+
+type Config struct {
+	Port int
+}
+
+var conf Config
+
+func main() {
+	fmt.Println(yaml.NewWith("a: 1\n", yaml.Strict))
+	fmt.Println(yaml.NewWith("port: 8080\n", &conf, yaml.Strict))
+}
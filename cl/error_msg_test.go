@@ -45,6 +45,16 @@ func TestErrTplLit(t *testing.T) {
 	want (interface{})`, "tpl`a = INT => { return }`")
 }
 
+func TestErrDomainTextLit(t *testing.T) {
+	codeErrorTest(t, "bar.xgo:1:6: invalid json literal: invalid character '}' after array element", "echo json`{\"a\": [1, 2}`")
+	codeErrorTest(t, "bar.xgo:1:10: invalid regexp literal: error parsing regexp: missing closing ]: `[a-z`", "re, _ := regexp`[a-z`")
+	codeErrorTest(t, "bar.xgo:1:6: invalid xml literal: XML syntax error on line 1: unexpected EOF", "echo xml`<doc><a></a>`")
+	codeErrorTest(t, "bar.xgo:1:6: invalid xml literal: XML syntax error on line 1: element <a> closed by </b>", "echo xml`<a>${x}</b>`")
+	codeErrorTest(t, "bar.xgo:2:6: sql literal with arguments can't have ${} placeholders", "id := 1\necho sql`> 1\nSELECT * FROM t WHERE id = ${id}`")
+	codeErrorTest(t, "bar.xgo:1:19: invalid expression in ${} placeholder of json literal", "echo json`{\"t\":\"${a b}\"}`")
+	codeErrorTest(t, "bar.xgo:1:13: undefined: strict", "echo yaml`> strict\na: 1\n`")
+}
+
 func TestErrSendStmt(t *testing.T) {
 	codeErrorTest(t, `bar.xgo:3:7: can't send multiple values to a channel`, `
 	var a chan int
@@ -1607,6 +1607,17 @@ const (
 	encodingPkgPrefix = "github.com/goplus/xgo/encoding/"
 )
 
+// domainValidators checks domain text literals at compile time, so malformed
+// literals are compile errors instead of runtime errors.
+var domainValidators = make(map[string]func(text string) error)
+
+// RegisterDomainValidator registers validate to check domain text literals
+// (without arguments) of the package pkgPath at compile time. It should be
+// called before compiling, typically in an init function.
+func RegisterDomainValidator(pkgPath string, validate func(text string) error) {
+	domainValidators[pkgPath] = validate
+}
+
 // A DomainTextLit node represents a domain-specific text literal.
 // https://github.com/goplus/xgo/issues/2143
 //
@@ -1614,6 +1625,9 @@ const (
 //	domainTag`> arg1, arg2, ...
 //	  ...
 //	`
+//
+// Literals with arguments call NewWith of the domain package if it has one,
+// otherwise New.
 func compileDomainTextLit(ctx *blockCtx, v *ast.DomainTextLit) {
 	var cb = ctx.cb
 	var imp gogen.PkgRef
@@ -1647,48 +1661,275 @@ func compileDomainTextLit(ctx *blockCtx, v *ast.DomainTextLit) {
 		if f, ok := v.Extra.(*tpl.File); ok {
 			decls := f.Decls
 			for _, decl := range decls {
-				if r, ok := decl.(*tpl.Rule); ok {
+				if use, ok := decl.(*tpl.UseDecl); ok {
+					for _, ident := range use.Names {
+						cb.Val("use/" + ident.Name)
+						compileExpr(ctx, 1, &ast.Ident{NamePos: ident.NamePos, Name: ident.Name})
+						n += 2
+					}
+				} else if r, ok := decl.(*tpl.Rule); ok {
 					if expr, ok := r.RetProc.(*ast.LambdaExpr2); ok {
+						var span types.Type
+						if usesSpan(ctx, expr) {
+							span = imp.Ref("Span").Type()
+						}
 						cb.Val(r.Name.Name)
-						sig := sigRetFunc(ctx.pkg, r.IsList())
-						compileLambdaExpr2(ctx, lambdaRetFunc(expr), sig)
+						sig := sigRetFunc(ctx.pkg, r.IsList(), span)
+						compileLambdaExpr2(ctx, lambdaRetFunc(expr, span != nil), sig)
 						n += 2
 					}
+					for i, pred := range r.Predicates() {
+						if cond, ok := pred.Cond.(ast.Expr); ok {
+							cb.Val(r.Name.Name + "/" + strconv.Itoa(i+1))
+							compileLambda(ctx, lambdaCondFunc(cond), sigCondFunc(ctx.pkg))
+							n += 2
+						}
+					}
 				}
 			}
 		}
 	} else {
-		cb.Val(imp.Ref("New"))
-		if lit, ok := v.Extra.(*ast.DomainTextLitEx); ok {
-			cb.Val(lit.Raw)
+		raw := ""
+		lit, _ := v.Extra.(*ast.DomainTextLitEx)
+		if lit != nil {
+			raw = lit.Raw
+			if lit.Parts != nil {
+				if newEx := imp.TryRef("NewEx"); newEx != nil {
+					if lit.Args == nil {
+						compileDomainTextParts(ctx, v, lit, newEx)
+						return
+					}
+					text, ok := domainTextOfParts(lit.Parts)
+					if !ok {
+						invalidVal(cb)
+						ctx.handleErrorf(v.Pos(), v.End(), "%s literal with arguments can't have ${} placeholders", v.Domain.Name)
+						return
+					}
+					raw = text
+				}
+			}
+		}
+		if validate, ok := domainValidators[path]; ok {
+			if lit == nil {
+				if text, err := strconv.Unquote(v.Value); err == nil {
+					validateDomainTextLit(ctx, v, text, validate)
+				}
+			} else if lit.Args == nil {
+				validateDomainTextLit(ctx, v, raw, validate)
+			}
+		}
+		if lit != nil {
+			newFn := imp.TryRef("NewWith")
+			if newFn == nil || lit.Args == nil {
+				newFn = imp.Ref("New")
+			}
+			cb.Val(newFn)
+			cb.Val(raw)
 			for _, arg := range lit.Args {
+				if sel, ok := arg.(*ast.SelectorExpr); ok && compileDomainArgSel(ctx, imp, name, sel) {
+					continue
+				}
 				compileExpr(ctx, 1, arg)
 			}
 			n += len(lit.Args)
 		} else {
+			cb.Val(imp.Ref("New"))
 			cb.Val(&goast.BasicLit{Kind: gotoken.STRING, Value: v.Value}, v)
 		}
 	}
 	cb.CallWith(n, 0, 0, v)
 }
 
-func lambdaRetFunc(expr *ast.LambdaExpr2) *ast.LambdaExpr2 {
+// compileDomainTextParts compiles a domain text literal with ${expr}
+// placeholders into a call of the NewEx function of the domain package:
+//
+//	NewEx(texts []string, args ...any)
+//
+// where texts are the text parts around placeholders, and args are the values
+// of placeholders, so len(texts) == len(args)+1.
+func compileDomainTextParts(ctx *blockCtx, v *ast.DomainTextLit, lit *ast.DomainTextLitEx, newEx types.Object) {
+	cb := ctx.cb
+	cb.Val(newEx, v)
+	var args []ast.Expr
+	var text string
+	for _, part := range lit.Parts {
+		switch part := part.(type) {
+		case string:
+			text = part
+		case ast.Expr:
+			cb.Val(text)
+			text = ""
+			args = append(args, part)
+		}
+	}
+	cb.Val(text).SliceLit(types.NewSlice(types.Typ[types.String]), len(args)+1)
+	for _, arg := range args {
+		if bad, ok := arg.(*ast.BadExpr); ok {
+			ctx.handleErrorf(bad.Pos(), bad.End(), "invalid expression in ${} placeholder of %s literal", v.Domain.Name)
+			cb.Val(nil)
+			continue
+		}
+		compileExpr(ctx, 1, arg)
+	}
+	cb.CallWith(len(args)+1, 0, 0, v)
+}
+
+// domainTextOfParts returns the text of a domain text literal with arguments
+// whose package has NewEx, where escaped ${ are unescaped. It returns false if
+// there are placeholders, because NewEx doesn't take arguments of literals.
+func domainTextOfParts(parts []any) (string, bool) {
+	var b strings.Builder
+	for _, part := range parts {
+		text, ok := part.(string)
+		if !ok {
+			return "", false
+		}
+		b.WriteString(text)
+	}
+	return b.String(), true
+}
+
+// validateDomainTextLit reports an error if the text of a domain text literal
+// (without arguments) isn't valid.
+func validateDomainTextLit(ctx *blockCtx, v *ast.DomainTextLit, text string, validate func(string) error) {
+	if err := validate(text); err != nil {
+		ctx.handleErrorf(v.Pos(), v.End(), "invalid %s literal: %v", v.Domain.Name, err)
+	}
+}
+
+// compileDomainArgSel compiles a selector argument of a domain text literal
+// qualified by the domain tag, as an exported member of the domain package,
+// even if the package isn't imported. For example, yaml.Strict in
+// yaml`> yaml.Strict ...`.
+func compileDomainArgSel(ctx *blockCtx, imp gogen.PkgRef, domain string, sel *ast.SelectorExpr) bool {
+	x, ok := sel.X.(*ast.Ident)
+	if !ok || x.Name != domain {
+		return false
+	}
+	if _, o := ctx.cb.Scope().LookupParent(domain, token.NoPos); o != nil {
+		return false
+	}
+	if _, ok := ctx.syms[domain]; ok {
+		return false
+	}
+	if _, ok := ctx.findImport(domain); ok {
+		return false
+	}
+	o := imp.TryRef(sel.Sel.Name)
+	if o == nil {
+		return false
+	}
+	ctx.cb.Val(o, sel)
+	return true
+}
+
+// lambdaRetFunc converts the RetProc of a rule in a tpl literal to a lambda:
+// self => { ... }, or (self, span) => { ... } if span is used.
+func lambdaRetFunc(expr *ast.LambdaExpr2, span bool) *ast.LambdaExpr2 {
 	v := *expr
 	v.Lhs = []*ast.Ident{
 		{NamePos: expr.Pos(), Name: "self"},
 	}
+	if span {
+		v.Lhs = append(v.Lhs, &ast.Ident{NamePos: expr.Pos(), Name: "span"})
+	}
 	return &v
 }
 
-func sigRetFunc(pkg *gogen.Package, isList bool) *types.Signature {
+// usesSpan reports whether the RetProc of a rule in a tpl literal uses span,
+// the source span of the match. It does only if span is a free identifier of
+// the RetProc: not a selector, not declared in the RetProc and not resolved in
+// the enclosing scope (eg. a captured variable named span).
+func usesSpan(ctx *blockCtx, expr *ast.LambdaExpr2) bool {
+	const name = "span"
+	if _, o := ctx.cb.Scope().LookupParent(name, token.NoPos); o != nil || ctx.loadSymbol(name) {
+		return false
+	}
+	var used, declared bool
+	decl := func(idents ...*ast.Ident) {
+		for _, ident := range idents {
+			if ident != nil && ident.Name == name {
+				declared = true
+			}
+		}
+	}
+	declExprs := func(exprs ...ast.Expr) {
+		for _, e := range exprs {
+			if ident, ok := e.(*ast.Ident); ok {
+				decl(ident)
+			}
+		}
+	}
+	var visit func(n ast.Node) bool
+	visit = func(n ast.Node) bool {
+		switch v := n.(type) {
+		case *ast.Ident:
+			if v.Name == name {
+				used = true
+			}
+		case *ast.SelectorExpr:
+			ast.Inspect(v.X, visit)
+			return false
+		case *ast.AssignStmt:
+			if v.Tok == token.DEFINE {
+				declExprs(v.Lhs...)
+			}
+		case *ast.RangeStmt:
+			if v.Tok == token.DEFINE {
+				declExprs(v.Key, v.Value)
+			}
+		case *ast.ValueSpec:
+			decl(v.Names...)
+		case *ast.Field:
+			decl(v.Names...)
+		case *ast.ForPhrase:
+			decl(v.Key, v.Value)
+		case *ast.LambdaExpr:
+			decl(v.Lhs...)
+		case *ast.LambdaExpr2:
+			decl(v.Lhs...)
+		}
+		return !declared
+	}
+	ast.Inspect(expr.Body, visit)
+	return used && !declared
+}
+
+// lambdaCondFunc converts the condition of a semantic predicate in a tpl
+// literal to a lambda: self => cond.
+func lambdaCondFunc(cond ast.Expr) *ast.LambdaExpr {
+	return &ast.LambdaExpr{
+		First:  cond.Pos(),
+		Lhs:    []*ast.Ident{{NamePos: cond.Pos(), Name: "self"}},
+		Rarrow: cond.Pos(),
+		Rhs:    []ast.Expr{cond},
+		Last:   cond.End(),
+	}
+}
+
+// sigCondFunc returns the signature of conditions of semantic predicates:
+// func(self []any) bool.
+func sigCondFunc(pkg *gogen.Package) *types.Signature {
+	args := types.NewTuple(anySliceParam(pkg))
+	rets := types.NewTuple(pkg.NewParam(token.NoPos, "", types.Typ[types.Bool], false))
+	return types.NewSignatureType(nil, nil, nil, args, rets, false)
+}
+
+// sigRetFunc returns the signature of RetProcs: func(self any) any, or
+// func(self []any) any for list rules, with a span parameter if span isn't
+// nil.
+func sigRetFunc(pkg *gogen.Package, isList bool, span types.Type) *types.Signature {
 	rets := types.NewTuple(anyParam(pkg))
-	var args *types.Tuple
+	var params []*types.Var
 	if isList {
-		args = types.NewTuple(anySliceParam(pkg))
+		params = append(params, anySliceParam(pkg))
 	} else {
-		args = rets
+		params = append(params, anyParam(pkg))
 	}
-	return types.NewSignatureType(nil, nil, nil, args, rets, false)
+	if span != nil {
+		params = append(params, pkg.NewParam(token.NoPos, "", span, false))
+	}
+	return types.NewSignatureType(nil, nil, nil, types.NewTuple(params...), rets, false)
 }
 
 func anyParam(pkg *gogen.Package) *types.Var {
@@ -0,0 +1,215 @@
+/*
+ * Copyright (c) 2026 The XGo Authors (xgo.dev). All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package outline
+
+import (
+	"go/types"
+	"sort"
+
+	"github.com/goplus/gogen"
+	"github.com/goplus/xgo/ast"
+	"github.com/goplus/xgo/token"
+)
+
+// -----------------------------------------------------------------------------
+
+// docsLoader loads docs of objects from XGo source files, which aren't
+// recorded by cl: docs of consts, vars, struct fields, classes and functions
+// of overload groups.
+type docsLoader struct {
+	docs   gogen.ObjectDocs
+	scope  *types.Scope
+	fields map[token.Pos]*ast.CommentGroup // field name position => doc
+}
+
+func loadDocs(docs gogen.ObjectDocs, pkg *types.Package, in *ast.Package) *ast.CommentGroup {
+	p := &docsLoader{
+		docs:   docs,
+		scope:  pkg.Scope(),
+		fields: make(map[token.Pos]*ast.CommentGroup),
+	}
+	names := make([]string, 0, len(in.Files))
+	for name := range in.Files {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	var pkgDoc *ast.CommentGroup
+	var classes []*ast.File
+	for _, name := range names {
+		f := in.Files[name]
+		if f.IsClass {
+			classes = append(classes, f)
+		} else if pkgDoc == nil {
+			pkgDoc = f.Doc
+		}
+		p.loadFile(f)
+	}
+	p.loadFields(classes)
+	return pkgDoc
+}
+
+func (p *docsLoader) loadFile(f *ast.File) {
+	classDecl := f.ClassFieldsDecl()
+	for _, decl := range f.Decls {
+		switch d := decl.(type) {
+		case *ast.GenDecl:
+			if d == classDecl {
+				for _, spec := range d.Specs {
+					spec := spec.(*ast.ValueSpec)
+					p.setFieldDocs(spec.Names, spec.Doc, spec.Comment)
+				}
+				continue
+			}
+			for _, spec := range d.Specs {
+				switch spec := spec.(type) {
+				case *ast.ValueSpec:
+					for _, name := range spec.Names {
+						p.setDoc(p.scope.Lookup(name.Name), spec.Doc, d.Doc)
+					}
+				case *ast.TypeSpec:
+					p.setDoc(p.scope.Lookup(spec.Name.Name), spec.Doc, d.Doc)
+					if t, ok := spec.Type.(*ast.StructType); ok {
+						for _, fld := range t.Fields.List {
+							p.setFieldDocs(fld.Names, fld.Doc, fld.Comment)
+						}
+					}
+				}
+			}
+		case *ast.OverloadFuncDecl:
+			if d.Recv == nil {
+				p.loadOverload(f, d)
+			}
+		}
+	}
+}
+
+// loadOverload loads docs of the functions of overload group d, which are
+// the comments just before them.
+func (p *docsLoader) loadOverload(f *ast.File, d *ast.OverloadFuncDecl) {
+	p.setDoc(p.scope.Lookup(d.Name.Name), d.Doc)
+	prev := d.Lparen
+	for idx, fn := range d.Funcs {
+		if lit, ok := fn.(*ast.FuncLit); ok {
+			if doc := commentBetween(f, prev, lit.Pos()); doc != nil {
+				p.docs[p.scope.Lookup(overloadFuncName(d.Name.Name, idx))] = doc
+			}
+		}
+		prev = fn.End()
+	}
+}
+
+// commentBetween returns the last comment group between from and to.
+func commentBetween(f *ast.File, from, to token.Pos) (ret *ast.CommentGroup) {
+	for _, cg := range f.Comments {
+		if cg.Pos() > from && cg.End() < to {
+			ret = cg
+		}
+	}
+	return
+}
+
+func (p *docsLoader) setFieldDocs(names []*ast.Ident, docs ...*ast.CommentGroup) {
+	for _, doc := range docs {
+		if doc != nil {
+			for _, name := range names {
+				p.fields[name.Pos()] = doc
+			}
+			return
+		}
+	}
+}
+
+// loadFields sets docs of struct fields, and docs of classes defined by
+// classfiles, which are the comments at the beginning of them.
+func (p *docsLoader) loadFields(classes []*ast.File) {
+	for _, name := range p.scope.Names() {
+		t, ok := p.scope.Lookup(name).(*types.TypeName)
+		if !ok || t.IsAlias() {
+			continue
+		}
+		named, ok := t.Type().(*types.Named)
+		if !ok {
+			continue
+		}
+		if st, ok := named.Underlying().(*types.Struct); ok {
+			for i, n := 0, st.NumFields(); i < n; i++ {
+				fld := st.Field(i)
+				if doc, ok := p.fields[fld.Pos()]; ok {
+					p.docs[fld] = doc
+				}
+			}
+		}
+		if t.Pos() == token.NoPos { // class types have no position
+			pos := memberPos(named)
+			for _, f := range classes {
+				if f.Pos() <= pos && pos < f.End() {
+					p.setDoc(t, classDoc(f))
+				}
+			}
+		}
+	}
+}
+
+// memberPos returns the position of a method or a field of named type.
+func memberPos(named *types.Named) token.Pos {
+	if named.NumMethods() > 0 {
+		return named.Method(0).Pos()
+	}
+	if st, ok := named.Underlying().(*types.Struct); ok {
+		for i, n := 0, st.NumFields(); i < n; i++ {
+			if pos := st.Field(i).Pos(); pos != token.NoPos {
+				return pos
+			}
+		}
+	}
+	return token.NoPos
+}
+
+// classDoc returns the doc of the class defined by classfile f.
+func classDoc(f *ast.File) *ast.CommentGroup {
+	if f.Doc != nil || !f.NoPkgDecl || len(f.Comments) == 0 {
+		return f.Doc
+	}
+	if cg := f.Comments[0]; len(f.Decls) == 0 || cg.End() < f.Decls[0].Pos() {
+		return cg
+	}
+	return nil
+}
+
+// setDoc sets the doc of obj to the first non-nil doc of docs, if obj has no
+// doc yet.
+func (p *docsLoader) setDoc(obj types.Object, docs ...*ast.CommentGroup) {
+	if obj == nil || p.docs[obj] != nil {
+		return
+	}
+	for _, doc := range docs {
+		if doc != nil {
+			p.docs[obj] = doc
+			return
+		}
+	}
+}
+
+const (
+	indexTable = "0123456789abcdefghijklmnopqrstuvwxyz"
+)
+
+func overloadFuncName(name string, idx int) string {
+	return name + "__" + indexTable[idx:idx+1]
+}
+
+// -----------------------------------------------------------------------------
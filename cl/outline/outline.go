@@ -47,6 +47,7 @@ type Config struct {
 type Package struct {
 	pkg  *types.Package
 	docs gogen.ObjectDocs
+	doc  *ast.CommentGroup
 }
 
 // NewPackage creates a Go/XGo outline package.
@@ -63,13 +64,23 @@ func NewPackage(pkgPath string, pkg *ast.Package, conf *Config) (_ Package, err
 	if err != nil {
 		return
 	}
-	return Package{ret.Types, ret.Docs}, nil
+	docs := ret.Docs
+	if docs == nil {
+		docs = make(gogen.ObjectDocs)
+	}
+	doc := loadDocs(docs, ret.Types, pkg)
+	return Package{ret.Types, docs, doc}, nil
 }
 
 func (p Package) Pkg() *types.Package {
 	return p.pkg
 }
 
+// Doc returns the package documentation.
+func (p Package) Doc() string {
+	return p.doc.Text()
+}
+
 func (p Package) Valid() bool {
 	return p.pkg != nil
 }
@@ -188,7 +199,7 @@ func (p *All) getNamed(t *types.TypeName) *TypeName {
 func (p *All) initNamed(aliasr *typeutil.Map, objs []types.Object) {
 	for _, o := range objs {
 		if t, ok := o.(*types.TypeName); ok {
-			named := &TypeName{TypeName: t}
+			named := &TypeName{TypeName: t, docs: p.docs}
 			p.named[t] = named
 			p.Types = append(p.Types, named)
 			if t.IsAlias() {
@@ -267,15 +278,15 @@ func (p Package) Outline(withUnexported ...bool) (ret *All) {
 				ret.checkUsed(typ)
 			}
 			if named := ret.checkLocal(aliasr, typ, true); named != nil {
-				named.Consts = append(named.Consts, Const{v})
+				named.Consts = append(named.Consts, Const{v, p.docs})
 			} else {
-				ret.Consts = append(ret.Consts, Const{v})
+				ret.Consts = append(ret.Consts, Const{v, p.docs})
 			}
 		case *types.Var:
 			if !all {
 				ret.checkUsed(v.Type())
 			}
-			ret.Vars = append(ret.Vars, Var{v})
+			ret.Vars = append(ret.Vars, Var{v, p.docs})
 		}
 	}
 	return
@@ -329,6 +340,7 @@ func indirect(typ types.Type) types.Type {
 
 type Const struct {
 	*types.Const
+	docs gogen.ObjectDocs
 }
 
 func (p Const) Obj() types.Object {
@@ -336,11 +348,12 @@ func (p Const) Obj() types.Object {
 }
 
 func (p Const) Doc() string {
-	return ""
+	return p.docs[p.Const].Text()
 }
 
 type Var struct {
 	*types.Var
+	docs gogen.ObjectDocs
 }
 
 func (p Var) Obj() types.Object {
@@ -348,7 +361,7 @@ func (p Var) Obj() types.Object {
 }
 
 func (p Var) Doc() string {
-	return ""
+	return p.docs[p.Var].Text()
 }
 
 type Func struct {
@@ -410,6 +423,7 @@ type TypeName struct {
 	GoptFuncs []Func
 	Helpers   []Func
 	isUsed    bool
+	docs      gogen.ObjectDocs
 }
 
 func (p *TypeName) IsUsed() bool {
@@ -429,7 +443,21 @@ func (p *TypeName) Obj() types.Object {
 }
 
 func (p *TypeName) Doc() string {
-	return ""
+	return p.docs[p.TypeName].Text()
+}
+
+// Fields returns fields of the type if it's a struct type.
+func (p *TypeName) Fields() []Var {
+	st, ok := p.TypeName.Type().Underlying().(*types.Struct)
+	if !ok {
+		return nil
+	}
+	n := st.NumFields()
+	ret := make([]Var, n)
+	for i := 0; i < n; i++ {
+		ret[i] = Var{st.Field(i), p.docs}
+	}
+	return ret
 }
 
 func (p *TypeName) Type() Type {
@@ -52,6 +52,16 @@ func (p *PassArgs) Tags() string {
 	return ""
 }
 
+// Mod returns value of the -mod flag.
+func (p *PassArgs) Mod() string {
+	for _, v := range p.Args {
+		if strings.HasPrefix(v, "-mod=") {
+			return v[5:]
+		}
+	}
+	return ""
+}
+
 func (p *PassArgs) Var(names ...string) {
 	for _, name := range names {
 		p.Flag.Var(&stringValue{p: p, name: name}, name, "")
@@ -78,6 +88,6 @@ func PassBuildFlags(cmd *Command) *PassArgs {
 		"trimpath", "work")
 	p.Var("p", "asmflags", "compiler", "buildmode",
 		"gcflags", "gccgoflags", "installsuffix",
-		"ldflags", "pkgdir", "tags", "toolexec", "buildvcs")
+		"ldflags", "mod", "pkgdir", "tags", "toolexec", "buildvcs")
 	return p
 }
@@ -0,0 +1,354 @@
+/*
+ * Copyright (c) 2026 The XGo Authors (xgo.dev). All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package bench implements the “gop bench” command.
+package bench
+
+import (
+	"bufio"
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"io"
+	"log"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/goplus/xgo/cmd/internal/base"
+	"github.com/goplus/xgo/cmd/internal/test"
+	"github.com/goplus/xgo/tool"
+	"github.com/goplus/xgo/x/gocmd"
+	"github.com/goplus/xgo/x/xgoprojs"
+)
+
+// gop bench
+var Cmd = &base.Command{
+	UsageLine: "gop bench [-raw] [-bench regexp] [-benchmem] [packages]",
+	Short:     "Run benchmarks of XGo packages",
+}
+
+var (
+	flag    = &Cmd.Flag
+	flagRaw = flag.Bool("raw", false, "print the output of go test as it is, e.g. for benchstat")
+)
+
+func init() {
+	Cmd.Run = runCmd
+}
+
+func runCmd(cmd *base.Command, args []string) {
+	pass := test.PassTestFlags(cmd)
+	err := flag.Parse(args)
+	if err != nil {
+		log.Fatalln("parse input arguments failed:", err)
+	}
+
+	pattern := flag.Args()
+	if len(pattern) == 0 {
+		pattern = []string{"."}
+	}
+
+	projs, err := xgoprojs.ParseAll(pattern...)
+	if err != nil {
+		log.Panicln("xgoprojs.ParseAll:", err)
+	}
+
+	conf, err := tool.NewDefaultConf(".", 0, pass.Tags())
+	if err != nil {
+		log.Panicln("tool.NewDefaultConf:", err)
+	}
+	conf.Importer.SetModFlag(pass.Mod())
+	defer conf.UpdateCache()
+
+	confCmd := conf.NewGoCmdConf()
+	confCmd.Flags = benchFlags(pass.Args)
+	if !*flagRaw && !hasFlag(pass.Args, "-json=") {
+		confCmd.Run = runBench
+	}
+	for _, proj := range projs {
+		bench(proj, conf, confCmd)
+	}
+}
+
+// benchFlags runs all benchmarks by default.
+func benchFlags(args []string) []string {
+	if hasFlag(args, "-bench=") {
+		return args
+	}
+	return append([]string{"-bench=."}, args...)
+}
+
+func hasFlag(args []string, prefix string) bool {
+	for _, arg := range args {
+		if strings.HasPrefix(arg, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+func bench(proj xgoprojs.Proj, conf *tool.Config, testConf *gocmd.TestConfig) {
+	const flags = tool.GenFlagPrompt
+	var obj string
+	var err error
+	switch v := proj.(type) {
+	case *xgoprojs.DirProj:
+		obj = v.Dir
+		err = tool.TestDir(obj, conf, testConf, flags)
+	case *xgoprojs.PkgPathProj:
+		obj = v.Path
+		err = tool.TestPkgPath("", v.Path, conf, testConf, flags)
+	case *xgoprojs.FilesProj:
+		err = tool.TestFiles(v.Files, conf, testConf)
+	case *xgoprojs.URLProj:
+		err = fmt.Errorf("%s: %w", v.URL, xgoprojs.ErrURLProj)
+	default:
+		log.Panicln("`gop bench` doesn't support", reflect.TypeOf(v))
+	}
+	if tool.NotFound(err) {
+		fmt.Fprintf(os.Stderr, "gop bench %v: not found\n", obj)
+	} else if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+	} else {
+		return
+	}
+	os.Exit(1)
+}
+
+// -----------------------------------------------------------------------------
+
+// runBench runs go test and prefixes each benchmark result with the position of
+// the benchmark in XGo sources.
+func runBench(cmd *exec.Cmd) error {
+	r, w := io.Pipe()
+	cmd.Stdin = os.Stdin
+	cmd.Stderr = os.Stderr
+	cmd.Stdout = w
+	done := make(chan struct{})
+	go func() {
+		render(os.Stdout, r, cmd.Dir)
+		close(done)
+	}()
+	err := cmd.Run()
+	w.Close()
+	<-done
+	return err
+}
+
+func render(out io.Writer, in io.Reader, dir string) {
+	var pos map[string]token.Position
+	var pending []string // lines waiting for the package to be known
+	pkgs := make(map[string]map[string]token.Position)
+	setPkg := func(pkgPath string) {
+		var ok bool
+		if pos, ok = pkgs[pkgPath]; !ok {
+			pos = benchPositions(pkgDir(dir, pkgPath))
+			pkgs[pkgPath] = pos
+		}
+		for _, line := range pending {
+			renderLine(out, pos, line)
+		}
+		pending = pending[:0]
+	}
+	s := bufio.NewScanner(in)
+	for s.Scan() {
+		line := s.Text()
+		if pkgPath, ok := strings.CutPrefix(line, "pkg: "); ok {
+			setPkg(pkgPath)
+		} else if pkgPath, ok := resultPkg(line); ok { // end of a package
+			if pos == nil {
+				setPkg(pkgPath)
+			}
+			pos = nil
+		} else if pos == nil && (len(pending) > 0 || strings.HasPrefix(line, "Benchmark")) {
+			pending = append(pending, line)
+			continue
+		}
+		renderLine(out, pos, line)
+	}
+	for _, line := range pending {
+		fmt.Fprintln(out, line)
+	}
+	io.Copy(io.Discard, in)
+}
+
+func renderLine(out io.Writer, pos map[string]token.Position, line string) {
+	if strings.HasPrefix(line, "Benchmark") {
+		if p, ok := lookup(pos, line); ok {
+			fmt.Fprintf(out, "%v: %s\n", p, line)
+			return
+		}
+	}
+	fmt.Fprintln(out, line)
+}
+
+// resultPkg parses the package of a result line like "ok  \tpkg\t0.01s".
+func resultPkg(line string) (string, bool) {
+	for _, prefix := range []string{"ok  \t", "FAIL\t"} {
+		if rest, ok := strings.CutPrefix(line, prefix); ok {
+			pkgPath, _, _ := strings.Cut(rest, "\t")
+			return pkgPath, true
+		}
+	}
+	return "", false
+}
+
+// lookup finds the position of a benchmark result line like
+// "BenchmarkFoo/sub-8  1000  12 ns/op".
+func lookup(pos map[string]token.Position, line string) (token.Position, bool) {
+	name, _, _ := strings.Cut(line, "\t")
+	name = strings.TrimSpace(name)
+	if i := strings.LastIndexByte(name, '-'); i > 0 {
+		if _, err := strconv.Atoi(name[i+1:]); err == nil {
+			name = name[:i]
+		}
+	}
+	parts := strings.Split(name, "/")
+	if p, ok := pos[parts[0]]; ok {
+		return p, true
+	}
+	if len(parts) > 1 { // a bench block of a test classfile
+		p, ok := pos[parts[0]+"/"+parts[1]]
+		return p, ok
+	}
+	return token.Position{}, false
+}
+
+// benchPositions collects positions of benchmarks in generated test files. The
+// positions are mapped to XGo sources by //line directives. Benchmark functions
+// are keyed by their names, and bench blocks of test classfiles are keyed by
+// "Benchmark<TestName>/<name>".
+func benchPositions(dir string) map[string]token.Position {
+	ret := make(map[string]token.Position)
+	if dir == "" {
+		return ret
+	}
+	fset := token.NewFileSet()
+	for _, fname := range []string{"xgo_autogen_test.go", "xgo_autogen2_test.go"} {
+		f, err := parser.ParseFile(fset, filepath.Join(dir, fname), nil, 0)
+		if err != nil {
+			continue
+		}
+		cases := make(map[string]string) // case class => test name
+		for _, decl := range f.Decls {
+			fn, ok := decl.(*ast.FuncDecl)
+			if !ok {
+				continue
+			}
+			name := fn.Name.Name
+			switch {
+			case fn.Recv == nil && strings.HasPrefix(name, "Benchmark"):
+				ret[name] = srcPos(fset, dir, fn.Name.Pos())
+			case fn.Recv == nil && strings.HasPrefix(name, "Test"):
+				if cls := caseClass(fn); cls != "" {
+					cases[cls] = name
+				}
+			}
+		}
+		for _, decl := range f.Decls {
+			fn, ok := decl.(*ast.FuncDecl)
+			if !ok || fn.Recv == nil || fn.Body == nil {
+				continue
+			}
+			testName, ok := cases[recvName(fn)]
+			if !ok {
+				continue
+			}
+			ast.Inspect(fn.Body, func(n ast.Node) bool {
+				if call, ok := n.(*ast.CallExpr); ok && len(call.Args) == 2 {
+					if sel, ok := call.Fun.(*ast.SelectorExpr); ok && sel.Sel.Name == "Bench" {
+						if lit, ok := call.Args[0].(*ast.BasicLit); ok && lit.Kind == token.STRING {
+							if v, err := strconv.Unquote(lit.Value); err == nil {
+								key := "Benchmark" + testName + "/" + strings.ReplaceAll(v, " ", "_")
+								ret[key] = srcPos(fset, dir, call.Pos())
+							}
+						}
+					}
+				}
+				return true
+			})
+		}
+	}
+	return ret
+}
+
+// caseClass returns the case class of a test function generated for a test
+// classfile, i.e. `func Test_foo(t *testing.T) { test.Gopt_Case_TestMain(new(case_foo), t) }`.
+func caseClass(fn *ast.FuncDecl) string {
+	if fn.Body == nil || len(fn.Body.List) != 1 {
+		return ""
+	}
+	stmt, ok := fn.Body.List[0].(*ast.ExprStmt)
+	if !ok {
+		return ""
+	}
+	call, ok := stmt.X.(*ast.CallExpr)
+	if !ok || len(call.Args) != 2 {
+		return ""
+	}
+	if sel, ok := call.Fun.(*ast.SelectorExpr); !ok || sel.Sel.Name != "Gopt_Case_TestMain" {
+		return ""
+	}
+	if newCall, ok := call.Args[0].(*ast.CallExpr); ok && len(newCall.Args) == 1 {
+		if id, ok := newCall.Args[0].(*ast.Ident); ok {
+			return id.Name
+		}
+	}
+	return ""
+}
+
+// srcPos returns the position of pos in XGo sources. Filenames of //line
+// directives may be relative to the module root, so they are resolved by the
+// package directory and reported relative to the working directory.
+func srcPos(fset *token.FileSet, dir string, pos token.Pos) token.Position {
+	ret := fset.Position(pos)
+	ret.Column = 0 // columns of generated code don't match XGo sources
+	ret.Filename = filepath.Join(dir, filepath.Base(ret.Filename))
+	if wd, err := os.Getwd(); err == nil {
+		if rel, err := filepath.Rel(wd, ret.Filename); err == nil && !strings.HasPrefix(rel, "..") {
+			ret.Filename = rel
+		}
+	}
+	return ret
+}
+
+func recvName(fn *ast.FuncDecl) string {
+	typ := fn.Recv.List[0].Type
+	if star, ok := typ.(*ast.StarExpr); ok {
+		typ = star.X
+	}
+	if id, ok := typ.(*ast.Ident); ok {
+		return id.Name
+	}
+	return ""
+}
+
+func pkgDir(dir, pkgPath string) string {
+	cmd := exec.Command(gocmd.Name(), "list", "-e", "-f", "{{.Dir}}", pkgPath)
+	cmd.Dir = dir
+	out, err := cmd.Output()
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(string(out))
+}
+
+// -----------------------------------------------------------------------------
@@ -34,7 +34,7 @@ import (
 
 // gop build
 var Cmd = &base.Command{
-	UsageLine: "gop build [-debug -o output] [packages]",
+	UsageLine: "gop build [-debug -o output -targets list -wasm -wasip1] [packages]",
 	Short:     "Build XGo files",
 }
 
@@ -42,6 +42,10 @@ var (
 	flag       = &Cmd.Flag
 	flagDebug  = flag.Bool("debug", false, "print debug information")
 	flagOutput = flag.String("o", "", "gop build output file")
+
+	flagTargets = flag.String("targets", "", "a comma-separated list of GOOS/GOARCH targets to build for, e.g. linux/amd64,darwin/arm64")
+	flagWasm    = flag.Bool("wasm", false, "build for browsers (GOOS=js GOARCH=wasm) and export wasm_exec.js")
+	flagWasip1  = flag.Bool("wasip1", false, "build for WASI runtimes (GOOS=wasip1 GOARCH=wasm)")
 )
 
 func init() {
@@ -78,18 +82,61 @@ func runCmd(cmd *base.Command, args []string) {
 	if err != nil {
 		log.Panicln("tool.NewDefaultConf:", err)
 	}
+	conf.Importer.SetModFlag(pass.Mod())
 	defer conf.UpdateCache()
 
+	var wasm *wasmPreset
+	switch {
+	case *flagWasm && *flagWasip1:
+		log.Panicln("-wasm and -wasip1 are mutually exclusive")
+	case *flagWasm:
+		wasm = presetWasm
+	case *flagWasip1:
+		wasm = presetWasip1
+	}
+	if wasm != nil && *flagTargets != "" {
+		log.Panicln("-targets can't be used with -wasm or -wasip1")
+	}
+
 	confCmd := conf.NewGoCmdConf()
+	if wasm != nil {
+		if err = wasm.check(proj, conf); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+		if *flagOutput == "" {
+			output, err := wasm.output(proj)
+			if err != nil {
+				log.Panicln(err)
+			}
+			*flagOutput = output
+		}
+		confCmd.Env = wasm.env()
+	}
+	if *flagTargets != "" {
+		if confCmd.Targets, err = gocmd.ParseTargets(*flagTargets); err != nil {
+			log.Panicln(err)
+		}
+	}
 	if *flagOutput != "" {
 		output, err := filepath.Abs(*flagOutput)
 		if err != nil {
 			log.Panicln(err)
 		}
-		confCmd.Flags = []string{"-o", output}
+		if confCmd.Targets != nil {
+			confCmd.Output = output
+		} else {
+			confCmd.Flags = []string{"-o", output}
+		}
 	}
 	confCmd.Flags = append(confCmd.Flags, pass.Args...)
 	build(proj, conf, confCmd)
+	if wasm != nil {
+		if err = wasm.exportShim(*flagOutput); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+	}
 }
 
 func build(proj xgoprojs.Proj, conf *tool.Config, build *gocmd.BuildConfig) {
@@ -105,6 +152,8 @@ func build(proj xgoprojs.Proj, conf *tool.Config, build *gocmd.BuildConfig) {
 		err = tool.BuildPkgPath("", v.Path, conf, build, flags)
 	case *xgoprojs.FilesProj:
 		err = tool.BuildFiles(v.Files, conf, build)
+	case *xgoprojs.URLProj:
+		err = fmt.Errorf("%s: %w", v.URL, xgoprojs.ErrURLProj)
 	default:
 		log.Panicln("`gop build` doesn't support", reflect.TypeOf(v))
 	}
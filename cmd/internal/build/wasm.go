@@ -0,0 +1,136 @@
+/*
+ * Copyright (c) 2026 The XGo Authors (xgo.dev). All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package build
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"os/exec"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/goplus/xgo/tool"
+	"github.com/goplus/xgo/x/gocmd"
+	"github.com/goplus/xgo/x/xgoprojs"
+)
+
+// wasmPreset represents a WebAssembly build preset: `-wasm` for browsers
+// (GOOS=js) and `-wasip1` for WASI runtimes like wasmtime or edge platforms.
+type wasmPreset struct {
+	target gocmd.Target
+	shim   string // runtime shim to export from $GOROOT/lib/wasm
+}
+
+var (
+	presetWasm   = &wasmPreset{target: gocmd.Target{GOOS: "js", GOARCH: "wasm"}, shim: "wasm_exec.js"}
+	presetWasip1 = &wasmPreset{target: gocmd.Target{GOOS: "wasip1", GOARCH: "wasm"}}
+)
+
+func (p *wasmPreset) env() []string {
+	return []string{"GOOS=" + p.target.GOOS, "GOARCH=" + p.target.GOARCH}
+}
+
+// output returns the output file of proj: `<name>.wasm` in the current
+// directory.
+func (p *wasmPreset) output(proj xgoprojs.Proj) (string, error) {
+	var name string
+	switch v := proj.(type) {
+	case *xgoprojs.DirProj:
+		dir, err := filepath.Abs(v.Dir)
+		if err != nil {
+			return "", err
+		}
+		name = filepath.Base(dir)
+	case *xgoprojs.PkgPathProj:
+		name = path.Base(strings.TrimSuffix(v.Path, "/..."))
+	case *xgoprojs.FilesProj:
+		name = strings.TrimSuffix(filepath.Base(v.Files[0]), filepath.Ext(v.Files[0]))
+	default:
+		name = "main"
+	}
+	return filepath.Abs(name + ".wasm")
+}
+
+// check verifies that the runtimes of classfiles used by proj can be built
+// for the target, to report incompatible classfiles before building.
+func (p *wasmPreset) check(proj xgoprojs.Proj, conf *tool.Config) error {
+	v, ok := proj.(*xgoprojs.DirProj)
+	if !ok {
+		return nil
+	}
+	pkgs, err := tool.List(conf.Mod, v.Dir)
+	if err != nil {
+		return err
+	}
+	used := make(map[string]bool)
+	for _, pkg := range pkgs {
+		for _, c := range pkg.ClassFiles {
+			for _, pkgPath := range c.PkgPaths {
+				used[pkgPath] = true
+			}
+		}
+	}
+	if len(used) == 0 {
+		return nil
+	}
+	pkgPaths := make([]string, 0, len(used))
+	for pkgPath := range used {
+		pkgPaths = append(pkgPaths, pkgPath)
+	}
+	sort.Strings(pkgPaths)
+
+	args := append([]string{
+		"list", "-e", "-deps", "-f", "{{with .Error}}{{$.ImportPath}}: {{.Err}}{{end}}",
+	}, pkgPaths...)
+	cmd := exec.Command(gocmd.Name(), args...)
+	cmd.Dir = v.Dir
+	cmd.Env = append(os.Environ(), p.env()...)
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	out, err := cmd.Output()
+	if err != nil {
+		return fmt.Errorf("go list %v: %v\n%s", pkgPaths, err, stderr.Bytes())
+	}
+	if msg := strings.TrimSpace(string(out)); msg != "" {
+		return fmt.Errorf("classfile runtimes %v are not compatible with %v:\n%s", pkgPaths, p.target, msg)
+	}
+	return nil
+}
+
+// exportShim copies the runtime shim of the preset next to output.
+func (p *wasmPreset) exportShim(output string) error {
+	if p.shim == "" {
+		return nil
+	}
+	goroot, err := exec.Command(gocmd.Name(), "env", "GOROOT").Output()
+	if err != nil {
+		return fmt.Errorf("go env GOROOT: %v", err)
+	}
+	root := strings.TrimSpace(string(goroot))
+	src := filepath.Join(root, "lib", "wasm", p.shim)
+	if _, err = os.Stat(src); os.IsNotExist(err) { // before Go 1.24
+		src = filepath.Join(root, "misc", "wasm", p.shim)
+	}
+	data, err := os.ReadFile(src)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(filepath.Join(filepath.Dir(output), p.shim), data, 0644)
+}
@@ -0,0 +1,151 @@
+/*
+ * Copyright (c) 2026 The XGo Authors (xgo.dev). All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package clean
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/goplus/xgo/tool"
+)
+
+// -----------------------------------------------------------------------------
+
+type stat struct {
+	count  int
+	size   int64
+	oldest time.Time
+}
+
+func (p *stat) add(size int64, modTime time.Time) {
+	p.count++
+	p.size += size
+	if p.oldest.IsZero() || modTime.Before(p.oldest) {
+		p.oldest = modTime
+	}
+}
+
+func (p *stat) String() string {
+	if p.count == 0 {
+		return "empty"
+	}
+	return fmt.Sprintf("%d entries, %s, oldest %s ago", p.count, formatSize(p.size), formatAge(time.Since(p.oldest)))
+}
+
+// reportStat reports size and age of the caches of xgo and the auto generated
+// files in dir.
+func reportStat(dir string) {
+	for _, c := range tool.Caches() {
+		var st stat
+		entries, err := c.Entries()
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+		}
+		for _, e := range entries {
+			st.add(e.Size, e.ModTime)
+		}
+		fmt.Printf("%-8s %s: %v\n", c.Name, c.Dir, &st)
+	}
+	var st stat
+	walkAGFiles(dir, func(path string, isDir bool) {
+		if fi, err := os.Stat(path); err == nil && !isDir {
+			st.add(fi.Size(), fi.ModTime())
+		}
+	})
+	fmt.Printf("%-8s %s: %v\n", "autogen", dir, &st)
+}
+
+// pruneCaches removes entries of the caches of xgo not modified for age (all
+// entries if age is 0).
+func pruneCaches(age time.Duration, execAct bool) {
+	now := time.Now()
+	for _, c := range tool.Caches() {
+		entries, err := c.Entries()
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			continue
+		}
+		var st stat
+		for _, e := range entries {
+			if age > 0 && now.Sub(e.ModTime) < age {
+				continue
+			}
+			fmt.Printf("Cleaning %s ...\n", e.Path)
+			if execAct {
+				if err = os.RemoveAll(e.Path); err != nil {
+					fmt.Fprintln(os.Stderr, err)
+					continue
+				}
+			}
+			st.add(e.Size, e.ModTime)
+		}
+		if st.count > 0 {
+			verb := "freed"
+			if !execAct {
+				verb = "to free"
+			}
+			fmt.Printf("%s: %d entries, %s %s\n", c.Name, st.count, formatSize(st.size), verb)
+		}
+	}
+}
+
+// parseAge parses an age like time.ParseDuration, and also supports days,
+// e.g. 30d.
+func parseAge(s string) (time.Duration, error) {
+	if days, ok := strings.CutSuffix(s, "d"); ok {
+		n, err := strconv.ParseFloat(days, 64)
+		if err != nil || n < 0 {
+			return 0, fmt.Errorf("invalid age %q", s)
+		}
+		return time.Duration(n * float64(24*time.Hour)), nil
+	}
+	d, err := time.ParseDuration(s)
+	if err == nil && d < 0 {
+		err = fmt.Errorf("invalid age %q", s)
+	}
+	return d, err
+}
+
+func formatAge(d time.Duration) string {
+	switch {
+	case d >= 24*time.Hour:
+		return strconv.Itoa(int(d/(24*time.Hour))) + "d"
+	case d >= time.Hour:
+		return strconv.Itoa(int(d/time.Hour)) + "h"
+	case d >= time.Minute:
+		return strconv.Itoa(int(d/time.Minute)) + "m"
+	}
+	return strconv.Itoa(int(d/time.Second)) + "s"
+}
+
+func formatSize(n int64) string {
+	const unit = 1024
+	if n < unit {
+		return strconv.FormatInt(n, 10) + " B"
+	}
+	div, exp := int64(unit), 0
+	for v := n / unit; v >= unit; v /= unit {
+		div *= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f %cB", float64(n)/float64(div), "KMGTPE"[exp])
+}
+
+// -----------------------------------------------------------------------------
@@ -22,6 +22,7 @@ import (
 	"os"
 	"path/filepath"
 	"strings"
+	"time"
 
 	"github.com/goplus/xgo/cmd/internal/base"
 )
@@ -37,6 +38,19 @@ const (
 // -----------------------------------------------------------------------------
 
 func cleanAGFiles(dir string, execAct bool) {
+	walkAGFiles(dir, func(path string, isDir bool) {
+		if !isDir {
+			fmt.Printf("Cleaning %s ...\n", path)
+		}
+		if execAct {
+			os.Remove(path)
+		}
+	})
+}
+
+// walkAGFiles calls fn for each XGo auto generated file in dir, and for each
+// .xgo (or .gop) directory after the generated files in it.
+func walkAGFiles(dir string, fn func(path string, isDir bool)) {
 	fis, err := os.ReadDir(dir)
 	if err != nil {
 		return
@@ -49,18 +63,14 @@ func cleanAGFiles(dir string, execAct bool) {
 		if fi.IsDir() {
 			pkgDir := filepath.Join(dir, fname)
 			if fname == ".xgo" || fname == ".gop" {
-				removeGopDir(pkgDir, execAct)
+				walkGopDir(pkgDir, fn)
 			} else {
-				cleanAGFiles(pkgDir, execAct)
+				walkAGFiles(pkgDir, fn)
 			}
 			continue
 		}
 		if strings.HasSuffix(fname, autoGenFileSuffix) {
-			file := filepath.Join(dir, fname)
-			fmt.Printf("Cleaning %s ...\n", file)
-			if execAct {
-				os.Remove(file)
-			}
+			fn(filepath.Join(dir, fname), false)
 		}
 	}
 	autogens := []string{
@@ -70,15 +80,12 @@ func cleanAGFiles(dir string, execAct bool) {
 	for _, autogen := range autogens {
 		file := filepath.Join(dir, autogen)
 		if _, err = os.Stat(file); err == nil {
-			fmt.Printf("Cleaning %s ...\n", file)
-			if execAct {
-				os.Remove(file)
-			}
+			fn(file, false)
 		}
 	}
 }
 
-func removeGopDir(dir string, execAct bool) {
+func walkGopDir(dir string, fn func(path string, isDir bool)) {
 	fis, err := os.ReadDir(dir)
 	if err != nil {
 		return
@@ -86,23 +93,17 @@ func removeGopDir(dir string, execAct bool) {
 	for _, fi := range fis {
 		fname := fi.Name()
 		if strings.HasSuffix(fname, ".xgo.go") || strings.HasSuffix(fname, ".gop.go") {
-			genfile := filepath.Join(dir, fname)
-			fmt.Printf("Cleaning %s ...\n", genfile)
-			if execAct {
-				os.Remove(genfile)
-			}
+			fn(filepath.Join(dir, fname), false)
 		}
 	}
-	if execAct {
-		os.Remove(dir)
-	}
+	fn(dir, true)
 }
 
 // -----------------------------------------------------------------------------
 
 // Cmd - gop clean
 var Cmd = &base.Command{
-	UsageLine: "gop clean [flags] <gopSrcDir>",
+	UsageLine: "gop clean [-t -stat] [-cache [-older-than age]] [gopSrcDir]",
 	Short:     "Clean all XGo auto generated files",
 }
 
@@ -111,6 +112,10 @@ var (
 
 	_        = flag.Bool("v", false, "print verbose information.")
 	testMode = flag.Bool("t", false, "test mode: display files to clean but don't clean them.")
+
+	flagStat      = flag.Bool("stat", false, "report size and age of the caches and auto generated files instead of cleaning.")
+	flagCache     = flag.Bool("cache", false, "prune the caches of xgo instead of auto generated files.")
+	flagOlderThan = flag.String("older-than", "", "with -cache, prune only cache entries not modified for `age`, e.g. 30d or 12h.")
 )
 
 func init() {
@@ -128,7 +133,20 @@ func runCmd(_ *base.Command, args []string) {
 	} else {
 		dir = flag.Arg(0)
 	}
-	cleanAGFiles(dir, !*testMode)
+	switch {
+	case *flagStat:
+		reportStat(dir)
+	case *flagCache:
+		var age time.Duration
+		if *flagOlderThan != "" {
+			if age, err = parseAge(*flagOlderThan); err != nil {
+				log.Fatalln("invalid -older-than:", err)
+			}
+		}
+		pruneCaches(age, !*testMode)
+	default:
+		cleanAGFiles(dir, !*testMode)
+	}
 }
 
 // -----------------------------------------------------------------------------
@@ -17,6 +17,7 @@
 package doc
 
 import (
+	"encoding/json"
 	"fmt"
 	"go/types"
 	"log"
@@ -38,13 +39,14 @@ import (
 
 // gop doc
 var Cmd = &base.Command{
-	UsageLine: "gop doc [-u -all -debug] [pkgPath]",
+	UsageLine: "gop doc [-u -all -json -debug] [pkgPath]",
 	Short:     "Show documentation for package or symbol",
 }
 
 var (
 	flag    = &Cmd.Flag
 	withDoc = flag.Bool("all", false, "Show all the documentation for the package.")
+	asJSON  = flag.Bool("json", false, "Print the documentation for the package in JSON.")
 	debug   = flag.Bool("debug", false, "Print debug information.")
 	unexp   = flag.Bool("u", false, "Show documentation for unexported as well as exported symbols, methods, and fields.")
 )
@@ -99,7 +101,14 @@ func outlinePkg(proj xgoprojs.Proj, conf *tool.Config) {
 	} else if err != nil {
 		fmt.Fprintln(os.Stderr, err)
 	} else {
-		outlineDoc(out.Outline(*unexp), *unexp, *withDoc)
+		pkg := newPackage(out.Outline(*unexp), *unexp)
+		if *asJSON {
+			enc := json.NewEncoder(os.Stdout)
+			enc.SetIndent("", "  ")
+			enc.Encode(pkg)
+		} else {
+			printDoc(pkg, *withDoc)
+		}
 	}
 }
 
@@ -108,93 +117,97 @@ const (
 	ln     = "\n"
 )
 
-func outlineDoc(out *outline.All, all, withDoc bool) {
-	pkg := out.Pkg()
-	fmt.Printf("package %s // import %s\n\n", pkg.Name(), strconv.Quote(pkg.Path()))
-	if withDoc && len(out.Consts) > 0 {
+func printDoc(pkg *Package, withDoc bool) {
+	fmt.Printf("package %s // import %s\n\n", pkg.Name, strconv.Quote(pkg.ImportPath))
+	if withDoc && pkg.Doc != "" {
+		fmt.Print(pkg.Doc, ln)
+	}
+	if withDoc && len(pkg.Consts) > 0 {
 		fmt.Print("CONSTANTS\n\n")
 	}
-	for _, o := range out.Consts {
-		printObject(pkg, o, withDoc)
+	for _, o := range pkg.Consts {
+		printValue(o, withDoc)
 	}
-	if withDoc && len(out.Vars) > 0 {
+	if withDoc && len(pkg.Vars) > 0 {
 		fmt.Print("VARIABLES\n\n")
 	}
-	for _, o := range out.Vars {
-		printObject(pkg, o, withDoc)
+	for _, o := range pkg.Vars {
+		printValue(o, withDoc)
 	}
-	if withDoc && len(out.Funcs) > 0 {
+	if withDoc && len(pkg.Funcs) > 0 {
 		fmt.Print("FUNCTIONS\n\n")
 	}
-	for _, fn := range out.Funcs {
-		printObject(pkg, fn, withDoc)
-	}
-	if withDoc && len(out.Types) > 0 {
+	printFuncs(pkg.Funcs, "", withDoc)
+	if withDoc && len(pkg.Types) > 0 {
 		fmt.Print("TYPES\n\n")
 	}
-	for _, t := range out.Types {
-		if !(all || t.IsUsed()) {
-			continue
+	for _, t := range pkg.Types {
+		if withDoc && len(t.Fields) > 0 {
+			fmt.Print(structDecl(t), ln)
+		} else {
+			fmt.Print(t.Decl, ln)
 		}
-		typName := t.ObjWith(all)
-		fmt.Print(objectString(pkg, typName), ln)
 		for _, o := range t.Consts {
-			fmt.Print(indent, constShortString(o.Const), ln)
+			fmt.Print(indent, "const ", o.Name, ln)
 		}
 		if withDoc {
-			printDoc(t)
-		}
-		printFuncsForType(pkg, t.Creators, withDoc)
-		printFuncsForType(pkg, t.GoptFuncs, withDoc)
-		printFuncsForType(pkg, t.Helpers, withDoc)
-		if !typName.IsAlias() {
-			typ := t.Type()
-			if named, ok := typ.CheckNamed(out.Package); ok {
-				for _, fn := range named.Methods() {
-					if o := fn.Obj(); all || o.Exported() {
-						if withDoc {
-							fmt.Print(objectString(pkg, o), ln)
-							printDoc(fn)
-						} else {
-							fmt.Print(indent, objectString(pkg, o), ln)
-						}
-					}
-				}
-			}
+			printText(t.Doc)
 		}
+		printFuncs(t.Funcs, indent, withDoc)
+		printFuncs(t.Methods, indent, withDoc)
 	}
 }
 
-type object interface {
-	Obj() types.Object
-	Doc() string
-}
-
-func printObject(pkg *types.Package, o object, withDoc bool) {
-	fmt.Print(objectString(pkg, o.Obj()), ln)
-	if withDoc {
-		printDoc(o)
+// structDecl returns the declaration of struct type t with docs of fields.
+func structDecl(t *Type) string {
+	var b strings.Builder
+	b.WriteString("type " + t.Name + " struct {\n")
+	for _, fld := range t.Fields {
+		if fld.Doc != "" {
+			doc := strings.TrimSuffix(fld.Doc, "\n")
+			b.WriteString(indent + "// " + strings.ReplaceAll(doc, "\n", "\n"+indent+"// ") + "\n")
+		}
+		b.WriteString(indent + fld.Decl + "\n")
+	}
+	if t.unexported {
+		b.WriteString(indent + "// Has unexported fields.\n")
 	}
+	b.WriteString("}")
+	return b.String()
 }
 
-func printDoc(o object) {
-	if doc := o.Doc(); doc != "" {
-		fmt.Print(indent, strings.ReplaceAll(doc, "\n", "\n"+indent), ln)
-	} else {
-		fmt.Println()
+func printValue(o *Value, withDoc bool) {
+	fmt.Print(o.Decl, ln)
+	if withDoc {
+		printText(o.Doc)
 	}
 }
 
-func printFuncsForType(pkg *types.Package, fns []outline.Func, withDoc bool) {
+// printFuncs prints fns, which are indented by prefix unless withDoc is set.
+// Functions of an overload group are printed one by one.
+func printFuncs(fns []*Func, prefix string, withDoc bool) {
 	for _, fn := range fns {
+		if fn.Overloads != nil {
+			printFuncs(fn.Overloads, prefix, withDoc)
+			continue
+		}
 		if withDoc {
-			printObject(pkg, fn, true)
+			fmt.Print(fn.Decl, ln)
+			printText(fn.Doc)
 		} else {
-			fmt.Print(indent, objectString(pkg, fn.Obj()), ln)
+			fmt.Print(prefix, fn.Decl, ln)
 		}
 	}
 }
 
+func printText(doc string) {
+	if doc != "" {
+		fmt.Print(indent, strings.ReplaceAll(doc, "\n", "\n"+indent), ln)
+	} else {
+		fmt.Println()
+	}
+}
+
 func objectString(pkg *types.Package, obj types.Object) string {
 	if name, fn, ok := outline.CheckOverload(obj); ok {
 		obj = types.NewFunc(fn.Pos(), fn.Pkg(), name, fn.Type().(*types.Signature))
@@ -202,10 +215,6 @@ func objectString(pkg *types.Package, obj types.Object) string {
 	return types.ObjectString(obj, qualifier(pkg))
 }
 
-func constShortString(obj *types.Const) string {
-	return "const " + obj.Name()
-}
-
 func qualifier(pkg *types.Package) types.Qualifier {
 	return func(other *types.Package) string {
 		if pkg == other {
@@ -0,0 +1,296 @@
+/*
+ * Copyright (c) 2026 The XGo Authors (xgo.dev). All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package doc
+
+import (
+	"bytes"
+	"go/types"
+	"strings"
+
+	"github.com/goplus/gogen"
+	"github.com/goplus/xgo/cl/outline"
+)
+
+// -----------------------------------------------------------------------------
+
+// Package is the documentation of a package, which is printed as text or
+// JSON (see -json).
+type Package struct {
+	Name       string   `json:"name"`
+	ImportPath string   `json:"importPath"`
+	Doc        string   `json:"doc,omitempty"`
+	Consts     []*Value `json:"consts,omitempty"`
+	Vars       []*Value `json:"vars,omitempty"`
+	Funcs      []*Func  `json:"funcs,omitempty"`
+	Types      []*Type  `json:"types,omitempty"`
+}
+
+// Value is the documentation of a const or a var.
+type Value struct {
+	Name string `json:"name"`
+	Decl string `json:"decl"`
+	Doc  string `json:"doc,omitempty"`
+}
+
+// Func is the documentation of a function or a method. XGo_* methods of
+// operators are named as the operators, like + for XGo_Add.
+type Func struct {
+	Name      string  `json:"name"`
+	Decl      string  `json:"decl"`
+	Doc       string  `json:"doc,omitempty"`
+	Overloads []*Func `json:"overloads,omitempty"` // functions of an overload group
+}
+
+// Type is the documentation of a type, and the funcs and methods of it.
+type Type struct {
+	Name    string   `json:"name"`
+	Decl    string   `json:"decl"`
+	Doc     string   `json:"doc,omitempty"`
+	Fields  []*Value `json:"fields,omitempty"`
+	Consts  []*Value `json:"consts,omitempty"`
+	Funcs   []*Func  `json:"funcs,omitempty"` // creators, helpers and template methods
+	Methods []*Func  `json:"methods,omitempty"`
+
+	unexported bool // if there are unexported fields not shown
+}
+
+// -----------------------------------------------------------------------------
+
+type docBuilder struct {
+	pkg     *types.Package
+	all     bool
+	docs    map[types.Object]string
+	members map[types.Object]bool // functions of overload groups
+}
+
+func newPackage(out *outline.All, all bool) *Package {
+	pkg := out.Pkg()
+	p := &docBuilder{
+		pkg:     pkg,
+		all:     all,
+		docs:    make(map[types.Object]string),
+		members: make(map[types.Object]bool),
+	}
+	p.initFuncs(out)
+	ret := &Package{
+		Name:       pkg.Name(),
+		ImportPath: pkg.Path(),
+		Doc:        out.Doc(),
+	}
+	for _, o := range out.Consts {
+		ret.Consts = append(ret.Consts, p.newValue(o.Obj(), o.Doc()))
+	}
+	for _, o := range out.Vars {
+		ret.Vars = append(ret.Vars, p.newValue(o.Obj(), o.Doc()))
+	}
+	ret.Funcs = p.newFuncs(out.Funcs)
+	for _, t := range out.Types {
+		if all || t.IsUsed() {
+			ret.Types = append(ret.Types, p.newType(out, t))
+		}
+	}
+	return ret
+}
+
+// initFuncs records docs of all functions and methods, and the functions of
+// overload groups, which are documented with their groups.
+func (p *docBuilder) initFuncs(out *outline.All) {
+	add := func(fns []outline.Func) {
+		for _, fn := range fns {
+			p.docs[fn.Obj()] = fn.Doc()
+			if members, ok := overloadMembers(fn.Func); ok {
+				for _, o := range members {
+					p.members[o] = true
+				}
+			}
+		}
+	}
+	add(out.Funcs)
+	for _, t := range out.Types {
+		add(t.Creators)
+		add(t.GoptFuncs)
+		add(t.Helpers)
+		if named, ok := t.Type().CheckNamed(out.Package); ok {
+			add(named.Methods())
+		}
+	}
+}
+
+func overloadMembers(fn *types.Func) ([]types.Object, bool) {
+	sig := fn.Type().(*types.Signature)
+	if members, ok := gogen.CheckOverloadFunc(sig); ok {
+		return members, true
+	}
+	return gogen.CheckOverloadMethod(sig)
+}
+
+func (p *docBuilder) newValue(obj types.Object, doc string) *Value {
+	return &Value{Name: obj.Name(), Decl: objectString(p.pkg, obj), Doc: doc}
+}
+
+func (p *docBuilder) newFuncs(fns []outline.Func) (ret []*Func) {
+	for _, fn := range fns {
+		if o := fn.Obj(); !p.members[o] && (p.all || o.Exported()) {
+			ret = append(ret, p.newFunc(fn.Func))
+		}
+	}
+	return
+}
+
+func (p *docBuilder) newFunc(fn *types.Func) *Func {
+	if members, ok := overloadMembers(fn); ok {
+		ret := &Func{Name: fn.Name(), Doc: p.docs[fn]}
+		decl := "func " + fn.Name() + " = (\n"
+		for _, o := range members {
+			fn := o.(*types.Func)
+			ret.Overloads = append(ret.Overloads, p.newFunc(fn))
+			decl += indent + "func" + sigString(p.pkg, fn.Type().(*types.Signature)) + "\n"
+		}
+		ret.Decl = decl + ")"
+		return ret
+	}
+	name, decl := funcString(p.pkg, fn)
+	return &Func{Name: name, Decl: decl, Doc: p.docs[fn]}
+}
+
+func (p *docBuilder) newType(out *outline.All, t *outline.TypeName) *Type {
+	typName := t.ObjWith(p.all)
+	ret := &Type{
+		Name: typName.Name(),
+		Decl: objectString(p.pkg, typName),
+		Doc:  t.Doc(),
+	}
+	for _, fld := range t.Fields() {
+		if p.all || fld.Exported() {
+			ret.Fields = append(ret.Fields, &Value{
+				Name: fld.Name(),
+				Decl: fieldString(p.pkg, fld.Var),
+				Doc:  fld.Doc(),
+			})
+		} else {
+			ret.unexported = true
+		}
+	}
+	for _, o := range t.Consts {
+		ret.Consts = append(ret.Consts, p.newValue(o.Obj(), o.Doc()))
+	}
+	ret.Funcs = append(ret.Funcs, p.newFuncs(t.Creators)...)
+	ret.Funcs = append(ret.Funcs, p.newFuncs(t.GoptFuncs)...)
+	ret.Funcs = append(ret.Funcs, p.newFuncs(t.Helpers)...)
+	if !typName.IsAlias() {
+		if named, ok := t.Type().CheckNamed(out.Package); ok {
+			ret.Methods = p.newFuncs(named.Methods())
+		}
+	}
+	return ret
+}
+
+// -----------------------------------------------------------------------------
+
+// funcString returns the name of fn in XGo, and its declaration.
+func funcString(pkg *types.Package, fn *types.Func) (name, decl string) {
+	sig := fn.Type().(*types.Signature)
+	if recv := sig.Recv(); recv != nil {
+		if op, ok := binaryOps[fn.Name()]; ok {
+			return op, "func (" + varString(pkg, recv) + ") " + op + " " + sigString(pkg, sig)
+		}
+		if op, ok := unaryOps[fn.Name()]; ok && sig.Params().Len() == 0 {
+			return op, "func " + op + "(" + varString(pkg, recv) + ")" + strings.TrimPrefix(sigString(pkg, sig), "()")
+		}
+	}
+	name = fn.Name()
+	if oname, _, ok := outline.CheckOverload(fn); ok {
+		name = oname
+	}
+	return name, objectString(pkg, fn)
+}
+
+func fieldString(pkg *types.Package, fld *types.Var) string {
+	if fld.Anonymous() {
+		return types.TypeString(fld.Type(), qualifier(pkg))
+	}
+	return varString(pkg, fld)
+}
+
+func varString(pkg *types.Package, v *types.Var) string {
+	typ := types.TypeString(v.Type(), qualifier(pkg))
+	if v.Name() == "" {
+		return typ
+	}
+	return v.Name() + " " + typ
+}
+
+func sigString(pkg *types.Package, sig *types.Signature) string {
+	var b bytes.Buffer
+	types.WriteSignature(&b, sig, qualifier(pkg))
+	return b.String()
+}
+
+// binaryOps maps XGo_* methods to binary operators (see cl/compile.go).
+var binaryOps = map[string]string{
+	"XGo_Add":    "+",
+	"XGo_Sub":    "-",
+	"XGo_Mul":    "*",
+	"XGo_Quo":    "/",
+	"XGo_Rem":    "%",
+	"XGo_And":    "&",
+	"XGo_Or":     "|",
+	"XGo_Xor":    "^",
+	"XGo_Lsh":    "<<",
+	"XGo_Rsh":    ">>",
+	"XGo_AndNot": "&^",
+
+	"XGo_AddAssign":    "+=",
+	"XGo_SubAssign":    "-=",
+	"XGo_MulAssign":    "*=",
+	"XGo_QuoAssign":    "/=",
+	"XGo_RemAssign":    "%=",
+	"XGo_AndAssign":    "&=",
+	"XGo_OrAssign":     "|=",
+	"XGo_XorAssign":    "^=",
+	"XGo_LshAssign":    "<<=",
+	"XGo_RshAssign":    ">>=",
+	"XGo_AndNotAssign": "&^=",
+
+	"XGo_EQ": "==",
+	"XGo_NE": "!=",
+	"XGo_LE": "<=",
+	"XGo_LT": "<",
+	"XGo_GE": ">=",
+	"XGo_GT": ">",
+
+	"XGo_PointTo": "->",
+	"XGo_PointBi": "<>",
+
+	"XGo_LAnd": "&&",
+	"XGo_LOr":  "||",
+
+	"XGo_Send": "<-",
+}
+
+// unaryOps maps XGo_* methods to unary operators (see cl/compile.go).
+var unaryOps = map[string]string{
+	"XGo_Inc":  "++",
+	"XGo_Dec":  "--",
+	"XGo_Neg":  "-",
+	"XGo_Dup":  "+",
+	"XGo_Not":  "^",
+	"XGo_LNot": "!",
+	"XGo_Recv": "<-",
+}
+
+// -----------------------------------------------------------------------------
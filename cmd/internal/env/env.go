@@ -24,6 +24,7 @@ import (
 	"os"
 	"os/exec"
 	"sort"
+	"strings"
 
 	"github.com/goplus/mod"
 	"github.com/goplus/mod/modcache"
@@ -34,13 +35,15 @@ import (
 
 // Cmd - gop env
 var Cmd = &base.Command{
-	UsageLine: "gop env [-json] [var ...]",
+	UsageLine: "gop env [-json] [-w|-u] [var ...]",
 	Short:     "Prints XGo environment information",
 }
 
 var (
 	flag    = &Cmd.Flag
-	envJson = flag.Bool("json", false, "prints Go environment information.")
+	envJson = flag.Bool("json", false, "prints environment information in JSON format.")
+	envW    = flag.Bool("w", false, "writes NAME=VALUE settings into the config file.")
+	envU    = flag.Bool("u", false, "unsets settings in the config file.")
 )
 
 func init() {
@@ -52,6 +55,10 @@ func runCmd(_ *base.Command, args []string) {
 	if err != nil {
 		log.Fatalln("parse input arguments failed:", err)
 	}
+	if *envW || *envU {
+		writeConfig(flag.Args())
+		return
+	}
 
 	var stdout bytes.Buffer
 
@@ -76,12 +83,38 @@ func runCmd(_ *base.Command, args []string) {
 	xgoEnv["GOMODCACHE"] = modcache.GOMODCACHE
 	xgoEnv["GOXMOD"], _ = mod.GOXMOD("")
 	xgoEnv["HOME"] = env.HOME()
+	xgoEnv["XGOENV"] = env.ConfigFile()
+	xgoEnv["XGO_RUNNER"] = env.Getenv("XGO_RUNNER")
 
 	vars := flag.Args()
 
 	outputEnvVars(xgoEnv, vars, *envJson)
 }
 
+func writeConfig(args []string) {
+	if *envW && *envU {
+		log.Fatalln("gop env: -w and -u are mutually exclusive")
+	}
+	if len(args) == 0 {
+		log.Fatalln("gop env: no variables to write or unset")
+	}
+	settings := make(map[string]string, len(args))
+	for _, arg := range args {
+		if *envU {
+			settings[arg] = ""
+			continue
+		}
+		k, v, ok := strings.Cut(arg, "=")
+		if !ok || v == "" {
+			log.Fatalf("gop env -w: arguments must be NAME=VALUE: invalid argument: %s\n", arg)
+		}
+		settings[k] = v
+	}
+	if err := env.WriteConfig(settings); err != nil {
+		log.Fatalln("gop env:", err)
+	}
+}
+
 func outputEnvVars(gopEnv map[string]any, vars []string, outputJson bool) {
 	onlyValues := true
 
@@ -0,0 +1,303 @@
+/*
+ * Copyright (c) 2026 The XGo Authors (xgo.dev). All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package generate implements the “gop generate” command.
+package generate
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"log"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"runtime"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/goplus/xgo/cmd/internal/base"
+	"github.com/goplus/xgo/env"
+	"github.com/goplus/xgo/tool"
+)
+
+// gop generate
+var Cmd = &base.Command{
+	UsageLine: "gop generate [-n -v -x -run regexp -skip regexp -tags tags] [packages]",
+	Short:     "Generate files by processing XGo and Go sources",
+}
+
+var (
+	flag        = &Cmd.Flag
+	flagDryRun  = flag.Bool("n", false, "print commands that would be executed but don't run them")
+	flagVerbose = flag.Bool("v", false, "print the names of packages and files as they are processed")
+	flagPrint   = flag.Bool("x", false, "print commands as they are executed")
+	flagRun     = flag.String("run", "", "run only directives whose full original source text match `regexp`")
+	flagSkip    = flag.String("skip", "", "skip directives whose full original source text match `regexp`")
+	flagTags    = flag.String("tags", "", "a comma-separated list of additional build tags to consider satisfied")
+)
+
+func init() {
+	Cmd.Run = runCmd
+}
+
+func runCmd(cmd *base.Command, args []string) {
+	err := flag.Parse(args)
+	if err != nil {
+		log.Fatalln("parse input arguments failed:", err)
+	}
+	var runRE, skipRE *regexp.Regexp
+	if *flagRun != "" {
+		runRE = regexp.MustCompile(*flagRun)
+	}
+	if *flagSkip != "" {
+		skipRE = regexp.MustCompile(*flagSkip)
+	}
+
+	conf, err := tool.NewDefaultConf(".", 0, *flagTags)
+	if err != nil {
+		log.Fatalln("tool.NewDefaultConf:", err)
+	}
+	defer conf.UpdateCache()
+
+	pkgs, err := tool.List(conf.Mod, flag.Args()...)
+	if err != nil {
+		log.Fatalln(err)
+	}
+	failed := false
+	for _, pkg := range pkgs {
+		if *flagVerbose {
+			fmt.Fprintln(os.Stderr, pkgName(pkg))
+		}
+		g := &generator{pkg: pkg, runRE: runRE, skipRE: skipRE}
+		for _, file := range pkgFiles(pkg) {
+			if !g.processFile(file) {
+				failed = true
+				break
+			}
+		}
+		if g.ran && hasXGoFiles(pkg) {
+			// the directives may change XGo sources: regenerate xgo_autogen.go
+			// to keep it (and the build cache) up to date
+			if _, _, err = tool.GenGoEx(pkg.Dir, conf, true, tool.GenFlagPrintError); err != nil {
+				failed = true
+			}
+		}
+	}
+	if failed {
+		os.Exit(1)
+	}
+}
+
+func pkgName(pkg *tool.Package) string {
+	if pkg.ImportPath != "" {
+		return pkg.ImportPath
+	}
+	return pkg.Dir
+}
+
+func hasXGoFiles(pkg *tool.Package) bool {
+	return len(pkg.XGoFiles) > 0 || len(pkg.ClassFiles) > 0 || len(pkg.TestXGoFiles) > 0
+}
+
+// pkgFiles returns all source files of a package, in the order of their names.
+func pkgFiles(pkg *tool.Package) []string {
+	var files []string
+	files = append(files, pkg.XGoFiles...)
+	files = append(files, pkg.GoFiles...)
+	for _, c := range pkg.ClassFiles {
+		files = append(files, c.File)
+	}
+	files = append(files, pkg.TestXGoFiles...)
+	files = append(files, pkg.TestGoFiles...)
+	sort.Strings(files)
+	return files
+}
+
+// -----------------------------------------------------------------------------
+
+type generator struct {
+	pkg      *tool.Package
+	runRE    *regexp.Regexp
+	skipRE   *regexp.Regexp
+	commands map[string][]string // defined by -command
+	ran      bool                // some directive ran
+}
+
+// isDirective reports whether a line is a generate directive:
+// `//go:generate command args...` or `//xgo:generate command args...`.
+func isDirective(line string) (cmd string, ok bool) {
+	for _, prefix := range []string{"//go:generate", "//xgo:generate"} {
+		if rest, found := strings.CutPrefix(line, prefix); found && rest != "" && (rest[0] == ' ' || rest[0] == '\t') {
+			return rest, true
+		}
+	}
+	return
+}
+
+func (g *generator) processFile(fname string) bool {
+	file := filepath.Join(g.pkg.Dir, fname)
+	data, err := os.ReadFile(file)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return false
+	}
+	if !bytes.Contains(data, []byte(":generate")) {
+		return true
+	}
+	if *flagVerbose {
+		fmt.Fprintln(os.Stderr, file)
+	}
+	g.commands = nil
+	s := bufio.NewScanner(bytes.NewReader(data))
+	s.Buffer(nil, len(data)+1)
+	for lineno := 1; s.Scan(); lineno++ {
+		line := strings.TrimRight(s.Text(), "\r")
+		rest, ok := isDirective(line)
+		if !ok {
+			continue
+		}
+		if g.runRE != nil && !g.runRE.MatchString(line) || g.skipRE != nil && g.skipRE.MatchString(line) {
+			continue
+		}
+		words, err := g.split(rest, fname, lineno)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "%s:%d: %v\n", file, lineno, err)
+			return false
+		}
+		if len(words) == 0 {
+			fmt.Fprintf(os.Stderr, "%s:%d: no arguments to directive\n", file, lineno)
+			return false
+		}
+		if words[0] == "-command" {
+			if len(words) < 3 {
+				fmt.Fprintf(os.Stderr, "%s:%d: -command syntax: -command name command args...\n", file, lineno)
+				return false
+			}
+			if g.commands == nil {
+				g.commands = make(map[string][]string)
+			}
+			g.commands[words[1]] = words[2:]
+			continue
+		}
+		if alias, ok := g.commands[words[0]]; ok {
+			words = append(append([]string(nil), alias...), words[1:]...)
+		}
+		if !g.exec(words, fname, lineno) {
+			fmt.Fprintf(os.Stderr, "%s:%d: running %q failed\n", file, lineno, words[0])
+			return false
+		}
+	}
+	return true
+}
+
+// env returns the environment variables of a directive.
+func (g *generator) env(fname string, lineno int) []string {
+	return []string{
+		"GOARCH=" + runtime.GOARCH,
+		"GOOS=" + runtime.GOOS,
+		"GOFILE=" + fname,
+		"GOLINE=" + strconv.Itoa(lineno),
+		"GOPACKAGE=" + g.pkg.Name,
+		"XGOROOT=" + env.XGOROOT(),
+		"DOLLAR=$",
+	}
+}
+
+// split splits a directive into words, expanding environment variables. Like
+// go generate, a word can be a Go double-quoted string.
+func (g *generator) split(line, fname string, lineno int) ([]string, error) {
+	vars := g.env(fname, lineno)
+	expand := func(s string) string {
+		return os.Expand(s, func(name string) string {
+			for _, v := range vars {
+				if k, val, _ := strings.Cut(v, "="); k == name {
+					return val
+				}
+			}
+			return os.Getenv(name)
+		})
+	}
+	var words []string
+	line = strings.TrimSpace(line)
+	for line != "" {
+		if line[0] == '"' {
+			end := 1
+			for ; end < len(line); end++ {
+				if line[end] == '\\' {
+					end++
+				} else if line[end] == '"' {
+					break
+				}
+			}
+			if end >= len(line) {
+				return nil, fmt.Errorf("unterminated quoted string")
+			}
+			word, err := strconv.Unquote(line[:end+1])
+			if err != nil {
+				return nil, err
+			}
+			words = append(words, expand(word))
+			line = strings.TrimLeft(line[end+1:], " \t")
+			continue
+		}
+		word := line
+		if i := strings.IndexAny(line, " \t"); i >= 0 {
+			word, line = line[:i], strings.TrimLeft(line[i:], " \t")
+		} else {
+			line = ""
+		}
+		words = append(words, expand(word))
+	}
+	return words, nil
+}
+
+func (g *generator) exec(words []string, fname string, lineno int) bool {
+	if *flagDryRun || *flagPrint {
+		fmt.Fprintln(os.Stderr, strings.Join(words, " "))
+	}
+	if *flagDryRun {
+		return true
+	}
+	g.ran = true
+	name := words[0]
+	switch name {
+	case "xgo", "gop": // run the xgo command itself
+		if exe, err := os.Executable(); err == nil {
+			name = exe
+		}
+	case "go":
+		name = filepath.Join(runtime.GOROOT(), "bin", "go")
+		if _, err := os.Stat(name); err != nil {
+			name = "go"
+		}
+	}
+	cmd := exec.Command(name, words[1:]...)
+	cmd.Dir = g.pkg.Dir
+	cmd.Stdin = os.Stdin
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	cmd.Env = append(os.Environ(), g.env(fname, lineno)...)
+	if err := cmd.Run(); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return false
+	}
+	return true
+}
+
+// -----------------------------------------------------------------------------
@@ -96,6 +96,9 @@ func runCmd(cmd *base.Command, args []string) {
 			_, _, err = tool.GenGoPkgPathEx("", v.Path, conf, true, flags)
 		case *xgoprojs.FilesProj:
 			_, err = tool.GenGoFiles("", v.Files, conf)
+		case *xgoprojs.URLProj:
+			fmt.Fprintf(os.Stderr, "%s: %v\n", v.URL, xgoprojs.ErrURLProj)
+			os.Exit(1)
 		default:
 			log.Panicln("`gop go` doesn't support", reflect.TypeOf(v))
 		}
@@ -0,0 +1,335 @@
+/*
+ * Copyright (c) 2026 The XGo Authors (xgo.dev). All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package gopinit implements the “gop init” command.
+package gopinit
+
+import (
+	"bytes"
+	"embed"
+	"errors"
+	"fmt"
+	"io/fs"
+	"log"
+	"os"
+	"path"
+	"path/filepath"
+	"runtime"
+	"sort"
+	"strings"
+
+	"github.com/goplus/mod/modcache"
+	"github.com/goplus/mod/modload"
+	"github.com/goplus/xgo/cmd/internal/base"
+	"github.com/goplus/xgo/env"
+	"github.com/goplus/xgo/tool"
+	"github.com/goplus/xgo/x/modproxy"
+	"github.com/goplus/xgo/x/xgoenv"
+	"golang.org/x/mod/modfile"
+	"golang.org/x/mod/module"
+)
+
+// gop init
+var Cmd = &base.Command{
+	UsageLine: "gop init [-f -l] [template [module-path]]",
+	Short:     "Create a new XGo project from a template",
+}
+
+var (
+	flag      = &Cmd.Flag
+	flagForce = flag.Bool("f", false, "overwrite existing files")
+	flagList  = flag.Bool("l", false, "list builtin templates")
+)
+
+func init() {
+	Cmd.Run = runCmd
+}
+
+// -----------------------------------------------------------------------------
+
+//go:embed _templates
+var builtinFS embed.FS
+
+// template represents a builtin template. Its files are in _templates/<name>.
+type template struct {
+	name    string
+	short   string
+	classes []string // modules of classfiles used by the template
+}
+
+var templates = []*template{
+	{name: "app", short: "an XGo program (the default)"},
+	{name: "cli", short: "a command line tool with subcommands (cobra classfile)", classes: []string{"github.com/goplus/cobra"}},
+	{name: "spx", short: "a 2D game (spx classfile)", classes: []string{"github.com/goplus/spx/v2"}},
+	{name: "scraper", short: "a web scraper using DQL"},
+	{name: "tpl", short: "a DSL parser using TPL"},
+}
+
+func lookup(name string) *template {
+	for _, t := range templates {
+		if t.name == name {
+			return t
+		}
+	}
+	return nil
+}
+
+// isModPath reports whether a template is a module (or package) path, e.g.
+// github.com/user/repo@v1.0.0.
+func isModPath(name string) bool {
+	elem, _, _ := strings.Cut(name, "/")
+	return strings.Contains(elem, ".")
+}
+
+// -----------------------------------------------------------------------------
+
+func runCmd(cmd *base.Command, args []string) {
+	err := flag.Parse(args)
+	if err != nil {
+		log.Fatalln("parse input arguments failed:", err)
+	}
+	if *flagList {
+		for _, t := range templates {
+			fmt.Printf("%-10s %s\n", t.name, t.short)
+		}
+		return
+	}
+	name, modPath := "app", ""
+	switch flag.NArg() {
+	case 2:
+		modPath = flag.Arg(1)
+		fallthrough
+	case 1:
+		name = flag.Arg(0)
+	case 0:
+	default:
+		cmd.Usage(os.Stderr)
+	}
+	if modPath == "" {
+		dir, err := os.Getwd()
+		check(err)
+		modPath = filepath.Base(dir)
+	}
+
+	var files []string
+	if isModPath(name) {
+		files = initFromModule(name, modPath)
+	} else if t := lookup(name); t != nil {
+		files = initBuiltin(t, modPath)
+	} else {
+		fatal(fmt.Sprintf("gop init: unknown template %q, see 'gop init -l' for builtin templates", name))
+	}
+	for _, file := range files {
+		fmt.Fprintln(os.Stderr, "gop init: created", file)
+	}
+}
+
+func initBuiltin(t *template, modPath string) []string {
+	root := path.Join("_templates", t.name)
+	srcs := make(map[string][]byte)
+	err := fs.WalkDir(builtinFS, root, func(name string, d fs.DirEntry, err error) error {
+		if err == nil && !d.IsDir() {
+			srcs[filepath.FromSlash(name[len(root)+1:])], err = builtinFS.ReadFile(name)
+		}
+		return err
+	})
+	check(err)
+	checkExists(srcs)
+
+	mod, err := modload.Create(".", modPath, goMainVer(), env.MainVersion())
+	check(err)
+	var vers []module.Version
+	for _, class := range t.classes {
+		ver, err := classModVer(class)
+		if err != nil {
+			fatal(fmt.Sprintf("gop init: can't get %s: %v", class, err))
+		}
+		check(mod.AddRequire(ver.Path, ver.Version, true))
+		vers = append(vers, ver)
+	}
+	check(mod.Save())
+	files := writeFiles(srcs)
+	if vers != nil {
+		tidy(vers...)
+	}
+	return append([]string{"go.mod"}, files...)
+}
+
+// classModVer returns the version of a classfile module. It prefers the one
+// XGo depends on, so that no download is needed.
+func classModVer(modPath string) (ver module.Version, err error) {
+	if mod, e := modload.LoadFrom(filepath.Join(env.XGOROOT(), "go.mod"), ""); e == nil {
+		for _, r := range mod.File.Require {
+			if r.Mod.Path == modPath {
+				return r.Mod, nil
+			}
+		}
+	}
+	return tool.GetMod(modPath)
+}
+
+// initFromModule creates a project from a template module, like gonew: files
+// of the template are copied, and its module path is replaced by modPath.
+func initFromModule(tmpl, modPath string) []string {
+	modVer, relPath, err := tool.GetPkg(tmpl)
+	if err != nil {
+		fatal(fmt.Sprintf("gop init: can't get template %s: %v", tmpl, err))
+	}
+	modDir, err := modcache.Path(modVer)
+	check(err)
+	dir := filepath.Join(modDir, relPath)
+
+	oldPath := path.Join(modVer.Path, filepath.ToSlash(relPath))
+	srcs := make(map[string][]byte)
+	err = filepath.WalkDir(dir, func(file string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if name := d.Name(); file != dir && (strings.HasPrefix(name, ".") || strings.HasPrefix(name, "_")) {
+			if d.IsDir() {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if d.IsDir() || strings.HasPrefix(d.Name(), "xgo_autogen") {
+			return nil
+		}
+		rel, _ := filepath.Rel(dir, file)
+		data, err := os.ReadFile(file)
+		if err != nil {
+			return err
+		}
+		switch filepath.Ext(rel) {
+		case ".mod":
+			if rel == "go.mod" {
+				data, err = fixGoMod(file, data, modPath)
+			}
+		case ".sum":
+		default:
+			data = replaceImports(data, oldPath, modPath)
+		}
+		srcs[rel] = data
+		return err
+	})
+	check(err)
+	checkExists(srcs)
+	if _, ok := srcs["go.mod"]; ok {
+		return writeFiles(srcs)
+	}
+
+	// the template is a subdirectory of a module: if the module is a classfile
+	// module, the new project depends on it.
+	mod, err := modload.Create(".", modPath, goMainVer(), env.MainVersion())
+	check(err)
+	tmplMod, err := modload.Load(modDir)
+	hasProj := err == nil && tmplMod.HasProject()
+	if hasProj {
+		check(mod.AddRequire(modVer.Path, modVer.Version, true))
+	}
+	check(mod.Save())
+	files := writeFiles(srcs)
+	if hasProj {
+		tidy(modVer)
+	}
+	return append([]string{"go.mod"}, files...)
+}
+
+// tidy adds hashes of required modules to go.sum. If the module proxies can't
+// serve them, `gop mod tidy` is done instead.
+func tidy(mods ...module.Version) {
+	err := tool.AddSums(".", mods...)
+	if errors.Is(err, modproxy.ErrNoProxy) {
+		err = tool.Tidy(".", xgoenv.Get())
+	}
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		fmt.Fprintln(os.Stderr, "gop init: run 'gop mod tidy' to complete go.sum")
+	}
+}
+
+// fixGoMod replaces the module path of a go.mod file.
+func fixGoMod(file string, data []byte, modPath string) ([]byte, error) {
+	f, err := modfile.Parse(file, data, nil)
+	if err != nil {
+		return nil, err
+	}
+	if err = f.AddModuleStmt(modPath); err != nil {
+		return nil, err
+	}
+	return modfile.Format(f.Syntax), nil
+}
+
+// replaceImports replaces import paths of the template module in a source file.
+func replaceImports(data []byte, oldPath, modPath string) []byte {
+	data = bytes.ReplaceAll(data, []byte(`"`+oldPath+`"`), []byte(`"`+modPath+`"`))
+	return bytes.ReplaceAll(data, []byte(`"`+oldPath+`/`), []byte(`"`+modPath+`/`))
+}
+
+// checkExists checks no file of the new project exists unless -f is specified.
+func checkExists(srcs map[string][]byte) {
+	if *flagForce {
+		return
+	}
+	for _, name := range []string{"go.mod", "gox.mod"} {
+		if _, err := os.Stat(name); err == nil {
+			fatal("gop init: " + name + " already exists")
+		}
+	}
+	for file := range srcs {
+		if _, err := os.Stat(file); err == nil {
+			fatal("gop init: " + file + " already exists, use -f to overwrite it")
+		}
+	}
+}
+
+func writeFiles(srcs map[string][]byte) []string {
+	files := make([]string, 0, len(srcs))
+	for file := range srcs {
+		files = append(files, file)
+	}
+	sort.Strings(files)
+	for _, file := range files {
+		if dir := filepath.Dir(file); dir != "." {
+			check(os.MkdirAll(dir, 0755))
+		}
+		check(os.WriteFile(file, srcs[file], 0644))
+	}
+	return files
+}
+
+func goMainVer() string {
+	ver := strings.TrimPrefix(runtime.Version(), "go")
+	if pos := strings.Index(ver, "."); pos > 0 {
+		pos++
+		if pos2 := strings.Index(ver[pos:], "."); pos2 > 0 {
+			ver = ver[:pos+pos2]
+		}
+	}
+	return ver
+}
+
+func check(err error) {
+	if err != nil {
+		log.Fatalln(err)
+	}
+}
+
+func fatal(msg string) {
+	fmt.Fprintln(os.Stderr, msg)
+	os.Exit(1)
+}
+
+// -----------------------------------------------------------------------------
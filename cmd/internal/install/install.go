@@ -75,6 +75,7 @@ func runCmd(cmd *base.Command, args []string) {
 	if err != nil {
 		log.Panicln("tool.NewDefaultConf:", err)
 	}
+	conf.Importer.SetModFlag(pass.Mod())
 	defer conf.UpdateCache()
 
 	confCmd := conf.NewGoCmdConf()
@@ -97,6 +98,8 @@ func install(proj xgoprojs.Proj, conf *tool.Config, install *gocmd.InstallConfig
 		err = tool.InstallPkgPath("", v.Path, conf, install, flags)
 	case *xgoprojs.FilesProj:
 		err = tool.InstallFiles(v.Files, conf, install)
+	case *xgoprojs.URLProj:
+		err = fmt.Errorf("%s: %w", v.URL, xgoprojs.ErrURLProj)
 	default:
 		log.Panicln("`gop install` doesn't support", reflect.TypeOf(v))
 	}
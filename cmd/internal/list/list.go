@@ -14,15 +14,18 @@
  * limitations under the License.
  */
 
+// Package list implements the “gop list” command.
 package list
 
-/*
 import (
+	"encoding/json"
 	"fmt"
 	"log"
+	"os"
+	"strings"
 
 	"github.com/goplus/xgo/cmd/internal/base"
-	"github.com/goplus/xgo/x/gopmod"
+	"github.com/goplus/xgo/tool"
 )
 
 // -----------------------------------------------------------------------------
@@ -30,12 +33,12 @@ import (
 // gop list
 var Cmd = &base.Command{
 	UsageLine: "gop list [-json] [packages]",
-	Short:     "List packages or modules",
+	Short:     "List packages, their XGo/Go files, classfiles and generated files",
 }
 
 var (
-	flag = &Cmd.Flag
-	_    = flag.Bool("json", false, "printing in JSON format.")
+	flag     = &Cmd.Flag
+	flagJSON = flag.Bool("json", false, "printing in JSON format.")
 )
 
 func init() {
@@ -48,15 +51,55 @@ func runCmd(cmd *base.Command, args []string) {
 		log.Fatalln("parse input arguments failed:", err)
 	}
 
-	pattern := flag.Args()
-	if len(pattern) == 0 {
-		pattern = []string{"."}
-	}
+	mod, err := tool.LoadMod(".")
+	check(err)
 
-	pkgPaths, err := gopmod.List(pattern...)
+	pkgs, err := tool.List(mod, flag.Args()...)
 	check(err)
-	for _, pkgPath := range pkgPaths {
-		fmt.Println(pkgPath)
+	if *flagJSON {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "\t")
+		for _, pkg := range pkgs {
+			check(enc.Encode(pkg))
+		}
+		return
+	}
+	for _, pkg := range pkgs {
+		printPkg(pkg)
+	}
+}
+
+func printPkg(pkg *tool.Package) {
+	name := pkg.ImportPath
+	if name == "" {
+		name = pkg.Dir
+	}
+	fmt.Printf("%s (package %s)\n", name, pkg.Name)
+	printFiles("xgo", pkg.XGoFiles)
+	printFiles("go", pkg.GoFiles)
+	for _, c := range pkg.ClassFiles {
+		kind := "class"
+		if c.Proj {
+			kind = "project"
+		}
+		if c.Class != "" {
+			kind += " " + c.Class
+		}
+		if c.PkgPaths != nil {
+			kind += " of " + c.PkgPaths[0]
+		}
+		fmt.Printf("\tclassfile: %s (%s)\n", c.File, kind)
+	}
+	printFiles("test xgo", pkg.TestXGoFiles)
+	printFiles("test go", pkg.TestGoFiles)
+	for _, g := range pkg.GenFiles {
+		fmt.Printf("\tgen: %s <- %s\n", g.File, strings.Join(g.Sources, " "))
+	}
+}
+
+func printFiles(kind string, files []string) {
+	if files != nil {
+		fmt.Printf("\t%s: %s\n", kind, strings.Join(files, " "))
 	}
 }
 
@@ -65,6 +108,5 @@ func check(err error) {
 		log.Fatalln(err)
 	}
 }
-*/
 
 // -----------------------------------------------------------------------------
@@ -0,0 +1,94 @@
+/*
+ * Copyright (c) 2026 The XGo Authors (xgo.dev). All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package plugin dispatches unknown xgo subcommands to external commands.
+//
+// Like git, “xgo name args...” runs the executable “xgo-name” found on PATH
+// if name isn't a builtin command. The plugin gets the context of the XGo
+// project by environment variables:
+//
+//	XGO        path of the running xgo command
+//	XGOROOT    root directory of XGo
+//	XGOVERSION version of XGo
+//	XGOMOD     path of the XGo module's go.mod file (empty if there is none)
+//	XGOMODPATH module path of the XGo module (empty if there is none)
+package plugin
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+
+	"github.com/goplus/mod/xgomod"
+	"github.com/goplus/xgo/env"
+)
+
+// Prefix is the name prefix of plugin executables.
+const Prefix = "xgo-"
+
+// Lookup searches PATH for the plugin executable of the subcommand name.
+func Lookup(name string) (string, error) {
+	if name == "" || name[0] == '-' || filepath.Base(name) != name {
+		return "", exec.ErrNotFound
+	}
+	return exec.LookPath(Prefix + name)
+}
+
+// Env returns the environment variables passed to a plugin.
+func Env() []string {
+	self, err := os.Executable()
+	if err != nil {
+		self = os.Args[0]
+	}
+	var modFile, modPath string
+	if mod, err := xgomod.Load("."); err == nil {
+		modFile, modPath = mod.Modfile(), mod.Path()
+	}
+	return []string{
+		"XGO=" + self,
+		"XGOROOT=" + env.XGOROOT(),
+		"XGOVERSION=" + env.Version(),
+		"XGOMOD=" + modFile,
+		"XGOMODPATH=" + modPath,
+	}
+}
+
+// Run runs “xgo args...” by the plugin executable of args[0]. It doesn't
+// return: the xgo process exits with the exit code of the plugin.
+func Run(args []string) {
+	name := args[0]
+	path, err := Lookup(name)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "xgo %s: unknown command\nRun 'xgo help' for usage.\n", name)
+		os.Exit(2)
+	}
+	cmd := exec.Command(path, args[1:]...)
+	cmd.Stdin = os.Stdin
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	cmd.Env = append(os.Environ(), Env()...)
+	if err = cmd.Run(); err != nil {
+		var e *exec.ExitError
+		if errors.As(err, &e) {
+			os.Exit(e.ExitCode())
+		}
+		fmt.Fprintf(os.Stderr, "xgo %s: %v\n", name, err)
+		os.Exit(1)
+	}
+	os.Exit(0)
+}
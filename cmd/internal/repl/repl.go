@@ -0,0 +1,136 @@
+/*
+ * Copyright (c) 2026 The XGo Authors (xgo.dev). All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package repl implements the “gop repl” command.
+package repl
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/goplus/xgo/cmd/internal/base"
+	"github.com/goplus/xgo/tool"
+	"github.com/qiniu/x/log"
+)
+
+// gop repl
+var Cmd = &base.Command{
+	UsageLine: "gop repl [-tags tags]",
+	Short:     "Start an interactive XGo session",
+}
+
+var (
+	flag = &Cmd.Flag
+)
+
+func init() {
+	Cmd.Run = runCmd
+}
+
+const help = `Enter XGo statements, expressions or declarations (imports, funcs, types,
+consts and vars), which are kept in the session. Values of expressions are
+printed. Input continues on the next line if brackets aren't closed.
+
+Commands:
+  :source  print the program of the session
+  :reset   clear the session
+  :help    print this help
+  :quit    quit (or Ctrl-D)
+`
+
+func runCmd(cmd *base.Command, args []string) {
+	pass := base.PassBuildFlags(cmd)
+	err := flag.Parse(args)
+	if err != nil {
+		log.Fatalln("parse input arguments failed:", err)
+	}
+	if flag.NArg() > 0 {
+		cmd.Usage(os.Stderr)
+	}
+
+	conf, err := tool.NewDefaultConf(".", tool.ConfFlagNoTestFiles|tool.ConfFlagNoCacheFile, pass.Tags())
+	if err != nil {
+		log.Panicln("tool.NewDefaultConf:", err)
+	}
+	conf.Importer.SetModFlag(pass.Mod())
+	if !conf.Mod.HasModfile() { // if no go.mod, check GopDeps
+		conf.XGoDeps = new(int)
+	}
+	confCmd := conf.NewGoCmdConf()
+	confCmd.Flags = pass.Args
+
+	dir, err := os.MkdirTemp("", "xgo-repl")
+	if err != nil {
+		log.Fatalln(err)
+	}
+	defer os.RemoveAll(dir)
+
+	s := newSession(dir, conf, confCmd)
+	fmt.Println(`XGo REPL, type ":help" for help.`)
+	repl(s, bufio.NewReader(os.Stdin))
+}
+
+func repl(s *session, r *bufio.Reader) {
+	for {
+		src, err := readInput(r)
+		if err != nil {
+			if err == io.EOF {
+				fmt.Println()
+				return
+			}
+			log.Fatalln(err)
+		}
+		switch strings.TrimSpace(src) {
+		case "":
+		case ":quit", ":q":
+			return
+		case ":help":
+			fmt.Print(help)
+		case ":reset":
+			s.reset()
+		case ":source":
+			fmt.Print(s.source(nil))
+		default:
+			s.eval(src)
+		}
+	}
+}
+
+// readInput reads lines of input until it's complete (see incomplete).
+func readInput(r *bufio.Reader) (string, error) {
+	var b strings.Builder
+	prompt := ">>> "
+	for {
+		fmt.Print(prompt)
+		line, err := r.ReadString('\n')
+		b.WriteString(line)
+		if err != nil {
+			if err == io.EOF && b.Len() > 0 {
+				return b.String(), nil
+			}
+			return "", err
+		}
+		if !incomplete(b.String()) {
+			return b.String(), nil
+		}
+		prompt = "... "
+	}
+}
+
+// -----------------------------------------------------------------------------
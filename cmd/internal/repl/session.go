@@ -0,0 +1,295 @@
+/*
+ * Copyright (c) 2026 The XGo Authors (xgo.dev). All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package repl
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/goplus/xgo/ast"
+	"github.com/goplus/xgo/parser"
+	"github.com/goplus/xgo/scanner"
+	"github.com/goplus/xgo/token"
+	"github.com/goplus/xgo/tool"
+	"github.com/goplus/xgo/x/gocmd"
+	"github.com/qiniu/x/errors"
+)
+
+// -----------------------------------------------------------------------------
+
+// A session keeps the input accepted so far as the source of a program, which
+// is compiled and run again for each input. Only the output beyond what has
+// been printed is printed, so the side effects other than output (like
+// writing files) of earlier inputs are repeated.
+type session struct {
+	imports []string
+	decls   []string
+	stmts   []string
+
+	nout int // bytes of stdout printed
+	nerr int // bytes of stderr printed
+
+	file    string // source file of the program
+	autogen string
+	conf    *tool.Config
+	run     *gocmd.RunConfig
+}
+
+func newSession(dir string, conf *tool.Config, run *gocmd.RunConfig) *session {
+	return &session{
+		file:    filepath.Join(dir, "main.xgo"),
+		autogen: filepath.Join(dir, "xgo_autogen.go"),
+		conf:    conf,
+		run:     run,
+	}
+}
+
+func (p *session) reset() {
+	p.imports, p.decls, p.stmts = nil, nil, nil
+	p.nout, p.nerr = 0, 0
+}
+
+// input is an input split into parts of a program.
+type input struct {
+	imports []string
+	decls   []string
+	stmts   []string
+}
+
+// eval evaluates an input, which is kept in the session if it compiles and
+// runs without errors. The value of a single expression is printed.
+func (p *session) eval(src string) {
+	in, expr, err := splitInput(src)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return
+	}
+	if expr != "" {
+		printIn := *in
+		printIn.stmts = []string{"xgoReplPrint(" + expr + ")"}
+		if p.compile(&printIn) == nil {
+			p.exec(&printIn)
+			return
+		}
+	}
+	if err = p.compile(in); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return
+	}
+	p.exec(in)
+}
+
+// posProgram matches positions in the program, which are meaningless to the
+// input.
+var posProgram = regexp.MustCompile(`\S*main\.xgo:\d+:\d+: `)
+
+func (p *session) compile(in *input) error {
+	if err := os.WriteFile(p.file, []byte(p.source(in)), 0666); err != nil {
+		return err
+	}
+	_, err := tool.GenGoFiles(p.autogen, []string{p.file}, p.conf)
+	if err != nil {
+		return errors.New(posProgram.ReplaceAllString(errors.Summary(err), ""))
+	}
+	return nil
+}
+
+// exec runs the program compiled, and keeps the input in the session if it
+// succeeds.
+func (p *session) exec(in *input) {
+	var stdout, stderr bytes.Buffer
+	run := *p.run
+	run.Run = func(cmd *exec.Cmd) error {
+		cmd.Stdout, cmd.Stderr = &stdout, &stderr
+		return cmd.Run()
+	}
+	var buildDir string
+	if p.conf.XGoDeps != nil && *p.conf.XGoDeps != 0 {
+		buildDir = p.conf.XGo.Root
+	}
+	err := gocmd.RunFiles(buildDir, []string{p.autogen}, nil, &run)
+	out, errout := stdout.Bytes(), stderr.Bytes()
+	os.Stdout.Write(out[min(p.nout, len(out)):])
+	if err != nil {
+		os.Stderr.Write(errout)
+		if stderr.Len() == 0 {
+			fmt.Fprintln(os.Stderr, err)
+		}
+		return
+	}
+	os.Stderr.Write(errout[min(p.nerr, len(errout)):])
+	p.nout, p.nerr = len(out), len(errout)
+	p.imports = append(p.imports, in.imports...)
+	p.decls = append(p.decls, in.decls...)
+	p.stmts = append(p.stmts, in.stmts...)
+}
+
+const prelude = `import (
+	xgorepl_fmt "fmt"
+	xgorepl_strconv "strconv"
+)
+`
+
+const printFn = `
+func xgoReplPrint(vals ...any) {
+	for i, v := range vals {
+		if i > 0 {
+			xgorepl_fmt.Print(" ")
+		}
+		if s, ok := v.(string); ok {
+			xgorepl_fmt.Print(xgorepl_strconv.Quote(s))
+		} else {
+			xgorepl_fmt.Printf("%+v", v)
+		}
+	}
+	xgorepl_fmt.Println()
+}
+`
+
+// source returns the source of the program of the session with the input in
+// (if not nil).
+func (p *session) source(in *input) string {
+	all := input{p.imports, p.decls, p.stmts}
+	if in != nil {
+		all.imports = slices.Concat(p.imports, in.imports)
+		all.decls = slices.Concat(p.decls, in.decls)
+		all.stmts = slices.Concat(p.stmts, in.stmts)
+	}
+	var b strings.Builder
+	b.WriteString("package main\n\n")
+	b.WriteString(prelude)
+	for _, imp := range all.imports {
+		b.WriteString(imp)
+		b.WriteByte('\n')
+	}
+	for _, decl := range all.decls {
+		b.WriteByte('\n')
+		b.WriteString(decl)
+		b.WriteByte('\n')
+	}
+	b.WriteString(printFn)
+	b.WriteString("\nfunc main() {\n")
+	for _, stmt := range all.stmts {
+		b.WriteString(stmt)
+		b.WriteByte('\n')
+	}
+	b.WriteString("}\n")
+	return b.String()
+}
+
+// splitInput splits an input into imports, declarations and statements. If
+// the input is a single expression, it's returned as expr.
+func splitInput(src string) (in *input, expr string, err error) {
+	fset := token.NewFileSet()
+	f, err := parser.ParseFile(fset, "", src, 0)
+	if err != nil {
+		return
+	}
+	text := func(node ast.Node) string {
+		return src[fset.Position(node.Pos()).Offset:fset.Position(node.End()).Offset]
+	}
+	in = new(input)
+	for _, decl := range f.Decls {
+		switch decl := decl.(type) {
+		case *ast.GenDecl:
+			if decl.Tok == token.IMPORT {
+				in.imports = append(in.imports, text(decl))
+			} else {
+				in.decls = append(in.decls, text(decl))
+			}
+		case *ast.FuncDecl:
+			if !decl.Shadow {
+				in.decls = append(in.decls, text(decl))
+				continue
+			}
+			list := decl.Body.List
+			if len(list) == 1 {
+				if stmt, ok := list[0].(*ast.ExprStmt); ok {
+					expr = text(stmt.X)
+				}
+			}
+			for _, stmt := range list {
+				in.stmts = append(in.stmts, text(stmt)+useVars(stmt))
+			}
+		}
+	}
+	return
+}
+
+// useVars returns statements using variables defined by stmt, so that they
+// may be unused.
+func useVars(stmt ast.Stmt) string {
+	var names []*ast.Ident
+	switch stmt := stmt.(type) {
+	case *ast.AssignStmt:
+		if stmt.Tok == token.DEFINE {
+			for _, x := range stmt.Lhs {
+				if ident, ok := x.(*ast.Ident); ok {
+					names = append(names, ident)
+				}
+			}
+		}
+	case *ast.DeclStmt:
+		if decl, ok := stmt.Decl.(*ast.GenDecl); ok && decl.Tok == token.VAR {
+			for _, spec := range decl.Specs {
+				names = append(names, spec.(*ast.ValueSpec).Names...)
+			}
+		}
+	}
+	var b strings.Builder
+	for _, name := range names {
+		if name.Name != "_" {
+			b.WriteString("\n_ = ")
+			b.WriteString(name.Name)
+		}
+	}
+	return b.String()
+}
+
+// incomplete reports whether src has unclosed brackets, or an unterminated
+// raw string or comment, so that more lines are expected.
+func incomplete(src string) bool {
+	fset := token.NewFileSet()
+	file := fset.AddFile("", -1, len(src))
+	unterminated := false
+	var s scanner.Scanner
+	s.Init(file, []byte(src), func(pos token.Position, msg string) {
+		if strings.HasSuffix(msg, "not terminated") {
+			unterminated = true
+		}
+	}, 0)
+	depth := 0
+	for {
+		_, tok, _ := s.Scan()
+		switch tok {
+		case token.EOF:
+			return depth > 0 || unterminated
+		case token.LPAREN, token.LBRACK, token.LBRACE:
+			depth++
+		case token.RPAREN, token.RBRACK, token.RBRACE:
+			depth--
+		}
+	}
+}
+
+// -----------------------------------------------------------------------------
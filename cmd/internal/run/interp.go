@@ -0,0 +1,113 @@
+/*
+ * Copyright (c) 2026 The XGo Authors (xgo.dev). All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package run
+
+import (
+	"fmt"
+	"go/ast"
+	"go/build"
+	"go/parser"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+
+	"github.com/goplus/xgo/tool"
+	"github.com/goplus/xgo/x/interp"
+	"github.com/goplus/xgo/x/xgoprojs"
+	"github.com/qiniu/x/log"
+
+	_ "github.com/goplus/xgo/x/interp/stdlib"
+)
+
+// interpret runs an XGo program by the interpreter instead of the Go toolchain.
+func interpret(proj xgoprojs.Proj, args []string, chDir bool, conf *tool.Config, tags string) {
+	var goFiles []string
+	var err error
+	switch v := proj.(type) {
+	case *xgoprojs.DirProj:
+		if _, _, err = tool.GenGo(v.Dir, conf, false); err == nil {
+			goFiles, err = pkgGoFiles(v.Dir, tags)
+		}
+	case *xgoprojs.PkgPathProj:
+		var localDir string
+		var recursively bool
+		localDir, recursively, err = tool.GenGoPkgPath("", v.Path, conf, true)
+		if err == nil {
+			if recursively {
+				log.Fatalln("can't use ... pattern for `gop run` command")
+			}
+			goFiles, err = pkgGoFiles(localDir, tags)
+			if chDir {
+				os.Chdir(localDir)
+			}
+		}
+	case *xgoprojs.FilesProj:
+		goFiles, err = tool.GenGoFiles("", v.Files, conf)
+	case *xgoprojs.URLProj:
+		var file string
+		if file, err = tool.FetchScript(v.URL); err == nil {
+			goFiles, err = tool.GenGoFiles(autogenOf(file), []string{file}, conf)
+		}
+	default:
+		log.Panicln("`gop run` doesn't support", reflect.TypeOf(v))
+	}
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+
+	fset := conf.Fset
+	files := make([]*ast.File, 0, len(goFiles))
+	for _, file := range goFiles {
+		f, err := parser.ParseFile(fset, file, nil, parser.SkipObjectResolution)
+		if err != nil {
+			log.Fatalln(err)
+		}
+		files = append(files, f)
+	}
+	p, err := interp.New(fset, files, conf.Importer)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+	os.Args = append([]string{goFiles[0]}, args...)
+	if err = p.Run(); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		if _, ok := err.(*interp.PanicError); ok {
+			os.Exit(2)
+		}
+		os.Exit(1)
+	}
+}
+
+// pkgGoFiles returns Go files (excluding tests) of the package in dir.
+func pkgGoFiles(dir, tags string) ([]string, error) {
+	ctx := build.Default
+	if tags != "" {
+		ctx.BuildTags = strings.Split(tags, ",")
+	}
+	pkg, err := ctx.ImportDir(dir, 0)
+	if err != nil {
+		return nil, err
+	}
+	files := make([]string, len(pkg.GoFiles))
+	for i, name := range pkg.GoFiles {
+		files[i] = filepath.Join(dir, name)
+	}
+	return files, nil
+}
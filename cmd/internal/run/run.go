@@ -20,11 +20,14 @@ package run
 import (
 	"fmt"
 	"os"
+	"os/exec"
+	"path/filepath"
 	"reflect"
 
 	"github.com/goplus/gogen"
 	"github.com/goplus/xgo/cl"
 	"github.com/goplus/xgo/cmd/internal/base"
+	"github.com/goplus/xgo/env"
 	"github.com/goplus/xgo/tool"
 	"github.com/goplus/xgo/x/gocmd"
 	"github.com/goplus/xgo/x/xgoprojs"
@@ -33,17 +36,20 @@ import (
 
 // gop run
 var Cmd = &base.Command{
-	UsageLine: "gop run [-nc -asm -quiet -debug -prof] package [arguments...]",
+	UsageLine: "gop run [-nc -i -asm -quiet -debug -prof -profdir dir -pprof] package [arguments...]",
 	Short:     "Run an XGo program",
 }
 
 var (
 	flag        = &Cmd.Flag
-	flagAsm     = flag.Bool("asm", false, "generates `asm` code of XGo bytecode backend")
+	flagAsm     = flag.Bool("asm", false, "print instructions of the Go code generator while compiling")
+	flagInterp  = flag.Bool("i", false, "run the program by the interpreter instead of the Go toolchain")
 	flagDebug   = flag.Bool("debug", false, "print debug information")
 	flagQuiet   = flag.Bool("quiet", false, "don't generate any compiling stage log")
 	flagNoChdir = flag.Bool("nc", false, "don't change dir (only for `gop run pkgPath`)")
 	flagProf    = flag.Bool("prof", false, "do profile and generate profile report")
+	flagProfDir = flag.String("profdir", ".", "write profiles (cpu.pprof and mem.pprof) to `dir` (only for -prof)")
+	flagPprof   = flag.Bool("pprof", false, "open the CPU profile by `go tool pprof` after the run (only for -prof)")
 )
 
 func init() {
@@ -75,25 +81,54 @@ func runCmd(cmd *base.Command, args []string) {
 		gogen.SetDebug(gogen.DbgFlagInstruction)
 	}
 
-	if *flagProf {
-		panic("TODO: profile not impl")
-	}
-
 	noChdir := *flagNoChdir
 	conf, err := tool.NewDefaultConf(".", tool.ConfFlagNoTestFiles, pass.Tags())
 	if err != nil {
 		log.Panicln("tool.NewDefaultConf:", err)
 	}
+	conf.Importer.SetModFlag(pass.Mod())
 	defer conf.UpdateCache()
 
 	if !conf.Mod.HasModfile() { // if no go.mod, check GopDeps
 		conf.XGoDeps = new(int)
 	}
+	if *flagInterp || env.Getenv("XGO_RUNNER") == "interp" {
+		interpret(proj, args, !noChdir, conf, pass.Tags())
+		return
+	}
 	confCmd := conf.NewGoCmdConf()
 	confCmd.Flags = pass.Args
+	if *flagProf {
+		profDir, err := filepath.Abs(*flagProfDir)
+		if err != nil {
+			log.Fatalln(err)
+		}
+		confCmd.ProfDir = profDir
+		defer report(profDir)
+	}
 	run(proj, args, !noChdir, conf, confCmd)
 }
 
+// report prints where the profiles are and opens the CPU profile by
+// `go tool pprof` if -pprof is specified.
+func report(profDir string) {
+	cpu, mem := filepath.Join(profDir, "cpu.pprof"), filepath.Join(profDir, "mem.pprof")
+	if _, err := os.Stat(mem); err != nil {
+		fmt.Fprintln(os.Stderr, "no profile written, maybe the program exited by os.Exit")
+		return
+	}
+	fmt.Fprintf(os.Stderr, "profiles written to %s and %s\n", cpu, mem)
+	if *flagPprof {
+		cmd := exec.Command(gocmd.Name(), "tool", "pprof", cpu)
+		cmd.Stdin = os.Stdin
+		cmd.Stdout = os.Stdout
+		cmd.Stderr = os.Stderr
+		if err := cmd.Run(); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+		}
+	}
+}
+
 func run(proj xgoprojs.Proj, args []string, chDir bool, conf *tool.Config, run *gocmd.RunConfig) {
 	const flags = 0
 	var obj string
@@ -107,6 +142,12 @@ func run(proj xgoprojs.Proj, args []string, chDir bool, conf *tool.Config, run *
 		err = tool.RunPkgPath(v.Path, args, chDir, conf, run, flags)
 	case *xgoprojs.FilesProj:
 		err = tool.RunFiles("", v.Files, args, conf, run)
+	case *xgoprojs.URLProj:
+		obj = v.URL
+		var file string
+		if file, err = tool.FetchScript(v.URL); err == nil {
+			err = tool.RunFiles(autogenOf(file), []string{file}, args, conf, run)
+		}
 	default:
 		log.Panicln("`gop run` doesn't support", reflect.TypeOf(v))
 	}
@@ -121,3 +162,11 @@ func run(proj xgoprojs.Proj, args []string, chDir bool, conf *tool.Config, run *
 }
 
 // -----------------------------------------------------------------------------
+
+// autogenOf returns the autogen file of a remote script, which is beside the
+// script in the module cache.
+func autogenOf(file string) string {
+	return filepath.Join(filepath.Dir(file), "xgo_autogen.go")
+}
+
+// -----------------------------------------------------------------------------
@@ -19,6 +19,7 @@ package serve
 
 import (
 	"context"
+	"time"
 
 	"github.com/goplus/xgo/cmd/internal/base"
 	"github.com/goplus/xgo/x/jsonrpc2"
@@ -36,6 +37,9 @@ var Cmd = &base.Command{
 var (
 	flag        = &Cmd.Flag
 	flagVerbose = flag.Bool("v", false, "print verbose information")
+	flagListen  = flag.String("listen", "", "serve as a daemon listening on `addr` (unix;path or [host]:port) instead of stdin/stdout")
+	flagIdle    = flag.Duration("idle", 0, "with -listen, exit if no client is connected for the duration")
+	flagTimeout = flag.Duration("timeout", 10*time.Minute, "kill check, build and run requests running longer than the duration (0 means no limit)")
 )
 
 func init() {
@@ -52,10 +56,21 @@ func runCmd(cmd *base.Command, args []string) {
 		jsonrpc2.SetDebug(jsonrpc2.DbgFlagCall)
 	}
 
-	listener := stdio.Listener(false)
+	var listener jsonrpc2.Listener
+	if *flagListen != "" {
+		listener, err = langserver.Listen(langserver.ParseAddr(*flagListen))
+		if err != nil {
+			log.Fatalln(err)
+		}
+		if *flagIdle > 0 {
+			listener = jsonrpc2.NewIdleListener(*flagIdle, listener)
+		}
+	} else {
+		listener = stdio.Listener(false)
+	}
 	defer listener.Close()
 
-	server := langserver.NewServer(context.Background(), listener, nil)
+	server := langserver.NewServer(context.Background(), listener, &langserver.Config{CmdTimeout: *flagTimeout})
 	server.Wait()
 }
 
@@ -0,0 +1,119 @@
+/*
+ * Copyright (c) 2026 The XGo Authors (xgo.dev). All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package test
+
+import (
+	"fmt"
+	"log"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/goplus/xgo/tool"
+	"github.com/goplus/xgo/x/gocmd"
+)
+
+// coverage collects coverage profiles of all tested projects, translates them
+// to XGo sources and merges them into one profile.
+type coverage struct {
+	profile string // merged profile, i.e. the -coverprofile file
+	html    string // HTML report, i.e. the -coverhtml file
+	tmp     string // profile of the current project
+	merged  *tool.CoverProfile
+}
+
+func newCoverage(args []string, html string) *coverage {
+	var profile string
+	for _, arg := range args {
+		if v, ok := strings.CutPrefix(arg, "-coverprofile="); ok {
+			profile = v
+		}
+	}
+	if profile == "" && html == "" {
+		return nil
+	}
+	f, err := os.CreateTemp("", "xgo-cover-*.out")
+	if err != nil {
+		log.Panicln("os.CreateTemp:", err)
+	}
+	f.Close()
+	if profile != "" {
+		profile, _ = filepath.Abs(profile)
+	}
+	if html != "" {
+		html, _ = filepath.Abs(html)
+	}
+	return &coverage{
+		profile: profile,
+		html:    html,
+		tmp:     f.Name(),
+		merged:  tool.NewCoverProfile(pkgDir),
+	}
+}
+
+// flags replaces the -coverprofile flag so that each project writes its own
+// profile.
+func (p *coverage) flags(args []string) []string {
+	ret := make([]string, 0, len(args)+1)
+	for _, arg := range args {
+		if !strings.HasPrefix(arg, "-coverprofile=") {
+			ret = append(ret, arg)
+		}
+	}
+	return append(ret, "-coverprofile="+p.tmp)
+}
+
+// collect adds the profile of the project just tested.
+func (p *coverage) collect() {
+	if fi, err := os.Stat(p.tmp); err != nil || fi.Size() == 0 {
+		return
+	}
+	if err := p.merged.AddFile(p.tmp); err != nil {
+		fmt.Fprintln(os.Stderr, "gop test: translate coverage profile:", err)
+		os.Exit(1)
+	}
+	os.Truncate(p.tmp, 0)
+}
+
+// finish writes the merged profile and the HTML report.
+func (p *coverage) finish() {
+	defer os.Remove(p.tmp)
+	profile := p.profile
+	if profile == "" {
+		profile = p.tmp
+	}
+	err := p.merged.WriteFile(profile)
+	if err == nil && p.html != "" {
+		cmd := exec.Command(gocmd.Name(), "tool", "cover", "-html="+profile, "-o", p.html)
+		cmd.Stdout = os.Stdout
+		cmd.Stderr = os.Stderr
+		err = cmd.Run()
+	}
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "gop test:", err)
+		os.Exit(1)
+	}
+}
+
+func pkgDir(pkgPath string) (string, error) {
+	out, err := exec.Command(gocmd.Name(), "list", "-e", "-f", "{{.Dir}}", pkgPath).Output()
+	if err != nil {
+		return "", fmt.Errorf("go list %s: %v", pkgPath, err)
+	}
+	return strings.TrimSpace(string(out)), nil
+}
@@ -33,13 +33,14 @@ import (
 
 // gop test
 var Cmd = &base.Command{
-	UsageLine: "gop test [-debug] [packages]",
+	UsageLine: "gop test [-debug] [-coverhtml file] [packages]",
 	Short:     "Test XGo packages",
 }
 
 var (
 	flag      = &Cmd.Flag
 	flagDebug = flag.Bool("debug", false, "print debug information")
+	flagHTML  = flag.String("coverhtml", "", "write an HTML coverage report of XGo sources to file")
 )
 
 func init() {
@@ -73,12 +74,23 @@ func runCmd(cmd *base.Command, args []string) {
 	if err != nil {
 		log.Panicln("tool.NewDefaultConf:", err)
 	}
+	conf.Importer.SetModFlag(pass.Mod())
 	defer conf.UpdateCache()
 
 	confCmd := conf.NewGoCmdConf()
 	confCmd.Flags = pass.Args
+	cover := newCoverage(pass.Args, *flagHTML)
 	for _, proj := range projs {
+		if cover != nil {
+			confCmd.Flags = cover.flags(pass.Args)
+		}
 		test(proj, conf, confCmd)
+		if cover != nil {
+			cover.collect()
+		}
+	}
+	if cover != nil {
+		cover.finish()
 	}
 }
 
@@ -95,6 +107,8 @@ func test(proj xgoprojs.Proj, conf *tool.Config, test *gocmd.TestConfig) {
 		err = tool.TestPkgPath("", v.Path, conf, test, flags)
 	case *xgoprojs.FilesProj:
 		err = tool.TestFiles(v.Files, conf, test)
+	case *xgoprojs.URLProj:
+		err = fmt.Errorf("%s: %w", v.URL, xgoprojs.ErrURLProj)
 	default:
 		log.Panicln("`gop test` doesn't support", reflect.TypeOf(v))
 	}
@@ -0,0 +1,233 @@
+/*
+ * Copyright (c) 2026 The XGo Authors (xgo.dev). All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package vet implements the “gop vet” command.
+package vet
+
+import (
+	"fmt"
+	goast "go/ast"
+	"go/types"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"reflect"
+	"slices"
+	"strings"
+
+	"github.com/goplus/xgo/ast"
+	"github.com/goplus/xgo/cmd/internal/base"
+	"github.com/goplus/xgo/parser"
+	"github.com/goplus/xgo/tool"
+	"github.com/goplus/xgo/x/typesutil"
+	"github.com/goplus/xgo/x/vet"
+	"github.com/goplus/xgo/x/xgoprojs"
+	"github.com/qiniu/x/log"
+)
+
+// gop vet
+var Cmd = &base.Command{
+	UsageLine: "gop vet [-checks list] [-tags tags] [packages]",
+	Short:     "Report likely mistakes in XGo packages",
+}
+
+var (
+	flag       = &Cmd.Flag
+	flagChecks = flag.String("checks", "", "a comma-separated list of checks to run (default: all), which are:\n"+checkList())
+	flagTags   = flag.String("tags", "", "a comma-separated list of additional build tags to consider satisfied")
+)
+
+func init() {
+	Cmd.Run = runCmd
+}
+
+func checkList() string {
+	var lines []string
+	for _, a := range vet.Analyzers {
+		summary, _, _ := strings.Cut(a.Doc, "\n")
+		lines = append(lines, fmt.Sprintf("  %-10s  %s", a.Name, summary))
+	}
+	return strings.Join(lines, "\n")
+}
+
+func runCmd(cmd *base.Command, args []string) {
+	err := flag.Parse(args)
+	if err != nil {
+		log.Fatalln("parse input arguments failed:", err)
+	}
+	analyzers, err := selectAnalyzers(*flagChecks)
+	if err != nil {
+		log.Fatalln(err)
+	}
+	pattern := flag.Args()
+	if len(pattern) == 0 {
+		pattern = []string{"."}
+	}
+	projs, err := xgoprojs.ParseAll(pattern...)
+	if err != nil {
+		log.Panicln("xgoprojs.ParseAll:", err)
+	}
+
+	conf, err := tool.NewDefaultConf(".", tool.ConfFlagNoTestFiles, *flagTags)
+	if err != nil {
+		log.Panicln("tool.NewDefaultConf:", err)
+	}
+	defer conf.UpdateCache()
+
+	ok := true
+	for _, proj := range projs {
+		switch v := proj.(type) {
+		case *xgoprojs.DirProj:
+			dir, recursively := strings.CutSuffix(v.Dir, "/...")
+			if !recursively {
+				ok = vetDir(dir, conf, analyzers) && ok
+				continue
+			}
+			filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+				if err != nil || !d.IsDir() {
+					return err
+				}
+				if path != dir && (strings.HasPrefix(d.Name(), "_") || strings.HasPrefix(d.Name(), ".") || hasModfile(path)) {
+					return filepath.SkipDir
+				}
+				ok = vetDir(path, conf, analyzers) && ok
+				return nil
+			})
+		default:
+			log.Panicln("`gop vet` doesn't support", reflect.TypeOf(v))
+		}
+	}
+	if !ok {
+		os.Exit(1)
+	}
+}
+
+func selectAnalyzers(checks string) ([]*vet.Analyzer, error) {
+	if checks == "" {
+		return vet.Analyzers, nil
+	}
+	var ret []*vet.Analyzer
+	for _, name := range strings.Split(checks, ",") {
+		i := slices.IndexFunc(vet.Analyzers, func(a *vet.Analyzer) bool {
+			return a.Name == name
+		})
+		if i < 0 {
+			return nil, fmt.Errorf("unknown check: %s", name)
+		}
+		ret = append(ret, vet.Analyzers[i])
+	}
+	return ret, nil
+}
+
+func hasModfile(dir string) bool {
+	_, err := os.Stat(filepath.Join(dir, "go.mod"))
+	return err == nil
+}
+
+// vetDir checks the XGo package in dir, and reports whether no problem is
+// found.
+func vetDir(dir string, conf *tool.Config, analyzers []*vet.Analyzer) bool {
+	fset := conf.Fset
+	mod := conf.Mod
+	pkgs, err := parser.ParseDirEx(fset, dir, parser.Config{
+		ClassKind: mod.ClassKind,
+		Filter:    conf.Filter,
+		Mode:      parser.ParseComments | parser.SaveAbsFile,
+	})
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return false
+	}
+	ok := true
+	for name, pkg := range pkgs {
+		if len(pkg.Files) == 0 { // no XGo source files
+			continue
+		}
+		xgofiles := sortedFiles(pkg.Files)
+		gofiles := sortedFiles(pkg.GoFiles)
+		typesPkg := types.NewPackage(pkgPath(mod.Root(), mod.Path(), dir, name), name)
+		info := &typesutil.Info{
+			Types:      make(map[ast.Expr]types.TypeAndValue),
+			Defs:       make(map[*ast.Ident]types.Object),
+			Uses:       make(map[*ast.Ident]types.Object),
+			Implicits:  make(map[ast.Node]types.Object),
+			Selections: make(map[*ast.SelectorExpr]*types.Selection),
+			Scopes:     make(map[ast.Node]*types.Scope),
+			Overloads:  make(map[*ast.Ident]types.Object),
+		}
+		var nerr int
+		typesConf := &types.Config{
+			Importer: conf.Importer,
+			Error: func(err error) {
+				fmt.Fprintln(os.Stderr, err)
+				nerr++
+			},
+		}
+		chk := typesutil.NewChecker(typesConf, &typesutil.Config{
+			Types: typesPkg,
+			Fset:  fset,
+			Mod:   mod,
+		}, nil, info)
+		if chk.Files(gofiles, xgofiles) != nil || nerr > 0 {
+			ok = false
+			continue
+		}
+		for _, d := range vet.Run(fset, typesPkg, xgofiles, info, analyzers) {
+			pos := fset.Position(d.Pos)
+			pos.Filename = relPath(pos.Filename)
+			fmt.Fprintf(os.Stderr, "%v: %s\n", pos, d.Message)
+			ok = false
+		}
+	}
+	return ok
+}
+
+func sortedFiles[F *ast.File | *goast.File](files map[string]F) []F {
+	names := make([]string, 0, len(files))
+	for name := range files {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	ret := make([]F, len(names))
+	for i, name := range names {
+		ret[i] = files[name]
+	}
+	return ret
+}
+
+func pkgPath(root, modPath, dir, name string) string {
+	if modPath == "" {
+		return name
+	}
+	absDir, _ := filepath.Abs(dir)
+	rel, err := filepath.Rel(root, absDir)
+	if err != nil || strings.HasPrefix(rel, "..") {
+		return name
+	}
+	return path.Join(modPath, filepath.ToSlash(rel))
+}
+
+func relPath(file string) string {
+	if wd, err := os.Getwd(); err == nil {
+		if rel, err := filepath.Rel(wd, file); err == nil && !strings.HasPrefix(rel, "..") {
+			return "." + string(filepath.Separator) + rel
+		}
+	}
+	return file
+}
+
+// -----------------------------------------------------------------------------
@@ -0,0 +1,143 @@
+/*
+ * Copyright (c) 2026 The XGo Authors (xgo.dev). All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package watch
+
+import (
+	"fmt"
+	"log"
+	"os"
+	"os/exec"
+	"sync"
+
+	"github.com/goplus/xgo/tool"
+)
+
+// -----------------------------------------------------------------------------
+
+// An executor runs (or tests) a package, one run at a time.
+type executor struct {
+	mode string   // run or test
+	dir  string   // directory of the package
+	args []string // arguments of the program, or flags of `go test`
+	bin  string   // program built in run mode
+
+	mutex  sync.Mutex
+	proc   *os.Process   // program running, if any
+	exited chan struct{} // closed when proc exits
+	err    error         // error of the last run
+}
+
+// exec stops the program running, if any, and runs the package again. It
+// returns when the program is started in run mode, or when the tests end in
+// test mode.
+func (p *executor) exec() {
+	p.stop()
+	if *clearScreen {
+		fmt.Print("\033[H\033[2J")
+	}
+	log.Println("Exec", p.mode, p.dir)
+	if p.mode == "test" {
+		p.done(p.test())
+		return
+	}
+	if err := p.build(); err != nil {
+		p.done(err)
+		return
+	}
+	cmd := exec.Command(p.bin, p.args...)
+	cmd.Stdin, cmd.Stdout, cmd.Stderr = os.Stdin, os.Stdout, os.Stderr
+	if err := cmd.Start(); err != nil {
+		p.done(err)
+		return
+	}
+	exited := make(chan struct{})
+	p.mutex.Lock()
+	p.proc, p.exited = cmd.Process, exited
+	p.mutex.Unlock()
+	go func() {
+		err := cmd.Wait()
+		p.mutex.Lock()
+		stopped := p.proc == nil
+		p.proc = nil
+		p.mutex.Unlock()
+		if !stopped {
+			p.done(err)
+		}
+		close(exited)
+	}()
+}
+
+// stop kills the program running, if any.
+func (p *executor) stop() {
+	p.mutex.Lock()
+	proc, exited := p.proc, p.exited
+	p.proc = nil
+	p.mutex.Unlock()
+	if proc != nil {
+		proc.Kill()
+		<-exited
+	}
+}
+
+// done reports the end of a run.
+func (p *executor) done(err error) {
+	p.mutex.Lock()
+	p.err = err
+	p.mutex.Unlock()
+	if err != nil {
+		log.Println("Failed:", err)
+	} else {
+		log.Println("Done")
+	}
+}
+
+// failed reports whether the last run failed.
+func (p *executor) failed() bool {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	return p.err != nil
+}
+
+func (p *executor) build() error {
+	conf, err := tool.NewDefaultConf(p.dir, tool.ConfFlagNoTestFiles|tool.ConfFlagNoCacheFile)
+	if err != nil {
+		return err
+	}
+	build := conf.NewGoCmdConf()
+	build.Flags = []string{"-o", p.bin}
+	return tool.BuildDir(p.dir, conf, build)
+}
+
+func (p *executor) test() error {
+	conf, err := tool.NewDefaultConf(p.dir, tool.ConfFlagNoCacheFile)
+	if err != nil {
+		return err
+	}
+	test := conf.NewGoCmdConf()
+	test.Flags = p.args
+	return tool.TestDir(p.dir, conf, test)
+}
+
+// close stops the program running, and removes the program built.
+func (p *executor) close() {
+	p.stop()
+	if p.bin != "" {
+		os.Remove(p.bin)
+	}
+}
+
+// -----------------------------------------------------------------------------
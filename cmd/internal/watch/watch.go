@@ -18,7 +18,10 @@ package watch
 
 import (
 	"log"
+	"os"
+	"os/signal"
 	"path/filepath"
+	"time"
 
 	"github.com/goplus/xgo/cmd/internal/base"
 	"github.com/goplus/xgo/tool"
@@ -30,15 +33,17 @@ import (
 
 // gop watch
 var Cmd = &base.Command{
-	UsageLine: "gop watch [-v -gentest] [dir]",
-	Short:     "Monitor code changes in an XGo workspace to generate Go files",
+	UsageLine: "gop watch [-v -gentest -delay d -clear] [run|test] [dir] [arguments...]",
+	Short:     "Monitor code changes in an XGo workspace to generate Go files, or rerun a package",
 }
 
 var (
-	flag       = &Cmd.Flag
-	verbose    = flag.Bool("v", false, "print verbose information.")
-	debug      = flag.Bool("debug", false, "show all debug information.")
-	genTestPkg = flag.Bool("gentest", false, "generate test package.")
+	flag        = &Cmd.Flag
+	verbose     = flag.Bool("v", false, "print verbose information.")
+	debug       = flag.Bool("debug", false, "show all debug information.")
+	genTestPkg  = flag.Bool("gentest", false, "generate test package.")
+	delay       = flag.Duration("delay", 200*time.Millisecond, "wait for no more changes for `duration` before rerunning.")
+	clearScreen = flag.Bool("clear", false, "clear the screen before rerunning.")
 )
 
 func init() {
@@ -59,11 +64,19 @@ func runCmd(cmd *base.Command, args []string) {
 	}
 
 	args = flag.Args()
+	var mode string
+	if len(args) > 0 && (args[0] == "run" || args[0] == "test") {
+		mode, args = args[0], args[1:]
+	}
 	if len(args) == 0 {
 		args = []string{"."}
 	}
 
 	root, _ := filepath.Abs(args[0])
+	if mode != "" {
+		rerun(mode, root, args[1:])
+		return
+	}
 	log.Println("Watch", root)
 	w := watcher.New(root)
 	go w.Run()
@@ -77,4 +90,49 @@ func runCmd(cmd *base.Command, args []string) {
 	}
 }
 
+// rerun runs (or tests) the package in dir, and reruns it when the module of
+// the package changes. It exits with status 1 on interrupt if the last run
+// failed.
+func rerun(mode, dir string, args []string) {
+	root := dir
+	if conf, err := tool.NewDefaultConf(dir, tool.ConfFlagNoCacheFile); err == nil && conf.Mod.HasModfile() {
+		root = conf.Mod.Root()
+	}
+	log.Println("Watch", root)
+	w := watcher.New(root)
+	go w.Run()
+
+	e := &executor{mode: mode, dir: dir, args: args}
+	if mode == "run" {
+		f, err := os.CreateTemp("", "xgowatch")
+		if err != nil {
+			log.Fatalln(err)
+		}
+		f.Close()
+		e.bin = f.Name()
+	}
+
+	sig := make(chan os.Signal, 1)
+	signal.Notify(sig, os.Interrupt)
+	changed := make(chan struct{})
+	go func() {
+		for {
+			w.FetchAll(false, *delay)
+			changed <- struct{}{}
+		}
+	}()
+	for {
+		e.exec()
+		select {
+		case <-changed:
+		case <-sig:
+			e.close()
+			if e.failed() {
+				os.Exit(1)
+			}
+			return
+		}
+	}
+}
+
 // -----------------------------------------------------------------------------
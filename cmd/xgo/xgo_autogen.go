@@ -4,23 +4,31 @@ package main
 
 import (
 	"fmt"
+	"github.com/goplus/cobra"
 	"github.com/goplus/cobra/xcmd"
 	"github.com/goplus/gogen"
+	"github.com/goplus/xgo/cmd/internal/bench"
 	"github.com/goplus/xgo/cmd/internal/bug"
 	"github.com/goplus/xgo/cmd/internal/build"
 	"github.com/goplus/xgo/cmd/internal/clean"
 	"github.com/goplus/xgo/cmd/internal/doc"
-	"github.com/goplus/xgo/cmd/internal/env"
+	env1 "github.com/goplus/xgo/cmd/internal/env"
+	"github.com/goplus/xgo/cmd/internal/generate"
 	"github.com/goplus/xgo/cmd/internal/gengo"
 	"github.com/goplus/xgo/cmd/internal/gopfmt"
 	"github.com/goplus/xgo/cmd/internal/gopget"
+	"github.com/goplus/xgo/cmd/internal/gopinit"
 	"github.com/goplus/xgo/cmd/internal/install"
+	"github.com/goplus/xgo/cmd/internal/list"
 	"github.com/goplus/xgo/cmd/internal/mod"
+	"github.com/goplus/xgo/cmd/internal/plugin"
+	"github.com/goplus/xgo/cmd/internal/repl"
 	"github.com/goplus/xgo/cmd/internal/run"
 	"github.com/goplus/xgo/cmd/internal/serve"
 	"github.com/goplus/xgo/cmd/internal/test"
+	"github.com/goplus/xgo/cmd/internal/vet"
 	"github.com/goplus/xgo/cmd/internal/watch"
-	env1 "github.com/goplus/xgo/env"
+	"github.com/goplus/xgo/env"
 	"github.com/goplus/xgo/tool"
 	"github.com/qiniu/x/log"
 	"github.com/qiniu/x/stringutil"
@@ -30,6 +38,10 @@ import (
 
 const _ = true
 
+type Cmd_bench struct {
+	xcmd.Command
+	*App
+}
 type Cmd_bug struct {
 	xcmd.Command
 	*App
@@ -54,6 +66,10 @@ type Cmd_fmt struct {
 	xcmd.Command
 	*App
 }
+type Cmd_generate struct {
+	xcmd.Command
+	*App
+}
 type Cmd_get struct {
 	xcmd.Command
 	*App
@@ -62,10 +78,18 @@ type Cmd_go struct {
 	xcmd.Command
 	*App
 }
+type Cmd_init struct {
+	xcmd.Command
+	*App
+}
 type Cmd_install struct {
 	xcmd.Command
 	*App
 }
+type Cmd_list struct {
+	xcmd.Command
+	*App
+}
 type App struct {
 	xcmd.App
 }
@@ -90,6 +114,10 @@ type Cmd_pack struct {
 	*App
 	TestMode bool `flag:"test, short: t, usage: verify that every index_pack file already exists and that its content matches what xgo pack would produce"`
 }
+type Cmd_repl struct {
+	xcmd.Command
+	*App
+}
 type Cmd_run struct {
 	xcmd.Command
 	*App
@@ -106,40 +134,94 @@ type Cmd_version struct {
 	xcmd.Command
 	*App
 }
+type Cmd_vet struct {
+	xcmd.Command
+	*App
+}
 type Cmd_watch struct {
 	xcmd.Command
 	*App
 }
-//line cmd/xgo/main_app.gox:6
+//line cmd/xgo/main_app.gox:11
 func (this *App) MainEntry() {
-//line cmd/xgo/main_app.gox:6:1
+//line cmd/xgo/main_app.gox:11:1
 	this.Short("xgo is a tool for managing XGo source code.")
-//line cmd/xgo/main_app.gox:7:1
+//line cmd/xgo/main_app.gox:12:1
 	log.SetFlags(log.Ldefault &^ log.LstdFlags)
-//line cmd/xgo/main_app.gox:9:1
+//line cmd/xgo/main_app.gox:14:1
 	gogen.GeneratedHeader = "// Code generated by xgo (XGo); DO NOT EDIT.\n\n"
+//line cmd/xgo/main_app.gox:17:1
+	if os.Getenv("GOPROXY") == "" {
+//line cmd/xgo/main_app.gox:18:1
+		if
+//line cmd/xgo/main_app.gox:18:1
+		proxy := env.Config()["GOPROXY"]; proxy != "" {
+//line cmd/xgo/main_app.gox:19:1
+			os.Setenv("GOPROXY", proxy)
+		}
+	}
+//line cmd/xgo/main_app.gox:24:1
+	this.Args = cobra.ArbitraryArgs
+//line cmd/xgo/main_app.gox:25:1
+	this.FlagOff()
+//line cmd/xgo/main_app.gox:27:1
+	this.Run__1(func(args []string) {
+//line cmd/xgo/main_app.gox:28:1
+		if len(args) == 0 || args[0] == "-h" || args[0] == "--help" {
+//line cmd/xgo/main_app.gox:29:1
+			this.Help()
+//line cmd/xgo/main_app.gox:30:1
+			return
+		}
+//line cmd/xgo/main_app.gox:32:1
+		plugin.Run(args)
+	})
 }
 func (this *App) Main() {
-	_xgo_obj0 := &Cmd_bug{App: this}
-	_xgo_obj1 := &Cmd_build{App: this}
-	_xgo_obj2 := &Cmd_clean{App: this}
-	_xgo_obj3 := &Cmd_doc{App: this}
-	_xgo_obj4 := &Cmd_env{App: this}
-	_xgo_obj5 := &Cmd_fmt{App: this}
-	_xgo_obj6 := &Cmd_get{App: this}
-	_xgo_obj7 := &Cmd_go{App: this}
-	_xgo_obj8 := &Cmd_install{App: this}
-	_xgo_obj9 := &Cmd_mod{App: this}
-	_xgo_obj10 := &Cmd_mod_download{App: this}
-	_xgo_obj11 := &Cmd_mod_init{App: this}
-	_xgo_obj12 := &Cmd_mod_tidy{App: this}
-	_xgo_obj13 := &Cmd_pack{App: this}
-	_xgo_obj14 := &Cmd_run{App: this}
-	_xgo_obj15 := &Cmd_serve{App: this}
-	_xgo_obj16 := &Cmd_test{App: this}
-	_xgo_obj17 := &Cmd_version{App: this}
-	_xgo_obj18 := &Cmd_watch{App: this}
-	xcmd.XGot_App_Main(this, _xgo_obj0, _xgo_obj1, _xgo_obj2, _xgo_obj3, _xgo_obj4, _xgo_obj5, _xgo_obj6, _xgo_obj7, _xgo_obj8, _xgo_obj9, _xgo_obj10, _xgo_obj11, _xgo_obj12, _xgo_obj13, _xgo_obj14, _xgo_obj15, _xgo_obj16, _xgo_obj17, _xgo_obj18)
+	_xgo_obj0 := &Cmd_bench{App: this}
+	_xgo_obj1 := &Cmd_bug{App: this}
+	_xgo_obj2 := &Cmd_build{App: this}
+	_xgo_obj3 := &Cmd_clean{App: this}
+	_xgo_obj4 := &Cmd_doc{App: this}
+	_xgo_obj5 := &Cmd_env{App: this}
+	_xgo_obj6 := &Cmd_fmt{App: this}
+	_xgo_obj7 := &Cmd_generate{App: this}
+	_xgo_obj8 := &Cmd_get{App: this}
+	_xgo_obj9 := &Cmd_go{App: this}
+	_xgo_obj10 := &Cmd_init{App: this}
+	_xgo_obj11 := &Cmd_install{App: this}
+	_xgo_obj12 := &Cmd_list{App: this}
+	_xgo_obj13 := &Cmd_mod{App: this}
+	_xgo_obj14 := &Cmd_mod_download{App: this}
+	_xgo_obj15 := &Cmd_mod_init{App: this}
+	_xgo_obj16 := &Cmd_mod_tidy{App: this}
+	_xgo_obj17 := &Cmd_pack{App: this}
+	_xgo_obj18 := &Cmd_repl{App: this}
+	_xgo_obj19 := &Cmd_run{App: this}
+	_xgo_obj20 := &Cmd_serve{App: this}
+	_xgo_obj21 := &Cmd_test{App: this}
+	_xgo_obj22 := &Cmd_version{App: this}
+	_xgo_obj23 := &Cmd_vet{App: this}
+	_xgo_obj24 := &Cmd_watch{App: this}
+	xcmd.XGot_App_Main(this, _xgo_obj0, _xgo_obj1, _xgo_obj2, _xgo_obj3, _xgo_obj4, _xgo_obj5, _xgo_obj6, _xgo_obj7, _xgo_obj8, _xgo_obj9, _xgo_obj10, _xgo_obj11, _xgo_obj12, _xgo_obj13, _xgo_obj14, _xgo_obj15, _xgo_obj16, _xgo_obj17, _xgo_obj18, _xgo_obj19, _xgo_obj20, _xgo_obj21, _xgo_obj22, _xgo_obj23, _xgo_obj24)
+}
+//line cmd/xgo/bench_cmd.gox:20
+func (this *Cmd_bench) Main(_xgo_arg0 string) {
+	this.Command.Main(_xgo_arg0)
+//line cmd/xgo/bench_cmd.gox:20:1
+	this.Use("bench [-raw] [-bench regexp] [-benchmem] [packages]")
+//line cmd/xgo/bench_cmd.gox:22:1
+	this.Short("Run benchmarks of XGo packages")
+//line cmd/xgo/bench_cmd.gox:24:1
+	this.FlagOff()
+//line cmd/xgo/bench_cmd.gox:26:1
+	this.Run__1(func(args []string) {
+//line cmd/xgo/bench_cmd.gox:27:1
+		bench.Cmd.Run(bench.Cmd, args)
+	})
+}
+func (this *Cmd_bench) Classfname() string {
+	return "bench"
 }
 //line cmd/xgo/bug_cmd.gox:20
 func (this *Cmd_bug) Main(_xgo_arg0 string) {
@@ -225,7 +307,7 @@ func (this *Cmd_env) Main(_xgo_arg0 string) {
 //line cmd/xgo/env_cmd.gox:26:1
 	this.Run__1(func(args []string) {
 //line cmd/xgo/env_cmd.gox:27:1
-		env.Cmd.Run(env.Cmd, args)
+		env1.Cmd.Run(env1.Cmd, args)
 	})
 }
 func (this *Cmd_env) Classfname() string {
@@ -249,6 +331,24 @@ func (this *Cmd_fmt) Main(_xgo_arg0 string) {
 func (this *Cmd_fmt) Classfname() string {
 	return "fmt"
 }
+//line cmd/xgo/generate_cmd.gox:20
+func (this *Cmd_generate) Main(_xgo_arg0 string) {
+	this.Command.Main(_xgo_arg0)
+//line cmd/xgo/generate_cmd.gox:20:1
+	this.Use("generate [-n -v -x -run regexp -skip regexp -tags tags] [packages]")
+//line cmd/xgo/generate_cmd.gox:22:1
+	this.Short("Generate files by processing XGo and Go sources")
+//line cmd/xgo/generate_cmd.gox:24:1
+	this.FlagOff()
+//line cmd/xgo/generate_cmd.gox:26:1
+	this.Run__1(func(args []string) {
+//line cmd/xgo/generate_cmd.gox:27:1
+		generate.Cmd.Run(generate.Cmd, args)
+	})
+}
+func (this *Cmd_generate) Classfname() string {
+	return "generate"
+}
 //line cmd/xgo/get_cmd.gox:20
 func (this *Cmd_get) Main(_xgo_arg0 string) {
 	this.Command.Main(_xgo_arg0)
@@ -285,6 +385,24 @@ func (this *Cmd_go) Main(_xgo_arg0 string) {
 func (this *Cmd_go) Classfname() string {
 	return "go"
 }
+//line cmd/xgo/init_cmd.gox:20
+func (this *Cmd_init) Main(_xgo_arg0 string) {
+	this.Command.Main(_xgo_arg0)
+//line cmd/xgo/init_cmd.gox:20:1
+	this.Use("init [-f -l] [template [module-path]]")
+//line cmd/xgo/init_cmd.gox:22:1
+	this.Short("Create a new XGo project from a template")
+//line cmd/xgo/init_cmd.gox:24:1
+	this.FlagOff()
+//line cmd/xgo/init_cmd.gox:26:1
+	this.Run__1(func(args []string) {
+//line cmd/xgo/init_cmd.gox:27:1
+		gopinit.Cmd.Run(gopinit.Cmd, args)
+	})
+}
+func (this *Cmd_init) Classfname() string {
+	return "init"
+}
 //line cmd/xgo/install_cmd.gox:20
 func (this *Cmd_install) Main(_xgo_arg0 string) {
 	this.Command.Main(_xgo_arg0)
@@ -303,6 +421,24 @@ func (this *Cmd_install) Main(_xgo_arg0 string) {
 func (this *Cmd_install) Classfname() string {
 	return "install"
 }
+//line cmd/xgo/list_cmd.gox:20
+func (this *Cmd_list) Main(_xgo_arg0 string) {
+	this.Command.Main(_xgo_arg0)
+//line cmd/xgo/list_cmd.gox:20:1
+	this.Use("list [-json] [packages]")
+//line cmd/xgo/list_cmd.gox:22:1
+	this.Short("List packages, their XGo/Go files, classfiles and generated files")
+//line cmd/xgo/list_cmd.gox:24:1
+	this.FlagOff()
+//line cmd/xgo/list_cmd.gox:26:1
+	this.Run__1(func(args []string) {
+//line cmd/xgo/list_cmd.gox:27:1
+		list.Cmd.Run(list.Cmd, args)
+	})
+}
+func (this *Cmd_list) Classfname() string {
+	return "list"
+}
 //line cmd/xgo/mod_cmd.gox:20
 func (this *Cmd_mod) Main(_xgo_arg0 string) {
 	this.Command.Main(_xgo_arg0)
@@ -437,6 +573,24 @@ func (this *Cmd_pack) Main(_xgo_arg0 string) {
 func (this *Cmd_pack) Classfname() string {
 	return "pack"
 }
+//line cmd/xgo/repl_cmd.gox:20
+func (this *Cmd_repl) Main(_xgo_arg0 string) {
+	this.Command.Main(_xgo_arg0)
+//line cmd/xgo/repl_cmd.gox:20:1
+	this.Use("repl [flags]")
+//line cmd/xgo/repl_cmd.gox:22:1
+	this.Short("Start an interactive XGo session")
+//line cmd/xgo/repl_cmd.gox:24:1
+	this.FlagOff()
+//line cmd/xgo/repl_cmd.gox:26:1
+	this.Run__1(func(args []string) {
+//line cmd/xgo/repl_cmd.gox:27:1
+		repl.Cmd.Run(repl.Cmd, args)
+	})
+}
+func (this *Cmd_repl) Classfname() string {
+	return "repl"
+}
 //line cmd/xgo/run_cmd.gox:20
 func (this *Cmd_run) Main(_xgo_arg0 string) {
 	this.Command.Main(_xgo_arg0)
@@ -501,19 +655,37 @@ func (this *Cmd_version) Main(_xgo_arg0 string) {
 //line cmd/xgo/version_cmd.gox:25:1
 	this.Run__0(func() {
 //line cmd/xgo/version_cmd.gox:26:1
-		fmt.Println(stringutil.Concat("xgo ", env1.Version(), " ", runtime.GOOS, "/", runtime.GOARCH))
+		fmt.Println(stringutil.Concat("xgo ", env.Version(), " ", runtime.GOOS, "/", runtime.GOARCH))
 	})
 }
 func (this *Cmd_version) Classfname() string {
 	return "version"
 }
+//line cmd/xgo/vet_cmd.gox:20
+func (this *Cmd_vet) Main(_xgo_arg0 string) {
+	this.Command.Main(_xgo_arg0)
+//line cmd/xgo/vet_cmd.gox:20:1
+	this.Use("vet [-checks list] [-tags tags] [packages]")
+//line cmd/xgo/vet_cmd.gox:22:1
+	this.Short("Report likely mistakes in XGo packages")
+//line cmd/xgo/vet_cmd.gox:24:1
+	this.FlagOff()
+//line cmd/xgo/vet_cmd.gox:26:1
+	this.Run__1(func(args []string) {
+//line cmd/xgo/vet_cmd.gox:27:1
+		vet.Cmd.Run(vet.Cmd, args)
+	})
+}
+func (this *Cmd_vet) Classfname() string {
+	return "vet"
+}
 //line cmd/xgo/watch_cmd.gox:20
 func (this *Cmd_watch) Main(_xgo_arg0 string) {
 	this.Command.Main(_xgo_arg0)
 //line cmd/xgo/watch_cmd.gox:20:1
-	this.Use("watch [flags] [dir]")
+	this.Use("watch [flags] [run|test] [dir] [arguments...]")
 //line cmd/xgo/watch_cmd.gox:22:1
-	this.Short("Monitor code changes in an XGo workspace to generate Go files")
+	this.Short("Monitor code changes in an XGo workspace to generate Go files, or rerun a package")
 //line cmd/xgo/watch_cmd.gox:24:1
 	this.FlagOff()
 //line cmd/xgo/watch_cmd.gox:26:1
@@ -0,0 +1,264 @@
+/*
+ * Copyright (c) 2026 The XGo Authors (xgo.dev). All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package csv
+
+import (
+	"bytes"
+	"encoding/csv"
+	"errors"
+	"io"
+	"iter"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/goplus/xgo/dql/maps"
+	"github.com/goplus/xgo/dql/stream"
+)
+
+const (
+	XGoPackage = "github.com/goplus/xgo/dql/maps"
+)
+
+// -----------------------------------------------------------------------------
+
+// Node represents a row of CSV data, whose value is a map[string]any from the
+// column names to the fields.
+type Node = maps.Node
+
+// NodeSet represents a set of CSV rows.
+type NodeSet = maps.NodeSet
+
+// Config specifies how CSV data is read.
+type Config struct {
+	Comma      rune // field delimiter, ',' by default
+	Quote      rune // quote character (must be ASCII), '"' by default
+	Comment    rune // if not 0, lines beginning with it are ignored
+	LazyQuotes bool // allow bare quotes in fields
+	TrimSpace  bool // ignore leading white space of fields
+
+	// Header specifies the column names. If it's nil, the first row is read as
+	// the header.
+	Header []string
+
+	// Infer converts fields that look like integers, floats or booleans to
+	// int64, float64 or bool values. Otherwise all fields are strings, which
+	// can still be converted by the typed accessors (Int, Float, Bool, etc.).
+	Infer bool
+
+	// OnError is called if an error occurs while reading rows. The iteration
+	// stops after the error.
+	OnError func(error)
+}
+
+// ErrInvalidQuote is returned if the quote character isn't valid.
+var ErrInvalidQuote = errors.New("csv: invalid quote character")
+
+// New creates a CSV NodeSet reading rows from r. Each row is a node with the
+// fields as attributes named by the header, like row.$name. Rows are read
+// lazily, so large files are streamed, and it means the NodeSet can only be
+// iterated once (use _all to traverse the rows multiple times).
+//
+// Missing fields of a short row are absent from its node, and fields beyond
+// the header are ignored.
+func New(r io.Reader, conf ...Config) NodeSet {
+	c := configOf(conf)
+	if c.Quote != '"' && (c.Quote >= utf8.RuneSelf || c.Quote == c.Comma || c.Quote == '\n' || c.Quote == '\r') {
+		return NodeSet{Err: ErrInvalidQuote}
+	}
+	return NodeSet{Data: rows(r, c)}
+}
+
+// Source creates a CSV NodeSet from various source types:
+// - string: treats the string as a file path (or URL), opens it, and reads CSV data from it.
+// - *stream.Request: opens the resource with the request options (like HTTP headers).
+// - []byte: reads CSV data from the byte slice.
+// - io.Reader: reads CSV data from the provided reader.
+// - Node: creates a NodeSet containing the single provided node.
+// - iter.Seq[Node]: directly uses the provided sequence of nodes.
+// - NodeSet: returns the provided NodeSet as is.
+// If the source type is unsupported, it panics.
+//
+// A file (or a resource) is closed after the rows are iterated.
+func Source(r any, conf ...Config) (ret NodeSet) {
+	switch v := r.(type) {
+	case string:
+		f, err := stream.Open(v)
+		if err != nil {
+			return NodeSet{Err: err}
+		}
+		return closeAfter(New(f, conf...), f)
+	case *stream.Request:
+		f, err := v.Open()
+		if err != nil {
+			return NodeSet{Err: err}
+		}
+		return closeAfter(New(f, conf...), f)
+	case []byte:
+		return New(bytes.NewReader(v), conf...)
+	case io.Reader:
+		return New(v, conf...)
+	case Node:
+		return maps.Root(v)
+	case iter.Seq[Node]:
+		return NodeSet{Data: v}
+	case NodeSet:
+		return v
+	default:
+		panic("dql/csv.Source: unsupported source type")
+	}
+}
+
+// closeAfter closes f after the rows of ns are iterated.
+func closeAfter(ns NodeSet, f io.Closer) NodeSet {
+	if ns.Err != nil {
+		f.Close()
+		return ns
+	}
+	data := ns.Data
+	ns.Data = func(yield func(Node) bool) {
+		defer f.Close()
+		data(yield)
+	}
+	return ns
+}
+
+func configOf(conf []Config) (c Config) {
+	if len(conf) > 0 {
+		c = conf[0]
+	}
+	if c.Comma == 0 {
+		c.Comma = ','
+	}
+	if c.Quote == 0 {
+		c.Quote = '"'
+	}
+	return
+}
+
+// -----------------------------------------------------------------------------
+
+func rows(r io.Reader, c Config) iter.Seq[Node] {
+	return func(yield func(Node) bool) {
+		in, quote := r, byte(c.Quote)
+		if quote != '"' {
+			in = &swapReader{r, quote}
+		}
+		cr := csv.NewReader(in)
+		cr.Comma = c.Comma
+		cr.Comment = c.Comment
+		cr.LazyQuotes = c.LazyQuotes
+		cr.TrimLeadingSpace = c.TrimSpace
+		cr.FieldsPerRecord = -1
+		cr.ReuseRecord = true
+		header := c.Header
+		for {
+			record, err := cr.Read()
+			if err != nil {
+				if err != io.EOF && c.OnError != nil {
+					c.OnError(err)
+				}
+				return
+			}
+			if quote != '"' {
+				for i, field := range record {
+					record[i] = swapQuote(field, quote)
+				}
+			}
+			if header == nil {
+				header = make([]string, len(record))
+				copy(header, record)
+				continue
+			}
+			row := make(map[string]any, len(header))
+			for i, field := range record {
+				if i >= len(header) {
+					break
+				}
+				if c.Infer {
+					row[header[i]] = infer(field)
+				} else {
+					row[header[i]] = field
+				}
+			}
+			if !yield(Node{Value: row}) {
+				return
+			}
+		}
+	}
+}
+
+// infer converts a field to an int64, float64 or bool value if it looks like
+// one, otherwise it returns the field as is.
+func infer(field string) any {
+	s := strings.TrimSpace(field)
+	if s == "" {
+		return field
+	}
+	if v, err := strconv.ParseInt(s, 10, 64); err == nil {
+		return v
+	}
+	if v, err := strconv.ParseFloat(s, 64); err == nil {
+		return v
+	}
+	switch s {
+	case "true", "TRUE", "True":
+		return true
+	case "false", "FALSE", "False":
+		return false
+	}
+	return field
+}
+
+// swapReader swaps the quote character with '"' in the data read from r, so
+// encoding/csv, which only supports '"', can read data with another quote
+// character. The fields are swapped back by swapQuote.
+type swapReader struct {
+	r     io.Reader
+	quote byte
+}
+
+func (p *swapReader) Read(b []byte) (n int, err error) {
+	n, err = p.r.Read(b)
+	for i, c := range b[:n] {
+		switch c {
+		case p.quote:
+			b[i] = '"'
+		case '"':
+			b[i] = p.quote
+		}
+	}
+	return
+}
+
+func swapQuote(field string, quote byte) string {
+	if strings.IndexByte(field, '"') < 0 && strings.IndexByte(field, quote) < 0 {
+		return field
+	}
+	b := []byte(field)
+	for i, c := range b {
+		switch c {
+		case quote:
+			b[i] = '"'
+		case '"':
+			b[i] = quote
+		}
+	}
+	return string(b)
+}
+
+// -----------------------------------------------------------------------------
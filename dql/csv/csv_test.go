@@ -0,0 +1,101 @@
+/*
+ * Copyright (c) 2026 The XGo Authors (xgo.dev). All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package csv
+
+import (
+	"encoding/csv"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/goplus/xgo/dql"
+)
+
+const people = `name,age,admin
+Alice,30,true
+"Bob, Jr.",25,false
+Carol
+`
+
+func TestSource(t *testing.T) {
+	dir := t.TempDir()
+	file := filepath.Join(dir, "people.csv")
+	if err := os.WriteFile(file, []byte(people), 0644); err != nil {
+		t.Fatal(err)
+	}
+	var names []string
+	for row := range Source(file).XGo_Enum() {
+		names = append(names, row.XGo_Attr__0("name").(string))
+	}
+	if strings.Join(names, "|") != "Alice|Bob, Jr.|Carol" {
+		t.Fatal("names:", names)
+	}
+
+	rows := Source([]byte(people)).XGo_all()
+	var ages []int
+	for row := range rows.XGo_Enum() {
+		if age, err := row.XGo_Elem("age").Int(); err == nil {
+			ages = append(ages, age)
+		}
+	}
+	if len(ages) != 2 || ages[0] != 30 || ages[1] != 25 {
+		t.Fatal("ages:", ages)
+	}
+	if _, err := rows.XGo_single().XGo_Attr__1("age"); err != dql.ErrMultiEntities {
+		t.Fatal("XGo_single:", err)
+	}
+
+	if ns := Source(filepath.Join(dir, "missing.csv")); ns.Err == nil {
+		t.Fatal("Source missing.csv: no error")
+	}
+}
+
+func TestConfig(t *testing.T) {
+	const data = "# comment\nAlice;'30';'say ''hi'' \"x\"'\nBob; 2.5;TRUE\n"
+	rows := dql.Collect(New(strings.NewReader(data), Config{
+		Comma:     ';',
+		Quote:     '\'',
+		Comment:   '#',
+		TrimSpace: true,
+		Header:    []string{"name", "score", "note"},
+		Infer:     true,
+	}).Data)
+	if len(rows) != 2 {
+		t.Fatal("rows:", rows)
+	}
+	alice := rows[0].Value.(map[string]any)
+	if alice["score"] != int64(30) || alice["note"] != `say 'hi' "x"` {
+		t.Fatal("alice:", alice)
+	}
+	bob := rows[1].Value.(map[string]any)
+	if bob["score"] != 2.5 || bob["note"] != true {
+		t.Fatal("bob:", bob)
+	}
+
+	if ns := New(strings.NewReader(data), Config{Quote: 'é'}); ns.Err != ErrInvalidQuote {
+		t.Fatal("New:", ns.Err)
+	}
+	var err error
+	n := len(dql.Collect(New(strings.NewReader("a\n1\n\"2\n"), Config{OnError: func(e error) {
+		err = e
+	}}).Data))
+	if n != 1 || !errors.Is(err, csv.ErrQuote) {
+		t.Fatal("OnError:", n, err)
+	}
+}
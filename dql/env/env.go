@@ -0,0 +1,131 @@
+/*
+ * Copyright (c) 2026 The XGo Authors (xgo.dev). All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package env
+
+import (
+	"flag"
+	"iter"
+	"os"
+	"strings"
+
+	"github.com/goplus/xgo/dql/maps"
+)
+
+const (
+	XGoPackage = "github.com/goplus/xgo/dql/maps"
+)
+
+// -----------------------------------------------------------------------------
+
+// Node represents the variables (or flags), whose value is a map[string]any
+// from the names to the values.
+type Node = maps.Node
+
+// NodeSet represents a set of nodes.
+type NodeSet = maps.NodeSet
+
+// Config represents the configuration of reading variables.
+type Config struct {
+	// Prefix, if not empty, selects the variables whose names have the
+	// prefix, and the prefix is trimmed from the names. For example, APP_PORT
+	// is PORT if Prefix is "APP_".
+	Prefix string
+}
+
+// New returns a NodeSet containing a node of the environment variables of
+// the process. The variables are attributes (and children) of the node, and
+// the values can be converted by the typed accessors:
+//
+//	vars := env.new(env.Config{Prefix: "APP_"})
+//	addr := vars.$ADDR
+//	port := vars.PORT.int!
+func New(conf ...Config) NodeSet {
+	return Vars(os.Environ(), conf...)
+}
+
+// Vars returns a NodeSet containing a node of the variables, each in the
+// form "name=value" (see os.Environ). If a name occurs multiple times, the
+// last value wins.
+func Vars(environ []string, conf ...Config) NodeSet {
+	var prefix string
+	if conf != nil {
+		prefix = conf[0].Prefix
+	}
+	vars := make(map[string]any, len(environ))
+	for _, kv := range environ {
+		name, val, ok := strings.Cut(kv, "=")
+		if !ok {
+			continue
+		}
+		if name, ok = strings.CutPrefix(name, prefix); ok && name != "" {
+			vars[name] = val
+		}
+	}
+	return maps.New(vars)
+}
+
+// Flags returns a NodeSet containing a node of the flags of fs, or of
+// flag.CommandLine if fs is nil. Flags that aren't set have their default
+// values. Values of the standard flag types (bool, int, duration, etc.) are
+// of their Go types, and others are strings.
+func Flags(fs *flag.FlagSet) NodeSet {
+	if fs == nil {
+		fs = flag.CommandLine
+	}
+	flags := make(map[string]any)
+	fs.VisitAll(func(f *flag.Flag) {
+		if g, ok := f.Value.(flag.Getter); ok {
+			flags[f.Name] = g.Get()
+		} else {
+			flags[f.Name] = f.Value.String()
+		}
+	})
+	return maps.New(flags)
+}
+
+// Source creates a NodeSet from various source types:
+// - []string: variables in the form "name=value" (see Vars).
+// - map[string]string: variables.
+// - *flag.FlagSet: flags of the flag set (see Flags).
+// - Node: creates a NodeSet containing the single provided node.
+// - iter.Seq[Node]: directly uses the provided sequence of nodes.
+// - NodeSet: returns the provided NodeSet as is.
+// If the source type is unsupported, it panics.
+func Source(r any, conf ...Config) (ret NodeSet) {
+	switch v := r.(type) {
+	case []string:
+		return Vars(v, conf...)
+	case map[string]string:
+		environ := make([]string, 0, len(v))
+		for name, val := range v {
+			environ = append(environ, name+"="+val)
+		}
+		return Vars(environ, conf...)
+	case *flag.FlagSet:
+		return Flags(v)
+	case Node:
+		return maps.Root(v)
+	case iter.Seq[Node]:
+		return NodeSet{Data: v}
+	case NodeSet:
+		return v
+	default:
+		panic("dql/env.Source: unsupported source type")
+	}
+}
+
+// -----------------------------------------------------------------------------
@@ -0,0 +1,69 @@
+/*
+ * Copyright (c) 2026 The XGo Authors (xgo.dev). All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package env
+
+import (
+	"flag"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestVars(t *testing.T) {
+	t.Setenv("XGO_ENV_TEST_PORT", "8080")
+	t.Setenv("XGO_ENV_TEST_DEBUG", "true")
+	doc := New(Config{Prefix: "XGO_ENV_TEST_"})
+	if port, err := doc.XGo_Elem("PORT").Int(); err != nil || port != 8080 {
+		t.Fatal("PORT:", port, err)
+	}
+	if debug, err := doc.XGo_Elem("DEBUG").Bool(); err != nil || !debug {
+		t.Fatal("DEBUG:", debug, err)
+	}
+	if v := doc.XGo_Attr__0("HOME"); v != nil {
+		t.Fatal("HOME:", v)
+	}
+	if _, err := doc.XGo_Elem("XGO_ENV_TEST_PORT").Str(); err == nil {
+		t.Fatal("prefix not trimmed")
+	}
+
+	doc = Source([]string{"A=1", "B=x=y", "A=2", "=C:", "invalid"})
+	first, _ := doc.XGo_first()
+	if !reflect.DeepEqual(first.Value, map[string]any{"A": "2", "B": "x=y"}) {
+		t.Fatal("Source:", first.Value)
+	}
+	doc = Source(map[string]string{"APP_A": "1", "B": "2"}, Config{Prefix: "APP_"})
+	first, _ = doc.XGo_first()
+	if !reflect.DeepEqual(first.Value, map[string]any{"A": "1"}) {
+		t.Fatal("Source map:", first.Value)
+	}
+}
+
+func TestFlags(t *testing.T) {
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	fs.Int("n", 1, "")
+	fs.Bool("v", false, "")
+	fs.Duration("timeout", time.Second, "")
+	fs.Func("tag", "", func(string) error { return nil })
+	if err := fs.Parse([]string{"-n", "3", "-v"}); err != nil {
+		t.Fatal(err)
+	}
+	first, _ := Source(fs).XGo_first()
+	want := map[string]any{"n": 3, "v": true, "timeout": time.Second, "tag": ""}
+	if !reflect.DeepEqual(first.Value, want) {
+		t.Fatal("Flags:", first.Value)
+	}
+}
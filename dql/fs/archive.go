@@ -0,0 +1,265 @@
+/*
+ * Copyright (c) 2026 The XGo Authors (xgo.dev). All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package fs
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bytes"
+	"compress/gzip"
+	"errors"
+	"io"
+	"io/fs"
+	"path"
+	"path/filepath"
+	"slices"
+	"strings"
+)
+
+// -----------------------------------------------------------------------------
+
+// ErrUnknownArchive is returned by Archive if the file isn't a supported
+// archive.
+var ErrUnknownArchive = errors.New("unknown archive format")
+
+// Archive returns a NodeSet over the contents of the first node in the
+// NodeSet, which must be a .zip, .tar, .tar.gz or .tgz file. The returned
+// NodeSet contains the root node of the archive, so it can be queried like
+// a directory:
+//
+//	for e in fs`.`.**.file.ext(".zip").archive.**.file {
+//		echo e.path
+//	}
+//
+// A zip file is read on demand if the file system supports random access,
+// while a tar file is read into memory.
+func (p NodeSet) Archive() NodeSet {
+	node, err := p.First()
+	if err != nil {
+		return NodeSet{Err: err}
+	}
+	name, err := node.Name()
+	if err != nil {
+		return NodeSet{Err: err}
+	}
+	fsys, err := openArchive(p.Base, node.Path, strings.ToLower(name))
+	if err != nil {
+		return NodeSet{Err: &fs.PathError{Op: "archive", Path: node.Path, Err: err}}
+	}
+	ret := New(fsys)
+	ret.opts = p.opts
+	return ret
+}
+
+// Archive returns a NodeSet over the contents of the archive file with the
+// specified path.
+func Archive(file string) NodeSet {
+	base := DirFS(filepath.Dir(file))
+	fsys, err := openArchive(base, filepath.Base(file), strings.ToLower(file))
+	if err != nil {
+		return NodeSet{Err: &fs.PathError{Op: "archive", Path: file, Err: err}}
+	}
+	return New(fsys)
+}
+
+func openArchive(base fs.FS, name, lname string) (fs.FS, error) {
+	switch {
+	case strings.HasSuffix(lname, ".zip"):
+		return openZip(base, name)
+	case strings.HasSuffix(lname, ".tar"):
+		return openTar(base, name, false)
+	case strings.HasSuffix(lname, ".tar.gz"), strings.HasSuffix(lname, ".tgz"):
+		return openTar(base, name, true)
+	}
+	return nil, ErrUnknownArchive
+}
+
+func openZip(base fs.FS, name string) (fs.FS, error) {
+	f, err := base.Open(name)
+	if err != nil {
+		return nil, err
+	}
+	fi, err := f.Stat()
+	if err != nil {
+		f.Close()
+		return nil, err
+	}
+	if ra, ok := f.(io.ReaderAt); ok {
+		// The file is kept open as long as the archive is in use.
+		return zip.NewReader(ra, fi.Size())
+	}
+	b, err := io.ReadAll(f)
+	f.Close()
+	if err != nil {
+		return nil, err
+	}
+	return zip.NewReader(bytes.NewReader(b), int64(len(b)))
+}
+
+func openTar(base fs.FS, name string, gz bool) (fs.FS, error) {
+	f, err := base.Open(name)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var r io.Reader = f
+	if gz {
+		zr, err := gzip.NewReader(f)
+		if err != nil {
+			return nil, err
+		}
+		defer zr.Close()
+		r = zr
+	}
+	ret := tarFS{".": &tarFile{hdr: &tar.Header{Name: ".", Typeflag: tar.TypeDir, Mode: 0o755}}}
+	tr := tar.NewReader(r)
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		name := path.Clean(strings.TrimPrefix(hdr.Name, "/"))
+		if name == "." || !fs.ValidPath(name) {
+			continue
+		}
+		file := &tarFile{hdr: hdr}
+		if hdr.Typeflag == tar.TypeReg {
+			if file.data, err = io.ReadAll(tr); err != nil {
+				return nil, err
+			}
+		}
+		ret.add(name, file)
+	}
+	return ret, nil
+}
+
+// -----------------------------------------------------------------------------
+
+// tarFS is an in-memory file system holding the entries of a tar archive.
+type tarFS map[string]*tarFile
+
+type tarFile struct {
+	hdr      *tar.Header
+	data     []byte
+	children []string // names of the children (if it's a directory)
+}
+
+// add adds the file and its missing parent directories.
+func (p tarFS) add(name string, file *tarFile) {
+	if old, ok := p[name]; ok {
+		file.children = old.children
+		p[name] = file
+		return
+	}
+	p[name] = file
+	dir, elem := path.Split(name)
+	dir = path.Clean(dir)
+	parent, ok := p[dir]
+	if !ok {
+		parent = &tarFile{hdr: &tar.Header{Name: dir, Typeflag: tar.TypeDir, Mode: 0o755}}
+		p.add(dir, parent)
+	}
+	parent.children = append(parent.children, elem)
+}
+
+func (p tarFS) Open(name string) (fs.File, error) {
+	if !fs.ValidPath(name) {
+		return nil, &fs.PathError{Op: "open", Path: name, Err: fs.ErrInvalid}
+	}
+	file, ok := p[name]
+	if !ok {
+		return nil, &fs.PathError{Op: "open", Path: name, Err: fs.ErrNotExist}
+	}
+	return &openTarFile{fs: p, name: name, file: file, r: bytes.NewReader(file.data)}, nil
+}
+
+func (p tarFS) ReadDir(name string) ([]fs.DirEntry, error) {
+	file, ok := p[name]
+	if !ok {
+		return nil, &fs.PathError{Op: "readdir", Path: name, Err: fs.ErrNotExist}
+	}
+	if !file.hdr.FileInfo().IsDir() {
+		return nil, &fs.PathError{Op: "readdir", Path: name, Err: errors.New("not a directory")}
+	}
+	children := slices.Clone(file.children)
+	slices.Sort(children)
+	ret := make([]fs.DirEntry, len(children))
+	for i, elem := range children {
+		ret[i] = fs.FileInfoToDirEntry(p.stat(path.Join(name, elem)))
+	}
+	return ret, nil
+}
+
+func (p tarFS) stat(name string) fs.FileInfo {
+	return tarFileInfo{p[name].hdr.FileInfo(), path.Base(name)}
+}
+
+// tarFileInfo overrides the name of the file info since intermediate
+// directories don't have a header in the archive.
+type tarFileInfo struct {
+	fs.FileInfo
+	name string
+}
+
+func (p tarFileInfo) Name() string {
+	return p.name
+}
+
+type openTarFile struct {
+	fs   tarFS
+	name string
+	file *tarFile
+	r    *bytes.Reader
+	off  int // offset of ReadDir
+}
+
+func (f *openTarFile) Stat() (fs.FileInfo, error) {
+	return f.fs.stat(f.name), nil
+}
+
+func (f *openTarFile) Read(b []byte) (int, error) {
+	if f.file.hdr.FileInfo().IsDir() {
+		return 0, &fs.PathError{Op: "read", Path: f.name, Err: errors.New("is a directory")}
+	}
+	return f.r.Read(b)
+}
+
+func (f *openTarFile) ReadDir(n int) ([]fs.DirEntry, error) {
+	entries, err := f.fs.ReadDir(f.name)
+	if err != nil {
+		return nil, err
+	}
+	entries = entries[f.off:]
+	if n > 0 {
+		if len(entries) == 0 {
+			return nil, io.EOF
+		}
+		entries = entries[:min(n, len(entries))]
+	}
+	f.off += len(entries)
+	return entries, nil
+}
+
+func (f *openTarFile) Close() error {
+	return nil
+}
+
+// -----------------------------------------------------------------------------
@@ -20,7 +20,6 @@ import (
 	"errors"
 	"io/fs"
 	"iter"
-	"os"
 	"path"
 	"time"
 
@@ -36,9 +35,11 @@ type Node struct {
 	Path string
 
 	// directory entry for the file or directory.
-	de  fs.DirEntry
-	fi  fs.FileInfo
-	err error
+	de   fs.DirEntry
+	fi   fs.FileInfo
+	err  error
+	op   Op   // file operations reported by Watch
+	link bool // the node is a symbolic link resolved to its target
 }
 
 // Name returns the name of the file (or subdirectory) described by the entry.
@@ -105,6 +106,22 @@ func (p *Node) ModTime() (time.Time, error) {
 	return fi.ModTime(), nil
 }
 
+// IsSymlink reports whether the entry is a symbolic link. If the NodeSet
+// follows symbolic links, the other methods of the node describe the target
+// of the link.
+func (p *Node) IsSymlink() (bool, error) {
+	if p.err != nil {
+		return false, p.err
+	}
+	return p.link || p.de.Type()&fs.ModeSymlink != 0, nil
+}
+
+// Op returns the file operations of the node reported by Watch. It returns 0
+// if the node isn't produced by Watch.
+func (p *Node) Op() Op {
+	return p.op
+}
+
 // Sys returns the underlying data source (can return nil).
 func (p *Node) Sys() (any, error) {
 	fi, err := p.info()
@@ -122,6 +139,7 @@ type NodeSet struct {
 	Data iter.Seq[*Node]
 	Base fs.FS
 	Err  error
+	opts options
 }
 
 // Root creates a NodeSet containing the provided root node.
@@ -150,7 +168,7 @@ func Nodes(root fs.FS, nodes ...*Node) NodeSet {
 
 // Dir returns a NodeSet for the specified directory.
 func Dir(dir string) NodeSet {
-	return New(os.DirFS(dir))
+	return New(DirFS(dir))
 }
 
 // New creates a NodeSet for the provided file system, starting with
@@ -216,7 +234,9 @@ func (p NodeSet) XGo_Enum() iter.Seq[NodeSet] {
 	}
 	return func(yield func(NodeSet) bool) {
 		p.Data(func(node *Node) bool {
-			return yield(Root(p.Base, node))
+			ret := Root(p.Base, node)
+			ret.opts = p.opts
+			return yield(ret)
 		})
 	}
 }
@@ -229,9 +249,10 @@ func (p NodeSet) Dir() NodeSet {
 	}
 	return NodeSet{
 		Base: p.Base,
+		opts: p.opts,
 		Data: func(yield func(*Node) bool) {
 			p.Data(func(node *Node) bool {
-				return yieldChildNodes(p.Base, node, filterDir, yield)
+				return yieldChildNodes(p.Base, &p.opts, node, filterDir, yield)
 			})
 		},
 	}
@@ -245,9 +266,10 @@ func (p NodeSet) File() NodeSet {
 	}
 	return NodeSet{
 		Base: p.Base,
+		opts: p.opts,
 		Data: func(yield func(*Node) bool) {
 			p.Data(func(node *Node) bool {
-				return yieldChildNodes(p.Base, node, filterFile, yield)
+				return yieldChildNodes(p.Base, &p.opts, node, filterFile, yield)
 			})
 		},
 	}
@@ -260,9 +282,10 @@ func (p NodeSet) XGo_Child() NodeSet {
 	}
 	return NodeSet{
 		Base: p.Base,
+		opts: p.opts,
 		Data: func(yield func(*Node) bool) {
 			p.Data(func(node *Node) bool {
-				return yieldChildNodes(p.Base, node, nil, yield)
+				return yieldChildNodes(p.Base, &p.opts, node, nil, yield)
 			})
 		},
 	}
@@ -279,32 +302,25 @@ func filterFile(de fs.DirEntry) bool {
 }
 
 // yieldChildNodes yields all child nodes of the given node.
-func yieldChildNodes(base fs.FS, node *Node, filter filterType, yield func(*Node) bool) bool {
+func yieldChildNodes(base fs.FS, opts *options, node *Node, filter filterType, yield func(*Node) bool) bool {
 	var items []fs.DirEntry
-	var path = node.Path
 	isDir, err := node.IsDir()
 	if err == nil {
 		if !isDir {
 			return true
 		}
-		dir := path
-		if dir == "" {
-			// fs.ReadDir does not accept an empty string as the directory, use "."
-			// instead to read the root directory.
-			dir = "."
-		}
-		items, err = fs.ReadDir(base, dir)
+		items, err = readDir(base, node.Path)
 	}
 	if err != nil {
-		return yield(&Node{Path: path, err: err}) // yield the error as a node
-	}
-	if path != "" {
-		path += "/"
+		return yield(&Node{Path: node.Path, err: err}) // yield the error as a node
 	}
 	for _, item := range items {
-		if filter == nil || filter(item) {
-			childPath := path + item.Name()
-			if !yield(&Node{Path: childPath, de: item}) {
+		child := &Node{Path: childPath(node.Path, item.Name()), de: item}
+		if !opts.resolve(base, child) {
+			continue
+		}
+		if filter == nil || filter(child.de) {
+			if !yield(child) {
 				return false
 			}
 		}
@@ -342,9 +358,10 @@ func (p NodeSet) XGo_Any(name string) NodeSet {
 	}
 	return NodeSet{
 		Base: p.Base,
+		opts: p.opts,
 		Data: func(yield func(*Node) bool) {
 			p.Data(func(node *Node) bool {
-				return yieldAnyNodes(kind, p.Base, node, yield)
+				return yieldAnyNodes(kind, p.Base, &p.opts, node, yield)
 			})
 		},
 	}
@@ -352,31 +369,34 @@ func (p NodeSet) XGo_Any(name string) NodeSet {
 
 // yieldAnyNodes yields all descendant nodes of the given node that match the
 // specified kind. If kind is kindAny, it yields all nodes.
-func yieldAnyNodes(kind int, base fs.FS, node *Node, yield func(*Node) bool) bool {
+func yieldAnyNodes(kind int, base fs.FS, opts *options, node *Node, yield func(*Node) bool) bool {
+	isDir, ok := yieldKind(kind, node, yield)
+	if ok && isDir {
+		return opts.walk(base, node, func(child *Node) (bool, bool) {
+			return yieldKind(kind, child, yield)
+		})
+	}
+	return ok
+}
+
+// yieldKind yields the node if it matches the specified kind. It returns
+// whether the node is a directory and whether to continue.
+func yieldKind(kind int, node *Node, yield func(*Node) bool) (isDir, ok bool) {
 	isDir, err := node.IsDir()
 	if err != nil {
-		return yield(&Node{Path: node.Path, err: err}) // yield the error as a node
+		return false, yield(&Node{Path: node.Path, err: err}) // yield the error as a node
 	}
 	switch kind {
 	case kindFile:
 		if isDir {
-			goto checkChildren
+			return true, true
 		}
 	case kindDir:
 		if !isDir {
-			return true
+			return false, true
 		}
 	}
-	if !yield(node) {
-		return false
-	}
-checkChildren:
-	if isDir {
-		return yieldChildNodes(base, node, nil, func(n *Node) bool {
-			return yieldAnyNodes(kind, base, n, yield)
-		})
-	}
-	return true
+	return isDir, yield(node)
 }
 
 // -----------------------------------------------------------------------------
@@ -393,6 +413,7 @@ func (p NodeSet) Match(pattern string) NodeSet {
 	}
 	return NodeSet{
 		Base: p.Base,
+		opts: p.opts,
 		Data: func(yield func(*Node) bool) {
 			p.Data(func(node *Node) bool {
 				if name, err := node.Name(); err == nil {
@@ -408,6 +429,80 @@ func (p NodeSet) Match(pattern string) NodeSet {
 	}
 }
 
+// Ext returns a NodeSet containing the nodes in the NodeSet whose names have
+// one of the specified extensions, e.g. Ext(".go", ".xgo").
+func (p NodeSet) Ext(exts ...string) NodeSet {
+	return p.filter(func(node *Node) (bool, error) {
+		name, err := node.Name()
+		if err != nil {
+			return false, err
+		}
+		ext := path.Ext(name)
+		for _, v := range exts {
+			if ext == v {
+				return true, nil
+			}
+		}
+		return false, nil
+	})
+}
+
+// SizeGreater returns a NodeSet containing the nodes in the NodeSet whose
+// sizes are greater than n bytes.
+func (p NodeSet) SizeGreater(n int64) NodeSet {
+	return p.filter(func(node *Node) (bool, error) {
+		size, err := node.Size()
+		return size > n, err
+	})
+}
+
+// ModifiedAfter returns a NodeSet containing the nodes in the NodeSet whose
+// modification times are after t.
+func (p NodeSet) ModifiedAfter(t time.Time) NodeSet {
+	return p.filter(func(node *Node) (bool, error) {
+		modTime, err := node.ModTime()
+		return modTime.After(t), err
+	})
+}
+
+// ModeMatches returns a NodeSet containing the nodes in the NodeSet whose file
+// modes have all the bits of perm set, e.g. ModeMatches(0o111) selects the
+// files executable by everyone, ModeMatches(fs.ModeSymlink) selects symbolic
+// links.
+func (p NodeSet) ModeMatches(perm fs.FileMode) NodeSet {
+	return p.filter(func(node *Node) (bool, error) {
+		mode, err := node.Mode()
+		return mode&perm == perm, err
+	})
+}
+
+// filter returns a NodeSet containing the nodes in the NodeSet for which fn
+// returns true. If fn returns an error, the error is yielded as a node.
+func (p NodeSet) filter(fn func(node *Node) (bool, error)) NodeSet {
+	if p.Err != nil {
+		return NodeSet{Err: p.Err}
+	}
+	return NodeSet{
+		Base: p.Base,
+		opts: p.opts,
+		Data: func(yield func(*Node) bool) {
+			p.Data(func(node *Node) bool {
+				if node.err != nil {
+					return yield(node)
+				}
+				ok, err := fn(node)
+				if err != nil {
+					return yield(&Node{Path: node.Path, err: err}) // yield the error as a node
+				}
+				if ok {
+					return yield(node)
+				}
+				return true
+			})
+		},
+	}
+}
+
 // OnError calls onErr for any error in the NodeSet and returns a new NodeSet without
 // the nodes that have errors. If onErr returns false, it stops processing and returns
 // a NodeSet without the remaining nodes.
@@ -418,6 +513,7 @@ func (p NodeSet) OnError(onErr func(error) bool) NodeSet {
 	}
 	return NodeSet{
 		Base: p.Base,
+		opts: p.opts,
 		Data: func(yield func(*Node) bool) {
 			p.Data(func(node *Node) bool {
 				if node.err != nil {
@@ -439,7 +535,9 @@ func (p NodeSet) All() NodeSet {
 		return NodeSet{Err: p.Err}
 	}
 	nodes := dql.Collect(p.Data)
-	return Nodes(p.Base, nodes...)
+	ret := Nodes(p.Base, nodes...)
+	ret.opts = p.opts
+	return ret
 }
 
 // One returns a NodeSet containing the first node.
@@ -452,7 +550,9 @@ func (p NodeSet) One() NodeSet {
 	if err != nil {
 		return NodeSet{Err: err}
 	}
-	return Root(p.Base, n)
+	ret := Root(p.Base, n)
+	ret.opts = p.opts
+	return ret
 }
 
 // Single returns a NodeSet containing the single node.
@@ -466,7 +566,9 @@ func (p NodeSet) Single() NodeSet {
 	if err != nil {
 		return NodeSet{Err: err}
 	}
-	return Root(p.Base, n)
+	ret := Root(p.Base, n)
+	ret.opts = p.opts
+	return ret
 }
 
 // -----------------------------------------------------------------------------
@@ -564,4 +666,47 @@ func (p NodeSet) ModTime() (modTime time.Time, err error) {
 	return node.ModTime()
 }
 
+// IsSymlink reports whether the first node in the NodeSet is a symbolic link.
+func (p NodeSet) IsSymlink() (is bool, err error) {
+	node, err := p.First()
+	if err != nil {
+		return
+	}
+	return node.IsSymlink()
+}
+
+// Readlink returns the destination of the first node in the NodeSet, which
+// must be a symbolic link. The file system of the NodeSet must implement
+// the ReadLink method (like DirFS), otherwise errors.ErrUnsupported is
+// returned.
+func (p NodeSet) Readlink() (dest string, err error) {
+	node, err := p.First()
+	if err != nil {
+		return
+	}
+	if node.err != nil {
+		return "", node.err
+	}
+	if fsys, ok := p.Base.(readLinkFS); ok {
+		return fsys.ReadLink(node.Path)
+	}
+	return "", &fs.PathError{Op: "readlink", Path: node.Path, Err: errors.ErrUnsupported}
+}
+
+// readLinkFS is the interface implemented by a file system that supports
+// reading symbolic links (same as fs.ReadLinkFS of Go 1.25).
+type readLinkFS interface {
+	ReadLink(name string) (string, error)
+}
+
+// Op returns the file operations of the first node in the NodeSet reported by
+// Watch.
+func (p NodeSet) Op() (op Op, err error) {
+	node, err := p.First()
+	if err != nil {
+		return
+	}
+	return node.op, node.err
+}
+
 // -----------------------------------------------------------------------------
@@ -0,0 +1,385 @@
+/*
+ * Copyright (c) 2026 The XGo Authors (xgo.dev). All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package fs
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bytes"
+	"compress/gzip"
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"testing"
+	"testing/fstest"
+	"time"
+
+	"github.com/goplus/xgo/dql"
+)
+
+var testFS = fstest.MapFS{
+	"go.mod":                 {Data: []byte("module example\n")},
+	"main.xgo":               {Data: []byte("echo \"hello\"\n")},
+	"src/a.go":               {Data: []byte("package src\n\nfunc A() {}\n")},
+	"src/a_test.go":          {Data: []byte("package src\n")},
+	"src/x/y/b.go":           {Data: []byte("package y\n\n// TODO: b\nfunc B() {}\n")},
+	"src/x/y/c.xgo":          {Data: []byte("echo \"c\"\n")},
+	"vendor/v/v.go":          {Data: []byte("package v\n")},
+	"docs/README.md":         {Data: []byte("# TODO\n")},
+	"docs/img/logo.png":      {Data: []byte{0x89, 'P', 'N', 'G'}},
+	"docs/img/.keep":         {},
+	"src/x/y/z/testdata.txt": {Data: []byte("todo\n")},
+}
+
+func paths(t *testing.T, ns NodeSet) string {
+	t.Helper()
+	nodes, err := ns.Collect()
+	if err != nil {
+		t.Fatal(err)
+	}
+	ret := make([]string, 0, len(nodes))
+	for _, node := range nodes {
+		if node.err != nil {
+			t.Fatal(node.err)
+		}
+		ret = append(ret, node.Path)
+	}
+	sort.Strings(ret)
+	return strings.Join(ret, ",")
+}
+
+func TestGlob(t *testing.T) {
+	root := New(testFS)
+	tests := []struct {
+		patterns []string
+		want     string
+	}{
+		{[]string{"src/**/*.go"}, "src/a.go,src/a_test.go,src/x/y/b.go"},
+		{[]string{"**/*.{go,xgo}", "!**/*_test.go", "!vendor/**"}, "main.xgo,src/a.go,src/x/y/b.go,src/x/y/c.xgo"},
+		{[]string{"*.xgo"}, "main.xgo"},
+		{[]string{"{docs,src/x}/*"}, "docs/README.md,docs/img,src/x/y"},
+		{[]string{"docs/**"}, "docs,docs/README.md,docs/img,docs/img/.keep,docs/img/logo.png"},
+		{[]string{"!**/*.go", "!{docs,src}/**"}, "go.mod,main.xgo,vendor,vendor/v"},
+	}
+	for _, tt := range tests {
+		if got := paths(t, root.Glob(tt.patterns...)); got != tt.want {
+			t.Errorf("Glob(%v): got %s, want %s", tt.patterns, got, tt.want)
+		}
+	}
+	src := root.XGo_Child().Match("src")
+	if got := paths(t, src.Glob("x/**/*.go")); got != "src/x/y/b.go" {
+		t.Error("Glob from src:", got)
+	}
+	for _, pattern := range []string{"{a,b", "a}", "[a"} {
+		if root.Glob(pattern).Err == nil {
+			t.Errorf("Glob(%q): expected error", pattern)
+		}
+	}
+}
+
+func TestGrep(t *testing.T) {
+	root := New(testFS)
+	var got []string
+	for m := range root.XGo_Any("file").Grep("TODO") {
+		if m.Err != nil {
+			t.Fatal(m.Err)
+		}
+		got = append(got, m.Node.Path+":"+strconv.Itoa(m.LineNo)+":"+m.Line)
+	}
+	sort.Strings(got)
+	if v := strings.Join(got, "|"); v != "docs/README.md:1:# TODO|src/x/y/b.go:3:// TODO: b" {
+		t.Fatal("Grep:", v)
+	}
+
+	n := 0
+	for m := range root.Glob("**/*.go").GrepRegex(regexp.MustCompile(`^func \w+\(`)) {
+		if m.Err != nil || !strings.HasPrefix(m.Line, "func ") {
+			t.Fatal("GrepRegex:", m)
+		}
+		n++
+	}
+	if n != 2 {
+		t.Fatal("GrepRegex:", n)
+	}
+
+	for m := range root.Glob("[").Grep("x") {
+		if m.Err == nil {
+			t.Fatal("Grep: expected error")
+		}
+	}
+}
+
+func TestWatch(t *testing.T) {
+	dir := t.TempDir()
+	done := make(chan string)
+	go func() {
+		for node := range Watch(dir).Match("*.txt").Data {
+			if node.err != nil {
+				done <- "error: " + node.err.Error()
+				return
+			}
+			if node.Op()&Create != 0 {
+				done <- node.Path
+				return
+			}
+		}
+	}()
+	deadline := time.After(5 * time.Second)
+	for i := 0; ; i++ {
+		// The watcher may not be ready yet, so keep creating files.
+		os.WriteFile(filepath.Join(dir, "a"+strconv.Itoa(i)+".txt"), nil, 0644)
+		select {
+		case v := <-done:
+			if !strings.HasPrefix(v, "a") {
+				t.Fatal("Watch:", v)
+			}
+			return
+		case <-deadline:
+			t.Fatal("Watch: timeout")
+		case <-time.After(50 * time.Millisecond):
+		}
+	}
+}
+
+func TestParallel(t *testing.T) {
+	root := New(testFS)
+	for _, name := range []string{"", "file", "dir"} {
+		want := paths(t, root.XGo_Any(name))
+		if got := paths(t, root.Parallel(4).XGo_Any(name)); got != want {
+			t.Errorf("XGo_Any(%q): got %s, want %s", name, got, want)
+		}
+	}
+	want := paths(t, root.Glob("**/*.go", "!vendor/**"))
+	if got := paths(t, root.Parallel(0).One().Glob("**/*.go", "!vendor/**")); got != want {
+		t.Errorf("Glob: got %s, want %s", got, want)
+	}
+	if _, err := dql.First(root.Parallel(2).XGo_Any("file").Data); err != nil {
+		t.Fatal("First:", err)
+	}
+}
+
+func TestFilters(t *testing.T) {
+	now := time.Now()
+	memFS := fstest.MapFS{
+		"big.bin":   {Data: make([]byte, 1024), ModTime: now},
+		"small.go":  {Data: []byte("package a\n"), ModTime: now.Add(-48 * time.Hour)},
+		"run.sh":    {Data: []byte("#!/bin/sh\n"), Mode: 0o755, ModTime: now},
+		"lib/b.xgo": {Data: []byte("echo 1\n"), Mode: 0o644, ModTime: now},
+	}
+	files := New(memFS).XGo_Any("file")
+	tests := []struct {
+		name string
+		ns   NodeSet
+		want string
+	}{
+		{"Ext", files.Ext(".go", ".xgo"), "lib/b.xgo,small.go"},
+		{"SizeGreater", files.SizeGreater(100), "big.bin"},
+		{"ModifiedAfter", files.ModifiedAfter(now.Add(-time.Hour)), "big.bin,lib/b.xgo,run.sh"},
+		{"ModeMatches", files.ModeMatches(0o111), "run.sh"},
+		{"chain", files.ModifiedAfter(now.Add(-time.Hour)).SizeGreater(8).Ext(".sh"), "run.sh"},
+	}
+	for _, tt := range tests {
+		if got := paths(t, tt.ns); got != tt.want {
+			t.Errorf("%s: got %s, want %s", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestArchive(t *testing.T) {
+	var zbuf bytes.Buffer
+	zw := zip.NewWriter(&zbuf)
+	for _, name := range []string{"a.txt", "dir/b.txt", "dir/sub/c.go"} {
+		w, _ := zw.Create(name)
+		w.Write([]byte(name))
+	}
+	zw.Close()
+
+	var tbuf bytes.Buffer
+	gw := gzip.NewWriter(&tbuf)
+	tw := tar.NewWriter(gw)
+	for _, name := range []string{"x/y.txt", "x/z/w.go", "top.md"} {
+		tw.WriteHeader(&tar.Header{Name: name, Mode: 0o644, Size: int64(len(name)), Typeflag: tar.TypeReg})
+		tw.Write([]byte(name))
+	}
+	tw.Close()
+	gw.Close()
+
+	root := New(fstest.MapFS{
+		"pkg/a.zip":    {Data: zbuf.Bytes()},
+		"pkg/b.tar.gz": {Data: tbuf.Bytes()},
+		"pkg/c.txt":    {Data: []byte("c")},
+	})
+	zipNS := root.Glob("**/*.zip").Archive()
+	if got := paths(t, zipNS.XGo_Any("")); got != ",a.txt,dir,dir/b.txt,dir/sub,dir/sub/c.go" {
+		t.Error("zip:", got)
+	}
+	tarNS := root.Glob("**/*.tar.gz").Archive()
+	if got := paths(t, tarNS.XGo_Any("file")); got != "top.md,x/y.txt,x/z/w.go" {
+		t.Error("tar.gz:", got)
+	}
+	var lines []string
+	for m := range tarNS.Glob("**/*.go").Grep("w") {
+		lines = append(lines, m.Line)
+	}
+	if strings.Join(lines, ",") != "x/z/w.go" {
+		t.Error("tar.gz grep:", lines)
+	}
+	if err := root.Glob("**/*.txt").Archive().Err; !errors.Is(err, ErrUnknownArchive) {
+		t.Error("Archive:", err)
+	}
+
+	dir := t.TempDir()
+	os.WriteFile(filepath.Join(dir, "a.zip"), zbuf.Bytes(), 0644)
+	if got := paths(t, Archive(filepath.Join(dir, "a.zip")).Glob("dir/*")); got != "dir/b.txt,dir/sub" {
+		t.Error("Archive:", got)
+	}
+}
+
+func TestWrite(t *testing.T) {
+	dir := t.TempDir()
+	src := filepath.Join(dir, "src")
+	os.MkdirAll(filepath.Join(src, "sub"), 0755)
+	os.WriteFile(filepath.Join(src, "a.go"), []byte("a"), 0644)
+	os.WriteFile(filepath.Join(src, "b.txt"), []byte("b"), 0644)
+	os.WriteFile(filepath.Join(src, "sub", "c.go"), []byte("c"), 0644)
+
+	root := Dir(src)
+	var log bytes.Buffer
+	if err := root.Glob("**/*.go").DryRun(&log).Delete(); err != nil {
+		t.Fatal("DryRun:", err)
+	}
+	if log.String() != "rm "+filepath.Join(src, "a.go")+"\nrm "+filepath.Join(src, "sub", "c.go")+"\n" {
+		t.Fatal("DryRun:", log.String())
+	}
+
+	dst := filepath.Join(dir, "dst")
+	if err := root.XGo_Child().Copy(dst); err != nil {
+		t.Fatal("Copy:", err)
+	}
+	if got := paths(t, Dir(dst).XGo_Any("file")); got != "a.go,b.txt,sub/c.go" {
+		t.Fatal("Copy:", got)
+	}
+	if err := root.Glob("*.txt").Chmod(0600); err != nil {
+		t.Fatal("Chmod:", err)
+	}
+	if fi, _ := os.Stat(filepath.Join(src, "b.txt")); fi.Mode().Perm() != 0600 {
+		t.Fatal("Chmod:", fi.Mode())
+	}
+	if err := root.Glob("*.txt").Move(filepath.Join(dir, "moved")); err != nil {
+		t.Fatal("Move:", err)
+	}
+	if err := Dir(dst).Glob("**/*.go").Delete(); err != nil {
+		t.Fatal("Delete:", err)
+	}
+	if got := paths(t, Dir(dir).XGo_Any("file")); got != "dst/b.txt,moved/b.txt,src/a.go,src/sub/c.go" {
+		t.Fatal("after write:", got)
+	}
+
+	if err := New(testFS).Glob("*.xgo").Delete(); err != ErrReadOnly {
+		t.Fatal("Delete:", err)
+	}
+	err := Dir(dir).XGo_Child().Match("nope").XGo_Child().Chmod(0600)
+	if err != nil {
+		t.Fatal("Chmod empty:", err)
+	}
+	err = Nodes(DirFS(dir), &Node{Path: "x", de: goneEntry("x")}, &Node{Path: "y", de: goneEntry("y")}).Chmod(0600)
+	if err == nil || !errors.Is(err, fs.ErrNotExist) || strings.Count(err.Error(), "\n") != 1 {
+		t.Fatal("Chmod errors:", err)
+	}
+}
+
+func TestSymlinks(t *testing.T) {
+	dir := t.TempDir()
+	os.MkdirAll(filepath.Join(dir, "a", "b"), 0755)
+	os.WriteFile(filepath.Join(dir, "a", "b", "f.txt"), nil, 0644)
+	if err := os.Symlink("..", filepath.Join(dir, "a", "b", "up")); err != nil {
+		t.Skip("symlink:", err)
+	}
+	os.Symlink("a/b/f.txt", filepath.Join(dir, "ln.txt"))
+	os.Symlink("nowhere", filepath.Join(dir, "dangling"))
+
+	root := Dir(dir)
+	if got := paths(t, root.XGo_Any("")); got != ",a,a/b,a/b/f.txt,a/b/up,dangling,ln.txt" {
+		t.Error("keep:", got)
+	}
+	if got := paths(t, root.SkipSymlinks().XGo_Any("")); got != ",a,a/b,a/b/f.txt" {
+		t.Error("skip:", got)
+	}
+	// a/b/up is resolved to the directory a, which has been visited already.
+	for _, ns := range []NodeSet{root.FollowSymlinks(), root.FollowSymlinks().Parallel(2)} {
+		if got := paths(t, ns.XGo_Any("dir")); got != ",a,a/b,a/b/up" {
+			t.Error("follow:", got)
+		}
+	}
+	if got := paths(t, Dir(filepath.Join(dir, "a", "b")).FollowSymlinks().XGo_Any("")); got != ",f.txt,up,up/b" {
+		t.Error("follow up:", got)
+	}
+	if got := paths(t, root.FollowSymlinks().XGo_Any("file")); got != "a/b/f.txt,dangling,ln.txt" {
+		t.Error("follow files:", got)
+	}
+
+	ln := root.XGo_Child().Match("ln.txt")
+	if is, err := ln.IsSymlink(); !is || err != nil {
+		t.Error("IsSymlink:", is, err)
+	}
+	if dest, err := ln.Readlink(); dest != "a/b/f.txt" || err != nil {
+		t.Error("Readlink:", dest, err)
+	}
+	if is, _ := root.FollowSymlinks().XGo_Child().Match("ln.txt").IsSymlink(); !is {
+		t.Error("IsSymlink (follow): false")
+	}
+	if _, err := New(testFS).XGo_Child().Match("go.mod").Readlink(); err == nil {
+		t.Error("Readlink:", err)
+	}
+}
+
+func TestGitignore(t *testing.T) {
+	fsys := fstest.MapFS{
+		".gitignore":         {Data: []byte("# build output\n*.log\n/out/\n!keep.log\n")},
+		".git/HEAD":          {},
+		"a.log":              {},
+		"keep.log":           {},
+		"main.go":            {},
+		"out/bin":            {},
+		"src/.gitignore":     {Data: []byte("gen/**\n!debug.log\n")},
+		"src/debug.log":      {},
+		"src/gen/x.go":       {},
+		"src/out/y.go":       {},
+		"src/x.log":          {},
+		".dockerignore":      {Data: []byte("src\n")},
+		"docs/.dockerignore": {Data: []byte("*.md\n")},
+		"docs/a.md":          {},
+	}
+	root := New(fsys)
+	want := ",.dockerignore,.gitignore,docs,docs/.dockerignore,docs/a.md,keep.log,main.go,src,src/.gitignore,src/debug.log,src/gen,src/out,src/out/y.go"
+	for _, ns := range []NodeSet{root.RespectGitignore(), root.RespectGitignore().Parallel(2)} {
+		if got := paths(t, ns.XGo_Any("")); got != want {
+			t.Error("gitignore:", got)
+		}
+	}
+	if got := paths(t, root.RespectGitignore().Glob("**/*.go")); got != "main.go,src/out/y.go" {
+		t.Error("gitignore glob:", got)
+	}
+	if got := paths(t, root.IgnoreFile(".dockerignore").XGo_Any("file")); got != ".dockerignore,.git/HEAD,.gitignore,a.log,docs/.dockerignore,keep.log,main.go,out/bin" {
+		t.Error("dockerignore:", got)
+	}
+}
@@ -0,0 +1,238 @@
+/*
+ * Copyright (c) 2026 The XGo Authors (xgo.dev). All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package fs
+
+import (
+	"errors"
+	"io/fs"
+	"path"
+	"strings"
+)
+
+// -----------------------------------------------------------------------------
+
+// Glob returns a NodeSet containing all descendant nodes of the nodes in the
+// NodeSet whose paths (relative to the node) match the specified patterns.
+//
+// Besides the syntax of path.Match, a pattern supports:
+//   - "**" as a whole path element, matching zero or more directories,
+//     e.g. "src/**/*.go" matches "src/a.go" and "src/x/y/b.go".
+//   - brace expansion, e.g. "*.{go,xgo}" or "{cmd,tool}/**".
+//   - negation, a pattern starting with "!" excludes the paths it matches,
+//     e.g. Glob("**/*.go", "!**/*_test.go").
+//
+// If there are only negative patterns, all paths not excluded are matched.
+func (p NodeSet) Glob(patterns ...string) NodeSet {
+	if p.Err != nil {
+		return NodeSet{Err: p.Err}
+	}
+	g, err := compileGlob(patterns)
+	if err != nil {
+		return NodeSet{Err: err}
+	}
+	return NodeSet{
+		Base: p.Base,
+		opts: p.opts,
+		Data: func(yield func(*Node) bool) {
+			p.Data(func(node *Node) bool {
+				return yieldGlobNodes(p.Base, &p.opts, node, g, yield)
+			})
+		},
+	}
+}
+
+// yieldGlobNodes yields all descendant nodes of the given node whose paths
+// relative to the node match g.
+func yieldGlobNodes(base fs.FS, opts *options, node *Node, g *globMatcher, yield func(*Node) bool) bool {
+	n := len(node.Path)
+	return opts.walk(base, node, func(child *Node) (bool, bool) {
+		if child.err != nil {
+			return false, yield(child) // yield the error as a node
+		}
+		rel := strings.TrimPrefix(child.Path[n:], "/")
+		if g.match(rel) {
+			if !yield(child) {
+				return false, false
+			}
+		}
+		isDir, _ := child.IsDir()
+		return isDir && g.matchDir(rel), true
+	})
+}
+
+// -----------------------------------------------------------------------------
+
+// globMatcher matches slash-separated paths against a set of doublestar
+// patterns. Each pattern is stored with its brace expansions split into
+// path elements.
+type globMatcher struct {
+	include [][]string
+	exclude [][]string
+}
+
+func compileGlob(patterns []string) (*globMatcher, error) {
+	g := new(globMatcher)
+	for _, pattern := range patterns {
+		list := &g.include
+		if neg, ok := strings.CutPrefix(pattern, "!"); ok {
+			pattern, list = neg, &g.exclude
+		}
+		expanded, err := expandBraces(pattern)
+		if err != nil {
+			return nil, err
+		}
+		for _, pat := range expanded {
+			elems := strings.Split(strings.Trim(pat, "/"), "/")
+			for _, elem := range elems {
+				if _, err := path.Match(elem, ""); err != nil {
+					return nil, err
+				}
+			}
+			*list = append(*list, elems)
+		}
+	}
+	if g.include == nil {
+		g.include = [][]string{{"**"}}
+	}
+	return g, nil
+}
+
+// match reports whether name matches the patterns.
+func (g *globMatcher) match(name string) bool {
+	elems := strings.Split(name, "/")
+	for _, pat := range g.exclude {
+		if matchElems(pat, elems) {
+			return false
+		}
+	}
+	for _, pat := range g.include {
+		if matchElems(pat, elems) {
+			return true
+		}
+	}
+	return false
+}
+
+// matchDir reports whether descendants of the directory dir may match the
+// patterns, so directories which can't contain any match are not walked.
+func (g *globMatcher) matchDir(dir string) bool {
+	elems := strings.Split(dir, "/")
+	for _, pat := range g.exclude {
+		if n := len(pat); n > 0 && pat[n-1] == "**" && matchElems(pat[:n-1], elems) {
+			return false // all descendants are excluded
+		}
+	}
+	for _, pat := range g.include {
+		if matchPrefix(pat, elems) {
+			return true
+		}
+	}
+	return false
+}
+
+// matchElems reports whether the path elements match the pattern elements.
+func matchElems(pat, elems []string) bool {
+	for len(pat) > 0 {
+		if pat[0] == "**" {
+			for len(pat) > 0 && pat[0] == "**" {
+				pat = pat[1:]
+			}
+			if len(pat) == 0 {
+				return true
+			}
+			for i := range elems {
+				if matchElems(pat, elems[i:]) {
+					return true
+				}
+			}
+			return false
+		}
+		if len(elems) == 0 {
+			return false
+		}
+		// The pattern has been validated, so we can ignore the error here.
+		if ok, _ := path.Match(pat[0], elems[0]); !ok {
+			return false
+		}
+		pat, elems = pat[1:], elems[1:]
+	}
+	return len(elems) == 0
+}
+
+// matchPrefix reports whether some path starting with the path elements may
+// match the pattern elements.
+func matchPrefix(pat, elems []string) bool {
+	for len(elems) > 0 {
+		if len(pat) == 0 {
+			return false
+		}
+		if pat[0] == "**" {
+			return true
+		}
+		if ok, _ := path.Match(pat[0], elems[0]); !ok {
+			return false
+		}
+		pat, elems = pat[1:], elems[1:]
+	}
+	return true
+}
+
+// errBadBraces is returned by Glob for patterns with unbalanced braces.
+var errBadBraces = errors.New("syntax error in pattern: unbalanced braces")
+
+// expandBraces expands the first (outermost) brace group of the pattern and
+// recursively the results, e.g. "a{b,c{d,e}}" expands to "ab", "acd", "ace".
+func expandBraces(pattern string) ([]string, error) {
+	start := strings.IndexByte(pattern, '{')
+	if start < 0 {
+		if strings.IndexByte(pattern, '}') >= 0 {
+			return nil, errBadBraces
+		}
+		return []string{pattern}, nil
+	}
+	depth, last := 0, start+1
+	var alts []string
+	for i := start; i < len(pattern); i++ {
+		switch pattern[i] {
+		case '\\':
+			i++
+		case '{':
+			depth++
+		case ',':
+			if depth == 1 {
+				alts = append(alts, pattern[last:i])
+				last = i + 1
+			}
+		case '}':
+			if depth--; depth == 0 {
+				alts = append(alts, pattern[last:i])
+				var ret []string
+				for _, alt := range alts {
+					expanded, err := expandBraces(pattern[:start] + alt + pattern[i+1:])
+					if err != nil {
+						return nil, err
+					}
+					ret = append(ret, expanded...)
+				}
+				return ret, nil
+			}
+		}
+	}
+	return nil, errBadBraces
+}
+
+// -----------------------------------------------------------------------------
@@ -0,0 +1,97 @@
+/*
+ * Copyright (c) 2026 The XGo Authors (xgo.dev). All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package fs
+
+import (
+	"bufio"
+	"io/fs"
+	"iter"
+	"regexp"
+	"strings"
+)
+
+// -----------------------------------------------------------------------------
+
+// Match represents a line of a file that matches a Grep search.
+type Match struct {
+	Node   *Node  // the file node
+	Line   string // the matched line, without the line terminator
+	LineNo int    // the 1-based line number
+	Err    error  // error reading the file, Line and LineNo are not set
+}
+
+// maxLineSize is the maximum length of a line that Grep can handle.
+const maxLineSize = 16 << 20
+
+// Grep returns an iterator over the lines of the file nodes in the NodeSet
+// that contain substr. Directories are skipped. Files are opened lazily
+// while iterating, and errors are reported as a Match with Err set.
+func (p NodeSet) Grep(substr string) iter.Seq[*Match] {
+	return p.grep(func(line string) bool {
+		return strings.Contains(line, substr)
+	})
+}
+
+// GrepRegex returns an iterator over the lines of the file nodes in the
+// NodeSet that match the regular expression re. Directories are skipped.
+// Files are opened lazily while iterating, and errors are reported as a
+// Match with Err set.
+func (p NodeSet) GrepRegex(re *regexp.Regexp) iter.Seq[*Match] {
+	return p.grep(re.MatchString)
+}
+
+func (p NodeSet) grep(match func(line string) bool) iter.Seq[*Match] {
+	return func(yield func(*Match) bool) {
+		if p.Err != nil {
+			yield(&Match{Err: p.Err})
+			return
+		}
+		p.Data(func(node *Node) bool {
+			if node.err != nil {
+				return yield(&Match{Node: node, Err: node.err})
+			}
+			if isDir, _ := node.IsDir(); isDir {
+				return true
+			}
+			return grepFile(p.Base, node, match, yield)
+		})
+	}
+}
+
+func grepFile(base fs.FS, node *Node, match func(line string) bool, yield func(*Match) bool) bool {
+	f, err := base.Open(node.Path)
+	if err != nil {
+		return yield(&Match{Node: node, Err: err})
+	}
+	defer f.Close()
+
+	s := bufio.NewScanner(f)
+	s.Buffer(nil, maxLineSize)
+	for lineNo := 1; s.Scan(); lineNo++ {
+		if line := s.Text(); match(line) {
+			if !yield(&Match{Node: node, Line: line, LineNo: lineNo}) {
+				return false
+			}
+		}
+	}
+	if err = s.Err(); err != nil {
+		return yield(&Match{Node: node, Err: err})
+	}
+	return true
+}
+
+// -----------------------------------------------------------------------------
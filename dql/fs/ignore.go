@@ -0,0 +1,171 @@
+/*
+ * Copyright (c) 2026 The XGo Authors (xgo.dev). All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package fs
+
+import (
+	"io/fs"
+	"path"
+	"slices"
+	"strings"
+)
+
+// -----------------------------------------------------------------------------
+
+// RespectGitignore returns a copy of the NodeSet whose recursive traversals
+// (XGo_Any, Glob) skip .git directories and the paths ignored by .gitignore
+// files, which are read from the directories while descending.
+func (p NodeSet) RespectGitignore() NodeSet {
+	p = p.IgnoreFile(".gitignore")
+	p.opts.gitignore = true
+	return p
+}
+
+// IgnoreFile returns a copy of the NodeSet whose recursive traversals (XGo_Any,
+// Glob) skip the paths ignored by the ignore files with the specified name
+// (like ".dockerignore" or ".npmignore"), which use the .gitignore syntax and
+// are read from the directories while descending.
+func (p NodeSet) IgnoreFile(name string) NodeSet {
+	if !slices.Contains(p.opts.ignoreFiles, name) {
+		p.opts.ignoreFiles = append(slices.Clip(p.opts.ignoreFiles), name)
+	}
+	return p
+}
+
+// -----------------------------------------------------------------------------
+
+// ignoreList holds the rules of the ignore files of a directory and its
+// ancestors (up to the root of the traversal).
+type ignoreList struct {
+	parent *ignoreList
+	dir    string // path of the directory containing the ignore files
+	rules  []ignoreRule
+}
+
+// ignoreRule is a pattern of an ignore file.
+type ignoreRule struct {
+	elems   []string // path elements of the pattern
+	neg     bool     // the pattern starts with "!"
+	dirOnly bool     // the pattern ends with "/"
+}
+
+func (o *options) rootIgnore() *ignoreList {
+	if o.gitignore {
+		return &ignoreList{rules: []ignoreRule{{elems: []string{"**", ".git"}, dirOnly: true}}}
+	}
+	return nil
+}
+
+// loadIgnore reads the ignore files of the directory dir, and returns the
+// ignore rules for its children.
+func (o *options) loadIgnore(base fs.FS, dir string, parent *ignoreList) *ignoreList {
+	var rules []ignoreRule
+	for _, name := range o.ignoreFiles {
+		data, err := fs.ReadFile(base, childPath(dir, name))
+		if err == nil {
+			rules = append(rules, parseIgnore(string(data))...)
+		}
+	}
+	if rules == nil {
+		return parent
+	}
+	return &ignoreList{parent: parent, dir: dir, rules: rules}
+}
+
+// parseIgnore parses the content of an ignore file in the .gitignore syntax.
+func parseIgnore(data string) (rules []ignoreRule) {
+	for _, line := range strings.Split(data, "\n") {
+		line = strings.TrimSuffix(line, "\r")
+		if line == "" || line[0] == '#' {
+			continue
+		}
+		if !strings.HasSuffix(line, "\\ ") {
+			line = strings.TrimRight(line, " ")
+		}
+		var rule ignoreRule
+		if strings.HasPrefix(line, "!") {
+			rule.neg, line = true, line[1:]
+		} else if strings.HasPrefix(line, "\\!") || strings.HasPrefix(line, "\\#") {
+			line = line[1:]
+		}
+		if strings.HasSuffix(line, "/") {
+			rule.dirOnly, line = true, strings.TrimRight(line, "/")
+		}
+		if line == "" {
+			continue
+		}
+		// path.Match uses [^...] instead of [!...] for negated classes.
+		line = strings.ReplaceAll(line, "[!", "[^")
+		anchored := strings.Contains(line, "/")
+		rule.elems = strings.Split(strings.TrimPrefix(line, "/"), "/")
+		if !anchored {
+			rule.elems = append([]string{"**"}, rule.elems...)
+		}
+		if !validElems(rule.elems) {
+			continue
+		}
+		rules = append(rules, rule)
+	}
+	return
+}
+
+func validElems(elems []string) bool {
+	for _, elem := range elems {
+		if _, err := path.Match(elem, ""); err != nil {
+			return false
+		}
+	}
+	return true
+}
+
+// ignored reports whether the node is ignored by the rules. The rules of the
+// nearest ignore file take precedence, and in an ignore file the last
+// matching rule wins.
+func (l *ignoreList) ignored(node *Node) bool {
+	if node.err != nil {
+		return false // errors are always reported
+	}
+	isDir := node.de.IsDir()
+	for ; l != nil; l = l.parent {
+		rel := node.Path
+		if l.dir != "" {
+			rel = strings.TrimPrefix(rel, l.dir+"/")
+		}
+		elems := strings.Split(rel, "/")
+		for i := len(l.rules) - 1; i >= 0; i-- {
+			if rule := &l.rules[i]; rule.match(elems, isDir) {
+				return !rule.neg
+			}
+		}
+	}
+	return false
+}
+
+func (r *ignoreRule) match(elems []string, isDir bool) bool {
+	if r.dirOnly && !isDir {
+		return false
+	}
+	if !matchElems(r.elems, elems) {
+		return false
+	}
+	if n := len(r.elems); n > 1 && r.elems[n-1] == "**" {
+		// "dir/**" matches everything inside dir, but not dir itself.
+		return !matchElems(r.elems[:n-1], elems)
+	}
+	return true
+}
+
+// -----------------------------------------------------------------------------
@@ -0,0 +1,252 @@
+/*
+ * Copyright (c) 2026 The XGo Authors (xgo.dev). All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package fs
+
+import (
+	"io"
+	"io/fs"
+	"runtime"
+)
+
+// -----------------------------------------------------------------------------
+
+// options holds the traversal options of a NodeSet.
+type options struct {
+	workers     int       // number of goroutines reading directories, 0 means sequential
+	symlinks    int       // symlinkKeep, symlinkFollow or symlinkSkip
+	ignoreFiles []string  // names of ignore files, like ".gitignore"
+	gitignore   bool      // skip .git directories
+	dryRun      io.Writer // if not nil, write operations are printed instead of done
+}
+
+const (
+	symlinkKeep   = iota // report symbolic links as they are, don't follow them
+	symlinkFollow        // resolve symbolic links to their targets
+	symlinkSkip          // omit symbolic links
+)
+
+// Parallel returns a copy of the NodeSet whose recursive traversals (XGo_Any,
+// Glob) read directories concurrently using the specified number of workers.
+// If workers <= 0, runtime.GOMAXPROCS(0) workers are used.
+//
+// Nodes are still yielded one by one from the goroutine iterating the
+// NodeSet, but the order of the nodes isn't deterministic.
+func (p NodeSet) Parallel(workers int) NodeSet {
+	if workers <= 0 {
+		workers = runtime.GOMAXPROCS(0)
+	}
+	p.opts.workers = workers
+	return p
+}
+
+// FollowSymlinks returns a copy of the NodeSet that resolves symbolic links
+// to their targets while listing directories, so links to directories are
+// descended by recursive traversals. Each directory is visited only once
+// (tracked by device and inode), which prevents cycles. On systems without
+// inodes, links to directories are not descended.
+func (p NodeSet) FollowSymlinks() NodeSet {
+	p.opts.symlinks = symlinkFollow
+	return p
+}
+
+// SkipSymlinks returns a copy of the NodeSet that omits symbolic links while
+// listing directories.
+func (p NodeSet) SkipSymlinks() NodeSet {
+	p.opts.symlinks = symlinkSkip
+	return p
+}
+
+// resolve applies the symbolic link option to the child node just read from
+// a directory. It returns false if the node should be omitted.
+func (o *options) resolve(base fs.FS, node *Node) bool {
+	if o.symlinks == symlinkKeep || node.de.Type()&fs.ModeSymlink == 0 {
+		return true
+	}
+	if o.symlinks == symlinkSkip {
+		return false
+	}
+	if fi, err := fs.Stat(base, node.Path); err == nil { // keep dangling links as they are
+		node.de, node.fi, node.link = fs.FileInfoToDirEntry(fi), fi, true
+	}
+	return true
+}
+
+// visitedDirs tracks the directories visited by a traversal following
+// symbolic links. It's nil if symbolic links are not followed.
+type visitedDirs map[fileID]bool
+
+// enter reports whether the directory should be descended, and marks it as
+// visited.
+func (v visitedDirs) enter(node *Node) bool {
+	if v == nil {
+		return true
+	}
+	fi, err := node.info()
+	if err != nil {
+		return true // let the error be reported while reading the directory
+	}
+	id, ok := fileIDOf(fi)
+	if !ok {
+		return !node.link
+	}
+	if v[id] {
+		return false
+	}
+	v[id] = true
+	return true
+}
+
+// walkFunc is called for each descendant node visited by walk. It returns
+// whether to descend into the node (if it's a directory) and whether to
+// continue the traversal.
+type walkFunc = func(node *Node) (descend, ok bool)
+
+// walk visits all descendant nodes of the given node (not including itself).
+func (o *options) walk(base fs.FS, node *Node, visit walkFunc) bool {
+	var visited visitedDirs
+	if o.symlinks == symlinkFollow {
+		visited = make(visitedDirs)
+		name := node.Path
+		if name == "" {
+			name = "."
+		}
+		if fi, err := fs.Stat(base, name); err == nil {
+			if id, ok := fileIDOf(fi); ok {
+				visited[id] = true
+			}
+		}
+	}
+	ign := o.rootIgnore()
+	if o.workers > 0 {
+		return o.walkParallel(base, node, ign, visited, visit)
+	}
+	return o.walkSeq(base, node, ign, visited, visit)
+}
+
+// walkSeq visits the descendant nodes in depth-first order.
+func (o *options) walkSeq(base fs.FS, node *Node, ign *ignoreList, visited visitedDirs, visit walkFunc) bool {
+	ign = o.loadIgnore(base, node.Path, ign)
+	return yieldChildNodes(base, o, node, nil, func(child *Node) bool {
+		if ign.ignored(child) {
+			return true
+		}
+		descend, ok := visit(child)
+		if ok && descend && visited.enter(child) {
+			return o.walkSeq(base, child, ign, visited, visit)
+		}
+		return ok
+	})
+}
+
+type dirJob struct {
+	dir *Node
+	ign *ignoreList // ignore rules of the parent directory
+}
+
+type dirResult struct {
+	dir   *Node
+	ign   *ignoreList // ignore rules of the directory
+	items []fs.DirEntry
+	err   error
+}
+
+// walkParallel visits the descendant nodes, reading directories by a pool of
+// workers. visit is only called from the current goroutine.
+func (o *options) walkParallel(base fs.FS, node *Node, ign *ignoreList, visited visitedDirs, visit walkFunc) bool {
+	if isDir, err := node.IsDir(); err != nil || !isDir {
+		return yieldChildNodes(base, o, node, nil, func(child *Node) bool {
+			_, ok := visit(child) // yield the error (if any) as a node
+			return ok
+		})
+	}
+
+	jobs := make(chan dirJob)
+	results := make(chan dirResult)
+	done := make(chan struct{})
+	defer close(done)
+	defer close(jobs)
+	for range o.workers {
+		go func() {
+			for job := range jobs {
+				ign := o.loadIgnore(base, job.dir.Path, job.ign)
+				items, err := readDir(base, job.dir.Path)
+				select {
+				case results <- dirResult{job.dir, ign, items, err}:
+				case <-done:
+					return
+				}
+			}
+		}()
+	}
+
+	pending := []dirJob{{node, ign}}
+	inflight := 0
+	for len(pending) > 0 || inflight > 0 {
+		var send chan dirJob
+		var next dirJob
+		if n := len(pending); n > 0 {
+			send, next = jobs, pending[n-1]
+		}
+		select {
+		case send <- next:
+			pending = pending[:len(pending)-1]
+			inflight++
+		case r := <-results:
+			inflight--
+			if r.err != nil {
+				if _, ok := visit(&Node{Path: r.dir.Path, err: r.err}); !ok {
+					return false
+				}
+				continue
+			}
+			for _, item := range r.items {
+				child := &Node{Path: childPath(r.dir.Path, item.Name()), de: item}
+				if !o.resolve(base, child) || r.ign.ignored(child) {
+					continue
+				}
+				descend, ok := visit(child)
+				if !ok {
+					return false
+				}
+				if descend && child.de.IsDir() && visited.enter(child) {
+					pending = append(pending, dirJob{child, r.ign})
+				}
+			}
+		}
+	}
+	return true
+}
+
+// readDir reads the directory with the specified path.
+func readDir(base fs.FS, dir string) ([]fs.DirEntry, error) {
+	if dir == "" {
+		// fs.ReadDir does not accept an empty string as the directory, use "."
+		// instead to read the root directory.
+		dir = "."
+	}
+	return fs.ReadDir(base, dir)
+}
+
+// childPath returns the path of the child with the specified name.
+func childPath(dir, name string) string {
+	if dir == "" {
+		return name
+	}
+	return dir + "/" + name
+}
+
+// -----------------------------------------------------------------------------
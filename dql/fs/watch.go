@@ -0,0 +1,138 @@
+/*
+ * Copyright (c) 2026 The XGo Authors (xgo.dev). All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package fs
+
+import (
+	"io/fs"
+	"path"
+	"path/filepath"
+
+	"github.com/fsnotify/fsnotify"
+)
+
+// -----------------------------------------------------------------------------
+
+// Op describes the file operations of a node reported by Watch.
+type Op = fsnotify.Op
+
+const (
+	Create = fsnotify.Create // the file or directory was created
+	Write  = fsnotify.Write  // the file was written to
+	Remove = fsnotify.Remove // the file or directory was removed
+	Rename = fsnotify.Rename // the file or directory was renamed (moved away)
+	Chmod  = fsnotify.Chmod  // the file attributes were changed
+)
+
+// Watch returns a live NodeSet for the specified directory. Its iterator
+// watches the directory recursively and yields a node for each file system
+// event, until the iteration stops (the loop breaks). The operations of the
+// event can be retrieved by Node.Op. Nodes of removed or renamed files only
+// support Path, Name and Op.
+//
+// Each iteration sets up its own watcher, so events occurred between two
+// iterations are lost.
+func Watch(dir string) NodeSet {
+	base := DirFS(dir)
+	return NodeSet{
+		Base: base,
+		Data: func(yield func(*Node) bool) {
+			w, err := fsnotify.NewWatcher()
+			if err == nil {
+				defer w.Close()
+				err = watchDir(w, dir)
+			}
+			if err != nil {
+				yield(&Node{Path: "", err: err}) // yield the error as a node
+				return
+			}
+			for {
+				select {
+				case event, ok := <-w.Events:
+					if !ok {
+						return
+					}
+					if !yieldEvent(w, base, dir, event, yield) {
+						return
+					}
+				case err, ok := <-w.Errors:
+					if !ok {
+						return
+					}
+					if !yield(&Node{Path: "", err: err}) {
+						return
+					}
+				}
+			}
+		},
+	}
+}
+
+func yieldEvent(w *fsnotify.Watcher, base DirFS, dir string, event fsnotify.Event, yield func(*Node) bool) bool {
+	rel, err := filepath.Rel(dir, event.Name)
+	if err != nil {
+		return yield(&Node{Path: event.Name, err: err})
+	}
+	name := filepath.ToSlash(rel)
+	node := &Node{Path: name, op: event.Op}
+	if event.Op&(Remove|Rename) != 0 {
+		node.de = goneEntry(path.Base(name))
+		return yield(node)
+	}
+	fi, err := fs.Stat(base, name)
+	if err != nil {
+		node.err = err
+		return yield(node)
+	}
+	node.de, node.fi = fs.FileInfoToDirEntry(fi), fi
+	if fi.IsDir() && event.Op&Create != 0 {
+		// fsnotify doesn't watch recursively, so watch the new directory too.
+		if err = watchDir(w, event.Name); err != nil {
+			return yield(&Node{Path: name, err: err})
+		}
+	}
+	return yield(node)
+}
+
+func watchDir(w *fsnotify.Watcher, dir string) error {
+	return filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err == nil && d.IsDir() {
+			err = w.Add(path)
+		}
+		return err
+	})
+}
+
+// goneEntry represents the directory entry of a removed or renamed file.
+type goneEntry string
+
+func (p goneEntry) Name() string {
+	return string(p)
+}
+
+func (p goneEntry) IsDir() bool {
+	return false
+}
+
+func (p goneEntry) Type() fs.FileMode {
+	return 0
+}
+
+func (p goneEntry) Info() (fs.FileInfo, error) {
+	return nil, fs.ErrNotExist
+}
+
+// -----------------------------------------------------------------------------
@@ -0,0 +1,233 @@
+/*
+ * Copyright (c) 2026 The XGo Authors (xgo.dev). All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package fs
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+
+	"github.com/goplus/xgo/dql"
+)
+
+// -----------------------------------------------------------------------------
+
+// ErrReadOnly is returned by the write operations of a NodeSet whose file
+// system isn't a DirFS.
+var ErrReadOnly = errors.New("read-only file system")
+
+// DirFS is the file system of a directory of the host, as returned by Dir.
+// Unlike os.DirFS, it supports the write operations of NodeSet.
+type DirFS string
+
+func (dir DirFS) base() fs.FS {
+	return os.DirFS(string(dir))
+}
+
+// Open opens the named file.
+func (dir DirFS) Open(name string) (fs.File, error) {
+	return dir.base().Open(name)
+}
+
+// ReadDir reads the named directory and returns a list of directory entries
+// sorted by filename.
+func (dir DirFS) ReadDir(name string) ([]fs.DirEntry, error) {
+	return fs.ReadDir(dir.base(), name)
+}
+
+// Stat returns a FileInfo describing the named file.
+func (dir DirFS) Stat(name string) (fs.FileInfo, error) {
+	return fs.Stat(dir.base(), name)
+}
+
+// ReadLink returns the destination of the named symbolic link.
+func (dir DirFS) ReadLink(name string) (string, error) {
+	if !fs.ValidPath(name) {
+		return "", &fs.PathError{Op: "readlink", Path: name, Err: fs.ErrInvalid}
+	}
+	return os.Readlink(dir.Join(name))
+}
+
+// Join returns the host path of the named file.
+func (dir DirFS) Join(name string) string {
+	return filepath.Join(string(dir), filepath.FromSlash(name))
+}
+
+// -----------------------------------------------------------------------------
+
+// DryRun returns a copy of the NodeSet whose write operations (Copy, Move,
+// Delete, Chmod) don't change anything, but print what they would do to w.
+// If w is nil, os.Stdout is used.
+func (p NodeSet) DryRun(w io.Writer) NodeSet {
+	if w == nil {
+		w = os.Stdout
+	}
+	p.opts.dryRun = w
+	return p
+}
+
+// Copy copies all nodes in the NodeSet into the directory dstDir of the host,
+// which is created if necessary. Directories are copied recursively. The
+// source can be any file system, e.g. an archive opened by Archive.
+// It doesn't stop at the first error, but returns all errors joined.
+func (p NodeSet) Copy(dstDir string) error {
+	return p.apply(false, func(node *Node) error {
+		dst := filepath.Join(dstDir, filepath.FromSlash(path.Base("/"+node.Path)))
+		if p.opts.dryRun != nil {
+			fmt.Fprintln(p.opts.dryRun, "cp", p.hostPath(node), dst)
+			return nil
+		}
+		return copyTree(p.Base, node.Path, dst)
+	})
+}
+
+// Move moves all nodes in the NodeSet into the directory dstDir of the host,
+// which is created if necessary. It doesn't stop at the first error, but
+// returns all errors joined.
+func (p NodeSet) Move(dstDir string) error {
+	created := false
+	return p.apply(true, func(node *Node) error {
+		src := p.hostPath(node)
+		dst := filepath.Join(dstDir, path.Base("/"+node.Path))
+		if p.opts.dryRun != nil {
+			fmt.Fprintln(p.opts.dryRun, "mv", src, dst)
+			return nil
+		}
+		if !created {
+			if err := os.MkdirAll(dstDir, 0o755); err != nil {
+				return err
+			}
+			created = true
+		}
+		return os.Rename(src, dst)
+	})
+}
+
+// Delete removes all nodes in the NodeSet. Directories are removed with all
+// their contents. It doesn't stop at the first error, but returns all errors
+// joined.
+func (p NodeSet) Delete() error {
+	return p.apply(true, func(node *Node) error {
+		name := p.hostPath(node)
+		if p.opts.dryRun != nil {
+			fmt.Fprintln(p.opts.dryRun, "rm", name)
+			return nil
+		}
+		return os.RemoveAll(name)
+	})
+}
+
+// Chmod changes the mode of all nodes in the NodeSet. It doesn't stop at the
+// first error, but returns all errors joined.
+func (p NodeSet) Chmod(mode fs.FileMode) error {
+	return p.apply(true, func(node *Node) error {
+		name := p.hostPath(node)
+		if p.opts.dryRun != nil {
+			fmt.Fprintf(p.opts.dryRun, "chmod %v %s\n", mode, name)
+			return nil
+		}
+		return os.Chmod(name, mode)
+	})
+}
+
+// apply calls fn for all nodes in the NodeSet, and joins the errors. The
+// nodes are collected first, since fn may change the file system being
+// traversed.
+func (p NodeSet) apply(needWrite bool, fn func(node *Node) error) error {
+	if p.Err != nil {
+		return p.Err
+	}
+	if needWrite {
+		if _, ok := p.Base.(DirFS); !ok {
+			return ErrReadOnly
+		}
+	}
+	var errs []error
+	for _, node := range dql.Collect(p.Data) {
+		err := node.err
+		if err == nil {
+			err = fn(node)
+		}
+		if err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// hostPath returns the host path of the node if possible, or the path of the
+// node in the file system otherwise.
+func (p NodeSet) hostPath(node *Node) string {
+	if dir, ok := p.Base.(DirFS); ok {
+		return dir.Join(node.Path)
+	}
+	return node.Path
+}
+
+// copyTree copies the file or directory src of base to the host path dst.
+func copyTree(base fs.FS, src, dst string) error {
+	if src == "" {
+		src = "."
+	}
+	return fs.WalkDir(base, src, func(name string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		rel := name[len(src):]
+		if src == "." {
+			rel = "/" + name
+		}
+		target := filepath.Join(dst, filepath.FromSlash(rel))
+		if d.IsDir() {
+			return os.MkdirAll(target, 0o755)
+		}
+		return copyFile(base, name, target, d)
+	})
+}
+
+func copyFile(base fs.FS, name, target string, d fs.DirEntry) error {
+	fi, err := d.Info()
+	if err != nil {
+		return err
+	}
+	if !fi.Mode().IsRegular() {
+		return &fs.PathError{Op: "copy", Path: name, Err: errors.New("not a regular file")}
+	}
+	if err = os.MkdirAll(filepath.Dir(target), 0o755); err != nil {
+		return err
+	}
+	r, err := base.Open(name)
+	if err != nil {
+		return err
+	}
+	defer r.Close()
+	w, err := os.OpenFile(target, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, fi.Mode().Perm())
+	if err != nil {
+		return err
+	}
+	_, err = io.Copy(w, r)
+	if e := w.Close(); err == nil {
+		err = e
+	}
+	return err
+}
+
+// -----------------------------------------------------------------------------
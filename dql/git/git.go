@@ -0,0 +1,341 @@
+/*
+ * Copyright (c) 2026 The XGo Authors (xgo.dev). All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package git
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"iter"
+	"os/exec"
+	"strconv"
+	"strings"
+
+	"github.com/goplus/xgo/dql/maps"
+)
+
+const (
+	XGoPackage = "github.com/goplus/xgo/dql/maps"
+)
+
+// -----------------------------------------------------------------------------
+
+// Node represents a commit, a ref or an entry of a tree, whose value is a
+// map[string]any of its attributes.
+type Node = maps.Node
+
+// NodeSet represents a set of nodes.
+type NodeSet = maps.NodeSet
+
+// Repo is a git repository. It runs the git command to read the repository,
+// so git must be installed.
+type Repo struct {
+	// Dir is a directory in the work tree (or the git directory of a bare
+	// repository).
+	Dir string
+
+	// OnError is called if an error occurs while reading nodes lazily.
+	OnError func(error)
+}
+
+// Open opens the git repository containing dir.
+func Open(dir string) (*Repo, error) {
+	r := &Repo{Dir: dir}
+	if _, err := r.output("rev-parse", "--git-dir"); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// Commits returns the commits listed by `git log` with the arguments, which
+// are revisions, paths (after "--") or options limiting the commits, like
+// "--since=90.days.ago" or "--author=alice". Commits of HEAD are listed if
+// there is no revision. Each commit is a node named "commit" with attributes:
+//   - hash, parents: the hash of the commit and the hashes of its parents.
+//   - author, email, date: the author, the email and the date (in the RFC
+//     3339 form) of the commit.
+//   - committer, committerEmail, committerDate: likewise of the committer.
+//   - subject, message: the first line and the full message.
+//   - files: the files changed by the commit (empty for merges), each has
+//     path, status (A, M, D, T), added and deleted (the numbers of lines,
+//     nil for binary files).
+//
+// For example, the files changed most in the last 90 days are:
+//
+//	count := {}
+//	for f in repo.commits("--since=90.days.ago").**.files.* {
+//		count[f.$path.(string)]++
+//	}
+//
+// Commits are read lazily, so the NodeSet can only be iterated once.
+func (r *Repo) Commits(args ...string) NodeSet {
+	args = append([]string{
+		"log", "--no-renames", "--raw", "--numstat",
+		"--format=%x1e%H%x00%P%x00%an%x00%ae%x00%aI%x00%cn%x00%ce%x00%cI%x00%B%x00",
+	}, args...)
+	return r.stream(args, splitRecord, func(rec []byte) (Node, bool) {
+		fields := bytes.SplitN(rec, []byte{0}, 10)
+		if len(fields) != 10 {
+			return Node{}, false
+		}
+		msg := strings.TrimRight(string(fields[8]), "\n")
+		subject, _, _ := strings.Cut(msg, "\n")
+		parents := []any{}
+		for _, p := range strings.Fields(string(fields[1])) {
+			parents = append(parents, p)
+		}
+		return Node{Name: "commit", Value: map[string]any{
+			"hash":           string(fields[0]),
+			"parents":        parents,
+			"author":         string(fields[2]),
+			"email":          string(fields[3]),
+			"date":           string(fields[4]),
+			"committer":      string(fields[5]),
+			"committerEmail": string(fields[6]),
+			"committerDate":  string(fields[7]),
+			"subject":        subject,
+			"message":        msg,
+			"files":          changedFiles(fields[9]),
+		}}, true
+	})
+}
+
+// changedFiles parses the --raw and --numstat output of a commit.
+func changedFiles(b []byte) []any {
+	files := []any{}
+	index := make(map[string]map[string]any)
+	for line := range strings.SplitSeq(string(b), "\n") {
+		if line == "" {
+			continue
+		}
+		if line[0] == ':' { // :100644 100644 a1b2c3 d4e5f6 M\tpath
+			meta, path, ok := strings.Cut(line, "\t")
+			if !ok {
+				continue
+			}
+			status := meta[strings.LastIndexByte(meta, ' ')+1:]
+			f := map[string]any{"path": path, "status": status, "added": nil, "deleted": nil}
+			index[path] = f
+			files = append(files, f)
+			continue
+		}
+		parts := strings.SplitN(line, "\t", 3) // added\tdeleted\tpath
+		if len(parts) != 3 {
+			continue
+		}
+		if f, ok := index[parts[2]]; ok {
+			f["added"], f["deleted"] = count(parts[0]), count(parts[1])
+		}
+	}
+	return files
+}
+
+func count(s string) any {
+	if n, err := strconv.Atoi(s); err == nil {
+		return n
+	}
+	return nil // "-" of binary files
+}
+
+// Refs returns the refs of the repository. Each ref is a node named "ref"
+// with attributes:
+//   - name, short: the full name (like refs/heads/main) and the short name.
+//   - hash, type: the object the ref points to, and its type.
+//   - target: the commit an annotated tag points to, or the hash otherwise.
+func (r *Repo) Refs() NodeSet {
+	args := []string{
+		"for-each-ref",
+		"--format=%(refname)%00%(refname:short)%00%(objectname)%00%(objecttype)%00%(*objectname)",
+	}
+	return r.stream(args, bufio.ScanLines, func(line []byte) (Node, bool) {
+		fields := strings.Split(string(line), "\x00")
+		if len(fields) != 5 {
+			return Node{}, false
+		}
+		target := fields[4]
+		if target == "" {
+			target = fields[2]
+		}
+		return Node{Name: "ref", Value: map[string]any{
+			"name":   fields[0],
+			"short":  fields[1],
+			"hash":   fields[2],
+			"type":   fields[3],
+			"target": target,
+		}}, true
+	})
+}
+
+// Tree returns the entries of the tree of a revision (like "HEAD" or
+// "v1.0:src") recursively. Each entry is a node named after its type: "blob"
+// for files, "tree" for directories and "commit" for submodules, with
+// attributes:
+//   - path, name: the path relative to the tree, and the base name.
+//   - hash, mode: the object of the entry, and its mode like "100644".
+//   - size: the size of a blob, nil for others.
+//
+// Contents of blobs can be read by Blob.
+func (r *Repo) Tree(rev string) NodeSet {
+	args := []string{"ls-tree", "-r", "-t", "-l", "-z", rev}
+	return r.stream(args, splitNul, func(entry []byte) (Node, bool) {
+		meta, path, ok := strings.Cut(string(entry), "\t") // mode type hash size\tpath
+		if !ok {
+			return Node{}, false
+		}
+		fields := strings.Fields(meta)
+		if len(fields) != 4 {
+			return Node{}, false
+		}
+		var size any
+		if n, err := strconv.ParseInt(fields[3], 10, 64); err == nil {
+			size = n
+		}
+		return Node{Name: fields[1], Value: map[string]any{
+			"path": path,
+			"name": path[strings.LastIndexByte(path, '/')+1:],
+			"hash": fields[2],
+			"mode": fields[0],
+			"size": size,
+		}}, true
+	})
+}
+
+// Blob returns the content of a blob, which is specified by its hash or by a
+// revision and a path like "HEAD:README.md".
+func (r *Repo) Blob(obj string) ([]byte, error) {
+	return r.output("cat-file", "blob", obj)
+}
+
+// -----------------------------------------------------------------------------
+
+func (r *Repo) command(args ...string) *exec.Cmd {
+	return exec.Command("git", append([]string{"-C", r.Dir, "-c", "core.quotePath=false"}, args...)...)
+}
+
+func (r *Repo) output(args ...string) ([]byte, error) {
+	var stderr bytes.Buffer
+	cmd := r.command(args...)
+	cmd.Stderr = &stderr
+	out, err := cmd.Output()
+	if err != nil {
+		return nil, gitError(args, &stderr, err)
+	}
+	return out, nil
+}
+
+// stream runs git with the arguments, and returns a NodeSet of the records
+// of its output split by split, which are converted to nodes by conv. Records
+// that conv can't convert are skipped.
+func (r *Repo) stream(args []string, split bufio.SplitFunc, conv func(rec []byte) (Node, bool)) NodeSet {
+	return NodeSet{
+		Data: func(yield func(Node) bool) {
+			var stderr bytes.Buffer
+			cmd := r.command(args...)
+			cmd.Stderr = &stderr
+			stdout, err := cmd.StdoutPipe()
+			if err == nil {
+				err = cmd.Start()
+			}
+			if err != nil {
+				r.error(gitError(args, &stderr, err))
+				return
+			}
+			s := bufio.NewScanner(stdout)
+			s.Buffer(nil, 64<<20)
+			s.Split(split)
+			for s.Scan() {
+				if node, ok := conv(s.Bytes()); ok && !yield(node) {
+					cmd.Process.Kill()
+					cmd.Wait()
+					return
+				}
+			}
+			err = s.Err()
+			if e := cmd.Wait(); err == nil && e != nil {
+				err = gitError(args, &stderr, e)
+			}
+			if err != nil {
+				r.error(err)
+			}
+		},
+	}
+}
+
+func (r *Repo) error(err error) {
+	if r.OnError != nil {
+		r.OnError(err)
+	}
+}
+
+func gitError(args []string, stderr *bytes.Buffer, err error) error {
+	if msg := strings.TrimSpace(stderr.String()); msg != "" {
+		return fmt.Errorf("git %s: %s", args[0], msg)
+	}
+	return fmt.Errorf("git %s: %w", args[0], err)
+}
+
+// splitRecord splits the output of Commits by the record separators (0x1e)
+// before each commit.
+func splitRecord(data []byte, atEOF bool) (advance int, token []byte, err error) {
+	return splitBy(0x1e, data, atEOF)
+}
+
+func splitNul(data []byte, atEOF bool) (advance int, token []byte, err error) {
+	return splitBy(0, data, atEOF)
+}
+
+func splitBy(sep byte, data []byte, atEOF bool) (advance int, token []byte, err error) {
+	if i := bytes.IndexByte(data, sep); i >= 0 {
+		return i + 1, data[:i], nil
+	}
+	if atEOF && len(data) > 0 {
+		return len(data), data, nil
+	}
+	return 0, nil, nil
+}
+
+// -----------------------------------------------------------------------------
+
+// Source creates a NodeSet from various source types:
+// - string: opens the repository containing the directory, and returns the commits of HEAD.
+// - *Repo: returns the commits of HEAD of the repository.
+// - Node: creates a NodeSet containing the single provided node.
+// - iter.Seq[Node]: directly uses the provided sequence of nodes.
+// - NodeSet: returns the provided NodeSet as is.
+// If the source type is unsupported, it panics.
+func Source(r any) (ret NodeSet) {
+	switch v := r.(type) {
+	case string:
+		repo, err := Open(v)
+		if err != nil {
+			return NodeSet{Err: err}
+		}
+		return repo.Commits()
+	case *Repo:
+		return v.Commits()
+	case Node:
+		return maps.Root(v)
+	case iter.Seq[Node]:
+		return NodeSet{Data: v}
+	case NodeSet:
+		return v
+	default:
+		panic("dql/git.Source: unsupported source type")
+	}
+}
+
+// -----------------------------------------------------------------------------
@@ -0,0 +1,155 @@
+/*
+ * Copyright (c) 2026 The XGo Authors (xgo.dev). All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package git
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+// testRepo creates a repository of 2 commits and a tag.
+func testRepo(t *testing.T) string {
+	t.Helper()
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not found")
+	}
+	dir := t.TempDir()
+	run := func(date string, args ...string) {
+		t.Helper()
+		cmd := exec.Command("git", args...)
+		cmd.Dir = dir
+		cmd.Env = append(os.Environ(),
+			"GIT_AUTHOR_NAME=Ann", "GIT_AUTHOR_EMAIL=ann@xgo.dev", "GIT_AUTHOR_DATE="+date,
+			"GIT_COMMITTER_NAME=Bob", "GIT_COMMITTER_EMAIL=bob@xgo.dev", "GIT_COMMITTER_DATE="+date,
+			"GIT_CONFIG_GLOBAL=/dev/null", "GIT_CONFIG_NOSYSTEM=1")
+		if out, err := cmd.CombinedOutput(); err != nil {
+			t.Fatalf("git %v: %v\n%s", args, err, out)
+		}
+	}
+	write := func(name, data string) {
+		t.Helper()
+		name = filepath.Join(dir, name)
+		os.MkdirAll(filepath.Dir(name), 0755)
+		if err := os.WriteFile(name, []byte(data), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	run("", "init", "-q", "-b", "main")
+	write("README.md", "# demo\n")
+	write("src/main.go", "package main\n")
+	run("2026-01-02T03:04:05Z", "add", ".")
+	run("2026-01-02T03:04:05Z", "commit", "-q", "-m", "init\n\nthe first commit")
+	write("src/main.go", "package main\n\nfunc main() {}\n")
+	write("logo.png", "\x00\x01")
+	os.Remove(filepath.Join(dir, "README.md"))
+	run("2026-02-03T04:05:06Z", "add", "-A")
+	run("2026-02-03T04:05:06Z", "commit", "-q", "-m", "add main")
+	run("2026-02-03T04:05:06Z", "tag", "-a", "-m", "release", "v1.0")
+	return dir
+}
+
+func values(t *testing.T, ns NodeSet) (names []string, ret []map[string]any) {
+	t.Helper()
+	if ns.Err != nil {
+		t.Fatal(ns.Err)
+	}
+	for node := range ns.XGo_Enum() {
+		first, _ := node.XGo_first()
+		names = append(names, first.Name)
+		ret = append(ret, first.Value.(map[string]any))
+	}
+	return
+}
+
+func TestCommits(t *testing.T) {
+	dir := testRepo(t)
+	_, commits := values(t, Source(dir))
+	if len(commits) != 2 {
+		t.Fatal("commits:", commits)
+	}
+	last, first := commits[0], commits[1]
+	if last["subject"] != "add main" || last["date"] != "2026-02-03T04:05:06+00:00" ||
+		last["author"] != "Ann" || last["committerEmail"] != "bob@xgo.dev" ||
+		!reflect.DeepEqual(last["parents"], []any{first["hash"]}) {
+		t.Fatal("last commit:", last)
+	}
+	if first["message"] != "init\n\nthe first commit" || first["subject"] != "init" || len(first["parents"].([]any)) != 0 {
+		t.Fatal("first commit:", first)
+	}
+	files := []any{
+		map[string]any{"path": "README.md", "status": "D", "added": 0, "deleted": 1},
+		map[string]any{"path": "logo.png", "status": "A", "added": nil, "deleted": nil},
+		map[string]any{"path": "src/main.go", "status": "M", "added": 2, "deleted": 0},
+	}
+	if !reflect.DeepEqual(last["files"], files) {
+		t.Fatal("files:", last["files"])
+	}
+
+	repo, err := Open(dir)
+	if err != nil {
+		t.Fatal("Open:", err)
+	}
+	if _, ret := values(t, repo.Commits("--", "README.md")); len(ret) != 2 {
+		t.Fatal("commits of README.md:", ret)
+	}
+	if _, ret := values(t, repo.Commits("--since=2026-01-15", "main")); len(ret) != 1 {
+		t.Fatal("commits since:", ret)
+	}
+	var errs []error
+	repo.OnError = func(err error) { errs = append(errs, err) }
+	if _, ret := values(t, repo.Commits("nosuchrev")); len(ret) != 0 || len(errs) != 1 {
+		t.Fatal("bad revision:", ret, errs)
+	}
+	if _, err = Open(t.TempDir()); err == nil {
+		t.Fatal("Open: not a repository")
+	}
+}
+
+func TestRefsAndTree(t *testing.T) {
+	repo, err := Open(testRepo(t))
+	if err != nil {
+		t.Fatal("Open:", err)
+	}
+	_, commits := values(t, repo.Commits("-1"))
+	head := commits[0]["hash"]
+	_, refs := values(t, repo.Refs())
+	if len(refs) != 2 || refs[0]["short"] != "main" || refs[0]["hash"] != head ||
+		refs[1]["name"] != "refs/tags/v1.0" || refs[1]["type"] != "tag" || refs[1]["target"] != head {
+		t.Fatal("refs:", refs)
+	}
+
+	names, entries := values(t, repo.Tree("v1.0"))
+	if !reflect.DeepEqual(names, []string{"blob", "tree", "blob"}) {
+		t.Fatal("tree:", names, entries)
+	}
+	if e := entries[2]; e["path"] != "src/main.go" || e["name"] != "main.go" || e["mode"] != "100644" || e["size"] != int64(29) {
+		t.Fatal("entry:", e)
+	}
+	if entries[1]["size"] != nil {
+		t.Fatal("size of tree:", entries[1])
+	}
+	b, err := repo.Blob("v1.0:src/main.go")
+	if err != nil || string(b) != "package main\n\nfunc main() {}\n" {
+		t.Fatal("Blob:", string(b), err)
+	}
+	if b2, err := repo.Blob(entries[2]["hash"].(string)); err != nil || string(b2) != string(b) {
+		t.Fatal("Blob by hash:", string(b2), err)
+	}
+}
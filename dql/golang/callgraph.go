@@ -0,0 +1,141 @@
+/*
+ * Copyright (c) 2026 The XGo Authors (xgo.dev). All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package golang
+
+import (
+	"go/ast"
+	"go/types"
+	"reflect"
+	"slices"
+
+	"github.com/goplus/xgo/dql/reflects"
+)
+
+// -----------------------------------------------------------------------------
+
+// Callers returns a NodeSet containing the function declarations (FuncDecl)
+// in the NodeSet (including the descendants of its nodes) which call the
+// specified function, answering "who calls this function":
+//
+//	for fn in golang.module(".").typeCheck.callers("example.com/m.Open") {
+//		echo fn.$name, fn.position
+//	}
+//
+// The function is specified by its full name like CallsTo. Calls in function
+// literals are attributed to the enclosing declaration.
+func (p NodeSet) Callers(fn string) NodeSet {
+	return p.walkTyped(func(info *types.Info, n ast.Node, yield func(Node) bool) bool {
+		decl, ok := n.(*ast.FuncDecl)
+		if !ok || decl.Body == nil {
+			return true
+		}
+		found := false
+		ast.Inspect(decl.Body, func(n ast.Node) bool {
+			if call, ok := n.(*ast.CallExpr); ok && calleeName(info, call) == fn {
+				found = true
+			}
+			return !found
+		})
+		return !found || yield(Node{Name: "", Value: reflect.ValueOf(decl)})
+	})
+}
+
+// Callees returns the full names (see CallsTo) of the functions called by the
+// nodes in the NodeSet (typically function declarations), sorted and without
+// duplicates. Calls of function values and conversions are not included.
+func (p NodeSet) Callees() []string {
+	ns := p.walkTyped(func(info *types.Info, n ast.Node, yield func(Node) bool) bool {
+		if call, ok := n.(*ast.CallExpr); ok && calleeName(info, call) != "" {
+			return yield(Node{Name: "", Value: reflect.ValueOf(call)})
+		}
+		return true
+	})
+	if ns.Err != nil {
+		return nil
+	}
+	var ret []string
+	ns.Data(func(node Node) bool {
+		ret = append(ret, calleeName(ns.Info, node.Value.Interface().(*ast.CallExpr)))
+		return true
+	})
+	slices.Sort(ret)
+	return slices.Compact(ret)
+}
+
+// ReferencesTo returns a NodeSet containing the identifiers in the NodeSet
+// (including the descendants of its nodes) which refer to the specified
+// object. The object is specified by its full name: "fmt.Println",
+// "(*bytes.Buffer).Write", or "example.com/m.Config" for other package-level
+// objects. Declarations of the object are not included.
+func (p NodeSet) ReferencesTo(name string) NodeSet {
+	return p.walkTyped(func(info *types.Info, n ast.Node, yield func(Node) bool) bool {
+		if id, ok := n.(*ast.Ident); ok {
+			if obj := info.Uses[id]; obj != nil && objectName(obj) == name {
+				return yield(Node{Name: "", Value: reflect.ValueOf(id)})
+			}
+		}
+		return true
+	})
+}
+
+// walkTyped returns a NodeSet of the nodes yielded by fn, which is called for
+// each AST node in the NodeSet and its descendants. ErrNoTypeInfo is returned
+// if the NodeSet isn't type-checked.
+func (p NodeSet) walkTyped(fn func(info *types.Info, n ast.Node, yield func(Node) bool) bool) NodeSet {
+	if p.Err != nil {
+		return p
+	}
+	if p.Info == nil {
+		return NodeSet{NodeSet: reflects.NodeSet{Err: ErrNoTypeInfo}}
+	}
+	info := p.Info
+	return p.with(reflects.NodeSet{
+		Data: func(yield func(Node) bool) {
+			p.Data(func(node Node) bool {
+				root := astNodeOf(node.Value)
+				if root == nil {
+					return true
+				}
+				ok := true
+				ast.Inspect(root, func(n ast.Node) bool {
+					if !ok || n == nil {
+						return false
+					}
+					ok = fn(info, n, yield)
+					return ok
+				})
+				return ok
+			})
+		},
+	})
+}
+
+// objectName returns the full name of obj (see ReferencesTo).
+func objectName(obj types.Object) string {
+	switch obj := obj.(type) {
+	case *types.Func:
+		return obj.Origin().FullName()
+	case *types.Builtin, *types.Nil:
+		return obj.Name()
+	}
+	if pkg := obj.Pkg(); pkg != nil && obj.Parent() == pkg.Scope() {
+		return pkg.Path() + "." + obj.Name()
+	}
+	return obj.Name()
+}
+
+// -----------------------------------------------------------------------------
@@ -0,0 +1,261 @@
+/*
+ * Copyright (c) 2026 The XGo Authors (xgo.dev). All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package golang
+
+import (
+	"errors"
+	"go/parser"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/goplus/xgo/dql"
+)
+
+const mainSrc = `package main
+
+import "fmt"
+
+func add(a, b int) int {
+	return a + b
+}
+
+func main() {
+	fmt.Println(add(1, 2))
+}
+`
+
+// funcDecls returns the function declarations like doc.decls.*@(self.class == "FuncDecl").
+func funcDecls(ns NodeSet) NodeSet {
+	var nodes []Node
+	for decl := range ns.XGo_Elem("decls").XGo_Child().XGo_Enum() {
+		if decl.Class() == "FuncDecl" {
+			node, _ := decl.XGo_first()
+			nodes = append(nodes, node)
+		}
+	}
+	return Nodes(nodes...).WithFset(ns.Fset)
+}
+
+func TestPosition(t *testing.T) {
+	doc := From("main.go", mainSrc)
+	var got []string
+	for fn := range funcDecls(doc).XGo_Enum() {
+		got = append(got, fn.XGo_Attr__0("name").(string)+" at "+fn.Position().String())
+	}
+	if len(got) != 2 || got[0] != "add at main.go:5:1" || got[1] != "main at main.go:9:1" {
+		t.Error("Position:", got)
+	}
+	if name := funcDecls(doc).At(10).XGo_Attr__0("name"); name != "main" {
+		t.Error("At(10):", name)
+	}
+	if n := len(dql.Collect(doc.XGo_Any("").At(6).Data)); n == 0 {
+		t.Error("At(6): no nodes")
+	}
+	if ok := funcDecls(doc).At(8).XGo_ok(); !ok {
+		t.Error("At(8):", funcDecls(doc).At(8).Err)
+	}
+
+	f, err := ParseFile("main.go", mainSrc)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if pos := f.XGo_Elem("decls").XGo_Child().One().Position(); pos.Line != 3 {
+		t.Error("File Position:", pos)
+	}
+	if pos := NodeSet_Cast(doc.Data).Position(); pos.IsValid() {
+		t.Error("Position without Fset:", pos)
+	}
+	if pos := NodeSet_Cast(doc.Data).WithFset(doc.Fset).Position(); pos.Line != 1 {
+		t.Error("WithFset:", pos)
+	}
+}
+
+const typesSrc = `package main
+
+import (
+	"fmt"
+	"io"
+)
+
+type reader struct{}
+
+func (r *reader) Read(p []byte) (int, error) { return 0, io.EOF }
+
+type point struct{ x, y int }
+
+func main() {
+	var r io.Reader = &reader{}
+	fmt.Println(r, len("hi"))
+	fmt.Printf("%v\n", point{})
+}
+`
+
+func TestTypes(t *testing.T) {
+	doc := From("main.go", typesSrc, Config{Mode: parser.SkipObjectResolution})
+	if err := doc.XGo_Any("").CallsTo("fmt.Println").Err; err != ErrNoTypeInfo {
+		t.Error("CallsTo without TypeCheck:", err)
+	}
+	doc = doc.TypeCheck()
+	if doc.Pkg == nil || doc.Pkg.Name() != "main" {
+		t.Fatal("TypeCheck:", doc.Err)
+	}
+	calls := doc.XGo_Any("").CallsTo("fmt.Println", "fmt.Printf")
+	if n := len(dql.Collect(calls.Data)); n != 2 {
+		t.Error("CallsTo:", n)
+	}
+	if pos := doc.XGo_Any("").CallsTo("len").Position(); pos.Line != 16 {
+		t.Error("CallsTo(len):", pos)
+	}
+	if obj := calls.ObjectOf(); obj == nil || obj.Name() != "Println" {
+		t.Error("ObjectOf:", obj)
+	}
+	if typ := calls.TypeOf(); typ == nil || typ.String() != "(n int, err error)" {
+		t.Error("TypeOf:", typ)
+	}
+	var names []string
+	for spec := range doc.XGo_Any("").Implements("io.Reader").XGo_Enum() {
+		if spec.Class() == "TypeSpec" {
+			names = append(names, spec.ObjectOf().Name())
+		}
+	}
+	if len(names) != 1 || names[0] != "reader" {
+		t.Error("Implements:", names)
+	}
+	if n := len(dql.Collect(doc.XGo_Any("").Implements("io.Writer").Data)); n != 0 {
+		t.Error("Implements(io.Writer):", n)
+	}
+	if err := doc.XGo_Any("").Implements("bytes.Buffer").Err; err == nil {
+		t.Error("Implements: bytes is not imported")
+	}
+	if err := doc.XGo_Any("").Implements("point").Err; err == nil {
+		t.Error("Implements: point is not an interface")
+	}
+}
+
+func TestModule(t *testing.T) {
+	dir := t.TempDir()
+	files := map[string]string{
+		"go.mod":        "module example.com/m\n\ngo 1.21\n",
+		"a.go":          "package m\n\nfunc A() {}\n",
+		"b.go":          "package m\n\nfunc B() {}\n",
+		"sub/c.go":      "package sub\n\nfunc C() {}\n",
+		"sub/c_test.go": "package sub\n\nfunc TestC() {}\n",
+	}
+	for name, src := range files {
+		path := filepath.Join(dir, name)
+		os.MkdirAll(filepath.Dir(path), 0755)
+		if err := os.WriteFile(path, []byte(src), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	doc := Module(dir)
+	if doc.Err != nil {
+		t.Fatal("Module:", doc.Err)
+	}
+	if n := len(dql.Collect(doc.Data)); n != 3 {
+		t.Error("Module: files", n)
+	}
+	var names []string
+	for fn := range funcDecls(doc).XGo_Enum() {
+		names = append(names, fn.XGo_Attr__0("name").(string)+"@"+filepath.Base(fn.Position().Filename))
+	}
+	if strings.Join(names, ",") != "A@a.go,B@b.go,C@c.go" {
+		t.Error("Module: funcs", names)
+	}
+	if err := Module(filepath.Join(dir, "nonexistent")).Err; err == nil {
+		t.Error("Module: no error for nonexistent dir")
+	}
+}
+
+func TestRewrite(t *testing.T) {
+	doc := From("main.go", mainSrc)
+	if err := funcDecls(doc).At(5).Set("name", "sum"); err != nil {
+		t.Fatal("Set:", err)
+	}
+	var calls []Node
+	for n := range doc.XGo_Any("args").XGo_Child().XGo_Enum() {
+		if n.Class() == "CallExpr" {
+			node, _ := n.XGo_first()
+			calls = append(calls, node)
+		}
+	}
+	err := Nodes(calls...).Replace(func(call NodeSet) any {
+		return "sum(" + call.XGo_Elem("args").XGo_Child().XGo_Attr__0("value").(string) + ", 3)"
+	})
+	if err != nil {
+		t.Fatal("Replace:", err)
+	}
+	b, err := doc.Format()
+	if err != nil {
+		t.Fatal("Format:", err)
+	}
+	if got := string(b); !strings.Contains(got, "func sum(a, b int) int {") ||
+		!strings.Contains(got, "fmt.Println(sum(1, 3))") {
+		t.Error("Format:\n" + got)
+	}
+	if err := doc.Replace(func(NodeSet) any { return nil }); !errors.Is(err, ErrNotSettable) {
+		t.Error("Replace root:", err)
+	}
+	if err := doc.Set("nonexistent", "x"); !errors.Is(err, dql.ErrNotFound) {
+		t.Error("Set nonexistent:", err)
+	}
+	if err := funcDecls(doc).Set("name", 1); err == nil {
+		t.Error("Set: no error for an invalid value")
+	}
+}
+
+func TestCallGraph(t *testing.T) {
+	dir := t.TempDir()
+	files := map[string]string{
+		"go.mod":   "module example.com/m\n\ngo 1.21\n",
+		"a.go":     "package m\n\nimport \"example.com/m/sub\"\n\nfunc A() { sub.C(); B() }\n\nfunc B() { func() { sub.C() }() }\n",
+		"sub/c.go": "package sub\n\nimport \"fmt\"\n\nvar N = 1\n\nfunc C() { fmt.Println(N) }\n",
+	}
+	for name, src := range files {
+		path := filepath.Join(dir, name)
+		os.MkdirAll(filepath.Dir(path), 0755)
+		if err := os.WriteFile(path, []byte(src), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	doc := Module(dir, Config{Mode: parser.SkipObjectResolution}).TypeCheck()
+	if doc.Err != nil {
+		t.Fatal("TypeCheck:", doc.Err)
+	}
+	var callers []string
+	for fn := range doc.Callers("example.com/m/sub.C").XGo_Enum() {
+		callers = append(callers, fn.XGo_Attr__0("name").(string))
+	}
+	if strings.Join(callers, ",") != "A,B" {
+		t.Error("Callers:", callers)
+	}
+	if got := doc.Callers("example.com/m.A"); len(dql.Collect(got.Data)) != 0 {
+		t.Error("Callers of A: not empty")
+	}
+	if callees := doc.Callees(); strings.Join(callees, ",") != "example.com/m.B,example.com/m/sub.C,fmt.Println" {
+		t.Error("Callees:", callees)
+	}
+	refs := doc.ReferencesTo("example.com/m/sub.N")
+	if pos := refs.Position(); pos.Line != 7 || filepath.Base(pos.Filename) != "c.go" {
+		t.Error("ReferencesTo:", pos)
+	}
+	if err := From("main.go", mainSrc).Callers("fmt.Println").Err; err != ErrNoTypeInfo {
+		t.Error("Callers without TypeCheck:", err)
+	}
+}
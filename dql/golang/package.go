@@ -0,0 +1,124 @@
+/*
+ * Copyright (c) 2026 The XGo Authors (xgo.dev). All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package golang
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"go/parser"
+	"io"
+	"os/exec"
+	"path/filepath"
+	"reflect"
+	"strings"
+
+	"github.com/goplus/xgo/dql/reflects"
+)
+
+// -----------------------------------------------------------------------------
+
+// listedPackage is a package reported by `go list -json`.
+type listedPackage struct {
+	Dir        string
+	ImportPath string
+	GoFiles    []string
+	CgoFiles   []string
+	Error      *struct {
+		Err string
+	}
+}
+
+// Package loads the Go packages matching pattern (like "./...", "./cmd/..."
+// or "fmt", see `go help packages`) relative to the current directory, and
+// returns a NodeSet containing the parsed files (*ast.File) as root nodes, so
+// queries can span multiple packages:
+//
+//	for fn in golang.package("./...").**.*@(self.class == "FuncDecl") {
+//		echo fn.$name, fn.position
+//	}
+//
+// The root nodes are named by the import paths of their packages, and share
+// one file set. Test files are not loaded. An optional Config can be provided
+// to customize the parsing behavior.
+func Package(pattern string, conf ...Config) NodeSet {
+	return load("", pattern, conf)
+}
+
+// Module loads all the Go packages in the module rooted at dir. It's the same
+// as Package("./...") in the directory dir.
+func Module(dir string, conf ...Config) NodeSet {
+	return load(dir, "./...", conf)
+}
+
+func load(dir, pattern string, conf []Config) NodeSet {
+	pkgs, err := goList(dir, pattern)
+	if err != nil {
+		return NodeSet{NodeSet: reflects.NodeSet{Err: err}}
+	}
+	c := configOf(conf)
+	var nodes []Node
+	var errs []error
+	for _, pkg := range pkgs {
+		if pkg.Error != nil {
+			errs = append(errs, fmt.Errorf("golang: %s: %s", pkg.ImportPath, pkg.Error.Err))
+			continue
+		}
+		for _, files := range [][]string{pkg.GoFiles, pkg.CgoFiles} {
+			for _, file := range files {
+				f, err := parser.ParseFile(c.Fset, filepath.Join(pkg.Dir, file), nil, c.Mode)
+				if err != nil {
+					errs = append(errs, err)
+					continue
+				}
+				nodes = append(nodes, Node{Name: pkg.ImportPath, Value: reflect.ValueOf(f)})
+			}
+		}
+	}
+	if len(errs) > 0 {
+		return NodeSet{NodeSet: reflects.NodeSet{Err: errors.Join(errs...)}}
+	}
+	return NodeSet{NodeSet: reflects.Nodes(nodes...), Fset: c.Fset}
+}
+
+// goList runs `go list -e -json` to find the packages matching pattern.
+func goList(dir, pattern string) (pkgs []*listedPackage, err error) {
+	var stdout, stderr bytes.Buffer
+	cmd := exec.Command("go", "list", "-e", "-json=Dir,ImportPath,GoFiles,CgoFiles,Error", pattern)
+	cmd.Dir = dir
+	cmd.Stdout, cmd.Stderr = &stdout, &stderr
+	if err = cmd.Run(); err != nil {
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			err = fmt.Errorf("golang: go list %s: %s", pattern, msg)
+		}
+		return
+	}
+	dec := json.NewDecoder(&stdout)
+	for {
+		pkg := new(listedPackage)
+		if err = dec.Decode(pkg); err != nil {
+			if err == io.EOF {
+				err = nil
+			}
+			return
+		}
+		pkgs = append(pkgs, pkg)
+	}
+}
+
+// -----------------------------------------------------------------------------
@@ -0,0 +1,221 @@
+/*
+ * Copyright (c) 2026 The XGo Authors (xgo.dev). All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package golang
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"os"
+	"reflect"
+	"strings"
+
+	"github.com/goplus/xgo/dql"
+	"github.com/goplus/xgo/dql/reflects"
+)
+
+// -----------------------------------------------------------------------------
+
+// ErrNotSettable is returned when a node can't be modified, like a root node.
+var ErrNotSettable = errors.New("golang: node is not settable")
+
+// Set sets the field name of each node in the NodeSet to value, and modifies
+// the AST in place:
+//
+//	for fn in doc.**.*@(self.class == "FuncDecl" && self.$name == "add") {
+//		fn.set "name", "sum"
+//	}
+//
+// Besides AST nodes, value can be a string, which is converted to an
+// identifier for *ast.Ident fields, or parsed as an expression for ast.Expr
+// fields. value can also be a NodeSet, whose first node is used.
+func (p NodeSet) Set(name string, value any) error {
+	if name == "" {
+		return fmt.Errorf("set: %w", dql.ErrNotFound)
+	}
+	fieldName := strings.ToUpper(name[:1]) + name[1:]
+	return p.each(func(node Node) error {
+		v := node.Value
+		for v.Kind() == reflect.Interface || v.Kind() == reflect.Pointer {
+			v = v.Elem()
+		}
+		if v.Kind() != reflect.Struct {
+			return fmt.Errorf("set %s: %w", name, ErrNotSettable)
+		}
+		field := v.FieldByName(fieldName)
+		if !field.IsValid() {
+			return fmt.Errorf("set %s: %w", name, dql.ErrNotFound)
+		}
+		return setNode(field, value)
+	})
+}
+
+// Replace replaces each node in the NodeSet by fn(node) in the AST:
+//
+//	doc.**.*.callsTo("fmt.Println").replace(call => ...)
+//
+// The result of fn is converted like the value of Set. Root nodes can't be
+// replaced, ErrNotSettable is returned for them.
+func (p NodeSet) Replace(fn func(node NodeSet) any) error {
+	return p.each(func(node Node) error {
+		return setNode(node.Value, fn(p.with(reflects.Root(node))))
+	})
+}
+
+// each calls fn for each node in the NodeSet. The nodes are collected first,
+// so fn can modify the AST safely.
+func (p NodeSet) each(fn func(node Node) error) error {
+	if p.Err != nil {
+		return p.Err
+	}
+	var errs []error
+	for _, node := range dql.Collect(p.Data) {
+		if err := fn(node); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// setNode sets the AST field (or slice element) v to value.
+func setNode(v reflect.Value, value any) error {
+	if !v.CanSet() {
+		return ErrNotSettable
+	}
+	val, err := astValue(value, v.Type())
+	if err != nil {
+		return err
+	}
+	v.Set(val)
+	return nil
+}
+
+// astValue converts value to a value of type typ.
+func astValue(value any, typ reflect.Type) (reflect.Value, error) {
+	switch v := value.(type) {
+	case NodeSet:
+		node, err := v.XGo_first()
+		if err != nil {
+			return reflect.Value{}, err
+		}
+		return astValue(node.Value, typ)
+	case reflect.Value:
+		if v.Kind() == reflect.Interface {
+			v = v.Elem()
+		}
+		if !v.IsValid() {
+			return reflect.Zero(typ), nil
+		}
+		return astValue(v.Interface(), typ)
+	case string:
+		switch {
+		case typ == reflect.TypeFor[*ast.Ident]():
+			return reflect.ValueOf(ast.NewIdent(v)), nil
+		case typ == reflect.TypeFor[ast.Expr]():
+			expr, err := parser.ParseExpr(v)
+			if err != nil {
+				return reflect.Value{}, err
+			}
+			clearPos(expr)
+			return reflect.ValueOf(expr), nil
+		}
+	case nil:
+		switch typ.Kind() {
+		case reflect.Interface, reflect.Pointer, reflect.Slice:
+			return reflect.Zero(typ), nil
+		}
+	}
+	val := reflect.ValueOf(value)
+	if val.IsValid() && val.Type().AssignableTo(typ) {
+		return val, nil
+	}
+	return reflect.Value{}, fmt.Errorf("golang: cannot use %T as %v", value, typ)
+}
+
+// clearPos clears the positions of the parsed node n, since they are not in
+// the file set of the AST where n is inserted.
+func clearPos(n ast.Node) {
+	posType := reflect.TypeFor[token.Pos]()
+	ast.Inspect(n, func(n ast.Node) bool {
+		if v := reflect.ValueOf(n); v.Kind() == reflect.Pointer && !v.IsNil() {
+			v = v.Elem()
+			for i := range v.NumField() {
+				if f := v.Field(i); f.Type() == posType {
+					f.SetInt(0)
+				}
+			}
+		}
+		return true
+	})
+}
+
+// -----------------------------------------------------------------------------
+
+// Format formats the first node in the NodeSet (typically a modified file)
+// in canonical gofmt style, and returns the source code.
+func (p NodeSet) Format() ([]byte, error) {
+	if p.Err != nil {
+		return nil, p.Err
+	}
+	node, err := p.XGo_first()
+	if err != nil {
+		return nil, err
+	}
+	n := astNodeOf(node.Value)
+	if n == nil {
+		return nil, fmt.Errorf("golang: cannot format %v", node.Value.Type())
+	}
+	var buf bytes.Buffer
+	fset := p.Fset
+	if fset == nil {
+		fset = token.NewFileSet()
+	}
+	if err = format.Node(&buf, fset, n); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// Save formats the files (*ast.File) in the NodeSet and writes them back to
+// their source files, which is useful after modifying a NodeSet returned by
+// Package or Module. It requires the file set to locate the files.
+func (p NodeSet) Save() error {
+	if p.Err == nil && p.Fset == nil {
+		return ErrNoFileSet
+	}
+	return p.each(func(node Node) error {
+		f, ok := astNodeOf(node.Value).(*ast.File)
+		if !ok {
+			return nil
+		}
+		var buf bytes.Buffer
+		if err := format.Node(&buf, p.Fset, f); err != nil {
+			return err
+		}
+		filename := p.Fset.Position(f.Package).Filename
+		if filename == "" {
+			return fmt.Errorf("golang: file name of package %s unknown", f.Name.Name)
+		}
+		return os.WriteFile(filename, buf.Bytes(), 0666)
+	})
+}
+
+// -----------------------------------------------------------------------------
@@ -0,0 +1,337 @@
+/*
+ * Copyright (c) 2026 The XGo Authors (xgo.dev). All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package golang
+
+import (
+	"errors"
+	"fmt"
+	"go/ast"
+	"go/importer"
+	"go/token"
+	"go/types"
+	"slices"
+	"strings"
+
+	"github.com/goplus/xgo/dql/reflects"
+)
+
+// -----------------------------------------------------------------------------
+
+var (
+	// ErrNoTypeInfo is returned by type-aware queries if the NodeSet isn't
+	// type-checked. See TypeCheck.
+	ErrNoTypeInfo = errors.New("golang: no type information, call TypeCheck first")
+	// ErrNoFileSet is returned by TypeCheck if the file set is unknown.
+	ErrNoFileSet = errors.New("golang: file set unknown")
+)
+
+// TypeCheck type-checks the files in the NodeSet (the *ast.File nodes) with
+// go/types, and returns a copy of the NodeSet with the type information,
+// which enables type-aware queries like TypeOf, ObjectOf, CallsTo and
+// Implements:
+//
+//	doc := golang`...`.typeCheck
+//	for call in doc.**.*.callsTo("fmt.Println") {
+//		echo call.position
+//	}
+//
+// If the files belong to multiple packages (see Package and Module), each
+// package is checked separately, and Pkg is set to the first one.
+//
+// An optional types.Config can be provided, by default the other imported
+// packages are loaded by importer.Default. Type errors don't fail the type
+// checking, so the type information may be partial for invalid code.
+func (p NodeSet) TypeCheck(conf ...*types.Config) NodeSet {
+	if p.Err != nil {
+		return p
+	}
+	if p.Fset == nil {
+		return NodeSet{NodeSet: reflects.NodeSet{Err: ErrNoFileSet}}
+	}
+	c := &checker{
+		fset:  p.Fset,
+		files: make(map[string][]*ast.File),
+		pkgs:  make(map[string]*types.Package),
+		info: &types.Info{
+			Types:      make(map[ast.Expr]types.TypeAndValue),
+			Defs:       make(map[*ast.Ident]types.Object),
+			Uses:       make(map[*ast.Ident]types.Object),
+			Implicits:  make(map[ast.Node]types.Object),
+			Selections: make(map[*ast.SelectorExpr]*types.Selection),
+		},
+	}
+	var paths []string
+	p.Data(func(node Node) bool {
+		if f, ok := astNodeOf(node.Value).(*ast.File); ok {
+			path := node.Name // the import path of files loaded by Package
+			if path == "" {
+				path = f.Name.Name
+			}
+			if _, ok := c.files[path]; !ok {
+				paths = append(paths, path)
+			}
+			c.files[path] = append(c.files[path], f)
+		}
+		return true
+	})
+	if len(paths) == 0 {
+		return NodeSet{NodeSet: reflects.NodeSet{Err: errors.New("golang: no file to type-check")}}
+	}
+	if len(conf) > 0 && conf[0] != nil {
+		c.conf = *conf[0]
+	}
+	c.importer = c.conf.Importer
+	if c.importer == nil {
+		c.importer = importer.Default()
+	}
+	c.conf.Importer = c
+	if c.conf.Error == nil {
+		c.conf.Error = func(err error) {} // keep going on type errors
+	}
+	for _, path := range paths {
+		c.check(path)
+	}
+	p.Pkg, p.Info = c.pkgs[paths[0]], c.info
+	return p
+}
+
+// checker type-checks the packages of a NodeSet, and imports them from each
+// other.
+type checker struct {
+	conf     types.Config
+	fset     *token.FileSet
+	info     *types.Info
+	files    map[string][]*ast.File    // import path => files
+	pkgs     map[string]*types.Package // import path => checked package
+	importer types.Importer
+}
+
+func (c *checker) Import(path string) (*types.Package, error) {
+	if _, ok := c.files[path]; ok {
+		if pkg := c.check(path); pkg != nil {
+			return pkg, nil
+		}
+		return nil, fmt.Errorf("golang: import cycle via %s", path)
+	}
+	return c.importer.Import(path)
+}
+
+func (c *checker) check(path string) *types.Package {
+	pkg, ok := c.pkgs[path]
+	if !ok {
+		c.pkgs[path] = nil // break import cycles
+		pkg, _ = c.conf.Check(path, c.fset, c.files[path], c.info)
+		c.pkgs[path] = pkg
+	}
+	return pkg
+}
+
+// TypeOf returns the type of the first node in the NodeSet, which is an
+// expression or a declaration (like FuncDecl or TypeSpec). It returns nil if
+// the type is unknown or the NodeSet isn't type-checked.
+func (p NodeSet) TypeOf() types.Type {
+	if p.Info == nil {
+		return nil
+	}
+	return typeOf(p.Info, p.astNode())
+}
+
+// ObjectOf returns the object (types.Var, types.Func, types.TypeName, etc.)
+// denoted by the first node in the NodeSet, which is an identifier, a
+// selector (like fmt.Println), a call (the callee), or a declaration (like
+// FuncDecl or TypeSpec). It returns nil if the object is unknown or the
+// NodeSet isn't type-checked.
+func (p NodeSet) ObjectOf() types.Object {
+	if p.Info == nil {
+		return nil
+	}
+	return objectOf(p.Info, p.astNode())
+}
+
+// CallsTo returns a NodeSet containing the call expressions in the NodeSet
+// which call one of the specified functions. A function is specified by its
+// full name: "fmt.Println", "encoding/json.Marshal", "(*bytes.Buffer).Write",
+// or a builtin like "len".
+func (p NodeSet) CallsTo(funcs ...string) NodeSet {
+	return p.filterTyped(func(info *types.Info, n ast.Node) bool {
+		call, ok := n.(*ast.CallExpr)
+		if !ok {
+			return false
+		}
+		return slices.Contains(funcs, calleeName(info, call))
+	})
+}
+
+// Implements returns a NodeSet containing the nodes in the NodeSet whose types
+// (see TypeOf) implement the specified interface. A type T also matches if *T
+// implements the interface. The interface is specified by its qualified name,
+// like "io.Reader" or "error", or by its name if it's declared in the
+// type-checked package.
+func (p NodeSet) Implements(iface string) NodeSet {
+	if p.Err == nil && p.Pkg != nil {
+		t, err := lookupType(p.Pkg, iface)
+		if err != nil {
+			return NodeSet{NodeSet: reflects.NodeSet{Err: err}}
+		}
+		it, ok := t.Underlying().(*types.Interface)
+		if !ok {
+			return NodeSet{NodeSet: reflects.NodeSet{Err: fmt.Errorf("golang: %s is not an interface", iface)}}
+		}
+		return p.filterTyped(func(info *types.Info, n ast.Node) bool {
+			t := typeOf(info, n)
+			if t == nil || t == types.Typ[types.Invalid] { // like package names
+				return false
+			}
+			if types.Implements(t, it) {
+				return true
+			}
+			_, isPtr := t.Underlying().(*types.Pointer)
+			return !isPtr && !types.IsInterface(t) && types.Implements(types.NewPointer(t), it)
+		})
+	}
+	return p.filterTyped(nil)
+}
+
+// filterTyped returns a NodeSet containing the AST nodes in the NodeSet which
+// satisfy fn. ErrNoTypeInfo is returned if the NodeSet isn't type-checked.
+func (p NodeSet) filterTyped(fn func(info *types.Info, n ast.Node) bool) NodeSet {
+	if p.Err != nil {
+		return p
+	}
+	if p.Info == nil {
+		return NodeSet{NodeSet: reflects.NodeSet{Err: ErrNoTypeInfo}}
+	}
+	info := p.Info
+	return p.with(reflects.NodeSet{
+		Data: func(yield func(Node) bool) {
+			p.Data(func(node Node) bool {
+				if n := astNodeOf(node.Value); n != nil && fn(info, n) {
+					return yield(node)
+				}
+				return true
+			})
+		},
+	})
+}
+
+// -----------------------------------------------------------------------------
+
+func typeOf(info *types.Info, n ast.Node) types.Type {
+	if e, ok := n.(ast.Expr); ok {
+		if t := info.TypeOf(e); t != nil {
+			return t
+		}
+	}
+	if obj := objectOf(info, n); obj != nil {
+		return obj.Type()
+	}
+	return nil
+}
+
+func objectOf(info *types.Info, n ast.Node) types.Object {
+	switch n := n.(type) {
+	case *ast.Ident:
+		return info.ObjectOf(n)
+	case *ast.SelectorExpr:
+		return info.ObjectOf(n.Sel)
+	case *ast.CallExpr:
+		return callee(info, n)
+	case *ast.FuncDecl:
+		return info.Defs[n.Name]
+	case *ast.TypeSpec:
+		return info.Defs[n.Name]
+	case *ast.ImportSpec:
+		return info.PkgNameOf(n)
+	}
+	return nil
+}
+
+// callee returns the function or builtin called by the call expression, or
+// nil for conversions and calls of function values.
+func callee(info *types.Info, call *ast.CallExpr) types.Object {
+	fun := ast.Unparen(call.Fun)
+	switch e := fun.(type) { // instantiation of a generic function
+	case *ast.IndexExpr:
+		fun = e.X
+	case *ast.IndexListExpr:
+		fun = e.X
+	}
+	var obj types.Object
+	switch e := ast.Unparen(fun).(type) {
+	case *ast.Ident:
+		obj = info.Uses[e]
+	case *ast.SelectorExpr:
+		if sel, ok := info.Selections[e]; ok {
+			obj = sel.Obj()
+		} else {
+			obj = info.Uses[e.Sel] // qualified identifier
+		}
+	}
+	switch obj.(type) {
+	case *types.Func, *types.Builtin:
+		return obj
+	}
+	return nil
+}
+
+// calleeName returns the full name of the function called by the call
+// expression (see CallsTo), or "" if it's unknown.
+func calleeName(info *types.Info, call *ast.CallExpr) string {
+	switch obj := callee(info, call).(type) {
+	case *types.Func:
+		return obj.Origin().FullName()
+	case *types.Builtin:
+		return obj.Name()
+	}
+	return ""
+}
+
+// lookupType looks up the type with the qualified name in pkg and the
+// packages it imports (directly or indirectly).
+func lookupType(pkg *types.Package, name string) (types.Type, error) {
+	scope := types.Universe
+	if i := strings.LastIndexByte(name, '.'); i >= 0 {
+		path := name[:i]
+		if scope = findPackageScope(pkg, path, make(map[*types.Package]bool)); scope == nil {
+			return nil, fmt.Errorf("golang: package %s not imported", path)
+		}
+		name = name[i+1:]
+	} else if obj := pkg.Scope().Lookup(name); obj != nil {
+		scope = pkg.Scope()
+	}
+	if tn, ok := scope.Lookup(name).(*types.TypeName); ok {
+		return tn.Type(), nil
+	}
+	return nil, fmt.Errorf("golang: type %s not found", name)
+}
+
+func findPackageScope(pkg *types.Package, path string, visited map[*types.Package]bool) *types.Scope {
+	if pkg.Path() == path {
+		return pkg.Scope()
+	}
+	visited[pkg] = true
+	for _, imp := range pkg.Imports() {
+		if !visited[imp] {
+			if scope := findPackageScope(imp, path, visited); scope != nil {
+				return scope
+			}
+		}
+	}
+	return nil
+}
+
+// -----------------------------------------------------------------------------
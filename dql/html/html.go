@@ -24,7 +24,7 @@ import (
 	"os"
 
 	"github.com/goplus/xgo/dql"
-	"github.com/qiniu/x/stream"
+	"github.com/goplus/xgo/dql/stream"
 	"golang.org/x/net/html"
 )
 
@@ -85,6 +85,7 @@ func New(r io.Reader) NodeSet {
 
 // Source creates a NodeSet from various types of sources:
 // - string: treated as an URL to read HTML content from.
+// - *stream.Request: opens the resource with the request options (like HTTP headers).
 // - []byte: treated as raw HTML content.
 // - io.Reader: reads HTML content from the reader.
 // - *Node: creates a NodeSet containing the single provided node.
@@ -100,6 +101,13 @@ func Source(r any) (ret NodeSet) {
 		}
 		defer f.Close()
 		return New(f)
+	case *stream.Request:
+		f, err := v.Open()
+		if err != nil {
+			return NodeSet{Err: err}
+		}
+		defer f.Close()
+		return New(f)
 	case []byte:
 		r := bytes.NewReader(v)
 		return New(r)
@@ -0,0 +1,346 @@
+/*
+ * Copyright (c) 2026 The XGo Authors (xgo.dev). All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package http
+
+import (
+	"encoding/json"
+	"fmt"
+	"iter"
+	nethttp "net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/goplus/xgo/dql/maps"
+	"github.com/goplus/xgo/dql/stream"
+)
+
+const (
+	XGoPackage = "github.com/goplus/xgo/dql/maps"
+)
+
+// -----------------------------------------------------------------------------
+
+// Node represents an object of the responses.
+type Node = maps.Node
+
+// NodeSet represents a set of objects.
+type NodeSet = maps.NodeSet
+
+// Config represents the configuration of fetching a paginated API.
+type Config struct {
+	// Items is the path (separated by dots, like "data.items") of the array
+	// of objects in a response. If it's "", a response that is an array is
+	// the objects, and other responses are an object each.
+	Items string
+
+	// Paginate returns the request of the next page, nil means only one page
+	// is fetched. See Link, Cursor and PageNumber.
+	Paginate Paginator
+
+	// MaxPages limits the number of pages to fetch, 0 means no limit.
+	MaxPages int
+
+	// OnError is called if an error occurs while fetching pages.
+	OnError func(error)
+}
+
+// Page represents a fetched page.
+type Page struct {
+	Request *stream.Request
+	Header  nethttp.Header
+	Body    any   // decoded JSON of the response
+	Items   []any // objects of the page
+}
+
+// Paginator returns the request of the next page, or nil if page is the last
+// one.
+type Paginator func(page *Page) *stream.Request
+
+// defaultRetry is the retry policy of requests without one, so requests
+// limited by the rate (status 429) are retried after Retry-After.
+var defaultRetry = stream.RetryPolicy{Max: 3, Wait: time.Second}
+
+// maxRateLimitWait is the maximum time to wait for the rate limit to reset.
+const maxRateLimitWait = time.Minute
+
+var sleep = time.Sleep
+
+// New returns a NodeSet of the objects of the pages fetched by the request
+// and the following requests returned by conf.Paginate. Responses must be
+// JSON:
+//
+//	conf := http.Config{Items: "items", Paginate: http.link()}
+//	for repo in http.new(stream.newRequest(url).setBearer(token), conf) {
+//		echo repo.$full_name
+//	}
+//
+// Pages are fetched lazily, so the NodeSet can only be iterated once (use
+// _all to traverse the objects multiple times). If the rate limit is
+// exhausted (by the headers X-RateLimit-Remaining and X-RateLimit-Reset, or
+// RateLimit-Remaining and RateLimit-Reset), the next page is fetched after
+// the limit resets (or maxRateLimitWait).
+func New(req *stream.Request, conf ...Config) NodeSet {
+	var c Config
+	if conf != nil {
+		c = conf[0]
+	}
+	return NodeSet{
+		Data: func(yield func(Node) bool) {
+			for n := 0; req != nil && (c.MaxPages <= 0 || n < c.MaxPages); n++ {
+				page, err := fetch(req, c.Items)
+				if err != nil {
+					if c.OnError != nil {
+						c.OnError(err)
+					}
+					return
+				}
+				for _, item := range page.Items {
+					if !yield(Node{Value: item}) {
+						return
+					}
+				}
+				if c.Paginate == nil {
+					return
+				}
+				if req = c.Paginate(page); req != nil {
+					rateLimit(page.Header)
+				}
+			}
+		},
+	}
+}
+
+// Get returns a NodeSet of the objects of the pages starting from url. See
+// New.
+func Get(url string, conf ...Config) NodeSet {
+	return New(stream.NewRequest(url), conf...)
+}
+
+func fetch(req *stream.Request, items string) (page *Page, err error) {
+	if req.Retry.Max == 0 {
+		r := *req
+		r.Retry = defaultRetry
+		req = &r
+	}
+	resp, err := req.Do()
+	if err != nil {
+		return
+	}
+	defer resp.Body.Close()
+	var body any
+	if err = json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		return nil, fmt.Errorf("http: %s: %w", req.URL, err)
+	}
+	page = &Page{Request: req, Header: resp.Header, Body: body}
+	v := body
+	if items != "" {
+		if v = lookup(body, items); v == nil {
+			return
+		}
+	}
+	if arr, ok := v.([]any); ok {
+		page.Items = arr
+	} else if items != "" {
+		return nil, fmt.Errorf("http: %s: %s isn't an array", req.URL, items)
+	} else {
+		page.Items = []any{v}
+	}
+	return
+}
+
+// lookup returns the value of the path (separated by dots) in v, or nil if
+// it doesn't exist.
+func lookup(v any, path string) any {
+	for name := range strings.SplitSeq(path, ".") {
+		m, ok := v.(map[string]any)
+		if !ok {
+			return nil
+		}
+		v = m[name]
+	}
+	return v
+}
+
+// rateLimit waits for the rate limit to reset if it's exhausted.
+func rateLimit(h nethttp.Header) {
+	for _, prefix := range []string{"X-RateLimit-", "RateLimit-"} {
+		if h.Get(prefix+"Remaining") != "0" {
+			continue
+		}
+		reset, err := strconv.ParseInt(h.Get(prefix+"Reset"), 10, 64)
+		if err != nil {
+			continue
+		}
+		wait := time.Duration(reset) * time.Second
+		if reset > 1e9 { // a Unix time, otherwise seconds to wait
+			wait = time.Until(time.Unix(reset, 0))
+		}
+		if wait > 0 {
+			sleep(min(wait, maxRateLimitWait))
+		}
+		return
+	}
+}
+
+// -----------------------------------------------------------------------------
+
+// Link returns a Paginator following the "next" links in the Link headers of
+// responses (see RFC 8288), like the GitHub API.
+func Link() Paginator {
+	return func(page *Page) *stream.Request {
+		for _, h := range page.Header.Values("Link") {
+			if next := nextLink(h); next != "" {
+				return withURL(page.Request, next)
+			}
+		}
+		return nil
+	}
+}
+
+// nextLink returns the URL of the link with rel="next" in a Link header.
+func nextLink(h string) string {
+	for link := range strings.SplitSeq(h, ",") {
+		target, params, ok := strings.Cut(strings.TrimSpace(link), ";")
+		if !ok || !strings.HasPrefix(target, "<") || !strings.HasSuffix(target, ">") {
+			continue
+		}
+		for param := range strings.SplitSeq(params, ";") {
+			k, v, _ := strings.Cut(strings.TrimSpace(param), "=")
+			if strings.EqualFold(k, "rel") && hasToken(strings.Trim(v, `"`), "next") {
+				return target[1 : len(target)-1]
+			}
+		}
+	}
+	return ""
+}
+
+func hasToken(s, token string) bool {
+	for _, v := range strings.Fields(s) {
+		if strings.EqualFold(v, token) {
+			return true
+		}
+	}
+	return false
+}
+
+// Cursor returns a Paginator of cursor-based pagination: the cursor of the
+// next page is the value of field (a path separated by dots, like
+// "meta.next_cursor") in a response, which is passed as the query parameter
+// param of the next request. It stops if the cursor is empty (or unchanged)
+// or the page has no objects.
+func Cursor(param, field string) Paginator {
+	return func(page *Page) *stream.Request {
+		var cursor string
+		switch v := lookup(page.Body, field).(type) {
+		case string:
+			cursor = v
+		case float64:
+			cursor = strconv.FormatFloat(v, 'f', -1, 64)
+		}
+		if cursor == "" || len(page.Items) == 0 || cursor == query(page.Request, param) {
+			return nil
+		}
+		return withQuery(page.Request, param, cursor)
+	}
+}
+
+// PageNumber returns a Paginator of page-numbered pagination: the page number
+// is the query parameter param, starting from the number in the first request
+// (1 if it's absent). It stops at the first page without objects.
+func PageNumber(param string) Paginator {
+	return func(page *Page) *stream.Request {
+		if len(page.Items) == 0 {
+			return nil
+		}
+		n := 1
+		if v := query(page.Request, param); v != "" {
+			var err error
+			if n, err = strconv.Atoi(v); err != nil {
+				return nil
+			}
+		}
+		return withQuery(page.Request, param, strconv.Itoa(n+1))
+	}
+}
+
+// query returns the query parameter key of req.
+func query(req *stream.Request, key string) string {
+	u, err := url.Parse(req.URL)
+	if err != nil {
+		return ""
+	}
+	return u.Query().Get(key)
+}
+
+// withURL returns a copy of req with the URL ref resolved against req.URL.
+func withURL(req *stream.Request, ref string) *stream.Request {
+	base, err := url.Parse(req.URL)
+	if err != nil {
+		return nil
+	}
+	u, err := base.Parse(ref)
+	if err != nil {
+		return nil
+	}
+	r := *req
+	r.URL = u.String()
+	return &r
+}
+
+// withQuery returns a copy of req with the query parameter key set to val.
+func withQuery(req *stream.Request, key, val string) *stream.Request {
+	u, err := url.Parse(req.URL)
+	if err != nil {
+		return nil
+	}
+	q := u.Query()
+	q.Set(key, val)
+	u.RawQuery = q.Encode()
+	r := *req
+	r.URL = u.String()
+	return &r
+}
+
+// -----------------------------------------------------------------------------
+
+// Source creates a NodeSet from various source types:
+// - string: treats the string as the URL of the first page (see Get).
+// - *stream.Request: the request of the first page (see New).
+// - Node: creates a NodeSet containing the single provided node.
+// - iter.Seq[Node]: directly uses the provided sequence of nodes.
+// - NodeSet: returns the provided NodeSet as is.
+// If the source type is unsupported, it panics.
+func Source(r any, conf ...Config) (ret NodeSet) {
+	switch v := r.(type) {
+	case string:
+		return Get(v, conf...)
+	case *stream.Request:
+		return New(v, conf...)
+	case Node:
+		return maps.Root(v)
+	case iter.Seq[Node]:
+		return NodeSet{Data: v}
+	case NodeSet:
+		return v
+	default:
+		panic("dql/http.Source: unsupported source type")
+	}
+}
+
+// -----------------------------------------------------------------------------
@@ -0,0 +1,118 @@
+/*
+ * Copyright (c) 2026 The XGo Authors (xgo.dev). All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package http
+
+import (
+	"fmt"
+	"io"
+	nethttp "net/http"
+	"net/http/httptest"
+	"reflect"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/goplus/xgo/dql/stream"
+)
+
+func ids(ns NodeSet) (ret []int) {
+	for obj := range ns.XGo_Enum() {
+		id, _ := obj.XGo_Elem("id").Int()
+		ret = append(ret, id)
+	}
+	return
+}
+
+func TestPaginate(t *testing.T) {
+	var waits []time.Duration
+	sleep = func(d time.Duration) { waits = append(waits, d) }
+	defer func() { sleep = time.Sleep }()
+
+	ts := httptest.NewServer(nethttp.HandlerFunc(func(w nethttp.ResponseWriter, r *nethttp.Request) {
+		page, _ := strconv.Atoi(r.URL.Query().Get("page"))
+		switch r.URL.Path {
+		case "/link": // pages 0, 1 and 2 linked by the Link headers
+			if page < 2 {
+				w.Header().Set("Link", fmt.Sprintf(`</link?page=%d>; rel="next", </link?page=2>; rel="last"`, page+1))
+			}
+			fmt.Fprintf(w, `[{"id":%d},{"id":%d}]`, page*2, page*2+1)
+		case "/cursor":
+			next := map[string]string{"": "b", "b": "c", "c": ""}[r.URL.Query().Get("after")]
+			w.Header().Set("X-RateLimit-Remaining", "0")
+			w.Header().Set("X-RateLimit-Reset", "2")
+			fmt.Fprintf(w, `{"data":{"items":[{"id":%d}]},"meta":{"next":%q}}`, len(next), next)
+		case "/page": // 2 pages of objects
+			if page > 2 {
+				io.WriteString(w, `{"items":[]}`)
+				return
+			}
+			fmt.Fprintf(w, `{"items":[{"id":%d}]}`, page)
+		case "/object":
+			io.WriteString(w, `{"id":7}`)
+		default:
+			nethttp.NotFound(w, r)
+		}
+	}))
+	defer ts.Close()
+
+	if got := ids(Get(ts.URL+"/link", Config{Paginate: Link()})); !reflect.DeepEqual(got, []int{0, 1, 2, 3, 4, 5}) {
+		t.Fatal("Link:", got)
+	}
+	conf := Config{Paginate: Link(), MaxPages: 2}
+	if got := ids(Source(ts.URL+"/link", conf)); !reflect.DeepEqual(got, []int{0, 1, 2, 3}) {
+		t.Fatal("MaxPages:", got)
+	}
+
+	conf = Config{Items: "data.items", Paginate: Cursor("after", "meta.next")}
+	if got := ids(Source(stream.NewRequest(ts.URL+"/cursor"), conf)); !reflect.DeepEqual(got, []int{1, 1, 0}) {
+		t.Fatal("Cursor:", got)
+	}
+	if !reflect.DeepEqual(waits, []time.Duration{2 * time.Second, 2 * time.Second}) {
+		t.Fatal("rate limit:", waits)
+	}
+
+	conf = Config{Items: "items", Paginate: PageNumber("page")}
+	if got := ids(Get(ts.URL+"/page?page=1", conf)); !reflect.DeepEqual(got, []int{1, 2}) {
+		t.Fatal("PageNumber:", got)
+	}
+	if got := ids(Get(ts.URL + "/object")); !reflect.DeepEqual(got, []int{7}) {
+		t.Fatal("object:", got)
+	}
+
+	var errs []error
+	onErr := func(err error) { errs = append(errs, err) }
+	ids(Get(ts.URL+"/none", Config{OnError: onErr}))
+	ids(Get(ts.URL+"/object", Config{Items: "id", OnError: onErr}))
+	if len(errs) != 2 {
+		t.Fatal("errors:", errs)
+	}
+}
+
+func TestNextLink(t *testing.T) {
+	cases := map[string]string{
+		`<https://api/x?page=2>; rel="next"`:                  "https://api/x?page=2",
+		`<https://api/x?page=1>; rel="prev", <y>; rel="next"`: "y",
+		`<z>; title="a"; rel="next last"`:                     "z",
+		`<https://api/x?page=9>; rel="last"`:                  "",
+		`https://api/x; rel="next"`:                           "",
+	}
+	for h, want := range cases {
+		if got := nextLink(h); got != want {
+			t.Errorf("nextLink(%q) = %q, want %q", h, got, want)
+		}
+	}
+}
@@ -0,0 +1,223 @@
+/*
+ * Copyright (c) 2026 The XGo Authors (xgo.dev). All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package ini
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io"
+	"iter"
+	"strings"
+
+	"github.com/goplus/xgo/dql/maps"
+	"github.com/goplus/xgo/dql/stream"
+)
+
+const (
+	XGoPackage = "github.com/goplus/xgo/dql/maps"
+)
+
+// -----------------------------------------------------------------------------
+
+// Node represents a map[string]any or []any node.
+type Node = maps.Node
+
+// NodeSet represents a set of INI nodes.
+type NodeSet = maps.NodeSet
+
+// New creates an INI NodeSet from INI data read from r. It reads INI files,
+// properties files, systemd units and git config files:
+//
+//   - Sections are elements of the root node, and keys are attributes of
+//     their sections (keys before the first section are attributes of the
+//     root node):
+//     doc.Service.$ExecStart
+//   - A subsection like [remote "origin"] of git config is an element of its
+//     section: doc.remote.origin.$url
+//   - Keys and values are separated by '=' or ':'. A key without a value
+//     (like "bare" of git config) is true.
+//   - Values enclosed in double quotes are unquoted, and a backslash at the
+//     end of a line continues the value on the next line.
+//   - A key which appears more than once has a []any value of all the values,
+//     and a section which appears more than once is merged.
+//   - Lines beginning with '#', ';' or '!' are comments.
+func New(r io.Reader) NodeSet {
+	doc, err := parse(r)
+	if err != nil {
+		return NodeSet{Err: err}
+	}
+	return maps.New(doc)
+}
+
+// Source creates an INI NodeSet from various source types:
+// - string: treats the string as a file path, opens the file, and reads INI data from it.
+// - *stream.Request: opens the resource with the request options (like HTTP headers).
+// - []byte: reads INI data from the byte slice.
+// - io.Reader: reads INI data from the provided reader.
+// - map[string]any: creates a NodeSet from the provided map.
+// - Node: creates a NodeSet containing the single provided node.
+// - iter.Seq[Node]: directly uses the provided sequence of nodes.
+// - NodeSet: returns the provided NodeSet as is.
+// If the source type is unsupported, it panics.
+func Source(r any) (ret NodeSet) {
+	switch v := r.(type) {
+	case string:
+		f, err := stream.Open(v)
+		if err != nil {
+			return NodeSet{Err: err}
+		}
+		defer f.Close()
+		return New(f)
+	case *stream.Request:
+		f, err := v.Open()
+		if err != nil {
+			return NodeSet{Err: err}
+		}
+		defer f.Close()
+		return New(f)
+	case []byte:
+		return New(bytes.NewReader(v))
+	case io.Reader:
+		return New(v)
+	case map[string]any:
+		return maps.New(v)
+	case Node:
+		return maps.Root(v)
+	case iter.Seq[Node]:
+		return NodeSet{Data: v}
+	case NodeSet:
+		return v
+	default:
+		panic("dql/ini.Source: unsupported source type")
+	}
+}
+
+// -----------------------------------------------------------------------------
+
+func parse(r io.Reader) (doc map[string]any, err error) {
+	doc = make(map[string]any)
+	sect := doc
+	s := bufio.NewScanner(r)
+	s.Buffer(nil, 1<<20)
+	lineno := 0
+	for s.Scan() {
+		lineno++
+		line := strings.TrimSpace(s.Text())
+		for strings.HasSuffix(line, `\`) && s.Scan() {
+			lineno++
+			line = line[:len(line)-1] + strings.TrimSpace(s.Text())
+		}
+		if line == "" || strings.ContainsRune("#;!", rune(line[0])) {
+			continue
+		}
+		if line[0] == '[' {
+			end := strings.IndexByte(line, ']')
+			if end < 0 {
+				return nil, fmt.Errorf("ini: line %d: missing ']' of section", lineno)
+			}
+			if sect, err = section(doc, line[1:end]); err != nil {
+				return nil, fmt.Errorf("ini: line %d: %w", lineno, err)
+			}
+			continue
+		}
+		key, val, ok := cutKey(line)
+		if key == "" {
+			return nil, fmt.Errorf("ini: line %d: missing key", lineno)
+		}
+		var v any = true
+		if ok {
+			v = unquote(val)
+		}
+		switch old := sect[key].(type) {
+		case nil:
+			sect[key] = v
+		case []any:
+			sect[key] = append(old, v)
+		case map[string]any:
+			return nil, fmt.Errorf("ini: line %d: key %q conflicts with a section", lineno, key)
+		default:
+			sect[key] = []any{old, v}
+		}
+	}
+	return doc, s.Err()
+}
+
+// section returns the section (or the subsection) of the specified name in
+// doc, and creates it if it doesn't exist.
+func section(doc map[string]any, name string) (map[string]any, error) {
+	name = strings.TrimSpace(name)
+	var names []string
+	if i := strings.IndexAny(name, " \t"); i > 0 {
+		sub := strings.TrimSpace(name[i:])
+		names = []string{name[:i], unquote(sub)}
+	} else {
+		names = []string{name}
+	}
+	sect := doc
+	for _, name := range names {
+		switch v := sect[name].(type) {
+		case map[string]any:
+			sect = v
+		case nil:
+			sub := make(map[string]any)
+			sect[name] = sub
+			sect = sub
+		default:
+			return nil, fmt.Errorf("section %q conflicts with a key", name)
+		}
+	}
+	return sect, nil
+}
+
+// cutKey splits a line into the key and the value by the first '=' or ':'.
+func cutKey(line string) (key, val string, ok bool) {
+	i := strings.IndexAny(line, "=:")
+	if i < 0 {
+		return line, "", false
+	}
+	return strings.TrimSpace(line[:i]), strings.TrimSpace(line[i+1:]), true
+}
+
+// unquote removes the double quotes enclosing s, and unescapes \", \\, \n
+// and \t in it.
+func unquote(s string) string {
+	if len(s) < 2 || s[0] != '"' || s[len(s)-1] != '"' {
+		return s
+	}
+	s = s[1 : len(s)-1]
+	if !strings.Contains(s, `\`) {
+		return s
+	}
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		if c == '\\' && i+1 < len(s) {
+			i++
+			switch c = s[i]; c {
+			case 'n':
+				c = '\n'
+			case 't':
+				c = '\t'
+			}
+		}
+		b.WriteByte(c)
+	}
+	return b.String()
+}
+
+// -----------------------------------------------------------------------------
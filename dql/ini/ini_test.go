@@ -0,0 +1,88 @@
+/*
+ * Copyright (c) 2026 The XGo Authors (xgo.dev). All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package ini
+
+import (
+	"strings"
+	"testing"
+)
+
+const gitConfig = `# git config
+[core]
+	bare
+	filemode = false
+[remote "origin"]
+	url = git@github.com:goplus/xgo.git
+	fetch = +refs/heads/*:refs/remotes/origin/*
+[alias]
+	lg = "log --graph \"--format=%h %s\""
+`
+
+const unit = `[Unit]
+Description=Demo \
+  service
+
+[Service]
+ExecStart=/usr/bin/demo
+Environment=A=1
+Environment=B=2
+; comment
+`
+
+func TestGitConfig(t *testing.T) {
+	doc := Source([]byte(gitConfig))
+	core := doc.XGo_Elem("core")
+	if bare, err := core.XGo_Elem("bare").Bool(); err != nil || !bare {
+		t.Fatal("core.bare:", bare, err)
+	}
+	if filemode, err := core.XGo_Elem("filemode").Bool(); err != nil || filemode {
+		t.Fatal("core.filemode:", filemode, err)
+	}
+	if url := doc.XGo_Elem("remote").XGo_Elem("origin").XGo_Attr__0("url"); url != "git@github.com:goplus/xgo.git" {
+		t.Fatal("remote.origin.url:", url)
+	}
+	if lg := doc.XGo_Elem("alias").XGo_Attr__0("lg"); lg != `log --graph "--format=%h %s"` {
+		t.Fatal("alias.lg:", lg)
+	}
+}
+
+func TestUnit(t *testing.T) {
+	doc := Source(strings.NewReader("Global = 1\n" + unit))
+	if v := doc.XGo_Attr__0("Global"); v != "1" {
+		t.Fatal("Global:", v)
+	}
+	if v := doc.XGo_Elem("Unit").XGo_Attr__0("Description"); v != "Demo service" {
+		t.Fatal("Description:", v)
+	}
+	var envs []string
+	for env := range doc.XGo_Elem("Service").XGo_Elem("Environment").XGo_Child().XGo_Enum() {
+		envs = append(envs, env.XGo_value__0().(string))
+	}
+	if strings.Join(envs, ",") != "A=1,B=2" {
+		t.Fatal("Environment:", envs)
+	}
+
+	merged := Source([]byte("[a]\nx=1\n[b]\n[a]\ny=2"))
+	if x, y := merged.XGo_Elem("a").XGo_Attr__0("x"), merged.XGo_Elem("a").XGo_Attr__0("y"); x != "1" || y != "2" {
+		t.Fatal("merged section:", x, y)
+	}
+	for _, data := range []string{"[a\nb=1", "=1", "a=1\n[a]", "[remote]\norigin=1\n[remote \"origin\"]"} {
+		if ns := Source([]byte(data)); ns.Err == nil {
+			t.Errorf("%q: no error", data)
+		}
+	}
+}
@@ -23,7 +23,7 @@ import (
 	"iter"
 
 	"github.com/goplus/xgo/dql/maps"
-	"github.com/qiniu/x/stream"
+	"github.com/goplus/xgo/dql/stream"
 )
 
 const (
@@ -48,8 +48,24 @@ func New(r io.Reader) NodeSet {
 	return maps.New(data)
 }
 
+// NewNumber creates a JSON NodeSet from JSON data read from r, like New, but
+// decodes numbers as json.Number instead of float64, so integers beyond 2^53
+// (like 64-bit IDs) keep their precision. The typed accessors (Int, Int64,
+// Str, etc.) and JSONPath filters of the NodeSet handle json.Number values.
+func NewNumber(r io.Reader) NodeSet {
+	var data any
+	dec := json.NewDecoder(r)
+	dec.UseNumber()
+	err := dec.Decode(&data)
+	if err != nil {
+		return NodeSet{Err: err}
+	}
+	return maps.New(data)
+}
+
 // Source creates a JSON NodeSet from various source types:
 // - string: treats the string as a file path, opens the file, and reads JSON data from it.
+// - *stream.Request: opens the resource with the request options (like HTTP headers).
 // - []byte: reads JSON data from the byte slice.
 // - io.Reader: reads JSON data from the provided reader.
 // - map[string]any: creates a NodeSet from the provided map.
@@ -67,6 +83,13 @@ func Source(r any) (ret NodeSet) {
 		}
 		defer f.Close()
 		return New(f)
+	case *stream.Request:
+		f, err := v.Open()
+		if err != nil {
+			return NodeSet{Err: err}
+		}
+		defer f.Close()
+		return New(f)
 	case []byte:
 		r := bytes.NewReader(v)
 		return New(r)
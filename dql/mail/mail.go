@@ -0,0 +1,258 @@
+/*
+ * Copyright (c) 2026 The XGo Authors (xgo.dev). All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package mail
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"iter"
+
+	"github.com/goplus/xgo/dql/maps"
+	"github.com/goplus/xgo/dql/stream"
+)
+
+const (
+	XGoPackage = "github.com/goplus/xgo/dql/maps"
+)
+
+// -----------------------------------------------------------------------------
+
+// Node represents a message or a MIME part, whose value is a map[string]any
+// of its attributes.
+type Node = maps.Node
+
+// NodeSet represents a set of messages.
+type NodeSet = maps.NodeSet
+
+// Config specifies how mail is read.
+type Config struct {
+	// OnError is called if a message can't be parsed (the message is
+	// skipped), or an error occurs while reading.
+	OnError func(error)
+}
+
+// ErrNoContent is returned by Content if a part has no content, like
+// multipart parts.
+var ErrNoContent = errors.New("mail: no content")
+
+// MessageError is passed to Config.OnError if a message can't be parsed.
+type MessageError struct {
+	Index int // index of the message in the mailbox, starting at 0
+	Err   error
+}
+
+func (e *MessageError) Error() string {
+	return fmt.Sprintf("mail: message %d: %v", e.Index, e.Err)
+}
+
+func (e *MessageError) Unwrap() error {
+	return e.Err
+}
+
+// New creates a NodeSet of the messages read from r, which is an mbox
+// mailbox (if it starts with a "From " line) or a single message (like an
+// .eml file). Each message is a node named "message" with attributes:
+//   - from, to, cc: the email addresses of the header fields.
+//   - subject, date, messageId, inReplyTo: the header fields (decoded), and
+//     the date is in the RFC 3339 form.
+//   - text, html: the first text/plain and text/html body, nil if none.
+//   - header: all header fields, each is a string, or a list of strings if
+//     the field occurs multiple times.
+//   - contentType, disposition, filename, parts, content, size: see below.
+//
+// A message is also its top-level MIME part. Parts have attributes
+// contentType, disposition, filename and header. A multipart part has parts
+// as children (and an enclosed message/rfc822 part has the message), and
+// other parts have the decoded content ([]byte, see Content) and its size,
+// and the text of text parts. Attachments are parts whose disposition is
+// "attachment":
+//
+//	for att in mail.source("inbox.mbox").**.*@($disposition == "attachment") {
+//		echo att.$filename, att.$size
+//	}
+//
+// Messages of a mailbox are parsed one by one while iterating, so the
+// NodeSet can only be iterated once (use _all to traverse the messages
+// multiple times).
+func New(r io.Reader, conf ...Config) NodeSet {
+	var c Config
+	if conf != nil {
+		c = conf[0]
+	}
+	return NodeSet{
+		Data: func(yield func(Node) bool) {
+			i := 0
+			for b, err := range messages(r) {
+				if err != nil {
+					if c.OnError != nil {
+						c.OnError(err)
+					}
+					return
+				}
+				msg, err := message(b, 0)
+				if err != nil {
+					if c.OnError != nil {
+						c.OnError(&MessageError{Index: i, Err: err})
+					}
+				} else if !yield(Node{Name: "message", Value: msg}) {
+					return
+				}
+				i++
+			}
+		},
+	}
+}
+
+// Content returns a reader of the decoded content of the first part in ps.
+func Content(ps NodeSet) (io.Reader, error) {
+	part, err := ps.XGo_first()
+	if err != nil {
+		return nil, err
+	}
+	if m, ok := part.Value.(map[string]any); ok {
+		if b, ok := m["content"].([]byte); ok {
+			return bytes.NewReader(b), nil
+		}
+	}
+	return nil, ErrNoContent
+}
+
+// Source creates a NodeSet of messages from various source types:
+// - string: treats the string as a file path (or URL), opens it, and reads the messages from it.
+// - *stream.Request: opens the resource with the request options (like HTTP headers).
+// - []byte: reads the messages from the byte slice.
+// - io.Reader: reads the messages from the provided reader.
+// - Node: creates a NodeSet containing the single provided node.
+// - iter.Seq[Node]: directly uses the provided sequence of nodes.
+// - NodeSet: returns the provided NodeSet as is.
+// If the source type is unsupported, it panics.
+//
+// A file (or a resource) is closed after the messages are iterated.
+func Source(r any, conf ...Config) (ret NodeSet) {
+	switch v := r.(type) {
+	case string:
+		f, err := stream.Open(v)
+		if err != nil {
+			return NodeSet{Err: err}
+		}
+		return closeAfter(New(f, conf...), f)
+	case *stream.Request:
+		f, err := v.Open()
+		if err != nil {
+			return NodeSet{Err: err}
+		}
+		return closeAfter(New(f, conf...), f)
+	case []byte:
+		return New(bytes.NewReader(v), conf...)
+	case io.Reader:
+		return New(v, conf...)
+	case Node:
+		return maps.Root(v)
+	case iter.Seq[Node]:
+		return NodeSet{Data: v}
+	case NodeSet:
+		return v
+	default:
+		panic("dql/mail.Source: unsupported source type")
+	}
+}
+
+// closeAfter closes f after the nodes of ns are iterated.
+func closeAfter(ns NodeSet, f io.Closer) NodeSet {
+	data := ns.Data
+	ns.Data = func(yield func(Node) bool) {
+		defer f.Close()
+		data(yield)
+	}
+	return ns
+}
+
+// -----------------------------------------------------------------------------
+
+var fromLine = []byte("From ")
+
+// messages returns the raw messages read from r. See New.
+func messages(r io.Reader) iter.Seq2[[]byte, error] {
+	return func(yield func([]byte, error) bool) {
+		br := bufio.NewReader(r)
+		head, err := br.Peek(len(fromLine))
+		if err != nil && err != io.EOF {
+			yield(nil, err)
+			return
+		}
+		if !bytes.Equal(head, fromLine) {
+			b, err := io.ReadAll(br)
+			if err == nil && len(bytes.TrimSpace(b)) == 0 {
+				return
+			}
+			yield(b, err)
+			return
+		}
+		var msg []byte
+		blank := true // the previous line is blank
+		started := false
+		for {
+			line, err := br.ReadBytes('\n')
+			if len(line) > 0 {
+				isBlank := len(bytes.TrimRight(line, "\r\n")) == 0
+				switch {
+				case blank && bytes.HasPrefix(line, fromLine):
+					if started && !yield(trimBlank(msg), nil) {
+						return
+					}
+					msg, started = msg[:0:0], true
+				case isFrom(line):
+					msg = append(msg, line[1:]...) // unescape >From (see mboxrd)
+				default:
+					msg = append(msg, line...)
+				}
+				blank = isBlank
+			}
+			if err != nil {
+				if err != io.EOF {
+					yield(nil, err)
+				} else if started {
+					yield(trimBlank(msg), nil)
+				}
+				return
+			}
+		}
+	}
+}
+
+// isFrom reports whether line is an escaped "From " line, like ">From " or
+// ">>From ".
+func isFrom(line []byte) bool {
+	s := bytes.TrimLeft(line, ">")
+	return len(s) < len(line) && bytes.HasPrefix(s, fromLine)
+}
+
+// trimBlank removes the blank line separating a message from the next one.
+func trimBlank(msg []byte) []byte {
+	if b, ok := bytes.CutSuffix(msg, []byte("\r\n\r\n")); ok {
+		return append(b, "\r\n"...)
+	}
+	if b, ok := bytes.CutSuffix(msg, []byte("\n\n")); ok {
+		return append(b, '\n')
+	}
+	return msg
+}
+
+// -----------------------------------------------------------------------------
@@ -0,0 +1,143 @@
+/*
+ * Copyright (c) 2026 The XGo Authors (xgo.dev). All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package mail
+
+import (
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+const mbox = `From ann@xgo.dev Mon Jan  5 10:00:00 2026
+From: Ann <ann@xgo.dev>
+To: bob@xgo.dev, "Carl" <carl@xgo.dev>
+Subject: =?UTF-8?B?5L2g5aW9?= report
+Date: Mon, 5 Jan 2026 10:00:00 +0800
+Message-ID: <1@xgo.dev>
+X-Tag: a
+X-Tag: b
+MIME-Version: 1.0
+Content-Type: multipart/mixed; boundary="outer"
+
+--outer
+Content-Type: multipart/alternative; boundary="inner"
+
+--inner
+Content-Type: text/plain; charset=utf-8
+Content-Transfer-Encoding: quoted-printable
+
+Hello=2C world
+>From here on
+--inner
+Content-Type: text/html
+
+<p>Hello</p>
+--inner--
+--outer
+Content-Type: application/octet-stream; name="data.bin"
+Content-Disposition: attachment; filename="report.csv"
+Content-Transfer-Encoding: base64
+
+aWQsbmFtZQox
+LGFubgo=
+--outer--
+
+From bob@xgo.dev Tue Jan  6 10:00:00 2026
+From: bob@xgo.dev
+Subject: broken
+Content-Type: multipart/mixed; boundary="none"
+
+no parts
+
+From carl@xgo.dev Wed Jan  7 10:00:00 2026
+From: carl@xgo.dev
+To: ann@xgo.dev
+Subject: Re: report
+In-Reply-To: <1@xgo.dev>
+Content-Type: text/plain; charset=iso-8859-1
+
+caf` + "\xe9" + `
+`
+
+func TestMbox(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "inbox.mbox")
+	if err := os.WriteFile(file, []byte(mbox), 0644); err != nil {
+		t.Fatal(err)
+	}
+	var errs []error
+	var msgs []map[string]any
+	for msg := range Source(file, Config{OnError: func(err error) { errs = append(errs, err) }}).XGo_Enum() {
+		node, _ := msg.XGo_first()
+		msgs = append(msgs, node.Value.(map[string]any))
+	}
+	var e *MessageError
+	if len(msgs) != 2 || len(errs) != 1 || !errors.As(errs[0], &e) || e.Index != 1 {
+		t.Fatal("messages:", len(msgs), errs)
+	}
+
+	msg := msgs[0]
+	if msg["from"] != "ann@xgo.dev" || !reflect.DeepEqual(msg["to"], []any{"bob@xgo.dev", "carl@xgo.dev"}) ||
+		msg["subject"] != "你好 report" || msg["date"] != "2026-01-05T10:00:00+08:00" || msg["messageId"] != "1@xgo.dev" {
+		t.Fatal("header:", msg)
+	}
+	if msg["text"] != "Hello, world\nFrom here on" || msg["html"] != "<p>Hello</p>" {
+		t.Fatalf("text: %q, html: %q", msg["text"], msg["html"])
+	}
+	if tags := msg["header"].(map[string]any)["X-Tag"]; !reflect.DeepEqual(tags, []any{"a", "b"}) {
+		t.Fatal("X-Tag:", tags)
+	}
+
+	var att NodeSet
+	for p := range Source(Node{Value: msg}).XGo_Any("").XGo_Enum() {
+		if p.XGo_Attr__0("disposition") == "attachment" {
+			att = p
+		}
+	}
+	if att.XGo_Attr__0("filename") != "report.csv" || att.XGo_Attr__0("size") != 14 {
+		t.Fatal("attachment:", att.XGo_Attr__0("filename"), att.XGo_Attr__0("size"))
+	}
+	r, err := Content(att)
+	if err != nil {
+		t.Fatal("Content:", err)
+	}
+	if b, _ := io.ReadAll(r); string(b) != "id,name\n1,ann\n" {
+		t.Fatalf("content: %q", b)
+	}
+	if _, err = Content(Source(Node{Value: msg})); err != ErrNoContent {
+		t.Fatal("Content of multipart:", err)
+	}
+
+	reply := msgs[1]
+	if reply["inReplyTo"] != "1@xgo.dev" || reply["text"] != "café\n" || reply["html"] != nil {
+		t.Fatalf("reply: %q", reply["text"])
+	}
+}
+
+func TestEml(t *testing.T) {
+	eml := "From: ann@xgo.dev\r\nSubject: hi\r\n\r\nbody\r\n"
+	msg, err := Source(strings.NewReader(eml)).XGo_first()
+	if err != nil || msg.Name != "message" || msg.XGo_Attr__0("text") != "body\r\n" {
+		t.Fatal("eml:", msg, err)
+	}
+	if _, err = Source([]byte(" \n")).XGo_first(); err == nil {
+		t.Fatal("empty")
+	}
+}
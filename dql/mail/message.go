@@ -0,0 +1,243 @@
+/*
+ * Copyright (c) 2026 The XGo Authors (xgo.dev). All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package mail
+
+import (
+	"bytes"
+	"encoding/base64"
+	"fmt"
+	"io"
+	"mime"
+	"mime/multipart"
+	"mime/quotedprintable"
+	"net/mail"
+	"net/textproto"
+	"strings"
+	"time"
+)
+
+// maxDepth limits the nesting of MIME parts.
+const maxDepth = 32
+
+var wordDecoder = &mime.WordDecoder{
+	CharsetReader: func(charset string, input io.Reader) (io.Reader, error) {
+		if !isLatin1(charset) {
+			return nil, fmt.Errorf("unsupported charset %q", charset)
+		}
+		b, err := io.ReadAll(input)
+		return strings.NewReader(latin1(b)), err
+	},
+}
+
+// message parses a message (see RFC 5322) to its map.
+func message(b []byte, depth int) (map[string]any, error) {
+	msg, err := mail.ReadMessage(bytes.NewReader(b))
+	if err != nil {
+		return nil, err
+	}
+	body, err := io.ReadAll(msg.Body)
+	if err != nil {
+		return nil, err
+	}
+	h := msg.Header
+	ret, err := part(textproto.MIMEHeader(h), body, depth)
+	if err != nil {
+		return nil, err
+	}
+	from := ""
+	if addrs := addresses(h, "From"); len(addrs) > 0 {
+		from = addrs[0].(string)
+	}
+	date := h.Get("Date")
+	if t, err := mail.ParseDate(date); err == nil {
+		date = t.Format(time.RFC3339)
+	}
+	ret["from"] = from
+	ret["to"] = addresses(h, "To")
+	ret["cc"] = addresses(h, "Cc")
+	ret["subject"] = decodeHeader(h.Get("Subject"))
+	ret["date"] = date
+	ret["messageId"] = strings.Trim(h.Get("Message-Id"), "<> ")
+	ret["inReplyTo"] = strings.Trim(h.Get("In-Reply-To"), "<> ")
+	ret["text"] = firstText(ret, "text/plain")
+	ret["html"] = firstText(ret, "text/html")
+	return ret, nil
+}
+
+// part parses a MIME part to its map.
+func part(h textproto.MIMEHeader, body []byte, depth int) (map[string]any, error) {
+	if depth > maxDepth {
+		return nil, fmt.Errorf("MIME parts nested too deeply")
+	}
+	contentType, params, err := mime.ParseMediaType(h.Get("Content-Type"))
+	if err != nil {
+		contentType, params = "text/plain", map[string]string{}
+	}
+	disposition, dparams, _ := mime.ParseMediaType(h.Get("Content-Disposition"))
+	filename := dparams["filename"]
+	if filename == "" {
+		filename = params["name"]
+	}
+	ret := map[string]any{
+		"contentType": contentType,
+		"disposition": disposition,
+		"filename":    decodeHeader(filename),
+		"header":      header(h),
+	}
+	switch {
+	case strings.HasPrefix(contentType, "multipart/"):
+		parts := []any{}
+		mr := multipart.NewReader(bytes.NewReader(body), params["boundary"])
+		for {
+			p, err := mr.NextRawPart()
+			if err == io.EOF {
+				break
+			}
+			if err != nil {
+				return nil, err
+			}
+			data, err := io.ReadAll(p)
+			if err != nil {
+				return nil, err
+			}
+			child, err := part(p.Header, data, depth+1)
+			if err != nil {
+				return nil, err
+			}
+			parts = append(parts, child)
+		}
+		ret["parts"] = parts
+	case contentType == "message/rfc822":
+		msg, err := message(body, depth+1)
+		if err != nil {
+			return nil, err
+		}
+		ret["parts"] = []any{msg}
+	default:
+		data, err := decodeBody(h.Get("Content-Transfer-Encoding"), body)
+		if err != nil {
+			return nil, err
+		}
+		ret["content"] = data
+		ret["size"] = len(data)
+		if strings.HasPrefix(contentType, "text/") && disposition != "attachment" {
+			ret["text"] = text(data, params["charset"])
+		}
+	}
+	return ret, nil
+}
+
+// firstText returns the text of the first part of the content type.
+func firstText(part map[string]any, contentType string) any {
+	if part["contentType"] == contentType {
+		if v, ok := part["text"]; ok {
+			return v
+		}
+	}
+	if parts, ok := part["parts"].([]any); ok {
+		for _, p := range parts {
+			if p := p.(map[string]any); p["from"] == nil { // not an enclosed message
+				if v := firstText(p, contentType); v != nil {
+					return v
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// header converts a header to a map, whose values are strings, or []any of
+// strings if there are multiple values.
+func header(h textproto.MIMEHeader) map[string]any {
+	ret := make(map[string]any, len(h))
+	for k, vals := range h {
+		if len(vals) == 1 {
+			ret[k] = decodeHeader(vals[0])
+			continue
+		}
+		list := make([]any, len(vals))
+		for i, v := range vals {
+			list[i] = decodeHeader(v)
+		}
+		ret[k] = list
+	}
+	return ret
+}
+
+// addresses returns the email addresses of an address list header.
+func addresses(h mail.Header, key string) []any {
+	ret := []any{}
+	list, err := h.AddressList(key)
+	if err != nil {
+		return ret
+	}
+	for _, addr := range list {
+		ret = append(ret, addr.Address)
+	}
+	return ret
+}
+
+// decodeHeader decodes the encoded-words (see RFC 2047) in a header.
+func decodeHeader(s string) string {
+	if v, err := wordDecoder.DecodeHeader(s); err == nil {
+		return v
+	}
+	return s
+}
+
+func decodeBody(encoding string, body []byte) ([]byte, error) {
+	switch strings.ToLower(strings.TrimSpace(encoding)) {
+	case "base64":
+		data := make([]byte, base64.StdEncoding.DecodedLen(len(body)))
+		clean := bytes.Map(func(r rune) rune {
+			if r == '\r' || r == '\n' || r == ' ' || r == '\t' {
+				return -1
+			}
+			return r
+		}, body)
+		n, err := base64.StdEncoding.Decode(data, clean)
+		return data[:n], err
+	case "quoted-printable":
+		return io.ReadAll(quotedprintable.NewReader(bytes.NewReader(body)))
+	}
+	return body, nil
+}
+
+// text converts data in the charset to a string. Data in charsets other than
+// UTF-8, US-ASCII and ISO-8859-1 is kept as is.
+func text(data []byte, charset string) string {
+	if isLatin1(charset) {
+		return latin1(data)
+	}
+	return string(data)
+}
+
+func isLatin1(charset string) bool {
+	switch strings.ToLower(charset) {
+	case "iso-8859-1", "latin1", "windows-1252":
+		return true
+	}
+	return false
+}
+
+func latin1(b []byte) string {
+	runes := make([]rune, len(b))
+	for i, c := range b {
+		runes[i] = rune(c)
+	}
+	return string(runes)
+}
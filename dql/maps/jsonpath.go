@@ -0,0 +1,716 @@
+/*
+ * Copyright (c) 2026 The XGo Authors (xgo.dev). All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package maps
+
+import (
+	"cmp"
+	"encoding/json"
+	"errors"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// -----------------------------------------------------------------------------
+
+// JSONPath returns a NodeSet containing the nodes selected by the JSONPath
+// expression, evaluated with each node in the NodeSet as the root ($):
+//
+//	for title in doc.jsonPath("$.store.book[?(@.price < 10)].title") {
+//		echo title.value
+//	}
+//
+// The following syntax is supported:
+//   - child: $.name, $['name'], $["a","b"], $.*, $[*]
+//   - descendant: $..name, $..*, $..[0]
+//   - array: $[0], $[-1], $[0,2], $[1:3], $[::2]
+//   - filter: $[?(@.price < 10)], $[?(@.isbn)], $[?(@.a == $.b && !@.c)]
+//
+// Filter expressions support the operators ==, !=, <, <=, >, >=, &&, || and
+// !, and literals of numbers, strings, true, false and null. A query in a
+// filter expression is an existence test unless it is compared.
+//
+// Array elements are returned as nodes with an empty name.
+func (p NodeSet) JSONPath(expr string) NodeSet {
+	if p.Err != nil {
+		return p
+	}
+	path, err := compileJSONPath(expr)
+	if err != nil {
+		return NodeSet{Err: err}
+	}
+	return NodeSet{
+		Data: func(yield func(Node) bool) {
+			p.Data(func(node Node) bool {
+				return path.apply(node, &jsonEnv{root: node.Value, sorted: p.sorted}, yield)
+			})
+		},
+		sorted: p.sorted,
+	}
+}
+
+// JSONPath returns a NodeSet containing the nodes selected by the JSONPath
+// expression, evaluated with the node as the root ($).
+func (n Node) JSONPath(expr string) NodeSet {
+	return Root(n).JSONPath(expr)
+}
+
+// -----------------------------------------------------------------------------
+
+// ErrJSONPathSyntax is the base error of all JSONPath syntax errors.
+var ErrJSONPathSyntax = errors.New("jsonpath: syntax error")
+
+type jsonPathError struct {
+	expr string
+	pos  int
+	msg  string
+}
+
+func (e *jsonPathError) Error() string {
+	return "jsonpath: " + e.msg + " at offset " + strconv.Itoa(e.pos) + " in " + strconv.Quote(e.expr)
+}
+
+func (e *jsonPathError) Unwrap() error {
+	return ErrJSONPathSyntax
+}
+
+// -----------------------------------------------------------------------------
+
+// jsonPath is a compiled query, that is, a list of segments applied in turn.
+type jsonPath []*jsonSegment
+
+// jsonSegment selects children (or descendants if desc is true) of a node.
+type jsonSegment struct {
+	desc bool
+	sels []jsonSelector
+}
+
+// jsonSelector yields the selected children of a node.
+type jsonSelector interface {
+	selectFrom(node Node, env *jsonEnv, yield func(Node) bool) bool
+}
+
+// jsonEnv is the environment of a query.
+type jsonEnv struct {
+	root   any  // value of the root node ($), which is referred by filters
+	sorted bool // yield object members in key order
+}
+
+// apply yields the nodes selected by the query from node.
+func (p jsonPath) apply(node Node, env *jsonEnv, yield func(Node) bool) bool {
+	if len(p) == 0 {
+		return yield(node)
+	}
+	seg, rest := p[0], p[1:]
+	next := func(n Node) bool {
+		return rest.apply(n, env, yield)
+	}
+	if seg.desc {
+		return seg.applyDesc(node, env, next)
+	}
+	return seg.apply(node, env, next)
+}
+
+func (seg *jsonSegment) apply(node Node, env *jsonEnv, yield func(Node) bool) bool {
+	for _, sel := range seg.sels {
+		if !sel.selectFrom(node, env, yield) {
+			return false
+		}
+	}
+	return true
+}
+
+// applyDesc applies the selectors to the node and all its descendants.
+func (seg *jsonSegment) applyDesc(node Node, env *jsonEnv, yield func(Node) bool) bool {
+	if !seg.apply(node, env, yield) {
+		return false
+	}
+	return yieldChildNodes(node, env.sorted, func(child Node) bool {
+		switch child.Value.(type) {
+		case map[string]any, []any:
+			return seg.applyDesc(child, env, yield)
+		}
+		return true
+	})
+}
+
+type jsonName string
+
+func (sel jsonName) selectFrom(node Node, env *jsonEnv, yield func(Node) bool) bool {
+	return yieldElem(node, string(sel), yield)
+}
+
+type jsonWildcard struct{}
+
+func (jsonWildcard) selectFrom(node Node, env *jsonEnv, yield func(Node) bool) bool {
+	return yieldChildNodes(node, env.sorted, yield)
+}
+
+type jsonIndex int
+
+func (sel jsonIndex) selectFrom(node Node, env *jsonEnv, yield func(Node) bool) bool {
+	if arr, ok := node.Value.([]any); ok {
+		i := int(sel)
+		if i < 0 {
+			i += len(arr)
+		}
+		if i >= 0 && i < len(arr) {
+			return yield(Node{Name: "", Value: arr[i], parent: arr, index: i})
+		}
+	}
+	return true
+}
+
+// jsonSlice is an array slice [start:end:step], nil means the default value.
+type jsonSlice struct {
+	start, end, step *int
+}
+
+func (sel *jsonSlice) selectFrom(node Node, env *jsonEnv, yield func(Node) bool) bool {
+	arr, ok := node.Value.([]any)
+	if !ok {
+		return true
+	}
+	n, step := len(arr), 1
+	if sel.step != nil {
+		step = *sel.step
+	}
+	if step == 0 {
+		return true
+	}
+	bound := func(v *int, def int) int {
+		if v == nil {
+			return def
+		}
+		i := *v
+		if i < 0 {
+			i += n
+		}
+		if step > 0 {
+			return min(max(i, 0), n)
+		}
+		return min(max(i, -1), n-1)
+	}
+	if step > 0 {
+		for i, end := bound(sel.start, 0), bound(sel.end, n); i < end; i += step {
+			if !yield(Node{Name: "", Value: arr[i], parent: arr, index: i}) {
+				return false
+			}
+		}
+	} else {
+		for i, end := bound(sel.start, n-1), bound(sel.end, -1); i > end; i += step {
+			if !yield(Node{Name: "", Value: arr[i], parent: arr, index: i}) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+type jsonFilter struct {
+	cond jsonExpr
+}
+
+func (sel *jsonFilter) selectFrom(node Node, env *jsonEnv, yield func(Node) bool) bool {
+	return yieldChildNodes(node, env.sorted, func(child Node) bool {
+		if toBool(sel.cond.eval(child.Value, env)) {
+			return yield(child)
+		}
+		return true
+	})
+}
+
+// -----------------------------------------------------------------------------
+
+// jsonExpr is an expression of a filter. Values are represented as: []any
+// (result of a query), and the JSON values string, float64 (or int64 for
+// integers), bool and nil.
+type jsonExpr interface {
+	eval(cur any, env *jsonEnv) any
+}
+
+type jsonLit struct {
+	val any
+}
+
+func (e *jsonLit) eval(cur any, env *jsonEnv) any {
+	return e.val
+}
+
+type jsonQuery struct {
+	abs  bool // starts with $ instead of @
+	path jsonPath
+}
+
+func (e *jsonQuery) eval(cur any, env *jsonEnv) any {
+	if e.abs {
+		cur = env.root
+	}
+	ret := []any{}
+	e.path.apply(Node{Value: cur}, env, func(n Node) bool {
+		ret = append(ret, n.Value)
+		return true
+	})
+	return ret
+}
+
+type jsonNot struct {
+	x jsonExpr
+}
+
+func (e *jsonNot) eval(cur any, env *jsonEnv) any {
+	return !toBool(e.x.eval(cur, env))
+}
+
+type jsonLogic struct {
+	and  bool
+	x, y jsonExpr
+}
+
+func (e *jsonLogic) eval(cur any, env *jsonEnv) any {
+	if toBool(e.x.eval(cur, env)) != e.and {
+		return !e.and
+	}
+	return toBool(e.y.eval(cur, env))
+}
+
+type jsonCompare struct {
+	op   string
+	x, y jsonExpr
+}
+
+func (e *jsonCompare) eval(cur any, env *jsonEnv) any {
+	x, ok1 := single(e.x.eval(cur, env))
+	y, ok2 := single(e.y.eval(cur, env))
+	if !ok1 || !ok2 {
+		// A query selecting nothing only equals another one selecting nothing.
+		switch e.op {
+		case "==", "<=", ">=":
+			return !ok1 && !ok2
+		case "!=":
+			return ok1 != ok2
+		}
+		return false
+	}
+	switch e.op {
+	case "==":
+		return equal(x, y)
+	case "!=":
+		return !equal(x, y)
+	}
+	cmp, ok := order(x, y)
+	if !ok {
+		return false
+	}
+	switch e.op {
+	case "<":
+		return cmp < 0
+	case "<=":
+		return cmp <= 0
+	case ">":
+		return cmp > 0
+	default: // ">="
+		return cmp >= 0
+	}
+}
+
+// single returns the value of a comparison operand. A query is comparable
+// only if it selects exactly one value.
+func single(v any) (any, bool) {
+	if items, ok := v.([]any); ok {
+		if len(items) != 1 {
+			return nil, false
+		}
+		return normalize(items[0]), true
+	}
+	return v, true
+}
+
+// normalize converts integers (including json.Number without a fraction) to
+// int64 and other numbers to float64, so they can be compared exactly.
+func normalize(v any) any {
+	switch v := v.(type) {
+	case int:
+		return int64(v)
+	case int32:
+		return int64(v)
+	case uint64: // decoded by YAML decoders for non-negative integers
+		if v <= math.MaxInt64 {
+			return int64(v)
+		}
+		return float64(v)
+	case float32:
+		return float64(v)
+	case json.Number:
+		if i, err := v.Int64(); err == nil {
+			return i
+		}
+		if f, err := v.Float64(); err == nil {
+			return f
+		}
+	}
+	return v
+}
+
+func equal(x, y any) bool {
+	if cmp, ok := compareNumbers(x, y); ok {
+		return cmp == 0
+	}
+	switch x := x.(type) {
+	case float64, int64, string, bool, nil:
+		return x == y
+	case []any:
+		if y, ok := y.([]any); ok && len(x) == len(y) {
+			for i := range x {
+				if !equal(normalize(x[i]), normalize(y[i])) {
+					return false
+				}
+			}
+			return true
+		}
+	case map[string]any:
+		if y, ok := y.(map[string]any); ok && len(x) == len(y) {
+			for k, v := range x {
+				if w, ok := y[k]; !ok || !equal(normalize(v), normalize(w)) {
+					return false
+				}
+			}
+			return true
+		}
+	}
+	return false
+}
+
+// order compares two numbers or two strings.
+func order(x, y any) (int, bool) {
+	if cmp, ok := compareNumbers(x, y); ok {
+		return cmp, true
+	}
+	if x, ok := x.(string); ok {
+		if y, ok := y.(string); ok {
+			return strings.Compare(x, y), true
+		}
+	}
+	return 0, false
+}
+
+// compareNumbers compares two normalized numbers. Two int64 values are
+// compared exactly.
+func compareNumbers(x, y any) (int, bool) {
+	if x, ok := x.(int64); ok {
+		if y, ok := y.(int64); ok {
+			return cmp.Compare(x, y), true
+		}
+	}
+	fx, ok1 := toFloat(x)
+	fy, ok2 := toFloat(y)
+	if !ok1 || !ok2 {
+		return 0, false
+	}
+	return cmp.Compare(fx, fy), true
+}
+
+func toFloat(v any) (float64, bool) {
+	switch v := v.(type) {
+	case float64:
+		return v, true
+	case int64:
+		return float64(v), true
+	}
+	return 0, false
+}
+
+// toBool converts the value of a filter expression to a boolean. A query is
+// true if it selects anything.
+func toBool(v any) bool {
+	switch v := v.(type) {
+	case bool:
+		return v
+	case []any:
+		return len(v) > 0
+	}
+	return false
+}
+
+// -----------------------------------------------------------------------------
+
+type jsonPathParser struct {
+	expr string
+	pos  int
+}
+
+func compileJSONPath(expr string) (path jsonPath, err error) {
+	p := &jsonPathParser{expr: expr}
+	defer func() {
+		if e := recover(); e != nil {
+			perr, ok := e.(*jsonPathError)
+			if !ok {
+				panic(e)
+			}
+			err = perr
+		}
+	}()
+	p.skipSpace()
+	if !p.accept("$") {
+		p.fail("expected $")
+	}
+	path = p.parseSegments()
+	p.skipSpace()
+	if p.pos < len(p.expr) {
+		p.fail("unexpected " + strconv.Quote(p.expr[p.pos:p.pos+1]))
+	}
+	return
+}
+
+func (p *jsonPathParser) fail(msg string) {
+	panic(&jsonPathError{expr: p.expr, pos: p.pos, msg: msg})
+}
+
+func (p *jsonPathParser) skipSpace() {
+	for p.pos < len(p.expr) && strings.IndexByte(" \t\r\n", p.expr[p.pos]) >= 0 {
+		p.pos++
+	}
+}
+
+func (p *jsonPathParser) peek() byte {
+	if p.pos < len(p.expr) {
+		return p.expr[p.pos]
+	}
+	return 0
+}
+
+func (p *jsonPathParser) accept(tok string) bool {
+	if strings.HasPrefix(p.expr[p.pos:], tok) {
+		p.pos += len(tok)
+		return true
+	}
+	return false
+}
+
+func (p *jsonPathParser) expect(tok string) {
+	p.skipSpace()
+	if !p.accept(tok) {
+		p.fail("expected " + strconv.Quote(tok))
+	}
+}
+
+// parseSegments parses the segments following $ or @.
+func (p *jsonPathParser) parseSegments() (path jsonPath) {
+	for {
+		seg := new(jsonSegment)
+		switch {
+		case p.accept(".."):
+			seg.desc = true
+			if p.peek() == '[' {
+				p.pos++
+				seg.sels = p.parseBracket()
+			} else {
+				seg.sels = []jsonSelector{p.parseDotSelector()}
+			}
+		case p.accept("."):
+			seg.sels = []jsonSelector{p.parseDotSelector()}
+		case p.accept("["):
+			seg.sels = p.parseBracket()
+		default:
+			return
+		}
+		path = append(path, seg)
+	}
+}
+
+func isJSONNameChar(c byte) bool {
+	return c == '_' || c == '-' || c == '$' || c >= 0x80 ||
+		'a' <= c && c <= 'z' || 'A' <= c && c <= 'Z' || '0' <= c && c <= '9'
+}
+
+func (p *jsonPathParser) parseDotSelector() jsonSelector {
+	if p.accept("*") {
+		return jsonWildcard{}
+	}
+	start := p.pos
+	for p.pos < len(p.expr) && isJSONNameChar(p.expr[p.pos]) {
+		p.pos++
+	}
+	if p.pos == start {
+		p.fail("expected name")
+	}
+	return jsonName(p.expr[start:p.pos])
+}
+
+// parseBracket parses the comma separated selectors after "[".
+func (p *jsonPathParser) parseBracket() (sels []jsonSelector) {
+	for {
+		p.skipSpace()
+		switch c := p.peek(); {
+		case c == '\'' || c == '"':
+			sels = append(sels, jsonName(p.parseString()))
+		case c == '*':
+			p.pos++
+			sels = append(sels, jsonWildcard{})
+		case c == '?':
+			p.pos++
+			sels = append(sels, &jsonFilter{cond: p.parseOr()})
+		default:
+			sels = append(sels, p.parseIndexOrSlice())
+		}
+		p.skipSpace()
+		if p.accept("]") {
+			return
+		}
+		p.expect(",")
+	}
+}
+
+func (p *jsonPathParser) parseIndexOrSlice() jsonSelector {
+	var parts [3]*int
+	n := 0
+	for {
+		p.skipSpace()
+		if c := p.peek(); c == '-' || '0' <= c && c <= '9' {
+			v := p.parseInt()
+			parts[n] = &v
+		}
+		p.skipSpace()
+		if n == 2 || !p.accept(":") {
+			break
+		}
+		n++
+	}
+	if n == 0 {
+		if parts[0] == nil {
+			p.fail("expected selector")
+		}
+		return jsonIndex(*parts[0])
+	}
+	return &jsonSlice{start: parts[0], end: parts[1], step: parts[2]}
+}
+
+func (p *jsonPathParser) parseInt() int {
+	start := p.pos
+	p.accept("-")
+	for p.pos < len(p.expr) && '0' <= p.expr[p.pos] && p.expr[p.pos] <= '9' {
+		p.pos++
+	}
+	v, err := strconv.Atoi(p.expr[start:p.pos])
+	if err != nil {
+		p.pos = start
+		p.fail("invalid integer")
+	}
+	return v
+}
+
+func (p *jsonPathParser) parseString() string {
+	quote := p.expr[p.pos]
+	start := p.pos
+	var sb strings.Builder
+	for p.pos++; p.pos < len(p.expr); p.pos++ {
+		switch c := p.expr[p.pos]; c {
+		case quote:
+			p.pos++
+			return sb.String()
+		case '\\':
+			if p.pos++; p.pos < len(p.expr) {
+				switch c = p.expr[p.pos]; c {
+				case 'n':
+					c = '\n'
+				case 't':
+					c = '\t'
+				}
+				sb.WriteByte(c)
+			}
+		default:
+			sb.WriteByte(c)
+		}
+	}
+	p.pos = start
+	p.fail("unterminated string")
+	return ""
+}
+
+func (p *jsonPathParser) parseOr() jsonExpr {
+	x := p.parseAnd()
+	for p.skipSpace(); p.accept("||"); p.skipSpace() {
+		x = &jsonLogic{x: x, y: p.parseAnd()}
+	}
+	return x
+}
+
+func (p *jsonPathParser) parseAnd() jsonExpr {
+	x := p.parseUnary()
+	for p.skipSpace(); p.accept("&&"); p.skipSpace() {
+		x = &jsonLogic{and: true, x: x, y: p.parseUnary()}
+	}
+	return x
+}
+
+func (p *jsonPathParser) parseUnary() jsonExpr {
+	p.skipSpace()
+	if p.peek() == '!' && !strings.HasPrefix(p.expr[p.pos:], "!=") {
+		p.pos++
+		return &jsonNot{x: p.parseUnary()}
+	}
+	x := p.parsePrimary()
+	p.skipSpace()
+	for _, op := range [...]string{"==", "!=", "<=", ">=", "<", ">"} {
+		if p.accept(op) {
+			return &jsonCompare{op: op, x: x, y: p.parsePrimary()}
+		}
+	}
+	return x
+}
+
+func (p *jsonPathParser) parsePrimary() jsonExpr {
+	p.skipSpace()
+	switch c := p.peek(); {
+	case c == '(':
+		p.pos++
+		x := p.parseOr()
+		p.expect(")")
+		return x
+	case c == '@' || c == '$':
+		p.pos++
+		return &jsonQuery{abs: c == '$', path: p.parseSegments()}
+	case c == '\'' || c == '"':
+		return &jsonLit{val: p.parseString()}
+	case c == '-' || '0' <= c && c <= '9':
+		start := p.pos
+		for p.pos++; p.pos < len(p.expr) && strings.IndexByte("0123456789.eE+-", p.expr[p.pos]) >= 0; p.pos++ {
+		}
+		lit := p.expr[start:p.pos]
+		if v, err := strconv.ParseInt(lit, 10, 64); err == nil {
+			return &jsonLit{val: v}
+		}
+		v, err := strconv.ParseFloat(lit, 64)
+		if err != nil {
+			p.pos = start
+			p.fail("invalid number")
+		}
+		return &jsonLit{val: v}
+	}
+	for _, lit := range [...]struct {
+		tok string
+		val any
+	}{{"true", true}, {"false", false}, {"null", nil}} {
+		if p.accept(lit.tok) {
+			return &jsonLit{val: lit.val}
+		}
+	}
+	p.fail("expected expression")
+	return nil
+}
+
+// -----------------------------------------------------------------------------
@@ -32,6 +32,8 @@ const (
 type NodeSet struct {
 	Data iter.Seq[Node]
 	Err  error
+
+	sorted bool // yield object members in key order
 }
 
 // NodeSet(seq) casts a NodeSet from a sequence of nodes.
@@ -108,7 +110,9 @@ func (p NodeSet) XGo_Enum() iter.Seq[NodeSet] {
 	}
 	return func(yield func(NodeSet) bool) {
 		p.Data(func(node Node) bool {
-			return yield(Root(node))
+			ret := Root(node)
+			ret.sorted = p.sorted
+			return yield(ret)
 		})
 	}
 }
@@ -129,6 +133,7 @@ func (p NodeSet) XGo_Select(name string) NodeSet {
 				return true
 			})
 		},
+		sorted: p.sorted,
 	}
 }
 
@@ -145,6 +150,7 @@ func (p NodeSet) XGo_Elem(name string) NodeSet {
 				return yieldElem(node, name, yield)
 			})
 		},
+		sorted: p.sorted,
 	}
 }
 
@@ -152,7 +158,7 @@ func (p NodeSet) XGo_Elem(name string) NodeSet {
 func yieldElem(node Node, name string, yield func(Node) bool) bool {
 	if children, ok := node.Value.(map[string]any); ok {
 		if v, ok := children[name]; ok {
-			return yield(Node{Name: name, Value: v})
+			return yield(Node{Name: name, Value: v, parent: children})
 		}
 	}
 	return true
@@ -166,24 +172,26 @@ func (p NodeSet) XGo_Child() NodeSet {
 	return NodeSet{
 		Data: func(yield func(Node) bool) {
 			p.Data(func(node Node) bool {
-				return yieldChildNodes(node, yield)
+				return yieldChildNodes(node, p.sorted, yield)
 			})
 		},
+		sorted: p.sorted,
 	}
 }
 
-// yieldChildNodes yields all child nodes of the given node.
-func yieldChildNodes(node Node, yield func(Node) bool) bool {
+// yieldChildNodes yields all child nodes of the given node. If sorted is true,
+// object members are yielded in key order.
+func yieldChildNodes(node Node, sorted bool, yield func(Node) bool) bool {
 	switch children := node.Value.(type) {
 	case map[string]any:
-		for k, v := range children {
-			if !yield(Node{Name: k, Value: v}) {
+		for k, v := range members(children, sorted) {
+			if !yield(Node{Name: k, Value: v, parent: children}) {
 				return false
 			}
 		}
 	case []any:
-		for _, v := range children {
-			if !yield(Node{Name: "", Value: v}) {
+		for i, v := range children {
+			if !yield(Node{Name: "", Value: v, parent: children, index: i}) {
 				return false
 			}
 		}
@@ -204,15 +212,16 @@ func (p NodeSet) XGo_Any(name string) NodeSet {
 	return NodeSet{
 		Data: func(yield func(Node) bool) {
 			p.Data(func(node Node) bool {
-				return yieldAnyNodes(name, node, yield)
+				return yieldAnyNodes(name, node, p.sorted, yield)
 			})
 		},
+		sorted: p.sorted,
 	}
 }
 
 // yieldAnyNodes yields all descendant nodes of the given node that match the
 // specified name. If name is "", it yields all nodes.
-func yieldAnyNodes(name string, node Node, yield func(Node) bool) bool {
+func yieldAnyNodes(name string, node Node, sorted bool, yield func(Node) bool) bool {
 	if name == "" || node.Name == name {
 		if !yield(node) {
 			return false
@@ -220,14 +229,14 @@ func yieldAnyNodes(name string, node Node, yield func(Node) bool) bool {
 	}
 	switch children := node.Value.(type) {
 	case map[string]any:
-		for k, v := range children {
-			if !yieldAnyNode(name, k, v, yield) {
+		for k, v := range members(children, sorted) {
+			if !yieldAnyNode(name, Node{Name: k, Value: v, parent: children}, sorted, yield) {
 				return false
 			}
 		}
 	case []any:
-		for _, v := range children {
-			if !yieldAnyNode(name, "", v, yield) {
+		for i, v := range children {
+			if !yieldAnyNode(name, Node{Name: "", Value: v, parent: children, index: i}, sorted, yield) {
 				return false
 			}
 		}
@@ -235,12 +244,12 @@ func yieldAnyNodes(name string, node Node, yield func(Node) bool) bool {
 	return true
 }
 
-// yieldAnyNode recursively traverses into v if it is a map[string]any or []any,
-// looking for descendant nodes matching name.
-func yieldAnyNode(name, k string, v any, yield func(Node) bool) bool {
-	switch v.(type) {
+// yieldAnyNode recursively traverses into node if its value is a
+// map[string]any or []any, looking for descendant nodes matching name.
+func yieldAnyNode(name string, node Node, sorted bool, yield func(Node) bool) bool {
+	switch node.Value.(type) {
 	case map[string]any, []any:
-		return yieldAnyNodes(name, Node{Name: k, Value: v}, yield)
+		return yieldAnyNodes(name, node, sorted, yield)
 	}
 	return true
 }
@@ -255,7 +264,9 @@ func (p NodeSet) XGo_all() NodeSet {
 		return NodeSet{Err: p.Err}
 	}
 	nodes := dql.Collect(p.Data)
-	return Nodes(nodes...)
+	ret := Nodes(nodes...)
+	ret.sorted = p.sorted
+	return ret
 }
 
 // _one returns a NodeSet containing the first node.
@@ -268,7 +279,9 @@ func (p NodeSet) XGo_one() NodeSet {
 	if err != nil {
 		return NodeSet{Err: err}
 	}
-	return Root(n)
+	ret := Root(n)
+	ret.sorted = p.sorted
+	return ret
 }
 
 // _single returns a NodeSet containing the single node.
@@ -282,7 +295,9 @@ func (p NodeSet) XGo_single() NodeSet {
 	if err != nil {
 		return NodeSet{Err: err}
 	}
-	return Root(n)
+	ret := Root(n)
+	ret.sorted = p.sorted
+	return ret
 }
 
 // -----------------------------------------------------------------------------
@@ -0,0 +1,334 @@
+/*
+ * Copyright (c) 2026 The XGo Authors (xgo.dev). All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package maps
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/goplus/xgo/dql"
+)
+
+const storeDoc = `{
+	"store": {
+		"book": [
+			{"category": "reference", "author": "Nigel Rees", "title": "Sayings of the Century", "price": 8.95},
+			{"category": "fiction", "author": "Evelyn Waugh", "title": "Sword of Honour", "price": 12.99},
+			{"category": "fiction", "author": "Herman Melville", "title": "Moby Dick", "isbn": "0-553-21311-3", "price": 8.99},
+			{"category": "fiction", "author": "J. R. R. Tolkien", "title": "The Lord of the Rings", "isbn": "0-395-19395-8", "price": 22.99}
+		],
+		"bicycle": {"color": "red", "price": 19.95}
+	},
+	"limit": 10
+}`
+
+func loadDoc(t *testing.T, data string) NodeSet {
+	t.Helper()
+	var doc map[string]any
+	if err := json.Unmarshal([]byte(data), &doc); err != nil {
+		t.Fatal(err)
+	}
+	return New(doc)
+}
+
+// values returns the values of the nodes formatted with %v, sorted if the
+// order isn't specified.
+func values(t *testing.T, ns NodeSet, sorted bool) string {
+	t.Helper()
+	if ns.Err != nil {
+		t.Fatal(ns.Err)
+	}
+	var ret []string
+	ns.Data(func(n Node) bool {
+		ret = append(ret, fmt.Sprint(n.Value))
+		return true
+	})
+	if sorted {
+		slices.Sort(ret)
+	}
+	return fmt.Sprint(ret)
+}
+
+func TestJSONPath(t *testing.T) {
+	doc := loadDoc(t, storeDoc)
+	cases := []struct {
+		expr   string
+		want   string
+		sorted bool
+	}{
+		{"$.store.book[?(@.price < 10)].title", "[Sayings of the Century Moby Dick]", false},
+		{"$['store']['book'][0].author", "[Nigel Rees]", false},
+		{"$.store.book[*].author", "[Nigel Rees Evelyn Waugh Herman Melville J. R. R. Tolkien]", false},
+		{"$..author", "[Evelyn Waugh Herman Melville J. R. R. Tolkien Nigel Rees]", true},
+		{"$.store..price", "[12.99 19.95 22.99 8.95 8.99]", true},
+		{"$..book[-1].title", "[The Lord of the Rings]", false},
+		{"$..book[0,2].title", "[Sayings of the Century Moby Dick]", false},
+		{"$..book[1:3].title", "[Sword of Honour Moby Dick]", false},
+		{"$..book[::-2].title", "[The Lord of the Rings Sword of Honour]", false},
+		{"$..book[:2].price", "[8.95 12.99]", false},
+		{"$..book[?(@.isbn)].title", "[Moby Dick The Lord of the Rings]", false},
+		{"$..book[?(!@.isbn && @.category == 'fiction')].title", "[Sword of Honour]", false},
+		{"$..book[?(@.price > $.limit || @.author == \"Nigel Rees\")].price", "[8.95 12.99 22.99]", false},
+		{"$..book[?(@.price >= 8.99 && @.price <= 12.99)].title", "[Sword of Honour Moby Dick]", false},
+		{"$..book[?(@.missing == null)]", "[]", false},
+		{"$.store.bicycle.*", "[19.95 red]", true},
+		{"$.limit", "[10]", false},
+		{"$", "[" + fmt.Sprint(doc.XGo_value__0()) + "]", false},
+	}
+	for _, c := range cases {
+		if got := values(t, doc.JSONPath(c.expr), c.sorted); got != c.want {
+			t.Errorf("%s: got %s, want %s", c.expr, got, c.want)
+		}
+	}
+
+	for _, expr := range []string{"", "store", "$.", "$[", "$['a", "$[?(@.a ==)]", "$.a b"} {
+		if ns := doc.JSONPath(expr); !errors.Is(ns.Err, ErrJSONPathSyntax) {
+			t.Errorf("%q: unexpected error: %v", expr, ns.Err)
+		}
+	}
+}
+
+func decode(t *testing.T, data string) (ret any) {
+	t.Helper()
+	if err := json.Unmarshal([]byte(data), &ret); err != nil {
+		t.Fatal(err)
+	}
+	return
+}
+
+func encode(t *testing.T, v any) string {
+	t.Helper()
+	b, err := json.Marshal(v)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(b)
+}
+
+func TestMerge(t *testing.T) {
+	const dst = `{"a": 1, "b": {"x": [1], "y": "old"}, "c": [1, 2]}`
+	const src = `{"b": {"x": [2], "z": true}, "c": [3], "d": null}`
+	cases := []struct {
+		strategy MergeStrategy
+		want     string
+	}{
+		{MergeReplace, `{"a":1,"b":{"x":[2],"y":"old","z":true},"c":[3],"d":null}`},
+		{MergeAppend, `{"a":1,"b":{"x":[1,2],"y":"old","z":true},"c":[1,2,3],"d":null}`},
+		{MergeKeep, `{"a":1,"b":{"x":[1],"y":"old","z":true},"c":[1,2],"d":null}`},
+	}
+	for _, c := range cases {
+		s := decode(t, src)
+		if got := encode(t, Merge(decode(t, dst), s, c.strategy)); got != c.want {
+			t.Errorf("strategy %d: got %s", c.strategy, got)
+		}
+		if got := encode(t, s); got != `{"b":{"x":[2],"z":true},"c":[3],"d":null}` {
+			t.Errorf("strategy %d: src changed: %s", c.strategy, got)
+		}
+	}
+}
+
+func TestMergePatch(t *testing.T) {
+	doc := decode(t, `{"title": "Goodbye!", "author": {"givenName": "John", "familyName": "Doe"}, "tags": ["example", "sample"]}`)
+	patch := decode(t, `{"title": "Hello!", "phoneNumber": "+01-123-456-7890", "author": {"familyName": null}, "tags": ["example"]}`)
+	want := `{"author":{"givenName":"John"},"phoneNumber":"+01-123-456-7890","tags":["example"],"title":"Hello!"}`
+	if got := encode(t, MergePatch(doc, patch)); got != want {
+		t.Error("MergePatch:", got)
+	}
+	if got := encode(t, MergePatch(doc, "x")); got != `"x"` {
+		t.Error("MergePatch non-object:", got)
+	}
+}
+
+func TestApplyPatch(t *testing.T) {
+	const doc = `{"a": {"b": [1, 2, 3]}, "c~d": "x", "e/f": 1}`
+	cases := []struct {
+		patch string
+		want  string
+		err   error
+	}{
+		{`[{"op": "add", "path": "/a/b/1", "value": 9}, {"op": "add", "path": "/a/b/-", "value": 4}]`, `{"a":{"b":[1,9,2,3,4]},"c~d":"x","e/f":1}`, nil},
+		{`[{"op": "remove", "path": "/a/b/0"}, {"op": "remove", "path": "/c~0d"}]`, `{"a":{"b":[2,3]},"e/f":1}`, nil},
+		{`[{"op": "replace", "path": "/e~1f", "value": {"g": null}}]`, `{"a":{"b":[1,2,3]},"c~d":"x","e/f":{"g":null}}`, nil},
+		{`[{"op": "move", "from": "/a/b", "path": "/b"}]`, `{"a":{},"b":[1,2,3],"c~d":"x","e/f":1}`, nil},
+		{`[{"op": "copy", "from": "/a/b/2", "path": "/a/b/0"}]`, `{"a":{"b":[3,1,2,3]},"c~d":"x","e/f":1}`, nil},
+		{`[{"op": "test", "path": "/a", "value": {"b": [1, 2, 3]}}, {"op": "add", "path": "", "value": 1}]`, `1`, nil},
+		{`[{"op": "remove", "path": "/a/b/0"}, {"op": "test", "path": "/c~0d", "value": "y"}]`, ``, ErrTestFailed},
+		{`[{"op": "remove", "path": "/a/x"}]`, ``, dql.ErrNotFound},
+		{`[{"op": "add", "path": "/a/b/4", "value": 0}]`, ``, dql.ErrNotFound},
+		{`[{"op": "move", "from": "/a", "path": "/a/b/0"}]`, ``, ErrInvalidPatch},
+		{`[{"op": "add", "path": "a"}]`, ``, ErrInvalidPatch},
+		{`[{"op": "frobnicate", "path": "/a"}]`, ``, ErrInvalidPatch},
+	}
+	for _, c := range cases {
+		d := decode(t, doc)
+		ret, err := ApplyPatch(d, decode(t, c.patch).([]any))
+		if c.err != nil {
+			if !errors.Is(err, c.err) {
+				t.Errorf("%s: unexpected error: %v", c.patch, err)
+			}
+		} else if err != nil {
+			t.Errorf("%s: %v", c.patch, err)
+		} else if got := encode(t, ret); got != c.want {
+			t.Errorf("%s: got %s", c.patch, got)
+		}
+		if got := encode(t, d); got != `{"a":{"b":[1,2,3]},"c~d":"x","e/f":1}` {
+			t.Errorf("%s: doc changed: %s", c.patch, got)
+		}
+	}
+}
+
+func TestMutation(t *testing.T) {
+	v := decode(t, `{"store": {"book": [{"price": 10}, {"price": 20}], "owner": null}, "tags": ["a", "b"]}`)
+	doc := New(v)
+	prices := doc.JSONPath("$..price")
+	if err := prices.Update(func(n Node) any { return n.Value.(float64) * 0.5 }); err != nil {
+		t.Fatal("Update:", err)
+	}
+	if err := doc.Set("/store/bicycle/color", "red"); err != nil {
+		t.Fatal("Set:", err)
+	}
+	if err := doc.XGo_Elem("store").XGo_Elem("owner").Set("/name", "Bob"); err != nil {
+		t.Fatal("Set null:", err)
+	}
+	if err := doc.XGo_Elem("tags").Set("/-", "c"); err != nil {
+		t.Fatal("Set append:", err)
+	}
+	if err := doc.Delete("/store/book/0"); err != nil {
+		t.Fatal("Delete:", err)
+	}
+	if err := doc.XGo_Elem("tags").Delete("/0"); err != nil {
+		t.Fatal("Delete element:", err)
+	}
+	want := `{"store":{"bicycle":{"color":"red"},"book":[{"price":10}],"owner":{"name":"Bob"}},"tags":["b","c"]}`
+	if got := encode(t, doc); got != want {
+		t.Error("MarshalJSON:", got)
+	}
+	if got := encode(t, v); got != want {
+		t.Error("document:", got)
+	}
+
+	if err := doc.Update(func(n Node) any { return nil }); !errors.Is(err, ErrRootNode) {
+		t.Error("Update root:", err)
+	}
+	if err := New([]any{1}).Set("/-", 2); !errors.Is(err, ErrRootNode) {
+		t.Error("Set root append:", err)
+	}
+	if err := doc.Delete("/store/missing"); !errors.Is(err, dql.ErrNotFound) {
+		t.Error("Delete missing:", err)
+	}
+	if err := doc.Set("/tags/0/x", 1); !errors.Is(err, dql.ErrNotFound) {
+		t.Error("Set into string:", err)
+	}
+}
+
+func TestSorted(t *testing.T) {
+	doc := loadDoc(t, `{"c": 1, "a": {"z": 1, "y": [{"k": 2, "j": 1}]}, "b": 2}`)
+	names := func(ns NodeSet) string {
+		var ret []string
+		ns.Data(func(n Node) bool {
+			ret = append(ret, n.Name)
+			return true
+		})
+		return fmt.Sprint(ret)
+	}
+	for i := 0; i < 5; i++ {
+		if got := names(doc.OrderedChild()); got != "[a b c]" {
+			t.Fatal("OrderedChild:", got)
+		}
+		if got := names(doc.Sorted().XGo_Any("")); got != "[ a y ]" {
+			t.Fatal("XGo_Any:", got)
+		}
+		if got := names(doc.Sorted().JSONPath("$..*")); got != "[a b c y z  j k]" {
+			t.Fatal("JSONPath:", got)
+		}
+		if got := names(doc.Sorted().XGo_Elem("a").XGo_one().XGo_Child()); got != "[y z]" {
+			t.Fatal("XGo_Elem:", got)
+		}
+	}
+}
+
+func TestTypedAccessors(t *testing.T) {
+	doc := loadDoc(t, `{"n": 42, "f": 1.5, "s": "1,024", "b": true, "bs": "false", "t": "2026-01-02", "null": null, "obj": {}}`)
+	if v, err := doc.XGo_Elem("n").Int(); v != 42 || err != nil {
+		t.Error("Int:", v, err)
+	}
+	if v, err := doc.XGo_Elem("s").Int(); v != 1024 || err != nil {
+		t.Error("Int string:", v, err)
+	}
+	if _, err := doc.XGo_Elem("f").Int(); !errors.Is(err, ErrInvalidType) {
+		t.Error("Int fraction:", err)
+	}
+	if v, err := doc.XGo_Elem("f").Float(); v != 1.5 || err != nil {
+		t.Error("Float:", v, err)
+	}
+	if v, err := doc.XGo_Elem("b").Bool(); !v || err != nil {
+		t.Error("Bool:", v, err)
+	}
+	if v, err := doc.XGo_Elem("bs").Bool(); v || err != nil {
+		t.Error("Bool string:", v, err)
+	}
+	if v, err := doc.XGo_Elem("f").Str(); v != "1.5" || err != nil {
+		t.Error("Str:", v, err)
+	}
+	if v, err := doc.XGo_Elem("t").Time("2006-01-02"); v.Day() != 2 || err != nil {
+		t.Error("Time:", v, err)
+	}
+	if _, err := doc.XGo_Elem("obj").Str(); !errors.Is(err, ErrInvalidType) {
+		t.Error("Str object:", err)
+	}
+	for _, name := range []string{"null", "missing"} {
+		if _, err := doc.XGo_Elem(name).Float(); err != dql.ErrNotFound {
+			t.Error(name+":", err)
+		}
+	}
+}
+
+func TestNumber(t *testing.T) {
+	dec := json.NewDecoder(strings.NewReader(`{"items": [{"id": 9007199254740993, "n": 1.5}, {"id": 9007199254740992, "n": 2}]}`))
+	dec.UseNumber()
+	var v any
+	if err := dec.Decode(&v); err != nil {
+		t.Fatal(err)
+	}
+	doc := New(v)
+	id := doc.JSONPath("$.items[0].id")
+	if v, err := id.Int64(); v != 9007199254740993 || err != nil {
+		t.Error("Int64:", v, err)
+	}
+	if v, err := id.Str(); v != "9007199254740993" || err != nil {
+		t.Error("Str:", v, err)
+	}
+	if v, err := doc.JSONPath("$.items[0].n").Float(); v != 1.5 || err != nil {
+		t.Error("Float:", v, err)
+	}
+	if _, err := doc.JSONPath("$.items[0].n").Int(); !errors.Is(err, ErrInvalidType) {
+		t.Error("Int fraction:", err)
+	}
+	if got := values(t, doc.JSONPath("$.items[?(@.id == 9007199254740993)].n"), false); got != "[1.5]" {
+		t.Error("filter ==:", got)
+	}
+	if got := values(t, doc.JSONPath("$.items[?(@.id < 9007199254740993 && @.n >= 2)].n"), false); got != "[2]" {
+		t.Error("filter <:", got)
+	}
+	if got := encode(t, doc); got != `{"items":[{"id":9007199254740993,"n":1.5},{"id":9007199254740992,"n":2}]}` {
+		t.Error("MarshalJSON:", got)
+	}
+}
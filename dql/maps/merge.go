@@ -0,0 +1,375 @@
+/*
+ * Copyright (c) 2026 The XGo Authors (xgo.dev). All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package maps
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/goplus/xgo/dql"
+)
+
+// -----------------------------------------------------------------------------
+
+// MergeStrategy specifies how Merge resolves conflicting values.
+type MergeStrategy int
+
+const (
+	// MergeReplace replaces values (including arrays) in dst by the values
+	// in src, objects are merged recursively.
+	MergeReplace MergeStrategy = iota
+	// MergeAppend is like MergeReplace, except that arrays in src are
+	// appended to the arrays in dst.
+	MergeAppend
+	// MergeKeep keeps existing values in dst and only adds missing fields
+	// from src, objects are merged recursively.
+	MergeKeep
+)
+
+// Merge deeply merges src into dst and returns the result, which is useful
+// for layering configurations:
+//
+//	conf = maps.merge(defaults, userConf, maps.MergeReplace)
+//
+// Objects (map[string]any) in dst are updated in place, values taken from
+// src are copied, so src is never shared with the result.
+func Merge(dst, src any, strategy MergeStrategy) any {
+	switch s := src.(type) {
+	case map[string]any:
+		d, ok := dst.(map[string]any)
+		if !ok {
+			if dst != nil && strategy == MergeKeep {
+				return dst
+			}
+			d = make(map[string]any, len(s))
+		}
+		for k, v := range s {
+			if old, ok := d[k]; ok {
+				d[k] = Merge(old, v, strategy)
+			} else {
+				d[k] = deepCopy(v)
+			}
+		}
+		return d
+	case []any:
+		if d, ok := dst.([]any); ok {
+			switch strategy {
+			case MergeAppend:
+				return append(d, deepCopy(s).([]any)...)
+			case MergeKeep:
+				return d
+			}
+		}
+	}
+	if dst != nil && strategy == MergeKeep {
+		return dst
+	}
+	return deepCopy(src)
+}
+
+// deepCopy returns a copy of the JSON value v.
+func deepCopy(v any) any {
+	switch v := v.(type) {
+	case map[string]any:
+		ret := make(map[string]any, len(v))
+		for k, e := range v {
+			ret[k] = deepCopy(e)
+		}
+		return ret
+	case []any:
+		ret := make([]any, len(v))
+		for i, e := range v {
+			ret[i] = deepCopy(e)
+		}
+		return ret
+	}
+	return v
+}
+
+// -----------------------------------------------------------------------------
+
+// MergePatch applies a JSON merge patch (RFC 7386) to doc and returns the
+// result: fields of objects in patch are merged recursively, null removes a
+// field, and any other value replaces the target. Objects in doc are updated
+// in place.
+func MergePatch(doc, patch any) any {
+	p, ok := patch.(map[string]any)
+	if !ok {
+		return deepCopy(patch)
+	}
+	d, ok := doc.(map[string]any)
+	if !ok {
+		d = make(map[string]any, len(p))
+	}
+	for k, v := range p {
+		if v == nil {
+			delete(d, k)
+		} else {
+			d[k] = MergePatch(d[k], v)
+		}
+	}
+	return d
+}
+
+// -----------------------------------------------------------------------------
+
+var (
+	// ErrInvalidPatch is returned by ApplyPatch if the patch is malformed.
+	ErrInvalidPatch = errors.New("maps: invalid patch")
+	// ErrTestFailed is returned by ApplyPatch if a "test" operation fails.
+	ErrTestFailed = errors.New("maps: patch test failed")
+)
+
+// ApplyPatch applies a JSON patch (RFC 6902), that is, a list of operations
+// decoded from JSON like
+//
+//	[{"op": "replace", "path": "/a/b", "value": 42}, {"op": "remove", "path": "/c/0"}]
+//
+// and returns the patched document. The operations add, remove, replace,
+// move, copy and test are supported. doc is left unchanged, and if any
+// operation fails, the error is returned and no change is applied.
+func ApplyPatch(doc any, patch []any) (any, error) {
+	doc = deepCopy(doc)
+	for i, item := range patch {
+		op, ok := item.(map[string]any)
+		if !ok {
+			return nil, fmt.Errorf("%w: operation %d is not an object", ErrInvalidPatch, i)
+		}
+		var err error
+		if doc, err = applyOp(doc, op); err != nil {
+			return nil, fmt.Errorf("operation %d: %w", i, err)
+		}
+	}
+	return doc, nil
+}
+
+func applyOp(doc any, op map[string]any) (any, error) {
+	name, _ := op["op"].(string)
+	path, err := pointerOf(op, "path")
+	if err != nil {
+		return nil, err
+	}
+	value, hasValue := op["value"]
+	switch name {
+	case "add", "replace", "test":
+		if !hasValue {
+			return nil, fmt.Errorf("%w: %s: missing value", ErrInvalidPatch, name)
+		}
+	}
+	switch name {
+	case "add":
+		return addValue(doc, path, deepCopy(value))
+	case "remove":
+		doc, _, err = removeValue(doc, path)
+		return doc, err
+	case "replace":
+		if doc, _, err = removeValue(doc, path); err != nil {
+			return nil, err
+		}
+		return addValue(doc, path, deepCopy(value))
+	case "move", "copy":
+		from, err := pointerOf(op, "from")
+		if err != nil {
+			return nil, err
+		}
+		var v any
+		if name == "move" {
+			if isPrefix(from, path) && len(from) < len(path) {
+				return nil, fmt.Errorf("%w: move %q into itself", ErrInvalidPatch, op["from"])
+			}
+			doc, v, err = removeValue(doc, from)
+		} else {
+			v, err = getValue(doc, from)
+			v = deepCopy(v)
+		}
+		if err != nil {
+			return nil, err
+		}
+		return addValue(doc, path, v)
+	case "test":
+		v, err := getValue(doc, path)
+		if err != nil {
+			return nil, err
+		}
+		if !equal(normalize(v), normalize(value)) {
+			return nil, fmt.Errorf("%w: %q", ErrTestFailed, op["path"])
+		}
+		return doc, nil
+	}
+	return nil, fmt.Errorf("%w: unknown op %q", ErrInvalidPatch, name)
+}
+
+// pointerOf returns the JSON pointer stored in op[key] as reference tokens.
+func pointerOf(op map[string]any, key string) ([]string, error) {
+	s, ok := op[key].(string)
+	if !ok {
+		return nil, fmt.Errorf("%w: missing %s", ErrInvalidPatch, key)
+	}
+	return parsePointer(s)
+}
+
+// parsePointer parses a JSON pointer (RFC 6901) like "/a/b~1c/0".
+func parsePointer(s string) ([]string, error) {
+	if s == "" {
+		return nil, nil
+	}
+	if s[0] != '/' {
+		return nil, fmt.Errorf("%w: invalid pointer %q", ErrInvalidPatch, s)
+	}
+	tokens := strings.Split(s[1:], "/")
+	for i, tok := range tokens {
+		tokens[i] = strings.ReplaceAll(strings.ReplaceAll(tok, "~1", "/"), "~0", "~")
+	}
+	return tokens, nil
+}
+
+func isPrefix(prefix, path []string) bool {
+	if len(prefix) > len(path) {
+		return false
+	}
+	for i, tok := range prefix {
+		if path[i] != tok {
+			return false
+		}
+	}
+	return true
+}
+
+// arrayIndex converts a reference token to an index of arr. If end is true,
+// the index len(arr) (or "-") is allowed.
+func arrayIndex(arr []any, tok string, end bool) (int, error) {
+	n := len(arr)
+	if tok == "-" && end {
+		return n, nil
+	}
+	i, err := strconv.Atoi(tok)
+	if err != nil || i < 0 || (tok != "0" && tok[0] == '0') {
+		return 0, fmt.Errorf("%w: invalid array index %q", ErrInvalidPatch, tok)
+	}
+	if i > n || (i == n && !end) {
+		return 0, fmt.Errorf("array index %d: %w", i, dql.ErrNotFound)
+	}
+	return i, nil
+}
+
+func getValue(doc any, path []string) (any, error) {
+	for _, tok := range path {
+		switch v := doc.(type) {
+		case map[string]any:
+			var ok bool
+			if doc, ok = v[tok]; !ok {
+				return nil, fmt.Errorf("field %q: %w", tok, dql.ErrNotFound)
+			}
+		case []any:
+			i, err := arrayIndex(v, tok, false)
+			if err != nil {
+				return nil, err
+			}
+			doc = v[i]
+		default:
+			return nil, fmt.Errorf("%q: %w", tok, dql.ErrNotFound)
+		}
+	}
+	return doc, nil
+}
+
+// update replaces the container at the parent of path by fn(container), and
+// returns the updated document. It's needed since appending to or removing
+// from an array creates a new slice.
+func update(doc any, path []string, fn func(parent any, tok string) (any, error)) (any, error) {
+	if len(path) == 1 {
+		return fn(doc, path[0])
+	}
+	tok := path[0]
+	switch v := doc.(type) {
+	case map[string]any:
+		child, ok := v[tok]
+		if !ok {
+			return nil, fmt.Errorf("field %q: %w", tok, dql.ErrNotFound)
+		}
+		child, err := update(child, path[1:], fn)
+		if err != nil {
+			return nil, err
+		}
+		v[tok] = child
+		return v, nil
+	case []any:
+		i, err := arrayIndex(v, tok, false)
+		if err != nil {
+			return nil, err
+		}
+		child, err := update(v[i], path[1:], fn)
+		if err != nil {
+			return nil, err
+		}
+		v[i] = child
+		return v, nil
+	}
+	return nil, fmt.Errorf("%q: %w", tok, dql.ErrNotFound)
+}
+
+func addValue(doc any, path []string, value any) (any, error) {
+	if len(path) == 0 {
+		return value, nil
+	}
+	return update(doc, path, func(parent any, tok string) (any, error) {
+		switch v := parent.(type) {
+		case map[string]any:
+			v[tok] = value
+			return v, nil
+		case []any:
+			i, err := arrayIndex(v, tok, true)
+			if err != nil {
+				return nil, err
+			}
+			return append(v[:i], append([]any{value}, v[i:]...)...), nil
+		}
+		return nil, fmt.Errorf("%q: %w", tok, dql.ErrNotFound)
+	})
+}
+
+// removeValue removes the value at path, and returns the updated document and
+// the removed value.
+func removeValue(doc any, path []string) (ret, old any, err error) {
+	if len(path) == 0 {
+		return nil, doc, nil
+	}
+	ret, err = update(doc, path, func(parent any, tok string) (any, error) {
+		switch v := parent.(type) {
+		case map[string]any:
+			var ok bool
+			if old, ok = v[tok]; !ok {
+				return nil, fmt.Errorf("field %q: %w", tok, dql.ErrNotFound)
+			}
+			delete(v, tok)
+			return v, nil
+		case []any:
+			i, err := arrayIndex(v, tok, false)
+			if err != nil {
+				return nil, err
+			}
+			old = v[i]
+			return append(v[:i], v[i+1:]...), nil
+		}
+		return nil, fmt.Errorf("%q: %w", tok, dql.ErrNotFound)
+	})
+	return
+}
+
+// -----------------------------------------------------------------------------
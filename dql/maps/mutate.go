@@ -0,0 +1,209 @@
+/*
+ * Copyright (c) 2026 The XGo Authors (xgo.dev). All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package maps
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+
+	"github.com/goplus/xgo/dql"
+)
+
+// -----------------------------------------------------------------------------
+
+// ErrRootNode is returned when a root node (or an array at the root) would
+// need to be replaced, which isn't possible in place.
+var ErrRootNode = errors.New("maps: cannot replace the root node")
+
+// Set sets the value at path, a JSON pointer (RFC 6901) like "/a/b/0"
+// relative to each node in the NodeSet, and modifies the underlying maps and
+// slices in place. Missing objects along the path are created, and the
+// index "-" (or the length of an array) appends to the array.
+func (p NodeSet) Set(path string, value any) error {
+	return p.each(func(node Node) error {
+		return node.Set(path, value)
+	})
+}
+
+// Delete deletes the value at path, a JSON pointer (RFC 6901) relative to each
+// node in the NodeSet, and modifies the underlying maps and slices in place.
+func (p NodeSet) Delete(path string) error {
+	return p.each(func(node Node) error {
+		return node.Delete(path)
+	})
+}
+
+// Update replaces the value of each node in the NodeSet by fn(node) in the
+// underlying maps and slices:
+//
+//	doc.**.book.*.price.update(n => n.value.(float64) * 0.9)
+//
+// Root nodes can't be replaced, ErrRootNode is returned for them.
+func (p NodeSet) Update(fn func(node Node) any) error {
+	return p.each(func(node Node) error {
+		return node.replace(fn(node))
+	})
+}
+
+// each calls fn for each node in the NodeSet. The nodes are collected first,
+// so fn can modify the document safely.
+func (p NodeSet) each(fn func(node Node) error) error {
+	if p.Err != nil {
+		return p.Err
+	}
+	var errs []error
+	for _, node := range dql.Collect(p.Data) {
+		if err := fn(node); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// MarshalJSON returns the JSON encoding of the value of the first node in
+// the NodeSet.
+func (p NodeSet) MarshalJSON() ([]byte, error) {
+	node, err := p.XGo_first()
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(node.Value)
+}
+
+// -----------------------------------------------------------------------------
+
+// Set sets the value at path, a JSON pointer (RFC 6901) relative to the node,
+// and modifies the underlying maps and slices in place. See NodeSet.Set.
+func (n Node) Set(path string, value any) error {
+	tokens, err := parsePointer(path)
+	if err != nil {
+		return err
+	}
+	if len(tokens) == 0 {
+		return n.replace(value)
+	}
+	// Appending to an array or setting a field of null creates a new value,
+	// which must be stored in the parent.
+	arr, isArr := n.Value.([]any)
+	newValue := n.Value == nil || isArr && (tokens[0] == "-" || tokens[0] == strconv.Itoa(len(arr)))
+	if newValue && n.parent == nil {
+		return fmt.Errorf("set %s: %w", path, ErrRootNode)
+	}
+	ret, err := setValue(n.Value, tokens, value)
+	if err != nil || !newValue {
+		return err
+	}
+	return n.replace(ret)
+}
+
+// Delete deletes the value at path, a JSON pointer (RFC 6901) relative to the
+// node, and modifies the underlying maps and slices in place.
+func (n Node) Delete(path string) error {
+	tokens, err := parsePointer(path)
+	if err != nil {
+		return err
+	}
+	if len(tokens) == 0 {
+		return n.remove()
+	}
+	// Removing an array element creates a new slice, which must be stored in
+	// the parent.
+	_, isArr := n.Value.([]any)
+	newValue := isArr && len(tokens) == 1
+	if newValue && n.parent == nil {
+		return fmt.Errorf("delete %s: %w", path, ErrRootNode)
+	}
+	ret, _, err := removeValue(n.Value, tokens)
+	if err != nil || !newValue {
+		return err
+	}
+	return n.replace(ret)
+}
+
+// replace replaces the value of the node in its parent.
+func (n Node) replace(value any) error {
+	switch parent := n.parent.(type) {
+	case map[string]any:
+		parent[n.Name] = value
+	case []any:
+		parent[n.index] = value
+	default:
+		return ErrRootNode
+	}
+	return nil
+}
+
+// remove removes the node from its parent. Array elements can't be removed
+// this way since it changes the length of the array, which is stored in the
+// grandparent.
+func (n Node) remove() error {
+	if parent, ok := n.parent.(map[string]any); ok {
+		delete(parent, n.Name)
+		return nil
+	}
+	return ErrRootNode
+}
+
+// MarshalJSON returns the JSON encoding of the value of the node.
+func (n Node) MarshalJSON() ([]byte, error) {
+	return json.Marshal(n.Value)
+}
+
+// -----------------------------------------------------------------------------
+
+// setValue sets the value at path and returns the updated document. Missing
+// objects along the path are created.
+func setValue(doc any, path []string, value any) (any, error) {
+	if len(path) == 0 {
+		return value, nil
+	}
+	tok := path[0]
+	switch v := doc.(type) {
+	case map[string]any:
+		child, err := setValue(v[tok], path[1:], value)
+		if err != nil {
+			return nil, err
+		}
+		v[tok] = child
+		return v, nil
+	case []any:
+		i, err := arrayIndex(v, tok, true)
+		if err != nil {
+			return nil, err
+		}
+		if i == len(v) {
+			child, err := setValue(nil, path[1:], value)
+			if err != nil {
+				return nil, err
+			}
+			return append(v, child), nil
+		}
+		child, err := setValue(v[i], path[1:], value)
+		if err != nil {
+			return nil, err
+		}
+		v[i] = child
+		return v, nil
+	case nil:
+		return setValue(map[string]any{}, path, value)
+	}
+	return nil, fmt.Errorf("%q: %w", tok, dql.ErrNotFound)
+}
+
+// -----------------------------------------------------------------------------
@@ -26,6 +26,9 @@ import (
 type Node struct {
 	Name  string
 	Value any
+
+	parent any // map[string]any or []any containing the node, nil for a root node
+	index  int // index of the node in the parent array
 }
 
 // XGo_Elem returns the child node with the specified name.
@@ -34,7 +37,7 @@ type Node struct {
 func (n Node) XGo_Elem(name string) (ret Node) {
 	if children, ok := n.Value.(map[string]any); ok {
 		if v, ok := children[name]; ok {
-			ret = Node{Name: name, Value: v}
+			ret = Node{Name: name, Value: v, parent: children}
 		}
 	}
 	return
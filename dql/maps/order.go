@@ -0,0 +1,66 @@
+/*
+ * Copyright (c) 2026 The XGo Authors (xgo.dev). All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package maps
+
+import (
+	"iter"
+	"maps"
+	"slices"
+)
+
+// -----------------------------------------------------------------------------
+
+// Sorted returns a copy of the NodeSet whose queries (XGo_Child, XGo_Any,
+// JSONPath, etc.) yield the members of objects in key order instead of the
+// random map order, so the output of queries is reproducible:
+//
+//	for e in doc.sorted.**.* {
+//		echo e.name
+//	}
+func (p NodeSet) Sorted() NodeSet {
+	p.sorted = true
+	return p
+}
+
+// OrderedChild returns a NodeSet containing all child nodes of the nodes in
+// the NodeSet, with the members of objects in key order.
+func (p NodeSet) OrderedChild() NodeSet {
+	return p.Sorted().XGo_Child()
+}
+
+// OrderedChild returns a NodeSet containing all child nodes of the node, with
+// the members of objects in key order.
+func (n Node) OrderedChild() NodeSet {
+	return Root(n).OrderedChild()
+}
+
+// members returns an iterator over the members of the object, in key order
+// if sorted is true.
+func members(obj map[string]any, sorted bool) iter.Seq2[string, any] {
+	if !sorted {
+		return maps.All(obj)
+	}
+	return func(yield func(string, any) bool) {
+		for _, k := range slices.Sorted(maps.Keys(obj)) {
+			if !yield(k, obj[k]) {
+				return
+			}
+		}
+	}
+}
+
+// -----------------------------------------------------------------------------
@@ -0,0 +1,196 @@
+/*
+ * Copyright (c) 2026 The XGo Authors (xgo.dev). All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package maps
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/goplus/xgo/dql"
+)
+
+// -----------------------------------------------------------------------------
+
+// ErrInvalidType is returned by the typed accessors (Int, Float, etc.) if the
+// value can't be converted to the requested type.
+var ErrInvalidType = errors.New("maps: invalid value type")
+
+func invalidType(v any, typ string) error {
+	return fmt.Errorf("%w: cannot convert %T to %s", ErrInvalidType, v, typ)
+}
+
+// Int returns the value of the first node in the NodeSet as an integer.
+// Numbers without a fractional part (including json.Number, which is
+// converted without losing precision) and numeric strings are accepted.
+// If the NodeSet is empty, it returns ErrNotFound.
+func (p NodeSet) Int() (int, error) {
+	node, err := p.XGo_first()
+	if err != nil {
+		return 0, err
+	}
+	return node.Int()
+}
+
+// Int64 returns the value of the first node in the NodeSet as a 64-bit
+// integer. Unlike Int, it doesn't lose precision on 32-bit platforms.
+// If the NodeSet is empty, it returns ErrNotFound.
+func (p NodeSet) Int64() (int64, error) {
+	node, err := p.XGo_first()
+	if err != nil {
+		return 0, err
+	}
+	return node.Int64()
+}
+
+// Float returns the value of the first node in the NodeSet as a float64.
+// Numbers and numeric strings are accepted.
+// If the NodeSet is empty, it returns ErrNotFound.
+func (p NodeSet) Float() (float64, error) {
+	node, err := p.XGo_first()
+	if err != nil {
+		return 0, err
+	}
+	return node.Float()
+}
+
+// Bool returns the value of the first node in the NodeSet as a boolean.
+// Booleans and the strings accepted by strconv.ParseBool are accepted.
+// If the NodeSet is empty, it returns ErrNotFound.
+func (p NodeSet) Bool() (bool, error) {
+	node, err := p.XGo_first()
+	if err != nil {
+		return false, err
+	}
+	return node.Bool()
+}
+
+// Str returns the value of the first node in the NodeSet as a string.
+// Strings, numbers and booleans are accepted.
+// If the NodeSet is empty, it returns ErrNotFound.
+func (p NodeSet) Str() (string, error) {
+	node, err := p.XGo_first()
+	if err != nil {
+		return "", err
+	}
+	return node.Str()
+}
+
+// Time parses the value of the first node in the NodeSet, which must be a
+// string, as a time with the specified layout (see time.Parse).
+// If the NodeSet is empty, it returns ErrNotFound.
+func (p NodeSet) Time(layout string) (time.Time, error) {
+	node, err := p.XGo_first()
+	if err != nil {
+		return time.Time{}, err
+	}
+	return node.Time(layout)
+}
+
+// -----------------------------------------------------------------------------
+
+// Int returns the value of the node as an integer. See NodeSet.Int.
+func (n Node) Int() (int, error) {
+	v, err := n.Int64()
+	if err == nil && (v < math.MinInt || v > math.MaxInt) {
+		return 0, invalidType(n.Value, "int")
+	}
+	return int(v), err
+}
+
+// Int64 returns the value of the node as a 64-bit integer. See NodeSet.Int64.
+func (n Node) Int64() (int64, error) {
+	switch v := normalize(n.Value).(type) {
+	case int64:
+		return v, nil
+	case float64:
+		if v == math.Trunc(v) && v >= math.MinInt64 && v < math.MaxInt64 {
+			return int64(v), nil
+		}
+	case string:
+		return strconv.ParseInt(strings.ReplaceAll(strings.TrimSpace(v), ",", ""), 10, 64)
+	case nil:
+		return 0, dql.ErrNotFound
+	}
+	return 0, invalidType(n.Value, "int64")
+}
+
+// Float returns the value of the node as a float64. See NodeSet.Float.
+func (n Node) Float() (float64, error) {
+	switch v := normalize(n.Value).(type) {
+	case float64:
+		return v, nil
+	case int64:
+		return float64(v), nil
+	case string:
+		return strconv.ParseFloat(strings.TrimSpace(v), 64)
+	case nil:
+		return 0, dql.ErrNotFound
+	}
+	return 0, invalidType(n.Value, "float64")
+}
+
+// Bool returns the value of the node as a boolean. See NodeSet.Bool.
+func (n Node) Bool() (bool, error) {
+	switch v := n.Value.(type) {
+	case bool:
+		return v, nil
+	case string:
+		return strconv.ParseBool(strings.TrimSpace(v))
+	case nil:
+		return false, dql.ErrNotFound
+	}
+	return false, invalidType(n.Value, "bool")
+}
+
+// Str returns the value of the node as a string. See NodeSet.Str.
+func (n Node) Str() (string, error) {
+	if v, ok := n.Value.(json.Number); ok {
+		return v.String(), nil // keep the original text
+	}
+	switch v := normalize(n.Value).(type) {
+	case string:
+		return v, nil
+	case int64:
+		return strconv.FormatInt(v, 10), nil
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64), nil
+	case bool:
+		return strconv.FormatBool(v), nil
+	case nil:
+		return "", dql.ErrNotFound
+	}
+	return "", invalidType(n.Value, "string")
+}
+
+// Time parses the value of the node as a time with the specified layout.
+// See NodeSet.Time.
+func (n Node) Time(layout string) (time.Time, error) {
+	switch v := n.Value.(type) {
+	case string:
+		return time.Parse(layout, v)
+	case nil:
+		return time.Time{}, dql.ErrNotFound
+	}
+	return time.Time{}, invalidType(n.Value, "time.Time")
+}
+
+// -----------------------------------------------------------------------------
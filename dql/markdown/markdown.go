@@ -0,0 +1,393 @@
+/*
+ * Copyright (c) 2026 The XGo Authors (xgo.dev). All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package markdown
+
+import (
+	"bytes"
+	"io"
+	"iter"
+	"sort"
+	"strconv"
+	"strings"
+
+	dhtml "github.com/goplus/xgo/dql/html"
+	"github.com/goplus/xgo/dql/stream"
+	"github.com/yuin/goldmark"
+	"github.com/yuin/goldmark/ast"
+	"github.com/yuin/goldmark/extension"
+	east "github.com/yuin/goldmark/extension/ast"
+	"github.com/yuin/goldmark/text"
+	"golang.org/x/net/html"
+	"golang.org/x/net/html/atom"
+)
+
+const (
+	XGoPackage = "github.com/goplus/xgo/dql/html"
+)
+
+// -----------------------------------------------------------------------------
+
+// Node represents a node of a Markdown document, which is an HTML node.
+type Node = dhtml.Node
+
+// NodeSet represents a set of Markdown nodes.
+type NodeSet = dhtml.NodeSet
+
+// Parse parses a Markdown document (with the GitHub Flavored Markdown
+// extensions: tables, strikethrough, task lists and autolinks) to a tree of
+// the HTML elements it's rendered to, so it's queried like HTML:
+//
+//	doc := markdown.source("README.md")
+//	for h in doc.**.h2 {
+//		echo h.$line, h.text
+//	}
+//	for a in doc.**.a {
+//		echo a.$url
+//	}
+//
+// Elements have extra attributes for querying:
+//   - h1-h6: level (1-6).
+//   - pre: language of a fenced code block, like "go". Its child is a code
+//     element with the class "language-go", whose text is the code.
+//   - a: url (the same as href) and title. img: url (the same as src), alt
+//     and title.
+//   - ol: start. li: checked ("true" or "false") of a task list item.
+//   - blocks (like p, pre and ul): line, the line number where it starts.
+//
+// Raw HTML blocks are parsed as HTML elements, and inline raw HTML is kept as
+// text. YAML front matter (between the "---" lines at the beginning) is the
+// text of a frontmatter element.
+func Parse(src []byte) *Node {
+	c := &converter{src: src}
+	doc := c.element(nil, "", nil)
+	doc.Type = html.DocumentNode
+	if fm, rest, ok := frontMatter(src); ok {
+		c.text(c.element(doc, "frontmatter", nil), string(fm))
+		c.offset = len(src) - len(rest)
+		src = rest
+	}
+	c.lines = lineStarts(c.src)
+	md := goldmark.New(goldmark.WithExtensions(extension.GFM))
+	root := md.Parser().Parse(text.NewReader(src))
+	c.src = src
+	c.children(doc, root)
+	return doc
+}
+
+// New parses a Markdown document read from r, and returns a NodeSet
+// containing the document node. See Parse.
+func New(r io.Reader) NodeSet {
+	src, err := io.ReadAll(r)
+	if err != nil {
+		return NodeSet{Err: err}
+	}
+	return dhtml.Root(Parse(src))
+}
+
+// Source creates a NodeSet from various types of sources:
+// - string: treats the string as a file path (or URL), opens it, and reads Markdown from it.
+// - *stream.Request: opens the resource with the request options (like HTTP headers).
+// - []byte: treated as Markdown text.
+// - io.Reader: reads Markdown text from the reader.
+// - *Node: creates a NodeSet containing the single provided node.
+// - iter.Seq[*Node]: directly uses the provided sequence of nodes.
+// - NodeSet: returns the provided NodeSet as is.
+// If the source type is unsupported, it panics.
+func Source(r any) (ret NodeSet) {
+	switch v := r.(type) {
+	case string:
+		f, err := stream.Open(v)
+		if err != nil {
+			return NodeSet{Err: err}
+		}
+		defer f.Close()
+		return New(f)
+	case *stream.Request:
+		f, err := v.Open()
+		if err != nil {
+			return NodeSet{Err: err}
+		}
+		defer f.Close()
+		return New(f)
+	case []byte:
+		return dhtml.Root(Parse(v))
+	case io.Reader:
+		return New(v)
+	case *Node:
+		return dhtml.Root(v)
+	case iter.Seq[*Node]:
+		return NodeSet{Data: v}
+	case NodeSet:
+		return v
+	default:
+		panic("dql/markdown.Source: unsupported source type")
+	}
+}
+
+// -----------------------------------------------------------------------------
+
+// converter converts a goldmark AST to HTML nodes.
+type converter struct {
+	src    []byte
+	offset int   // offset of src in the whole document (after the front matter)
+	lines  []int // offsets of the lines of the whole document
+}
+
+func (c *converter) element(parent *Node, name string, attrs []html.Attribute) *Node {
+	n := &Node{Node: html.Node{
+		Type:     html.ElementNode,
+		Data:     name,
+		DataAtom: atom.Lookup([]byte(name)),
+		Attr:     attrs,
+	}}
+	if parent != nil {
+		parent.AppendChild(&n.Node)
+	}
+	return n
+}
+
+// text appends a text node, merging it with the previous one.
+func (c *converter) text(parent *Node, s string) {
+	if s == "" {
+		return
+	}
+	if last := parent.LastChild; last != nil && last.Type == html.TextNode {
+		last.Data += s
+		return
+	}
+	parent.AppendChild(&(&Node{Node: html.Node{Type: html.TextNode, Data: s}}).Node)
+}
+
+func (c *converter) children(parent *Node, n ast.Node) {
+	for child := n.FirstChild(); child != nil; child = child.NextSibling() {
+		c.node(parent, child)
+	}
+}
+
+func attr(key, val string) html.Attribute {
+	return html.Attribute{Key: key, Val: val}
+}
+
+func (c *converter) node(parent *Node, n ast.Node) {
+	var elem *Node
+	switch n := n.(type) {
+	case *ast.Heading:
+		level := strconv.Itoa(n.Level)
+		elem = c.element(parent, "h"+level, []html.Attribute{attr("level", level)})
+	case *ast.Paragraph:
+		elem = c.element(parent, "p", nil)
+	case *ast.TextBlock: // paragraphs of tight lists
+		c.children(parent, n)
+		return
+	case *ast.Blockquote:
+		elem = c.element(parent, "blockquote", nil)
+	case *ast.ThematicBreak:
+		elem = c.element(parent, "hr", nil)
+	case *ast.List:
+		if n.IsOrdered() {
+			elem = c.element(parent, "ol", []html.Attribute{attr("start", strconv.Itoa(n.Start))})
+		} else {
+			elem = c.element(parent, "ul", nil)
+		}
+	case *ast.ListItem:
+		elem = c.element(parent, "li", nil)
+	case *ast.FencedCodeBlock:
+		var attrs []html.Attribute
+		var class []html.Attribute
+		if lang := string(n.Language(c.src)); lang != "" {
+			attrs = []html.Attribute{attr("language", lang)}
+			class = []html.Attribute{attr("class", "language-"+lang)}
+		}
+		elem = c.element(parent, "pre", attrs)
+		c.text(c.element(elem, "code", class), c.segments(n.Lines()))
+		c.setLine(elem, n)
+		return
+	case *ast.CodeBlock:
+		elem = c.element(parent, "pre", nil)
+		c.text(c.element(elem, "code", nil), c.segments(n.Lines()))
+		c.setLine(elem, n)
+		return
+	case *ast.HTMLBlock:
+		raw := c.segments(n.Lines())
+		if n.HasClosure() {
+			raw += string(n.ClosureLine.Value(c.src))
+		}
+		context := &html.Node{Type: html.ElementNode, Data: "body", DataAtom: atom.Body}
+		nodes, err := html.ParseFragment(strings.NewReader(raw), context)
+		if err != nil {
+			c.text(parent, raw)
+			return
+		}
+		for _, child := range nodes {
+			parent.AppendChild(child)
+		}
+		return
+	case *ast.Text:
+		c.text(parent, string(n.Value(c.src)))
+		if n.HardLineBreak() {
+			c.element(parent, "br", nil)
+		} else if n.SoftLineBreak() {
+			c.text(parent, "\n")
+		}
+		return
+	case *ast.String:
+		c.text(parent, string(n.Value))
+		return
+	case *ast.CodeSpan:
+		elem = c.element(parent, "code", nil)
+	case *ast.Emphasis:
+		name := "em"
+		if n.Level == 2 {
+			name = "strong"
+		}
+		elem = c.element(parent, name, nil)
+	case *ast.Link:
+		url := string(n.Destination)
+		attrs := []html.Attribute{attr("href", url), attr("url", url)}
+		if len(n.Title) > 0 {
+			attrs = append(attrs, attr("title", string(n.Title)))
+		}
+		elem = c.element(parent, "a", attrs)
+	case *ast.AutoLink:
+		url := string(n.URL(c.src))
+		elem = c.element(parent, "a", []html.Attribute{attr("href", url), attr("url", url)})
+		c.text(elem, string(n.Label(c.src)))
+		return
+	case *ast.Image:
+		url := string(n.Destination)
+		alt := textOf(n, c.src)
+		attrs := []html.Attribute{attr("src", url), attr("url", url), attr("alt", alt)}
+		if len(n.Title) > 0 {
+			attrs = append(attrs, attr("title", string(n.Title)))
+		}
+		c.element(parent, "img", attrs)
+		return
+	case *ast.RawHTML:
+		for i := 0; i < n.Segments.Len(); i++ {
+			seg := n.Segments.At(i)
+			c.text(parent, string(seg.Value(c.src)))
+		}
+		return
+	case *east.Strikethrough:
+		elem = c.element(parent, "del", nil)
+	case *east.TaskCheckBox:
+		checked := strconv.FormatBool(n.IsChecked)
+		for p := &parent.Node; p != nil; p = p.Parent {
+			if p.Type == html.ElementNode && p.Data == "li" {
+				p.Attr = append(p.Attr, attr("checked", checked))
+				break
+			}
+		}
+		return
+	case *east.Table:
+		elem = c.element(parent, "table", nil)
+	case *east.TableHeader:
+		elem = c.element(c.element(parent, "thead", nil), "tr", nil)
+	case *east.TableRow:
+		elem = c.element(parent, "tr", nil)
+	case *east.TableCell:
+		name := "td"
+		if _, ok := n.Parent().(*east.TableHeader); ok {
+			name = "th"
+		}
+		var attrs []html.Attribute
+		if n.Alignment != east.AlignNone {
+			attrs = []html.Attribute{attr("align", n.Alignment.String())}
+		}
+		elem = c.element(parent, name, attrs)
+	default:
+		c.children(parent, n) // unknown nodes are transparent
+		return
+	}
+	if n.Type() == ast.TypeBlock {
+		c.setLine(elem, n)
+	}
+	c.children(elem, n)
+}
+
+// segments returns the text of the lines of a block.
+func (c *converter) segments(lines *text.Segments) string {
+	var b strings.Builder
+	for i := 0; i < lines.Len(); i++ {
+		seg := lines.At(i)
+		b.Write(seg.Value(c.src))
+	}
+	return b.String()
+}
+
+// setLine sets the line attribute of a block element by its first line.
+func (c *converter) setLine(elem *Node, n ast.Node) {
+	for ; n != nil; n = n.FirstChild() {
+		if n.Type() != ast.TypeBlock {
+			return
+		}
+		if lines := n.Lines(); lines.Len() > 0 {
+			offset := c.offset + lines.At(0).Start
+			line := sort.Search(len(c.lines), func(i int) bool { return c.lines[i] > offset })
+			elem.Attr = append(elem.Attr, attr("line", strconv.Itoa(line)))
+			return
+		}
+	}
+}
+
+// textOf returns the plain text of the inline children of a node.
+func textOf(n ast.Node, src []byte) string {
+	var b bytes.Buffer
+	ast.Walk(n, func(n ast.Node, entering bool) (ast.WalkStatus, error) {
+		if entering {
+			switch n := n.(type) {
+			case *ast.Text:
+				b.Write(n.Value(src))
+			case *ast.String:
+				b.Write(n.Value)
+			}
+		}
+		return ast.WalkContinue, nil
+	})
+	return b.String()
+}
+
+func lineStarts(src []byte) []int {
+	lines := []int{0}
+	for i, c := range src {
+		if c == '\n' {
+			lines = append(lines, i+1)
+		}
+	}
+	return lines
+}
+
+// frontMatter splits the YAML front matter from a document.
+func frontMatter(src []byte) (fm, rest []byte, ok bool) {
+	rest, ok = bytes.CutPrefix(src, []byte("---\n"))
+	if !ok {
+		return
+	}
+	for off := 0; off < len(rest); {
+		line := rest[off:]
+		if i := bytes.IndexByte(line, '\n'); i >= 0 {
+			line = line[:i+1]
+		}
+		if s := bytes.TrimRight(line, "\r\n"); string(s) == "---" || string(s) == "..." {
+			return rest[:off], rest[off+len(line):], true
+		}
+		off += len(line)
+	}
+	return nil, src, false
+}
+
+// -----------------------------------------------------------------------------
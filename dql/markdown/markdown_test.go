@@ -0,0 +1,127 @@
+/*
+ * Copyright (c) 2026 The XGo Authors (xgo.dev). All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package markdown
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const readme = `---
+title: Demo
+---
+# Demo
+
+Intro with a [link](https://xgo.dev "XGo") and ![logo](logo.png).
+
+## Install
+
+` + "```sh" + `
+go install ./...
+` + "```" + `
+
+1. first
+2. second *item*
+
+- [x] done
+- [ ] todo
+
+| Name | Size |
+|:-----|-----:|
+| a    | 1    |
+
+<div class="note"><a href="/docs">docs</a></div>
+
+## Usage
+`
+
+func attrs(ns NodeSet, name string) (ret []string) {
+	for n := range ns.XGo_Enum() {
+		ret = append(ret, n.XGo_Attr__0(name))
+	}
+	return
+}
+
+func TestParse(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "README.md")
+	if err := os.WriteFile(file, []byte(readme), 0644); err != nil {
+		t.Fatal(err)
+	}
+	doc := Source(file)
+	if doc.Err != nil {
+		t.Fatal("Source:", doc.Err)
+	}
+	if v := doc.XGo_Elem("frontmatter").Text__0(); v != "title: Demo" {
+		t.Fatalf("frontmatter: %q", v)
+	}
+	if v := doc.XGo_Elem("h1").XGo_Attr__0("line"); v != "4" {
+		t.Fatal("line of h1:", v)
+	}
+	h2 := doc.XGo_Any("h2")
+	if v := attrs(h2, "level"); strings.Join(v, ",") != "2,2" {
+		t.Fatal("levels:", v)
+	}
+	if v := attrs(h2, "line"); strings.Join(v, ",") != "8,26" {
+		t.Fatal("lines:", v)
+	}
+	if v := doc.XGo_Any("a").All(); strings.Join(attrs(v, "href"), ",") != "https://xgo.dev,/docs" {
+		t.Fatal("links:", attrs(v, "href"))
+	}
+	if v := doc.XGo_Any("a").XGo_Attr__0("url"); v != "https://xgo.dev" {
+		t.Fatal("url:", v)
+	}
+	if v := doc.XGo_Any("a").XGo_Attr__0("title"); v != "XGo" {
+		t.Fatal("title:", v)
+	}
+	if v := doc.XGo_Any("img").XGo_Attr__0("alt"); v != "logo" {
+		t.Fatal("alt:", v)
+	}
+	pre := doc.XGo_Any("pre")
+	if lang := pre.XGo_Attr__0("language"); lang != "sh" {
+		t.Fatal("language:", lang)
+	}
+	if code := pre.XGo_Elem("code"); !code.HasClass("language-sh") || pre.Text__0() != "go install ./...\n" {
+		t.Fatal("code:", pre.Text__0())
+	}
+	if v := doc.XGo_Any("ol").XGo_Attr__0("start"); v != "1" {
+		t.Fatal("start:", v)
+	}
+	if v := doc.XGo_Any("ol").XGo_Child().All(); strings.Join(texts(v), ",") != "first,second item" {
+		t.Fatal("items:", texts(v))
+	}
+	if v := attrs(doc.XGo_Any("ul").XGo_Child(), "checked"); strings.Join(v, ",") != "true,false" {
+		t.Fatal("tasks:", v)
+	}
+	if v := doc.XGo_Any("th").All(); strings.Join(attrs(v, "align"), ",") != "left,right" {
+		t.Fatal("align:", attrs(v, "align"))
+	}
+	if v := doc.XGo_Any("td").Text__0(); v != "a" {
+		t.Fatal("td:", v)
+	}
+	if !doc.XGo_Any("div").HasClass("note") {
+		t.Fatal("raw HTML block")
+	}
+}
+
+func texts(ns NodeSet) (ret []string) {
+	for n := range ns.XGo_Enum() {
+		ret = append(ret, n.Text__0())
+	}
+	return
+}
@@ -0,0 +1,180 @@
+/*
+ * Copyright (c) 2026 The XGo Authors (xgo.dev). All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package ndjson
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"iter"
+
+	"github.com/goplus/xgo/dql/maps"
+	"github.com/goplus/xgo/dql/stream"
+)
+
+const (
+	XGoPackage = "github.com/goplus/xgo/dql/maps"
+)
+
+// -----------------------------------------------------------------------------
+
+// Node represents a JSON value of a line.
+type Node = maps.Node
+
+// NodeSet represents a set of NDJSON nodes.
+type NodeSet = maps.NodeSet
+
+// Config specifies how NDJSON data is read.
+type Config struct {
+	// UseNumber decodes numbers as json.Number instead of float64, so 64-bit
+	// integers keep their precision.
+	UseNumber bool
+
+	// SkipInvalid skips the lines which aren't valid JSON, instead of stopping
+	// the iteration. The errors are still passed to OnError.
+	SkipInvalid bool
+
+	// OnError is called if an error occurs while reading lines.
+	OnError func(error)
+}
+
+// LineError is passed to Config.OnError if a line isn't valid JSON.
+type LineError struct {
+	Line int // line number, starting at 1
+	Err  error
+}
+
+func (e *LineError) Error() string {
+	return fmt.Sprintf("ndjson: line %d: %v", e.Line, e.Err)
+}
+
+func (e *LineError) Unwrap() error {
+	return e.Err
+}
+
+// New creates an NDJSON NodeSet reading newline-delimited JSON values (like
+// JSON logs) from r. Each line is a root node, and blank lines are ignored:
+//
+//	for e in ndjson.source("app.log")@($level == "error") {
+//		echo e.$time, e.$msg
+//	}
+//
+// Lines are decoded one by one while iterating, so large files are filtered
+// with constant memory, and it means the NodeSet can only be iterated once
+// (use _all to traverse the nodes multiple times).
+func New(r io.Reader, conf ...Config) NodeSet {
+	var c Config
+	if len(conf) > 0 {
+		c = conf[0]
+	}
+	return NodeSet{Data: lines(r, c)}
+}
+
+// Source creates an NDJSON NodeSet from various source types:
+// - string: treats the string as a file path (or URL), opens it, and reads NDJSON data from it.
+// - *stream.Request: opens the resource with the request options (like HTTP headers).
+// - []byte: reads NDJSON data from the byte slice.
+// - io.Reader: reads NDJSON data from the provided reader.
+// - Node: creates a NodeSet containing the single provided node.
+// - iter.Seq[Node]: directly uses the provided sequence of nodes.
+// - NodeSet: returns the provided NodeSet as is.
+// If the source type is unsupported, it panics.
+//
+// A file (or a resource) is closed after the nodes are iterated.
+func Source(r any, conf ...Config) (ret NodeSet) {
+	switch v := r.(type) {
+	case string:
+		f, err := stream.Open(v)
+		if err != nil {
+			return NodeSet{Err: err}
+		}
+		return closeAfter(New(f, conf...), f)
+	case *stream.Request:
+		f, err := v.Open()
+		if err != nil {
+			return NodeSet{Err: err}
+		}
+		return closeAfter(New(f, conf...), f)
+	case []byte:
+		return New(bytes.NewReader(v), conf...)
+	case io.Reader:
+		return New(v, conf...)
+	case Node:
+		return maps.Root(v)
+	case iter.Seq[Node]:
+		return NodeSet{Data: v}
+	case NodeSet:
+		return v
+	default:
+		panic("dql/ndjson.Source: unsupported source type")
+	}
+}
+
+// closeAfter closes f after the nodes of ns are iterated.
+func closeAfter(ns NodeSet, f io.Closer) NodeSet {
+	data := ns.Data
+	ns.Data = func(yield func(Node) bool) {
+		defer f.Close()
+		data(yield)
+	}
+	return ns
+}
+
+// -----------------------------------------------------------------------------
+
+func lines(r io.Reader, c Config) iter.Seq[Node] {
+	return func(yield func(Node) bool) {
+		br := bufio.NewReader(r)
+		for lineno := 1; ; lineno++ {
+			line, err := br.ReadBytes('\n')
+			if len(bytes.TrimSpace(line)) > 0 {
+				v, e := decode(line, c.UseNumber)
+				if e != nil {
+					if c.OnError != nil {
+						c.OnError(&LineError{Line: lineno, Err: e})
+					}
+					if !c.SkipInvalid {
+						return
+					}
+				} else if !yield(Node{Value: v}) {
+					return
+				}
+			}
+			if err != nil {
+				if err != io.EOF && c.OnError != nil {
+					c.OnError(err)
+				}
+				return
+			}
+		}
+	}
+}
+
+func decode(line []byte, useNumber bool) (v any, err error) {
+	dec := json.NewDecoder(bytes.NewReader(line))
+	if useNumber {
+		dec.UseNumber()
+	}
+	if err = dec.Decode(&v); err == nil && dec.More() {
+		err = fmt.Errorf("invalid data after the JSON value at offset %d", dec.InputOffset())
+	}
+	return
+}
+
+// -----------------------------------------------------------------------------
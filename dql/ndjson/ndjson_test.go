@@ -0,0 +1,96 @@
+/*
+ * Copyright (c) 2026 The XGo Authors (xgo.dev). All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package ndjson
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/goplus/xgo/dql"
+)
+
+const logs = `{"level":"info","msg":"start","id":9007199254740993}
+
+{"level":"error","msg":"disk full"}
+{"level":"error","msg":
+{"level":"info","msg":"done"} x
+{"level":"error","msg":"timeout"}
+`
+
+func messages(ns NodeSet) (ret []string) {
+	for e := range ns.XGo_Enum() {
+		if e.XGo_Attr__0("level") == "error" {
+			ret = append(ret, e.XGo_Attr__0("msg").(string))
+		}
+	}
+	return
+}
+
+func TestSource(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "app.log")
+	if err := os.WriteFile(file, []byte(logs), 0644); err != nil {
+		t.Fatal(err)
+	}
+	var errs []int
+	ns := Source(file, Config{SkipInvalid: true, UseNumber: true, OnError: func(err error) {
+		var e *LineError
+		if errors.As(err, &e) {
+			errs = append(errs, e.Line)
+		}
+	}})
+	if msgs := messages(ns); strings.Join(msgs, ",") != "disk full,timeout" {
+		t.Fatal("messages:", msgs)
+	}
+	if len(errs) != 2 || errs[0] != 4 || errs[1] != 5 {
+		t.Fatal("errors:", errs)
+	}
+
+	first := dql.Collect(Source([]byte(logs), Config{UseNumber: true}).Data)
+	if len(first) != 2 {
+		t.Fatal("stop at the invalid line:", len(first))
+	}
+	if id := first[0].Value.(map[string]any)["id"]; id != json.Number("9007199254740993") {
+		t.Fatal("id:", id)
+	}
+}
+
+type lazyReader struct {
+	r    io.Reader
+	read int
+}
+
+func (p *lazyReader) Read(b []byte) (int, error) {
+	n, err := p.r.Read(b[:min(len(b), 16)])
+	p.read += n
+	return n, err
+}
+
+func TestLazy(t *testing.T) {
+	data := strings.Repeat(`{"n":1}`+"\n", 10000)
+	r := &lazyReader{r: strings.NewReader(data)}
+	ns := New(r)
+	for range ns.XGo_one().XGo_Enum() {
+	}
+	if r.read >= len(data)/2 {
+		t.Fatal("not read lazily:", r.read)
+	}
+}
@@ -0,0 +1,635 @@
+/*
+ * Copyright (c) 2026 The XGo Authors (xgo.dev). All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package parquet
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"math/big"
+	"time"
+)
+
+// This file reads the Parquet file format, see
+// https://github.com/apache/parquet-format.
+
+// -----------------------------------------------------------------------------
+
+var (
+	// ErrNotParquet is returned if a file isn't a Parquet file.
+	ErrNotParquet = errors.New("parquet: file is not a Parquet file")
+
+	errCorrupt = errors.New("parquet: file is corrupt")
+)
+
+// physical types
+const (
+	typeBoolean   = 0
+	typeInt32     = 1
+	typeInt64     = 2
+	typeInt96     = 3
+	typeFloat     = 4
+	typeDouble    = 5
+	typeByteArray = 6
+	typeFixedLen  = 7
+)
+
+// converted types (the legacy logical types)
+const (
+	convDecimal         = 5
+	convDate            = 6
+	convTimestampMillis = 9
+	convTimestampMicros = 10
+	convUint32          = 13
+	convUint64          = 14
+)
+
+// page types
+const (
+	pageData       = 0
+	pageDictionary = 2
+	pageDataV2     = 3
+)
+
+// encodings
+const (
+	encPlain          = 0
+	encPlainDict      = 2
+	encRLE            = 3
+	encRLEDictionary  = 8
+	codecUncompressed = 0
+	codecSnappy       = 1
+	codecGzip         = 2
+)
+
+// column is a leaf column of the schema.
+type column struct {
+	name     string
+	typ      int
+	typeLen  int
+	optional bool
+	conv     int // converted type, or -1
+	scale    int
+	unit     time.Duration // unit of timestamps, or 0
+}
+
+type rowGroup struct {
+	numRows int64
+	chunks  map[string]*chunk // keyed by the column names
+}
+
+// chunk is a column chunk of a row group.
+type chunk struct {
+	codec     int
+	numValues int64
+	offset    int64
+	size      int64
+	min, max  any // statistics, nil if unknown
+}
+
+// readMeta reads the file metadata from the footer.
+func (f *File) readMeta(size int64) error {
+	var tail [8]byte
+	if size < 12 {
+		return ErrNotParquet
+	}
+	if _, err := f.r.ReadAt(tail[:], size-8); err != nil {
+		return err
+	}
+	if string(tail[4:]) != "PAR1" {
+		return ErrNotParquet
+	}
+	n := int64(binary.LittleEndian.Uint32(tail[:]))
+	if n > size-12 {
+		return errCorrupt
+	}
+	b := make([]byte, n)
+	if _, err := f.r.ReadAt(b, size-8-n); err != nil {
+		return err
+	}
+	meta, _, err := readThrift(b)
+	if err != nil {
+		return err
+	}
+	f.numRows = meta.int(3)
+	if err = f.loadSchema(meta.list(2)); err != nil {
+		return err
+	}
+	for _, v := range meta.list(4) {
+		rg, _ := v.(tstruct)
+		g := rowGroup{numRows: rg.int(3), chunks: make(map[string]*chunk)}
+		for _, v := range rg.list(1) {
+			cc, _ := v.(tstruct)
+			md := cc.sub(3)
+			path := md.list(3)
+			if len(path) != 1 {
+				continue // nested columns
+			}
+			name, _ := path[0].([]byte)
+			col := f.column(string(name))
+			if col == nil {
+				continue
+			}
+			c := &chunk{
+				codec:     int(md.int(4)),
+				numValues: md.int(5),
+				offset:    md.int(9),
+				size:      md.int(7),
+			}
+			if md.has(11) && md.int(11) > 0 && md.int(11) < c.offset {
+				c.offset = md.int(11)
+			}
+			if stats := md.sub(12); stats != nil {
+				c.min, c.max = col.stat(stats, 6, 2), col.stat(stats, 5, 1)
+			}
+			g.chunks[col.name] = c
+		}
+		f.groups = append(f.groups, g)
+	}
+	return nil
+}
+
+// loadSchema loads the flat columns of the schema. Nested columns (groups and
+// repeated fields) are skipped.
+func (f *File) loadSchema(elems []any) error {
+	if len(elems) == 0 {
+		return errCorrupt
+	}
+	root, _ := elems[0].(tstruct)
+	i := 1
+	for n := root.int(5); n > 0 && i < len(elems); n-- {
+		e, _ := elems[i].(tstruct)
+		i = skipElems(elems, i)
+		if e.int(5) > 0 || e.int(3) == 2 { // groups and REPEATED fields
+			continue
+		}
+		col := &column{
+			name:     e.str(4),
+			typ:      int(e.int(1)),
+			typeLen:  int(e.int(2)),
+			optional: e.int(3) == 1,
+			conv:     -1,
+			scale:    int(e.int(7)),
+		}
+		if e.has(6) {
+			col.conv = int(e.int(6))
+		}
+		col.logical(e.sub(10))
+		f.cols = append(f.cols, col)
+	}
+	return nil
+}
+
+// skipElems returns the index of the next sibling of the schema element i.
+func skipElems(elems []any, i int) int {
+	e, _ := elems[i].(tstruct)
+	i++
+	for n := e.int(5); n > 0 && i < len(elems); n-- {
+		i = skipElems(elems, i)
+	}
+	return i
+}
+
+// logical applies the logical type (which supersedes the converted type).
+func (col *column) logical(lt tstruct) {
+	switch {
+	case lt.has(1): // STRING
+		col.conv = 0
+	case lt.has(5): // DECIMAL
+		col.conv, col.scale = convDecimal, int(lt.sub(5).int(1))
+	case lt.has(6):
+		col.conv = convDate
+	case lt.has(8): // TIMESTAMP
+		switch unit := lt.sub(8).sub(2); {
+		case unit.has(1):
+			col.conv = convTimestampMillis
+		case unit.has(2):
+			col.conv = convTimestampMicros
+		case unit.has(3):
+			col.unit = time.Nanosecond
+		}
+	case lt.has(10): // INTEGER
+		if it := lt.sub(10); !it.bool(2, true) {
+			switch it.int(1) {
+			case 32:
+				col.conv = convUint32
+			case 64:
+				col.conv = convUint64
+			}
+		}
+	}
+	switch col.conv {
+	case convTimestampMillis:
+		col.unit = time.Millisecond
+	case convTimestampMicros:
+		col.unit = time.Microsecond
+	}
+}
+
+// stat decodes a min or max statistic. The deprecated ones are only used for
+// numbers, as their sort order of byte arrays is signed.
+func (col *column) stat(stats tstruct, id, deprecated int16) any {
+	b := stats.bytes(id)
+	if b == nil {
+		if col.typ == typeByteArray || col.typ == typeFixedLen || col.conv == convUint32 || col.conv == convUint64 {
+			return nil
+		}
+		if b = stats.bytes(deprecated); b == nil {
+			return nil
+		}
+	}
+	if col.typ == typeByteArray || col.typ == typeFixedLen {
+		return col.convert(b)
+	}
+	vals, err := col.plain(b, 1)
+	if err != nil {
+		return nil
+	}
+	return vals[0]
+}
+
+// -----------------------------------------------------------------------------
+
+// readChunk reads the values of a column chunk, nil for nulls.
+func (f *File) readChunk(col *column, c *chunk) ([]any, error) {
+	if c.size < 0 || c.size > 1<<31 {
+		return nil, errCorrupt
+	}
+	data := make([]byte, c.size)
+	if _, err := f.r.ReadAt(data, c.offset); err != nil {
+		if err == io.EOF {
+			err = errCorrupt
+		}
+		return nil, err
+	}
+	var dict, vals []any
+	for len(data) > 0 && int64(len(vals)) < c.numValues {
+		hdr, n, err := readThrift(data)
+		if err != nil {
+			return nil, err
+		}
+		data = data[n:]
+		size := hdr.int(3)
+		if size < 0 || size > int64(len(data)) {
+			return nil, errCorrupt
+		}
+		page, usize := data[:size], int(hdr.int(2))
+		data = data[size:]
+		switch hdr.int(1) {
+		case pageDictionary:
+			if page, err = decompress(c.codec, page, usize); err == nil {
+				dict, err = col.plain(page, int(hdr.sub(7).int(1)))
+			}
+		case pageData:
+			h := hdr.sub(5)
+			if page, err = decompress(c.codec, page, usize); err == nil {
+				vals, err = col.page(vals, page, dict, int(h.int(1)), int(h.int(2)), nil)
+			}
+		case pageDataV2:
+			h := hdr.sub(8)
+			rlen, dlen := h.int(6), h.int(5)
+			if rlen < 0 || dlen < 0 || rlen+dlen > int64(len(page)) {
+				return nil, errCorrupt
+			}
+			levels, body := page[rlen:rlen+dlen], page[rlen+dlen:]
+			if h.bool(7, true) {
+				body, err = decompress(c.codec, body, usize-int(rlen+dlen))
+			}
+			if err == nil {
+				vals, err = col.page(vals, body, dict, int(h.int(1)), int(h.int(4)), levels)
+			}
+		}
+		if err != nil {
+			return nil, fmt.Errorf("parquet: column %s: %w", col.name, err)
+		}
+	}
+	return vals, nil
+}
+
+// page decodes the values of a data page, and appends them to vals. levels
+// are the definition levels of a v2 page, which aren't prefixed with their
+// length.
+func (col *column) page(vals []any, b []byte, dict []any, n, enc int, levels []byte) ([]any, error) {
+	var defs []uint64
+	nonNull := n
+	if col.optional {
+		if levels == nil {
+			if len(b) < 4 || int(binary.LittleEndian.Uint32(b)) > len(b)-4 {
+				return nil, errCorrupt
+			}
+			size := int(binary.LittleEndian.Uint32(b))
+			levels, b = b[4:4+size], b[4+size:]
+		}
+		var err error
+		if defs, err = hybrid(levels, 1, n); err != nil {
+			return nil, err
+		}
+		nonNull = 0
+		for _, d := range defs {
+			nonNull += int(d)
+		}
+	}
+	var values []any
+	var err error
+	switch enc {
+	case encPlain:
+		values, err = col.plain(b, nonNull)
+	case encPlainDict, encRLEDictionary:
+		if len(b) == 0 {
+			if nonNull > 0 {
+				return nil, errCorrupt
+			}
+			break
+		}
+		var idx []uint64
+		if idx, err = hybrid(b[1:], int(b[0]), nonNull); err == nil {
+			values = make([]any, nonNull)
+			for i, j := range idx {
+				if j >= uint64(len(dict)) {
+					return nil, errCorrupt
+				}
+				values[i] = dict[j]
+			}
+		}
+	case encRLE:
+		if col.typ != typeBoolean || len(b) < 4 {
+			return nil, errCorrupt
+		}
+		var bits []uint64
+		if bits, err = hybrid(b[4:], 1, nonNull); err == nil {
+			values = make([]any, nonNull)
+			for i, v := range bits {
+				values[i] = v != 0
+			}
+		}
+	default:
+		return nil, fmt.Errorf("unsupported encoding %d", enc)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if defs == nil {
+		return append(vals, values...), nil
+	}
+	j := 0
+	for _, d := range defs {
+		if d == 0 {
+			vals = append(vals, nil)
+		} else {
+			vals = append(vals, values[j])
+			j++
+		}
+	}
+	return vals, nil
+}
+
+// plain decodes n values in the PLAIN encoding.
+func (col *column) plain(b []byte, n int) ([]any, error) {
+	vals := make([]any, 0, min(n, len(b)+1))
+	width := map[int]int{typeInt32: 4, typeInt64: 8, typeInt96: 12, typeFloat: 4, typeDouble: 8, typeFixedLen: col.typeLen}[col.typ]
+	for i := 0; i < n; i++ {
+		var v any
+		switch col.typ {
+		case typeBoolean:
+			if i/8 >= len(b) {
+				return nil, errCorrupt
+			}
+			v = b[i/8]>>(i%8)&1 != 0
+			vals = append(vals, v)
+			continue
+		case typeByteArray:
+			if len(b) < 4 || int(binary.LittleEndian.Uint32(b)) > len(b)-4 {
+				return nil, errCorrupt
+			}
+			size := int(binary.LittleEndian.Uint32(b))
+			v, b = col.convert(b[4:4+size]), b[4+size:]
+			vals = append(vals, v)
+			continue
+		}
+		if len(b) < width || width == 0 {
+			return nil, errCorrupt
+		}
+		switch col.typ {
+		case typeInt32:
+			v = col.convert(int64(int32(binary.LittleEndian.Uint32(b))))
+		case typeInt64:
+			v = col.convert(int64(binary.LittleEndian.Uint64(b)))
+		case typeInt96:
+			nanos := int64(binary.LittleEndian.Uint64(b))
+			day := int64(binary.LittleEndian.Uint32(b[8:]))
+			t := time.Unix((day-2440588)*86400, nanos).UTC() // 2440588 is the Julian day of 1970-01-01
+			v = t.Format(time.RFC3339Nano)
+		case typeFloat:
+			v = float64(math.Float32frombits(binary.LittleEndian.Uint32(b)))
+		case typeDouble:
+			v = math.Float64frombits(binary.LittleEndian.Uint64(b))
+		case typeFixedLen:
+			v = col.convert(b[:width])
+		}
+		vals = append(vals, v)
+		b = b[width:]
+	}
+	return vals, nil
+}
+
+// convert converts a physical value by the logical type of the column:
+// strings for byte arrays, dates and timestamps, float64 for decimals.
+func (col *column) convert(v any) any {
+	switch v := v.(type) {
+	case int64:
+		switch {
+		case col.conv == convDate:
+			return time.Unix(v*86400, 0).UTC().Format("2006-01-02")
+		case col.unit != 0:
+			return timestamp(v, col.unit).UTC().Format(time.RFC3339Nano)
+		case col.conv == convDecimal:
+			return float64(v) / math.Pow10(col.scale)
+		case col.conv == convUint32:
+			return int64(uint32(v))
+		case col.conv == convUint64:
+			return uint64(v)
+		}
+		return v
+	case []byte:
+		if col.conv == convDecimal {
+			f, _ := new(big.Float).SetInt(signedInt(v)).Float64()
+			return f / math.Pow10(col.scale)
+		}
+		return string(v)
+	}
+	return v
+}
+
+func timestamp(v int64, unit time.Duration) time.Time {
+	switch unit {
+	case time.Millisecond:
+		return time.UnixMilli(v)
+	case time.Microsecond:
+		return time.UnixMicro(v)
+	}
+	return time.Unix(0, v)
+}
+
+// signedInt decodes a big-endian two's complement integer.
+func signedInt(b []byte) *big.Int {
+	v := new(big.Int).SetBytes(b)
+	if len(b) > 0 && b[0]&0x80 != 0 {
+		v.Sub(v, new(big.Int).Lsh(big.NewInt(1), uint(len(b)*8)))
+	}
+	return v
+}
+
+// hybrid decodes n values in the RLE/bit-packing hybrid encoding.
+func hybrid(b []byte, width, n int) ([]uint64, error) {
+	if width < 0 || width > 64 {
+		return nil, errCorrupt
+	}
+	vals := make([]uint64, 0, n)
+	for len(vals) < n {
+		hdr, m := binary.Uvarint(b)
+		if m <= 0 {
+			return nil, errCorrupt
+		}
+		b = b[m:]
+		if hdr&1 == 0 { // RLE run
+			size := (width + 7) / 8
+			if len(b) < size {
+				return nil, errCorrupt
+			}
+			var v uint64
+			for i := size - 1; i >= 0; i-- {
+				v = v<<8 | uint64(b[i])
+			}
+			b = b[size:]
+			for count := hdr >> 1; count > 0 && len(vals) < n; count-- {
+				vals = append(vals, v)
+			}
+			continue
+		}
+		count := int(hdr>>1) * 8 // bit-packed groups of 8 values
+		if count*width > len(b)*8 {
+			return nil, errCorrupt
+		}
+		for i := 0; i < count; i++ {
+			var v uint64
+			for j := 0; j < width; j++ {
+				bit := i*width + j
+				v |= uint64(b[bit/8]>>(bit%8)&1) << j
+			}
+			if len(vals) < n {
+				vals = append(vals, v)
+			}
+		}
+		b = b[(count*width+7)/8:]
+	}
+	return vals, nil
+}
+
+// -----------------------------------------------------------------------------
+
+func decompress(codec int, b []byte, size int) ([]byte, error) {
+	switch codec {
+	case codecUncompressed:
+		return b, nil
+	case codecSnappy:
+		return unsnappy(b)
+	case codecGzip:
+		r, err := gzip.NewReader(bytes.NewReader(b))
+		if err != nil {
+			return nil, err
+		}
+		buf := bytes.NewBuffer(make([]byte, 0, max(size, 0)))
+		_, err = io.Copy(buf, r)
+		return buf.Bytes(), err
+	}
+	return nil, fmt.Errorf("unsupported compression codec %d", codec)
+}
+
+// unsnappy decodes a block in the Snappy format, see
+// https://github.com/google/snappy/blob/main/format_description.txt.
+func unsnappy(src []byte) ([]byte, error) {
+	size, n := binary.Uvarint(src)
+	if n <= 0 || size > 1<<31 {
+		return nil, errCorrupt
+	}
+	src = src[n:]
+	dst := make([]byte, 0, size)
+	for len(src) > 0 {
+		tag := src[0]
+		var length, offset int
+		switch tag & 3 {
+		case 0: // literal
+			length = int(tag >> 2)
+			src = src[1:]
+			if length >= 60 {
+				nb := length - 59
+				if len(src) < nb {
+					return nil, errCorrupt
+				}
+				length = 0
+				for i := nb - 1; i >= 0; i-- {
+					length = length<<8 | int(src[i])
+				}
+				src = src[nb:]
+			}
+			length++
+			if length > len(src) {
+				return nil, errCorrupt
+			}
+			dst = append(dst, src[:length]...)
+			src = src[length:]
+			continue
+		case 1:
+			if len(src) < 2 {
+				return nil, errCorrupt
+			}
+			length = 4 + int(tag>>2)&7
+			offset = int(tag&0xe0)<<3 | int(src[1])
+			src = src[2:]
+		case 2:
+			if len(src) < 3 {
+				return nil, errCorrupt
+			}
+			length = 1 + int(tag>>2)
+			offset = int(binary.LittleEndian.Uint16(src[1:]))
+			src = src[3:]
+		case 3:
+			if len(src) < 5 {
+				return nil, errCorrupt
+			}
+			length = 1 + int(tag>>2)
+			offset = int(binary.LittleEndian.Uint32(src[1:]))
+			src = src[5:]
+		}
+		if offset <= 0 || offset > len(dst) {
+			return nil, errCorrupt
+		}
+		for i := 0; i < length; i++ { // copies may overlap
+			dst = append(dst, dst[len(dst)-offset])
+		}
+	}
+	if uint64(len(dst)) != size {
+		return nil, errCorrupt
+	}
+	return dst, nil
+}
+
+// -----------------------------------------------------------------------------
@@ -0,0 +1,506 @@
+/*
+ * Copyright (c) 2026 The XGo Authors (xgo.dev). All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package parquet
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"io/fs"
+	"iter"
+	"math"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/goplus/xgo/dql/maps"
+	"github.com/goplus/xgo/dql/stream"
+)
+
+const (
+	XGoPackage = "github.com/goplus/xgo/dql/maps"
+)
+
+// -----------------------------------------------------------------------------
+
+// Node represents a row of a Parquet file, whose value is a map[string]any
+// from the column names to the values.
+type Node = maps.Node
+
+// NodeSet represents a set of Parquet rows.
+type NodeSet = maps.NodeSet
+
+// Config specifies how the rows of a Parquet file are read.
+type Config struct {
+	// Columns specifies the columns to read. If it's nil, all the columns are
+	// read. As Parquet is a columnar format, the other columns aren't read
+	// from the file at all.
+	Columns []string
+
+	// Where specifies the conditions the rows must meet. Row groups whose
+	// statistics (the min and max values of the columns) show that none of
+	// their rows meets the conditions are skipped without being read.
+	Where []Cond
+
+	// OnError is called if an error occurs while reading rows.
+	OnError func(error)
+}
+
+// Cond is a condition on the value of a column, like Cond{"age", ">=", 18}.
+// Op is one of "==", "!=", "<", "<=", ">" and ">=". Null values don't meet
+// any condition.
+type Cond struct {
+	Column string
+	Op     string
+	Value  any
+}
+
+// File is a Parquet file opened for reading. Columns of primitive types are
+// supported, nested columns (groups and repeated fields) are skipped. Pages
+// can be PLAIN or dictionary encoded, and uncompressed or compressed by Snappy
+// or gzip.
+type File struct {
+	r       io.ReaderAt
+	f       io.Closer
+	cols    []*column
+	groups  []rowGroup
+	numRows int64
+}
+
+// Open opens a Parquet file for reading.
+func Open(path string) (*File, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	fi, err := f.Stat()
+	if err == nil {
+		var pf *File
+		if pf, err = NewFile(f, fi.Size()); err == nil {
+			pf.f = f
+			return pf, nil
+		}
+	}
+	f.Close()
+	return nil, fmt.Errorf("%s: %w", path, err)
+}
+
+// NewFile reads a Parquet file from r, which has the given size in bytes.
+func NewFile(r io.ReaderAt, size int64) (*File, error) {
+	f := &File{r: r}
+	if err := f.readMeta(size); err != nil {
+		return nil, err
+	}
+	return f, nil
+}
+
+// Close closes the file.
+func (f *File) Close() error {
+	if f.f == nil {
+		return nil
+	}
+	return f.f.Close()
+}
+
+// NumRows returns the number of rows.
+func (f *File) NumRows() int64 {
+	return f.numRows
+}
+
+// Columns returns the names of the columns, in the order of the schema.
+func (f *File) Columns() []string {
+	names := make([]string, len(f.cols))
+	for i, col := range f.cols {
+		names[i] = col.name
+	}
+	return names
+}
+
+func (f *File) column(name string) *column {
+	for _, col := range f.cols {
+		if col.name == name {
+			return col
+		}
+	}
+	return nil
+}
+
+// Rows returns a NodeSet of the rows. Each row is a node with the columns as
+// attributes, like row.$name. Values are nil (null), bool, int64, float64 or
+// string, and uint64 for unsigned 64-bit integers. Byte arrays are strings,
+// dates are strings in the form of "2006-01-02", timestamps are strings in
+// the RFC 3339 form (in UTC), and decimals are float64.
+//
+// Row groups are read lazily one by one while iterating, and conf.Where is
+// pushed down to skip row groups by their statistics:
+//
+//	f := parquet.open("events.parquet")!
+//	for e in f.rows(parquet.Config{Where: []parquet.Cond{{"status", "==", 500}}}) {
+//		echo e.$time, e.$path
+//	}
+func (f *File) Rows(conf ...Config) NodeSet {
+	var c Config
+	if len(conf) > 0 {
+		c = conf[0]
+	}
+	cols := f.cols
+	if c.Columns != nil {
+		cols = nil
+		for _, name := range c.Columns {
+			col := f.column(name)
+			if col == nil {
+				return NodeSet{Err: fmt.Errorf("parquet: no such column: %s", name)}
+			}
+			cols = append(cols, col)
+		}
+	}
+	conds := make([]cond, len(c.Where))
+	for i, w := range c.Where {
+		col := f.column(w.Column)
+		if col == nil {
+			return NodeSet{Err: fmt.Errorf("parquet: no such column: %s", w.Column)}
+		}
+		switch w.Op {
+		case "==", "!=", "<", "<=", ">", ">=":
+		default:
+			return NodeSet{Err: fmt.Errorf("parquet: invalid operator %q", w.Op)}
+		}
+		conds[i] = cond{col, w.Op, normalize(w.Value)}
+	}
+	return NodeSet{
+		Data: func(yield func(Node) bool) {
+			for i := range f.groups {
+				ok, err := f.rowGroup(&f.groups[i], cols, conds, yield)
+				if err != nil && c.OnError != nil {
+					c.OnError(err)
+				}
+				if !ok || err != nil {
+					return
+				}
+			}
+		},
+	}
+}
+
+type cond struct {
+	col *column
+	op  string
+	val any
+}
+
+// rowGroup yields the rows of a row group which meet the conditions.
+func (f *File) rowGroup(g *rowGroup, cols []*column, conds []cond, yield func(Node) bool) (bool, error) {
+	for _, c := range conds {
+		if ch := g.chunks[c.col.name]; ch != nil && !c.mayMatch(ch.min, ch.max) {
+			return true, nil // skipped by the statistics
+		}
+	}
+	values := make(map[*column][]any)
+	read := func(col *column) error {
+		if _, ok := values[col]; ok {
+			return nil
+		}
+		ch := g.chunks[col.name]
+		if ch == nil {
+			values[col] = nil // missing columns are null
+			return nil
+		}
+		vals, err := f.readChunk(col, ch)
+		values[col] = vals
+		return err
+	}
+	for _, c := range conds {
+		if err := read(c.col); err != nil {
+			return false, err
+		}
+	}
+	for _, col := range cols {
+		if err := read(col); err != nil {
+			return false, err
+		}
+	}
+	value := func(col *column, i int) any {
+		if vals := values[col]; i < len(vals) {
+			return vals[i]
+		}
+		return nil
+	}
+next:
+	for i := 0; i < int(g.numRows); i++ {
+		for _, c := range conds {
+			if !c.match(value(c.col, i)) {
+				continue next
+			}
+		}
+		row := make(map[string]any, len(cols))
+		for _, col := range cols {
+			row[col.name] = value(col, i)
+		}
+		if !yield(Node{Value: row}) {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+// match reports whether a value meets the condition.
+func (c *cond) match(v any) bool {
+	if v == nil {
+		return false
+	}
+	r, ok := compare(v, c.val)
+	if !ok {
+		return c.op == "!="
+	}
+	switch c.op {
+	case "==":
+		return r == 0
+	case "!=":
+		return r != 0
+	case "<":
+		return r < 0
+	case "<=":
+		return r <= 0
+	case ">":
+		return r > 0
+	}
+	return r >= 0
+}
+
+// mayMatch reports whether a value in [min, max] may meet the condition.
+func (c *cond) mayMatch(min, max any) bool {
+	if min == nil || max == nil {
+		return true
+	}
+	lo, ok1 := compare(min, c.val)
+	hi, ok2 := compare(max, c.val)
+	if !ok1 || !ok2 {
+		return true
+	}
+	switch c.op {
+	case "==":
+		return lo <= 0 && hi >= 0
+	case "!=":
+		return lo != 0 || hi != 0
+	case "<":
+		return lo < 0
+	case "<=":
+		return lo <= 0
+	case ">":
+		return hi > 0
+	}
+	return hi >= 0
+}
+
+// normalize converts the numbers of Go types to int64, uint64 or float64.
+func normalize(v any) any {
+	switch v := v.(type) {
+	case int:
+		return int64(v)
+	case int8:
+		return int64(v)
+	case int16:
+		return int64(v)
+	case int32:
+		return int64(v)
+	case uint:
+		return uint64(v)
+	case uint8:
+		return int64(v)
+	case uint16:
+		return int64(v)
+	case uint32:
+		return int64(v)
+	case float32:
+		return float64(v)
+	case time.Time:
+		return v.UTC().Format(time.RFC3339Nano)
+	}
+	return v
+}
+
+// compare compares two values of the same kind: numbers, strings or bools.
+// Strings of RFC 3339 times are compared as times.
+func compare(a, b any) (int, bool) {
+	switch a := a.(type) {
+	case int64:
+		switch b := b.(type) {
+		case int64:
+			return cmp(a, b), true
+		case uint64:
+			if a < 0 || b > math.MaxInt64 {
+				return -1, true
+			}
+			return cmp(a, int64(b)), true
+		case float64:
+			return cmp(float64(a), b), true
+		}
+	case uint64:
+		switch b := b.(type) {
+		case uint64:
+			return cmp(a, b), true
+		case int64:
+			if b < 0 || a > math.MaxInt64 {
+				return 1, true
+			}
+			return cmp(int64(a), b), true
+		case float64:
+			return cmp(float64(a), b), true
+		}
+	case float64:
+		switch b := b.(type) {
+		case float64:
+			return cmp(a, b), true
+		case int64:
+			return cmp(a, float64(b)), true
+		case uint64:
+			return cmp(a, float64(b)), true
+		}
+	case string:
+		if b, ok := b.(string); ok {
+			if ta, tb, ok := times(a, b); ok {
+				return ta.Compare(tb), true
+			}
+			return strings.Compare(a, b), true
+		}
+	case bool:
+		if b, ok := b.(bool); ok {
+			if a == b {
+				return 0, true
+			}
+			if b {
+				return -1, true
+			}
+			return 1, true
+		}
+	}
+	return 0, false
+}
+
+func cmp[T int64 | uint64 | float64](a, b T) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	}
+	return 0
+}
+
+func times(a, b string) (ta, tb time.Time, ok bool) {
+	if len(a) < 20 || len(b) < 20 || a[10] != 'T' || b[10] != 'T' {
+		return
+	}
+	ta, err1 := time.Parse(time.RFC3339Nano, a)
+	tb, err2 := time.Parse(time.RFC3339Nano, b)
+	return ta, tb, err1 == nil && err2 == nil
+}
+
+// -----------------------------------------------------------------------------
+
+// New creates a Parquet NodeSet of the rows of a file read from r. See
+// File.Rows.
+func New(r io.Reader, conf ...Config) NodeSet {
+	b, err := io.ReadAll(r)
+	if err != nil {
+		return NodeSet{Err: err}
+	}
+	return newRows(bytes.NewReader(b), int64(len(b)), conf)
+}
+
+func newRows(r io.ReaderAt, size int64, conf []Config) NodeSet {
+	f, err := NewFile(r, size)
+	if err != nil {
+		return NodeSet{Err: err}
+	}
+	return f.Rows(conf...)
+}
+
+// Source creates a Parquet NodeSet from various source types:
+// - string: treats the string as a file path (or URL), opens it, and reads the rows from it.
+// - *stream.Request: opens the resource with the request options (like HTTP headers).
+// - []byte: reads the rows from the byte slice.
+// - io.Reader: reads the rows from the provided reader.
+// - *File: reads the rows of the opened file.
+// - Node: creates a NodeSet containing the single provided node.
+// - iter.Seq[Node]: directly uses the provided sequence of nodes.
+// - NodeSet: returns the provided NodeSet as is.
+// If the source type is unsupported, it panics.
+//
+// A local file is read lazily, and closed after the rows are iterated. Other
+// resources (like URLs) are read into memory first.
+func Source(r any, conf ...Config) (ret NodeSet) {
+	switch v := r.(type) {
+	case string:
+		f, err := stream.Open(v)
+		if err != nil {
+			return NodeSet{Err: err}
+		}
+		return readAndClose(f, conf)
+	case *stream.Request:
+		f, err := v.Open()
+		if err != nil {
+			return NodeSet{Err: err}
+		}
+		return readAndClose(f, conf)
+	case []byte:
+		return newRows(bytes.NewReader(v), int64(len(v)), conf)
+	case io.Reader:
+		return New(v, conf...)
+	case *File:
+		return v.Rows(conf...)
+	case Node:
+		return maps.Root(v)
+	case iter.Seq[Node]:
+		return NodeSet{Data: v}
+	case NodeSet:
+		return v
+	default:
+		panic("dql/parquet.Source: unsupported source type")
+	}
+}
+
+type statReaderAt interface {
+	io.ReaderAt
+	Stat() (fs.FileInfo, error)
+}
+
+func readAndClose(f io.ReadCloser, conf []Config) NodeSet {
+	ra, ok := f.(statReaderAt)
+	if !ok {
+		defer f.Close()
+		return New(f, conf...)
+	}
+	fi, err := ra.Stat()
+	if err != nil {
+		f.Close()
+		return NodeSet{Err: err}
+	}
+	ns := newRows(ra, fi.Size(), conf)
+	if ns.Err != nil {
+		f.Close()
+		return ns
+	}
+	data := ns.Data
+	ns.Data = func(yield func(Node) bool) {
+		defer f.Close()
+		data(yield)
+	}
+	return ns
+}
+
+// -----------------------------------------------------------------------------
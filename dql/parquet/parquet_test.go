@@ -0,0 +1,309 @@
+/*
+ * Copyright (c) 2026 The XGo Authors (xgo.dev). All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package parquet
+
+import (
+	"encoding/binary"
+	"math"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+// tf is a field of a Thrift struct to encode. Values are int32, int64,
+// string, []byte, bool, tlist and []tf (structs).
+type tf struct {
+	id int16
+	v  any
+}
+
+type tlist struct {
+	typ   byte
+	elems []any
+}
+
+func thrift(b []byte, fields []tf) []byte {
+	var last int16
+	for _, f := range fields {
+		typ := thriftType(f.v)
+		if delta := f.id - last; delta > 0 && delta <= 15 {
+			b = append(b, byte(delta)<<4|typ)
+		} else {
+			b = append(b, typ)
+			b = binary.AppendVarint(b, int64(f.id))
+		}
+		last = f.id
+		b = thriftValue(b, f.v)
+	}
+	return append(b, ctStop)
+}
+
+func thriftType(v any) byte {
+	switch v := v.(type) {
+	case int32:
+		return ctI32
+	case int64:
+		return ctI64
+	case string, []byte:
+		return ctBinary
+	case bool:
+		if v {
+			return ctTrue
+		}
+		return ctFalse
+	case tlist:
+		return ctList
+	}
+	return ctStruct
+}
+
+func thriftValue(b []byte, v any) []byte {
+	switch v := v.(type) {
+	case int32:
+		return binary.AppendVarint(b, int64(v))
+	case int64:
+		return binary.AppendVarint(b, v)
+	case string:
+		return append(binary.AppendUvarint(b, uint64(len(v))), v...)
+	case []byte:
+		return append(binary.AppendUvarint(b, uint64(len(v))), v...)
+	case tlist:
+		b = append(b, byte(len(v.elems))<<4|v.typ)
+		for _, e := range v.elems {
+			b = thriftValue(b, e)
+		}
+		return b
+	case []tf:
+		return thrift(b, v)
+	}
+	return b
+}
+
+func plainInt64(vals ...int64) []byte {
+	var b []byte
+	for _, v := range vals {
+		b = binary.LittleEndian.AppendUint64(b, uint64(v))
+	}
+	return b
+}
+
+func plainStrings(vals ...string) []byte {
+	var b []byte
+	for _, v := range vals {
+		b = binary.LittleEndian.AppendUint32(b, uint32(len(v)))
+		b = append(b, v...)
+	}
+	return b
+}
+
+// snappy encodes b as a single literal.
+func snappy(b []byte) []byte {
+	ret := binary.AppendUvarint(nil, uint64(len(b)))
+	return append(append(ret, byte(len(b)-1)<<2), b...)
+}
+
+// dataPage returns a v1 data page, values are prefixed with the definition
+// levels if defs isn't nil.
+func dataPage(n int32, enc int32, defs, values []byte) []byte {
+	body := values
+	if defs != nil {
+		body = binary.LittleEndian.AppendUint32(nil, uint32(len(defs)))
+		body = append(append(body, defs...), values...)
+	}
+	hdr := thrift(nil, []tf{
+		{1, int32(pageData)}, {2, int32(len(body))}, {3, int32(len(body))},
+		{5, []tf{{1, n}, {2, enc}, {3, int32(encRLE)}, {4, int32(encRLE)}}},
+	})
+	return append(hdr, body...)
+}
+
+func dictPage(n int32, values []byte) []byte {
+	hdr := thrift(nil, []tf{
+		{1, int32(pageDictionary)}, {2, int32(len(values))}, {3, int32(len(values))},
+		{7, []tf{{1, n}, {2, int32(encPlain)}}},
+	})
+	return append(hdr, values...)
+}
+
+// dataPageV2 returns a v2 data page whose values are compressed by snappy.
+func dataPageV2(n, nulls int32, defs, values []byte) []byte {
+	compressed := snappy(values)
+	hdr := thrift(nil, []tf{
+		{1, int32(pageDataV2)}, {2, int32(len(defs) + len(values))}, {3, int32(len(defs) + len(compressed))},
+		{8, []tf{{1, n}, {2, nulls}, {3, n}, {4, int32(encPlain)}, {5, int32(len(defs))}, {6, int32(0)}}},
+	})
+	return append(append(hdr, defs...), compressed...)
+}
+
+type testChunk struct {
+	col      string
+	typ      int32
+	codec    int32
+	n        int64
+	pages    []byte
+	min, max []byte
+}
+
+// testFile returns a Parquet file of:
+//
+//	message schema {
+//		required int64 id;
+//		optional binary name (STRING);
+//		required double score;
+//		optional int32 day (DATE);
+//		optional group tags (LIST) { repeated binary element; }
+//	}
+func testFile() []byte {
+	groups := [][]testChunk{{
+		{"id", typeInt64, codecUncompressed, 3, dataPage(3, encPlain, nil, plainInt64(1, 2, 3)), plainInt64(1), plainInt64(3)},
+		{"name", typeByteArray, codecUncompressed, 3, append(
+			dictPage(2, plainStrings("ann", "bob")),
+			dataPage(3, encRLEDictionary, []byte{3, 5}, []byte{1, 3, 2})...), nil, nil},
+		{"score", typeDouble, codecSnappy, 3, dataPageV2(3, 0, nil, plainInt64(
+			int64(math.Float64bits(1.5)), int64(math.Float64bits(2.5)), int64(math.Float64bits(3.5)))), nil, nil},
+		{"day", typeInt32, codecSnappy, 3, dataPageV2(3, 2, []byte{3, 1}, []byte{0x4c, 0x4d, 0, 0}), nil, nil},
+	}, {
+		{"id", typeInt64, codecUncompressed, 2, dataPage(2, encPlain, nil, plainInt64(4, 5)), plainInt64(4), plainInt64(5)},
+		{"name", typeByteArray, codecUncompressed, 2, append(
+			dictPage(1, plainStrings("ann")),
+			dataPage(2, encPlainDict, []byte{4, 1}, []byte{0, 4})...), []byte("ann"), []byte("ann")},
+		{"score", typeDouble, codecSnappy, 2, dataPageV2(2, 0, nil, plainInt64(
+			int64(math.Float64bits(4.5)), int64(math.Float64bits(5.5)))), nil, nil},
+	}}
+	b := []byte("PAR1")
+	var rowGroups []any
+	for _, g := range groups {
+		var chunks []any
+		for _, c := range g {
+			md := []tf{
+				{1, c.typ}, {2, tlist{ctI32, []any{int32(encPlain)}}}, {3, tlist{ctBinary, []any{c.col}}},
+				{4, c.codec}, {5, c.n}, {6, int64(len(c.pages))}, {7, int64(len(c.pages))}, {9, int64(len(b))},
+			}
+			if c.min != nil {
+				md = append(md, tf{12, []tf{{5, c.max}, {6, c.min}}})
+			}
+			chunks = append(chunks, []tf{{2, int64(len(b))}, {3, md}})
+			b = append(b, c.pages...)
+		}
+		rowGroups = append(rowGroups, []tf{{1, tlist{ctStruct, chunks}}, {2, int64(0)}, {3, g[0].n}})
+	}
+	meta := thrift(nil, []tf{
+		{1, int32(1)},
+		{2, tlist{ctStruct, []any{
+			[]tf{{4, "schema"}, {5, int32(5)}},
+			[]tf{{1, int32(typeInt64)}, {3, int32(0)}, {4, "id"}},
+			[]tf{{1, int32(typeByteArray)}, {3, int32(1)}, {4, "name"}, {6, int32(0)}},
+			[]tf{{1, int32(typeDouble)}, {3, int32(0)}, {4, "score"}},
+			[]tf{{1, int32(typeInt32)}, {3, int32(1)}, {4, "day"}, {10, []tf{{6, []tf{}}}}},
+			[]tf{{3, int32(1)}, {4, "tags"}, {5, int32(1)}, {6, int32(3)}},
+			[]tf{{1, int32(typeByteArray)}, {3, int32(2)}, {4, "element"}},
+		}}},
+		{3, int64(5)},
+		{4, tlist{ctStruct, rowGroups}},
+	})
+	b = append(b, meta...)
+	b = binary.LittleEndian.AppendUint32(b, uint32(len(meta)))
+	return append(b, "PAR1"...)
+}
+
+func rows(t *testing.T, ns NodeSet) (ret []map[string]any) {
+	t.Helper()
+	if ns.Err != nil {
+		t.Fatal(ns.Err)
+	}
+	for row := range ns.XGo_Enum() {
+		first, _ := row.XGo_first()
+		ret = append(ret, first.Value.(map[string]any))
+	}
+	return
+}
+
+func TestRows(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "users.parquet")
+	if err := os.WriteFile(file, testFile(), 0644); err != nil {
+		t.Fatal(err)
+	}
+	f, err := Open(file)
+	if err != nil {
+		t.Fatal("Open:", err)
+	}
+	defer f.Close()
+	if f.NumRows() != 5 || !reflect.DeepEqual(f.Columns(), []string{"id", "name", "score", "day"}) {
+		t.Fatal("metadata:", f.NumRows(), f.Columns())
+	}
+	want := []map[string]any{
+		{"id": int64(1), "name": "ann", "score": 1.5, "day": "2024-03-06"},
+		{"id": int64(2), "name": nil, "score": 2.5, "day": nil},
+		{"id": int64(3), "name": "bob", "score": 3.5, "day": nil},
+		{"id": int64(4), "name": "ann", "score": 4.5, "day": nil},
+		{"id": int64(5), "name": "ann", "score": 5.5, "day": nil},
+	}
+	if got := rows(t, f.Rows()); !reflect.DeepEqual(got, want) {
+		t.Fatal("rows:", got)
+	}
+
+	var errs []error
+	conf := Config{
+		Columns: []string{"id"},
+		Where:   []Cond{{"name", "==", "ann"}, {"id", ">", 1}},
+		OnError: func(err error) { errs = append(errs, err) },
+	}
+	if got := rows(t, f.Rows(conf)); !reflect.DeepEqual(got, []map[string]any{{"id": int64(4)}, {"id": int64(5)}}) {
+		t.Fatal("where:", got)
+	}
+
+	// the first row group is skipped by the statistics of id, so its broken
+	// pages aren't read
+	broken := testFile()
+	broken[4] = 0xff
+	conf = Config{Where: []Cond{{"id", ">=", 4}}, OnError: conf.OnError}
+	if got := rows(t, Source(broken, conf)); len(got) != 2 || len(errs) != 0 {
+		t.Fatal("pushdown:", got, errs)
+	}
+	if got := rows(t, Source(broken, Config{OnError: conf.OnError})); len(got) != 0 || len(errs) != 1 {
+		t.Fatal("broken:", got, errs)
+	}
+
+	if ns := f.Rows(Config{Columns: []string{"tags"}}); ns.Err == nil {
+		t.Fatal("nested column")
+	}
+	if ns := f.Rows(Config{Where: []Cond{{"id", "~", 1}}}); ns.Err == nil {
+		t.Fatal("invalid operator")
+	}
+	if ns := Source([]byte("PAR1")); ns.Err != ErrNotParquet {
+		t.Fatal("not parquet:", ns.Err)
+	}
+}
+
+func TestSnappy(t *testing.T) {
+	b, err := unsnappy([]byte{9, 8, 'a', 'b', 'c', 9, 3})
+	if err != nil || string(b) != "abcabcabc" {
+		t.Fatal("unsnappy:", string(b), err)
+	}
+	if _, err = unsnappy([]byte{9, 8, 'a', 'b', 'c', 9, 4}); err == nil {
+		t.Fatal("invalid offset")
+	}
+}
+
+func TestHybrid(t *testing.T) {
+	// a run of 3 sevens, then 8 bit-packed values 0..7 of width 3
+	vals, err := hybrid([]byte{6, 7, 3, 0x88, 0xc6, 0xfa}, 3, 11)
+	if err != nil || !reflect.DeepEqual(vals, []uint64{7, 7, 7, 0, 1, 2, 3, 4, 5, 6, 7}) {
+		t.Fatal("hybrid:", vals, err)
+	}
+}
@@ -0,0 +1,233 @@
+/*
+ * Copyright (c) 2026 The XGo Authors (xgo.dev). All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package parquet
+
+import (
+	"encoding/binary"
+	"math"
+)
+
+// This file decodes the Thrift compact protocol, which encodes the metadata
+// and the page headers of Parquet files, see
+// https://github.com/apache/thrift/blob/master/doc/specs/thrift-compact-protocol.md.
+
+// -----------------------------------------------------------------------------
+
+const (
+	ctStop      = 0
+	ctTrue      = 1
+	ctFalse     = 2
+	ctByte      = 3
+	ctI16       = 4
+	ctI32       = 5
+	ctI64       = 6
+	ctDouble    = 7
+	ctBinary    = 8
+	ctList      = 9
+	ctSet       = 10
+	ctMap       = 11
+	ctStruct    = 12
+	maxTDepth   = 64
+	maxTElemLen = 1 << 24
+)
+
+// tstruct is a decoded Thrift struct, keyed by the field ids. Values are
+// bool, int64, float64, []byte, []any (lists and sets) and tstruct. Maps are
+// skipped.
+type tstruct map[int16]any
+
+func (s tstruct) int(id int16) int64 {
+	v, _ := s[id].(int64)
+	return v
+}
+
+func (s tstruct) has(id int16) bool {
+	_, ok := s[id]
+	return ok
+}
+
+func (s tstruct) str(id int16) string {
+	v, _ := s[id].([]byte)
+	return string(v)
+}
+
+func (s tstruct) bytes(id int16) []byte {
+	v, _ := s[id].([]byte)
+	return v
+}
+
+func (s tstruct) bool(id int16, def bool) bool {
+	if v, ok := s[id].(bool); ok {
+		return v
+	}
+	return def
+}
+
+func (s tstruct) sub(id int16) tstruct {
+	v, _ := s[id].(tstruct)
+	return v
+}
+
+func (s tstruct) list(id int16) []any {
+	v, _ := s[id].([]any)
+	return v
+}
+
+// tdecoder decodes a buffer in the Thrift compact protocol.
+type tdecoder struct {
+	b   []byte
+	off int
+	err error
+}
+
+func (d *tdecoder) fail() {
+	if d.err == nil {
+		d.err = errCorrupt
+	}
+	d.off = len(d.b)
+}
+
+func (d *tdecoder) byte() byte {
+	if d.off >= len(d.b) {
+		d.fail()
+		return 0
+	}
+	c := d.b[d.off]
+	d.off++
+	return c
+}
+
+func (d *tdecoder) uvarint() uint64 {
+	v, n := binary.Uvarint(d.b[d.off:])
+	if n <= 0 {
+		d.fail()
+		return 0
+	}
+	d.off += n
+	return v
+}
+
+func (d *tdecoder) varint() int64 {
+	u := d.uvarint()
+	return int64(u>>1) ^ -int64(u&1)
+}
+
+// readStruct reads a struct, until its stop field.
+func (d *tdecoder) readStruct(depth int) tstruct {
+	if depth > maxTDepth {
+		d.fail()
+		return nil
+	}
+	s := make(tstruct)
+	var id int16
+	for d.err == nil {
+		hdr := d.byte()
+		typ := hdr & 0x0f
+		if typ == ctStop {
+			break
+		}
+		if delta := hdr >> 4; delta != 0 {
+			id += int16(delta)
+		} else {
+			id = int16(d.varint())
+		}
+		switch typ {
+		case ctTrue:
+			s[id] = true
+		case ctFalse:
+			s[id] = false
+		default:
+			if v := d.value(typ, depth); v != nil {
+				s[id] = v
+			}
+		}
+	}
+	return s
+}
+
+func (d *tdecoder) value(typ byte, depth int) any {
+	switch typ {
+	case ctTrue, ctFalse: // elements of lists
+		return d.byte() == ctTrue
+	case ctByte:
+		return int64(int8(d.byte()))
+	case ctI16, ctI32, ctI64:
+		return d.varint()
+	case ctDouble:
+		if len(d.b)-d.off < 8 {
+			d.fail()
+			return nil
+		}
+		v := math.Float64frombits(binary.LittleEndian.Uint64(d.b[d.off:]))
+		d.off += 8
+		return v
+	case ctBinary:
+		n := d.uvarint()
+		if n > uint64(len(d.b)-d.off) {
+			d.fail()
+			return nil
+		}
+		v := d.b[d.off : d.off+int(n)]
+		d.off += int(n)
+		return v
+	case ctList, ctSet:
+		hdr := d.byte()
+		n, elem := int(hdr>>4), hdr&0x0f
+		if n == 15 {
+			u := d.uvarint()
+			if u > maxTElemLen {
+				d.fail()
+				return nil
+			}
+			n = int(u)
+		}
+		list := make([]any, 0, min(n, 1024))
+		for i := 0; i < n && d.err == nil; i++ {
+			list = append(list, d.value(elem, depth+1))
+		}
+		return list
+	case ctMap:
+		n := d.uvarint()
+		if n == 0 {
+			return nil
+		}
+		if n > maxTElemLen {
+			d.fail()
+			return nil
+		}
+		kv := d.byte()
+		for i := uint64(0); i < n && d.err == nil; i++ {
+			d.value(kv>>4, depth+1)
+			d.value(kv&0x0f, depth+1)
+		}
+		return nil
+	case ctStruct:
+		return d.readStruct(depth + 1)
+	}
+	d.fail()
+	return nil
+}
+
+// readThrift reads a struct from the beginning of b, and returns the number of
+// bytes read.
+func readThrift(b []byte) (tstruct, int, error) {
+	d := &tdecoder{b: b}
+	s := d.readStruct(0)
+	return s, d.off, d.err
+}
+
+// -----------------------------------------------------------------------------
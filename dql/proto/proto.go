@@ -0,0 +1,221 @@
+/*
+ * Copyright (c) 2026 The XGo Authors (xgo.dev). All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package proto
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"iter"
+
+	"github.com/goplus/xgo/dql/maps"
+	"github.com/goplus/xgo/dql/stream"
+)
+
+const (
+	XGoPackage = "github.com/goplus/xgo/dql/maps"
+)
+
+// -----------------------------------------------------------------------------
+
+// Node represents a message (map[string]any) or a repeated field ([]any).
+type Node = maps.Node
+
+// NodeSet represents a set of protobuf nodes.
+type NodeSet = maps.NodeSet
+
+// Config specifies how protobuf data is decoded.
+type Config struct {
+	// Schema is used to decode messages by their types. Without a schema,
+	// fields are keyed by their numbers (like msg.$1), and values are decoded
+	// by guessing: varints are int64, length-delimited values are strings if
+	// they are printable, nested messages if they can be parsed, or []byte.
+	Schema *Schema
+
+	// Message is the full name of the message type, like "pkg.Msg". It's
+	// required if Schema is set.
+	Message string
+
+	// Delimited reads a stream of messages, each prefixed with its size as a
+	// varint (see writeDelimitedTo of the protobuf libraries), instead of a
+	// single message.
+	Delimited bool
+
+	// OnError is called if an error occurs while reading a stream of
+	// messages.
+	OnError func(error)
+}
+
+// New creates a protobuf NodeSet from a message in the wire format read from
+// r. With a schema, fields are attributes named by the field names, and
+// repeated fields are children whose values are []any:
+//
+//	schema := proto.loadSchemaFile("api.pb")!
+//	doc := proto.source("user.bin", proto.Config{Schema: schema, Message: "api.User"})
+//	echo doc.$name
+//	for addr in doc.addresses.* {
+//		echo addr.$city
+//	}
+//
+// Enums are decoded to their names, map fields to map[string]any, and 64-bit
+// unsigned integers (uint64 and fixed64) to uint64. Other integers are int64.
+//
+// If conf.Delimited is set, each message of the stream is a root node, and
+// messages are decoded one by one while iterating, so the NodeSet can only be
+// iterated once (use _all to traverse the nodes multiple times).
+func New(r io.Reader, conf ...Config) NodeSet {
+	var c Config
+	if len(conf) > 0 {
+		c = conf[0]
+	}
+	msg, err := c.message()
+	if err != nil {
+		return NodeSet{Err: err}
+	}
+	if c.Delimited {
+		return NodeSet{Data: delimited(r, c, msg)}
+	}
+	b, err := io.ReadAll(r)
+	if err != nil {
+		return NodeSet{Err: err}
+	}
+	doc, err := decode(b, c.Schema, msg)
+	if err != nil {
+		return NodeSet{Err: err}
+	}
+	return maps.New(doc)
+}
+
+// Source creates a protobuf NodeSet from various source types:
+// - string: treats the string as a file path (or URL), opens it, and reads protobuf data from it.
+// - *stream.Request: opens the resource with the request options (like HTTP headers).
+// - []byte: reads protobuf data from the byte slice.
+// - io.Reader: reads protobuf data from the provided reader.
+// - Node: creates a NodeSet containing the single provided node.
+// - iter.Seq[Node]: directly uses the provided sequence of nodes.
+// - NodeSet: returns the provided NodeSet as is.
+// If the source type is unsupported, it panics.
+//
+// A file (or a resource) is closed after it's read, or after the nodes are
+// iterated if conf.Delimited is set.
+func Source(r any, conf ...Config) (ret NodeSet) {
+	switch v := r.(type) {
+	case string:
+		f, err := stream.Open(v)
+		if err != nil {
+			return NodeSet{Err: err}
+		}
+		return readAndClose(f, conf)
+	case *stream.Request:
+		f, err := v.Open()
+		if err != nil {
+			return NodeSet{Err: err}
+		}
+		return readAndClose(f, conf)
+	case []byte:
+		return New(bytes.NewReader(v), conf...)
+	case io.Reader:
+		return New(v, conf...)
+	case Node:
+		return maps.Root(v)
+	case iter.Seq[Node]:
+		return NodeSet{Data: v}
+	case NodeSet:
+		return v
+	default:
+		panic("dql/proto.Source: unsupported source type")
+	}
+}
+
+func readAndClose(f io.ReadCloser, conf []Config) NodeSet {
+	if len(conf) == 0 || !conf[0].Delimited {
+		defer f.Close()
+		return New(f, conf...)
+	}
+	ns := New(f, conf...)
+	if ns.Err != nil {
+		f.Close()
+		return ns
+	}
+	data := ns.Data
+	ns.Data = func(yield func(Node) bool) {
+		defer f.Close()
+		data(yield)
+	}
+	return ns
+}
+
+// -----------------------------------------------------------------------------
+
+func (c *Config) message() (*message, error) {
+	if c.Schema == nil {
+		return nil, nil
+	}
+	msg := c.Schema.msgs[c.Message]
+	if msg == nil {
+		return nil, fmt.Errorf("proto: unknown message type %q", c.Message)
+	}
+	return msg, nil
+}
+
+// decode decodes a message, msg is nil if there is no schema.
+func decode(b []byte, s *Schema, msg *message) (map[string]any, error) {
+	fields, _, err := parseFields(b, 0)
+	if err != nil {
+		return nil, err
+	}
+	if msg == nil {
+		return rawMessage(fields), nil
+	}
+	return s.decode(msg, fields)
+}
+
+func delimited(r io.Reader, c Config, msg *message) iter.Seq[Node] {
+	return func(yield func(Node) bool) {
+		br := bufio.NewReader(r)
+		for {
+			size, err := binary.ReadUvarint(br)
+			if err == io.EOF {
+				return
+			}
+			var buf []byte // not reused: bytes values of the messages refer to it
+			if err == nil {
+				buf = make([]byte, size)
+				if _, err = io.ReadFull(br, buf); err == io.EOF {
+					err = io.ErrUnexpectedEOF
+				}
+			}
+			var doc map[string]any
+			if err == nil {
+				doc, err = decode(buf, c.Schema, msg)
+			}
+			if err != nil {
+				if c.OnError != nil {
+					c.OnError(err)
+				}
+				return
+			}
+			if !yield(Node{Value: doc}) {
+				return
+			}
+		}
+	}
+}
+
+// -----------------------------------------------------------------------------
@@ -0,0 +1,219 @@
+/*
+ * Copyright (c) 2026 The XGo Authors (xgo.dev). All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package proto
+
+import (
+	"encoding/binary"
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/goplus/xgo/dql"
+)
+
+func varintField(b []byte, num int, v uint64) []byte {
+	b = binary.AppendUvarint(b, uint64(num<<3|wireVarint))
+	return binary.AppendUvarint(b, v)
+}
+
+func bytesField(b []byte, num int, v []byte) []byte {
+	b = binary.AppendUvarint(b, uint64(num<<3|wireBytes))
+	b = binary.AppendUvarint(b, uint64(len(v)))
+	return append(b, v...)
+}
+
+func strField(b []byte, num int, v string) []byte {
+	return bytesField(b, num, []byte(v))
+}
+
+func fieldDescriptor(name string, num, label, typ int, typeName string) []byte {
+	b := strField(nil, 1, name)
+	b = varintField(b, 3, uint64(num))
+	b = varintField(b, 4, uint64(label))
+	b = varintField(b, 5, uint64(typ))
+	if typeName != "" {
+		b = strField(b, 6, typeName)
+	}
+	return b
+}
+
+// schema returns the FileDescriptorSet of:
+//
+//	package api;
+//	enum Role { USER = 0; ADMIN = 1; }
+//	message User {
+//		message Address { string city = 1; }
+//		string name = 1;
+//		int64 id = 2;
+//		repeated string tags = 3;
+//		Role role = 4;
+//		repeated Address addresses = 5;
+//		repeated sint32 scores = 6;
+//		map<string, string> attrs = 7;
+//	}
+func schema() []byte {
+	const optional, repeated = 1, 3
+	address := strField(nil, 1, "Address")
+	address = bytesField(address, 2, fieldDescriptor("city", 1, optional, typeString, ""))
+	entry := strField(nil, 1, "AttrsEntry")
+	entry = bytesField(entry, 2, fieldDescriptor("key", 1, optional, typeString, ""))
+	entry = bytesField(entry, 2, fieldDescriptor("value", 2, optional, typeString, ""))
+	entry = bytesField(entry, 7, varintField(nil, 7, 1))
+
+	user := strField(nil, 1, "User")
+	user = bytesField(user, 2, fieldDescriptor("name", 1, optional, typeString, ""))
+	user = bytesField(user, 2, fieldDescriptor("id", 2, optional, typeInt64, ""))
+	user = bytesField(user, 2, fieldDescriptor("tags", 3, repeated, typeString, ""))
+	user = bytesField(user, 2, fieldDescriptor("role", 4, optional, typeEnum, ".api.Role"))
+	user = bytesField(user, 2, fieldDescriptor("addresses", 5, repeated, typeMessage, ".api.User.Address"))
+	user = bytesField(user, 2, fieldDescriptor("scores", 6, repeated, typeSint32, ""))
+	user = bytesField(user, 2, fieldDescriptor("attrs", 7, repeated, typeMessage, ".api.User.AttrsEntry"))
+	user = bytesField(user, 3, address)
+	user = bytesField(user, 3, entry)
+
+	role := strField(nil, 1, "Role")
+	role = bytesField(role, 2, varintField(strField(nil, 1, "USER"), 2, 0))
+	role = bytesField(role, 2, varintField(strField(nil, 1, "ADMIN"), 2, 1))
+
+	file := strField(nil, 2, "api")
+	file = bytesField(file, 4, user)
+	file = bytesField(file, 5, role)
+	return bytesField(nil, 1, file)
+}
+
+func user(name string, id uint64) []byte {
+	b := strField(nil, 1, name)
+	b = varintField(b, 2, id)
+	b = strField(b, 3, "admin")
+	b = strField(b, 3, "ops")
+	b = varintField(b, 4, 1)
+	b = bytesField(b, 5, strField(nil, 1, "Paris"))
+	b = bytesField(b, 5, strField(nil, 1, "Tokyo"))
+	b = bytesField(b, 6, []byte{3, 4}) // packed: -2, 2
+	b = bytesField(b, 7, strField(strField(nil, 1, "team"), 2, "infra"))
+	b = bytesField(b, 7, strField(strField(nil, 1, "site"), 2, "eu"))
+	return varintField(b, 99, 7)
+}
+
+func TestSchema(t *testing.T) {
+	s, err := LoadSchema(schema())
+	if err != nil {
+		t.Fatal("LoadSchema:", err)
+	}
+	if msgs := s.Messages(); strings.Join(msgs, ",") != "api.User,api.User.Address,api.User.AttrsEntry" {
+		t.Fatal("Messages:", msgs)
+	}
+	doc := Source(user("Ann", 42), Config{Schema: s, Message: "api.User"})
+	if doc.Err != nil {
+		t.Fatal("Source:", doc.Err)
+	}
+	if v, _ := doc.XGo_Attr__1("name"); v != "Ann" {
+		t.Fatal("name:", v)
+	}
+	if v, _ := doc.XGo_Attr__1("id"); v != int64(42) {
+		t.Fatal("id:", v)
+	}
+	if v, _ := doc.XGo_Attr__1("role"); v != "ADMIN" {
+		t.Fatal("role:", v)
+	}
+	var cities []string
+	for addr := range doc.XGo_Elem("addresses").XGo_Child().XGo_Enum() {
+		cities = append(cities, addr.XGo_Attr__0("city").(string))
+	}
+	if strings.Join(cities, ",") != "Paris,Tokyo" {
+		t.Fatal("cities:", cities)
+	}
+	first, _ := doc.XGo_first()
+	v := first.Value.(map[string]any)
+	if !reflect.DeepEqual(v["tags"], []any{"admin", "ops"}) {
+		t.Fatal("tags:", v["tags"])
+	}
+	if !reflect.DeepEqual(v["scores"], []any{int64(-2), int64(2)}) {
+		t.Fatal("scores:", v["scores"])
+	}
+	if !reflect.DeepEqual(v["attrs"], map[string]any{"team": "infra", "site": "eu"}) {
+		t.Fatal("attrs:", v["attrs"])
+	}
+	if v["99"] != int64(7) {
+		t.Fatal("unknown field:", v["99"])
+	}
+
+	if ns := Source(user("Ann", 42), Config{Schema: s, Message: "api.Group"}); ns.Err == nil {
+		t.Fatal("unknown message type")
+	}
+	bad := varintField(nil, 1, 1) // name is a string
+	if ns := Source(bad, Config{Schema: s, Message: "api.User"}); ns.Err == nil {
+		t.Fatal("unexpected wire type")
+	}
+}
+
+func TestRaw(t *testing.T) {
+	first, err := Source(user("Bob", 7)).XGo_first()
+	if err != nil {
+		t.Fatal("Source:", err)
+	}
+	v := first.Value.(map[string]any)
+	if v["1"] != "Bob" || v["2"] != int64(7) {
+		t.Fatal("raw:", v)
+	}
+	if !reflect.DeepEqual(v["5"], []any{map[string]any{"1": "Paris"}, map[string]any{"1": "Tokyo"}}) {
+		t.Fatal("nested:", v["5"])
+	}
+	if !reflect.DeepEqual(v["6"], []byte{3, 4}) {
+		t.Fatal("bytes:", v["6"])
+	}
+	if ns := Source([]byte{0x08}); !errors.Is(ns.Err, ErrInvalidWire) {
+		t.Fatal("truncated:", ns.Err)
+	}
+}
+
+func TestDelimited(t *testing.T) {
+	s, err := LoadSchema(schema())
+	if err != nil {
+		t.Fatal("LoadSchema:", err)
+	}
+	var data []byte
+	for i, name := range []string{"Ann", "Bob", "Cid"} {
+		msg := user(name, uint64(i))
+		data = binary.AppendUvarint(data, uint64(len(msg)))
+		data = append(data, msg...)
+	}
+	file := filepath.Join(t.TempDir(), "users.bin")
+	if err := os.WriteFile(file, append(data, 0x10, 0x01), 0644); err != nil {
+		t.Fatal(err)
+	}
+	var errs []error
+	conf := Config{Schema: s, Message: "api.User", Delimited: true, OnError: func(err error) {
+		errs = append(errs, err)
+	}}
+	var names []string
+	for u := range Source(file, conf).XGo_Enum() {
+		names = append(names, u.XGo_Attr__0("name").(string))
+	}
+	if strings.Join(names, ",") != "Ann,Bob,Cid" {
+		t.Fatal("names:", names)
+	}
+	if len(errs) != 1 {
+		t.Fatal("errors:", errs)
+	}
+	if nodes := dql.Collect(Source(data, conf).Data); len(nodes) != 3 {
+		t.Fatal("nodes:", len(nodes))
+	}
+}
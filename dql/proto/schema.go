@@ -0,0 +1,386 @@
+/*
+ * Copyright (c) 2026 The XGo Authors (xgo.dev). All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package proto
+
+import (
+	"encoding/binary"
+	"fmt"
+	"math"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// -----------------------------------------------------------------------------
+
+// Schema is a set of message types loaded from a FileDescriptorSet, which is
+// generated by protoc:
+//
+//	protoc --descriptor_set_out=api.pb --include_imports api.proto
+//
+// It's decoded from the wire format directly, so no generated code is needed.
+type Schema struct {
+	msgs  map[string]*message // keyed by the full names, like "pkg.Msg"
+	enums map[string]map[int64]string
+}
+
+type message struct {
+	fields   map[int]*fieldDesc
+	mapEntry bool
+}
+
+type fieldDesc struct {
+	name     string
+	typ      int
+	repeated bool
+	typeName string // full name of a message or enum type, without the leading '.'
+}
+
+// types of fields, see FieldDescriptorProto.Type
+const (
+	typeDouble   = 1
+	typeFloat    = 2
+	typeInt64    = 3
+	typeUint64   = 4
+	typeInt32    = 5
+	typeFixed64  = 6
+	typeFixed32  = 7
+	typeBool     = 8
+	typeString   = 9
+	typeGroup    = 10
+	typeMessage  = 11
+	typeBytes    = 12
+	typeUint32   = 13
+	typeEnum     = 14
+	typeSfixed32 = 15
+	typeSfixed64 = 16
+	typeSint32   = 17
+	typeSint64   = 18
+)
+
+const labelRepeated = 3
+
+// LoadSchema loads a schema from a FileDescriptorSet.
+func LoadSchema(descriptorSet []byte) (*Schema, error) {
+	files, _, err := parseFields(descriptorSet, 0)
+	if err != nil {
+		return nil, err
+	}
+	s := &Schema{msgs: make(map[string]*message), enums: make(map[string]map[int64]string)}
+	for _, f := range files {
+		if f.num != 1 || f.wire != wireBytes { // FileDescriptorSet.file
+			continue
+		}
+		file, _, err := parseFields(f.b, 0)
+		if err != nil {
+			return nil, err
+		}
+		var pkg string
+		for _, f := range file {
+			if f.num == 2 { // FileDescriptorProto.package
+				pkg = string(f.b)
+			}
+		}
+		for _, f := range file {
+			switch f.num {
+			case 4: // FileDescriptorProto.message_type
+				err = s.loadMessage(pkg, f.b)
+			case 5: // FileDescriptorProto.enum_type
+				err = s.loadEnum(pkg, f.b)
+			}
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+	return s, nil
+}
+
+// LoadSchemaFile loads a schema from a FileDescriptorSet file.
+func LoadSchemaFile(name string) (*Schema, error) {
+	b, err := os.ReadFile(name)
+	if err != nil {
+		return nil, err
+	}
+	return LoadSchema(b)
+}
+
+// Messages returns the full names of the message types, sorted.
+func (s *Schema) Messages() []string {
+	names := make([]string, 0, len(s.msgs))
+	for name := range s.msgs {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	return names
+}
+
+func fullName(scope, name string) string {
+	if scope == "" {
+		return name
+	}
+	return scope + "." + name
+}
+
+func (s *Schema) loadMessage(scope string, b []byte) error {
+	fields, _, err := parseFields(b, 0)
+	if err != nil {
+		return err
+	}
+	var name string
+	for _, f := range fields {
+		if f.num == 1 { // DescriptorProto.name
+			name = fullName(scope, string(f.b))
+		}
+	}
+	msg := &message{fields: make(map[int]*fieldDesc)}
+	s.msgs[name] = msg
+	for _, f := range fields {
+		switch f.num {
+		case 2: // DescriptorProto.field
+			num, fd, err := loadField(f.b)
+			if err != nil {
+				return err
+			}
+			msg.fields[num] = fd
+		case 3: // DescriptorProto.nested_type
+			err = s.loadMessage(name, f.b)
+		case 4: // DescriptorProto.enum_type
+			err = s.loadEnum(name, f.b)
+		case 7: // DescriptorProto.options
+			var opts []field
+			if opts, _, err = parseFields(f.b, 0); err == nil {
+				for _, opt := range opts {
+					if opt.num == 7 { // MessageOptions.map_entry
+						msg.mapEntry = opt.u != 0
+					}
+				}
+			}
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func loadField(b []byte) (num int, fd *fieldDesc, err error) {
+	fields, _, err := parseFields(b, 0)
+	if err != nil {
+		return
+	}
+	fd = new(fieldDesc)
+	for _, f := range fields {
+		switch f.num {
+		case 1: // FieldDescriptorProto.name
+			fd.name = string(f.b)
+		case 3: // FieldDescriptorProto.number
+			num = int(f.u)
+		case 4: // FieldDescriptorProto.label
+			fd.repeated = f.u == labelRepeated
+		case 5: // FieldDescriptorProto.type
+			fd.typ = int(f.u)
+		case 6: // FieldDescriptorProto.type_name
+			fd.typeName = strings.TrimPrefix(string(f.b), ".")
+		}
+	}
+	return
+}
+
+func (s *Schema) loadEnum(scope string, b []byte) error {
+	fields, _, err := parseFields(b, 0)
+	if err != nil {
+		return err
+	}
+	values := make(map[int64]string)
+	var name string
+	for _, f := range fields {
+		switch f.num {
+		case 1: // EnumDescriptorProto.name
+			name = fullName(scope, string(f.b))
+		case 2: // EnumDescriptorProto.value
+			val, _, err := parseFields(f.b, 0)
+			if err != nil {
+				return err
+			}
+			var vname string
+			var num int64
+			for _, v := range val {
+				switch v.num {
+				case 1: // EnumValueDescriptorProto.name
+					vname = string(v.b)
+				case 2: // EnumValueDescriptorProto.number
+					num = int64(int32(v.u))
+				}
+			}
+			values[num] = vname
+		}
+	}
+	s.enums[name] = values
+	return nil
+}
+
+// -----------------------------------------------------------------------------
+
+// decode converts the fields of a message of the type msg to a map[string]any
+// keyed by the field names. Unknown fields are keyed by their numbers.
+func (s *Schema) decode(msg *message, fields []field) (map[string]any, error) {
+	ret := make(map[string]any, len(fields))
+	for _, f := range fields {
+		fd := msg.fields[f.num]
+		if fd == nil {
+			add(ret, strconv.Itoa(f.num), rawValue(f), false)
+			continue
+		}
+		if f.wire == wireBytes && fd.repeated && isScalar(fd.typ) { // packed
+			vals, err := s.unpack(fd, f.b)
+			if err != nil {
+				return nil, err
+			}
+			for _, v := range vals {
+				add(ret, fd.name, v, true)
+			}
+			continue
+		}
+		v, err := s.value(fd, f)
+		if err != nil {
+			return nil, err
+		}
+		if entry, ok := v.(mapEntry); ok {
+			m, _ := ret[fd.name].(map[string]any)
+			if m == nil {
+				m = make(map[string]any)
+				ret[fd.name] = m
+			}
+			m[fmt.Sprint(entry.key)] = entry.value
+			continue
+		}
+		if fd.repeated {
+			add(ret, fd.name, v, true)
+		} else {
+			ret[fd.name] = v // the last one wins
+		}
+	}
+	return ret, nil
+}
+
+type mapEntry struct {
+	key, value any
+}
+
+func isScalar(typ int) bool {
+	switch typ {
+	case typeString, typeBytes, typeMessage, typeGroup:
+		return false
+	}
+	return true
+}
+
+// unpack decodes the values of a packed repeated field.
+func (s *Schema) unpack(fd *fieldDesc, b []byte) (vals []any, err error) {
+	for len(b) > 0 {
+		f := field{wire: wireVarint}
+		switch fd.typ {
+		case typeDouble, typeFixed64, typeSfixed64:
+			if len(b) < 8 {
+				return nil, ErrInvalidWire
+			}
+			f.wire, f.u, b = wireFixed64, binary.LittleEndian.Uint64(b), b[8:]
+		case typeFloat, typeFixed32, typeSfixed32:
+			if len(b) < 4 {
+				return nil, ErrInvalidWire
+			}
+			f.wire, f.u, b = wireFixed32, uint64(binary.LittleEndian.Uint32(b)), b[4:]
+		default:
+			var n int
+			if f.u, n = binary.Uvarint(b); n <= 0 {
+				return nil, ErrInvalidWire
+			}
+			b = b[n:]
+		}
+		v, err := s.value(fd, f)
+		if err != nil {
+			return nil, err
+		}
+		vals = append(vals, v)
+	}
+	return
+}
+
+// value decodes the value of a field by its descriptor.
+func (s *Schema) value(fd *fieldDesc, f field) (any, error) {
+	want := wireVarint
+	switch fd.typ {
+	case typeDouble, typeFixed64, typeSfixed64:
+		want = wireFixed64
+	case typeFloat, typeFixed32, typeSfixed32:
+		want = wireFixed32
+	case typeString, typeBytes, typeMessage:
+		want = wireBytes
+	case typeGroup:
+		want = wireStartGroup
+	}
+	if f.wire != want {
+		return nil, fmt.Errorf("proto: field %s: unexpected wire type %d", fd.name, f.wire)
+	}
+	switch fd.typ {
+	case typeDouble:
+		return math.Float64frombits(f.u), nil
+	case typeFloat:
+		return float64(math.Float32frombits(uint32(f.u))), nil
+	case typeInt64, typeSfixed64:
+		return int64(f.u), nil
+	case typeUint64, typeFixed64:
+		return f.u, nil
+	case typeInt32, typeSfixed32:
+		return int64(int32(f.u)), nil
+	case typeUint32, typeFixed32:
+		return int64(uint32(f.u)), nil
+	case typeSint32, typeSint64:
+		return int64(f.u>>1) ^ -int64(f.u&1), nil
+	case typeBool:
+		return f.u != 0, nil
+	case typeString:
+		return string(f.b), nil
+	case typeBytes:
+		return f.b, nil
+	case typeEnum:
+		if name, ok := s.enums[fd.typeName][int64(int32(f.u))]; ok {
+			return name, nil
+		}
+		return int64(int32(f.u)), nil
+	}
+	msg := s.msgs[fd.typeName]
+	fields := f.group
+	if fd.typ == typeMessage {
+		var err error
+		if fields, _, err = parseFields(f.b, 0); err != nil {
+			return nil, err
+		}
+	}
+	if msg == nil {
+		return rawMessage(fields), nil
+	}
+	v, err := s.decode(msg, fields)
+	if err != nil || !msg.mapEntry {
+		return v, err
+	}
+	return mapEntry{v["key"], v["value"]}, nil
+}
+
+// -----------------------------------------------------------------------------
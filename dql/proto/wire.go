@@ -0,0 +1,170 @@
+/*
+ * Copyright (c) 2026 The XGo Authors (xgo.dev). All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package proto
+
+import (
+	"encoding/binary"
+	"errors"
+	"strconv"
+	"unicode"
+	"unicode/utf8"
+)
+
+// This file decodes the wire format of protobuf, see
+// https://protobuf.dev/programming-guides/encoding/.
+
+// -----------------------------------------------------------------------------
+
+const (
+	wireVarint     = 0
+	wireFixed64    = 1
+	wireBytes      = 2
+	wireStartGroup = 3
+	wireEndGroup   = 4
+	wireFixed32    = 5
+)
+
+// ErrInvalidWire is returned if the data isn't valid protobuf wire format.
+var ErrInvalidWire = errors.New("proto: invalid wire format")
+
+// field is a field of a message in the wire format.
+type field struct {
+	num   int
+	wire  int
+	u     uint64  // value of varint, fixed64 and fixed32 fields
+	b     []byte  // value of length-delimited fields
+	group []field // fields of a group
+}
+
+// parseFields parses the fields of a message. If group isn't 0, the fields
+// of the group are parsed until its end, and the number of the bytes read
+// (including the end of the group) is returned.
+func parseFields(b []byte, group int) (fields []field, n int, err error) {
+	for n < len(b) {
+		tag, m := binary.Uvarint(b[n:])
+		if m <= 0 || tag>>3 == 0 || tag>>3 > 1<<29-1 {
+			return nil, 0, ErrInvalidWire
+		}
+		n += m
+		f := field{num: int(tag >> 3), wire: int(tag & 7)}
+		switch f.wire {
+		case wireVarint:
+			if f.u, m = binary.Uvarint(b[n:]); m <= 0 {
+				return nil, 0, ErrInvalidWire
+			}
+			n += m
+		case wireFixed64:
+			if len(b)-n < 8 {
+				return nil, 0, ErrInvalidWire
+			}
+			f.u = binary.LittleEndian.Uint64(b[n:])
+			n += 8
+		case wireFixed32:
+			if len(b)-n < 4 {
+				return nil, 0, ErrInvalidWire
+			}
+			f.u = uint64(binary.LittleEndian.Uint32(b[n:]))
+			n += 4
+		case wireBytes:
+			size, m := binary.Uvarint(b[n:])
+			if m <= 0 || size > uint64(len(b)-n-m) {
+				return nil, 0, ErrInvalidWire
+			}
+			n += m
+			f.b = b[n : n+int(size)]
+			n += int(size)
+		case wireStartGroup:
+			if f.group, m, err = parseFields(b[n:], f.num); err != nil {
+				return nil, 0, err
+			}
+			n += m
+		case wireEndGroup:
+			if f.num != group {
+				return nil, 0, ErrInvalidWire
+			}
+			return fields, n, nil
+		default:
+			return nil, 0, ErrInvalidWire
+		}
+		fields = append(fields, f)
+	}
+	if group != 0 {
+		return nil, 0, ErrInvalidWire // missing the end of the group
+	}
+	return fields, n, nil
+}
+
+// rawMessage converts the fields of a message without its schema to a
+// map[string]any keyed by the field numbers. See Config.Schema.
+func rawMessage(fields []field) map[string]any {
+	ret := make(map[string]any, len(fields))
+	for _, f := range fields {
+		add(ret, strconv.Itoa(f.num), rawValue(f), false)
+	}
+	return ret
+}
+
+func rawValue(f field) any {
+	switch f.wire {
+	case wireVarint:
+		return int64(f.u)
+	case wireFixed64:
+		return f.u
+	case wireFixed32:
+		return int64(f.u)
+	case wireStartGroup:
+		return rawMessage(f.group)
+	}
+	if isText(f.b) {
+		return string(f.b)
+	}
+	if fields, _, err := parseFields(f.b, 0); err == nil && len(fields) > 0 {
+		return rawMessage(fields)
+	}
+	return f.b
+}
+
+// isText reports whether b is a printable UTF-8 string.
+func isText(b []byte) bool {
+	if !utf8.Valid(b) {
+		return false
+	}
+	for _, r := range string(b) {
+		if !unicode.IsPrint(r) && !unicode.IsSpace(r) {
+			return false
+		}
+	}
+	return true
+}
+
+// add adds a value of a field to a message. The values of a field which
+// appears more than once (or a repeated field) are collected into []any.
+func add(msg map[string]any, key string, v any, repeated bool) {
+	switch old := msg[key].(type) {
+	case nil:
+		if repeated {
+			v = []any{v}
+		}
+		msg[key] = v
+	case []any:
+		msg[key] = append(old, v)
+	default:
+		msg[key] = []any{old, v}
+	}
+}
+
+// -----------------------------------------------------------------------------
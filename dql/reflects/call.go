@@ -0,0 +1,204 @@
+/*
+ * Copyright (c) 2026 The XGo Authors (xgo.dev). All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package reflects
+
+import (
+	"errors"
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/goplus/xgo/dql"
+)
+
+// -----------------------------------------------------------------------------
+
+// ErrInvalidCall is returned by Call if the arguments don't match the
+// parameters of the method, or the method has an unsupported signature.
+var ErrInvalidCall = errors.New("reflects: invalid method call")
+
+// Call calls the method with the specified name and arguments on the first
+// node in the NodeSet, and returns the result. The arguments are converted to
+// the parameter types of the method if possible (e.g. int to int64). The
+// method must return a single value, or a value and an error.
+// If the NodeSet is empty or the method doesn't exist, it returns ErrNotFound.
+//
+// A method with arguments can also be called by the attribute and element
+// syntax with Go literals as arguments:
+//   - $“get("key")”
+//   - .“child(1)”
+func (p NodeSet) Call(name string, args ...any) (any, error) {
+	node, err := p.XGo_first()
+	if err != nil {
+		return nil, err
+	}
+	return node.Call(name, args...)
+}
+
+// Call calls the method with the specified name and arguments on the node,
+// and returns the result. See NodeSet.Call.
+func (n Node) Call(name string, args ...any) (any, error) {
+	mth := n.Value.MethodByName(capitalize(name))
+	if !mth.IsValid() {
+		return nil, dql.ErrNotFound
+	}
+	ret, err := callMethod(mth, args)
+	if err != nil {
+		return nil, err
+	}
+	return ret.Interface(), nil
+}
+
+// callMethod calls the method with the arguments converted to its parameter
+// types.
+func callMethod(mth reflect.Value, args []any) (reflect.Value, error) {
+	t := mth.Type()
+	switch {
+	case t.NumOut() == 1 && t.Out(0) != tyError:
+	case t.NumOut() == 2 && t.Out(1) == tyError:
+	default:
+		return reflect.Value{}, fmt.Errorf("%w: unsupported signature %v", ErrInvalidCall, t)
+	}
+	n := t.NumIn()
+	if len(args) < n-1 || (len(args) != n && !t.IsVariadic()) {
+		return reflect.Value{}, fmt.Errorf("%w: %d arguments for %v", ErrInvalidCall, len(args), t)
+	}
+	in := make([]reflect.Value, len(args))
+	for i, arg := range args {
+		var typ reflect.Type
+		if t.IsVariadic() && i >= n-1 {
+			typ = t.In(n - 1).Elem()
+		} else {
+			typ = t.In(i)
+		}
+		v, err := convertArg(arg, typ)
+		if err != nil {
+			return reflect.Value{}, fmt.Errorf("%w: argument %d: %v", ErrInvalidCall, i+1, err)
+		}
+		in[i] = v
+	}
+	out := mth.Call(in)
+	if len(out) == 2 && !out[1].IsNil() {
+		return reflect.Value{}, out[1].Interface().(error)
+	}
+	return out[0], nil
+}
+
+// convertArg converts arg to a value of type typ.
+func convertArg(arg any, typ reflect.Type) (reflect.Value, error) {
+	if arg == nil {
+		switch typ.Kind() {
+		case reflect.Pointer, reflect.Interface, reflect.Map, reflect.Slice, reflect.Func, reflect.Chan:
+			return reflect.Zero(typ), nil
+		}
+		return reflect.Value{}, fmt.Errorf("cannot use nil as %v", typ)
+	}
+	v := reflect.ValueOf(arg)
+	if v.Type().AssignableTo(typ) {
+		return v, nil
+	}
+	// Only convert between numbers, or between strings, since Go also allows
+	// conversions like int to string which aren't expected here.
+	if isNumber(v.Kind()) && isNumber(typ.Kind()) ||
+		v.Kind() == reflect.String && typ.Kind() == reflect.String {
+		return v.Convert(typ), nil
+	}
+	return reflect.Value{}, fmt.Errorf("cannot use %v as %v", v.Type(), typ)
+}
+
+func isNumber(kind reflect.Kind) bool {
+	return kind >= reflect.Int && kind <= reflect.Float64
+}
+
+// -----------------------------------------------------------------------------
+
+// parseCall parses a method call like `get("key", 1)` used as an attribute
+// or element name. The arguments must be Go literals (strings, characters,
+// numbers, true, false or nil).
+func parseCall(name string) (method string, args []any, ok bool) {
+	if !strings.HasSuffix(name, ")") {
+		return
+	}
+	e, err := parser.ParseExpr(name)
+	if err != nil {
+		return
+	}
+	call, ok := e.(*ast.CallExpr)
+	if !ok || call.Ellipsis.IsValid() {
+		return "", nil, false
+	}
+	fn, ok := call.Fun.(*ast.Ident)
+	if !ok {
+		return
+	}
+	args = make([]any, len(call.Args))
+	for i, arg := range call.Args {
+		if args[i], ok = literalOf(arg); !ok {
+			return
+		}
+	}
+	return fn.Name, args, true
+}
+
+// literalOf returns the value of a Go literal expression.
+func literalOf(e ast.Expr) (any, bool) {
+	switch e := e.(type) {
+	case *ast.BasicLit:
+		switch e.Kind {
+		case token.STRING:
+			s, err := strconv.Unquote(e.Value)
+			return s, err == nil
+		case token.CHAR:
+			s, err := strconv.Unquote(e.Value)
+			if err != nil {
+				return nil, false
+			}
+			return []rune(s)[0], true
+		case token.INT:
+			v, err := strconv.ParseInt(e.Value, 0, 64)
+			return int(v), err == nil
+		case token.FLOAT:
+			v, err := strconv.ParseFloat(e.Value, 64)
+			return v, err == nil
+		}
+	case *ast.UnaryExpr:
+		if e.Op == token.SUB {
+			switch v, ok := literalOf(e.X); v := v.(type) {
+			case int:
+				return -v, ok
+			case float64:
+				return -v, ok
+			}
+		}
+	case *ast.Ident:
+		switch e.Name {
+		case "true":
+			return true, true
+		case "false":
+			return false, true
+		case "nil":
+			return nil, true
+		}
+	}
+	return nil, false
+}
+
+// -----------------------------------------------------------------------------
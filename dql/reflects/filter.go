@@ -0,0 +1,84 @@
+/*
+ * Copyright (c) 2026 The XGo Authors (xgo.dev). All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package reflects
+
+import (
+	"reflect"
+	"slices"
+)
+
+// -----------------------------------------------------------------------------
+
+// OfType returns a NodeSet containing the nodes in p whose values are of type
+// T, or implement T if T is an interface type:
+//
+//	for call in reflects.ofType[*ast.CallExpr](doc.**.*) {
+//		echo call.$fun
+//	}
+//
+// Error nodes (see Node.Err) are kept.
+func OfType[T any](p NodeSet) NodeSet {
+	t := reflect.TypeFor[T]()
+	return p.filter(func(v reflect.Value) bool {
+		if t.Kind() == reflect.Interface {
+			return v.Type().Implements(t)
+		}
+		return v.Type() == t
+	})
+}
+
+// KindIs returns a NodeSet containing the nodes in the NodeSet whose values
+// are of one of the specified kinds. The value of a pointer node matches both
+// reflect.Pointer and the kind of the pointed-to value. Error nodes (see
+// Node.Err) are kept.
+func (p NodeSet) KindIs(kinds ...reflect.Kind) NodeSet {
+	return p.filter(func(v reflect.Value) bool {
+		if slices.Contains(kinds, v.Kind()) {
+			return true
+		}
+		kind, _ := deref(v)
+		return slices.Contains(kinds, kind)
+	})
+}
+
+// filter returns a NodeSet containing the nodes in the NodeSet whose values
+// (with interfaces unwrapped) satisfy fn. Nil interfaces never match.
+func (p NodeSet) filter(fn func(v reflect.Value) bool) NodeSet {
+	if p.Err != nil {
+		return p
+	}
+	return NodeSet{
+		Data: func(yield func(Node) bool) {
+			p.Data(func(node Node) bool {
+				if node.err != nil {
+					return yield(node)
+				}
+				v := node.Value
+				if v.Kind() == reflect.Interface {
+					v = v.Elem()
+				}
+				if v.IsValid() && fn(v) {
+					return yield(node)
+				}
+				return true
+			})
+		},
+		opts: p.opts,
+	}
+}
+
+// -----------------------------------------------------------------------------
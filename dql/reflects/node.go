@@ -50,10 +50,16 @@ var (
 	tyError = reflect.TypeFor[error]()
 )
 
-func lookup(obj reflect.Value, name string, allowMthd func(reflect.Value, string) bool) (ret reflect.Value) {
+func lookup(opts *options, obj reflect.Value, name string, allowMthd func(reflect.Value, string) bool) (ret reflect.Value) {
 	kind, node := deref(obj)
 	switch kind {
 	case reflect.Struct:
+		if ret = opts.fieldByTag(node, name); ret.IsValid() {
+			return
+		}
+		if ret = opts.unexportedField(node, name); ret.IsValid() {
+			return
+		}
 		name = capitalize(name)
 		ret = node.FieldByName(name)
 		if !ret.IsValid() {
@@ -71,6 +77,24 @@ func lookup(obj reflect.Value, name string, allowMthd func(reflect.Value, string
 	case reflect.Map:
 		ret = node.MapIndex(reflect.ValueOf(name))
 	}
+	if !ret.IsValid() {
+		ret = lookupCall(obj, name, allowMthd)
+	}
+	return
+}
+
+// lookupCall calls the method if name is a method call like `get("key")`.
+func lookupCall(obj reflect.Value, name string, allowMthd func(reflect.Value, string) bool) (ret reflect.Value) {
+	if method, args, ok := parseCall(name); ok {
+		method = capitalize(method)
+		if mth := obj.MethodByName(method); mth.IsValid() {
+			if allowMthd == nil {
+				return mth // only find the method, do not call it
+			} else if allowMthd(obj, method) {
+				ret, _ = callMethod(mth, args)
+			}
+		}
+	}
 	return
 }
 
@@ -93,6 +117,14 @@ func deref(v reflect.Value) (reflect.Kind, reflect.Value) {
 type Node struct {
 	Name  string
 	Value reflect.Value
+
+	err error // error of the node, see Err
+}
+
+// Err returns the error if the node is an error node (e.g. a cycle reported
+// by ReportCycles), and nil otherwise.
+func (n Node) Err() error {
+	return n.err
 }
 
 // XGo_Elem returns the child node with the specified name.
@@ -107,7 +139,7 @@ func (n Node) XGo_Elem(name string) (ret Node) {
 //   - .name
 //   - .“element-name”
 func (n Node) XGo_ElemEx(name string, allowMthd func(reflect.Value, string) bool) (ret Node) {
-	if v := lookup(n.Value, name, allowMthd); v.IsValid() {
+	if v := lookup(nil, n.Value, name, allowMthd); v.IsValid() {
 		ret = Node{Name: name, Value: v}
 	}
 	return
@@ -133,7 +165,7 @@ func (n Node) XGo_Any(name string) NodeSet {
 
 // _hasAttr checks if the node has an attribute with the specified name.
 func (n Node) XGo_hasAttr(name string) bool {
-	return lookup(n.Value, name, nil).IsValid()
+	return lookup(nil, n.Value, name, nil).IsValid()
 }
 
 // XGo_Attr returns the value of the attribute with the specified name.
@@ -159,7 +191,7 @@ func (n Node) XGo_Attr__1(name string) (any, error) {
 //   - $name
 //   - $“attr-name”
 func (n Node) XGo_AttrEx(name string, allowMthd func(reflect.Value, string) bool) (any, error) {
-	if v := lookup(n.Value, name, allowMthd); v.IsValid() {
+	if v := lookup(nil, n.Value, name, allowMthd); v.IsValid() {
 		return v.Interface(), nil
 	}
 	return nil, dql.ErrNotFound
@@ -0,0 +1,201 @@
+/*
+ * Copyright (c) 2026 The XGo Authors (xgo.dev). All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package reflects
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
+	"unsafe"
+)
+
+// -----------------------------------------------------------------------------
+
+// options holds the traversal options of a NodeSet.
+type options struct {
+	tags          []string // struct tag keys naming the fields, like "json"
+	allUnexported bool     // read unexported struct fields
+	cycles        bool     // report cycles as error nodes
+	limited       bool     // the depth of descendant traversal is limited
+	maxDepth      int      // max depth of descendant traversal if limited
+}
+
+// Tags returns a copy of the NodeSet which names struct fields by the
+// specified struct tags (in priority order) instead of their Go names:
+//
+//	type User struct {
+//		ID   int    `json:"user_id"`
+//		Name string `json:"name" xgo:"fullName"`
+//	}
+//
+//	doc.tags("xgo", "json").user_id
+//
+// Fields without any of the tags keep their uncapitalized Go names, and
+// fields tagged with "-" are skipped when enumerating children. Looking up a
+// field by its Go name still works.
+func (p NodeSet) Tags(keys ...string) NodeSet {
+	p.opts.tags = slices.Clone(keys)
+	return p
+}
+
+// fieldName returns the name of the struct field, and false if the field is
+// excluded by a "-" tag.
+func (o *options) fieldName(f reflect.StructField) (string, bool) {
+	if o != nil {
+		for _, key := range o.tags {
+			if tag, ok := f.Tag.Lookup(key); ok {
+				name, _, _ := strings.Cut(tag, ",")
+				if name == "-" {
+					return "", false
+				}
+				if name != "" {
+					return name, true
+				}
+			}
+		}
+	}
+	return uncapitalize(f.Name), true
+}
+
+// fieldByTag returns the exported field of the struct whose tag name is name.
+// It returns an invalid value if no struct tag is used.
+func (o *options) fieldByTag(node reflect.Value, name string) reflect.Value {
+	if o == nil || len(o.tags) == 0 {
+		return reflect.Value{}
+	}
+	typ := node.Type()
+	for i, n := 0, typ.NumField(); i < n; i++ {
+		if f := typ.Field(i); f.IsExported() {
+			if fname, ok := o.fieldName(f); ok && fname == name {
+				return node.Field(i)
+			}
+		}
+	}
+	return reflect.Value{}
+}
+
+// -----------------------------------------------------------------------------
+
+// Unexported returns a copy of the NodeSet whose queries also traverse and
+// read unexported struct fields, which is useful to inspect the internals of
+// third-party types (like go/types) for debugging and analysis. Unexported
+// fields are named as declared, and they are read with package unsafe, so
+// the values must be treated as read-only.
+func (p NodeSet) Unexported() NodeSet {
+	p.opts.allUnexported = true
+	return p
+}
+
+func (o *options) unexported() bool {
+	return o != nil && o.allUnexported
+}
+
+// unexportedField returns the unexported field of the struct with the
+// specified name, if unexported fields are allowed.
+func (o *options) unexportedField(node reflect.Value, name string) reflect.Value {
+	if !o.unexported() {
+		return reflect.Value{}
+	}
+	f, ok := node.Type().FieldByName(name)
+	if !ok || f.IsExported() {
+		return reflect.Value{}
+	}
+	v, err := addressable(node).FieldByIndexErr(f.Index)
+	if err != nil { // nil embedded pointer
+		return reflect.Value{}
+	}
+	return expose(v)
+}
+
+// addressable returns an addressable copy of v if v isn't addressable, so the
+// addresses of its fields can be taken.
+func addressable(v reflect.Value) reflect.Value {
+	if v.CanAddr() {
+		return v
+	}
+	ret := reflect.New(v.Type()).Elem()
+	ret.Set(v)
+	return ret
+}
+
+// expose returns a value of the addressable (unexported) field v which can be
+// used with Interface.
+func expose(v reflect.Value) reflect.Value {
+	return reflect.NewAt(v.Type(), unsafe.Pointer(v.UnsafeAddr())).Elem()
+}
+
+// -----------------------------------------------------------------------------
+
+// ErrCycle is the error of the nodes reported by ReportCycles.
+var ErrCycle = errors.New("reflects: cycle detected")
+
+// ReportCycles returns a copy of the NodeSet whose descendant traversals
+// (XGo_Any) yield an error node (see Node.Err) wrapping ErrCycle when a value
+// refers to one of its ancestors. Such values are always skipped to avoid
+// infinite recursion, and by default they are skipped silently.
+func (p NodeSet) ReportCycles() NodeSet {
+	p.opts.cycles = true
+	return p
+}
+
+func (o *options) reportCycles() bool {
+	return o != nil && o.cycles
+}
+
+// refKey identifies a pointer, map or slice value, which may be a part of a
+// cycle. The type is needed since a struct and its first field share the same
+// address.
+type refKey struct {
+	ptr uintptr
+	typ reflect.Type
+}
+
+// onPath is the set of values referred by the nodes on the current path.
+type onPath map[refKey]bool
+
+func refKeyOf(v reflect.Value) (refKey, bool) {
+	if v.Kind() == reflect.Interface {
+		v = v.Elem()
+	}
+	switch v.Kind() {
+	case reflect.Pointer, reflect.Map, reflect.Slice:
+		if !v.IsNil() {
+			return refKey{v.Pointer(), v.Type()}, true
+		}
+	}
+	return refKey{}, false
+}
+
+func cycleNode(n Node) Node {
+	err := fmt.Errorf("%w: %s (%v)", ErrCycle, n.Name, n.Value.Type())
+	return Node{Name: n.Name, Value: reflect.ValueOf(err), err: err}
+}
+
+// -----------------------------------------------------------------------------
+
+// MaxDepth returns a copy of the NodeSet whose descendant traversals (XGo_Any)
+// don't go deeper than n levels below the starting nodes, so huge object
+// graphs can be queried without exploding traversal. MaxDepth(1) visits the
+// nodes and their children, MaxDepth(0) only the nodes themselves.
+func (p NodeSet) MaxDepth(n int) NodeSet {
+	p.opts.limited, p.opts.maxDepth = true, max(n, 0)
+	return p
+}
+
+// -----------------------------------------------------------------------------
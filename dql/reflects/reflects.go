@@ -33,6 +33,8 @@ const (
 type NodeSet struct {
 	Data iter.Seq[Node]
 	Err  error
+
+	opts options
 }
 
 // NodeSet(seq) casts a NodeSet from a sequence of nodes.
@@ -99,7 +101,9 @@ func (p NodeSet) XGo_Enum() iter.Seq[NodeSet] {
 	}
 	return func(yield func(NodeSet) bool) {
 		p.Data(func(node Node) bool {
-			return yield(Root(node))
+			ret := Root(node)
+			ret.opts = p.opts
+			return yield(ret)
 		})
 	}
 }
@@ -120,6 +124,7 @@ func (p NodeSet) XGo_Select(name string) NodeSet {
 				return true
 			})
 		},
+		opts: p.opts,
 	}
 }
 
@@ -141,30 +146,41 @@ func (p NodeSet) XGo_ElemEx(name string, allowMthd func(reflect.Value, string) b
 	return NodeSet{
 		Data: func(yield func(Node) bool) {
 			p.Data(func(node Node) bool {
-				return yieldElem(node, name, allowMthd, yield)
+				return yieldElem(&p.opts, node, name, allowMthd, yield)
 			})
 		},
+		opts: p.opts,
 	}
 }
 
 // yieldElem yields the child node with the specified name if it exists.
-func yieldElem(node Node, name string, allowMthd func(reflect.Value, string) bool, yield func(Node) bool) bool {
-	if v := lookup(node.Value, name, allowMthd); v.IsValid() {
+func yieldElem(opts *options, node Node, name string, allowMthd func(reflect.Value, string) bool, yield func(Node) bool) bool {
+	if v := lookup(opts, node.Value, name, allowMthd); v.IsValid() {
 		return yield(Node{Name: name, Value: v})
 	}
 	return true
 }
 
-func yieldChildNodes(node reflect.Value, yield func(Node) bool) bool {
+func yieldChildNodes(opts *options, node reflect.Value, yield func(Node) bool) bool {
 	kind, node := deref(node)
 	switch kind {
 	case reflect.Struct:
 		typ := node.Type()
+		unexported := opts.unexported()
+		if unexported {
+			node = addressable(node)
+		}
 		for i, n := 0, typ.NumField(); i < n; i++ {
-			if v := node.Field(i); v.CanInterface() { // only yield exported fields
-				if !yield(Node{Name: uncapitalize(typ.Field(i).Name), Value: v}) {
-					return false
+			v := node.Field(i)
+			if !v.CanInterface() { // only yield exported fields by default
+				if !unexported {
+					continue
 				}
+				v = expose(v)
+			}
+			name, ok := opts.fieldName(typ.Field(i))
+			if ok && !yield(Node{Name: name, Value: v}) {
+				return false
 			}
 		}
 	case reflect.Map:
@@ -190,14 +206,34 @@ func yieldChildNodes(node reflect.Value, yield func(Node) bool) bool {
 
 // yieldAnyNodes yields all descendant nodes of the given node that match the
 // specified name. If name is "", it yields all nodes.
-func yieldAnyNodes(name string, node Node, yield func(Node) bool) bool {
+func yieldAnyNodes(opts *options, name string, node Node, yield func(Node) bool) bool {
+	return yieldAnyNodesEx(opts, name, node, 0, make(onPath), yield)
+}
+
+// yieldAnyNodesEx is like yieldAnyNodes, but skips the nodes referring to the
+// ancestors in path, so traversing cyclic object graphs terminates. depth is
+// the depth of node relative to the starting node.
+func yieldAnyNodesEx(opts *options, name string, node Node, depth int, path onPath, yield func(Node) bool) bool {
 	if name == "" || node.Name == name {
 		if !yield(node) {
 			return false
 		}
 	}
-	return yieldChildNodes(node.Value, func(n Node) bool {
-		return yieldAnyNodes(name, n, yield)
+	if opts.limited && depth >= opts.maxDepth {
+		return true
+	}
+	if key, ok := refKeyOf(node.Value); ok {
+		path[key] = true
+		defer delete(path, key)
+	}
+	return yieldChildNodes(opts, node.Value, func(n Node) bool {
+		if key, ok := refKeyOf(n.Value); ok && path[key] {
+			if opts.reportCycles() {
+				return yield(cycleNode(n))
+			}
+			return true
+		}
+		return yieldAnyNodesEx(opts, name, n, depth+1, path, yield)
 	})
 }
 
@@ -209,9 +245,10 @@ func (p NodeSet) XGo_Child() NodeSet {
 	return NodeSet{
 		Data: func(yield func(Node) bool) {
 			p.Data(func(node Node) bool {
-				return yieldChildNodes(node.Value, yield)
+				return yieldChildNodes(&p.opts, node.Value, yield)
 			})
 		},
+		opts: p.opts,
 	}
 }
 
@@ -228,9 +265,10 @@ func (p NodeSet) XGo_Any(name string) NodeSet {
 	return NodeSet{
 		Data: func(yield func(Node) bool) {
 			p.Data(func(node Node) bool {
-				return yieldAnyNodes(name, node, yield)
+				return yieldAnyNodes(&p.opts, name, node, yield)
 			})
 		},
+		opts: p.opts,
 	}
 }
 
@@ -244,7 +282,9 @@ func (p NodeSet) XGo_all() NodeSet {
 		return NodeSet{Err: p.Err}
 	}
 	nodes := dql.Collect(p.Data)
-	return Nodes(nodes...)
+	ret := Nodes(nodes...)
+	ret.opts = p.opts
+	return ret
 }
 
 // _one returns a NodeSet containing the first node.
@@ -257,7 +297,9 @@ func (p NodeSet) XGo_one() NodeSet {
 	if err != nil {
 		return NodeSet{Err: err}
 	}
-	return Root(n)
+	ret := Root(n)
+	ret.opts = p.opts
+	return ret
 }
 
 // _single returns a NodeSet containing the single node.
@@ -271,7 +313,9 @@ func (p NodeSet) XGo_single() NodeSet {
 	if err != nil {
 		return NodeSet{Err: err}
 	}
-	return Root(n)
+	ret := Root(n)
+	ret.opts = p.opts
+	return ret
 }
 
 // -----------------------------------------------------------------------------
@@ -318,7 +362,9 @@ func (p NodeSet) XGo_value__0() any {
 func (p NodeSet) XGo_value__1() (ret any, err error) {
 	node, err := p.XGo_first()
 	if err == nil {
-		ret = node.Value.Interface()
+		if err = node.err; err == nil {
+			ret = node.Value.Interface()
+		}
 	}
 	return
 }
@@ -341,7 +387,7 @@ func (p NodeSet) XGo_class() (class string) {
 func (p NodeSet) XGo_hasAttr(name string) bool {
 	node, err := p.XGo_first()
 	if err == nil {
-		return node.XGo_hasAttr(name)
+		return lookup(&p.opts, node.Value, name, nil).IsValid()
 	}
 	return false
 }
@@ -371,7 +417,10 @@ func (p NodeSet) XGo_Attr__1(name string) (any, error) {
 func (p NodeSet) XGo_AttrEx(name string, allowMthd func(reflect.Value, string) bool) (any, error) {
 	node, err := p.XGo_first()
 	if err == nil {
-		return node.XGo_AttrEx(name, allowMthd)
+		if v := lookup(&p.opts, node.Value, name, allowMthd); v.IsValid() {
+			return v.Interface(), nil
+		}
+		return nil, dql.ErrNotFound
 	}
 	return nil, err
 }
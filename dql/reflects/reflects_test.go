@@ -0,0 +1,256 @@
+/*
+ * Copyright (c) 2026 The XGo Authors (xgo.dev). All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package reflects
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/goplus/xgo/dql"
+)
+
+type config struct {
+	Name  string
+	props map[string]string
+	Sub   *config
+}
+
+func (c *config) Get(key string) string {
+	return c.props[key]
+}
+
+func (c *config) Lookup(key string) (string, error) {
+	if v, ok := c.props[key]; ok {
+		return v, nil
+	}
+	return "", fmt.Errorf("key %q: %w", key, dql.ErrNotFound)
+}
+
+func (c *config) Join(sep string, n int64, keys ...string) string {
+	vals := make([]string, 0, len(keys))
+	for _, k := range keys {
+		vals = append(vals, strings.Repeat(c.props[k], int(n)))
+	}
+	return strings.Join(vals, sep)
+}
+
+func (c *config) Child(name string) *config {
+	if c.Sub != nil && c.Sub.Name == name {
+		return c.Sub
+	}
+	return nil
+}
+
+func newConfig() *config {
+	return &config{
+		Name:  "root",
+		props: map[string]string{"a": "1", "b": "2"},
+		Sub:   &config{Name: "sub", props: map[string]string{"a": "x"}},
+	}
+}
+
+func TestCall(t *testing.T) {
+	doc := Source(newConfig())
+	if v, err := doc.Call("get", "a"); v != "1" || err != nil {
+		t.Error("Call get:", v, err)
+	}
+	if v, err := doc.Call("join", "-", 2, "a", "b"); v != "11-22" || err != nil {
+		t.Error("Call join:", v, err)
+	}
+	if _, err := doc.Call("lookup", "c"); !errors.Is(err, dql.ErrNotFound) {
+		t.Error("Call lookup:", err)
+	}
+	if _, err := doc.Call("missing"); err != dql.ErrNotFound {
+		t.Error("Call missing:", err)
+	}
+	for _, args := range [][]any{{}, {1}, {"a", "b"}} {
+		if _, err := doc.Call("get", args...); !errors.Is(err, ErrInvalidCall) {
+			t.Error("Call get:", args, err)
+		}
+	}
+
+	if v := doc.XGo_Attr__0(`get("b")`); v != "2" {
+		t.Error(`$get("b"):`, v)
+	}
+	if v := doc.XGo_Attr__0(`join(",", 1, "b", "a")`); v != "2,1" {
+		t.Error(`$join:`, v)
+	}
+	if !doc.XGo_hasAttr(`lookup("c")`) {
+		t.Error(`hasAttr lookup: false`)
+	}
+	if _, err := doc.XGo_Attr__1(`lookup("c")`); err != dql.ErrNotFound {
+		t.Error(`$lookup("c"):`, err)
+	}
+	if v := doc.XGo_Elem(`child("sub")`).XGo_Attr__0(`get("a")`); v != "x" {
+		t.Error(`.child("sub").$get("a"):`, v)
+	}
+	if _, err := doc.XGo_Attr__1(`get(a)`); err != dql.ErrNotFound {
+		t.Error(`$get(a):`, err)
+	}
+}
+
+type user struct {
+	ID      int    `json:"user_id"`
+	Name    string `json:"name,omitempty" xgo:"fullName"`
+	Secret  string `json:"-"`
+	Email   string
+	Friends []*user `json:"friends"`
+}
+
+func names(ns NodeSet) string {
+	var ret []string
+	ns.Data(func(n Node) bool {
+		ret = append(ret, n.Name)
+		return true
+	})
+	return strings.Join(ret, ",")
+}
+
+func TestTags(t *testing.T) {
+	u := &user{ID: 1, Name: "Ann", Secret: "x", Email: "ann@example.com", Friends: []*user{{ID: 2, Name: "Bob"}}}
+	doc := Source(u).Tags("xgo", "json")
+	if got := names(doc.XGo_Child()); got != "user_id,fullName,email,friends" {
+		t.Error("XGo_Child:", got)
+	}
+	if v := doc.XGo_Attr__0("user_id"); v != 1 {
+		t.Error("$user_id:", v)
+	}
+	if v := doc.XGo_Attr__0("name"); v != "Ann" {
+		t.Error("$name:", v)
+	}
+	if !doc.XGo_hasAttr("fullName") {
+		t.Error("hasAttr fullName: false")
+	}
+	if v := doc.XGo_Elem("friends").XGo_Child().XGo_Attr__0("fullName"); v != "Bob" {
+		t.Error("friends.*.$fullName:", v)
+	}
+	if got := names(doc.XGo_Any("user_id")); got != "user_id,user_id" {
+		t.Error("XGo_Any:", got)
+	}
+	if got := names(Source(u).XGo_Child()); got != "iD,name,secret,email,friends" {
+		t.Error("XGo_Child without tags:", got)
+	}
+}
+
+func TestUnexported(t *testing.T) {
+	c := newConfig()
+	if got := names(Source(c).XGo_Child()); got != "name,sub" {
+		t.Error("XGo_Child:", got)
+	}
+	doc := Source(c).Unexported()
+	if got := names(doc.XGo_Child()); got != "name,props,sub" {
+		t.Error("XGo_Child unexported:", got)
+	}
+	if v := doc.XGo_Elem("sub").XGo_Elem("props").XGo_Attr__0("a"); v != "x" {
+		t.Error("sub.props.$a:", v)
+	}
+	if _, err := Source(c).XGo_Attr__1("props"); err != dql.ErrNotFound {
+		t.Error("$props:", err)
+	}
+	// a struct value (not addressable) also works
+	if got := names(Source(*c).Unexported().XGo_Any("props")); got != "props,props" {
+		t.Error("XGo_Any:", got)
+	}
+}
+
+type treeNode struct {
+	Name     string
+	Parent   *treeNode
+	Children []*treeNode
+}
+
+func TestCycles(t *testing.T) {
+	root := &treeNode{Name: "root"}
+	a := &treeNode{Name: "a", Parent: root}
+	b := &treeNode{Name: "b", Parent: a}
+	root.Children = []*treeNode{a}
+	a.Children = []*treeNode{b}
+	b.Children = []*treeNode{root} // not a tree any more
+
+	var got []string
+	Source(root).XGo_Any("name").Data(func(n Node) bool {
+		got = append(got, n.Value.String())
+		return true
+	})
+	if s := strings.Join(got, ","); s != "root,a,b" {
+		t.Error("XGo_Any:", s)
+	}
+
+	var errs int
+	Source(root).ReportCycles().XGo_Any("").Data(func(n Node) bool {
+		if err := n.Err(); err != nil {
+			if !errors.Is(err, ErrCycle) {
+				t.Error("Err:", err)
+			}
+			errs++
+		}
+		return true
+	})
+	if errs != 3 { // a.parent, b.parent and b.children[0]
+		t.Error("cycles:", errs)
+	}
+	var errNode Node
+	Source(a).ReportCycles().XGo_Any("").Data(func(n Node) bool {
+		errNode = n
+		return n.Err() == nil
+	})
+	if _, err := Root(errNode).XGo_value__1(); !errors.Is(err, ErrCycle) {
+		t.Error("value:", err)
+	}
+}
+
+func TestDepthAndTypes(t *testing.T) {
+	root := &treeNode{Name: "root"}
+	for cur, i := root, 0; i < 3; i++ {
+		child := &treeNode{Name: fmt.Sprint("n", i)}
+		cur.Children = []*treeNode{child}
+		cur = child
+	}
+	count := func(ns NodeSet) int {
+		return len(dql.Collect(ns.Data))
+	}
+	all := Source(root).XGo_Any("")
+	if n := count(Source(root).MaxDepth(0).XGo_Any("")); n != 1 {
+		t.Error("MaxDepth(0):", n)
+	}
+	// root, root.name, root.parent, root.children
+	if n := count(Source(root).MaxDepth(1).XGo_Any("")); n != 4 {
+		t.Error("MaxDepth(1):", n)
+	}
+	if n := count(Source(root).MaxDepth(4).XGo_Any("name")); n != 2 {
+		t.Error("MaxDepth(4):", n)
+	}
+	// 4 nodes and 4 nil parents
+	if n := count(OfType[*treeNode](all)); n != 8 {
+		t.Error("OfType[*treeNode]:", n)
+	}
+	if n := count(OfType[fmt.Stringer](all)); n != 0 {
+		t.Error("OfType[fmt.Stringer]:", n)
+	}
+	if n := count(all.KindIs(reflect.String)); n != 4 {
+		t.Error("KindIs(String):", n)
+	}
+	if n := count(all.KindIs(reflect.Struct)); n != 4 {
+		t.Error("KindIs(Struct):", n)
+	}
+	if n := count(all.KindIs(reflect.Slice, reflect.Pointer)); n != 12 {
+		t.Error("KindIs(Slice, Pointer):", n)
+	}
+}
@@ -0,0 +1,217 @@
+/*
+ * Copyright (c) 2026 The XGo Authors (xgo.dev). All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package regexp
+
+import (
+	"bufio"
+	"bytes"
+	"io"
+	"iter"
+	goregexp "regexp"
+	"strconv"
+	"strings"
+
+	"github.com/goplus/xgo/dql/maps"
+	"github.com/goplus/xgo/dql/stream"
+)
+
+const (
+	XGoPackage = "github.com/goplus/xgo/dql/maps"
+)
+
+// -----------------------------------------------------------------------------
+
+// Node represents a match, whose value is a map[string]any of its
+// attributes.
+type Node = maps.Node
+
+// NodeSet represents a set of matches.
+type NodeSet = maps.NodeSet
+
+// Config specifies how the text is matched.
+type Config struct {
+	// Lines matches each line separately (without its line ending), so
+	// matches can't span lines, and the text is read line by line while
+	// iterating. Otherwise the whole text is read into memory first.
+	Lines bool
+
+	// OnError is called if an error occurs while reading the text.
+	OnError func(error)
+}
+
+// New creates a NodeSet of the matches of re in the text read from r. Each
+// match is a node named "match" with attributes:
+//   - the capture groups: named groups by their names, and other groups by
+//     their indexes ("1", "2", etc.). A group that doesn't participate in
+//     the match is nil.
+//   - text: the text of the whole match.
+//   - offset: the byte offset of the match in the text.
+//   - line: the line number (starting at 1) where the match starts.
+//
+// A group named text, offset or line hides the attribute. For example:
+//
+//	re := `(?P<ip>\S+) \S+ \S+ \[[^]]+\] "(?P<method>\S+) (?P<path>\S+)`
+//	for m in regexp.source("access.log", re, regexp.Config{Lines: true}) {
+//		echo m.$ip, m.$method, m.$path
+//	}
+func New(r io.Reader, re *goregexp.Regexp, conf ...Config) NodeSet {
+	var c Config
+	if conf != nil {
+		c = conf[0]
+	}
+	if c.Lines {
+		return NodeSet{Data: lines(r, re, c)}
+	}
+	return NodeSet{
+		Data: func(yield func(Node) bool) {
+			b, err := io.ReadAll(r)
+			if err != nil {
+				if c.OnError != nil {
+					c.OnError(err)
+				}
+				return
+			}
+			line, last := 1, 0
+			for _, loc := range re.FindAllSubmatchIndex(b, -1) {
+				line += bytes.Count(b[last:loc[0]], []byte{'\n'})
+				last = loc[0]
+				if !yield(match(re, b, loc, 0, line)) {
+					return
+				}
+			}
+		},
+	}
+}
+
+// Matches creates a NodeSet of the matches of re in the string s. See New.
+func Matches(s string, re *goregexp.Regexp) NodeSet {
+	return New(strings.NewReader(s), re)
+}
+
+func lines(r io.Reader, re *goregexp.Regexp, c Config) iter.Seq[Node] {
+	return func(yield func(Node) bool) {
+		br := bufio.NewReader(r)
+		offset := 0
+		for lineno := 1; ; lineno++ {
+			line, err := br.ReadBytes('\n')
+			text := bytes.TrimSuffix(bytes.TrimSuffix(line, []byte{'\n'}), []byte{'\r'})
+			for _, loc := range re.FindAllSubmatchIndex(text, -1) {
+				if !yield(match(re, text, loc, offset, lineno)) {
+					return
+				}
+			}
+			offset += len(line)
+			if err != nil {
+				if err != io.EOF && c.OnError != nil {
+					c.OnError(err)
+				}
+				return
+			}
+		}
+	}
+}
+
+// match creates the node of a match, whose submatch indexes in b are loc.
+func match(re *goregexp.Regexp, b []byte, loc []int, offset, line int) Node {
+	ret := map[string]any{
+		"text":   string(b[loc[0]:loc[1]]),
+		"offset": offset + loc[0],
+		"line":   line,
+	}
+	for i, name := range re.SubexpNames() {
+		if i == 0 {
+			continue
+		}
+		if name == "" {
+			name = strconv.Itoa(i)
+		}
+		if start := loc[2*i]; start >= 0 {
+			ret[name] = string(b[start:loc[2*i+1]])
+		} else {
+			ret[name] = nil
+		}
+	}
+	return Node{Name: "match", Value: ret}
+}
+
+// -----------------------------------------------------------------------------
+
+// Source creates a NodeSet of the matches of re, which is a pattern string or
+// a *regexp.Regexp, from various source types:
+// - string: treats the string as a file path (or URL), opens it, and matches the text of it.
+// - *stream.Request: opens the resource with the request options (like HTTP headers).
+// - []byte: matches the text of the byte slice.
+// - io.Reader: matches the text read from the provided reader.
+// - Node: creates a NodeSet containing the single provided node.
+// - iter.Seq[Node]: directly uses the provided sequence of nodes.
+// - NodeSet: returns the provided NodeSet as is.
+// If the source type (or the pattern type) is unsupported, it panics. Use
+// Matches to match a string.
+//
+// A file (or a resource) is closed after the matches are iterated.
+func Source(r any, re any, conf ...Config) (ret NodeSet) {
+	var pattern *goregexp.Regexp
+	switch v := re.(type) {
+	case string:
+		var err error
+		if pattern, err = goregexp.Compile(v); err != nil {
+			return NodeSet{Err: err}
+		}
+	case *goregexp.Regexp:
+		pattern = v
+	default:
+		panic("dql/regexp.Source: unsupported pattern type")
+	}
+	switch v := r.(type) {
+	case string:
+		f, err := stream.Open(v)
+		if err != nil {
+			return NodeSet{Err: err}
+		}
+		return closeAfter(New(f, pattern, conf...), f)
+	case *stream.Request:
+		f, err := v.Open()
+		if err != nil {
+			return NodeSet{Err: err}
+		}
+		return closeAfter(New(f, pattern, conf...), f)
+	case []byte:
+		return New(bytes.NewReader(v), pattern, conf...)
+	case io.Reader:
+		return New(v, pattern, conf...)
+	case Node:
+		return maps.Root(v)
+	case iter.Seq[Node]:
+		return NodeSet{Data: v}
+	case NodeSet:
+		return v
+	default:
+		panic("dql/regexp.Source: unsupported source type")
+	}
+}
+
+// closeAfter closes f after the nodes of ns are iterated.
+func closeAfter(ns NodeSet, f io.Closer) NodeSet {
+	data := ns.Data
+	ns.Data = func(yield func(Node) bool) {
+		defer f.Close()
+		data(yield)
+	}
+	return ns
+}
+
+// -----------------------------------------------------------------------------
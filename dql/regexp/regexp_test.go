@@ -0,0 +1,77 @@
+/*
+ * Copyright (c) 2026 The XGo Authors (xgo.dev). All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package regexp
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	goregexp "regexp"
+	"testing"
+)
+
+const log = `10.0.0.1 GET /index.html 200
+10.0.0.2 POST /api/users 201
+garbage
+10.0.0.1 GET /missing 404
+`
+
+func values(t *testing.T, ns NodeSet) (ret []map[string]any) {
+	t.Helper()
+	if ns.Err != nil {
+		t.Fatal(ns.Err)
+	}
+	for m := range ns.XGo_Enum() {
+		node, _ := m.XGo_first()
+		ret = append(ret, node.Value.(map[string]any))
+	}
+	return
+}
+
+func TestMatch(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "access.log")
+	if err := os.WriteFile(file, []byte(log), 0644); err != nil {
+		t.Fatal(err)
+	}
+	re := `(?P<ip>\S+) (?P<method>GET|POST) (\S+) (?P<status>\d+)`
+	want := []map[string]any{
+		{"ip": "10.0.0.1", "method": "GET", "3": "/index.html", "status": "200", "text": "10.0.0.1 GET /index.html 200", "offset": 0, "line": 1},
+		{"ip": "10.0.0.2", "method": "POST", "3": "/api/users", "status": "201", "text": "10.0.0.2 POST /api/users 201", "offset": 29, "line": 2},
+		{"ip": "10.0.0.1", "method": "GET", "3": "/missing", "status": "404", "text": "10.0.0.1 GET /missing 404", "offset": 66, "line": 4},
+	}
+	if got := values(t, Source(file, re)); !reflect.DeepEqual(got, want) {
+		t.Fatal("Source:", got)
+	}
+	if got := values(t, Source(file, goregexp.MustCompile(re), Config{Lines: true})); !reflect.DeepEqual(got, want) {
+		t.Fatal("Lines:", got)
+	}
+
+	got := values(t, Matches("a1 b c3", goregexp.MustCompile(`(?P<name>[a-z])(\d)?`)))
+	if len(got) != 3 || got[1]["name"] != "b" || got[1]["2"] != nil || got[2]["2"] != "3" {
+		t.Fatal("Matches:", got)
+	}
+	// matches span lines unless Lines is set
+	if got = values(t, Source([]byte("a\nb"), `a\sb`)); len(got) != 1 {
+		t.Fatal("multiline:", got)
+	}
+	if got = values(t, Source([]byte("a\r\nb"), `a\sb`, Config{Lines: true})); len(got) != 0 {
+		t.Fatal("Lines:", got)
+	}
+	if ns := Source([]byte("a"), `(`); ns.Err == nil {
+		t.Fatal("invalid pattern")
+	}
+}
@@ -0,0 +1,320 @@
+/*
+ * Copyright (c) 2026 The XGo Authors (xgo.dev). All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package sqlite
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"unicode/utf16"
+)
+
+// This file reads the SQLite database file format, see
+// https://www.sqlite.org/fileformat.html.
+
+// -----------------------------------------------------------------------------
+
+const (
+	pageInteriorTable = 0x05
+	pageLeafTable     = 0x0d
+)
+
+var (
+	// ErrNotDatabase is returned if a file isn't an SQLite database.
+	ErrNotDatabase = errors.New("sqlite: file is not a database")
+
+	errCorrupt = errors.New("sqlite: database disk image is malformed")
+)
+
+// file reads the pages of a database file.
+type file struct {
+	r        io.ReaderAt
+	pageSize int
+	usable   int // usable size of a page, without the reserved space
+	encoding int // text encoding, 1: UTF-8, 2: UTF-16le, 3: UTF-16be
+}
+
+func newFile(r io.ReaderAt) (*file, error) {
+	var hdr [100]byte
+	if _, err := r.ReadAt(hdr[:], 0); err != nil {
+		if err == io.EOF {
+			err = ErrNotDatabase
+		}
+		return nil, err
+	}
+	if string(hdr[:16]) != "SQLite format 3\x00" {
+		return nil, ErrNotDatabase
+	}
+	pageSize := int(binary.BigEndian.Uint16(hdr[16:]))
+	if pageSize == 1 {
+		pageSize = 65536
+	}
+	if pageSize < 512 || pageSize&(pageSize-1) != 0 {
+		return nil, errCorrupt
+	}
+	f := &file{
+		r:        r,
+		pageSize: pageSize,
+		usable:   pageSize - int(hdr[20]),
+		encoding: int(binary.BigEndian.Uint32(hdr[56:])),
+	}
+	if f.encoding == 0 {
+		f.encoding = 1
+	}
+	return f, nil
+}
+
+func (f *file) page(n uint32) ([]byte, error) {
+	if n == 0 {
+		return nil, errCorrupt
+	}
+	b := make([]byte, f.pageSize)
+	if _, err := f.r.ReadAt(b, int64(n-1)*int64(f.pageSize)); err != nil {
+		if err == io.EOF {
+			err = errCorrupt
+		}
+		return nil, err
+	}
+	return b, nil
+}
+
+// rows walks the table b-tree whose root page is root, and calls fn with the
+// rowid and the record of each row, in the order of rowid.
+func (f *file) rows(root uint32, fn func(rowid int64, rec []any) bool) error {
+	_, err := f.walk(root, 0, fn)
+	return err
+}
+
+func (f *file) walk(pgno uint32, depth int, fn func(rowid int64, rec []any) bool) (bool, error) {
+	if depth > 64 {
+		return false, errCorrupt // a cycle of pages
+	}
+	page, err := f.page(pgno)
+	if err != nil {
+		return false, err
+	}
+	hdr := page
+	if pgno == 1 {
+		hdr = page[100:]
+	}
+	if len(hdr) < 8 {
+		return false, errCorrupt
+	}
+	typ, ncell := hdr[0], int(binary.BigEndian.Uint16(hdr[3:]))
+	hdrSize := 8
+	if typ == pageInteriorTable {
+		hdrSize = 12
+	} else if typ != pageLeafTable {
+		return false, fmt.Errorf("sqlite: unexpected b-tree page type %d", typ)
+	}
+	if len(hdr) < hdrSize+2*ncell {
+		return false, errCorrupt
+	}
+	for i := 0; i < ncell; i++ {
+		off := int(binary.BigEndian.Uint16(hdr[hdrSize+2*i:]))
+		if off >= len(page) {
+			return false, errCorrupt
+		}
+		cell := page[off:]
+		if typ == pageInteriorTable {
+			if len(cell) < 4 {
+				return false, errCorrupt
+			}
+			if ok, err := f.walk(binary.BigEndian.Uint32(cell), depth+1, fn); !ok || err != nil {
+				return ok, err
+			}
+			continue
+		}
+		payload, rowid, err := f.leafCell(cell)
+		if err != nil {
+			return false, err
+		}
+		rec, err := f.record(payload)
+		if err != nil {
+			return false, err
+		}
+		if !fn(rowid, rec) {
+			return false, nil
+		}
+	}
+	if typ == pageInteriorTable {
+		return f.walk(binary.BigEndian.Uint32(hdr[8:]), depth+1, fn)
+	}
+	return true, nil
+}
+
+// leafCell returns the payload (including the overflow) and the rowid of a
+// cell of a table leaf page.
+func (f *file) leafCell(cell []byte) (payload []byte, rowid int64, err error) {
+	size, n := varint(cell)
+	if n == 0 {
+		return nil, 0, errCorrupt
+	}
+	cell = cell[n:]
+	id, n := varint(cell)
+	if n == 0 {
+		return nil, 0, errCorrupt
+	}
+	cell = cell[n:]
+	total := int(size)
+	local := f.localSize(total)
+	if local > len(cell) || total < 0 {
+		return nil, 0, errCorrupt
+	}
+	payload = append(make([]byte, 0, total), cell[:local]...)
+	if local == total {
+		return payload, int64(id), nil
+	}
+	if len(cell) < local+4 {
+		return nil, 0, errCorrupt
+	}
+	next := binary.BigEndian.Uint32(cell[local:])
+	for len(payload) < total {
+		page, err := f.page(next)
+		if err != nil {
+			return nil, 0, err
+		}
+		next = binary.BigEndian.Uint32(page)
+		n := min(total-len(payload), f.usable-4)
+		payload = append(payload, page[4:4+n]...)
+	}
+	return payload, int64(id), nil
+}
+
+// localSize returns the size of the payload stored in a table leaf cell, the
+// rest is stored in overflow pages.
+func (f *file) localSize(total int) int {
+	u := f.usable
+	x := u - 35
+	if total <= x {
+		return total
+	}
+	m := (u-12)*32/255 - 23
+	k := m + (total-m)%(u-4)
+	if k <= x {
+		return k
+	}
+	return m
+}
+
+// record decodes a record: int64, float64, string, []byte or nil values.
+func (f *file) record(b []byte) ([]any, error) {
+	hdrSize, n := varint(b)
+	if n == 0 || int(hdrSize) > len(b) || int(hdrSize) < n {
+		return nil, errCorrupt
+	}
+	hdr, body := b[n:hdrSize], b[hdrSize:]
+	var rec []any
+	for len(hdr) > 0 {
+		typ, n := varint(hdr)
+		if n == 0 {
+			return nil, errCorrupt
+		}
+		hdr = hdr[n:]
+		size := serialSize(typ)
+		if size > len(body) {
+			return nil, errCorrupt
+		}
+		v := body[:size]
+		body = body[size:]
+		switch {
+		case typ == 0:
+			rec = append(rec, nil)
+		case typ <= 6:
+			rec = append(rec, bigInt(v))
+		case typ == 7:
+			rec = append(rec, math.Float64frombits(binary.BigEndian.Uint64(v)))
+		case typ == 8:
+			rec = append(rec, int64(0))
+		case typ == 9:
+			rec = append(rec, int64(1))
+		case typ >= 12 && typ%2 == 0:
+			rec = append(rec, append([]byte(nil), v...))
+		case typ >= 13:
+			rec = append(rec, f.text(v))
+		default:
+			return nil, errCorrupt
+		}
+	}
+	return rec, nil
+}
+
+func serialSize(typ uint64) int {
+	switch typ {
+	case 0, 8, 9:
+		return 0
+	case 1, 2, 3, 4:
+		return int(typ)
+	case 5:
+		return 6
+	case 6, 7:
+		return 8
+	}
+	if typ >= 12 {
+		return int((typ - 12) / 2)
+	}
+	return 0
+}
+
+// bigInt decodes a big-endian two's complement integer.
+func bigInt(b []byte) int64 {
+	var v int64
+	if len(b) > 0 && b[0]&0x80 != 0 {
+		v = -1
+	}
+	for _, c := range b {
+		v = v<<8 | int64(c)
+	}
+	return v
+}
+
+func (f *file) text(b []byte) string {
+	if f.encoding == 1 || len(b)%2 != 0 {
+		return string(b)
+	}
+	u := make([]uint16, len(b)/2)
+	for i := range u {
+		if f.encoding == 2 {
+			u[i] = binary.LittleEndian.Uint16(b[2*i:])
+		} else {
+			u[i] = binary.BigEndian.Uint16(b[2*i:])
+		}
+	}
+	return string(utf16.Decode(u))
+}
+
+// varint decodes a variable-length integer of SQLite, and returns the number
+// of bytes read, or 0 if b is too short.
+func varint(b []byte) (v uint64, n int) {
+	for i := 0; i < 9; i++ {
+		if i >= len(b) {
+			return 0, 0
+		}
+		if i == 8 {
+			return v<<8 | uint64(b[i]), 9
+		}
+		v = v<<7 | uint64(b[i]&0x7f)
+		if b[i] < 0x80 {
+			return v, i + 1
+		}
+	}
+	return
+}
+
+// -----------------------------------------------------------------------------
@@ -0,0 +1,362 @@
+/*
+ * Copyright (c) 2026 The XGo Authors (xgo.dev). All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package sqlite
+
+import (
+	"fmt"
+	"iter"
+	"os"
+	"strings"
+
+	"github.com/goplus/xgo/dql/maps"
+)
+
+const (
+	XGoPackage = "github.com/goplus/xgo/dql/maps"
+)
+
+// -----------------------------------------------------------------------------
+
+// Node represents a map[string]any or []any node.
+type Node = maps.Node
+
+// NodeSet represents a set of SQLite nodes.
+type NodeSet = maps.NodeSet
+
+// DB is an SQLite database file opened for reading. It reads the file format
+// directly, so no driver (and no cgo) is required. Tables WITHOUT ROWID and
+// virtual tables aren't supported, and the changes in the write-ahead log
+// (the -wal file) which aren't checkpointed yet are invisible.
+type DB struct {
+	f      *os.File
+	file   *file
+	tables []*table
+
+	// OnError is called if an error occurs while reading rows.
+	OnError func(error)
+}
+
+// table is the schema of a table.
+type table struct {
+	name  string
+	root  uint32 // root page of the table b-tree
+	cols  []string
+	rowid int // index of the INTEGER PRIMARY KEY column, which is an alias of rowid, or -1
+	err   error
+}
+
+// Open opens an SQLite database file for reading.
+func Open(path string) (*DB, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	db := &DB{f: f}
+	if db.file, err = newFile(f); err == nil {
+		err = db.loadSchema()
+	}
+	if err != nil {
+		f.Close()
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return db, nil
+}
+
+// Close closes the database file.
+func (db *DB) Close() error {
+	return db.f.Close()
+}
+
+func (db *DB) loadSchema() error {
+	return db.file.rows(1, func(_ int64, rec []any) bool {
+		if len(rec) < 5 || rec[0] != "table" {
+			return true
+		}
+		name, _ := rec[1].(string)
+		root, _ := rec[3].(int64)
+		sql, _ := rec[4].(string)
+		if strings.HasPrefix(name, "sqlite_") {
+			return true
+		}
+		t := &table{name: name, root: uint32(root)}
+		t.cols, t.rowid, t.err = columns(sql)
+		if t.err != nil {
+			t.err = fmt.Errorf("sqlite: table %s: %w", name, t.err)
+		}
+		db.tables = append(db.tables, t)
+		return true
+	})
+}
+
+// Tables returns the names of the tables, in the order of the schema.
+func (db *DB) Tables() []string {
+	names := make([]string, len(db.tables))
+	for i, t := range db.tables {
+		names[i] = t.name
+	}
+	return names
+}
+
+func (db *DB) table(name string) (*table, error) {
+	for _, t := range db.tables {
+		if t.name == name {
+			return t, t.err
+		}
+	}
+	return nil, fmt.Errorf("sqlite: no such table: %s", name)
+}
+
+// Table returns a NodeSet of the rows of the table, in the order of rowid.
+// Each row is a node named by the table, with the columns as attributes.
+// Rows are read lazily while iterating, so large tables are streamed.
+func (db *DB) Table(name string) NodeSet {
+	t, err := db.table(name)
+	if err != nil {
+		return NodeSet{Err: err}
+	}
+	return NodeSet{
+		Data: func(yield func(Node) bool) {
+			err := db.file.rows(t.root, func(rowid int64, rec []any) bool {
+				return yield(Node{Name: t.name, Value: t.row(rowid, rec)})
+			})
+			if err != nil && db.OnError != nil {
+				db.OnError(err)
+			}
+		},
+	}
+}
+
+func (t *table) row(rowid int64, rec []any) map[string]any {
+	row := make(map[string]any, len(t.cols))
+	for i, col := range t.cols {
+		var v any
+		if i == t.rowid {
+			v = rowid
+		} else if i < len(rec) {
+			v = rec[i] // columns added by ALTER TABLE may be missing in old rows
+		}
+		row[col] = v
+	}
+	return row
+}
+
+// Doc returns the document of the database: a map from the table names to
+// the rows ([]any) of the tables. All the rows are loaded into memory, so use
+// Table to stream a large table.
+func (db *DB) Doc() (map[string]any, error) {
+	doc := make(map[string]any, len(db.tables))
+	for _, t := range db.tables {
+		if t.err != nil {
+			continue // unsupported tables are omitted
+		}
+		rows := []any{}
+		err := db.file.rows(t.root, func(rowid int64, rec []any) bool {
+			rows = append(rows, t.row(rowid, rec))
+			return true
+		})
+		if err != nil {
+			return nil, err
+		}
+		doc[t.name] = rows
+	}
+	return doc, nil
+}
+
+// Source creates an SQLite NodeSet from various source types:
+// - string: treats the string as the path of a database file, and loads it.
+// - *DB: loads the opened database.
+// - Node: creates a NodeSet containing the single provided node.
+// - iter.Seq[Node]: directly uses the provided sequence of nodes.
+// - NodeSet: returns the provided NodeSet as is.
+// If the source type is unsupported, it panics.
+//
+// A database is loaded as a root node whose children are the tables, and the
+// children of a table are its rows (see DB.Doc):
+//
+//	db := sqlite.source("app.db")
+//	for u in db.users.* {
+//		echo u.$id, u.$name
+//	}
+func Source(r any) (ret NodeSet) {
+	switch v := r.(type) {
+	case string:
+		db, err := Open(v)
+		if err != nil {
+			return NodeSet{Err: err}
+		}
+		defer db.Close()
+		return Source(db)
+	case *DB:
+		doc, err := v.Doc()
+		if err != nil {
+			return NodeSet{Err: err}
+		}
+		return maps.New(doc)
+	case Node:
+		return maps.Root(v)
+	case iter.Seq[Node]:
+		return NodeSet{Data: v}
+	case NodeSet:
+		return v
+	default:
+		panic("dql/sqlite.Source: unsupported source type")
+	}
+}
+
+// -----------------------------------------------------------------------------
+
+// columns parses the column names of a CREATE TABLE statement, and returns
+// the index of the INTEGER PRIMARY KEY column (or -1).
+func columns(sql string) (cols []string, rowid int, err error) {
+	rowid = -1
+	sql = stripComments(sql)
+	start := strings.IndexByte(sql, '(')
+	if start < 0 || !strings.HasPrefix(strings.ToUpper(sql), "CREATE TABLE") {
+		return nil, -1, fmt.Errorf("unsupported schema: %s", sql)
+	}
+	defs, tail := splitDefs(sql[start+1:])
+	if strings.Contains(strings.ToUpper(tail), "WITHOUT ROWID") {
+		return nil, -1, fmt.Errorf("WITHOUT ROWID tables are unsupported")
+	}
+	var pk string
+	var types []string
+	for _, def := range defs {
+		name, rest := ident(def)
+		if !isQuoted(def) {
+			switch strings.ToUpper(name) {
+			case "CONSTRAINT", "PRIMARY", "UNIQUE", "CHECK", "FOREIGN": // table constraints
+				if i := strings.Index(strings.ToUpper(def), "PRIMARY KEY"); i >= 0 {
+					keys := strings.TrimSpace(def[i+len("PRIMARY KEY"):])
+					if list, _ := splitDefs(strings.TrimPrefix(keys, "(")); len(list) == 1 {
+						pk, _ = ident(list[0])
+					}
+				}
+				continue
+			}
+		}
+		typ, _ := ident(rest)
+		cols = append(cols, name)
+		types = append(types, strings.ToUpper(typ))
+		if upper := strings.ToUpper(rest); types[len(types)-1] == "INTEGER" &&
+			strings.Contains(upper, "PRIMARY KEY") && !strings.Contains(upper, "DESC") {
+			rowid = len(cols) - 1
+		}
+	}
+	for i, col := range cols {
+		if strings.EqualFold(col, pk) && types[i] == "INTEGER" {
+			rowid = i
+		}
+	}
+	return
+}
+
+// splitDefs splits the definitions in parentheses by commas, until the
+// closing parenthesis, and returns the text after it.
+func splitDefs(s string) (defs []string, tail string) {
+	depth, start := 0, 0
+	for i := 0; i < len(s); i++ {
+		switch c := s[i]; c {
+		case '\'', '"', '`', '[':
+			end := byte(c)
+			if c == '[' {
+				end = ']'
+			}
+			if j := strings.IndexByte(s[i+1:], end); j >= 0 {
+				i += j + 1
+			}
+		case '(':
+			depth++
+		case ')':
+			if depth == 0 {
+				return append(defs, strings.TrimSpace(s[start:i])), s[i+1:]
+			}
+			depth--
+		case ',':
+			if depth == 0 {
+				defs = append(defs, strings.TrimSpace(s[start:i]))
+				start = i + 1
+			}
+		}
+	}
+	return append(defs, strings.TrimSpace(s[start:])), ""
+}
+
+// stripComments removes the comments (-- and /* */) of a SQL statement.
+func stripComments(sql string) string {
+	if !strings.Contains(sql, "--") && !strings.Contains(sql, "/*") {
+		return sql
+	}
+	var b strings.Builder
+	for i := 0; i < len(sql); i++ {
+		switch c := sql[i]; {
+		case c == '\'' || c == '"' || c == '`' || c == '[':
+			end := c
+			if c == '[' {
+				end = ']'
+			}
+			j := strings.IndexByte(sql[i+1:], end)
+			if j < 0 {
+				b.WriteString(sql[i:])
+				return b.String()
+			}
+			b.WriteString(sql[i : i+j+2])
+			i += j + 1
+		case strings.HasPrefix(sql[i:], "--"):
+			j := strings.IndexByte(sql[i:], '\n')
+			if j < 0 {
+				return b.String()
+			}
+			i += j - 1
+		case strings.HasPrefix(sql[i:], "/*"):
+			j := strings.Index(sql[i+2:], "*/")
+			if j < 0 {
+				return b.String()
+			}
+			b.WriteByte(' ')
+			i += j + 3
+		default:
+			b.WriteByte(c)
+		}
+	}
+	return b.String()
+}
+
+// ident returns the first identifier (which may be quoted) of s, and the rest.
+func ident(s string) (name, rest string) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return "", ""
+	}
+	if end := map[byte]byte{'"': '"', '`': '`', '[': ']', '\'': '\''}[s[0]]; end != 0 {
+		if j := strings.IndexByte(s[1:], end); j >= 0 {
+			return s[1 : j+1], s[j+2:]
+		}
+	}
+	i := strings.IndexFunc(s, func(r rune) bool {
+		return r == ' ' || r == '\t' || r == '\n' || r == '\r' || r == '('
+	})
+	if i < 0 {
+		return s, ""
+	}
+	return s[:i], s[i:]
+}
+
+func isQuoted(s string) bool {
+	return s != "" && strings.ContainsRune("\"`['", rune(s[0]))
+}
+
+// -----------------------------------------------------------------------------
@@ -0,0 +1,121 @@
+/*
+ * Copyright (c) 2026 The XGo Authors (xgo.dev). All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package sqlite
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/goplus/xgo/dql"
+)
+
+// testdata/app.db is created by Python's sqlite3 module with a page size of
+// 512 bytes, so the tables have interior pages and overflow pages.
+
+func TestTable(t *testing.T) {
+	db, err := Open("testdata/app.db")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+	if tables := db.Tables(); !slices.Equal(tables, []string{"users", "posts", "kv"}) {
+		t.Fatal("Tables:", tables)
+	}
+
+	users := dql.Collect(db.Table("users").Data)
+	if len(users) != 301 {
+		t.Fatal("users:", len(users))
+	}
+	u3 := users[2].Value.(map[string]any)
+	if u3["id"] != int64(3) || u3["full name"] != "user 3" || u3["age"] != int64(23) ||
+		u3["score"] != 0.75 || string(u3["avatar"].([]byte)) != "\x03\x01\x02" {
+		t.Fatal("user 3:", u3)
+	}
+	big := users[300].Value.(map[string]any)
+	if big["id"] != int64(1000000) || big["age"] != int64(-5) || big["score"] != -1.5 || big["avatar"] != nil {
+		t.Fatal("big:", big)
+	}
+
+	posts := dql.Collect(db.Table("posts").Data)
+	if len(posts) != 3 {
+		t.Fatal("posts:", len(posts))
+	}
+	p1, p2, p3 := posts[0].Value.(map[string]any), posts[1].Value.(map[string]any), posts[2].Value.(map[string]any)
+	if p1["pid"] != int64(10) || p1["body"] != strings.Repeat("x", 3000) {
+		t.Fatal("overflow:", p1["pid"], len(p1["body"].(string)))
+	}
+	if p2["body"] != "héllo" || p2["tag"] != nil || p3["tag"] != "go" {
+		t.Fatal("posts:", p2, p3)
+	}
+
+	if ns := db.Table("kv"); ns.Err == nil {
+		t.Fatal("WITHOUT ROWID: no error")
+	}
+	if ns := db.Table("none"); ns.Err == nil {
+		t.Fatal("no such table: no error")
+	}
+}
+
+func TestSource(t *testing.T) {
+	doc := Source("testdata/app.db")
+	var names []string
+	for u := range doc.XGo_Elem("users").XGo_Child().XGo_Enum() {
+		if age, _ := u.XGo_Elem("age").Int(); age == 69 {
+			names = append(names, u.XGo_Attr__0("full name").(string))
+		}
+	}
+	if strings.Join(names, ",") != "user 49,user 99,user 149,user 199,user 249,user 299" {
+		t.Fatal("names:", names)
+	}
+	if _, err := doc.XGo_Elem("kv").XGo_first(); err != dql.ErrNotFound {
+		t.Fatal("kv should be omitted:", err)
+	}
+
+	file := filepath.Join(t.TempDir(), "bad.db")
+	os.WriteFile(file, []byte("not a database"), 0644)
+	if ns := Source(file); !errors.Is(ns.Err, ErrNotDatabase) {
+		t.Fatal("Source bad.db:", ns.Err)
+	}
+}
+
+func TestColumns(t *testing.T) {
+	cases := []struct {
+		sql   string
+		cols  string
+		rowid int
+	}{
+		{"CREATE TABLE t(a, b)", "a,b", -1},
+		{"CREATE TABLE t(a INT PRIMARY KEY, b)", "a,b", -1},
+		{"CREATE TABLE t(a integer primary key desc, b)", "a,b", -1},
+		{"CREATE TABLE t(`a b` TEXT, [c] INTEGER, PRIMARY KEY(c))", "a b,c", 1},
+		{"CREATE TABLE t(a DECIMAL(10, 2), /* x, y */ b INTEGER NOT NULL PRIMARY KEY)", "a,b", 1},
+		{"CREATE TABLE t('primary' INT, CHECK (primary > 0))", "primary", -1},
+	}
+	for _, c := range cases {
+		cols, rowid, err := columns(c.sql)
+		if err != nil || strings.Join(cols, ",") != c.cols || rowid != c.rowid {
+			t.Errorf("%s: %v %d %v", c.sql, cols, rowid, err)
+		}
+	}
+	if _, _, err := columns("CREATE VIRTUAL TABLE t USING fts5(a)"); err == nil {
+		t.Error("virtual table: no error")
+	}
+}
@@ -0,0 +1,183 @@
+/*
+ * Copyright (c) 2026 The XGo Authors (xgo.dev). All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package stream
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// -----------------------------------------------------------------------------
+
+// Cache is an on-disk cache of HTTP responses, so repeated runs of a script
+// don't request the same resources again and again. A cached response is used
+// directly within TTL, and after that, it's revalidated by a conditional
+// request with its validators (ETag and Last-Modified).
+//
+// Only GET requests without a body are cached.
+type Cache struct {
+	Dir string        // directory of the cached responses
+	TTL time.Duration // time to use a cached response without revalidation
+}
+
+// DefaultCache is the cache used by Open and requests without a Cache. It's
+// nil by default, which disables caching:
+//
+//	stream.DefaultCache = stream.newCache("", 10*time.Minute)
+var DefaultCache *Cache
+
+// NewCache creates a cache in the directory dir. If dir is "", a directory in
+// the user cache directory (see os.UserCacheDir) is used.
+func NewCache(dir string, ttl time.Duration) *Cache {
+	if dir == "" {
+		if root, err := os.UserCacheDir(); err == nil {
+			dir = filepath.Join(root, "xgo", "dql-stream")
+		} else {
+			dir = filepath.Join(os.TempDir(), "xgo-dql-stream")
+		}
+	}
+	return &Cache{Dir: dir, TTL: ttl}
+}
+
+// Clear removes all the cached responses.
+func (c *Cache) Clear() error {
+	return os.RemoveAll(c.Dir)
+}
+
+// cacheEntry is the metadata of a cached response.
+type cacheEntry struct {
+	URL             string    `json:"url"`
+	ETag            string    `json:"etag,omitempty"`
+	LastModified    string    `json:"lastModified,omitempty"`
+	ContentEncoding string    `json:"contentEncoding,omitempty"`
+	Time            time.Time `json:"time"` // time of the last validation
+
+	key string
+}
+
+func cacheKey(url string) string {
+	h := sha256.Sum256([]byte(url))
+	return hex.EncodeToString(h[:16])
+}
+
+func (c *Cache) path(key, ext string) string {
+	return filepath.Join(c.Dir, key+ext)
+}
+
+// load returns the cached response of url, or nil if it isn't cached.
+func (c *Cache) load(url string) *cacheEntry {
+	key := cacheKey(url)
+	b, err := os.ReadFile(c.path(key, ".json"))
+	if err != nil {
+		return nil
+	}
+	entry := &cacheEntry{key: key}
+	if json.Unmarshal(b, entry) != nil || entry.URL != url {
+		return nil
+	}
+	if _, err = os.Stat(c.path(key, ".body")); err != nil {
+		return nil
+	}
+	return entry
+}
+
+// open opens the body of the cached response.
+func (c *Cache) open(entry *cacheEntry) (io.ReadCloser, error) {
+	return os.Open(c.path(entry.key, ".body"))
+}
+
+// touch updates the validation time of the cached response.
+func (c *Cache) touch(entry *cacheEntry) {
+	entry.Time = time.Now()
+	c.saveEntry(entry)
+}
+
+func (c *Cache) saveEntry(entry *cacheEntry) error {
+	b, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+	return writeFile(c.path(entry.key, ".json"), func(w io.Writer) error {
+		_, err := w.Write(b)
+		return err
+	})
+}
+
+// store saves the response of url to the cache, and closes its body.
+func (c *Cache) store(url string, resp *http.Response) (entry *cacheEntry, err error) {
+	defer resp.Body.Close()
+	if err = os.MkdirAll(c.Dir, 0755); err != nil {
+		return
+	}
+	entry = &cacheEntry{
+		URL:          url,
+		ETag:         resp.Header.Get("ETag"),
+		LastModified: resp.Header.Get("Last-Modified"),
+		Time:         time.Now(),
+		key:          cacheKey(url),
+	}
+	if !resp.Uncompressed {
+		entry.ContentEncoding = resp.Header.Get("Content-Encoding")
+	}
+	err = writeFile(c.path(entry.key, ".body"), func(w io.Writer) error {
+		_, err := io.Copy(w, resp.Body)
+		return err
+	})
+	if err == nil {
+		err = c.saveEntry(entry)
+	}
+	return
+}
+
+// writeFile writes a file atomically by renaming a temporary file.
+func writeFile(name string, write func(w io.Writer) error) (err error) {
+	f, err := os.CreateTemp(filepath.Dir(name), "*.tmp~")
+	if err != nil {
+		return
+	}
+	tmp := f.Name()
+	err = write(f)
+	if e := f.Close(); err == nil {
+		err = e
+	}
+	if err == nil {
+		err = os.Rename(tmp, name)
+	}
+	if err != nil {
+		os.Remove(tmp)
+	}
+	return
+}
+
+// cache returns the cache of the request, or nil if it isn't cacheable.
+func (r *Request) cache() *Cache {
+	if r.NoCache || r.method() != http.MethodGet || r.Body != nil {
+		return nil
+	}
+	if r.Cache != nil {
+		return r.Cache
+	}
+	return DefaultCache
+}
+
+// -----------------------------------------------------------------------------
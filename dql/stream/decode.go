@@ -0,0 +1,125 @@
+/*
+ * Copyright (c) 2026 The XGo Authors (xgo.dev). All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package stream
+
+import (
+	"compress/bzip2"
+	"compress/gzip"
+	"compress/zlib"
+	"fmt"
+	"io"
+	"net/url"
+	"path"
+	"strings"
+)
+
+// -----------------------------------------------------------------------------
+
+// Decoder creates a reader decompressing the data read from r.
+type Decoder = func(r io.Reader) (io.ReadCloser, error)
+
+var decoders = map[string]Decoder{
+	"gzip": func(r io.Reader) (io.ReadCloser, error) {
+		return gzip.NewReader(r)
+	},
+	"deflate": zlib.NewReader,
+	"bzip2": func(r io.Reader) (io.ReadCloser, error) {
+		return io.NopCloser(bzip2.NewReader(r)), nil
+	},
+}
+
+// extCodings maps file extensions to the codings of compressed files.
+var extCodings = map[string]string{
+	".gz":  "gzip",
+	".bz2": "bzip2",
+	".br":  "br",
+	".zst": "zstd",
+}
+
+// RegisterDecoder registers a decoder of the specified coding, which is a
+// content coding of HTTP (like "br" or "zstd"). The gzip, deflate and bzip2
+// codings are supported by default, other codings like br and zstd need a
+// decoder from a third-party package:
+//
+//	stream.RegisterDecoder("zstd", func(r io.Reader) (io.ReadCloser, error) {
+//		d, err := zstd.NewReader(r)
+//		return d.IOReadCloser(), err
+//	})
+func RegisterDecoder(coding string, dec Decoder) {
+	decoders[coding] = dec
+}
+
+// acceptEncoding returns the value of the Accept-Encoding header, listing the
+// content codings which can be decoded.
+func acceptEncoding() string {
+	var codings []string
+	for _, coding := range [...]string{"gzip", "deflate", "br", "zstd"} {
+		if decoders[coding] != nil {
+			codings = append(codings, coding)
+		}
+	}
+	return strings.Join(codings, ", ")
+}
+
+// codingOf returns the coding of the compressed file of uri by its extension,
+// or "" if it isn't compressed.
+func codingOf(uri string) string {
+	if schemeOf(uri) != "" {
+		if u, err := url.Parse(uri); err == nil {
+			uri = u.Path
+		}
+	}
+	return extCodings[path.Ext(uri)]
+}
+
+// decode returns a reader decompressing rc with the specified coding. If
+// coding is "" or "identity", rc is returned as is.
+func decode(rc io.ReadCloser, coding, uri string) (io.ReadCloser, error) {
+	switch coding = strings.ToLower(strings.TrimSpace(coding)); coding {
+	case "", "identity":
+		return rc, nil
+	case "x-gzip":
+		coding = "gzip"
+	}
+	dec, ok := decoders[coding]
+	if !ok {
+		rc.Close()
+		return nil, fmt.Errorf("stream: %s: no decoder of %s, see RegisterDecoder", uri, coding)
+	}
+	d, err := dec(rc)
+	if err != nil {
+		rc.Close()
+		return nil, fmt.Errorf("stream: %s: %w", uri, err)
+	}
+	return &decodedBody{d, rc}, nil
+}
+
+// decodedBody closes both the decoder and the underlying reader.
+type decodedBody struct {
+	io.ReadCloser
+	src io.Closer
+}
+
+func (p *decodedBody) Close() error {
+	err := p.ReadCloser.Close()
+	if e := p.src.Close(); err == nil {
+		err = e
+	}
+	return err
+}
+
+// -----------------------------------------------------------------------------
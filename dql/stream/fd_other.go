@@ -0,0 +1,29 @@
+//go:build !unix
+
+/*
+ * Copyright (c) 2026 The XGo Authors (xgo.dev). All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package stream
+
+import (
+	"errors"
+	"os"
+)
+
+// openFd opens the file descriptor fd. It's not supported on this system.
+func openFd(fd int, name string) (*os.File, error) {
+	return nil, &os.PathError{Op: "stream.Open", Path: name, Err: errors.ErrUnsupported}
+}
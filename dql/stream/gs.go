@@ -0,0 +1,190 @@
+/*
+ * Copyright (c) 2026 The XGo Authors (xgo.dev). All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package stream
+
+import (
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"sync"
+	"time"
+)
+
+// -----------------------------------------------------------------------------
+
+const gcsReadOnlyScope = "https://www.googleapis.com/auth/devstorage.read_only"
+
+// OpenGS opens an object of Google Cloud Storage by the URL gs://bucket/key.
+// The access token is read from the environment variable
+// GOOGLE_OAUTH_ACCESS_TOKEN, or obtained by the service account key file
+// specified by GOOGLE_APPLICATION_CREDENTIALS, and the object is requested
+// anonymously if there is no credential. STORAGE_EMULATOR_HOST specifies the
+// host of a storage emulator.
+func OpenGS(uri string) (io.ReadCloser, error) {
+	bucket, key, err := splitBucket(uri, "gs://")
+	if err != nil {
+		return nil, err
+	}
+	endpoint := "https://storage.googleapis.com"
+	if host := os.Getenv("STORAGE_EMULATOR_HOST"); host != "" {
+		endpoint = host
+		if !strings.Contains(host, "://") {
+			endpoint = "http://" + host
+		}
+	}
+	req := &Request{
+		URL:    endpoint + "/storage/v1/b/" + url.PathEscape(bucket) + "/o/" + url.PathEscape(key) + "?alt=media",
+		Header: make(http.Header),
+		Raw:    true,
+	}
+	token, err := gcsToken()
+	if err != nil {
+		return nil, err
+	}
+	if token != "" {
+		req.SetBearer(token)
+	}
+	return req.Open()
+}
+
+var gcsTokens struct {
+	sync.Mutex
+	file   string
+	token  string
+	expiry time.Time
+}
+
+// gcsToken returns the access token of Google Cloud Storage, or "" if there
+// is no credential.
+func gcsToken() (string, error) {
+	if token := os.Getenv("GOOGLE_OAUTH_ACCESS_TOKEN"); token != "" {
+		return token, nil
+	}
+	file := os.Getenv("GOOGLE_APPLICATION_CREDENTIALS")
+	if file == "" {
+		return "", nil
+	}
+	gcsTokens.Lock()
+	defer gcsTokens.Unlock()
+	if gcsTokens.file == file && time.Until(gcsTokens.expiry) > time.Minute {
+		return gcsTokens.token, nil
+	}
+	token, expiry, err := serviceAccountToken(file, gcsReadOnlyScope)
+	if err != nil {
+		return "", err
+	}
+	gcsTokens.file, gcsTokens.token, gcsTokens.expiry = file, token, expiry
+	return token, nil
+}
+
+// serviceAccountToken obtains an access token by the service account key
+// file, with the OAuth 2.0 JWT bearer grant.
+func serviceAccountToken(file, scope string) (token string, expiry time.Time, err error) {
+	b, err := os.ReadFile(file)
+	if err != nil {
+		return
+	}
+	var key struct {
+		Type        string `json:"type"`
+		ClientEmail string `json:"client_email"`
+		PrivateKey  string `json:"private_key"`
+		TokenURI    string `json:"token_uri"`
+	}
+	if err = json.Unmarshal(b, &key); err != nil {
+		return
+	}
+	if key.Type != "service_account" {
+		err = fmt.Errorf("stream: %s: unsupported credential type %q", file, key.Type)
+		return
+	}
+	if key.TokenURI == "" {
+		key.TokenURI = "https://oauth2.googleapis.com/token"
+	}
+	pk, err := parsePrivateKey(key.PrivateKey)
+	if err != nil {
+		return
+	}
+	now := time.Now()
+	claims, _ := json.Marshal(map[string]any{
+		"iss":   key.ClientEmail,
+		"scope": scope,
+		"aud":   key.TokenURI,
+		"iat":   now.Unix(),
+		"exp":   now.Add(time.Hour).Unix(),
+	})
+	enc := base64.RawURLEncoding
+	unsigned := enc.EncodeToString([]byte(`{"alg":"RS256","typ":"JWT"}`)) + "." + enc.EncodeToString(claims)
+	hash := sha256.Sum256([]byte(unsigned))
+	sig, err := rsa.SignPKCS1v15(rand.Reader, pk, crypto.SHA256, hash[:])
+	if err != nil {
+		return
+	}
+	form := url.Values{
+		"grant_type": {"urn:ietf:params:oauth:grant-type:jwt-bearer"},
+		"assertion":  {unsigned + "." + enc.EncodeToString(sig)},
+	}
+	resp, err := http.PostForm(key.TokenURI, form)
+	if err != nil {
+		return
+	}
+	defer resp.Body.Close()
+	var ret struct {
+		AccessToken string `json:"access_token"`
+		ExpiresIn   int    `json:"expires_in"`
+	}
+	if resp.StatusCode/100 != 2 {
+		err = &StatusError{Method: http.MethodPost, URL: key.TokenURI, StatusCode: resp.StatusCode, Status: resp.Status}
+		return
+	}
+	if err = json.NewDecoder(resp.Body).Decode(&ret); err != nil {
+		return
+	}
+	return ret.AccessToken, now.Add(time.Duration(ret.ExpiresIn) * time.Second), nil
+}
+
+func parsePrivateKey(s string) (*rsa.PrivateKey, error) {
+	block, _ := pem.Decode([]byte(s))
+	if block == nil {
+		return nil, errors.New("stream: invalid private key")
+	}
+	if key, err := x509.ParsePKCS1PrivateKey(block.Bytes); err == nil {
+		return key, nil
+	}
+	key, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, err
+	}
+	pk, ok := key.(*rsa.PrivateKey)
+	if !ok {
+		return nil, errors.New("stream: private key is not a RSA key")
+	}
+	return pk, nil
+}
+
+// -----------------------------------------------------------------------------
@@ -0,0 +1,293 @@
+/*
+ * Copyright (c) 2026 The XGo Authors (xgo.dev). All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package stream
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// -----------------------------------------------------------------------------
+
+// Request describes how to open a resource, with the options of HTTP(S)
+// requests. It can be used as the source of DQL documents:
+//
+//	req := stream.newRequest("https://api.example.com/items").setBearer(token)
+//	for item in json.source(req).items.* {
+//		echo item.$name
+//	}
+type Request struct {
+	Method  string        // HTTP method, GET by default
+	URL     string        // URL of the resource
+	Header  http.Header   // HTTP request header
+	Body    []byte        // HTTP request body
+	Timeout time.Duration // timeout of each attempt, including reading the body
+	Retry   RetryPolicy   // retry policy of failed requests
+	Client  *http.Client  // HTTP client, http.DefaultClient by default
+	Raw     bool          // don't decompress the resource
+	Cache   *Cache        // cache of responses, DefaultCache by default
+	NoCache bool          // bypass the cache
+}
+
+// RetryPolicy specifies how a failed HTTP request is retried. A request is
+// retried on network errors and on responses with status 429 (Too Many
+// Requests) or 5xx.
+type RetryPolicy struct {
+	Max  int           // maximum number of retries, 0 means no retry
+	Wait time.Duration // wait before the first retry, doubled for each retry
+}
+
+// StatusError is returned when an HTTP request fails with a non-2xx status.
+type StatusError struct {
+	Method     string
+	URL        string
+	StatusCode int
+	Status     string
+}
+
+func (e *StatusError) Error() string {
+	return fmt.Sprintf("stream: %s %s: %s", e.Method, e.URL, e.Status)
+}
+
+// NewRequest creates a GET request of the specified URL.
+func NewRequest(url string) *Request {
+	return &Request{Method: http.MethodGet, URL: url, Header: make(http.Header)}
+}
+
+// SetHeader sets the header key to value, and returns the request.
+func (r *Request) SetHeader(key, value string) *Request {
+	if r.Header == nil {
+		r.Header = make(http.Header)
+	}
+	r.Header.Set(key, value)
+	return r
+}
+
+// SetBasicAuth sets the basic authentication of the request, and returns the
+// request.
+func (r *Request) SetBasicAuth(username, password string) *Request {
+	req := http.Request{Header: r.Header}
+	if req.Header == nil {
+		req.Header = make(http.Header)
+	}
+	req.SetBasicAuth(username, password)
+	r.Header = req.Header
+	return r
+}
+
+// SetBearer sets the bearer token of the request, and returns the request.
+func (r *Request) SetBearer(token string) *Request {
+	return r.SetHeader("Authorization", "Bearer "+token)
+}
+
+// -----------------------------------------------------------------------------
+
+const defaultRetryWait = 500 * time.Millisecond
+
+// Open opens the resource of the request. HTTP(S) resources are requested
+// with the options of the request, and other resources are opened by Open,
+// in which case only the URL can be specified.
+//
+// Unless Raw is set, compressed responses (by their Content-Encoding) and
+// compressed files (by their extensions) are decompressed transparently.
+func (r *Request) Open() (io.ReadCloser, error) {
+	switch schemeOf(r.URL) {
+	case "http", "https":
+		return r.do()
+	}
+	if (r.Method != "" && r.Method != http.MethodGet) || len(r.Header) > 0 || r.Body != nil {
+		return nil, fmt.Errorf("stream: %s: HTTP options on a non-HTTP resource", r.URL)
+	}
+	return open(r.URL, r.Raw)
+}
+
+// Do sends the HTTP(S) request, and returns the response, whose body is
+// decompressed unless Raw is set. Unlike Open, the cache isn't used, so the
+// header of the response is always available.
+func (r *Request) Do() (*http.Response, error) {
+	switch schemeOf(r.URL) {
+	case "http", "https":
+	default:
+		return nil, fmt.Errorf("stream: %s: not an HTTP resource", r.URL)
+	}
+	resp, err := r.send(nil)
+	if err != nil {
+		return nil, err
+	}
+	coding := resp.Header.Get("Content-Encoding")
+	if resp.Uncompressed {
+		coding = ""
+	}
+	if resp.Body, err = r.decode(resp.Body, coding); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+func (r *Request) do() (io.ReadCloser, error) {
+	c := r.cache()
+	var entry *cacheEntry
+	if c != nil {
+		if entry = c.load(r.URL); entry != nil && time.Since(entry.Time) < c.TTL {
+			return r.decodeCached(c, entry) // fresh, no need to revalidate
+		}
+	}
+	resp, err := r.send(entry)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode == http.StatusNotModified {
+		resp.Body.Close()
+		c.touch(entry)
+		return r.decodeCached(c, entry)
+	}
+	if c != nil {
+		if entry, err = c.store(r.URL, resp); err != nil {
+			return nil, err
+		}
+		return r.decodeCached(c, entry)
+	}
+	coding := resp.Header.Get("Content-Encoding")
+	if resp.Uncompressed {
+		coding = ""
+	}
+	return r.decode(resp.Body, coding)
+}
+
+// decode decompresses body with the specified content coding, or the coding
+// of the URL extension if it's "".
+func (r *Request) decode(body io.ReadCloser, coding string) (io.ReadCloser, error) {
+	if r.Raw {
+		return body, nil
+	}
+	if coding == "" {
+		coding = codingOf(r.URL)
+	}
+	return decode(body, coding, r.URL)
+}
+
+func (r *Request) decodeCached(c *Cache, entry *cacheEntry) (io.ReadCloser, error) {
+	body, err := c.open(entry)
+	if err != nil {
+		return nil, err
+	}
+	return r.decode(body, entry.ContentEncoding)
+}
+
+// send sends the request, and retries it according to the retry policy. If
+// entry isn't nil, the request is conditional on the validators of the
+// cached response, and a response with status 304 (Not Modified) may be
+// returned.
+func (r *Request) send(entry *cacheEntry) (resp *http.Response, err error) {
+	wait := r.Retry.Wait
+	if wait <= 0 {
+		wait = defaultRetryWait
+	}
+	for i := 0; ; i++ {
+		var retryAfter time.Duration
+		resp, retryAfter, err = r.once(entry)
+		if err == nil || retryAfter < 0 || i >= r.Retry.Max {
+			return
+		}
+		if retryAfter == 0 {
+			retryAfter = wait
+		}
+		time.Sleep(retryAfter)
+		wait *= 2
+	}
+}
+
+// once sends the request once. If it fails, retryAfter is the time to wait
+// before a retry (0 for the default), or -1 if it shouldn't be retried.
+func (r *Request) once(entry *cacheEntry) (resp *http.Response, retryAfter time.Duration, err error) {
+	method := r.method()
+	ctx, cancel := context.Background(), context.CancelFunc(func() {})
+	if r.Timeout > 0 {
+		ctx, cancel = context.WithTimeout(ctx, r.Timeout)
+	}
+	var in io.Reader
+	if r.Body != nil {
+		in = bytes.NewReader(r.Body)
+	}
+	req, err := http.NewRequestWithContext(ctx, method, r.URL, in)
+	if err != nil {
+		cancel()
+		return nil, -1, err
+	}
+	for k, v := range r.Header {
+		req.Header[k] = v
+	}
+	if !r.Raw && req.Header.Get("Accept-Encoding") == "" {
+		req.Header.Set("Accept-Encoding", acceptEncoding())
+	}
+	if entry != nil {
+		if entry.ETag != "" {
+			req.Header.Set("If-None-Match", entry.ETag)
+		}
+		if entry.LastModified != "" {
+			req.Header.Set("If-Modified-Since", entry.LastModified)
+		}
+	}
+	client := r.Client
+	if client == nil {
+		client = http.DefaultClient
+	}
+	if resp, err = client.Do(req); err != nil {
+		cancel()
+		return nil, 0, err
+	}
+	if resp.StatusCode/100 != 2 && (entry == nil || resp.StatusCode != http.StatusNotModified) {
+		resp.Body.Close()
+		cancel()
+		retryAfter = -1
+		if resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode/100 == 5 {
+			retryAfter = 0
+			if secs, e := strconv.Atoi(resp.Header.Get("Retry-After")); e == nil && secs >= 0 {
+				retryAfter = time.Duration(secs) * time.Second
+			}
+		}
+		return nil, retryAfter, &StatusError{Method: method, URL: r.URL, StatusCode: resp.StatusCode, Status: resp.Status}
+	}
+	resp.Body = &cancelBody{resp.Body, cancel}
+	return resp, 0, nil
+}
+
+func (r *Request) method() string {
+	if r.Method == "" {
+		return http.MethodGet
+	}
+	return r.Method
+}
+
+// cancelBody cancels the context of a request when its body is closed.
+type cancelBody struct {
+	io.ReadCloser
+	cancel context.CancelFunc
+}
+
+func (p *cancelBody) Close() error {
+	err := p.ReadCloser.Close()
+	p.cancel()
+	return err
+}
+
+// -----------------------------------------------------------------------------
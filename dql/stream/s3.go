@@ -0,0 +1,162 @@
+/*
+ * Copyright (c) 2026 The XGo Authors (xgo.dev). All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package stream
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"sort"
+	"strings"
+	"time"
+)
+
+// -----------------------------------------------------------------------------
+
+// OpenS3 opens an object of Amazon S3 (or a S3 compatible storage) by the URL
+// s3://bucket/key. The credentials and the region are read from the standard
+// environment variables AWS_ACCESS_KEY_ID, AWS_SECRET_ACCESS_KEY,
+// AWS_SESSION_TOKEN and AWS_REGION (or AWS_DEFAULT_REGION), and the object is
+// requested anonymously if there is no credential. AWS_ENDPOINT_URL_S3 (or
+// AWS_ENDPOINT_URL) specifies the endpoint of a S3 compatible storage, which
+// is accessed with path-style URLs.
+func OpenS3(uri string) (io.ReadCloser, error) {
+	bucket, key, err := splitBucket(uri, "s3://")
+	if err != nil {
+		return nil, err
+	}
+	region := getenv("AWS_REGION", "AWS_DEFAULT_REGION")
+	if region == "" {
+		region = "us-east-1"
+	}
+	var objURL string
+	if endpoint := getenv("AWS_ENDPOINT_URL_S3", "AWS_ENDPOINT_URL"); endpoint != "" {
+		objURL = strings.TrimSuffix(endpoint, "/") + "/" + bucket + "/" + escapePath(key)
+	} else {
+		objURL = "https://" + bucket + ".s3." + region + ".amazonaws.com/" + escapePath(key)
+	}
+	req := &Request{URL: objURL, Header: make(http.Header), Raw: true}
+	if id, secret := os.Getenv("AWS_ACCESS_KEY_ID"), os.Getenv("AWS_SECRET_ACCESS_KEY"); id != "" && secret != "" {
+		signV4(req, id, secret, os.Getenv("AWS_SESSION_TOKEN"), region, time.Now())
+	}
+	return req.Open()
+}
+
+const unsignedPayload = "UNSIGNED-PAYLOAD"
+
+// signV4 signs the S3 GET request with AWS Signature Version 4.
+func signV4(req *Request, id, secret, token, region string, now time.Time) {
+	u, _ := url.Parse(req.URL)
+	now = now.UTC()
+	amzDate := now.Format("20060102T150405Z")
+	date := amzDate[:8]
+	req.Header.Set("X-Amz-Date", amzDate)
+	payloadHash := req.Header.Get("X-Amz-Content-Sha256")
+	if payloadHash == "" {
+		payloadHash = unsignedPayload
+		req.Header.Set("X-Amz-Content-Sha256", payloadHash)
+	}
+	if token != "" {
+		req.Header.Set("X-Amz-Security-Token", token)
+	}
+
+	headers := map[string]string{"host": u.Host}
+	for k, v := range req.Header {
+		if k := strings.ToLower(k); k == "range" || strings.HasPrefix(k, "x-amz-") {
+			headers[k] = strings.TrimSpace(strings.Join(v, ","))
+		}
+	}
+	names := make([]string, 0, len(headers))
+	for k := range headers {
+		names = append(names, k)
+	}
+	sort.Strings(names)
+	var canonHeaders strings.Builder
+	for _, k := range names {
+		canonHeaders.WriteString(k + ":" + headers[k] + "\n")
+	}
+	signedHeaders := strings.Join(names, ";")
+	path := u.EscapedPath()
+	if path == "" {
+		path = "/"
+	}
+	canonReq := strings.Join([]string{
+		http.MethodGet, path, u.Query().Encode(), canonHeaders.String(), signedHeaders, payloadHash,
+	}, "\n")
+
+	scope := date + "/" + region + "/s3/aws4_request"
+	hash := sha256.Sum256([]byte(canonReq))
+	strToSign := "AWS4-HMAC-SHA256\n" + amzDate + "\n" + scope + "\n" + hex.EncodeToString(hash[:])
+	key := hmacSHA256([]byte("AWS4"+secret), date)
+	for _, s := range []string{region, "s3", "aws4_request"} {
+		key = hmacSHA256(key, s)
+	}
+	sig := hex.EncodeToString(hmacSHA256(key, strToSign))
+	req.Header.Set("Authorization", fmt.Sprintf(
+		"AWS4-HMAC-SHA256 Credential=%s/%s, SignedHeaders=%s, Signature=%s", id, scope, signedHeaders, sig))
+}
+
+func hmacSHA256(key []byte, data string) []byte {
+	h := hmac.New(sha256.New, key)
+	h.Write([]byte(data))
+	return h.Sum(nil)
+}
+
+// -----------------------------------------------------------------------------
+
+// splitBucket splits a URL like s3://bucket/key into the bucket and the key.
+func splitBucket(uri, prefix string) (bucket, key string, err error) {
+	bucket, key, ok := strings.Cut(strings.TrimPrefix(uri, prefix), "/")
+	if !ok || bucket == "" || key == "" {
+		err = fmt.Errorf("stream: invalid object URL %q, expect %sbucket/key", uri, prefix)
+	}
+	return
+}
+
+// escapePath escapes the segments of a path, all the bytes except the
+// unreserved characters (A-Z, a-z, 0-9, '-', '.', '_' and '~') are escaped,
+// as required by the canonical requests of AWS Signature Version 4.
+func escapePath(path string) string {
+	var b strings.Builder
+	for i := 0; i < len(path); i++ {
+		c := path[i]
+		if 'A' <= c && c <= 'Z' || 'a' <= c && c <= 'z' || '0' <= c && c <= '9' ||
+			c == '-' || c == '.' || c == '_' || c == '~' || c == '/' {
+			b.WriteByte(c)
+		} else {
+			fmt.Fprintf(&b, "%%%02X", c)
+		}
+	}
+	return b.String()
+}
+
+// getenv returns the value of the first non-empty environment variable.
+func getenv(keys ...string) string {
+	for _, key := range keys {
+		if v := os.Getenv(key); v != "" {
+			return v
+		}
+	}
+	return ""
+}
+
+// -----------------------------------------------------------------------------
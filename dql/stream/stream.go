@@ -0,0 +1,116 @@
+/*
+ * Copyright (c) 2026 The XGo Authors (xgo.dev). All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package stream opens the sources of DQL documents. It extends
+// github.com/qiniu/x/stream, whose registered schemes are supported by Open,
+// with requests carrying HTTP options (see Request), and with the object
+// storage schemes s3:// (see OpenS3) and gs:// (see OpenGS).
+package stream
+
+import (
+	"io"
+	"io/fs"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/qiniu/x/stream"
+)
+
+const (
+	XGoPackage = true
+)
+
+// -----------------------------------------------------------------------------
+
+// OpenFunc defines the function type for opening a resource by URL.
+type OpenFunc = stream.OpenFunc
+
+// Register registers a scheme with an open function, so Open (and the DQL
+// sources) can open the resources of the scheme. It's shared with
+// github.com/qiniu/x/stream.
+func Register(scheme string, open OpenFunc) {
+	stream.Register(scheme, open)
+}
+
+func init() {
+	Register("s3", OpenS3)
+	Register("gs", OpenGS)
+}
+
+// Open opens a resource identified by the given URI. It supports the schemes
+// registered to github.com/qiniu/x/stream, and if the URI has no scheme, it's
+// treated as a file path. The pseudo-path "-" means the standard input, and
+// "fd:N" means the open file descriptor N (closing the returned reader doesn't
+// close them), so DQL scripts compose with pipes:
+//
+//	cat data.json | xgo run query.xgo   # query.xgo reads json.source("-")
+//
+// Compressed files (like *.gz, see RegisterDecoder) are decompressed
+// transparently. Use a Request with Raw set to read them as is. If
+// DefaultCache is set, HTTP(S) resources are requested by Request, which
+// caches the responses.
+func Open(uri string) (io.ReadCloser, error) {
+	return open(uri, false)
+}
+
+func open(uri string, raw bool) (io.ReadCloser, error) {
+	if uri == "-" {
+		return io.NopCloser(os.Stdin), nil // don't close stdin
+	}
+	if fd, ok := strings.CutPrefix(uri, "fd:"); ok {
+		n, err := strconv.Atoi(fd)
+		if err != nil || n < 0 {
+			return nil, &fs.PathError{Op: "stream.Open", Path: uri, Err: fs.ErrInvalid}
+		}
+		return openFd(n, uri)
+	}
+	if DefaultCache != nil {
+		switch schemeOf(uri) {
+		case "http", "https":
+			return (&Request{URL: uri, Raw: raw}).Open()
+		}
+	}
+	rc, err := stream.Open(uri)
+	if err != nil || raw {
+		return rc, err
+	}
+	return decode(rc, codingOf(uri), uri)
+}
+
+// ReadSourceFromURI reads the source from the given URI. If src != nil, it
+// reads from src (see github.com/qiniu/x/stream.ReadSource); otherwise, it
+// opens the URI by Open and reads from it.
+func ReadSourceFromURI(uri string, src any) ([]byte, error) {
+	if src == nil {
+		f, err := Open(uri)
+		if err != nil {
+			return nil, err
+		}
+		defer f.Close()
+		src = f
+	}
+	return stream.ReadSource(src)
+}
+
+func schemeOf(uri string) string {
+	if pos := strings.IndexAny(uri, ":/"); pos > 0 && uri[pos] == ':' {
+		return uri[:pos]
+	}
+	return ""
+}
+
+// -----------------------------------------------------------------------------
@@ -0,0 +1,111 @@
+/*
+ * Copyright (c) 2026 The XGo Authors (xgo.dev). All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package toml
+
+import (
+	"io"
+	"iter"
+
+	"github.com/goplus/xgo/dql/maps"
+	"github.com/goplus/xgo/dql/stream"
+	"github.com/goplus/xgo/encoding/toml"
+)
+
+const (
+	XGoPackage = "github.com/goplus/xgo/dql/maps"
+)
+
+// -----------------------------------------------------------------------------
+
+// Node represents a map[string]any or []any node.
+type Node = maps.Node
+
+// NodeSet represents a set of TOML nodes.
+type NodeSet = maps.NodeSet
+
+// New creates a TOML NodeSet from TOML data read from r. Tables are nodes
+// whose children are the keys of the tables, and an array of tables is a node
+// whose children are the tables:
+//
+//	doc := toml.source("Cargo.toml")
+//	echo doc.package.$name
+//	for dep in doc.dependencies.* {
+//		echo dep._name, dep._value
+//	}
+//
+// See github.com/goplus/xgo/encoding/toml.Parse for how the values are
+// decoded.
+func New(r io.Reader) NodeSet {
+	b, err := io.ReadAll(r)
+	if err != nil {
+		return NodeSet{Err: err}
+	}
+	return newDoc(string(b))
+}
+
+func newDoc(text string) NodeSet {
+	doc, err := toml.Parse(text)
+	if err != nil {
+		return NodeSet{Err: err}
+	}
+	return maps.New(doc)
+}
+
+// Source creates a TOML NodeSet from various source types:
+// - string: treats the string as a file path, opens the file, and reads TOML data from it.
+// - *stream.Request: opens the resource with the request options (like HTTP headers).
+// - []byte: reads TOML data from the byte slice.
+// - io.Reader: reads TOML data from the provided reader.
+// - map[string]any: creates a NodeSet from the provided map (like a toml`...` literal).
+// - Node: creates a NodeSet containing the single provided node.
+// - iter.Seq[Node]: directly uses the provided sequence of nodes.
+// - NodeSet: returns the provided NodeSet as is.
+// If the source type is unsupported, it panics.
+func Source(r any) (ret NodeSet) {
+	switch v := r.(type) {
+	case string:
+		f, err := stream.Open(v)
+		if err != nil {
+			return NodeSet{Err: err}
+		}
+		defer f.Close()
+		return New(f)
+	case *stream.Request:
+		f, err := v.Open()
+		if err != nil {
+			return NodeSet{Err: err}
+		}
+		defer f.Close()
+		return New(f)
+	case []byte:
+		return newDoc(string(v))
+	case io.Reader:
+		return New(v)
+	case map[string]any:
+		return maps.New(v)
+	case Node:
+		return maps.Root(v)
+	case iter.Seq[Node]:
+		return NodeSet{Data: v}
+	case NodeSet:
+		return v
+	default:
+		panic("dql/toml.Source: unsupported source type")
+	}
+}
+
+// -----------------------------------------------------------------------------
@@ -0,0 +1,72 @@
+/*
+ * Copyright (c) 2026 The XGo Authors (xgo.dev). All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package toml
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+const cargo = `[package]
+name = "demo"
+version = "0.1.0"
+published = 2024-03-01T10:00:00Z
+
+[dependencies]
+serde = "1.0"
+tokio = { version = "1", features = ["full"] }
+
+[[bin]]
+name = "server"
+
+[[bin]]
+name = "client"
+`
+
+func TestSource(t *testing.T) {
+	doc := Source([]byte(cargo))
+	if name, err := doc.XGo_Elem("package").XGo_Attr__1("name"); err != nil || name != "demo" {
+		t.Fatal("package.$name:", name, err)
+	}
+	published, err := doc.XGo_Elem("package").XGo_Elem("published").Time(time.RFC3339)
+	if err != nil || published.Year() != 2024 {
+		t.Fatal("published:", published, err)
+	}
+	var deps []string
+	for dep := range doc.XGo_Elem("dependencies").Sorted().XGo_Child().XGo_Enum() {
+		deps = append(deps, dep.XGo_name__0())
+	}
+	if strings.Join(deps, ",") != "serde,tokio" {
+		t.Fatal("dependencies:", deps)
+	}
+	var bins []string
+	for bin := range doc.XGo_Elem("bin").XGo_Child().XGo_Enum() {
+		bins = append(bins, bin.XGo_Attr__0("name").(string))
+	}
+	if strings.Join(bins, ",") != "server,client" {
+		t.Fatal("bin:", bins)
+	}
+	features := doc.JSONPath("$.dependencies.tokio.features[0]")
+	if v, err := features.XGo_value__1(); err != nil || v != "full" {
+		t.Fatal("features:", v, err)
+	}
+
+	if ns := Source(strings.NewReader("a = ")); ns.Err == nil || !strings.Contains(ns.Err.Error(), "line 1") {
+		t.Fatal("invalid TOML:", ns.Err)
+	}
+}
@@ -17,9 +17,8 @@
 package xgo
 
 import (
-	"unsafe"
-
 	"github.com/goplus/xgo/ast"
+	"github.com/goplus/xgo/token"
 )
 
 // -----------------------------------------------------------------------------
@@ -27,15 +26,15 @@ import (
 // File represents a XGo file.
 type File struct {
 	ast.File
-	// File must contain only the embedded ast.File field.
+	fset *token.FileSet
 }
 
 // ParseFile parses XGo source code from the given filename or source, returning a File
 // object. An optional Config can be provided to customize the parsing behavior.
 func ParseFile(filename string, src any, conf ...Config) (f *File, err error) {
-	doc, err := parse(filename, src, conf...)
+	doc, fset, err := parse(filename, src, conf...)
 	if err == nil {
-		f = (*File)(unsafe.Pointer(doc))
+		f = &File{File: *doc, fset: fset}
 	}
 	return
 }
@@ -46,13 +45,13 @@ func ParseFile(filename string, src any, conf ...Config) (f *File, err error) {
 //   - .name
 //   - .“element-name”
 func (f *File) XGo_Elem(name string) NodeSet {
-	return New(&f.File).XGo_Elem(name)
+	return New(&f.File, f.fset).XGo_Elem(name)
 }
 
 // XGo_Child returns a NodeSet containing all child nodes of the node.
 //   - .*
 func (f *File) XGo_Child() NodeSet {
-	return New(&f.File).XGo_Child()
+	return New(&f.File, f.fset).XGo_Child()
 }
 
 // XGo_Any returns a NodeSet containing all descendant nodes (including the
@@ -62,7 +61,7 @@ func (f *File) XGo_Child() NodeSet {
 //   - .**.“element-name”
 //   - .**.*
 func (f *File) XGo_Any(name string) NodeSet {
-	return New(&f.File).XGo_Any(name)
+	return New(&f.File, f.fset).XGo_Any(name)
 }
 
 // -----------------------------------------------------------------------------
@@ -0,0 +1,121 @@
+/*
+ * Copyright (c) 2026 The XGo Authors (xgo.dev). All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package xgo
+
+import (
+	"reflect"
+
+	"github.com/goplus/xgo/ast"
+	"github.com/goplus/xgo/dql/reflects"
+	"github.com/goplus/xgo/token"
+)
+
+// -----------------------------------------------------------------------------
+
+// WithFset returns a copy of the NodeSet using the specified file set to
+// report positions. It's needed for NodeSets created by NodeSet_Cast, Root or
+// Nodes, which don't know the file set of their nodes.
+func (p NodeSet) WithFset(fset *token.FileSet) NodeSet {
+	p.Fset = fset
+	return p
+}
+
+// astNode returns the first node in the NodeSet as an ast.Node.
+func (p NodeSet) astNode() ast.Node {
+	node, err := p.XGo_first()
+	if err != nil {
+		return nil
+	}
+	return astNodeOf(node.Value)
+}
+
+// astNodeOf returns v as an ast.Node, or nil if it isn't a (non-nil) AST node.
+func astNodeOf(v reflect.Value) ast.Node {
+	if v.Kind() == reflect.Interface {
+		v = v.Elem()
+	}
+	if !v.IsValid() || !v.CanInterface() || v.Kind() == reflect.Pointer && v.IsNil() {
+		return nil
+	}
+	n, _ := v.Interface().(ast.Node)
+	return n
+}
+
+// Pos returns the position of the first node in the NodeSet, or token.NoPos
+// if it isn't an AST node.
+func (p NodeSet) Pos() token.Pos {
+	if n := p.astNode(); n != nil {
+		return n.Pos()
+	}
+	return token.NoPos
+}
+
+// End returns the position of the character immediately after the first node
+// in the NodeSet, or token.NoPos if it isn't an AST node.
+func (p NodeSet) End() token.Pos {
+	if n := p.astNode(); n != nil {
+		return n.End()
+	}
+	return token.NoPos
+}
+
+// Position returns the source position (filename, line and column) of the
+// first node in the NodeSet, which prints like "main.go:42:1":
+//
+//	for fn in doc.decls.*@(self.class == "FuncDecl") {
+//		echo "func ${fn.$name} at ${fn.position}"
+//	}
+//
+// The zero Position is returned if the file set is unknown.
+func (p NodeSet) Position() token.Position {
+	if p.Fset == nil {
+		return token.Position{}
+	}
+	return p.Fset.Position(p.Pos())
+}
+
+// At returns a NodeSet containing the AST nodes in the NodeSet whose source
+// ranges contain the specified line, for example, doc.**.funcDecl.at(42)
+// returns the function declaration containing line 42. If the file set is
+// unknown, it returns an empty NodeSet.
+func (p NodeSet) At(line int) NodeSet {
+	if p.Err != nil {
+		return p
+	}
+	fset := p.Fset
+	return p.with(reflects.NodeSet{
+		Data: func(yield func(Node) bool) {
+			if fset == nil {
+				return
+			}
+			p.Data(func(node Node) bool {
+				n := astNodeOf(node.Value)
+				if n == nil {
+					return true
+				}
+				pos, end := n.Pos(), n.End()
+				if pos.IsValid() && end.IsValid() &&
+					fset.Position(pos).Line <= line && line <= fset.Position(end-1).Line {
+					return yield(node)
+				}
+				return true
+			})
+		},
+	})
+}
+
+// -----------------------------------------------------------------------------
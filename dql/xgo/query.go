@@ -0,0 +1,142 @@
+/*
+ * Copyright (c) 2026 The XGo Authors (xgo.dev). All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package xgo
+
+import (
+	"io/fs"
+	"maps"
+	"reflect"
+	"slices"
+	"strings"
+
+	"github.com/goplus/xgo/ast"
+	"github.com/goplus/xgo/dql/reflects"
+	"github.com/goplus/xgo/parser"
+)
+
+// -----------------------------------------------------------------------------
+
+// Dir parses the XGo source files (*.xgo, *.gop and classfiles like *.gox)
+// in the directory dir, and returns a NodeSet containing the parsed files
+// (*ast.File) as root nodes, which are named by their file names and sorted
+// by them:
+//
+//	for call in xgo.dir("src").commands {
+//		echo call.fun.$name, call.position
+//	}
+//
+// Go files and test files are not included. An optional Config can be
+// provided to customize the parsing behavior.
+func Dir(dir string, conf ...Config) NodeSet {
+	c := configOf(conf)
+	filter := func(fi fs.FileInfo) bool {
+		name := fi.Name()
+		return !strings.Contains(name, "_test.") && !strings.HasSuffix(name, "test.gox")
+	}
+	pkgs, err := parser.ParseDir(c.Fset, dir, filter, c.Mode)
+	if err != nil {
+		return NodeSet{NodeSet: reflects.NodeSet{Err: err}}
+	}
+	files := make(map[string]*ast.File)
+	for _, pkg := range pkgs {
+		maps.Copy(files, pkg.Files)
+	}
+	nodes := make([]Node, 0, len(files))
+	for _, name := range slices.Sorted(maps.Keys(files)) {
+		nodes = append(nodes, Node{Name: name, Value: reflect.ValueOf(files[name])})
+	}
+	return NodeSet{NodeSet: reflects.Nodes(nodes...), Fset: c.Fset}
+}
+
+// -----------------------------------------------------------------------------
+
+// Commands returns a NodeSet containing the command-style calls in the
+// NodeSet (including the descendants of its nodes), like `echo "Hello"` (as opposed to `echo("Hello")`).
+func (p NodeSet) Commands() NodeSet {
+	return p.walk(func(n ast.Node) bool {
+		call, ok := n.(*ast.CallExpr)
+		return ok && call.IsCommand()
+	})
+}
+
+// Lambdas returns a NodeSet containing the lambda expressions in the NodeSet
+// (including the descendants of its nodes), both the expression form `x => x * x` (LambdaExpr) and the statement form
+// `x => { ... }` (LambdaExpr2).
+func (p NodeSet) Lambdas() NodeSet {
+	return p.walk(func(n ast.Node) bool {
+		switch n.(type) {
+		case *ast.LambdaExpr, *ast.LambdaExpr2:
+			return true
+		}
+		return false
+	})
+}
+
+// ClassFields returns a NodeSet containing the field specs (ValueSpec) of the
+// classfiles (*ast.File) in the NodeSet, that is, the specs of the var block
+// at the beginning of a classfile.
+func (p NodeSet) ClassFields() NodeSet {
+	if p.Err != nil {
+		return p
+	}
+	return p.with(reflects.NodeSet{
+		Data: func(yield func(Node) bool) {
+			p.Data(func(node Node) bool {
+				f, ok := astNodeOf(node.Value).(*ast.File)
+				if !ok {
+					return true
+				}
+				if decl := f.ClassFieldsDecl(); decl != nil {
+					for _, spec := range decl.Specs {
+						if !yield(Node{Name: "", Value: reflect.ValueOf(spec)}) {
+							return false
+						}
+					}
+				}
+				return true
+			})
+		},
+	})
+}
+
+// walk returns a NodeSet containing the AST nodes in the NodeSet and their
+// descendants which satisfy fn.
+func (p NodeSet) walk(fn func(n ast.Node) bool) NodeSet {
+	if p.Err != nil {
+		return p
+	}
+	return p.with(reflects.NodeSet{
+		Data: func(yield func(Node) bool) {
+			p.Data(func(node Node) bool {
+				root := astNodeOf(node.Value)
+				if root == nil {
+					return true
+				}
+				ok := true
+				ast.Inspect(root, func(n ast.Node) bool {
+					if ok && n != nil && fn(n) {
+						ok = yield(Node{Name: "", Value: reflect.ValueOf(n)})
+					}
+					return ok
+				})
+				return ok
+			})
+		},
+	})
+}
+
+// -----------------------------------------------------------------------------
@@ -25,9 +25,9 @@ import (
 	"github.com/goplus/xgo/ast"
 	"github.com/goplus/xgo/dql"
 	"github.com/goplus/xgo/dql/reflects"
+	"github.com/goplus/xgo/dql/stream"
 	"github.com/goplus/xgo/parser"
 	"github.com/goplus/xgo/token"
-	"github.com/qiniu/x/stream"
 )
 
 const (
@@ -42,6 +42,13 @@ type Node = reflects.Node
 // NodeSet represents a set of XGo AST nodes.
 type NodeSet struct {
 	reflects.NodeSet
+	Fset *token.FileSet // file set of the nodes, nil if unknown
+}
+
+// with returns a NodeSet of the nodes in ns, sharing the file set of p.
+func (p NodeSet) with(ns reflects.NodeSet) NodeSet {
+	p.NodeSet = ns
+	return p
 }
 
 // NodeSet(seq) casts a NodeSet from a sequence of nodes.
@@ -65,11 +72,16 @@ func Nodes(nodes ...Node) NodeSet {
 	}
 }
 
-// New creates a NodeSet from the given *ast.File.
-func New(f *ast.File) NodeSet {
-	return NodeSet{
+// New creates a NodeSet from the given *ast.File. An optional file set can be
+// provided to report the positions of the nodes (see Position).
+func New(f *ast.File, fset ...*token.FileSet) NodeSet {
+	ret := NodeSet{
 		NodeSet: reflects.New(reflect.ValueOf(f)),
 	}
+	if len(fset) > 0 {
+		ret.Fset = fset[0]
+	}
+	return ret
 }
 
 // Config represents the configuration for parsing XGo source code.
@@ -82,13 +94,8 @@ const (
 	defaultMode = parser.ParseComments
 )
 
-// parse parses XGo source code from the given URI or source.
-func parse(uri string, src any, conf ...Config) (f *ast.File, err error) {
-	in, err := stream.ReadSourceFromURI(uri, src)
-	if err != nil {
-		return
-	}
-	var c Config
+// configOf returns the parsing configuration, with the defaults applied.
+func configOf(conf []Config) (c Config) {
 	if len(conf) > 0 {
 		c = conf[0]
 	} else {
@@ -97,17 +104,28 @@ func parse(uri string, src any, conf ...Config) (f *ast.File, err error) {
 	if c.Fset == nil {
 		c.Fset = token.NewFileSet()
 	}
-	return parser.ParseFile(c.Fset, uri, in, c.Mode)
+	return
+}
+
+// parse parses XGo source code from the given URI or source.
+func parse(uri string, src any, conf ...Config) (f *ast.File, fset *token.FileSet, err error) {
+	in, err := stream.ReadSourceFromURI(uri, src)
+	if err != nil {
+		return
+	}
+	c := configOf(conf)
+	f, err = parser.ParseFile(c.Fset, uri, in, c.Mode)
+	return f, c.Fset, err
 }
 
 // From parses XGo source code from the given URI or source, returning a NodeSet.
 // An optional Config can be provided to customize the parsing behavior.
 func From(uri string, src any, conf ...Config) NodeSet {
-	f, err := parse(uri, src, conf...)
+	f, fset, err := parse(uri, src, conf...)
 	if err != nil {
 		return NodeSet{NodeSet: reflects.NodeSet{Err: err}}
 	}
-	return New(f)
+	return New(f, fset)
 }
 
 // Source creates a NodeSet from various types of XGo sources.
@@ -155,8 +173,8 @@ func (p NodeSet) XGo_Enum() iter.Seq[NodeSet] {
 		return dql.NopIter[NodeSet]
 	}
 	return func(yield func(NodeSet) bool) {
-		p.Data(func(node Node) bool {
-			return yield(Root(node))
+		p.NodeSet.XGo_Enum()(func(ns reflects.NodeSet) bool {
+			return yield(p.with(ns))
 		})
 	}
 }
@@ -165,25 +183,19 @@ func (p NodeSet) XGo_Enum() iter.Seq[NodeSet] {
 //   - @name
 //   - @"element-name"
 func (p NodeSet) XGo_Select(name string) NodeSet {
-	return NodeSet{
-		NodeSet: p.NodeSet.XGo_Select(name),
-	}
+	return p.with(p.NodeSet.XGo_Select(name))
 }
 
 // XGo_Elem returns a NodeSet containing the child nodes with the specified name.
 //   - .name
 //   - .“element-name”
 func (p NodeSet) XGo_Elem(name string) NodeSet {
-	return NodeSet{
-		NodeSet: p.NodeSet.XGo_Elem(name),
-	}
+	return p.with(p.NodeSet.XGo_Elem(name))
 }
 
 // XGo_Child returns a NodeSet containing all child nodes of the nodes in the NodeSet.
 func (p NodeSet) XGo_Child() NodeSet {
-	return NodeSet{
-		NodeSet: p.NodeSet.XGo_Child(),
-	}
+	return p.with(p.NodeSet.XGo_Child())
 }
 
 // XGo_Any returns a NodeSet containing all descendant nodes (including the
@@ -193,9 +205,7 @@ func (p NodeSet) XGo_Child() NodeSet {
 //   - .**.“element-name”
 //   - .**.*
 func (p NodeSet) XGo_Any(name string) NodeSet {
-	return NodeSet{
-		NodeSet: p.NodeSet.XGo_Any(name),
-	}
+	return p.with(p.NodeSet.XGo_Any(name))
 }
 
 // -----------------------------------------------------------------------------
@@ -204,26 +214,20 @@ func (p NodeSet) XGo_Any(name string) NodeSet {
 // It's a cache operation for performance optimization when you need to traverse
 // the nodes multiple times.
 func (p NodeSet) All() NodeSet {
-	return NodeSet{
-		NodeSet: p.NodeSet.XGo_all(),
-	}
+	return p.with(p.NodeSet.XGo_all())
 }
 
 // One returns a NodeSet containing the first node.
 // It's a performance optimization when you only need the first node (stop early).
 func (p NodeSet) One() NodeSet {
-	return NodeSet{
-		NodeSet: p.NodeSet.XGo_one(),
-	}
+	return p.with(p.NodeSet.XGo_one())
 }
 
 // Single returns a NodeSet containing the single node.
 // If there are zero or more than one nodes, it returns an error.
 // ErrNotFound or ErrMultipleResults is returned accordingly.
 func (p NodeSet) Single() NodeSet {
-	return NodeSet{
-		NodeSet: p.NodeSet.XGo_single(),
-	}
+	return p.with(p.NodeSet.XGo_single())
 }
 
 // -----------------------------------------------------------------------------
@@ -0,0 +1,93 @@
+/*
+ * Copyright (c) 2026 The XGo Authors (xgo.dev). All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package xgo
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/goplus/xgo/dql"
+)
+
+const mainSrc = `echo "Hello"
+println("world")
+
+sq := x => x * x
+show := x => {
+	echo x
+}
+show sq(2)
+`
+
+func TestQuery(t *testing.T) {
+	doc := From("main.xgo", mainSrc)
+	if doc.Err != nil {
+		t.Fatal(doc.Err)
+	}
+	var cmds []string
+	for call := range doc.Commands().XGo_Enum() {
+		cmds = append(cmds, call.XGo_Attr__0("fun").(string)+"@"+call.Position().String())
+	}
+	if len(cmds) != 3 || cmds[0] != "echo@main.xgo:1:1" || cmds[2] != "show@main.xgo:8:1" {
+		t.Error("Commands:", cmds)
+	}
+	var classes []string
+	for fn := range doc.Lambdas().XGo_Enum() {
+		classes = append(classes, fn.Class())
+	}
+	if len(classes) != 2 || classes[0] != "LambdaExpr" || classes[1] != "LambdaExpr2" {
+		t.Error("Lambdas:", classes)
+	}
+	if n := len(dql.Collect(doc.Commands().At(6).Data)); n != 1 {
+		t.Error("At(6):", n)
+	}
+}
+
+func TestDir(t *testing.T) {
+	dir := t.TempDir()
+	files := map[string]string{
+		"main.xgo":      "echo \"Hi\"\n",
+		"Rect.gox":      "var (\n\tWidth, Height int\n\tName string\n)\n\nfunc Area() int { return Width * Height }\n",
+		"main_test.xgo": "echo \"test\"\n",
+		"util.go":       "package main\n",
+	}
+	for name, src := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(src), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	doc := Dir(dir)
+	if doc.Err != nil {
+		t.Fatal("Dir:", doc.Err)
+	}
+	if n := len(dql.Collect(doc.Data)); n != 2 {
+		t.Error("Dir: files", n)
+	}
+	var fields []string
+	for spec := range doc.ClassFields().XGo_Enum() {
+		for name := range spec.XGo_Elem("names").XGo_Child().XGo_Enum() {
+			fields = append(fields, name.XGo_Attr__0("name").(string))
+		}
+	}
+	if len(fields) != 3 || fields[0] != "Width" || fields[2] != "Name" {
+		t.Error("ClassFields:", fields)
+	}
+	if pos := doc.ClassFields().Position(); filepath.Base(pos.Filename) != "Rect.gox" || pos.Line != 2 {
+		t.Error("ClassFields position:", pos)
+	}
+}
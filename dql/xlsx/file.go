@@ -0,0 +1,329 @@
+/*
+ * Copyright (c) 2026 The XGo Authors (xgo.dev). All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package xlsx
+
+import (
+	"encoding/xml"
+	"errors"
+	"io"
+	"io/fs"
+	"math"
+	"path"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// This file reads the parts of an Office Open XML workbook (ECMA-376), which
+// is a zip archive of XML files.
+
+// -----------------------------------------------------------------------------
+
+// ErrNotWorkbook is returned if a file isn't an xlsx workbook.
+var ErrNotWorkbook = errors.New("xlsx: file is not a workbook")
+
+type xmlWorkbook struct {
+	Pr struct {
+		Date1904 string `xml:"date1904,attr"`
+	} `xml:"workbookPr"`
+	Sheets []struct {
+		Name string `xml:"name,attr"`
+		ID   string `xml:"http://schemas.openxmlformats.org/officeDocument/2006/relationships id,attr"`
+	} `xml:"sheets>sheet"`
+}
+
+type xmlRels struct {
+	Rels []struct {
+		ID     string `xml:"Id,attr"`
+		Target string `xml:"Target,attr"`
+	} `xml:"Relationship"`
+}
+
+type xmlText struct {
+	T string `xml:"t"`
+	R []struct {
+		T string `xml:"t"`
+	} `xml:"r"` // rich text runs
+}
+
+func (t *xmlText) String() string {
+	if len(t.R) == 0 {
+		return t.T
+	}
+	var b strings.Builder
+	for _, r := range t.R {
+		b.WriteString(r.T)
+	}
+	return b.String()
+}
+
+type xmlSharedStrings struct {
+	SI []xmlText `xml:"si"`
+}
+
+type xmlStyles struct {
+	NumFmts []struct {
+		ID   int    `xml:"numFmtId,attr"`
+		Code string `xml:"formatCode,attr"`
+	} `xml:"numFmts>numFmt"`
+	CellXfs []struct {
+		NumFmtID int `xml:"numFmtId,attr"`
+	} `xml:"cellXfs>xf"`
+}
+
+type xmlRow struct {
+	R     int       `xml:"r,attr"`
+	Cells []xmlCell `xml:"c"`
+}
+
+type xmlCell struct {
+	R  string  `xml:"r,attr"`
+	T  string  `xml:"t,attr"`
+	S  int     `xml:"s,attr"`
+	V  string  `xml:"v"`
+	IS xmlText `xml:"is"`
+}
+
+// -----------------------------------------------------------------------------
+
+// load loads the workbook part, the shared strings and the styles.
+func (wb *Workbook) load() error {
+	var w xmlWorkbook
+	if err := wb.decode("xl/workbook.xml", &w); err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return ErrNotWorkbook
+		}
+		return err
+	}
+	wb.date1904 = w.Pr.Date1904 == "1" || w.Pr.Date1904 == "true"
+
+	var rels xmlRels
+	if err := wb.decode("xl/_rels/workbook.xml.rels", &rels); err != nil {
+		return err
+	}
+	targets := make(map[string]string, len(rels.Rels))
+	for _, r := range rels.Rels {
+		if strings.HasPrefix(r.Target, "/") {
+			targets[r.ID] = r.Target[1:]
+		} else {
+			targets[r.ID] = path.Join("xl", r.Target)
+		}
+	}
+	for _, s := range w.Sheets {
+		wb.sheets = append(wb.sheets, sheet{name: s.Name, part: targets[s.ID]})
+	}
+
+	var sst xmlSharedStrings
+	if err := wb.decode("xl/sharedStrings.xml", &sst); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return err
+	}
+	wb.shared = make([]string, len(sst.SI))
+	for i, si := range sst.SI {
+		wb.shared[i] = si.String()
+	}
+
+	var styles xmlStyles
+	if err := wb.decode("xl/styles.xml", &styles); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return err
+	}
+	dateFmts := make(map[int]bool)
+	for _, f := range styles.NumFmts {
+		dateFmts[f.ID] = isDateFormat(f.Code)
+	}
+	wb.dates = make([]bool, len(styles.CellXfs))
+	for i, xf := range styles.CellXfs {
+		id := xf.NumFmtID
+		if v, ok := dateFmts[id]; ok {
+			wb.dates[i] = v
+		} else {
+			wb.dates[i] = isBuiltinDate(id)
+		}
+	}
+	return nil
+}
+
+func (wb *Workbook) decode(name string, v any) error {
+	f, err := wb.zr.Open(name)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	return xml.NewDecoder(f).Decode(v)
+}
+
+// rows reads the rows of a sheet, and calls fn with the row number (starting
+// at 1) and the values of the cells keyed by their column indexes.
+func (wb *Workbook) rows(s *sheet, fn func(r int, cells map[int]any) bool) error {
+	f, err := wb.zr.Open(s.part)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	dec := xml.NewDecoder(f)
+	r := 0
+	for {
+		tok, err := dec.Token()
+		if err != nil {
+			if err == io.EOF {
+				return nil
+			}
+			return err
+		}
+		start, ok := tok.(xml.StartElement)
+		if !ok || start.Name.Local != "row" {
+			continue
+		}
+		var row xmlRow
+		if err = dec.DecodeElement(&row, &start); err != nil {
+			return err
+		}
+		if row.R > 0 {
+			r = row.R
+		} else {
+			r++ // rows without the r attribute are consecutive
+		}
+		cells := make(map[int]any, len(row.Cells))
+		col := -1
+		for _, c := range row.Cells {
+			if c.R != "" {
+				col = colIndex(c.R)
+			} else {
+				col++
+			}
+			if v := wb.value(&c); v != nil {
+				cells[col] = v
+			}
+		}
+		if !fn(r, cells) {
+			return nil
+		}
+	}
+}
+
+// value returns the value of a cell: string, int64, float64, bool or nil.
+// Dates are strings, see Workbook.Sheet.
+func (wb *Workbook) value(c *xmlCell) any {
+	switch c.T {
+	case "s":
+		if i, err := strconv.Atoi(c.V); err == nil && i >= 0 && i < len(wb.shared) {
+			return wb.shared[i]
+		}
+		return nil
+	case "inlineStr":
+		return c.IS.String()
+	case "str", "e", "d": // formula strings, errors (like #DIV/0!) and ISO 8601 dates
+		return c.V
+	case "b":
+		return c.V == "1"
+	}
+	if c.V == "" {
+		return nil
+	}
+	if c.S >= 0 && c.S < len(wb.dates) && wb.dates[c.S] {
+		if f, err := strconv.ParseFloat(c.V, 64); err == nil {
+			return wb.date(f)
+		}
+	}
+	if v, err := strconv.ParseInt(c.V, 10, 64); err == nil {
+		return v
+	}
+	if v, err := strconv.ParseFloat(c.V, 64); err == nil {
+		return v
+	}
+	return c.V
+}
+
+// date converts a serial date number to a string in the form of "2006-01-02",
+// or "2006-01-02T15:04:05" if it has a time part.
+func (wb *Workbook) date(serial float64) string {
+	epoch := time.Date(1899, 12, 30, 0, 0, 0, 0, time.UTC)
+	if wb.date1904 {
+		epoch = time.Date(1904, 1, 1, 0, 0, 0, 0, time.UTC)
+	} else if serial < 61 {
+		serial++ // 1900-02-29 is a valid date in Excel
+	}
+	days := math.Floor(serial)
+	secs := math.Round((serial - days) * 86400)
+	t := epoch.AddDate(0, 0, int(days)).Add(time.Duration(secs) * time.Second)
+	if secs == 0 {
+		return t.Format("2006-01-02")
+	}
+	return t.Format("2006-01-02T15:04:05")
+}
+
+// colIndex returns the column index (starting at 0) of a cell reference like
+// "B3".
+func colIndex(ref string) int {
+	col := 0
+	for _, c := range ref {
+		if c >= 'a' && c <= 'z' {
+			c -= 'a' - 'A'
+		}
+		if c < 'A' || c > 'Z' {
+			break
+		}
+		col = col*26 + int(c-'A'+1)
+	}
+	return col - 1
+}
+
+// colName returns the name of a column index, like "A", "B", ..., "AA".
+func colName(col int) string {
+	var b []byte
+	for col++; col > 0; col = (col - 1) / 26 {
+		b = append(b, byte('A'+(col-1)%26))
+	}
+	for i, j := 0, len(b)-1; i < j; i, j = i+1, j-1 {
+		b[i], b[j] = b[j], b[i]
+	}
+	return string(b)
+}
+
+// isBuiltinDate reports whether a built-in number format is a date format.
+func isBuiltinDate(id int) bool {
+	return id >= 14 && id <= 22 || id >= 27 && id <= 36 || id >= 45 && id <= 47 || id >= 50 && id <= 58
+}
+
+// isDateFormat reports whether a custom number format is a date format, which
+// has date or time parts (d, m, y, h, s) outside the quoted text, the escaped
+// characters and the brackets (like [Red]).
+func isDateFormat(code string) bool {
+	for i := 0; i < len(code); i++ {
+		switch c := code[i]; c {
+		case '"':
+			if j := strings.IndexByte(code[i+1:], '"'); j >= 0 {
+				i += j + 1
+			}
+		case '[':
+			if j := strings.IndexByte(code[i+1:], ']'); j >= 0 {
+				if s := code[i+1 : i+j+1]; s == "h" || s == "hh" || s == "m" || s == "mm" || s == "s" || s == "ss" {
+					return true // elapsed time like [h]:mm
+				}
+				i += j + 1
+			}
+		case '\\', '_', '*':
+			i++
+		case 'd', 'D', 'm', 'M', 'y', 'Y', 'h', 'H', 's', 'S':
+			return true
+		case ';':
+			return false // only the first section matters
+		}
+	}
+	return false
+}
+
+// -----------------------------------------------------------------------------
@@ -0,0 +1,290 @@
+/*
+ * Copyright (c) 2026 The XGo Authors (xgo.dev). All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package xlsx
+
+import (
+	"archive/zip"
+	"bytes"
+	"fmt"
+	"io"
+	"iter"
+	"os"
+
+	"github.com/goplus/xgo/dql/maps"
+	"github.com/goplus/xgo/dql/stream"
+)
+
+const (
+	XGoPackage = "github.com/goplus/xgo/dql/maps"
+)
+
+// -----------------------------------------------------------------------------
+
+// Node represents a map[string]any or []any node.
+type Node = maps.Node
+
+// NodeSet represents a set of xlsx nodes.
+type NodeSet = maps.NodeSet
+
+// Config specifies how the rows of sheets are read.
+type Config struct {
+	// Header specifies the column names. If it's nil, the first row of a
+	// sheet is read as the header, and its empty cells are named by their
+	// column letters (A, B, ...).
+	Header []string
+
+	// NoHeader reads all the rows as data, and names the cells by their
+	// column letters, like row.$A.
+	NoHeader bool
+
+	// OnError is called if an error occurs while reading rows.
+	OnError func(error)
+}
+
+// Workbook is an Excel workbook (an .xlsx file) opened for reading.
+type Workbook struct {
+	zr       *zip.Reader
+	f        io.Closer
+	sheets   []sheet
+	shared   []string // shared strings
+	dates    []bool   // whether the cell styles are date formats
+	date1904 bool
+	conf     Config
+}
+
+type sheet struct {
+	name string
+	part string // name of the worksheet part in the archive
+}
+
+// Open opens a workbook file for reading.
+func Open(path string, conf ...Config) (*Workbook, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	fi, err := f.Stat()
+	if err == nil {
+		var wb *Workbook
+		if wb, err = NewWorkbook(f, fi.Size(), conf...); err == nil {
+			wb.f = f
+			return wb, nil
+		}
+	}
+	f.Close()
+	return nil, fmt.Errorf("%s: %w", path, err)
+}
+
+// NewWorkbook reads a workbook from r, which has the given size in bytes.
+func NewWorkbook(r io.ReaderAt, size int64, conf ...Config) (*Workbook, error) {
+	zr, err := zip.NewReader(r, size)
+	if err != nil {
+		if err == zip.ErrFormat {
+			err = ErrNotWorkbook
+		}
+		return nil, err
+	}
+	wb := &Workbook{zr: zr}
+	if len(conf) > 0 {
+		wb.conf = conf[0]
+	}
+	if err = wb.load(); err != nil {
+		return nil, err
+	}
+	return wb, nil
+}
+
+// Close closes the workbook file.
+func (wb *Workbook) Close() error {
+	if wb.f == nil {
+		return nil
+	}
+	return wb.f.Close()
+}
+
+// Sheets returns the names of the sheets, in the order of the workbook.
+func (wb *Workbook) Sheets() []string {
+	names := make([]string, len(wb.sheets))
+	for i, s := range wb.sheets {
+		names[i] = s.name
+	}
+	return names
+}
+
+// Sheet returns a NodeSet of the rows of a sheet. Each row is a node named by
+// the sheet, with the cells as attributes named by the header, like
+// row.$Amount. Cells beyond the header are named by their column letters.
+//
+// Empty cells are absent from a row, and empty rows are skipped. Values of
+// cells are string, int64, float64 or bool, and cells formatted as dates are
+// strings in the form of "2006-01-02" (or "2006-01-02T15:04:05" if they have
+// a time part). Cells are also the children of a row, so the typed accessors
+// (Int, Float, Time, etc.) apply:
+//
+//	for row in wb.sheet("Orders") {
+//		echo row.$Customer, row.Amount.float!, row.Date.time("2006-01-02")!
+//	}
+//
+// Rows are read lazily while iterating, so large sheets are streamed.
+func (wb *Workbook) Sheet(name string) NodeSet {
+	for i := range wb.sheets {
+		if s := &wb.sheets[i]; s.name == name {
+			return NodeSet{
+				Data: func(yield func(Node) bool) {
+					err := wb.sheetRows(s, func(row map[string]any) bool {
+						return yield(Node{Name: s.name, Value: row})
+					})
+					if err != nil && wb.conf.OnError != nil {
+						wb.conf.OnError(err)
+					}
+				},
+			}
+		}
+	}
+	return NodeSet{Err: fmt.Errorf("xlsx: no such sheet: %s", name)}
+}
+
+func (wb *Workbook) sheetRows(s *sheet, fn func(row map[string]any) bool) error {
+	header := wb.conf.Header
+	return wb.rows(s, func(_ int, cells map[int]any) bool {
+		if len(cells) == 0 {
+			return true
+		}
+		if header == nil && !wb.conf.NoHeader {
+			header = headerOf(cells)
+			return true
+		}
+		row := make(map[string]any, len(cells))
+		for col, v := range cells {
+			if col < len(header) {
+				row[header[col]] = v
+			} else {
+				row[colName(col)] = v
+			}
+		}
+		return fn(row)
+	})
+}
+
+func headerOf(cells map[int]any) []string {
+	n := 0
+	for col := range cells {
+		n = max(n, col+1)
+	}
+	header := make([]string, n)
+	for col := range header {
+		if v, ok := cells[col]; ok {
+			header[col] = fmt.Sprint(v)
+		} else {
+			header[col] = colName(col)
+		}
+	}
+	return header
+}
+
+// Doc returns the document of the workbook: a map from the sheet names to the
+// rows ([]any) of the sheets. All the rows are loaded into memory, so use
+// Sheet to stream a large sheet.
+func (wb *Workbook) Doc() (map[string]any, error) {
+	doc := make(map[string]any, len(wb.sheets))
+	for i := range wb.sheets {
+		s := &wb.sheets[i]
+		rows := []any{}
+		err := wb.sheetRows(s, func(row map[string]any) bool {
+			rows = append(rows, row)
+			return true
+		})
+		if err != nil {
+			return nil, fmt.Errorf("xlsx: sheet %s: %w", s.name, err)
+		}
+		doc[s.name] = rows
+	}
+	return doc, nil
+}
+
+// New creates an xlsx NodeSet from a workbook read from r. The workbook is a
+// root node whose children are the sheets, and the children of a sheet are
+// its rows (see Workbook.Sheet), whose children are the cells:
+//
+//	doc := xlsx.source("sales.xlsx")
+//	for row in doc.Orders.*@($Amount > 1000) {
+//		echo row.$Customer
+//	}
+func New(r io.Reader, conf ...Config) NodeSet {
+	b, err := io.ReadAll(r)
+	if err != nil {
+		return NodeSet{Err: err}
+	}
+	return newDoc(bytes.NewReader(b), int64(len(b)), conf)
+}
+
+func newDoc(r io.ReaderAt, size int64, conf []Config) NodeSet {
+	wb, err := NewWorkbook(r, size, conf...)
+	if err != nil {
+		return NodeSet{Err: err}
+	}
+	return Source(wb)
+}
+
+// Source creates an xlsx NodeSet from various source types:
+// - string: treats the string as a file path (or URL), opens it, and reads the workbook from it.
+// - *stream.Request: opens the resource with the request options (like HTTP headers).
+// - []byte: reads the workbook from the byte slice.
+// - io.Reader: reads the workbook from the provided reader.
+// - *Workbook: loads the opened workbook.
+// - Node: creates a NodeSet containing the single provided node.
+// - iter.Seq[Node]: directly uses the provided sequence of nodes.
+// - NodeSet: returns the provided NodeSet as is.
+// If the source type is unsupported, it panics.
+func Source(r any, conf ...Config) (ret NodeSet) {
+	switch v := r.(type) {
+	case string:
+		f, err := stream.Open(v)
+		if err != nil {
+			return NodeSet{Err: err}
+		}
+		defer f.Close()
+		return New(f, conf...)
+	case *stream.Request:
+		f, err := v.Open()
+		if err != nil {
+			return NodeSet{Err: err}
+		}
+		defer f.Close()
+		return New(f, conf...)
+	case []byte:
+		return newDoc(bytes.NewReader(v), int64(len(v)), conf)
+	case io.Reader:
+		return New(v, conf...)
+	case *Workbook:
+		doc, err := v.Doc()
+		if err != nil {
+			return NodeSet{Err: err}
+		}
+		return maps.New(doc)
+	case Node:
+		return maps.Root(v)
+	case iter.Seq[Node]:
+		return NodeSet{Data: v}
+	case NodeSet:
+		return v
+	default:
+		panic("dql/xlsx.Source: unsupported source type")
+	}
+}
+
+// -----------------------------------------------------------------------------
@@ -0,0 +1,196 @@
+/*
+ * Copyright (c) 2026 The XGo Authors (xgo.dev). All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package xlsx
+
+import (
+	"archive/zip"
+	"bytes"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+var parts = map[string]string{
+	"xl/workbook.xml": `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<workbook xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main"
+	xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships">
+<sheets>
+	<sheet name="Orders" sheetId="1" r:id="rId1"/>
+	<sheet name="Notes" sheetId="2" r:id="rId2"/>
+</sheets>
+</workbook>`,
+	"xl/_rels/workbook.xml.rels": `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">
+	<Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/worksheet" Target="worksheets/sheet1.xml"/>
+	<Relationship Id="rId2" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/worksheet" Target="/xl/worksheets/sheet2.xml"/>
+</Relationships>`,
+	"xl/sharedStrings.xml": `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<sst xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main" count="6" uniqueCount="6">
+	<si><t>Customer</t></si>
+	<si><t>Amount</t></si>
+	<si><t>Date</t></si>
+	<si><t>Ann</t></si>
+	<si><r><t>B</t></r><r><rPr><b/></rPr><t>ob</t></r></si>
+	<si><t>Paid</t></si>
+</sst>`,
+	"xl/styles.xml": `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<styleSheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main">
+	<numFmts count="2">
+		<numFmt numFmtId="164" formatCode="yyyy\-mm\-dd\ hh:mm"/>
+		<numFmt numFmtId="165" formatCode="&quot;Day &quot;0.00;[Red]0.00"/>
+	</numFmts>
+	<cellXfs count="4">
+		<xf numFmtId="0"/>
+		<xf numFmtId="14"/>
+		<xf numFmtId="164"/>
+		<xf numFmtId="165"/>
+	</cellXfs>
+</styleSheet>`,
+	"xl/worksheets/sheet1.xml": `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<worksheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main">
+<sheetData>
+	<row r="1"><c r="A1" t="s"><v>0</v></c><c r="B1" t="s"><v>1</v></c><c r="C1" t="s"><v>2</v></c><c r="E1" t="s"><v>5</v></c></row>
+	<row r="2"><c r="A2" t="s"><v>3</v></c><c r="B2"><v>1250</v></c><c r="C2" s="1"><v>45292</v></c><c r="E2" t="b"><v>1</v></c></row>
+	<row r="4"><c r="A4" t="s"><v>4</v></c><c r="B4" s="3"><v>99.5</v></c><c r="C4" s="2"><v>45292.75</v></c><c r="D4" t="inlineStr"><is><t>vip</t></is></c><c r="F4" t="e"><v>#N/A</v></c></row>
+	<row r="5"><c r="A5"/></row>
+</sheetData>
+</worksheet>`,
+	"xl/worksheets/sheet2.xml": `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<worksheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main">
+<sheetData>
+	<row><c t="inlineStr"><is><t>hello</t></is></c><c><v>1</v></c></row>
+	<row><c t="str"><v>world</v></c></row>
+</sheetData>
+</worksheet>`,
+}
+
+func workbook(t *testing.T) []byte {
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+	for name, data := range parts {
+		w, err := zw.Create(name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		w.Write([]byte(data))
+	}
+	if err := zw.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+func TestSheet(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "sales.xlsx")
+	if err := os.WriteFile(file, workbook(t), 0644); err != nil {
+		t.Fatal(err)
+	}
+	wb, err := Open(file)
+	if err != nil {
+		t.Fatal("Open:", err)
+	}
+	defer wb.Close()
+	if names := wb.Sheets(); strings.Join(names, ",") != "Orders,Notes" {
+		t.Fatal("Sheets:", names)
+	}
+	var rows []map[string]any
+	for row := range wb.Sheet("Orders").XGo_Enum() {
+		first, _ := row.XGo_first()
+		rows = append(rows, first.Value.(map[string]any))
+	}
+	if len(rows) != 2 {
+		t.Fatal("rows:", rows)
+	}
+	want := []map[string]any{
+		{"Customer": "Ann", "Amount": int64(1250), "Date": "2024-01-01", "Paid": true},
+		{"Customer": "Bob", "Amount": 99.5, "Date": "2024-01-01T18:00:00", "D": "vip", "F": "#N/A"},
+	}
+	if !reflect.DeepEqual(rows, want) {
+		t.Fatal("rows:", rows)
+	}
+	if ns := wb.Sheet("Missing"); ns.Err == nil {
+		t.Fatal("no such sheet")
+	}
+
+	notes := wb.Sheet("Notes")
+	first, err := notes.XGo_first()
+	if err != nil || !reflect.DeepEqual(first.Value, map[string]any{"hello": "world"}) {
+		t.Fatal("header row:", first.Value, err)
+	}
+	wb.conf.NoHeader = true
+	var cells []any
+	for row := range wb.Sheet("Notes").XGo_Enum() {
+		cells = append(cells, row.XGo_Attr__0("A"))
+	}
+	if !reflect.DeepEqual(cells, []any{"hello", "world"}) {
+		t.Fatal("no header:", cells)
+	}
+}
+
+func TestSource(t *testing.T) {
+	doc := Source(workbook(t), Config{Header: []string{"name", "amount"}})
+	if doc.Err != nil {
+		t.Fatal("Source:", doc.Err)
+	}
+	var names []string
+	var date time.Time
+	for row := range doc.XGo_Elem("Orders").XGo_Child().XGo_Enum() {
+		if v, err := row.XGo_Elem("amount").Float(); err == nil && v > 1000 {
+			names = append(names, row.XGo_Attr__0("name").(string))
+			date, err = row.XGo_Elem("C").Time("2006-01-02")
+			if err != nil {
+				t.Fatal("Time:", err)
+			}
+		}
+	}
+	if strings.Join(names, ",") != "Ann" || !date.Equal(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)) {
+		t.Fatal("names:", names, date)
+	}
+	if ns := Source([]byte("not a zip")); ns.Err != ErrNotWorkbook {
+		t.Fatal("not a workbook:", ns.Err)
+	}
+}
+
+func TestFormat(t *testing.T) {
+	dates := map[string]bool{
+		"yyyy-mm-dd":      true,
+		"[h]:mm:ss":       true,
+		"h:mm AM/PM":      true,
+		"General":         false,
+		"#,##0.00":        false,
+		`"Day "0.00`:      false,
+		"0.00;[Red]0.00":  false,
+		`0\d`:             false,
+		"[$-409]mmm d, y": true,
+	}
+	for code, want := range dates {
+		if got := isDateFormat(code); got != want {
+			t.Errorf("isDateFormat(%q) = %v", code, got)
+		}
+	}
+	for col, name := range map[int]string{0: "A", 25: "Z", 26: "AA", 701: "ZZ", 702: "AAA"} {
+		if got := colName(col); got != name {
+			t.Errorf("colName(%d) = %s", col, got)
+		}
+		if got := colIndex(name + "12"); got != col {
+			t.Errorf("colIndex(%s) = %d", name, got)
+		}
+	}
+}
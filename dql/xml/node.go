@@ -19,6 +19,9 @@ package xml
 import (
 	"encoding/xml"
 	"io"
+	"strconv"
+	"strings"
+	"unicode"
 
 	"github.com/goplus/xgo/dql"
 )
@@ -40,11 +43,24 @@ type Name = xml.Name
 // An Attr represents an attribute in an XML element (Name=Value).
 type Attr = xml.Attr
 
+// A Comment represents an XML comment of the form <!--comment-->.
+// The bytes do not include the <!-- and --> comment markers.
+type Comment = xml.Comment
+
+// A ProcInst represents an XML processing instruction of the form <?target inst?>
+type ProcInst = xml.ProcInst
+
+// A Directive represents an XML directive of the form <!text>.
+// The bytes do not include the <! and > markers.
+type Directive = xml.Directive
+
 // Node represents a generic XML node with its name, attributes, and children.
+// CDATA sections are reported as xml.CharData, merged with the surrounding
+// text, since encoding/xml does not distinguish them.
 type Node struct {
 	Name     xml.Name
 	Attr     []xml.Attr
-	Children []any // can be *Node or xml.CharData
+	Children []any // can be *Node, xml.CharData, xml.Comment, xml.ProcInst or xml.Directive
 }
 
 // Parse returns the parse tree for the XML from the given Reader.
@@ -81,12 +97,140 @@ func (n *Node) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
 			text := append(xml.CharData(nil), t...)
 			n.Children = append(n.Children, text)
 
+		case xml.Comment, xml.ProcInst, xml.Directive:
+			n.Children = append(n.Children, xml.CopyToken(t))
+
 		case xml.EndElement:
 			return nil
 		}
 	}
 }
 
+// MarshalXML implements the xml.Marshaler interface for the Node struct.
+// Namespace URIs are written back using the prefixes declared by the xmlns
+// attributes of the node and its ancestors, so a parsed document can be
+// round-tripped to XML.
+func (n *Node) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	return n.encode(e, nil)
+}
+
+// nsScope maps namespace URIs to the prefixes declared for them.
+type nsScope map[string]string
+
+func (n *Node) encode(e *xml.Encoder, scope nsScope) error {
+	// Collect the namespace declarations of this node first, so that its own
+	// name and attributes can use them.
+	for _, attr := range n.Attr {
+		switch {
+		case attr.Name.Space == "xmlns":
+			scope = scope.with(attr.Value, attr.Name.Local)
+		case attr.Name.Space == "" && attr.Name.Local == "xmlns":
+			scope = scope.with(attr.Value, "")
+		}
+	}
+	var decls []xml.Attr
+	start := xml.StartElement{Name: xml.Name{Local: n.Name.Local}}
+	if space := n.Name.Space; space != "" {
+		prefix, ok := scope[space]
+		if !ok {
+			prefix = scope.newPrefix(space)
+			scope = scope.with(space, prefix)
+			decls = append(decls, xml.Attr{Name: xml.Name{Local: "xmlns:" + prefix}, Value: space})
+		}
+		if prefix != "" {
+			start.Name.Local = prefix + ":" + n.Name.Local
+		}
+	} else if scope.used("") { // undeclare the default namespace
+		scope = scope.with("", "")
+		decls = append(decls, xml.Attr{Name: xml.Name{Local: "xmlns"}})
+	}
+	start.Attr = make([]xml.Attr, 0, len(n.Attr)+len(decls))
+	for _, attr := range n.Attr {
+		name := attr.Name.Local
+		switch space := attr.Name.Space; space {
+		case "":
+		case "xmlns":
+			name = "xmlns:" + name
+		case xmlURL:
+			name = "xml:" + name
+		default:
+			prefix, ok := scope[space]
+			if !ok || prefix == "" { // attributes can't use the default namespace
+				prefix = scope.newPrefix(space)
+				scope = scope.with(space, prefix)
+				decls = append(decls, xml.Attr{Name: xml.Name{Local: "xmlns:" + prefix}, Value: space})
+			}
+			name = prefix + ":" + name
+		}
+		start.Attr = append(start.Attr, xml.Attr{Name: xml.Name{Local: name}, Value: attr.Value})
+	}
+	start.Attr = append(start.Attr, decls...)
+	if err := e.EncodeToken(start); err != nil {
+		return err
+	}
+	for _, c := range n.Children {
+		var err error
+		switch v := c.(type) {
+		case *Node:
+			err = v.encode(e, scope)
+		case xml.CharData, xml.Comment, xml.ProcInst, xml.Directive:
+			err = e.EncodeToken(v)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return e.EncodeToken(xml.EndElement{Name: start.Name})
+}
+
+// with returns a copy of the scope with uri bound to prefix. Binding the empty
+// uri to the empty prefix undeclares the default namespace.
+func (s nsScope) with(uri, prefix string) nsScope {
+	ret := make(nsScope, len(s)+1)
+	for k, v := range s {
+		if v != prefix {
+			ret[k] = v
+		}
+	}
+	if uri != "" {
+		ret[uri] = prefix
+	}
+	return ret
+}
+
+// newPrefix picks a prefix for an undeclared namespace. An undeclared prefix
+// reported by encoding/xml is kept as is.
+func (s nsScope) newPrefix(space string) string {
+	if isPrefix(space) && !s.used(space) {
+		return space
+	}
+	for i := 1; ; i++ {
+		prefix := "ns" + strconv.Itoa(i)
+		if !s.used(prefix) {
+			return prefix
+		}
+	}
+}
+
+func (s nsScope) used(prefix string) bool {
+	for _, v := range s {
+		if v == prefix {
+			return true
+		}
+	}
+	return false
+}
+
+func isPrefix(s string) bool {
+	for i, c := range s {
+		if c == '_' || unicode.IsLetter(c) || i > 0 && (c == '-' || c == '.' || unicode.IsDigit(c)) {
+			continue
+		}
+		return false
+	}
+	return s != "" && !strings.HasPrefix(strings.ToLower(s), "xml")
+}
+
 // -----------------------------------------------------------------------------
 
 // XGo_Elem returns a NodeSet containing the child nodes with the specified name.
@@ -119,14 +263,43 @@ func (n *Node) XGo_dump() NodeSet {
 
 // -----------------------------------------------------------------------------
 
-// _hasAttr returns true if the node has the specified attribute.
-func (n *Node) XGo_hasAttr(name string) bool {
-	for _, attr := range n.Attr {
-		if attr.Name.Local == name {
-			return true
+// Comments returns the text of all comment children of the node.
+func (n *Node) Comments() []string {
+	return childrenOf[xml.Comment](n)
+}
+
+// Directives returns the text of all directive children of the node.
+func (n *Node) Directives() []string {
+	return childrenOf[xml.Directive](n)
+}
+
+// ProcInsts returns all processing instruction children of the node.
+func (n *Node) ProcInsts() []ProcInst {
+	var ret []ProcInst
+	for _, c := range n.Children {
+		if pi, ok := c.(xml.ProcInst); ok {
+			ret = append(ret, pi)
 		}
 	}
-	return false
+	return ret
+}
+
+func childrenOf[T ~[]byte](n *Node) []string {
+	var ret []string
+	for _, c := range n.Children {
+		if v, ok := c.(T); ok {
+			ret = append(ret, string(v))
+		}
+	}
+	return ret
+}
+
+// -----------------------------------------------------------------------------
+
+// _hasAttr returns true if the node has the specified attribute.
+func (n *Node) XGo_hasAttr(name string) bool {
+	_, err := n.XGo_Attr__1(name)
+	return err == nil
 }
 
 // XGo_Attr returns the value of the specified attribute from the node.
@@ -143,8 +316,13 @@ func (n *Node) XGo_Attr__0(name string) string {
 //   - $name
 //   - $“attr-name”
 func (n *Node) XGo_Attr__1(name string) (string, error) {
+	return n.attr(NodeSet{}.resolve(name))
+}
+
+// attr returns the value of the first attribute matching the specified name.
+func (n *Node) attr(name xml.Name) (string, error) {
 	for _, attr := range n.Attr {
-		if attr.Name.Local == name {
+		if matchName(attr.Name, name) {
 			return attr.Value, nil
 		}
 	}
@@ -0,0 +1,71 @@
+/*
+ * Copyright (c) 2026 The XGo Authors (xgo.dev). All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package xml
+
+import (
+	"encoding/xml"
+	"io"
+)
+
+// -----------------------------------------------------------------------------
+
+// Stream returns a NodeSet that reads the XML document from r token by token
+// and yields every element with the specified name as a separately decoded
+// subtree, without building the tree of the whole document. Elements nested
+// in a matched element belong to its subtree and are not yielded again.
+//
+// The name may be in the form "space:local", where space is matched against
+// the namespace URI (or the undeclared prefix) of the elements.
+//
+// Stream consumes r, so the returned NodeSet can only be iterated once. If a
+// decoding error occurs, the iteration stops and the error is passed to each
+// onErr callback.
+func Stream(r io.Reader, name string, onErr ...func(error)) NodeSet {
+	qname := NodeSet{}.resolve(name)
+	return NodeSet{
+		Data: func(yield func(*Node) bool) {
+			d := xml.NewDecoder(r)
+			for {
+				token, err := d.Token()
+				if err != nil {
+					if err != io.EOF {
+						streamError(err, onErr)
+					}
+					return
+				}
+				if start, ok := token.(xml.StartElement); ok && matchName(start.Name, qname) {
+					node := new(Node)
+					if err = d.DecodeElement(node, &start); err != nil {
+						streamError(err, onErr)
+						return
+					}
+					if !yield(node) {
+						return
+					}
+				}
+			}
+		},
+	}
+}
+
+func streamError(err error, onErr []func(error)) {
+	for _, fn := range onErr {
+		fn(err)
+	}
+}
+
+// -----------------------------------------------------------------------------
@@ -57,3 +57,33 @@ func (p NodeSet) XGo_int() (int, error) {
 }
 
 // -----------------------------------------------------------------------------
+
+// _comment retrieves the text of the first comment child.
+// It only retrieves from the first node in the NodeSet.
+func (p NodeSet) XGo_comment() (val string, err error) {
+	node, err := p.XGo_first()
+	if err == nil {
+		if comments := node.Comments(); len(comments) > 0 {
+			return comments[0], nil
+		}
+		err = dql.ErrNotFound // comment not found on first node
+	}
+	return
+}
+
+// _procInst retrieves the first processing instruction child with the
+// specified target. It only retrieves from the first node in the NodeSet.
+func (p NodeSet) XGo_procInst(target string) (pi ProcInst, err error) {
+	node, err := p.XGo_first()
+	if err == nil {
+		for _, v := range node.ProcInsts() {
+			if v.Target == target {
+				return v, nil
+			}
+		}
+		err = dql.ErrNotFound // processing instruction not found on first node
+	}
+	return
+}
+
+// -----------------------------------------------------------------------------
@@ -18,13 +18,15 @@ package xml
 
 import (
 	"bytes"
+	"encoding/xml"
 	"fmt"
 	"io"
 	"iter"
 	"os"
+	"strings"
 
 	"github.com/goplus/xgo/dql"
-	"github.com/qiniu/x/stream"
+	"github.com/goplus/xgo/dql/stream"
 )
 
 const (
@@ -36,6 +38,7 @@ const (
 // NodeSet represents a set of XML nodes.
 type NodeSet struct {
 	Data iter.Seq[*Node]
+	NS   map[string]string // namespace prefix => namespace URI
 	Err  error
 }
 
@@ -46,10 +49,12 @@ func NodeSet_Cast(seq iter.Seq[*Node]) NodeSet {
 
 // Root creates a NodeSet containing the provided root node.
 func Root(doc *Node) NodeSet {
-	return NodeSet{
-		Data: func(yield func(*Node) bool) {
-			yield(doc)
-		},
+	return NodeSet{Data: rootSeq(doc)}
+}
+
+func rootSeq(doc *Node) iter.Seq[*Node] {
+	return func(yield func(*Node) bool) {
+		yield(doc)
 	}
 }
 
@@ -83,6 +88,7 @@ func New(r io.Reader) NodeSet {
 
 // Source creates a NodeSet from various types of sources:
 // - string: treated as an URL to read XML content from.
+// - *stream.Request: opens the resource with the request options (like HTTP headers).
 // - []byte: treated as raw XML content.
 // - io.Reader: reads XML content from the reader.
 // - *Node: creates a NodeSet containing the single provided node.
@@ -98,6 +104,13 @@ func Source(r any) (ret NodeSet) {
 		}
 		defer f.Close()
 		return New(f)
+	case *stream.Request:
+		f, err := v.Open()
+		if err != nil {
+			return NodeSet{Err: err}
+		}
+		defer f.Close()
+		return New(f)
 	case []byte:
 		r := bytes.NewReader(v)
 		return New(r)
@@ -114,6 +127,57 @@ func Source(r any) (ret NodeSet) {
 	}
 }
 
+// Namespace returns a copy of the NodeSet with the namespace prefix registered
+// to the specified URI. Registered prefixes can be used in element and
+// attribute names to match nodes by namespace:
+//   - .“prefix:element-name”
+//   - $“prefix:attr-name”
+//
+// A prefix that is not registered is matched literally against Name.Space,
+// which is how encoding/xml reports undeclared prefixes.
+func (p NodeSet) Namespace(prefix, uri string) NodeSet {
+	ns := make(map[string]string, len(p.NS)+1)
+	for k, v := range p.NS {
+		ns[k] = v
+	}
+	ns[prefix] = uri
+	p.NS = ns
+	return p
+}
+
+// xmlURL is the namespace URI that encoding/xml uses for the predefined "xml"
+// prefix.
+const xmlURL = "http://www.w3.org/XML/1998/namespace"
+
+// resolve converts a name in the form "prefix:local" into an xml.Name whose
+// Space is the namespace URI registered for the prefix. A name without prefix
+// matches nodes in any namespace.
+func (p NodeSet) resolve(name string) xml.Name {
+	prefix, local, ok := strings.Cut(name, ":")
+	if !ok {
+		return xml.Name{Local: name}
+	}
+	if uri, ok := p.NS[prefix]; ok {
+		prefix = uri
+	} else if prefix == "xml" {
+		prefix = xmlURL
+	}
+	return xml.Name{Space: prefix, Local: local}
+}
+
+// matchName reports whether the node name n matches the query name. If
+// name.Space is empty, only the local names are compared. If name.Local ends
+// with "*", it matches all local names with the preceding prefix.
+func matchName(n, name xml.Name) bool {
+	if name.Space != "" && n.Space != name.Space {
+		return false
+	}
+	if prefix, ok := strings.CutSuffix(name.Local, "*"); ok {
+		return strings.HasPrefix(n.Local, prefix)
+	}
+	return n.Local == name.Local
+}
+
 // XGo_Enum returns an iterator over the nodes in the NodeSet.
 func (p NodeSet) XGo_Enum() iter.Seq[NodeSet] {
 	if p.Err != nil {
@@ -121,56 +185,64 @@ func (p NodeSet) XGo_Enum() iter.Seq[NodeSet] {
 	}
 	return func(yield func(NodeSet) bool) {
 		p.Data(func(node *Node) bool {
-			return yield(Root(node))
+			return yield(NodeSet{Data: rootSeq(node), NS: p.NS})
 		})
 	}
 }
 
 // XGo_Select returns a NodeSet containing the nodes with the specified name.
+// If name ends with "*", it matches all names with the preceding prefix.
 //   - @name
 //   - @"element-name"
+//   - @"prefix*"
 func (p NodeSet) XGo_Select(name string) NodeSet {
 	if p.Err != nil {
 		return p
 	}
+	qname := p.resolve(name)
 	return NodeSet{
 		Data: func(yield func(*Node) bool) {
 			p.Data(func(node *Node) bool {
-				return selectNode(node, name, yield)
+				return selectNode(node, qname, yield)
 			})
 		},
+		NS: p.NS,
 	}
 }
 
 // selectNode yields the node if it matches the specified name.
-func selectNode(node *Node, name string, yield func(*Node) bool) bool {
-	if node.Name.Local == name {
+func selectNode(node *Node, name xml.Name, yield func(*Node) bool) bool {
+	if matchName(node.Name, name) {
 		return yield(node)
 	}
 	return true
 }
 
 // XGo_Elem returns a NodeSet containing the child nodes with the specified name.
+// If name ends with "*", it matches all names with the preceding prefix.
 //   - .name
 //   - .“element-name”
+//   - .“prefix*”
 func (p NodeSet) XGo_Elem(name string) NodeSet {
 	if p.Err != nil {
 		return p
 	}
+	qname := p.resolve(name)
 	return NodeSet{
 		Data: func(yield func(*Node) bool) {
 			p.Data(func(node *Node) bool {
-				return yieldNode(node, name, yield)
+				return yieldNode(node, qname, yield)
 			})
 		},
+		NS: p.NS,
 	}
 }
 
 // yieldNode yields the child node with the specified name if it exists.
-func yieldNode(n *Node, name string, yield func(*Node) bool) bool {
+func yieldNode(n *Node, name xml.Name, yield func(*Node) bool) bool {
 	for _, c := range n.Children {
 		if child, ok := c.(*Node); ok {
-			if child.Name.Local == name {
+			if matchName(child.Name, name) {
 				if !yield(child) {
 					return false
 				}
@@ -191,6 +263,7 @@ func (p NodeSet) XGo_Child() NodeSet {
 				return yieldChildNodes(n, yield)
 			})
 		},
+		NS: p.NS,
 	}
 }
 
@@ -208,27 +281,31 @@ func yieldChildNodes(n *Node, yield func(*Node) bool) bool {
 
 // XGo_Any returns a NodeSet containing all descendant nodes (including the
 // nodes themselves) with the specified name.
+// If name ends with "*", it matches all names with the preceding prefix.
 // If name is "", it returns all nodes.
 //   - .**.name
 //   - .**.“element-name”
+//   - .**.“prefix*”
 //   - .**.*
 func (p NodeSet) XGo_Any(name string) NodeSet {
 	if p.Err != nil {
 		return p
 	}
+	qname := p.resolve(name)
 	return NodeSet{
 		Data: func(yield func(*Node) bool) {
 			p.Data(func(node *Node) bool {
-				return yieldAnyNodes(node, name, yield)
+				return yieldAnyNodes(node, qname, yield)
 			})
 		},
+		NS: p.NS,
 	}
 }
 
 // yieldAnyNodes yields all descendant nodes of the given node that match the
 // specified name. If name is "", it yields all nodes.
-func yieldAnyNodes(n *Node, name string, yield func(*Node) bool) bool {
-	if name == "" || n.Name.Local == name {
+func yieldAnyNodes(n *Node, name xml.Name, yield func(*Node) bool) bool {
+	if name.Local == "" || matchName(n.Name, name) {
 		if !yield(n) {
 			return false
 		}
@@ -243,6 +320,27 @@ func yieldAnyNodes(n *Node, name string, yield func(*Node) bool) bool {
 	return true
 }
 
+// WithAttr returns a NodeSet containing the nodes that have the specified
+// attribute with the specified value. It's a shortcut of the conditional
+// form @($name == value).
+func (p NodeSet) WithAttr(name, value string) NodeSet {
+	if p.Err != nil {
+		return p
+	}
+	qname := p.resolve(name)
+	return NodeSet{
+		Data: func(yield func(*Node) bool) {
+			p.Data(func(node *Node) bool {
+				if v, err := node.attr(qname); err == nil && v == value {
+					return yield(node)
+				}
+				return true
+			})
+		},
+		NS: p.NS,
+	}
+}
+
 // -----------------------------------------------------------------------------
 
 // _all returns a NodeSet containing all nodes.
@@ -298,6 +396,34 @@ func (p NodeSet) XGo_dump() NodeSet {
 
 // -----------------------------------------------------------------------------
 
+// Write writes the XML encoding of all nodes in the NodeSet to w.
+func (p NodeSet) Write(w io.Writer) (err error) {
+	if p.Err != nil {
+		return p.Err
+	}
+	e := xml.NewEncoder(w)
+	p.Data(func(node *Node) bool {
+		err = node.encode(e, nil)
+		return err == nil
+	})
+	if err == nil {
+		err = e.Close()
+	}
+	return
+}
+
+// String returns the XML encoding of all nodes in the NodeSet. If there is an
+// error, it returns the error message instead.
+func (p NodeSet) String() string {
+	var b strings.Builder
+	if err := p.Write(&b); err != nil {
+		return "error: " + err.Error()
+	}
+	return b.String()
+}
+
+// -----------------------------------------------------------------------------
+
 // _ok returns true if there is no error in the NodeSet.
 func (p NodeSet) XGo_ok() bool {
 	return p.Err == nil
@@ -316,7 +442,8 @@ func (p NodeSet) XGo_first() (*Node, error) {
 func (p NodeSet) XGo_hasAttr(name string) bool {
 	node, err := p.XGo_first()
 	if err == nil {
-		return node.XGo_hasAttr(name)
+		_, err = node.attr(p.resolve(name))
+		return err == nil
 	}
 	return false
 }
@@ -337,7 +464,7 @@ func (p NodeSet) XGo_Attr__0(name string) string {
 func (p NodeSet) XGo_Attr__1(name string) (val string, err error) {
 	node, err := p.XGo_first()
 	if err == nil {
-		return node.XGo_Attr__1(name)
+		return node.attr(p.resolve(name))
 	}
 	return
 }
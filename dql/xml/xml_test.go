@@ -0,0 +1,264 @@
+/*
+ * Copyright (c) 2026 The XGo Authors (xgo.dev). All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package xml
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/goplus/xgo/dql"
+)
+
+const soapDoc = `<soap:Envelope xmlns:soap="http://schemas.xmlsoap.org/soap/envelope/" xmlns:m="urn:example">
+<soap:Body>
+  <m:GetPrice m:currency="USD" xml:lang="en"><m:Item>Apple</m:Item><Item>Pear</Item></m:GetPrice>
+</soap:Body>
+</soap:Envelope>`
+
+func TestNamespace(t *testing.T) {
+	doc := New(strings.NewReader(soapDoc)).
+		Namespace("s", "http://schemas.xmlsoap.org/soap/envelope/").
+		Namespace("m", "urn:example")
+
+	price := doc.XGo_Elem("s:Body").XGo_Elem("m:GetPrice")
+	if !price.XGo_ok() {
+		t.Fatal("GetPrice:", price.Err)
+	}
+	if v := price.XGo_Attr__0("m:currency"); v != "USD" {
+		t.Fatal("m:currency:", v)
+	}
+	if v := price.XGo_Attr__0("currency"); v != "USD" {
+		t.Fatal("currency:", v)
+	}
+	if v := price.XGo_Attr__0("xml:lang"); v != "en" {
+		t.Fatal("xml:lang:", v)
+	}
+	if price.XGo_hasAttr("x:currency") {
+		t.Fatal("x:currency: unexpected attribute")
+	}
+
+	if v := price.XGo_Elem("m:Item").XGo_text__0(); v != "Apple" {
+		t.Fatal("m:Item:", v)
+	}
+	if n := len(dql.Collect(doc.XGo_Any("Item").Data)); n != 2 {
+		t.Fatal("Item count:", n)
+	}
+	if n := len(dql.Collect(doc.XGo_Any("m:Item").Data)); n != 1 {
+		t.Fatal("m:Item count:", n)
+	}
+	if doc.XGo_Elem("soap:Body").XGo_one().XGo_ok() {
+		t.Fatal("soap:Body: prefix should be resolved by registration, not by document")
+	}
+}
+
+func TestStream(t *testing.T) {
+	const feed = `<urlset>
+	<url><loc>a</loc><url><loc>nested</loc></url></url>
+	<meta/>
+	<url><loc>b</loc></url>
+	<url><loc>c</loc></url>
+</urlset>`
+	var locs []string
+	Stream(strings.NewReader(feed), "url").XGo_Enum()(func(ns NodeSet) bool {
+		locs = append(locs, ns.XGo_Elem("loc").XGo_text__0())
+		return len(locs) < 2
+	})
+	if strings.Join(locs, ",") != "a,b" {
+		t.Fatal("Stream:", locs)
+	}
+
+	var err error
+	n := len(dql.Collect(Stream(strings.NewReader("<a><url/><url>"), "url", func(e error) {
+		err = e
+	}).Data))
+	if n != 1 || err == nil {
+		t.Fatal("Stream error:", n, err)
+	}
+}
+
+func TestMarshal(t *testing.T) {
+	doc := New(strings.NewReader(soapDoc))
+	out := doc.String()
+	if out != soapDoc {
+		t.Fatal("round trip:\n", out)
+	}
+	price := doc.Namespace("m", "urn:example").XGo_Any("m:GetPrice")
+	if v := price.String(); v != `<ns1:GetPrice ns1:currency="USD" xml:lang="en" xmlns:ns1="urn:example">`+
+		`<ns1:Item>Apple</ns1:Item><Item>Pear</Item></ns1:GetPrice>` {
+		t.Fatal("GetPrice:", v)
+	}
+
+	node := &Node{
+		Name:     Name{Space: "urn:a", Local: "a"},
+		Attr:     []Attr{{Name: Name{Space: "urn:b", Local: "x"}, Value: "1<2"}},
+		Children: []any{CharData("&")},
+	}
+	if v := Root(node).String(); v != `<ns1:a ns2:x="1&lt;2" xmlns:ns1="urn:a" xmlns:ns2="urn:b">&amp;</ns1:a>` {
+		t.Fatal("Node:", v)
+	}
+
+	doc = New(strings.NewReader(`<a xmlns="urn:a"><b/></a>`))
+	b, _ := doc.XGo_Elem("b").XGo_first()
+	b.Name.Space = ""
+	if v := doc.String(); v != `<a xmlns="urn:a"><b xmlns=""></b></a>` {
+		t.Fatal("default namespace:", v)
+	}
+}
+
+func TestSpecialChildren(t *testing.T) {
+	const src = `<doc><!-- first --><?render mode="fast"?><![CDATA[a<b]]><!-- second --></doc>`
+	doc := New(strings.NewReader(src))
+	if v, err := doc.XGo_comment(); err != nil || v != " first " {
+		t.Fatal("comment:", v, err)
+	}
+	if pi, err := doc.XGo_procInst("render"); err != nil || string(pi.Inst) != `mode="fast"` {
+		t.Fatal("procInst:", pi, err)
+	}
+	if _, err := doc.XGo_procInst("xml"); err != dql.ErrNotFound {
+		t.Fatal("procInst xml:", err)
+	}
+	if v := doc.XGo_text__0(); v != "a<b" {
+		t.Fatal("text:", v)
+	}
+	node, _ := doc.XGo_first()
+	if v := node.Comments(); len(v) != 2 || v[1] != " second " {
+		t.Fatal("Comments:", v)
+	}
+	if v := doc.String(); v != `<doc><!-- first --><?render mode="fast"?>a&lt;b<!-- second --></doc>` {
+		t.Fatal("String:", v)
+	}
+}
+
+func TestWithAttr(t *testing.T) {
+	const src = `<doc><animals>
+	<animal class="gopher">Line 1</animal>
+	<animal class="zebra">Line 2</animal>
+	<animalia class="zebra">Line 3</animalia>
+	<plant class="zebra">Line 4</plant>
+</animals></doc>`
+	doc := New(strings.NewReader(src))
+	var texts []string
+	doc.XGo_Elem("animals").XGo_Elem("animal*").WithAttr("class", "zebra").XGo_Enum()(func(ns NodeSet) bool {
+		texts = append(texts, ns.XGo_text__0())
+		return true
+	})
+	if strings.Join(texts, ",") != "Line 2,Line 3" {
+		t.Fatal("WithAttr:", texts)
+	}
+	if n := len(dql.Collect(doc.XGo_Any("*").Data)); n != 6 {
+		t.Fatal("XGo_Any(*):", n)
+	}
+	if n := len(dql.Collect(doc.XGo_Any("").XGo_Select("pl*").Data)); n != 1 {
+		t.Fatal("XGo_Select(pl*):", n)
+	}
+}
+
+func TestXPath(t *testing.T) {
+	const src = `<store>
+	<book lang="en" price="8"><title>Go</title><author>Rob</author><author>Ken</author></book>
+	<book lang="zh" price="12"><title>XGo</title><author>Xu</author></book>
+	<shelf><book lang="en" price="30"><title>Plan 9</title></book></shelf>
+</store>`
+	doc := New(strings.NewReader(src)).Namespace("x", "urn:none")
+	tests := []struct {
+		expr string
+		want string
+	}{
+		{"/store/book/title", "Go,XGo"},
+		{"//book/title/text()", "Go,XGo,Plan 9"},
+		{"book[2]/title", "XGo"},
+		{"book[last()]/@lang", "zh"},
+		{"//book[@price<10 or @lang='zh']/title", "Go,XGo"},
+		{"//book[author='Ken']/title", "Go"},
+		{"//book[not(author)]/title", "Plan 9"},
+		{"//book[count(author)>=1 and starts-with(title,'X')]/@price", "12"},
+		{"//title[contains(., 'o')]", "Go,XGo"},
+		{"book[1]/author[2] | shelf/book/title", "Ken,Plan 9"},
+		{"count(//author)", "3"},
+		{"//*[@lang='en']/@*", "en,8,en,30"},
+		{"//x:book", ""},
+		{"normalize-space(' a  b ')", "a b"},
+	}
+	for _, tt := range tests {
+		got, err := doc.XPathText(tt.expr)
+		if err != nil {
+			t.Fatal(tt.expr, err)
+		}
+		if v := strings.Join(got, ","); v != tt.want {
+			t.Errorf("%s: got %q, want %q", tt.expr, v, tt.want)
+		}
+	}
+
+	if n := len(dql.Collect(doc.XPath("//book[@lang='en']").Data)); n != 2 {
+		t.Fatal("XPath:", n)
+	}
+	if n := len(dql.Collect(doc.XPath("//@lang").Data)); n != 0 {
+		t.Fatal("XPath(//@lang):", n)
+	}
+	if v, _ := doc.XPathText("//@lang"); strings.Join(v, ",") != "en,zh,en" {
+		t.Fatal("XPathText(//@lang):", v)
+	}
+	if v, _ := doc.XPathText("count(//*)"); v[0] != "11" {
+		t.Fatal("count(//*):", v)
+	}
+	for _, expr := range []string{"", "book[", "..", "'abc", "book]", "count()", "text(1)"} {
+		if _, err := doc.XPathText(expr); !errors.Is(err, ErrXPathSyntax) {
+			t.Errorf("%q: expected syntax error, got %v", expr, err)
+		}
+	}
+	if err := doc.XPath("a[").Err; err == nil {
+		t.Fatal("XPath: expected error")
+	}
+}
+
+func TestXPathOrder(t *testing.T) {
+	const src = `<a><b id="1"><b id="2">x<c>y</c>z</b></b><c><b id="3"/></c><b id="4"/></a>`
+	doc := New(strings.NewReader(src))
+	tests := []struct {
+		expr string
+		want string
+	}{
+		{"//b/@id", "1,2,3,4"},
+		{"//b[1]/@id", "1,2,3"},
+		{"(//b)[1]/@id", "1"},
+		{"(//b)[last()]/@id", "4"},
+		{"(//b/@id)[2]", "2"},
+		{"//c//text() | //b[@id='2']/text()", "x,y,z"},
+		{"//b[@id='4'] | //b[@id='1']/@id | //b[@id='4']", "1,"},
+		{"count(//b | //b[@id>2])", "4"},
+		{"//@id | //b/@id", "1,2,3,4"},
+		{"string(//b[@id='3'] | //b[@id='2'])", "xyz"},
+	}
+	for _, tt := range tests {
+		got, err := doc.XPathText(tt.expr)
+		if err != nil {
+			t.Fatal(tt.expr, err)
+		}
+		if v := strings.Join(got, ","); v != tt.want {
+			t.Errorf("%s: got %q, want %q", tt.expr, v, tt.want)
+		}
+	}
+
+	var ids []string
+	for n := range doc.XPath("//b | //c").Data {
+		ids = append(ids, n.Name.Local)
+	}
+	if v := strings.Join(ids, ","); v != "b,b,c,c,b,b" {
+		t.Fatal("XPath(//b | //c):", v)
+	}
+}
@@ -0,0 +1,810 @@
+/*
+ * Copyright (c) 2026 The XGo Authors (xgo.dev). All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package xml
+
+import (
+	"encoding/xml"
+	"errors"
+	"math"
+	"slices"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// -----------------------------------------------------------------------------
+
+// XPath returns a NodeSet containing the elements selected by the XPath
+// expression, evaluated with each node in the NodeSet as the context node.
+// Items that are not elements (attributes, text nodes) are skipped, use
+// XPathText to retrieve them.
+//
+// An XPath 1.0 subset is supported:
+//   - location paths: /a/b, //b, a//b, ., *, prefix:name
+//   - attribute and text steps: @name, @*, text()
+//   - predicates: [2], [@class='zebra'], [b>10 and not(@hidden)], (//b)[1]
+//   - operators: or, and, =, !=, <, <=, >, >=, |
+//   - functions: position(), last(), count(), not(), contains(),
+//     starts-with(), string(), number(), normalize-space()
+//
+// Absolute paths treat each node in the NodeSet as the document element.
+// Namespace prefixes are resolved by the prefixes registered by Namespace.
+func (p NodeSet) XPath(expr string) NodeSet {
+	if p.Err != nil {
+		return p
+	}
+	e, err := compileXPath(expr)
+	if err != nil {
+		return NodeSet{Err: err}
+	}
+	return NodeSet{
+		Data: func(yield func(*Node) bool) {
+			p.Data(func(node *Node) bool {
+				v := e.eval(&xpathCtx{node: node, pos: 1, size: 1, ns: p})
+				items, ok := v.([]any)
+				if !ok {
+					return true
+				}
+				for _, item := range items {
+					if n, ok := item.(*Node); ok {
+						if !yield(n) {
+							return false
+						}
+					}
+				}
+				return true
+			})
+		},
+		NS: p.NS,
+	}
+}
+
+// XPathText evaluates the XPath expression with each node in the NodeSet as
+// the context node, and returns the string values of the results. The string
+// value of an element is the concatenation of all its descendant text. If
+// the expression doesn't return a node-set (for example, count(//a)), its
+// value is converted to a string.
+func (p NodeSet) XPathText(expr string) (ret []string, err error) {
+	if p.Err != nil {
+		return nil, p.Err
+	}
+	e, err := compileXPath(expr)
+	if err != nil {
+		return
+	}
+	p.Data(func(node *Node) bool {
+		v := e.eval(&xpathCtx{node: node, pos: 1, size: 1, ns: p})
+		if items, ok := v.([]any); ok {
+			for _, item := range items {
+				ret = append(ret, stringValue(item))
+			}
+		} else {
+			ret = append(ret, toString(v))
+		}
+		return true
+	})
+	return
+}
+
+// XPath returns a NodeSet containing the elements selected by the XPath
+// expression, evaluated with the node as the context node.
+func (n *Node) XPath(expr string) NodeSet {
+	return Root(n).XPath(expr)
+}
+
+// -----------------------------------------------------------------------------
+
+// ErrXPathSyntax is the base error of all XPath syntax errors.
+var ErrXPathSyntax = errors.New("xpath: syntax error")
+
+type xpathError struct {
+	expr string
+	pos  int
+	msg  string
+}
+
+func (e *xpathError) Error() string {
+	return "xpath: " + e.msg + " at offset " + strconv.Itoa(e.pos) + " in " + strconv.Quote(e.expr)
+}
+
+func (e *xpathError) Unwrap() error {
+	return ErrXPathSyntax
+}
+
+// xpathCtx is the evaluation context of an XPath expression. Values are
+// represented as: []any (node-set in document order), string, float64 and
+// bool. Items of a node-set are *Node (element), *xml.Attr (attribute) and
+// *any (text node, pointing into the Children of its parent).
+type xpathCtx struct {
+	node *Node
+	pos  int
+	size int
+	ns   NodeSet
+}
+
+type xpathExpr interface {
+	eval(ctx *xpathCtx) any
+}
+
+// -----------------------------------------------------------------------------
+
+const (
+	axisChild = iota
+	axisSelf
+	axisAttr
+	axisText
+)
+
+type xpathStep struct {
+	axis  int
+	desc  bool // step is preceded by "//"
+	name  string
+	preds []xpathExpr
+}
+
+type xpathPath struct {
+	from  xpathExpr // filter expression the path starts from, if not nil
+	abs   bool
+	steps []*xpathStep
+}
+
+func (e *xpathPath) eval(ctx *xpathCtx) any {
+	var items []any
+	switch {
+	case e.from != nil:
+		items, _ = e.from.eval(ctx).([]any)
+	case e.abs:
+		items = []any{&Node{Children: []any{ctx.node}}} // document node
+	default:
+		items = []any{ctx.node}
+	}
+	var order map[any]int
+	for _, step := range e.steps {
+		var next []any
+		seen := make(map[any]bool)
+		for _, item := range items {
+			n, ok := item.(*Node)
+			if !ok {
+				continue
+			}
+			for _, v := range step.apply(n, ctx.ns) {
+				if !seen[v] {
+					seen[v] = true
+					next = append(next, v)
+				}
+			}
+		}
+		if len(next) > 1 && (step.desc || len(items) > 1) {
+			if order == nil {
+				order = docOrder(ctx.node)
+			}
+			sortItems(next, order)
+		}
+		items = next
+	}
+	return items
+}
+
+// docOrder returns the positions of all elements, attributes and text nodes
+// under root in document order.
+func docOrder(root *Node) map[any]int {
+	order := make(map[any]int)
+	var walk func(n *Node)
+	walk = func(n *Node) {
+		order[n] = len(order)
+		for i := range n.Attr {
+			order[&n.Attr[i]] = len(order)
+		}
+		for i, c := range n.Children {
+			switch c := c.(type) {
+			case *Node:
+				walk(c)
+			case xml.CharData:
+				order[&n.Children[i]] = len(order)
+			}
+		}
+	}
+	walk(root)
+	return order
+}
+
+// sortItems sorts the items of a node-set in document order.
+func sortItems(items []any, order map[any]int) {
+	slices.SortStableFunc(items, func(a, b any) int {
+		return order[a] - order[b]
+	})
+}
+
+// apply evaluates the step with n as the context node.
+func (step *xpathStep) apply(n *Node, ns NodeSet) []any {
+	if step.desc {
+		// descendant-or-self::node()/step
+		var ret []any
+		var walk func(n *Node)
+		walk = func(n *Node) {
+			ret = append(ret, step.filter(step.candidates(n, ns), ns)...)
+			for _, c := range n.Children {
+				if child, ok := c.(*Node); ok {
+					walk(child)
+				}
+			}
+		}
+		walk(n)
+		return ret
+	}
+	return step.filter(step.candidates(n, ns), ns)
+}
+
+func (step *xpathStep) candidates(n *Node, ns NodeSet) (ret []any) {
+	switch step.axis {
+	case axisSelf:
+		return []any{n}
+	case axisAttr:
+		name := ns.resolve(step.name)
+		for i, attr := range n.Attr {
+			if matchName(attr.Name, name) {
+				ret = append(ret, &n.Attr[i])
+			}
+		}
+	case axisText:
+		for i, c := range n.Children {
+			if _, ok := c.(xml.CharData); ok {
+				ret = append(ret, &n.Children[i])
+			}
+		}
+	default:
+		name := ns.resolve(step.name)
+		for _, c := range n.Children {
+			if child, ok := c.(*Node); ok && matchName(child.Name, name) {
+				ret = append(ret, child)
+			}
+		}
+	}
+	return
+}
+
+func (step *xpathStep) filter(items []any, ns NodeSet) []any {
+	return filterItems(items, step.preds, ns)
+}
+
+// filterItems returns the items of a node-set that satisfy all predicates.
+func filterItems(items []any, preds []xpathExpr, ns NodeSet) []any {
+	for _, pred := range preds {
+		var ret []any
+		for i, item := range items {
+			n, ok := item.(*Node)
+			if !ok {
+				n = &Node{Children: []any{xml.CharData(stringValue(item))}}
+			}
+			ctx := &xpathCtx{node: n, pos: i + 1, size: len(items), ns: ns}
+			v := pred.eval(ctx)
+			if f, ok := v.(float64); ok {
+				if f == float64(ctx.pos) {
+					ret = append(ret, item)
+				}
+			} else if toBool(v) {
+				ret = append(ret, item)
+			}
+		}
+		items = ret
+	}
+	return items
+}
+
+// -----------------------------------------------------------------------------
+
+type xpathLit struct {
+	val any
+}
+
+func (e *xpathLit) eval(ctx *xpathCtx) any {
+	return e.val
+}
+
+type xpathUnion struct {
+	x, y xpathExpr
+}
+
+func (e *xpathUnion) eval(ctx *xpathCtx) any {
+	x, _ := e.x.eval(ctx).([]any)
+	y, _ := e.y.eval(ctx).([]any)
+	ret := slices.Clone(x)
+	seen := make(map[any]bool, len(x))
+	for _, item := range x {
+		seen[item] = true
+	}
+	for _, item := range y {
+		if !seen[item] {
+			seen[item] = true
+			ret = append(ret, item)
+		}
+	}
+	if len(x) > 0 && len(y) > 0 {
+		sortItems(ret, docOrder(ctx.node))
+	}
+	return ret
+}
+
+// xpathFilter is a filter expression: a parenthesized expression followed
+// by predicates, such as (//b)[1].
+type xpathFilter struct {
+	x     xpathExpr
+	preds []xpathExpr
+}
+
+func (e *xpathFilter) eval(ctx *xpathCtx) any {
+	items, _ := e.x.eval(ctx).([]any)
+	return filterItems(items, e.preds, ctx.ns)
+}
+
+type xpathLogic struct {
+	and  bool
+	x, y xpathExpr
+}
+
+func (e *xpathLogic) eval(ctx *xpathCtx) any {
+	x := toBool(e.x.eval(ctx))
+	if x != e.and {
+		return x
+	}
+	return toBool(e.y.eval(ctx))
+}
+
+type xpathCompare struct {
+	op   string
+	x, y xpathExpr
+}
+
+func (e *xpathCompare) eval(ctx *xpathCtx) any {
+	return compare(e.op, e.x.eval(ctx), e.y.eval(ctx))
+}
+
+func compare(op string, x, y any) bool {
+	if xs, ok := x.([]any); ok {
+		for _, item := range xs {
+			if compare(op, stringValue(item), y) {
+				return true
+			}
+		}
+		return false
+	}
+	if ys, ok := y.([]any); ok {
+		for _, item := range ys {
+			if compare(op, x, stringValue(item)) {
+				return true
+			}
+		}
+		return false
+	}
+	switch op {
+	case "=", "!=":
+		var eq bool
+		switch {
+		case isBool(x) || isBool(y):
+			eq = toBool(x) == toBool(y)
+		case isNumber(x) || isNumber(y):
+			eq = toNumber(x) == toNumber(y)
+		default:
+			eq = toString(x) == toString(y)
+		}
+		return eq == (op == "=")
+	}
+	a, b := toNumber(x), toNumber(y)
+	switch op {
+	case "<":
+		return a < b
+	case "<=":
+		return a <= b
+	case ">":
+		return a > b
+	default:
+		return a >= b
+	}
+}
+
+type xpathCall struct {
+	fn   string
+	args []xpathExpr
+}
+
+var xpathFuncs = map[string]int{ // function name => number of arguments
+	"position":        0,
+	"last":            0,
+	"count":           1,
+	"not":             1,
+	"contains":        2,
+	"starts-with":     2,
+	"string":          -1,
+	"number":          -1,
+	"normalize-space": -1,
+}
+
+func (e *xpathCall) eval(ctx *xpathCtx) any {
+	arg := func(i int) any {
+		if i < len(e.args) {
+			return e.args[i].eval(ctx)
+		}
+		return []any{ctx.node}
+	}
+	switch e.fn {
+	case "position":
+		return float64(ctx.pos)
+	case "last":
+		return float64(ctx.size)
+	case "count":
+		items, _ := arg(0).([]any)
+		return float64(len(items))
+	case "not":
+		return !toBool(arg(0))
+	case "contains":
+		return strings.Contains(toString(arg(0)), toString(arg(1)))
+	case "starts-with":
+		return strings.HasPrefix(toString(arg(0)), toString(arg(1)))
+	case "string":
+		return toString(arg(0))
+	case "number":
+		return toNumber(arg(0))
+	default: // normalize-space
+		return strings.Join(strings.Fields(toString(arg(0))), " ")
+	}
+}
+
+// -----------------------------------------------------------------------------
+
+func isBool(v any) bool {
+	_, ok := v.(bool)
+	return ok
+}
+
+func isNumber(v any) bool {
+	_, ok := v.(float64)
+	return ok
+}
+
+func toBool(v any) bool {
+	switch v := v.(type) {
+	case bool:
+		return v
+	case float64:
+		return v != 0 && !math.IsNaN(v)
+	case string:
+		return v != ""
+	default:
+		return len(v.([]any)) > 0
+	}
+}
+
+func toNumber(v any) float64 {
+	switch v := v.(type) {
+	case float64:
+		return v
+	case bool:
+		if v {
+			return 1
+		}
+		return 0
+	}
+	f, err := strconv.ParseFloat(strings.TrimSpace(toString(v)), 64)
+	if err != nil {
+		return math.NaN()
+	}
+	return f
+}
+
+func toString(v any) string {
+	switch v := v.(type) {
+	case string:
+		return v
+	case bool:
+		return strconv.FormatBool(v)
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	default:
+		if items := v.([]any); len(items) > 0 {
+			return stringValue(items[0])
+		}
+		return ""
+	}
+}
+
+// stringValue returns the string value of a node-set item: the text of an
+// attribute or text node, or the concatenated descendant text of an element.
+func stringValue(item any) string {
+	switch v := item.(type) {
+	case *xml.Attr:
+		return v.Value
+	case *any:
+		return string((*v).(xml.CharData))
+	}
+	var b strings.Builder
+	var walk func(n *Node)
+	walk = func(n *Node) {
+		for _, c := range n.Children {
+			switch v := c.(type) {
+			case *Node:
+				walk(v)
+			case xml.CharData:
+				b.Write(v)
+			}
+		}
+	}
+	walk(item.(*Node))
+	return b.String()
+}
+
+// -----------------------------------------------------------------------------
+
+type xpathParser struct {
+	expr string
+	pos  int
+	tok  string // current token; "" means EOF
+	kind byte   // 'n' name, 's' string, 'f' number, 'o' operator
+	at   int    // offset of the current token
+}
+
+func compileXPath(expr string) (e xpathExpr, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			xe, ok := r.(*xpathError)
+			if !ok {
+				panic(r)
+			}
+			err = xe
+		}
+	}()
+	p := &xpathParser{expr: expr}
+	p.next()
+	e = p.parseOr()
+	if p.tok != "" {
+		p.fail("unexpected " + strconv.Quote(p.tok))
+	}
+	return
+}
+
+func (p *xpathParser) fail(msg string) {
+	panic(&xpathError{expr: p.expr, pos: p.at, msg: msg})
+}
+
+func isNameChar(c rune, first bool) bool {
+	return c == '_' || unicode.IsLetter(c) || !first && (c == '-' || c == '.' || unicode.IsDigit(c))
+}
+
+func (p *xpathParser) next() {
+	s := p.expr
+	for p.pos < len(s) && (s[p.pos] == ' ' || s[p.pos] == '\t' || s[p.pos] == '\n' || s[p.pos] == '\r') {
+		p.pos++
+	}
+	p.at = p.pos
+	if p.pos >= len(s) {
+		p.tok, p.kind = "", 0
+		return
+	}
+	start := p.pos
+	c := rune(s[p.pos])
+	switch {
+	case c == '\'' || c == '"':
+		end := strings.IndexByte(s[p.pos+1:], byte(c))
+		if end < 0 {
+			p.fail("unterminated string literal")
+		}
+		p.tok, p.kind = s[p.pos+1:p.pos+1+end], 's'
+		p.pos += end + 2
+		return
+	case c >= '0' && c <= '9' || c == '.' && p.pos+1 < len(s) && s[p.pos+1] >= '0' && s[p.pos+1] <= '9':
+		for p.pos < len(s) && (s[p.pos] >= '0' && s[p.pos] <= '9' || s[p.pos] == '.') {
+			p.pos++
+		}
+		p.kind = 'f'
+	case c >= 0x80 || isNameChar(c, true):
+		for _, r := range s[p.pos:] {
+			if !isNameChar(r, false) && r != ':' && r != '*' {
+				break
+			}
+			p.pos += len(string(r))
+		}
+		p.kind = 'n'
+	default:
+		p.kind = 'o'
+		for _, op := range []string{"//", "!=", "<=", ">=", ".."} {
+			if strings.HasPrefix(s[p.pos:], op) {
+				p.pos += 2
+				p.tok = op
+				return
+			}
+		}
+		p.pos++
+	}
+	p.tok = s[start:p.pos]
+}
+
+func (p *xpathParser) expect(tok string) {
+	if p.tok != tok {
+		p.fail("expected " + strconv.Quote(tok))
+	}
+	p.next()
+}
+
+func (p *xpathParser) parseOr() xpathExpr {
+	x := p.parseAnd()
+	for p.kind == 'n' && p.tok == "or" {
+		p.next()
+		x = &xpathLogic{x: x, y: p.parseAnd()}
+	}
+	return x
+}
+
+func (p *xpathParser) parseAnd() xpathExpr {
+	x := p.parseCompare()
+	for p.kind == 'n' && p.tok == "and" {
+		p.next()
+		x = &xpathLogic{and: true, x: x, y: p.parseCompare()}
+	}
+	return x
+}
+
+func (p *xpathParser) parseCompare() xpathExpr {
+	x := p.parseUnion()
+	for p.kind == 'o' {
+		switch op := p.tok; op {
+		case "=", "!=", "<", "<=", ">", ">=":
+			p.next()
+			x = &xpathCompare{op: op, x: x, y: p.parseUnion()}
+		default:
+			return x
+		}
+	}
+	return x
+}
+
+func (p *xpathParser) parseUnion() xpathExpr {
+	x := p.parsePrimary()
+	for p.kind == 'o' && p.tok == "|" {
+		p.next()
+		x = &xpathUnion{x: x, y: p.parsePrimary()}
+	}
+	return x
+}
+
+func (p *xpathParser) parsePrimary() xpathExpr {
+	switch p.kind {
+	case 's':
+		v := p.tok
+		p.next()
+		return &xpathLit{val: v}
+	case 'f':
+		v, err := strconv.ParseFloat(p.tok, 64)
+		if err != nil {
+			p.fail("invalid number " + strconv.Quote(p.tok))
+		}
+		p.next()
+		return &xpathLit{val: v}
+	case 'n':
+		if n, ok := xpathFuncs[p.tok]; ok && strings.HasPrefix(p.expr[p.pos:], "(") {
+			return p.parseCall(p.tok, n)
+		}
+	case 'o':
+		if p.tok == "(" {
+			p.next()
+			x := p.parseOr()
+			p.expect(")")
+			if preds := p.parsePreds(); preds != nil {
+				x = &xpathFilter{x: x, preds: preds}
+			}
+			if p.tok == "/" || p.tok == "//" {
+				desc := p.tok == "//"
+				p.next()
+				return p.parseSteps(&xpathPath{from: x}, desc)
+			}
+			return x
+		}
+	}
+	return p.parsePath()
+}
+
+func (p *xpathParser) parseCall(fn string, n int) xpathExpr {
+	p.next()
+	p.expect("(")
+	call := &xpathCall{fn: fn}
+	for p.tok != ")" {
+		if len(call.args) > 0 {
+			p.expect(",")
+		}
+		call.args = append(call.args, p.parseOr())
+	}
+	if n >= 0 && len(call.args) != n || n < 0 && len(call.args) > 1 {
+		p.fail("wrong number of arguments to " + fn + "()")
+	}
+	p.next()
+	return call
+}
+
+func (p *xpathParser) parsePath() xpathExpr {
+	path := new(xpathPath)
+	desc := false
+	switch p.tok {
+	case "/":
+		path.abs = true
+		p.next()
+	case "//":
+		path.abs, desc = true, true
+		p.next()
+	}
+	return p.parseSteps(path, desc)
+}
+
+func (p *xpathParser) parseSteps(path *xpathPath, desc bool) *xpathPath {
+	for {
+		step := p.parseStep()
+		step.desc = desc
+		path.steps = append(path.steps, step)
+		switch p.tok {
+		case "/":
+			desc = false
+		case "//":
+			desc = true
+		default:
+			return path
+		}
+		p.next()
+	}
+}
+
+func (p *xpathParser) parseStep() *xpathStep {
+	step := new(xpathStep)
+	switch {
+	case p.kind == 'o' && p.tok == ".":
+		step.axis = axisSelf
+		p.next()
+		return step
+	case p.kind == 'o' && p.tok == "..":
+		p.fail("parent axis is not supported")
+	case p.kind == 'o' && p.tok == "@":
+		p.next()
+		if p.kind != 'n' && p.tok != "*" {
+			p.fail("expected attribute name")
+		}
+		step.axis, step.name = axisAttr, p.tok
+	case p.kind == 'n' && p.tok == "text" && strings.HasPrefix(p.expr[p.pos:], "("):
+		p.next()
+		p.expect("(")
+		if p.tok != ")" {
+			p.fail("expected \")\"")
+		}
+		step.axis = axisText
+	case p.kind == 'n' || p.kind == 'o' && p.tok == "*":
+		step.name = p.tok
+	default:
+		if p.tok == "" {
+			p.fail("unexpected end of expression")
+		}
+		p.fail("unexpected " + strconv.Quote(p.tok))
+	}
+	p.next()
+	step.preds = p.parsePreds()
+	return step
+}
+
+func (p *xpathParser) parsePreds() (preds []xpathExpr) {
+	for p.kind == 'o' && p.tok == "[" {
+		p.next()
+		preds = append(preds, p.parseOr())
+		p.expect("]")
+	}
+	return
+}
+
+// -----------------------------------------------------------------------------
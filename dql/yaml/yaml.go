@@ -23,7 +23,7 @@ import (
 
 	"github.com/goccy/go-yaml"
 	"github.com/goplus/xgo/dql/maps"
-	"github.com/qiniu/x/stream"
+	"github.com/goplus/xgo/dql/stream"
 )
 
 const (
@@ -38,18 +38,36 @@ type Node = maps.Node
 // NodeSet represents a set of YAML nodes.
 type NodeSet = maps.NodeSet
 
-// New creates a YAML NodeSet from YAML data read from r.
+// New creates a YAML NodeSet from YAML data read from r. If the data is a
+// stream of multiple documents (separated by "---"), like the manifests of
+// Kubernetes, each document is a root node of the NodeSet:
+//
+//	for doc in yaml.source("deploy.yaml") {
+//		echo doc.$kind, doc.metadata.$name
+//	}
+//
+// Aliases are resolved to the values of their anchors, and merge keys ("<<")
+// are expanded. Mappings are decoded as map[string]any, so the order of keys
+// isn't preserved (use Sorted for a reproducible order).
 func New(r io.Reader, opts ...yaml.DecodeOption) NodeSet {
-	var data any
-	err := yaml.NewDecoder(r, opts...).Decode(&data)
-	if err != nil {
-		return NodeSet{Err: err}
+	dec := yaml.NewDecoder(r, opts...)
+	var docs []Node
+	for {
+		var data any
+		if err := dec.Decode(&data); err != nil {
+			if err == io.EOF {
+				break
+			}
+			return NodeSet{Err: err}
+		}
+		docs = append(docs, Node{Value: data})
 	}
-	return maps.New(data)
+	return maps.Nodes(docs...)
 }
 
 // Source creates a YAML NodeSet from various source types:
 // - string: treats the string as a file path, opens the file, and reads YAML data from it.
+// - *stream.Request: opens the resource with the request options (like HTTP headers).
 // - []byte: reads YAML data from the byte slice.
 // - io.Reader: reads YAML data from the provided reader.
 // - map[string]any: creates a NodeSet from the provided map.
@@ -67,6 +85,13 @@ func Source(r any, opts ...yaml.DecodeOption) (ret NodeSet) {
 		}
 		defer f.Close()
 		return New(f, opts...)
+	case *stream.Request:
+		f, err := v.Open()
+		if err != nil {
+			return NodeSet{Err: err}
+		}
+		defer f.Close()
+		return New(f, opts...)
 	case []byte:
 		r := bytes.NewReader(v)
 		return New(r, opts...)
@@ -0,0 +1,80 @@
+/*
+ * Copyright (c) 2026 The XGo Authors (xgo.dev). All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package yaml
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/goplus/xgo/dql"
+)
+
+const manifests = `defaults: &defaults
+  replicas: 2
+  image: nginx
+---
+kind: Deployment
+metadata:
+  name: web
+spec:
+  <<: *defaults
+  replicas: 3
+---
+kind: Service
+metadata:
+  name: web-svc
+---
+plain text
+`
+
+func TestDocuments(t *testing.T) {
+	docs := dql.Collect(Source([]byte(manifests)).Data)
+	if len(docs) != 4 {
+		t.Fatal("documents:", len(docs))
+	}
+	var kinds []string
+	for doc := range Source([]byte(manifests)).XGo_Enum() {
+		if kind, err := doc.XGo_Attr__1("kind"); err == nil {
+			kinds = append(kinds, kind.(string))
+		}
+	}
+	if strings.Join(kinds, ",") != "Deployment,Service" {
+		t.Fatal("kinds:", kinds)
+	}
+	if docs[3].Value != "plain text" {
+		t.Fatal("scalar document:", docs[3].Value)
+	}
+
+	spec := Source(docs[1]).XGo_Elem("spec")
+	if n, err := spec.XGo_Elem("replicas").Int(); err != nil || n != 3 {
+		t.Fatal("replicas:", n, err)
+	}
+	if image, err := spec.XGo_Elem("image").Str(); err != nil || image != "nginx" {
+		t.Fatal("image:", image, err)
+	}
+	images := Source([]byte(manifests)).JSONPath(`$[?(@.replicas > 2)].image`)
+	if v, err := images.XGo_single().XGo_value__1(); err != nil || v != "nginx" {
+		t.Fatal("JSONPath:", v, err)
+	}
+
+	if ns := Source([]byte("")); ns.Err != nil || len(dql.Collect(ns.Data)) != 0 {
+		t.Fatal("empty:", ns.Err)
+	}
+	if ns := Source([]byte("a: [")); ns.Err == nil {
+		t.Fatal("invalid YAML: no error")
+	}
+}
@@ -18,14 +18,80 @@ package csv
 
 import (
 	"encoding/csv"
+	"fmt"
 	"strings"
+	"unicode/utf8"
 )
 
 // Object is a type alias for a 2D slice of strings, representing the rows and
 // columns of a CSV file.
 type Object = [][]string
 
-// New creates a new CSV object from a string.
-func New(text string) (records Object, err error) {
-	return csv.NewReader(strings.NewReader(text)).ReadAll()
+// Option configures how the CSV text is read.
+type Option func(r *csv.Reader)
+
+// Comma returns an Option setting the field delimiter.
+func Comma(c rune) Option {
+	return func(r *csv.Reader) { r.Comma = c }
+}
+
+// Comment returns an Option setting the comment character: lines beginning
+// with it are ignored.
+func Comment(c rune) Option {
+	return func(r *csv.Reader) { r.Comment = c }
+}
+
+// FieldsPerRecord returns an Option setting the number of fields per record
+// (see csv.Reader.FieldsPerRecord). If n is negative, records may have a
+// variable number of fields.
+func FieldsPerRecord(n int) Option {
+	return func(r *csv.Reader) { r.FieldsPerRecord = n }
+}
+
+var (
+	// LazyQuotes allows quotes in unquoted fields, and non-doubled quotes in
+	// quoted fields.
+	LazyQuotes Option = func(r *csv.Reader) { r.LazyQuotes = true }
+
+	// TrimLeadingSpace ignores the leading white space of fields.
+	TrimLeadingSpace Option = func(r *csv.Reader) { r.TrimLeadingSpace = true }
+)
+
+// New creates a new CSV object from a string. Options are Options, or runes
+// (or strings of a single character) as the field delimiter, so the
+// delimiter can be specified in the header of a domain text literal:
+//
+//	rows := csv`> ';'
+//	name;age
+//	ken;18
+//	`
+func New(text string, opts ...any) (records Object, err error) {
+	r := csv.NewReader(strings.NewReader(text))
+	for _, opt := range opts {
+		switch v := opt.(type) {
+		case Option:
+			v(r)
+		case func(*csv.Reader):
+			v(r)
+		case rune:
+			r.Comma = v
+		case string:
+			c, size := utf8.DecodeRuneInString(v)
+			if size == 0 || size != len(v) {
+				return nil, fmt.Errorf("csv: invalid delimiter %q", v)
+			}
+			r.Comma = c
+		default:
+			return nil, fmt.Errorf("csv: unsupported option %T", opt)
+		}
+	}
+	return r.ReadAll()
+}
+
+// Validate reads text as CSV with the default options (comma separated), and
+// reports quoting errors and records whose number of fields differs from the
+// first record. Literals with a delimiter in the header aren't validated.
+func Validate(text string) error {
+	_, err := New(text)
+	return err
 }
@@ -18,6 +18,8 @@ package json
 
 import (
 	"encoding/json"
+	"errors"
+	"fmt"
 	"strings"
 )
 
@@ -30,3 +32,66 @@ func New(text string) (ret Object, err error) {
 	err = json.NewDecoder(strings.NewReader(text)).Decode(&ret)
 	return
 }
+
+// NewEx creates a new JSON object from text parts and the values between them,
+// which are encoded as JSON values. It's called by json literals with ${expr}
+// placeholders:
+//
+//	doc := json`{"name": ${name}, "tags": ${tags}}`!
+//
+// A placeholder in a JSON string is replaced by the text of its value (escaped
+// as JSON string content), instead of a JSON value:
+//
+//	doc := json`{"greeting": "hello ${name}"}`!
+func NewEx(texts []string, args ...any) (ret Object, err error) {
+	if len(texts) != len(args)+1 {
+		return nil, errors.New("json.NewEx: mismatched texts and args")
+	}
+	var b strings.Builder
+	var inStr bool
+	for i, arg := range args {
+		b.WriteString(texts[i])
+		if inStr = inString(texts[i], inStr); inStr {
+			s, ok := arg.(string)
+			if !ok {
+				s = fmt.Sprint(arg)
+			}
+			arg = s
+		}
+		v, err := json.Marshal(arg)
+		if err != nil {
+			return nil, err
+		}
+		if inStr {
+			v = v[1 : len(v)-1] // without quotes
+		}
+		b.Write(v)
+	}
+	b.WriteString(texts[len(args)])
+	return New(b.String())
+}
+
+// inString reports whether the end of text is in a JSON string, where in
+// reports whether text starts in a JSON string.
+func inString(text string, in bool) bool {
+	escaped := false
+	for i := 0; i < len(text); i++ {
+		switch c := text[i]; {
+		case escaped:
+			escaped = false
+		case c == '"':
+			in = !in
+		case c == '\\' && in:
+			escaped = true
+		}
+	}
+	return in
+}
+
+// Validate reports the syntax error of the first JSON value of text, which is
+// the value New decodes. json literals with ${expr} placeholders are built by
+// NewEx at run time, so only literals without placeholders are validated.
+func Validate(text string) error {
+	_, err := New(text)
+	return err
+}
@@ -0,0 +1,36 @@
+/*
+ * Copyright (c) 2026 The XGo Authors (xgo.dev). All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package json
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestNewEx(t *testing.T) {
+	ret, err := NewEx([]string{`{"name": `, `, "greeting": "hello \"`, `\"!", "n": "`, `"}`}, "ken", `a "b"`, 1)
+	if err != nil {
+		t.Fatal("NewEx:", err)
+	}
+	want := map[string]any{"name": "ken", "greeting": `hello "a "b""!`, "n": "1"}
+	if !reflect.DeepEqual(ret, want) {
+		t.Fatal("NewEx:", ret)
+	}
+	if _, err = NewEx([]string{`{}`}, 1); err == nil {
+		t.Fatal("NewEx: no error")
+	}
+}
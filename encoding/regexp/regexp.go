@@ -27,3 +27,11 @@ type Object = *regexp.Regexp
 func New(text string) (Object, error) {
 	return regexp.Compile(text)
 }
+
+// Validate reports the error of compiling text as an RE2 regular expression,
+// such as a missing closing bracket, so a typo in a regexp literal fails to
+// compile instead of returning an error on first use.
+func Validate(text string) error {
+	_, err := New(text)
+	return err
+}
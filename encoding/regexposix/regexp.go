@@ -27,3 +27,11 @@ type Object = *regexp.Regexp
 func New(text string) (Object, error) {
 	return regexp.CompilePOSIX(text)
 }
+
+// Validate reports the error of compiling text as a POSIX ERE regular
+// expression. RE2-only syntax like \d is rejected here, even though it's
+// accepted by regexp literals.
+func Validate(text string) error {
+	_, err := New(text)
+	return err
+}
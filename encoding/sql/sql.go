@@ -0,0 +1,68 @@
+/*
+ * Copyright (c) 2026 The XGo Authors (xgo.dev). All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package sql
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+)
+
+// Object is a type alias for *Query.
+type Object = *Query
+
+// Query represents a SQL statement with its arguments. Values of ${expr}
+// placeholders in a sql literal are passed as arguments rather than spliced
+// into the statement, so they can't be used for SQL injection:
+//
+//	q := sql`SELECT * FROM users WHERE id = ${id}`!
+//	rows := db.query(q.SQL, q.Args...)
+type Query struct {
+	SQL  string // statement with a "?" placeholder for each argument
+	Args []any  // arguments of placeholders
+
+	texts []string
+}
+
+// New creates a new SQL statement without arguments from a string.
+func New(text string) (Object, error) {
+	return &Query{SQL: text, texts: []string{text}}, nil
+}
+
+// NewEx creates a new SQL statement from text parts and the arguments between
+// them. It's called by sql literals with ${expr} placeholders.
+func NewEx(texts []string, args ...any) (Object, error) {
+	if len(texts) != len(args)+1 {
+		return nil, errors.New("sql.NewEx: mismatched texts and args")
+	}
+	return &Query{SQL: strings.Join(texts, "?"), Args: args, texts: texts}, nil
+}
+
+// Numbered returns the statement with numbered placeholders, which are prefix
+// followed by the argument index starting at 1, like $1 (PostgreSQL) or @p1
+// (SQL Server).
+func (q *Query) Numbered(prefix string) string {
+	var b strings.Builder
+	for i, text := range q.texts {
+		if i > 0 {
+			b.WriteString(prefix)
+			b.WriteString(strconv.Itoa(i))
+		}
+		b.WriteString(text)
+	}
+	return b.String()
+}
@@ -0,0 +1,41 @@
+/*
+ * Copyright (c) 2026 The XGo Authors (xgo.dev). All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package sql
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestNewEx(t *testing.T) {
+	q, err := NewEx([]string{"SELECT * FROM t WHERE id = ", " AND name = ", ""}, 1, "ken")
+	if err != nil {
+		t.Fatal("NewEx:", err)
+	}
+	if q.SQL != "SELECT * FROM t WHERE id = ? AND name = ?" || !reflect.DeepEqual(q.Args, []any{1, "ken"}) {
+		t.Fatal("NewEx:", q.SQL, q.Args)
+	}
+	if s := q.Numbered("$"); s != "SELECT * FROM t WHERE id = $1 AND name = $2" {
+		t.Fatal("Numbered:", s)
+	}
+	if _, err = NewEx([]string{"SELECT 1"}, 1); err == nil {
+		t.Fatal("NewEx: no error")
+	}
+	if q, _ = New("SELECT 1"); q.Numbered("$") != "SELECT 1" || q.Args != nil {
+		t.Fatal("New:", q)
+	}
+}
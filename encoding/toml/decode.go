@@ -0,0 +1,738 @@
+/*
+ * Copyright (c) 2026 The XGo Authors (xgo.dev). All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package toml
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// -----------------------------------------------------------------------------
+
+// SyntaxError is returned if a TOML document is invalid.
+type SyntaxError struct {
+	Line int // line number, starting at 1
+	Msg  string
+}
+
+func (e *SyntaxError) Error() string {
+	return fmt.Sprintf("toml: line %d: %s", e.Line, e.Msg)
+}
+
+// Parse parses a TOML document. Tables (including inline tables) are decoded
+// as map[string]any, arrays (including arrays of tables) as []any, integers as
+// int64, floats as float64, and booleans as bool. Date-times, dates and times
+// are decoded as strings in their RFC 3339 form, like "1979-05-27T07:32:00Z",
+// which can be parsed by time.Parse.
+func Parse(text string) (doc map[string]any, err error) {
+	p := &parser{src: text, line: 1}
+	defer func() {
+		if e := recover(); e != nil {
+			se, ok := e.(*SyntaxError)
+			if !ok {
+				panic(e)
+			}
+			err = se
+		}
+	}()
+	root := &table{kind: tableExplicit}
+	p.parse(root)
+	return root.value(), nil
+}
+
+// -----------------------------------------------------------------------------
+
+type tableKind int
+
+const (
+	tableImplicit tableKind = iota // created by the header of a sub-table
+	tableExplicit                  // defined by a [header]
+	tableDotted                    // created by dotted keys
+	tableInline                    // inline table, which can't be extended
+)
+
+// table is a table being parsed.
+type table struct {
+	kind    tableKind
+	members map[string]any // values are *table, *tableArray or decoded values
+}
+
+// tableArray is an array of tables being parsed.
+type tableArray struct {
+	tables []*table
+}
+
+func (t *table) get(key string) any {
+	return t.members[key]
+}
+
+func (t *table) set(key string, v any) {
+	if t.members == nil {
+		t.members = make(map[string]any)
+	}
+	t.members[key] = v
+}
+
+// value converts the table being parsed to a map[string]any.
+func (t *table) value() map[string]any {
+	ret := make(map[string]any, len(t.members))
+	for k, v := range t.members {
+		ret[k] = finish(v)
+	}
+	return ret
+}
+
+func finish(v any) any {
+	switch v := v.(type) {
+	case *table:
+		return v.value()
+	case *tableArray:
+		ret := make([]any, len(v.tables))
+		for i, t := range v.tables {
+			ret[i] = t.value()
+		}
+		return ret
+	case []any:
+		for i, e := range v {
+			v[i] = finish(e)
+		}
+	}
+	return v
+}
+
+// -----------------------------------------------------------------------------
+
+type parser struct {
+	src  string
+	pos  int
+	line int
+}
+
+func (p *parser) fail(format string, args ...any) {
+	panic(&SyntaxError{Line: p.line, Msg: fmt.Sprintf(format, args...)})
+}
+
+func (p *parser) eof() bool {
+	return p.pos >= len(p.src)
+}
+
+func (p *parser) peek() byte {
+	if p.pos < len(p.src) {
+		return p.src[p.pos]
+	}
+	return 0
+}
+
+func (p *parser) expect(c byte) {
+	if p.peek() != c {
+		p.fail("expected %q, found %s", c, p.found())
+	}
+	p.pos++
+}
+
+func (p *parser) found() string {
+	if p.eof() {
+		return "end of document"
+	}
+	r, _ := utf8.DecodeRuneInString(p.src[p.pos:])
+	return strconv.QuoteRune(r)
+}
+
+// skipSpace skips spaces and tabs.
+func (p *parser) skipSpace() {
+	for p.pos < len(p.src) && (p.src[p.pos] == ' ' || p.src[p.pos] == '\t') {
+		p.pos++
+	}
+}
+
+// skipComment skips a comment to the end of the line.
+func (p *parser) skipComment() {
+	if p.peek() != '#' {
+		return
+	}
+	for p.pos < len(p.src) && p.src[p.pos] != '\n' && !strings.HasPrefix(p.src[p.pos:], "\r\n") {
+		p.pos++
+	}
+}
+
+// newline consumes a newline, and returns false if there isn't one.
+func (p *parser) newline() bool {
+	switch {
+	case strings.HasPrefix(p.src[p.pos:], "\n"):
+		p.pos++
+	case strings.HasPrefix(p.src[p.pos:], "\r\n"):
+		p.pos += 2
+	default:
+		return false
+	}
+	p.line++
+	return true
+}
+
+// skipBlank skips white space, newlines and comments.
+func (p *parser) skipBlank() {
+	for {
+		p.skipSpace()
+		p.skipComment()
+		if !p.newline() {
+			return
+		}
+	}
+}
+
+// endLine expects the end of a line, after optional white space and comment.
+func (p *parser) endLine() {
+	p.skipSpace()
+	p.skipComment()
+	if !p.eof() && !p.newline() {
+		p.fail("expected the end of line, found %s", p.found())
+	}
+}
+
+// -----------------------------------------------------------------------------
+
+func (p *parser) parse(root *table) {
+	cur := root
+	for {
+		p.skipBlank()
+		if p.eof() {
+			return
+		}
+		if p.peek() == '[' {
+			cur = p.header(root)
+		} else {
+			p.keyValue(cur)
+		}
+		p.endLine()
+	}
+}
+
+// header parses a [table] or [[array of tables]] header, and returns the
+// table it defines.
+func (p *parser) header(root *table) *table {
+	p.pos++
+	array := p.peek() == '['
+	if array {
+		p.pos++
+	}
+	p.skipSpace()
+	keys := p.key()
+	p.skipSpace()
+	p.expect(']')
+	if array {
+		p.expect(']')
+	}
+	t := root
+	for _, k := range keys[:len(keys)-1] {
+		switch v := t.get(k).(type) {
+		case nil:
+			sub := &table{kind: tableImplicit}
+			t.set(k, sub)
+			t = sub
+		case *table:
+			if v.kind == tableInline {
+				p.fail("cannot extend inline table %q", k)
+			}
+			t = v
+		case *tableArray:
+			t = v.tables[len(v.tables)-1]
+		default:
+			p.fail("key %q is not a table", k)
+		}
+	}
+	k := keys[len(keys)-1]
+	if array {
+		sub := &table{kind: tableExplicit}
+		switch v := t.get(k).(type) {
+		case nil:
+			t.set(k, &tableArray{tables: []*table{sub}})
+		case *tableArray:
+			v.tables = append(v.tables, sub)
+		default:
+			p.fail("key %q is not an array of tables", k)
+		}
+		return sub
+	}
+	switch v := t.get(k).(type) {
+	case nil:
+		sub := &table{kind: tableExplicit}
+		t.set(k, sub)
+		return sub
+	case *table:
+		if v.kind == tableImplicit {
+			v.kind = tableExplicit
+			return v
+		}
+	}
+	p.fail("table %q is already defined", strings.Join(keys, "."))
+	return nil
+}
+
+// keyValue parses a key/value pair, and sets it to the table t.
+func (p *parser) keyValue(t *table) {
+	keys := p.key()
+	p.skipSpace()
+	p.expect('=')
+	p.skipSpace()
+	v := p.value()
+	for _, k := range keys[:len(keys)-1] {
+		switch sub := t.get(k).(type) {
+		case nil:
+			next := &table{kind: tableDotted}
+			t.set(k, next)
+			t = next
+		case *table:
+			if sub.kind != tableDotted {
+				p.fail("cannot extend table %q with dotted keys", k)
+			}
+			t = sub
+		default:
+			p.fail("key %q is already defined", k)
+		}
+	}
+	k := keys[len(keys)-1]
+	if t.get(k) != nil {
+		p.fail("key %q is already defined", k)
+	}
+	t.set(k, v)
+}
+
+// key parses a (dotted) key.
+func (p *parser) key() (keys []string) {
+	for {
+		var k string
+		switch c := p.peek(); {
+		case c == '"':
+			k = p.basicString()
+		case c == '\'':
+			k = p.literalString()
+		case isBare(c):
+			start := p.pos
+			for p.pos < len(p.src) && isBare(p.src[p.pos]) {
+				p.pos++
+			}
+			k = p.src[start:p.pos]
+		default:
+			p.fail("expected a key, found %s", p.found())
+		}
+		keys = append(keys, k)
+		p.skipSpace()
+		if p.peek() != '.' {
+			return
+		}
+		p.pos++
+		p.skipSpace()
+	}
+}
+
+func isBare(c byte) bool {
+	return 'A' <= c && c <= 'Z' || 'a' <= c && c <= 'z' || '0' <= c && c <= '9' || c == '_' || c == '-'
+}
+
+// -----------------------------------------------------------------------------
+
+func (p *parser) value() any {
+	switch c := p.peek(); c {
+	case '"':
+		if strings.HasPrefix(p.src[p.pos:], `"""`) {
+			return p.multilineString(`"""`, true)
+		}
+		return p.basicString()
+	case '\'':
+		if strings.HasPrefix(p.src[p.pos:], "'''") {
+			return p.multilineString("'''", false)
+		}
+		return p.literalString()
+	case '[':
+		return p.array()
+	case '{':
+		return p.inlineTable()
+	case 't', 'f':
+		if strings.HasPrefix(p.src[p.pos:], "true") {
+			p.pos += 4
+			return true
+		}
+		if strings.HasPrefix(p.src[p.pos:], "false") {
+			p.pos += 5
+			return false
+		}
+	}
+	return p.scalar()
+}
+
+func (p *parser) array() []any {
+	p.pos++
+	ret := []any{}
+	for {
+		p.skipBlank()
+		if p.peek() == ']' {
+			p.pos++
+			return ret
+		}
+		ret = append(ret, p.value())
+		p.skipBlank()
+		if p.peek() == ',' {
+			p.pos++
+		} else if p.peek() != ']' {
+			p.fail("expected ',' or ']' in array, found %s", p.found())
+		}
+	}
+}
+
+func (p *parser) inlineTable() *table {
+	p.pos++
+	t := &table{kind: tableExplicit}
+	p.skipSpace()
+	if p.peek() == '}' {
+		p.pos++
+		t.kind = tableInline
+		return t
+	}
+	for {
+		p.skipSpace()
+		p.keyValue(t)
+		p.skipSpace()
+		switch p.peek() {
+		case ',':
+			p.pos++
+		case '}':
+			p.pos++
+			freeze(t)
+			return t
+		default:
+			p.fail("expected ',' or '}' in inline table, found %s", p.found())
+		}
+	}
+}
+
+// freeze marks an inline table and its sub-tables as inline.
+func freeze(t *table) {
+	t.kind = tableInline
+	for _, v := range t.members {
+		if sub, ok := v.(*table); ok {
+			freeze(sub)
+		}
+	}
+}
+
+// -----------------------------------------------------------------------------
+
+func (p *parser) basicString() string {
+	p.pos++
+	var b strings.Builder
+	for {
+		if p.eof() {
+			p.fail("unterminated string")
+		}
+		c := p.src[p.pos]
+		switch {
+		case c == '"':
+			p.pos++
+			return b.String()
+		case c == '\\':
+			p.escape(&b)
+		case c == '\n' || c == '\r':
+			p.fail("newline in string")
+		default:
+			p.char(&b)
+		}
+	}
+}
+
+func (p *parser) literalString() string {
+	p.pos++
+	start := p.pos
+	for {
+		if p.eof() {
+			p.fail("unterminated string")
+		}
+		switch c := p.src[p.pos]; c {
+		case '\'':
+			s := p.src[start:p.pos]
+			p.pos++
+			return s
+		case '\n', '\r':
+			p.fail("newline in string")
+		default:
+			p.char(nil)
+		}
+	}
+}
+
+// multilineString parses a multi-line string delimited by delim, which is a
+// basic string if basic is true, or a literal string otherwise.
+func (p *parser) multilineString(delim string, basic bool) string {
+	p.pos += 3
+	p.newline() // a newline immediately following the delimiter is trimmed
+	var b strings.Builder
+	for {
+		if p.eof() {
+			p.fail("unterminated multi-line string")
+		}
+		if strings.HasPrefix(p.src[p.pos:], delim) {
+			// up to 2 quotes are allowed right before the closing delimiter
+			n := 3
+			for n < 5 && p.pos+n < len(p.src) && p.src[p.pos+n] == delim[0] {
+				n++
+			}
+			b.WriteString(p.src[p.pos : p.pos+n-3])
+			p.pos += n
+			return b.String()
+		}
+		switch c := p.src[p.pos]; {
+		case p.newline():
+			b.WriteByte('\n')
+		case c == '\\' && basic:
+			p.pos++
+			if rest := strings.TrimLeft(p.src[p.pos:], " \t"); strings.HasPrefix(rest, "\n") || strings.HasPrefix(rest, "\r\n") {
+				// line ending backslash, trim the following white space
+				p.skipSpace()
+				for p.newline() {
+					p.skipSpace()
+				}
+				continue
+			}
+			p.pos--
+			p.escape(&b)
+		default:
+			p.char(&b)
+		}
+	}
+}
+
+// char reads a character of a string to b (if not nil), and rejects control
+// characters.
+func (p *parser) char(b *strings.Builder) {
+	c := p.src[p.pos]
+	if c < 0x20 && c != '\t' || c == 0x7f {
+		p.fail("control character in string")
+	}
+	r, size := utf8.DecodeRuneInString(p.src[p.pos:])
+	if r == utf8.RuneError && size == 1 {
+		p.fail("invalid UTF-8")
+	}
+	if b != nil {
+		b.WriteString(p.src[p.pos : p.pos+size])
+	}
+	p.pos += size
+}
+
+func (p *parser) escape(b *strings.Builder) {
+	p.pos++
+	if p.eof() {
+		p.fail("unterminated string")
+	}
+	c := p.src[p.pos]
+	p.pos++
+	switch c {
+	case 'b':
+		b.WriteByte('\b')
+	case 't':
+		b.WriteByte('\t')
+	case 'n':
+		b.WriteByte('\n')
+	case 'f':
+		b.WriteByte('\f')
+	case 'r':
+		b.WriteByte('\r')
+	case 'e':
+		b.WriteByte(0x1b)
+	case '"':
+		b.WriteByte('"')
+	case '\\':
+		b.WriteByte('\\')
+	case 'u', 'U':
+		n := 4
+		if c == 'U' {
+			n = 8
+		}
+		if p.pos+n > len(p.src) {
+			p.fail("invalid unicode escape")
+		}
+		v, err := strconv.ParseUint(p.src[p.pos:p.pos+n], 16, 32)
+		if err != nil || !utf8.ValidRune(rune(v)) {
+			p.fail("invalid unicode escape")
+		}
+		p.pos += n
+		b.WriteRune(rune(v))
+	default:
+		p.fail("invalid escape sequence \\%c", c)
+	}
+}
+
+// -----------------------------------------------------------------------------
+
+// scalar parses a number, a date-time, a date or a time.
+func (p *parser) scalar() any {
+	start := p.pos
+	for p.pos < len(p.src) && isScalar(p.src[p.pos]) {
+		p.pos++
+	}
+	s := p.src[start:p.pos]
+	// a date-time may have a space instead of 'T' between the date and the time
+	if isDate(s) && p.pos+3 < len(p.src) && p.src[p.pos] == ' ' && isDigit(p.src[p.pos+1]) {
+		p.pos++
+		for p.pos < len(p.src) && isScalar(p.src[p.pos]) {
+			p.pos++
+		}
+		s = s + "T" + p.src[start+len(s)+1:p.pos]
+	}
+	if s == "" {
+		p.fail("expected a value, found %s", p.found())
+	}
+	if v, ok := datetime(s); ok {
+		return v
+	}
+	if v, ok := number(s); ok {
+		return v
+	}
+	p.fail("invalid value %q", s)
+	return nil
+}
+
+func isScalar(c byte) bool {
+	return isBare(c) || c == '+' || c == '.' || c == ':'
+}
+
+func isDigit(c byte) bool {
+	return '0' <= c && c <= '9'
+}
+
+func isDate(s string) bool {
+	return len(s) == 10 && s[4] == '-' && s[7] == '-' && allDigits(s[:4]) && allDigits(s[5:7]) && allDigits(s[8:])
+}
+
+func isTime(s string) bool {
+	if len(s) < 8 || s[2] != ':' || s[5] != ':' || !allDigits(s[:2]) || !allDigits(s[3:5]) || !allDigits(s[6:8]) {
+		return len(s) == 5 && s[2] == ':' && allDigits(s[:2]) && allDigits(s[3:]) // seconds may be omitted
+	}
+	if frac := s[8:]; frac != "" {
+		return frac[0] == '.' && len(frac) > 1 && allDigits(frac[1:])
+	}
+	return true
+}
+
+func allDigits(s string) bool {
+	for i := 0; i < len(s); i++ {
+		if !isDigit(s[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+// datetime checks if s is an (offset or local) date-time, a date or a time,
+// and returns it in the RFC 3339 form.
+func datetime(s string) (string, bool) {
+	if isTime(s) {
+		return s, true
+	}
+	if len(s) < 10 || !isDate(s[:10]) {
+		return "", false
+	}
+	if len(s) == 10 {
+		return s, true
+	}
+	if s[10] != 'T' && s[10] != 't' {
+		return "", false
+	}
+	t := s[11:]
+	switch {
+	case strings.HasSuffix(t, "Z") || strings.HasSuffix(t, "z"):
+		t = t[:len(t)-1]
+	case len(t) > 6 && (t[len(t)-6] == '+' || t[len(t)-6] == '-'):
+		off := t[len(t)-5:]
+		if off[2] != ':' || !allDigits(off[:2]) || !allDigits(off[3:]) {
+			return "", false
+		}
+		t = t[:len(t)-6]
+	}
+	if !isTime(t) {
+		return "", false
+	}
+	return s[:10] + "T" + strings.ToUpper(s[11:]), true
+}
+
+// number parses an integer or a float.
+func number(s string) (any, bool) {
+	switch s {
+	case "inf", "+inf":
+		return math.Inf(1), true
+	case "-inf":
+		return math.Inf(-1), true
+	case "nan", "+nan", "-nan":
+		return math.NaN(), true
+	}
+	if len(s) > 2 && s[0] == '0' {
+		base := 0
+		switch s[1] {
+		case 'x':
+			base = 16
+		case 'o':
+			base = 8
+		case 'b':
+			base = 2
+		}
+		if base != 0 {
+			digits, ok := underscores(s[2:])
+			if !ok {
+				return nil, false
+			}
+			v, err := strconv.ParseInt(digits, base, 64)
+			return v, err == nil
+		}
+	}
+	digits, ok := underscores(s)
+	if !ok {
+		return nil, false
+	}
+	mant := strings.TrimLeft(digits, "+-")
+	if len(mant) > 1 && mant[0] == '0' && isDigit(mant[1]) {
+		return nil, false // leading zeros aren't allowed
+	}
+	if strings.ContainsAny(digits, ".eE") {
+		if i := strings.IndexByte(mant, '.'); i == 0 || i == len(mant)-1 || i > 0 && !isDigit(mant[i+1]) {
+			return nil, false // a dot must be surrounded by digits
+		}
+		v, err := strconv.ParseFloat(digits, 64)
+		return v, err == nil
+	}
+	v, err := strconv.ParseInt(digits, 10, 64)
+	return v, err == nil
+}
+
+// underscores removes the underscores between digits of a number.
+func underscores(s string) (string, bool) {
+	if !strings.Contains(s, "_") {
+		return s, true
+	}
+	for i := 0; i < len(s); i++ {
+		if s[i] == '_' && (i == 0 || i == len(s)-1 || !isHexDigit(s[i-1]) || !isHexDigit(s[i+1])) {
+			return "", false
+		}
+	}
+	return strings.ReplaceAll(s, "_", ""), true
+}
+
+func isHexDigit(c byte) bool {
+	return isDigit(c) || 'a' <= c && c <= 'f' || 'A' <= c && c <= 'F'
+}
+
+// -----------------------------------------------------------------------------
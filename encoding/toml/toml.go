@@ -0,0 +1,33 @@
+/*
+ * Copyright (c) 2026 The XGo Authors (xgo.dev). All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package toml
+
+// Object is a type alias for a TOML document, which is always a table.
+type Object = map[string]any
+
+// New creates a new TOML object from a string. See Parse for how the values
+// are decoded.
+func New(text string) (Object, error) {
+	return Parse(text)
+}
+
+// Validate parses text as a TOML document and returns its *SyntaxError, which
+// records the line of the error. Defining a key or table twice is an error.
+func Validate(text string) error {
+	_, err := New(text)
+	return err
+}
@@ -0,0 +1,164 @@
+/*
+ * Copyright (c) 2026 The XGo Authors (xgo.dev). All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package toml
+
+import (
+	"encoding/json"
+	"math"
+	"strings"
+	"testing"
+)
+
+const doc = `# This is a TOML document
+title = "TOML \"Example\"\u00e9"
+path = 'C:\Users\nodejs'
+"quoted key" = 1_000
+site."google.com" = true
+3.14159 = "pi"
+
+[owner]
+name = "Tom Preston-Werner"
+dob = 1979-05-27 07:32:00-08:00
+date = 1979-05-27
+time = 07:32:00.999
+
+[database]
+enabled = true
+ports = [ 8000, 8001, 8002 ]
+data = [ ["delta", "phi"], [3.14] ]
+temp_targets = { cpu = 79.5, case = 72.0 }
+hex = 0xdead_beef
+oct = 0o755
+bin = 0b1101
+floats = [+1.0, 3.1415, -0.01, 5e+22, 1e06, -2E-2, 6.626e-34]
+
+[servers]
+
+[servers.alpha]
+ip = "10.0.0.1"
+
+[[products]]
+name = "Hammer"
+sku = 738594937
+
+[[products]]  # empty table within the array
+
+[[products]]
+name = "Nail"
+color = "gray"
+
+[[products.parts]]
+name = "head"
+
+[fruit]
+apple.color = "red"
+apple.taste.sweet = true
+
+[fruit.apple.texture]
+smooth = true
+`
+
+func TestParse(t *testing.T) {
+	v, err := New(doc)
+	if err != nil {
+		t.Fatal(err)
+	}
+	b, _ := json.Marshal(v)
+	want := `{"3":{"14159":"pi"},"database":{"bin":13,"data":[["delta","phi"],[3.14]],"enabled":true,` +
+		`"floats":[1,3.1415,-0.01,5e+22,1000000,-0.02,6.626e-34],"hex":3735928559,"oct":493,` +
+		`"ports":[8000,8001,8002],"temp_targets":{"case":72,"cpu":79.5}},` +
+		`"fruit":{"apple":{"color":"red","taste":{"sweet":true},"texture":{"smooth":true}}},` +
+		`"owner":{"date":"1979-05-27","dob":"1979-05-27T07:32:00-08:00","name":"Tom Preston-Werner","time":"07:32:00.999"},` +
+		`"path":"C:\\Users\\nodejs","products":[{"name":"Hammer","sku":738594937},{},{"color":"gray","name":"Nail","parts":[{"name":"head"}]}],` +
+		`"quoted key":1000,"servers":{"alpha":{"ip":"10.0.0.1"}},"site":{"google.com":true},"title":"TOML \"Example\"é"}`
+	if string(b) != want {
+		t.Fatal("Parse:", string(b))
+	}
+	if _, ok := v["database"].(map[string]any)["hex"].(int64); !ok {
+		t.Fatal("integers should be int64")
+	}
+}
+
+func TestStrings(t *testing.T) {
+	v, err := New(`
+str1 = """
+Roses are red
+Violets are blue"""
+str2 = """\
+       The quick brown \
+       fox."""
+str3 = """Here are two quotation marks: "". Simple enough."""
+str4 = """"This," she said, "is just a pointless statement.""""
+lit1 = '''
+The first newline is
+trimmed in raw strings.
+'''
+lit2 = '''Here are fifteen quotation marks: """""""""""""""'''
+special = [inf, -inf, nan]
+`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cases := map[string]string{
+		"str1": "Roses are red\nViolets are blue",
+		"str2": "The quick brown fox.",
+		"str3": `Here are two quotation marks: "". Simple enough.`,
+		"str4": `"This," she said, "is just a pointless statement."`,
+		"lit1": "The first newline is\ntrimmed in raw strings.\n",
+		"lit2": `Here are fifteen quotation marks: """""""""""""""`,
+	}
+	for k, want := range cases {
+		if v[k] != want {
+			t.Errorf("%s: %q", k, v[k])
+		}
+	}
+	special := v["special"].([]any)
+	if special[0] != math.Inf(1) || special[1] != math.Inf(-1) || !math.IsNaN(special[2].(float64)) {
+		t.Error("special:", special)
+	}
+}
+
+func TestErrors(t *testing.T) {
+	cases := []struct {
+		doc, err string
+	}{
+		{"a = 1\na = 2", "line 2: key \"a\" is already defined"},
+		{"[a]\n[a]", "line 2: table \"a\" is already defined"},
+		{"a = {x = 1}\n[a]", "line 2: table \"a\" is already defined"},
+		{"a = {x = 1}\na.y = 2", "cannot extend table \"a\" with dotted keys"},
+		{"[a.b]\n[a]\nb.c = 1", "line 3: cannot extend table \"b\" with dotted keys"},
+		{"a = [1]\n[[a]]", "key \"a\" is not an array of tables"},
+		{"[fruit]\napple.color = 1\n[fruit.apple]", "line 3: table \"fruit.apple\" is already defined"},
+		{"a = 01", "invalid value \"01\""},
+		{"a = 1__0", "invalid value \"1__0\""},
+		{"a = .5", "invalid value \".5\""},
+		{"a = \"abc", "unterminated string"},
+		{"a = \"\\q\"", "invalid escape sequence \\q"},
+		{"a = 1 b = 2", "expected the end of line"},
+		{"a = [1 2]", "expected ',' or ']' in array"},
+		{"= 1", "expected a key"},
+	}
+	for _, c := range cases {
+		_, err := New(c.doc)
+		if err == nil || !strings.Contains(err.Error(), c.err) {
+			t.Errorf("%q: %v", c.doc, err)
+		}
+	}
+	if _, err := New("[a.b]\n[a]\nc = 1\n[[x]]\n[x.y]\n[[x]]\n[x.y]"); err != nil {
+		t.Error(err)
+	}
+}
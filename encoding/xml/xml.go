@@ -33,3 +33,11 @@ type Object = *xml.Node
 func New(text string) (ret Object, err error) {
 	return xml.Parse(strings.NewReader(text))
 }
+
+// Validate checks that text is a well-formed XML document, whose tags are
+// balanced and properly nested. It doesn't check the document against a DTD
+// or schema.
+func Validate(text string) error {
+	_, err := New(text)
+	return err
+}
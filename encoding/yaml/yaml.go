@@ -17,6 +17,8 @@
 package yaml
 
 import (
+	"fmt"
+	"reflect"
 	"strings"
 
 	"github.com/goccy/go-yaml"
@@ -26,8 +28,65 @@ import (
 // number, boolean, or null.
 type Object = any
 
+// Mode specifies how strictly YAML text is parsed.
+type Mode int
+
+const (
+	// Strict reports unknown fields when decoding to a schema as errors.
+	// Otherwise unknown fields are ignored. Duplicate keys are always errors.
+	Strict Mode = 1 << iota
+)
+
 // New creates a new YAML object from a string.
 func New(text string, opts ...yaml.DecodeOption) (ret Object, err error) {
 	err = yaml.NewDecoder(strings.NewReader(text), opts...).Decode(&ret)
 	return
 }
+
+// NewWith creates a new YAML object from a string with the arguments of a
+// yaml literal. Arguments are:
+//   - yaml.DecodeOption: options of github.com/goccy/go-yaml.
+//   - Mode: like Strict.
+//   - a pointer (like *Config) as the schema: the text is decoded to the
+//     value it points to, and the pointer is returned.
+//
+// The XGo compiler calls it for literals with arguments in the header:
+//
+//	conf := yaml`> &conf, yaml.Strict
+//	port: 8080
+//	`!
+func NewWith(text string, args ...any) (ret Object, err error) {
+	var decOpts []yaml.DecodeOption
+	var mode Mode
+	var schema any
+	for _, arg := range args {
+		switch v := arg.(type) {
+		case yaml.DecodeOption:
+			decOpts = append(decOpts, v)
+		case Mode:
+			mode |= v
+		default:
+			if rv := reflect.ValueOf(arg); rv.Kind() != reflect.Pointer || rv.IsNil() {
+				return nil, fmt.Errorf("yaml: unsupported argument %T", arg)
+			}
+			schema = arg
+		}
+	}
+	if mode&Strict != 0 {
+		decOpts = append(decOpts, yaml.Strict())
+	}
+	if schema == nil {
+		return New(text, decOpts...)
+	}
+	if err = yaml.NewDecoder(strings.NewReader(text), decOpts...).Decode(schema); err != nil {
+		return nil, err
+	}
+	return schema, nil
+}
+
+// Validate decodes text like New without options, so duplicate keys are
+// errors. yaml literals with arguments, such as a schema, aren't validated.
+func Validate(text string) error {
+	_, err := New(text)
+	return err
+}
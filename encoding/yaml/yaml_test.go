@@ -0,0 +1,56 @@
+/*
+ * Copyright (c) 2026 The XGo Authors (xgo.dev). All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package yaml
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/goccy/go-yaml"
+)
+
+func TestNew(t *testing.T) {
+	if v, err := New("a: 1\nb: 2\n"); err != nil || !reflect.DeepEqual(v, map[string]any{"a": uint64(1), "b": uint64(2)}) {
+		t.Fatal("New:", v, err)
+	}
+	if _, err := New("a: 1\na: 2\n"); err == nil {
+		t.Fatal("New: no error of duplicate keys")
+	}
+	if v, err := New("a: 1\na: 2\n", yaml.AllowDuplicateMapKey()); err != nil || !reflect.DeepEqual(v, map[string]any{"a": uint64(2)}) {
+		t.Fatal("New(AllowDuplicateMapKey):", v, err)
+	}
+}
+
+func TestNewWith(t *testing.T) {
+	if _, err := NewWith("a: 1\na: 2\n"); err == nil {
+		t.Fatal("NewWith: no error of duplicate keys")
+	}
+
+	type Config struct {
+		Port int
+	}
+	var conf Config
+	if v, err := NewWith("port: 8080\nhost: x\n", &conf); err != nil || v != &conf || conf.Port != 8080 {
+		t.Fatal("schema:", v, err)
+	}
+	if _, err := NewWith("port: 8080\nhost: x\n", &conf, Strict); err == nil {
+		t.Fatal("Strict: no error of unknown fields")
+	}
+	if _, err := NewWith("a: 1", 1); err == nil {
+		t.Fatal("unsupported argument")
+	}
+}
@@ -0,0 +1,164 @@
+/*
+ * Copyright (c) 2026 The XGo Authors (xgo.dev). All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package env
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// ConfigKeys are the settings that `xgo env -w` can write into the config
+// file:
+//   - XGOROOT: root of the XGo tree.
+//   - GOPROXY: module proxy preferred by xgo, passed to the go command.
+//   - XGO_GOCMD: name of the go command, see gocmd.Name.
+//   - XGO_RUNNER: default runner of `xgo run`, `go` or `interp`.
+var ConfigKeys = []string{"XGOROOT", "GOPROXY", "XGO_GOCMD", "XGO_RUNNER"}
+
+const (
+	envXGOENV = "XGOENV"
+)
+
+// ConfigFile returns the path of the config file written by `xgo env -w`.
+// It is `$XGOENV` if set, or else `xgo/env` in the user's config directory.
+// It returns "" if XGOENV is `off` or there is no config directory.
+func ConfigFile() string {
+	if file := os.Getenv(envXGOENV); file != "" {
+		if file == "off" {
+			return ""
+		}
+		return file
+	}
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(dir, "xgo", "env")
+}
+
+var (
+	configOnce sync.Once
+	configVals map[string]string
+)
+
+// Config returns the settings in the config file.
+func Config() map[string]string {
+	configOnce.Do(func() {
+		configVals, _ = readConfig(ConfigFile())
+	})
+	return configVals
+}
+
+// Getenv returns the value of the environment variable key. If it isn't
+// set, Getenv returns the value in the config file: environment variables
+// take precedence over settings written by `xgo env -w`.
+func Getenv(key string) string {
+	if v := os.Getenv(key); v != "" {
+		return v
+	}
+	return Config()[key]
+}
+
+func readConfig(file string) (map[string]string, error) {
+	if file == "" {
+		return nil, nil
+	}
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return nil, err
+	}
+	ret := make(map[string]string)
+	s := bufio.NewScanner(bytes.NewReader(data))
+	for s.Scan() {
+		line := strings.TrimSpace(s.Text())
+		if line == "" || line[0] == '#' {
+			continue
+		}
+		if k, v, ok := strings.Cut(line, "="); ok {
+			ret[strings.TrimSpace(k)] = strings.TrimSpace(v)
+		}
+	}
+	return ret, s.Err()
+}
+
+// WriteConfig updates the config file: it sets a key to its value in
+// settings, or removes the key if the value is empty.
+func WriteConfig(settings map[string]string) error {
+	file := ConfigFile()
+	if file == "" {
+		return errors.New("no config file: XGOENV is off or there is no user config directory")
+	}
+	for k, v := range settings {
+		if !isConfigKey(k) {
+			return fmt.Errorf("unknown xgo env variable %s", k)
+		}
+		if k == "XGOROOT" && v != "" && !isValidXgoRoot(v) {
+			return fmt.Errorf("XGOROOT (%s) is not valid", v)
+		}
+	}
+	vals, err := readConfig(file)
+	if err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	if vals == nil {
+		vals = make(map[string]string)
+	}
+	for k, v := range settings {
+		if v == "" {
+			delete(vals, k)
+		} else {
+			vals[k] = v
+		}
+	}
+	keys := make([]string, 0, len(vals))
+	for k := range vals {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	var b bytes.Buffer
+	for _, k := range keys {
+		fmt.Fprintf(&b, "%s=%s\n", k, vals[k])
+	}
+	if err = os.MkdirAll(filepath.Dir(file), 0755); err != nil {
+		return err
+	}
+	if err = os.WriteFile(file, b.Bytes(), 0644); err != nil {
+		return err
+	}
+	resetConfig()
+	return nil
+}
+
+func resetConfig() {
+	configOnce, configVals = sync.Once{}, nil
+}
+
+func isConfigKey(key string) bool {
+	for _, k := range ConfigKeys {
+		if k == key {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,68 @@
+/*
+ * Copyright (c) 2026 The XGo Authors (xgo.dev). All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package env
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestConfig(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "xgo", "env")
+	t.Setenv(envXGOENV, file)
+	t.Setenv("XGO_RUNNER", "")
+	resetConfig()
+	t.Cleanup(resetConfig)
+	if ConfigFile() != file {
+		t.Fatal("ConfigFile:", ConfigFile())
+	}
+	_, _, xgoRoot := makeTestDir(t)
+	err := WriteConfig(map[string]string{"XGOROOT": xgoRoot, "XGO_RUNNER": "interp"})
+	if err != nil {
+		t.Fatal("WriteConfig:", err)
+	}
+	if v := Getenv("XGO_RUNNER"); v != "interp" {
+		t.Fatal("Getenv XGO_RUNNER:", v)
+	}
+	t.Setenv("XGO_RUNNER", "go")
+	if v := Getenv("XGO_RUNNER"); v != "go" {
+		t.Fatal("Getenv XGO_RUNNER: environment variable should take precedence:", v)
+	}
+	if err = WriteConfig(map[string]string{"XGO_RUNNER": ""}); err != nil {
+		t.Fatal("WriteConfig:", err)
+	}
+	data, _ := os.ReadFile(file)
+	if string(data) != "XGOROOT="+xgoRoot+"\n" {
+		t.Fatalf("config file:\n%s", data)
+	}
+	t.Setenv("XGOROOT", "")
+	if root, err := findXgoRoot(); err != nil || root != xgoRoot {
+		t.Fatal("findXgoRoot:", root, err)
+	}
+
+	if WriteConfig(map[string]string{"FOO": "1"}) == nil {
+		t.Fatal("WriteConfig FOO: no error")
+	}
+	if WriteConfig(map[string]string{"XGOROOT": t.TempDir()}) == nil {
+		t.Fatal("WriteConfig XGOROOT: no error")
+	}
+	t.Setenv(envXGOENV, "off")
+	if ConfigFile() != "" || WriteConfig(map[string]string{"XGO_RUNNER": "go"}) == nil {
+		t.Fatal("XGOENV=off")
+	}
+}
@@ -43,7 +43,7 @@ const (
 )
 
 func findXgoRoot() (string, error) {
-	envXgoRoot := os.Getenv(envXGOROOT)
+	envXgoRoot := Getenv(envXGOROOT)
 	if envXgoRoot != "" {
 		// XGOROOT must valid
 		if isValidXgoRoot(envXgoRoot) {
@@ -12,12 +12,11 @@ require (
 	github.com/goplus/lib v0.3.1
 	github.com/goplus/mod v0.21.1
 	github.com/qiniu/x v1.18.0
+	github.com/yuin/goldmark v1.7.8
+	golang.org/x/mod v0.20.0
 	golang.org/x/net v0.50.0
 )
 
-require (
-	golang.org/x/mod v0.20.0 // indirect
-	golang.org/x/sys v0.41.0 // indirect
-)
+require golang.org/x/sys v0.41.0 // indirect
 
 retract v1.1.12
@@ -1962,7 +1962,7 @@ func (p *parser) stringLitExpr(parts []any, off, end token.Pos) []any {
 	return parts
 }
 
-func (p *parser) domainTextLitEx(off, end token.Pos) *ast.DomainTextLitEx {
+func (p *parser) domainTextLitEx(domain string, off, end token.Pos) *ast.DomainTextLitEx {
 	file := p.file
 	base := file.Base()
 	src := p.scanner.CodeTo(int(end) - base)
@@ -1980,11 +1980,84 @@ func (p *parser) domainTextLitEx(off, end token.Pos) *ast.DomainTextLitEx {
 		sp.next()
 	}
 	sp.expect(token.SEMICOLON)
-	return &ast.DomainTextLitEx{
+	raw := string(src[int(sp.pos)-base:])
+	ret := &ast.DomainTextLitEx{
 		Args:   args,
 		RawPos: sp.pos,
-		Raw:    string(src[int(sp.pos)-base:]),
+		Raw:    raw,
 	}
+	if hasPlaceholders(domain) {
+		ret.Parts = p.domainTextParts(sp.pos, raw)
+	}
+	return ret
+}
+
+// hasPlaceholders reports whether text literals of domain have ${expr}
+// placeholders. Only domains whose packages lower them by NewEx (see cl) do,
+// so ${ in text of other domains (eg. ${VAR} in yaml) keeps its meaning.
+func hasPlaceholders(domain string) bool {
+	switch domain {
+	case "json", "sql":
+		return true
+	}
+	return false
+}
+
+// domainTextParts splits the raw string of a domain text literal into string
+// and Expr parts if it has ${expr} placeholders or escaped ${ (written as $${).
+// It returns nil if there is neither.
+//
+// Errors aren't reported here: a placeholder that isn't a valid expression is
+// a BadExpr, which is reported by cl.
+func (p *parser) domainTextParts(pos token.Pos, text string) (parts []any) {
+	var buf []byte
+	hasExpr := false
+	for {
+		at := strings.Index(text, "${")
+		if at < 0 {
+			break
+		}
+		if at > 0 && text[at-1] == '$' { // $${
+			buf = append(buf, text[:at]...)
+			buf = append(buf, '{')
+			pos += token.Pos(at + 2)
+			text = text[at+2:]
+			hasExpr = true
+			continue
+		}
+		end := strings.IndexByte(text[at+2:], '}')
+		if end < 0 { // not a placeholder
+			break
+		}
+		if buf = append(buf, text[:at]...); len(buf) > 0 {
+			parts = append(parts, string(buf))
+			buf = buf[:0]
+		}
+		from := pos + token.Pos(at+2)
+		to := from + token.Pos(end)
+		parts = append(parts, p.domainTextExpr(from, to))
+		pos = to + 1
+		text = text[at+2+end+1:]
+		hasExpr = true
+	}
+	if !hasExpr {
+		return nil
+	}
+	if buf = append(buf, text...); len(buf) > 0 {
+		parts = append(parts, string(buf))
+	}
+	return
+}
+
+func (p *parser) domainTextExpr(off, end token.Pos) ast.Expr {
+	file := p.file
+	base := file.Base()
+	src := p.scanner.CodeTo(int(end) - base)
+	expr, err := ParseExprEx(file, src, int(off)-base, 0)
+	if err != nil {
+		return &ast.BadExpr{From: off, To: end}
+	}
+	return expr
 }
 
 func (p *parser) tplLit(off, end token.Pos) any {
@@ -2024,7 +2097,11 @@ func (p *parser) parseOperand(flags int) (x ast.Expr, exprKind int) {
 			if ident.Name == "tpl" {
 				extra = p.tplLit(pos+1, pos+token.Pos(len(lit))-1)
 			} else if strings.HasPrefix(lit, "`> ") { // domainTag`> ...`
-				extra = p.domainTextLitEx(pos+3, pos+token.Pos(len(lit))-1)
+				extra = p.domainTextLitEx(ident.Name, pos+3, pos+token.Pos(len(lit))-1)
+			} else if raw := lit[1 : len(lit)-1]; hasPlaceholders(ident.Name) && strings.Contains(raw, "${") { // domainTag`... ${expr} ...`
+				if parts := p.domainTextParts(pos+1, raw); parts != nil {
+					extra = &ast.DomainTextLitEx{RawPos: pos + 1, Raw: raw, Parts: parts}
+				}
 			}
 			x = &ast.DomainTextLit{
 				Domain:   ident,
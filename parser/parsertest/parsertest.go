@@ -121,6 +121,13 @@ func FprintNode(w io.Writer, lead string, v any, prefix, indent string) {
 				FprintNode(w, "", arg, prefix, indent)
 			}
 			fmt.Fprintf(w, "%s%v\n", prefix, lit.Raw)
+			for _, part := range lit.Parts {
+				if val, ok := part.(string); ok {
+					fmt.Fprintf(w, "%s%v\n", prefix, val)
+				} else {
+					FprintNode(w, "", part, prefix, indent)
+				}
+			}
 		} else {
 			log.Panicln("FprintNode unexpected type:", t)
 		}
@@ -17,7 +17,12 @@
 package test
 
 import (
+	"flag"
+	"fmt"
 	"os"
+	"regexp"
+	"runtime"
+	"strings"
 	"testing"
 )
 
@@ -48,6 +53,43 @@ func (p Case) Run(name string, f func(t *testing.T)) bool {
 	return p.t.Run(name, f)
 }
 
+// Bench runs f as a benchmark called name if benchmarks are enabled by the
+// -test.bench flag and name matches it. The result is reported in the format
+// of `go test -bench`, named as a sub-benchmark of the current test.
+// Bench reports whether the benchmark was run.
+func (p Case) Bench(name string, f func(b *testing.B)) bool {
+	pattern := flagValue("test.bench")
+	if pattern == "" {
+		return false
+	}
+	name = strings.ReplaceAll(name, " ", "_")
+	if matched, err := regexp.MatchString(pattern, name); err != nil || !matched {
+		return false
+	}
+	r := testing.Benchmark(f)
+	if r.N == 0 {
+		p.t.Errorf("benchmark %s failed", name)
+		return false
+	}
+	full := "Benchmark" + p.t.Name() + "/" + name
+	if n := runtime.GOMAXPROCS(0); n > 1 {
+		full += fmt.Sprintf("-%d", n)
+	}
+	if flagValue("test.benchmem") == "true" {
+		fmt.Printf("%s\t%s\t%s\n", full, r.String(), r.MemString())
+	} else {
+		fmt.Printf("%s\t%s\n", full, r.String())
+	}
+	return true
+}
+
+func flagValue(name string) string {
+	if f := flag.Lookup(name); f != nil {
+		return f.Value.String()
+	}
+	return ""
+}
+
 // Gopt_Case_TestMain is required by XGo compiler as the test case entry.
 func Gopt_Case_TestMain(c interface{ initCase(t *testing.T) }, t *testing.T) {
 	c.initCase(t)
@@ -19,6 +19,8 @@ package tool
 import (
 	"log"
 	"os"
+	"path/filepath"
+	"strings"
 
 	"github.com/goplus/xgo/x/gocmd"
 	"github.com/qiniu/x/errors"
@@ -31,6 +33,24 @@ func genFlags(flags []GenFlags) GenFlags {
 	return 0
 }
 
+// goCmdConf returns the config of the go command with flags of conf.Importer
+// (see Importer.GoFlags) added before flags of c.
+func (conf *Config) goCmdConf(c *gocmd.Config) *gocmd.Config {
+	if conf == nil || conf.Importer == nil {
+		return c
+	}
+	flags := conf.Importer.GoFlags()
+	if len(flags) == 0 {
+		return c
+	}
+	ret := new(gocmd.Config)
+	if c != nil {
+		*ret = *c
+	}
+	ret.Flags = append(flags, ret.Flags...)
+	return ret
+}
+
 // -----------------------------------------------------------------------------
 
 // InstallDir installs an XGo package directory.
@@ -39,7 +59,7 @@ func InstallDir(dir string, conf *Config, install *gocmd.InstallConfig, flags ..
 	if err != nil {
 		return errors.NewWith(err, `GenGo(dir, conf, false)`, -2, "tool.GenGo", dir, conf, false)
 	}
-	return gocmd.Install(dir, install)
+	return gocmd.Install(dir, conf.goCmdConf(install))
 }
 
 // InstallPkgPath installs an XGo package.
@@ -50,7 +70,7 @@ func InstallPkgPath(workDir, pkgPath string, conf *Config, install *gocmd.Instal
 	}
 	old := chdir(localDir)
 	defer os.Chdir(old)
-	return gocmd.Install(cwdParam(recursively), install)
+	return gocmd.Install(cwdParam(recursively), conf.goCmdConf(install))
 }
 
 func cwdParam(recursively bool) string {
@@ -66,7 +86,7 @@ func InstallFiles(files []string, conf *Config, install *gocmd.InstallConfig) (e
 	if err != nil {
 		return errors.NewWith(err, `GenGoFiles("", files, conf)`, -2, "tool.GenGoFiles", "", files, conf)
 	}
-	return gocmd.InstallFiles(files, install)
+	return gocmd.InstallFiles(files, conf.goCmdConf(install))
 }
 
 func chdir(dir string) string {
@@ -89,7 +109,7 @@ func BuildDir(dir string, conf *Config, build *gocmd.BuildConfig, flags ...GenFl
 	if err != nil {
 		return errors.NewWith(err, `GenGo(dir, conf, false)`, -2, "tool.GenGo", dir, conf, false)
 	}
-	return gocmd.Build(dir, build)
+	return gocmd.Build(dir, conf.goCmdConf(build))
 }
 
 // BuildPkgPath builds an XGo package.
@@ -100,7 +120,7 @@ func BuildPkgPath(workDir, pkgPath string, conf *Config, build *gocmd.BuildConfi
 	}
 	old, mod := chdirAndMod(localDir)
 	defer restoreDirAndMod(old, mod)
-	return gocmd.Build(cwdParam(recursively), build)
+	return gocmd.Build(cwdParam(recursively), conf.goCmdConf(build))
 }
 
 // BuildFiles builds specified XGo files.
@@ -109,7 +129,7 @@ func BuildFiles(files []string, conf *Config, build *gocmd.BuildConfig) (err err
 	if err != nil {
 		return errors.NewWith(err, `GenGoFiles("", files, conf)`, -2, "tool.GenGoFiles", "", files, conf)
 	}
-	return gocmd.BuildFiles(files, build)
+	return gocmd.BuildFiles(files, conf.goCmdConf(build))
 }
 
 func chdirAndMod(dir string) (old string, mod os.FileMode) {
@@ -143,7 +163,30 @@ func RunDir(dir string, args []string, conf *Config, run *gocmd.RunConfig, flags
 	if err != nil {
 		return errors.NewWith(err, `GenGo(dir, conf, false)`, -2, "tool.GenGo", dir, conf, false)
 	}
-	return gocmd.RunDir(getBuildDir(conf), dir, args, run)
+	if ov := conf.overlay(); ov != nil { // files in the overlay are invisible to gocmd.RunDir
+		files, err := runFilesOf(ov, dir)
+		if err != nil {
+			return err
+		}
+		return gocmd.RunFiles(getBuildDir(conf), files, args, conf.goCmdConf(run))
+	}
+	return gocmd.RunDir(getBuildDir(conf), dir, args, conf.goCmdConf(run))
+}
+
+// runFilesOf returns Go files of dir to run, including files in the overlay.
+func runFilesOf(ov *Overlay, dir string) (files []string, err error) {
+	fis, err := ov.ReadDir(dir)
+	if err != nil {
+		return
+	}
+	for _, fi := range fis {
+		fname := fi.Name()
+		if !fi.IsDir() && strings.HasSuffix(fname, ".go") &&
+			!(strings.HasSuffix(fname, "_test.go") || strings.HasPrefix(fname, "_")) {
+			files = append(files, filepath.Join(dir, fname))
+		}
+	}
+	return
 }
 
 // RunPkgPath runs an application from an XGo package.
@@ -160,7 +203,7 @@ func RunPkgPath(pkgPath string, args []string, chDir bool, conf *Config, run *go
 		defer os.Chdir(old)
 		localDir = "."
 	}
-	return gocmd.RunDir("", localDir, args, run)
+	return gocmd.RunDir("", localDir, args, conf.goCmdConf(run))
 }
 
 // RunFiles runs an application from specified XGo files.
@@ -169,7 +212,7 @@ func RunFiles(autogen string, files []string, args []string, conf *Config, run *
 	if err != nil {
 		return errors.NewWith(err, `GenGoFiles(autogen, files, conf)`, -2, "tool.GenGoFiles", autogen, files, conf)
 	}
-	return gocmd.RunFiles(getBuildDir(conf), files, args, run)
+	return gocmd.RunFiles(getBuildDir(conf), files, args, conf.goCmdConf(run))
 }
 
 // -----------------------------------------------------------------------------
@@ -180,7 +223,7 @@ func TestDir(dir string, conf *Config, test *gocmd.TestConfig, flags ...GenFlags
 	if err != nil {
 		return errors.NewWith(err, `GenGo(dir, conf, true)`, -2, "tool.GenGo", dir, conf, true)
 	}
-	return gocmd.Test(dir, test)
+	return gocmd.Test(dir, conf.goCmdConf(test))
 }
 
 // TestPkgPath tests an XGo package.
@@ -191,7 +234,7 @@ func TestPkgPath(workDir, pkgPath string, conf *Config, test *gocmd.TestConfig,
 	}
 	old, mod := chdirAndMod(localDir)
 	defer restoreDirAndMod(old, mod)
-	return gocmd.Test(cwdParam(recursively), test)
+	return gocmd.Test(cwdParam(recursively), conf.goCmdConf(test))
 }
 
 // TestFiles tests specified XGo files.
@@ -200,7 +243,7 @@ func TestFiles(files []string, conf *Config, test *gocmd.TestConfig) (err error)
 	if err != nil {
 		return errors.NewWith(err, `GenGoFiles("", files, conf)`, -2, "tool.GenGoFiles", "", files, conf)
 	}
-	return gocmd.TestFiles(files, test)
+	return gocmd.TestFiles(files, conf.goCmdConf(test))
 }
 
 // -----------------------------------------------------------------------------
@@ -0,0 +1,108 @@
+/*
+ * Copyright (c) 2026 The XGo Authors (xgo.dev). All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package tool
+
+import (
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"github.com/goplus/mod/modcache"
+)
+
+// -----------------------------------------------------------------------------
+
+// Cache represents a cache directory of xgo.
+type Cache struct {
+	Name string // run, build or scripts
+	Dir  string
+}
+
+// Caches returns the cache directories of xgo:
+//   - run: ~/.xgo/run, the module to run XGo files that aren't in a module.
+//   - build: the xgo-build cache of packages, see Importer.CacheFile.
+//   - scripts: remote scripts fetched by `xgo run`, see FetchScript.
+func Caches() []Cache {
+	var ret []Cache
+	if home, err := os.UserHomeDir(); err == nil {
+		ret = append(ret, Cache{Name: "run", Dir: filepath.Join(home, ".xgo", "run")})
+	}
+	return append(ret,
+		Cache{Name: "build", Dir: buildCacheDir()},
+		Cache{Name: "scripts", Dir: filepath.Join(scriptCacheDir(), "scripts")},
+	)
+}
+
+func buildCacheDir() string {
+	cacheDir, _ := os.UserCacheDir()
+	return cacheDir + "/xgo-build"
+}
+
+func scriptCacheDir() string {
+	return filepath.Join(modcache.GOMODCACHE, "cache", "download", "xgo")
+}
+
+// CacheEntry represents a top-level file or directory of a cache.
+type CacheEntry struct {
+	Path    string
+	Size    int64     // total size of files
+	ModTime time.Time // latest modification time of files
+}
+
+// Entries returns the top-level entries of the cache, the oldest first. It
+// returns nil if the cache directory doesn't exist.
+func (c Cache) Entries() ([]*CacheEntry, error) {
+	fis, err := os.ReadDir(c.Dir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			err = nil
+		}
+		return nil, err
+	}
+	ret := make([]*CacheEntry, 0, len(fis))
+	for _, fi := range fis {
+		e := &CacheEntry{Path: filepath.Join(c.Dir, fi.Name())}
+		err = filepath.WalkDir(e.Path, func(path string, d fs.DirEntry, err error) error {
+			if err != nil {
+				return err
+			}
+			info, err := d.Info()
+			if err != nil {
+				return err
+			}
+			if !d.IsDir() {
+				e.Size += info.Size()
+			}
+			if t := info.ModTime(); t.After(e.ModTime) {
+				e.ModTime = t
+			}
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+		ret = append(ret, e)
+	}
+	sort.Slice(ret, func(i, j int) bool {
+		return ret[i].ModTime.Before(ret[j].ModTime)
+	})
+	return ret, nil
+}
+
+// -----------------------------------------------------------------------------
@@ -0,0 +1,63 @@
+/*
+ * Copyright (c) 2026 The XGo Authors (xgo.dev). All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package tool
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestCacheEntries(t *testing.T) {
+	dir := t.TempDir()
+	c := Cache{Name: "test", Dir: filepath.Join(dir, "cache")}
+	if entries, err := c.Entries(); err != nil || entries != nil {
+		t.Fatal("Entries of a nonexistent cache:", entries, err)
+	}
+	os.MkdirAll(filepath.Join(c.Dir, "a"), 0755)
+	os.WriteFile(filepath.Join(c.Dir, "a", "x"), []byte("hello"), 0644)
+	os.WriteFile(filepath.Join(c.Dir, "a", "y"), []byte("world!"), 0644)
+	os.WriteFile(filepath.Join(c.Dir, "b"), []byte("xgo"), 0644)
+	old := time.Now().Add(-48 * time.Hour)
+	for _, name := range []string{"a", "a/x", "a/y"} {
+		os.Chtimes(filepath.Join(c.Dir, name), old, old)
+	}
+	entries, err := c.Entries()
+	if err != nil {
+		t.Fatal("Entries:", err)
+	}
+	if len(entries) != 2 {
+		t.Fatal("Entries:", len(entries))
+	}
+	if a := entries[0]; filepath.Base(a.Path) != "a" || a.Size != 11 || !a.ModTime.Equal(old) {
+		t.Fatal("entry a:", a.Path, a.Size, a.ModTime)
+	}
+	if b := entries[1]; filepath.Base(b.Path) != "b" || b.Size != 3 {
+		t.Fatal("entry b:", b.Path, b.Size)
+	}
+}
+
+func TestCaches(t *testing.T) {
+	names := ""
+	for _, c := range Caches() {
+		names += c.Name + " "
+	}
+	if names != "run build scripts " {
+		t.Fatal("Caches:", names)
+	}
+}
@@ -0,0 +1,311 @@
+/*
+ * Copyright (c) 2026 The XGo Authors (xgo.dev). All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package tool
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// -----------------------------------------------------------------------------
+
+// CoverProfile translates coverage profiles produced by `go test -coverprofile`
+// for generated XGo packages back to the original XGo sources, and merges them
+// into a single profile.
+//
+// The go cover tool names a block after the file of its //line directive, but
+// still reports positions of the generated Go code. CoverProfile rewrites such
+// positions by the //line directives of xgo_autogen.go.
+type CoverProfile struct {
+	Mode string
+
+	// PkgDir returns the local directory of a package.
+	PkgDir func(pkgPath string) (dir string, err error)
+
+	blocks []*coverBlock
+	index  map[coverBlock]int
+	pkgs   map[string]*coverPkg
+	gen    int
+}
+
+// NewCoverProfile creates a CoverProfile.
+func NewCoverProfile(pkgDir func(pkgPath string) (string, error)) *CoverProfile {
+	return &CoverProfile{
+		PkgDir: pkgDir,
+		index:  make(map[coverBlock]int),
+		pkgs:   make(map[string]*coverPkg),
+	}
+}
+
+type coverBlock struct {
+	file                string
+	startLine, startCol int
+	endLine, endCol     int
+	numStmt, count      int
+	gen                 int
+}
+
+func (b *coverBlock) key() coverBlock {
+	return coverBlock{
+		file: b.file, startLine: b.startLine, startCol: b.startCol,
+		endLine: b.endLine, endCol: b.endCol,
+	}
+}
+
+// AddFile adds a coverage profile file.
+func (p *CoverProfile) AddFile(file string) error {
+	f, err := os.Open(file)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	return p.Add(f)
+}
+
+// Add adds a coverage profile. Blocks of XGo files are translated to positions
+// of the XGo sources, and blocks of code generated without a source position are
+// dropped.
+func (p *CoverProfile) Add(r io.Reader) error {
+	p.gen++
+	s := bufio.NewScanner(r)
+	s.Buffer(nil, 1<<20)
+	for lineno := 1; s.Scan(); lineno++ {
+		line := s.Text()
+		if line == "" {
+			continue
+		}
+		if mode, ok := strings.CutPrefix(line, "mode: "); ok {
+			if p.Mode != "" && p.Mode != mode {
+				return fmt.Errorf("line %d: inconsistent cover mode %s, expected %s", lineno, mode, p.Mode)
+			}
+			p.Mode = mode
+			continue
+		}
+		b, err := parseCoverBlock(line)
+		if err != nil {
+			return fmt.Errorf("line %d: %v", lineno, err)
+		}
+		if ok, err := p.translate(b); err != nil {
+			return err
+		} else if ok {
+			p.add(b)
+		}
+	}
+	return s.Err()
+}
+
+func (p *CoverProfile) add(b *coverBlock) {
+	b.gen = p.gen
+	key := b.key()
+	if i, ok := p.index[key]; ok {
+		old := p.blocks[i]
+		if old.gen == b.gen { // different statements mapped to the same range
+			old.numStmt += b.numStmt
+		} else {
+			old.numStmt = max(old.numStmt, b.numStmt)
+		}
+		if p.Mode == "set" || old.gen == b.gen {
+			old.count = max(old.count, b.count)
+		} else {
+			old.count += b.count
+		}
+		return
+	}
+	p.index[key] = len(p.blocks)
+	p.blocks = append(p.blocks, b)
+}
+
+// WriteTo writes the merged coverage profile to w.
+func (p *CoverProfile) WriteTo(w io.Writer) (n int64, err error) {
+	mode := p.Mode
+	if mode == "" {
+		mode = "set"
+	}
+	bw := bufio.NewWriter(w)
+	fmt.Fprintf(bw, "mode: %s\n", mode)
+	for _, b := range p.blocks {
+		fmt.Fprintf(bw, "%s:%d.%d,%d.%d %d %d\n",
+			b.file, b.startLine, b.startCol, b.endLine, b.endCol, b.numStmt, b.count)
+	}
+	n = int64(bw.Buffered())
+	err = bw.Flush()
+	return
+}
+
+// WriteFile writes the merged coverage profile to the named file.
+func (p *CoverProfile) WriteFile(file string) error {
+	f, err := os.Create(file)
+	if err != nil {
+		return err
+	}
+	_, err = p.WriteTo(f)
+	if e := f.Close(); err == nil {
+		err = e
+	}
+	return err
+}
+
+// parseCoverBlock parses a line like "pkg/file.xgo:10.2,12.3 1 0".
+func parseCoverBlock(line string) (*coverBlock, error) {
+	i := strings.LastIndexByte(line, ':')
+	if i < 0 {
+		return nil, fmt.Errorf("invalid cover block: %s", line)
+	}
+	b := &coverBlock{file: line[:i]}
+	_, err := fmt.Sscanf(line[i+1:], "%d.%d,%d.%d %d %d",
+		&b.startLine, &b.startCol, &b.endLine, &b.endCol, &b.numStmt, &b.count)
+	if err != nil {
+		return nil, fmt.Errorf("invalid cover block: %s", line)
+	}
+	return b, nil
+}
+
+// -----------------------------------------------------------------------------
+
+// lineDirective represents a `//line file:line` directive in generated Go code.
+type lineDirective struct {
+	goLine int    // line of the directive in the Go file
+	file   string // base name of the XGo file
+	line   int    // line of the XGo file that the next Go line maps to
+}
+
+type coverPkg struct {
+	dir   string
+	goSrc []string
+	lines []lineDirective
+	srcs  map[string][]string // XGo file => lines
+}
+
+func (p *CoverProfile) translate(b *coverBlock) (bool, error) {
+	pkgPath, name := path.Split(b.file)
+	if strings.HasSuffix(name, ".go") {
+		if strings.HasPrefix(name, "xgo_autogen") {
+			return false, nil // code generated without source position
+		}
+		return true, nil
+	}
+	pkg, err := p.loadPkg(strings.TrimSuffix(pkgPath, "/"))
+	if err != nil || pkg.goSrc == nil {
+		return false, err
+	}
+	src, ok := pkg.source(name)
+	if !ok {
+		return false, nil
+	}
+	startLine, startCol, ok1 := pkg.position(name, src, b.startLine, b.startCol)
+	endLine, endCol, ok2 := pkg.position(name, src, b.endLine, b.endCol)
+	if !ok1 || !ok2 || endLine < startLine || (endLine == startLine && endCol < startCol) {
+		return false, nil
+	}
+	b.startLine, b.startCol, b.endLine, b.endCol = startLine, startCol, endLine, endCol
+	return true, nil
+}
+
+// position maps a position of xgo_autogen.go to a position of the XGo file.
+// Columns are mapped relative to the indentation of both lines.
+func (pkg *coverPkg) position(name string, src []string, goLine, goCol int) (line, col int, ok bool) {
+	i := sort.Search(len(pkg.lines), func(i int) bool {
+		return pkg.lines[i].goLine >= goLine
+	}) - 1
+	if i < 0 || pkg.lines[i].file != name {
+		return
+	}
+	d := pkg.lines[i]
+	line = d.line + (goLine - d.goLine - 1)
+	if line < 1 || line > len(src) || goLine > len(pkg.goSrc) {
+		return
+	}
+	goText, text := pkg.goSrc[goLine-1], src[line-1]
+	col = indentOf(text) + 1 + goCol - indentOf(goText) - 1
+	col = min(max(col, 1), len(text)+1)
+	return line, col, true
+}
+
+func indentOf(s string) int {
+	return len(s) - len(strings.TrimLeft(s, " \t"))
+}
+
+func (pkg *coverPkg) source(name string) ([]string, bool) {
+	if src, ok := pkg.srcs[name]; ok {
+		return src, src != nil
+	}
+	data, err := os.ReadFile(filepath.Join(pkg.dir, name))
+	var src []string
+	if err == nil {
+		src = strings.Split(strings.TrimSuffix(string(data), "\n"), "\n")
+	}
+	pkg.srcs[name] = src
+	return src, src != nil
+}
+
+func (p *CoverProfile) loadPkg(pkgPath string) (*coverPkg, error) {
+	if pkg, ok := p.pkgs[pkgPath]; ok {
+		return pkg, nil
+	}
+	dir, err := p.PkgDir(pkgPath)
+	if err != nil {
+		return nil, err
+	}
+	pkg := &coverPkg{dir: dir, srcs: make(map[string][]string)}
+	p.pkgs[pkgPath] = pkg
+	data, err := os.ReadFile(filepath.Join(dir, autoGenFile))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return pkg, nil
+		}
+		return nil, err
+	}
+	pkg.goSrc = strings.Split(string(data), "\n")
+	for i, text := range pkg.goSrc {
+		if d, ok := parseLineDirective(text); ok {
+			d.goLine = i + 1
+			pkg.lines = append(pkg.lines, d)
+		}
+	}
+	return pkg, nil
+}
+
+// parseLineDirective parses `//line file:line` or `//line file:line:col`.
+func parseLineDirective(text string) (d lineDirective, ok bool) {
+	text, ok = strings.CutPrefix(text, "//line ")
+	if !ok {
+		return
+	}
+	i := strings.LastIndexByte(text, ':')
+	if i < 0 {
+		return d, false
+	}
+	n, err := strconv.Atoi(text[i+1:])
+	if err != nil {
+		return d, false
+	}
+	if j := strings.LastIndexByte(text[:i], ':'); j >= 0 {
+		if line, err := strconv.Atoi(text[j+1 : i]); err == nil {
+			n, i = line, j
+		}
+	}
+	return lineDirective{file: filepath.Base(text[:i]), line: n}, true
+}
+
+// -----------------------------------------------------------------------------
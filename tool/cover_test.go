@@ -0,0 +1,123 @@
+/*
+ * Copyright (c) 2026 The XGo Authors (xgo.dev). All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package tool
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const coverAutogen = `// Code generated by xgo (XGo); DO NOT EDIT.
+
+package cv
+
+const _ = true
+//line calc.xgo:3:1
+// Abs returns the absolute value.
+func Abs(x int) int {
+//line calc.xgo:5:1
+	if x < 0 {
+//line calc.xgo:6:1
+		return -x
+	}
+//line calc.xgo:8:1
+	return x
+}
+func main() {
+}
+`
+
+const coverCalc = `package cv
+
+// Abs returns the absolute value.
+func Abs(x int) int {
+	if x < 0 {
+		return -x
+	}
+	return x
+}
+`
+
+func TestCoverProfile(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "xgo_autogen.go"), []byte(coverAutogen), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "calc.xgo"), []byte(coverCalc), 0644); err != nil {
+		t.Fatal(err)
+	}
+	p := NewCoverProfile(func(pkgPath string) (string, error) {
+		if pkgPath != "example.com/cv" {
+			t.Fatal("unexpected package:", pkgPath)
+		}
+		return dir, nil
+	})
+	profiles := []string{`mode: count
+example.com/cv/calc.xgo:10.2,10.11 1 3
+example.com/cv/calc.xgo:12.3,13.1 1 1
+example.com/cv/calc.xgo:15.2,15.10 1 2
+example.com/cv/calc.xgo:17.13,18.1 0 0
+example.com/cv/xgo_autogen.go:5.1,5.5 1 1
+example.com/cv/util.go:3.2,3.10 1 1
+`, `mode: count
+example.com/cv/calc.xgo:10.2,10.11 1 2
+`}
+	for _, prof := range profiles {
+		if err := p.Add(strings.NewReader(prof)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	var buf bytes.Buffer
+	if _, err := p.WriteTo(&buf); err != nil {
+		t.Fatal(err)
+	}
+	const want = `mode: count
+example.com/cv/calc.xgo:5.2,5.11 1 5
+example.com/cv/calc.xgo:6.3,7.1 1 1
+example.com/cv/calc.xgo:8.2,8.10 1 2
+example.com/cv/util.go:3.2,3.10 1 1
+`
+	if got := buf.String(); got != want {
+		t.Fatalf("TestCoverProfile:\n%s\nwant:\n%s", got, want)
+	}
+	if err := p.Add(strings.NewReader("mode: set\n")); err == nil {
+		t.Fatal("TestCoverProfile: inconsistent mode should fail")
+	}
+}
+
+func TestParseLineDirective(t *testing.T) {
+	cases := []struct {
+		text string
+		file string
+		line int
+		ok   bool
+	}{
+		{"//line calc.xgo:3:1", "calc.xgo", 3, true},
+		{"//line /a/b/calc.xgo:7", "calc.xgo", 7, true},
+		{"// line calc.xgo:3", "", 0, false},
+		{"//line calc.xgo", "", 0, false},
+	}
+	for _, c := range cases {
+		d, ok := parseLineDirective(c.text)
+		if ok != c.ok || (ok && (d.file != c.file || d.line != c.line)) {
+			t.Fatalf("parseLineDirective(%q) = %v, %v", c.text, d, ok)
+		}
+	}
+}
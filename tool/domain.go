@@ -0,0 +1,46 @@
+/*
+ * Copyright (c) 2026 The XGo Authors (xgo.dev). All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package tool
+
+import (
+	"github.com/goplus/xgo/cl"
+	"github.com/goplus/xgo/encoding/csv"
+	"github.com/goplus/xgo/encoding/json"
+	"github.com/goplus/xgo/encoding/regexp"
+	"github.com/goplus/xgo/encoding/regexposix"
+	"github.com/goplus/xgo/encoding/toml"
+	"github.com/goplus/xgo/encoding/xml"
+	"github.com/goplus/xgo/encoding/yaml"
+)
+
+// -----------------------------------------------------------------------------
+
+const encodingPkgPrefix = "github.com/goplus/xgo/encoding/"
+
+// Malformed domain text literals of the encoding packages are reported at
+// compile time.
+func init() {
+	cl.RegisterDomainValidator(encodingPkgPrefix+"csv", csv.Validate)
+	cl.RegisterDomainValidator(encodingPkgPrefix+"json", json.Validate)
+	cl.RegisterDomainValidator(encodingPkgPrefix+"regexp", regexp.Validate)
+	cl.RegisterDomainValidator(encodingPkgPrefix+"regexposix", regexposix.Validate)
+	cl.RegisterDomainValidator(encodingPkgPrefix+"toml", toml.Validate)
+	cl.RegisterDomainValidator(encodingPkgPrefix+"xml", xml.Validate)
+	cl.RegisterDomainValidator(encodingPkgPrefix+"yaml", yaml.Validate)
+}
+
+// -----------------------------------------------------------------------------
@@ -0,0 +1,146 @@
+/*
+ * Copyright (c) 2026 The XGo Authors (xgo.dev). All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package tool
+
+import (
+	"context"
+	"errors"
+	"go/parser"
+	"go/token"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/goplus/mod/modfetch"
+	"github.com/goplus/xgo/x/modproxy"
+	"golang.org/x/mod/module"
+)
+
+// fetcher downloads depended modules by the GOPROXY protocol, without running
+// `go get` or `go mod tidy`. The go command is used only if the proxies can't
+// serve a module (see modproxy.ErrNoProxy), eg. GOPROXY=direct or modules
+// matching GONOPROXY.
+var fetcher = sync.OnceValue(modproxy.New)
+
+func useGoCmd(err error) bool {
+	return errors.Is(err, modproxy.ErrNoProxy)
+}
+
+// GetMod downloads a module specified by `modPath` or `modPath@query`
+// (`latest` if not specified) into the module cache, and returns its version.
+func GetMod(modPath string) (mod module.Version, err error) {
+	ctx := context.Background()
+	path, query, ok := strings.Cut(modPath, "@")
+	if !ok {
+		query = "latest"
+	}
+	if mod, err = fetcher().Query(ctx, path, query); err == nil {
+		_, err = fetcher().Download(ctx, mod)
+	}
+	if useGoCmd(err) {
+		return modfetch.Get(modPath)
+	}
+	return
+}
+
+// GetPkg downloads the module containing a package specified by `pkgPath` or
+// `pkgPath@query` (`latest` if not specified) into the module cache, and
+// returns the module and the path of the package relative to the module.
+func GetPkg(pkgPath string) (mod module.Version, relPath string, err error) {
+	mod, relPath, err = fetcher().GetPkg(context.Background(), pkgPath)
+	if useGoCmd(err) {
+		return modfetch.GetPkg(pkgPath, "")
+	}
+	return
+}
+
+// AddSums adds hashes of mods and modules they require to go.sum in dir, as
+// `go get` does for them.
+func AddSums(dir string, mods ...module.Version) error {
+	return fetcher().AddSums(context.Background(), filepath.Join(dir, "go.sum"), mods...)
+}
+
+// fetch downloads a depended module into the module cache if it isn't there,
+// and adds its hashes to go.sum of the module like `go get` does.
+func (p *Importer) fetch(mod module.Version) (err error) {
+	ctx := context.Background()
+	if _, err = fetcher().Download(ctx, mod); err != nil {
+		if useGoCmd(err) {
+			_, err = modfetch.Get(mod.String())
+		}
+		return
+	}
+	if p.vendor != "" || p.overlay != nil { // go.sum is kept as is
+		return
+	}
+	if err = AddSums(p.mod.Root(), mod); useGoCmd(err) {
+		err = nil // like modfetch.Get, go.sum isn't updated for modules in the module cache
+	}
+	return
+}
+
+// tidyMod adds requirements of imports to a go.mod file, and their hashes to
+// go.sum beside it. It runs `go mod tidy` if the proxies can't serve them.
+func tidyMod(gomod string, imports []string) error {
+	err := fetcher().Tidy(context.Background(), gomod, imports)
+	if useGoCmd(err) {
+		cmd := exec.Command("go", "mod", "tidy")
+		cmd.Stdout = os.Stdout
+		cmd.Stderr = os.Stderr
+		cmd.Dir = filepath.Dir(gomod)
+		err = cmd.Run()
+	}
+	return err
+}
+
+// goImportsOf returns imports of Go files generated in dir.
+func goImportsOf(dir string) (imports []string) {
+	files, _ := filepath.Glob(filepath.Join(dir, "xgo_autogen*.go"))
+	fset := token.NewFileSet()
+	for _, file := range files {
+		f, err := parser.ParseFile(fset, file, nil, parser.ImportsOnly)
+		if err != nil {
+			continue
+		}
+		for _, spec := range f.Imports {
+			if path, err := strconv.Unquote(spec.Path.Value); err == nil {
+				imports = append(imports, path)
+			}
+		}
+	}
+	return
+}
+
+// findGoMod returns the go.mod file of the module containing dir.
+func findGoMod(dir string) string {
+	for {
+		gomod := filepath.Join(dir, "go.mod")
+		if _, err := os.Stat(gomod); err == nil {
+			return gomod
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return ""
+		}
+		dir = parent
+	}
+}
+
+// -----------------------------------------------------------------------------
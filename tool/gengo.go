@@ -24,8 +24,8 @@ import (
 	"strings"
 	"syscall"
 
+	"github.com/goplus/gogen"
 	"github.com/goplus/mod/modcache"
-	"github.com/goplus/mod/modfetch"
 	"github.com/goplus/mod/xgomod"
 	"github.com/qiniu/x/errors"
 )
@@ -69,6 +69,7 @@ func genGoDir(dir string, conf *Config, genTestPkg, recursively bool, flags GenF
 	if recursively {
 		var (
 			list errors.List
+			dirs []string
 			fn   func(path string, d fs.DirEntry, err error) error
 		)
 		if flags&GenFlagSingleFile != 0 {
@@ -84,12 +85,7 @@ func genGoDir(dir string, conf *Config, genTestPkg, recursively bool, flags GenF
 					if strings.HasPrefix(d.Name(), "_") || (path != dir && hasMod(path)) { // skip _
 						return filepath.SkipDir
 					}
-					if e := genGoIn(path, conf, genTestPkg, flags); e != nil && notIgnNotated(e, conf) {
-						if flags&GenFlagPrintError != 0 {
-							fmt.Fprintln(os.Stderr, e)
-						}
-						list.Add(e)
-					}
+					dirs = append(dirs, path)
 				}
 				return err
 			}
@@ -98,6 +94,9 @@ func genGoDir(dir string, conf *Config, genTestPkg, recursively bool, flags GenF
 		if err != nil {
 			return errors.NewWith(err, `filepath.WalkDir(dir, fn)`, -2, "filepath.WalkDir", dir, fn)
 		}
+		if dirs != nil {
+			genGoDirs(&list, dirs, conf, genTestPkg, flags)
+		}
 		return list.ToError()
 	}
 	if flags&GenFlagSingleFile != 0 {
@@ -159,8 +158,8 @@ func genGoSingleFile(file string, extn int, conf *Config, flags GenFlags) (err e
 	if flags&GenFlagCheckOnly != 0 {
 		return nil
 	}
-	if err := out.WriteFile(autogen); err != nil {
-		return errors.NewWith(err, `out.WriteFile(autogen)`, -2, "(*gogen.Package).WriteFile", out, autogen)
+	if err := writeGoFile(conf, out, autogen); err != nil {
+		return errors.NewWith(err, `writeGoFile(conf, out, autogen)`, -2, "tool.writeGoFile", conf, out, autogen)
 	}
 	return nil
 }
@@ -176,27 +175,29 @@ func genGoIn(dir string, conf *Config, genTestPkg bool, flags GenFlags, gen ...*
 	if flags&GenFlagCheckOnly != 0 {
 		return nil
 	}
-	os.MkdirAll(dir, 0755)
+	if conf.overlay() == nil {
+		os.MkdirAll(dir, 0755)
+	}
 	file := filepath.Join(dir, autoGenFile)
-	err = out.WriteFile(file)
+	err = writeGoFile(conf, out, file)
 	if err != nil {
-		return errors.NewWith(err, `out.WriteFile(file)`, -2, "(*gogen.Package).WriteFile", out, file)
+		return errors.NewWith(err, `writeGoFile(conf, out, file)`, -2, "tool.writeGoFile", conf, out, file)
 	}
 	if gen != nil { // say `xgo_autogen.go generated`
 		*gen[0] = true
 	}
 
 	testFile := filepath.Join(dir, autoGenTestFile)
-	err = out.WriteFile(testFile, testingGoFile)
+	err = writeGoFile(conf, out, testFile, testingGoFile)
 	if err != nil && err != syscall.ENOENT {
-		return errors.NewWith(err, `out.WriteFile(testFile, testingGoFile)`, -2, "(*gogen.Package).WriteFile", out, testFile, testingGoFile)
+		return errors.NewWith(err, `writeGoFile(conf, out, testFile, testingGoFile)`, -2, "tool.writeGoFile", conf, out, testFile, testingGoFile)
 	}
 
 	if test != nil {
 		testFile = filepath.Join(dir, autoGen2TestFile)
-		err = test.WriteFile(testFile, testingGoFile)
+		err = writeGoFile(conf, test, testFile, testingGoFile)
 		if err != nil {
-			return errors.NewWith(err, `test.WriteFile(testFile, testingGoFile)`, -2, "(*gogen.Package).WriteFile", test, testFile, testingGoFile)
+			return errors.NewWith(err, `writeGoFile(conf, test, testFile, testingGoFile)`, -2, "tool.writeGoFile", conf, test, testFile, testingGoFile)
 		}
 	} else {
 		err = nil
@@ -204,6 +205,15 @@ func genGoIn(dir string, conf *Config, genTestPkg bool, flags GenFlags, gen ...*
 	return
 }
 
+// writeGoFile writes a Go file generated from pkg into the overlay of conf if
+// any, or into the disk otherwise.
+func writeGoFile(conf *Config, pkg *gogen.Package, file string, fname ...string) error {
+	if ov := conf.overlay(); ov != nil {
+		return ov.writeGoFile(file, pkg, fname...)
+	}
+	return pkg.WriteFile(file, fname...)
+}
+
 // -----------------------------------------------------------------------------
 
 const (
@@ -218,8 +228,10 @@ func GenGoPkgPath(workDir, pkgPath string, conf *Config, allowExtern bool) (loca
 
 func remotePkgPath(pkgPath string, conf *Config, recursively bool, flags GenFlags) (localDir string, _recursively bool, err error) {
 	remotePkgPathDo(pkgPath, func(dir, _ string) {
-		os.Chmod(dir, modWritable)
-		defer os.Chmod(dir, modReadonly)
+		if conf == nil || conf.overlay() == nil {
+			os.Chmod(dir, modWritable)
+			defer os.Chmod(dir, modReadonly)
+		}
 		localDir = dir
 		_recursively = recursively
 		err = genGoDir(dir, conf, false, recursively, flags)
@@ -250,7 +262,7 @@ func GenGoPkgPathEx(workDir, pkgPath string, conf *Config, allowExtern bool, fla
 		return
 	}
 	localDir = pkg.Dir
-	if pkg.Type == xgomod.PkgtExtern {
+	if pkg.Type == xgomod.PkgtExtern && (conf == nil || conf.overlay() == nil) {
 		os.Chmod(localDir, modWritable)
 		defer os.Chmod(localDir, modReadonly)
 	}
@@ -259,7 +271,7 @@ func GenGoPkgPathEx(workDir, pkgPath string, conf *Config, allowExtern bool, fla
 }
 
 func remotePkgPathDo(pkgPath string, doSth func(pkgDir, modDir string), onErr func(e error)) {
-	modVer, leftPart, err := modfetch.GetPkg(pkgPath, "")
+	modVer, leftPart, err := GetPkg(pkgPath)
 	if err != nil {
 		onErr(err)
 	} else if dir, err := modcache.Path(modVer); err != nil {
@@ -291,9 +303,9 @@ func GenGoFiles(autogen string, files []string, conf *Config) (outFiles []string
 		err = errors.NewWith(err, `LoadFiles(files, conf)`, -2, "tool.LoadFiles", files, conf)
 		return
 	}
-	err = out.WriteFile(autogen)
+	err = writeGoFile(conf, out, autogen)
 	if err != nil {
-		err = errors.NewWith(err, `out.WriteFile(autogen)`, -2, "(*gogen.Package).WriteFile", out, autogen)
+		err = errors.NewWith(err, `writeGoFile(conf, out, autogen)`, -2, "tool.writeGoFile", conf, out, autogen)
 	}
 	outFiles = []string{autogen}
 	return
@@ -0,0 +1,176 @@
+/*
+ * Copyright (c) 2026 The XGo Authors (xgo.dev). All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package tool
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strconv"
+	"sync"
+
+	"github.com/goplus/mod/xgomod"
+	"github.com/goplus/xgo/parser"
+	"github.com/goplus/xgo/token"
+	"github.com/qiniu/x/errors"
+)
+
+// -----------------------------------------------------------------------------
+
+// genGoDirs generates xgo_autogen.go for packages in dirs. Packages are
+// generated in parallel by a bounded pool of workers, and a package is
+// generated after the packages in dirs it imports.
+func genGoDirs(list *errors.List, dirs []string, conf *Config, genTestPkg bool, flags GenFlags) {
+	var mu sync.Mutex
+	report := func(e error) {
+		if e != nil && notIgnNotated(e, conf) {
+			mu.Lock()
+			defer mu.Unlock()
+			if flags&GenFlagPrintError != 0 {
+				fmt.Fprintln(os.Stderr, e)
+			}
+			list.Add(e)
+		}
+	}
+	n := len(dirs)
+	workers := min(runtime.GOMAXPROCS(0), n)
+	if workers <= 1 || conf.Importer == nil || conf.Mod == nil {
+		for _, dir := range dirs {
+			report(genGoIn(dir, conf, genTestPkg, flags))
+		}
+		return
+	}
+
+	deps := pkgDeps(conf.Mod, dirs, conf)
+	pending := make([]int, n)      // number of deps not generated yet
+	dependents := make([][]int, n) // packages importing a package
+	for i, ds := range deps {
+		pending[i] = len(ds)
+		for _, d := range ds {
+			dependents[d] = append(dependents[d], i)
+		}
+	}
+
+	ready := make(chan int, n)
+	done := make(chan int)
+	for range workers {
+		// each worker has its own Importer for its own import stack
+		wconf := *conf
+		wconf.Importer = conf.Importer.fork()
+		go func() {
+			imp := wconf.Importer
+			for i := range ready {
+				// a package may also be generated on demand by a worker importing it
+				dir, _ := filepath.Abs(dirs[i])
+				report(imp.sync.do(imp, dir, func() error {
+					return genGoIn(dirs[i], &wconf, genTestPkg, flags)
+				}))
+				done <- i
+			}
+		}()
+	}
+	queued := make([]bool, n)
+	nqueued := 0
+	push := func(i int) {
+		queued[i] = true
+		nqueued++
+		ready <- i
+	}
+	for i := range dirs {
+		if pending[i] == 0 {
+			push(i)
+		}
+	}
+	for finished := 0; finished < n; finished++ {
+		if nqueued == finished { // nothing is running: there are import cycles
+			for i := range dirs {
+				if !queued[i] {
+					push(i)
+				}
+			}
+		}
+		i := <-done
+		for _, j := range dependents[i] {
+			if pending[j]--; pending[j] == 0 && !queued[j] {
+				push(j)
+			}
+		}
+	}
+	close(ready)
+}
+
+// pkgDeps returns the indexes of packages in dirs that each package imports.
+func pkgDeps(mod *xgomod.Module, dirs []string, conf *Config) [][]int {
+	deps := make([][]int, len(dirs))
+	if !mod.HasModfile() {
+		return deps
+	}
+	root, modPath := mod.Root(), mod.Path()
+	index := make(map[string]int, len(dirs)) // pkgPath => index of dirs
+	for i, dir := range dirs {
+		if pkgPath, ok := pkgPathOf(root, modPath, dir); ok {
+			index[pkgPath] = i
+		}
+	}
+	for i, dir := range dirs {
+		pkgs, _ := parser.ParseDirEx(token.NewFileSet(), dir, parser.Config{
+			ClassKind: mod.ClassKind,
+			Filter:    conf.Filter,
+			Mode:      parser.ImportsOnly,
+		})
+		seen := make(map[int]bool)
+		addDep := func(lit string) {
+			if pkgPath, err := strconv.Unquote(lit); err == nil {
+				if d, ok := index[pkgPath]; ok && d != i && !seen[d] {
+					seen[d] = true
+					deps[i] = append(deps[i], d)
+				}
+			}
+		}
+		for _, pkg := range pkgs {
+			for _, f := range pkg.Files {
+				for _, imp := range f.Imports {
+					addDep(imp.Path.Value)
+				}
+			}
+			for _, f := range pkg.GoFiles {
+				for _, imp := range f.Imports {
+					addDep(imp.Path.Value)
+				}
+			}
+		}
+	}
+	return deps
+}
+
+func pkgPathOf(root, modPath, dir string) (string, bool) {
+	abs, err := filepath.Abs(dir)
+	if err != nil {
+		return "", false
+	}
+	rel, err := filepath.Rel(root, abs)
+	if err != nil || rel == ".." || len(rel) > 2 && rel[:3] == ".."+string(filepath.Separator) {
+		return "", false
+	}
+	if rel == "." {
+		return modPath, true
+	}
+	return modPath + "/" + filepath.ToSlash(rel), true
+}
+
+// -----------------------------------------------------------------------------
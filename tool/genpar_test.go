@@ -0,0 +1,110 @@
+/*
+ * Copyright (c) 2026 The XGo Authors (xgo.dev). All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package tool
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestPkgDeps(t *testing.T) {
+	dir := t.TempDir()
+	files := map[string]string{
+		"go.mod":  "module foo\n\ngo 1.21\n",
+		"a/a.xgo": "package a\n",
+		"b/b.xgo": "package b\n\nimport \"foo/a\"\n",
+		"c/c.go":  "package c\n\nimport (\n\t\"fmt\"\n\t\"foo/a\"\n\t\"foo/b\"\n)\n",
+		"d/d.xgo": "import \"foo/c\"\n\necho c.C\n",
+	}
+	for name, src := range files {
+		file := filepath.Join(dir, name)
+		os.MkdirAll(filepath.Dir(file), 0755)
+		if err := os.WriteFile(file, []byte(src), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	mod, err := LoadMod(dir)
+	if err != nil {
+		t.Fatal("LoadMod:", err)
+	}
+	var dirs []string
+	for _, name := range []string{"a", "b", "c", "d"} {
+		dirs = append(dirs, filepath.Join(dir, name))
+	}
+	deps := pkgDeps(mod, dirs, &Config{})
+	if want := [][]int{nil, {0}, {0, 1}, {2}}; !reflect.DeepEqual(deps, want) {
+		t.Fatal("pkgDeps:", deps)
+	}
+}
+
+func TestImportSync(t *testing.T) {
+	imp := &Importer{importStack: make(map[string]bool)}
+	w1, w2 := imp.fork(), imp.fork()
+	s := imp.sync
+	if w1.sync != s || w2.sync != s {
+		t.Fatal("fork: not shared")
+	}
+
+	// a package is generated once
+	n := 0
+	for range 2 {
+		s.do(w1, "/foo/a", func() error { n++; return nil })
+	}
+	if n != 1 {
+		t.Fatal("do: generated", n, "times")
+	}
+
+	// w2 generates y, which imports x; w1 generates x, which imports y
+	w2Started, w1Waiting, result := make(chan bool), make(chan bool), make(chan error, 2)
+	go func() {
+		result <- s.do(w2, "/foo/y", func() error {
+			w2Started <- true
+			<-w1Waiting
+			return s.do(w2, "/foo/x", func() error { return nil })
+		})
+	}()
+	<-w2Started
+	go func() {
+		result <- s.do(w1, "/foo/x", func() error {
+			return s.do(w1, "/foo/y", func() error { return nil })
+		})
+	}()
+	for { // wait for w1 waiting for y
+		s.genMu.Lock()
+		waiting := s.waiting[w1] != nil
+		s.genMu.Unlock()
+		if waiting {
+			break
+		}
+		time.Sleep(time.Millisecond)
+	}
+	close(w1Waiting)
+	for range 2 {
+		select {
+		case err := <-result:
+			if err == nil || !strings.Contains(err.Error(), "cycle import detected") {
+				t.Fatal("do: unexpected", err)
+			}
+		case <-time.After(10 * time.Second):
+			t.Fatal("do: deadlock")
+		}
+	}
+}
@@ -17,24 +17,30 @@
 package tool
 
 import (
+	"bytes"
 	"crypto/sha256"
 	"encoding/base64"
+	"encoding/hex"
 	"fmt"
 	"go/token"
 	"go/types"
 	"io"
 	"log"
 	"os"
-	"os/exec"
 	"path"
 	"path/filepath"
 	"runtime"
+	"strconv"
 	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
 
+	"github.com/goplus/gogen"
 	"github.com/goplus/gogen/packages"
 	"github.com/goplus/gogen/packages/cache"
 	"github.com/goplus/mod/env"
-	"github.com/goplus/mod/modfetch"
+	"github.com/goplus/mod/modcache"
 	"github.com/goplus/mod/modfile"
 	"github.com/goplus/mod/xgomod"
 )
@@ -47,10 +53,16 @@ type Importer struct {
 	mod     *xgomod.Module
 	xgo     *env.XGo
 	fset    *token.FileSet
+	vendor  string // vendor directory if packages of depended modules are loaded from it
+	modFlag string // value of the -mod flag passed to the go command
+	overlay *Overlay
+	hashes  *fileHashCache // shared by forks
 
 	Flags GenFlags // can change this for loading XGo modules
 
 	importStack map[string]bool
+	localDirs   map[string]bool // dirs of imported packages of the module
+	sync        *importSync     // not nil if shared by workers generating packages in parallel
 }
 
 // NewImporter creates an XGo Importer.
@@ -68,22 +80,56 @@ func NewImporter(mod *xgomod.Module, xgo *env.XGo, fset *token.FileSet) *Importe
 	}
 	dir := mod.Root()
 	impFrom := packages.NewImporter(fset, dir)
-	ret := &Importer{mod: mod, xgo: xgo, impFrom: impFrom, fset: fset, Flags: defaultFlags, importStack: make(map[string]bool)}
-	impFrom.SetCache(cache.New(ret.PkgHash))
+	ret := &Importer{mod: mod, xgo: xgo, impFrom: impFrom, fset: fset, vendor: vendorDir(mod, ""), hashes: new(fileHashCache), Flags: defaultFlags, importStack: make(map[string]bool), localDirs: make(map[string]bool)}
+	impFrom.SetCache(newPkgCache(ret.PkgHash, ret.GoFlags))
 	return ret
 }
 
 func (p *Importer) SetTags(tags string) {
 	p.impFrom.SetTags(tags)
-	if c, ok := p.impFrom.Cache().(*cache.Impl); ok {
-		c.SetTags(tags)
+	p.Cache().SetTags(tags)
+}
+
+// SetModFlag sets value of the -mod flag (eg. `vendor`) passed to the go
+// command, which decides whether to load packages of depended modules from
+// the vendor directory of the module. See GoFlags.
+func (p *Importer) SetModFlag(mod string) {
+	if mod == "" {
+		return
+	}
+	p.vendor = vendorDir(p.mod, mod)
+	p.modFlag = mod
+}
+
+// GoFlags returns flags of the go command to load packages the same way as
+// the Importer, like -mod set by SetModFlag and -overlay set by SetOverlay.
+// They are passed to `go list` called by the Importer, and to the go command
+// run by BuildDir, RunDir etc.
+func (p *Importer) GoFlags() (flags []string) {
+	if p.modFlag != "" {
+		flags = append(flags, "-mod="+p.modFlag)
+	}
+	if p.overlay != nil {
+		flags = append(flags, "-overlay="+p.overlay.File())
 	}
+	return
+}
+
+// SetOverlay makes the Importer (and LoadDir, GenGo etc. using it) read
+// sources from ov and write generated files into ov instead of the disk. The
+// go command sees files of ov by the -overlay flag, see GoFlags.
+func (p *Importer) SetOverlay(ov *Overlay) {
+	p.overlay = ov
+}
+
+// Overlay returns the Overlay set by SetOverlay.
+func (p *Importer) Overlay() *Overlay {
+	return p.overlay
 }
 
 // CacheFile returns file path of the cache.
 func (p *Importer) CacheFile() string {
-	cacheDir, _ := os.UserCacheDir()
-	cacheDir += "/xgo-build/"
+	cacheDir := buildCacheDir() + "/"
 	os.MkdirAll(cacheDir, 0755)
 
 	fname := ""
@@ -101,12 +147,12 @@ func (p *Importer) CacheFile() string {
 }
 
 // Cache returns the cache object.
-func (p *Importer) Cache() *cache.Impl {
-	return p.impFrom.Cache().(*cache.Impl)
+func (p *Importer) Cache() *PkgCache {
+	return p.impFrom.Cache().(*PkgCache)
 }
 
 // PkgHash calculates hash value for a package.
-// It is required by cache.New func.
+// It is required by PkgCache.
 func (p *Importer) PkgHash(pkgPath string, self bool) string {
 	if pkg, e := p.mod.Lookup(pkgPath); e == nil {
 		switch pkg.Type {
@@ -116,9 +162,16 @@ func (p *Importer) PkgHash(pkgPath string, self bool) string {
 			if pkg.Real.Version != "" {
 				return pkg.Real.String()
 			}
+			if p.vendor != "" {
+				return dirHash(p.hashes, p.mod, p.xgo, filepath.Join(p.vendor, pkgPath), p.mod.Root(), self)
+			}
 			fallthrough
 		case xgomod.PkgtModule:
-			return dirHash(p.mod, p.xgo, pkg.Dir, self)
+			ret := dirHash(p.hashes, p.mod, p.xgo, pkg.Dir, pkg.ModDir, self)
+			if p.overlay != nil {
+				ret += p.overlay.dirHash(pkg.Dir)
+			}
+			return ret
 		}
 	}
 	if isPkgInMod(pkgPath, xgoMod) || isPkgInMod(pkgPath, xMod) {
@@ -140,6 +193,11 @@ func (p *Importer) Import(pkgPath string) (pkg *types.Package, err error) {
 	}
 	p.importStack[pkgPath] = true
 	defer delete(p.importStack, pkgPath)
+	if p.vendored(pkgPath) { // the go command loads it from the vendor directory
+		p.lock()
+		defer p.unlock()
+		return p.imported(p.impFrom.Import(pkgPath))
+	}
 	if strings.HasPrefix(pkgPath, xgoMod) {
 		if suffix := pkgPath[len(xgoMod):]; suffix == "" || suffix[0] == '/' {
 			xgoRoot := p.xgo.Root
@@ -148,49 +206,108 @@ func (p *Importer) Import(pkgPath string) (pkg *types.Package, err error) {
 					return
 				}
 			}
-			return p.impFrom.ImportFrom(pkgPath, xgoRoot, 0)
+			return p.importFrom(pkgPath, xgoRoot)
 		}
 	}
 	if isPkgInMod(pkgPath, xMod) {
-		return p.impFrom.ImportFrom(pkgPath, p.xgo.Root, 0)
+		return p.importFrom(pkgPath, p.xgo.Root)
 	}
 	if mod := p.mod; mod.HasModfile() {
+		p.lock()
 		ret, e := mod.Lookup(pkgPath)
+		p.unlock()
 		if e != nil {
 			return nil, e
 		}
 		switch ret.Type {
 		case xgomod.PkgtExtern:
+			p.lock()
+			defer p.unlock()
 			isExtern := ret.Real.Version != ""
 			if isExtern {
-				if _, err = modfetch.Get(ret.Real.String()); err != nil {
+				if err = p.fetch(ret.Real); err != nil {
 					return
 				}
 			}
 			modDir := ret.ModDir
 			goModfile := filepath.Join(modDir, "go.mod")
 			if _, e := os.Lstat(goModfile); e != nil { // no go.mod
+				if p.overlay != nil { // don't write go.mod into the module cache
+					return p.imported(p.impFrom.Import(pkgPath))
+				}
 				os.Chmod(modDir, modWritable)
 				defer os.Chmod(modDir, modReadonly)
 				os.WriteFile(goModfile, defaultGoMod(ret.ModPath), 0644)
 			}
-			return p.impFrom.ImportFrom(pkgPath, modDir, 0)
+			return p.imported(p.impFrom.ImportFrom(pkgPath, modDir, 0))
 		case xgomod.PkgtModule, xgomod.PkgtLocal:
 			if pkgPath == p.mod.Path() {
 				break
 			}
+			p.lock()
+			p.localDirs[filepath.Clean(ret.Dir)] = true
+			p.unlock()
 			if err = p.genGoExtern(ret.Dir, false); err != nil {
 				return
 			}
 		case xgomod.PkgtStandard:
-			return p.impFrom.ImportFrom(pkgPath, p.xgo.Root, 0)
+			return p.importFrom(pkgPath, p.xgo.Root)
 		}
 	}
-	return p.impFrom.Import(pkgPath)
+	p.lock()
+	defer p.unlock()
+	return p.imported(p.impFrom.Import(pkgPath))
+}
+
+// Imported reports whether a package of the module in dir has been imported.
+// Types of such a package are cached by the Importer, so it should be dropped
+// if source files of the package are changed.
+func (p *Importer) Imported(dir string) bool {
+	p.lock()
+	defer p.unlock()
+	return p.localDirs[filepath.Clean(dir)]
+}
+
+// vendored reports whether pkgPath is loaded from the vendor directory.
+func (p *Importer) vendored(pkgPath string) bool {
+	if p.vendor == "" {
+		return false
+	}
+	fi, err := os.Stat(filepath.Join(p.vendor, pkgPath))
+	return err == nil && fi.IsDir()
+}
+
+func (p *Importer) importFrom(pkgPath, dir string) (*types.Package, error) {
+	p.lock()
+	defer p.unlock()
+	return p.imported(p.impFrom.ImportFrom(pkgPath, dir, 0))
+}
+
+// imported is called with the lock held after a package is imported.
+func (p *Importer) imported(pkg *types.Package, err error) (*types.Package, error) {
+	if p.sync != nil && err == nil {
+		p.sync.initXGoPkg(p, pkg)
+	}
+	return pkg, err
 }
 
 func (p *Importer) genGoExtern(dir string, isExtern bool) (err error) {
+	if p.sync != nil {
+		return p.sync.do(p, dir, func() error {
+			return p.doGenGoExtern(dir, isExtern)
+		})
+	}
+	return p.doGenGoExtern(dir, isExtern)
+}
+
+func (p *Importer) doGenGoExtern(dir string, isExtern bool) (err error) {
 	genfile := filepath.Join(dir, autoGenFile)
+	if ov := p.overlay; ov != nil {
+		if ov.exists(genfile) {
+			return
+		}
+		return genGoIn(dir, &Config{XGo: p.xgo, Importer: p, Fset: p.fset}, false, p.Flags)
+	}
 	if _, err = os.Lstat(genfile); err != nil { // no xgo_autogen.go
 		if isExtern {
 			os.Chmod(dir, modWritable)
@@ -201,17 +318,117 @@ func (p *Importer) genGoExtern(dir string, isExtern bool) (err error) {
 		if err != nil {
 			return
 		}
-		if gen {
-			cmd := exec.Command("go", "mod", "tidy")
-			cmd.Stdout = os.Stdout
-			cmd.Stderr = os.Stderr
-			cmd.Dir = dir
-			err = cmd.Run()
+		if gen && !isExtern { // go.mod of modules in the module cache is never used
+			if gomod := findGoMod(dir); gomod != "" && !modcache.InPath(gomod) {
+				err = tidyMod(gomod, goImportsOf(dir))
+			}
 		}
 	}
 	return
 }
 
+// -----------------------------------------------------------------------------
+
+// importSync synchronizes Importers of workers generating packages in
+// parallel. They share the underlying Go importer and the module, which are
+// protected by mu. Packages generated on demand (see genGoExtern) are
+// generated once, and workers importing a package being generated by another
+// worker wait for it.
+type importSync struct {
+	mu      sync.Mutex     // protects impFrom and mod
+	initPkg *gogen.Package // see initXGoPkg
+
+	genMu   sync.Mutex
+	gens    map[string]*genCall    // dir => generation of the package
+	waiting map[*Importer]*genCall // worker => generation it is waiting for
+}
+
+type genCall struct {
+	owner *Importer
+	done  chan struct{}
+	err   error
+}
+
+// fork returns an Importer for a worker generating packages in parallel with
+// other workers using Importers forked from p.
+func (p *Importer) fork() *Importer {
+	if p.sync == nil {
+		p.sync = &importSync{
+			gens:    make(map[string]*genCall),
+			waiting: make(map[*Importer]*genCall),
+		}
+	}
+	ret := *p
+	ret.importStack = make(map[string]bool)
+	return &ret
+}
+
+// initXGoPkg initializes an imported XGo package. gogen initializes it when
+// it is used the first time, which changes its scope. Packages are shared by
+// workers, so it is done here with the lock held.
+func (p *importSync) initXGoPkg(imp *Importer, pkg *types.Package) {
+	if p.initPkg == nil {
+		p.initPkg = gogen.NewPackage("", "main", &gogen.Config{
+			Fset:     imp.fset,
+			Importer: imp.impFrom,
+		})
+	}
+	p.initPkg.TryImport(pkg.Path())
+}
+
+func (p *Importer) lock() {
+	if p.sync != nil {
+		p.sync.mu.Lock()
+	}
+}
+
+func (p *Importer) unlock() {
+	if p.sync != nil {
+		p.sync.mu.Unlock()
+	}
+}
+
+// do calls gen to generate the package in dir for the worker imp, unless it
+// is generated (or being generated) by a worker. Instead of waiting forever,
+// it reports an import cycle if the worker generating the package is waiting
+// (directly or indirectly) for imp.
+func (p *importSync) do(imp *Importer, dir string, gen func() error) error {
+	p.genMu.Lock()
+	if c, ok := p.gens[dir]; ok {
+		select {
+		case <-c.done:
+			p.genMu.Unlock()
+			return c.err
+		default:
+		}
+		for w := c.owner; w != nil; {
+			if w == imp {
+				p.genMu.Unlock()
+				return fmt.Errorf("cycle import detected: package %s imports itself", dir)
+			}
+			next := p.waiting[w]
+			if next == nil {
+				break
+			}
+			w = next.owner
+		}
+		p.waiting[imp] = c
+		p.genMu.Unlock()
+		<-c.done
+		p.genMu.Lock()
+		delete(p.waiting, imp)
+		p.genMu.Unlock()
+		return c.err
+	}
+	c := &genCall{owner: imp, done: make(chan struct{})}
+	p.gens[dir] = c
+	p.genMu.Unlock()
+
+	c.err = gen()
+	close(c.done)
+	return c.err
+}
+
 func isPkgInMod(pkgPath, modPath string) bool {
 	if strings.HasPrefix(pkgPath, modPath) {
 		suffix := pkgPath[len(modPath):]
@@ -227,12 +444,20 @@ go 1.16
 `)
 }
 
-func dirHash(mod *xgomod.Module, xgo *env.XGo, dir string, self bool) string {
+func dirHash(hashes *fileHashCache, mod *xgomod.Module, xgo *env.XGo, dir, modDir string, self bool) string {
 	h := sha256.New()
 	if self {
 		fmt.Fprintf(h, "go\t%s\n", runtime.Version())
 		fmt.Fprintf(h, "xgo\t%s\n", xgo.Version)
 	}
+	if modDir != "" {
+		// classfiles are registered by go.mod (and gop.mod for old modules)
+		for _, fname := range []string{"go.mod", "gop.mod"} {
+			if sum, err := hashes.hash(filepath.Join(modDir, fname)); err == nil {
+				fmt.Fprintf(h, "mod\t%s\t%x\n", fname, sum)
+			}
+		}
+	}
 	if fis, err := os.ReadDir(dir); err == nil {
 		for _, fi := range fis {
 			if fi.IsDir() {
@@ -242,14 +467,123 @@ func dirHash(mod *xgomod.Module, xgo *env.XGo, dir string, self bool) string {
 			if strings.HasPrefix(fname, "_") || !canCl(mod, fname) {
 				continue
 			}
-			if v, e := fi.Info(); e == nil {
-				fmt.Fprintf(h, "file\t%s\t%x\t%x\n", fname, v.Size(), v.ModTime().UnixNano())
+			if sum, err := hashes.hash(filepath.Join(dir, fname)); err == nil {
+				fmt.Fprintf(h, "file\t%s\t%x\n", fname, sum)
 			}
 		}
 	}
 	return base64.RawStdEncoding.EncodeToString(h.Sum(nil))
 }
 
+type fileHashEntry struct {
+	size    int64
+	modTime int64
+	sum     [sha256.Size]byte
+}
+
+// modTimeCutoff is the safety window of mtimes, like the go command: hashes
+// of files modified within it before they are hashed aren't recorded, because
+// the files may be modified again without changing their mtimes (which are of
+// the granularity of file system timestamps).
+const modTimeCutoff = 2 * time.Second
+
+// fileHashCache caches content hashes of files, keyed by file path. They are
+// saved with the build cache (see save), so that unchanged files aren't read
+// again by the next run.
+type fileHashCache struct {
+	hashes sync.Map    // map[string]*fileHashEntry
+	dirty  atomic.Bool // files are hashed since hashes is loaded
+}
+
+// hash returns the content hash of a file. Keying the build cache on contents
+// rather than size+mtime keeps it valid across git checkouts and CI cache
+// restores. If size and mtime of the file are unchanged since it was hashed,
+// the hash is reused without reading the file again.
+func (p *fileHashCache) hash(file string) (sum [sha256.Size]byte, err error) {
+	now := time.Now()
+	fi, err := os.Stat(file)
+	if err != nil {
+		p.hashes.Delete(file)
+		return
+	}
+	size, modTime := fi.Size(), fi.ModTime().UnixNano()
+	if v, ok := p.hashes.Load(file); ok {
+		if e := v.(*fileHashEntry); e.size == size && e.modTime == modTime {
+			return e.sum, nil
+		}
+	}
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return
+	}
+	sum = sha256.Sum256(data)
+	if fi.ModTime().After(now.Add(-modTimeCutoff)) {
+		p.hashes.Delete(file)
+		return
+	}
+	p.hashes.Store(file, &fileHashEntry{size: size, modTime: modTime, sum: sum})
+	p.dirty.Store(true)
+	return
+}
+
+// fileHashesFile returns the file saving hashes of files for the cache file of
+// the build cache (see Importer.CacheFile).
+func fileHashesFile(cacheFile string) string {
+	return cacheFile + ".files"
+}
+
+/*
+The file of hashes of files is made of lines:
+
+	<sum>	<size>	<modTime>	<file>
+
+where sum is in hex, and modTime is in nanoseconds since the Unix epoch.
+*/
+
+// load loads hashes of files saved by save. Invalid lines are ignored.
+func (p *fileHashCache) load(file string) {
+	b, err := os.ReadFile(file)
+	if err != nil {
+		return
+	}
+	for line := range strings.SplitSeq(string(b), "\n") {
+		parts := strings.SplitN(line, "\t", 4)
+		if len(parts) != 4 {
+			continue
+		}
+		e := new(fileHashEntry)
+		sum, err1 := hex.DecodeString(parts[0])
+		size, err2 := strconv.ParseInt(parts[1], 10, 64)
+		modTime, err3 := strconv.ParseInt(parts[2], 10, 64)
+		if err1 != nil || err2 != nil || err3 != nil || len(sum) != len(e.sum) {
+			continue
+		}
+		e.size, e.modTime = size, modTime
+		copy(e.sum[:], sum)
+		p.hashes.LoadOrStore(parts[3], e)
+	}
+}
+
+// save saves hashes of files into file if files are hashed since they are
+// loaded. Hashes of files that no longer exist are dropped.
+func (p *fileHashCache) save(file string) error {
+	if !p.dirty.Swap(false) {
+		return nil
+	}
+	var buf bytes.Buffer
+	p.hashes.Range(func(key, val any) bool {
+		fname := key.(string)
+		if _, err := os.Lstat(fname); err != nil {
+			p.hashes.Delete(fname)
+			return true
+		}
+		e := val.(*fileHashEntry)
+		fmt.Fprintf(&buf, "%x\t%d\t%d\t%s\n", e.sum, e.size, e.modTime, fname)
+		return true
+	})
+	return os.WriteFile(file, buf.Bytes(), 0666)
+}
+
 func canCl(mod *xgomod.Module, fname string) bool {
 	switch path.Ext(fname) {
 	case ".go", ".xgo", ".gop", ".gox":
@@ -0,0 +1,136 @@
+/*
+ * Copyright (c) 2026 The XGo Authors (xgo.dev). All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package tool
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/goplus/mod/env"
+	"github.com/goplus/mod/xgomod"
+)
+
+func TestDirHash(t *testing.T) {
+	root := t.TempDir()
+	dir := filepath.Join(root, "foo")
+	os.Mkdir(dir, 0755)
+	gomod := filepath.Join(root, "go.mod")
+	file := filepath.Join(dir, "foo.xgo")
+	os.WriteFile(gomod, []byte("module foo\n"), 0644)
+	os.WriteFile(file, []byte("echo 1\n"), 0644)
+	os.WriteFile(filepath.Join(dir, "README.md"), []byte("readme"), 0644)
+
+	mod, xgo := xgomod.Default, &env.XGo{Version: "v1.0.0"}
+	hashes := new(fileHashCache)
+	hash := func() string {
+		return dirHash(hashes, mod, xgo, dir, root, true)
+	}
+	h1 := hash()
+
+	// touching files doesn't change the hash: git checkouts, CI cache restores
+	later := time.Now().Add(time.Hour)
+	os.Chtimes(file, later, later)
+	os.Chtimes(gomod, later, later)
+	if h := hash(); h != h1 {
+		t.Fatal("dirHash changed by mtime")
+	}
+	// files that can't be compiled are ignored
+	os.WriteFile(filepath.Join(dir, "README.md"), []byte("README"), 0644)
+	if h := hash(); h != h1 {
+		t.Fatal("dirHash changed by README.md")
+	}
+
+	os.WriteFile(file, []byte("echo 2\n"), 0644)
+	h2 := hash()
+	if h2 == h1 {
+		t.Fatal("dirHash not changed by contents")
+	}
+	os.WriteFile(gomod, []byte("module foo\n\nrequire github.com/goplus/yap v0.8.0 //xgo:class\n"), 0644)
+	if h := hash(); h == h2 {
+		t.Fatal("dirHash not changed by go.mod")
+	}
+	if h := dirHash(hashes, mod, &env.XGo{Version: "v1.0.1"}, dir, root, true); h == hash() {
+		t.Fatal("dirHash not changed by XGo version")
+	}
+}
+
+func TestFileHashes(t *testing.T) {
+	dir := t.TempDir()
+	old := time.Now().Add(-time.Hour)
+	writeFile := func(file, data string) {
+		os.WriteFile(file, []byte(data), 0644)
+		os.Chtimes(file, old, old)
+	}
+	c := new(fileHashCache)
+	file := filepath.Join(dir, "a.xgo")
+	writeFile(file, "echo 1\n")
+	sum, err := c.hash(file)
+	if err != nil {
+		t.Fatal("hash:", err)
+	}
+	gone := filepath.Join(dir, "b.xgo")
+	writeFile(gone, "echo 2\n")
+	c.hash(gone)
+	os.Remove(gone)
+	// files modified within modTimeCutoff may change without changing mtimes
+	fresh := filepath.Join(dir, "c.xgo")
+	os.WriteFile(fresh, []byte("echo 3\n"), 0644)
+	if _, err = c.hash(fresh); err != nil {
+		t.Fatal("hash:", err)
+	}
+
+	hashes := filepath.Join(dir, "cache.files")
+	if err = c.save(hashes); err != nil {
+		t.Fatal("save:", err)
+	}
+	data, err := os.ReadFile(hashes)
+	if err != nil || !strings.Contains(string(data), file) ||
+		strings.Contains(string(data), gone) || strings.Contains(string(data), fresh) {
+		t.Fatal("save:", string(data), err)
+	}
+	os.Remove(hashes)
+	if err = c.save(hashes); err != nil {
+		t.Fatal("save:", err)
+	}
+	if _, err = os.Stat(hashes); err == nil {
+		t.Fatal("save: saved without changes")
+	}
+
+	// the next run reuses the saved hash if size and mtime are unchanged
+	c = new(fileHashCache)
+	os.WriteFile(hashes, append(data, "invalid\n"...), 0644)
+	c.load(hashes)
+	writeFile(file, "echo 4\n")
+	if ret, err := c.hash(file); err != nil || ret != sum {
+		t.Fatal("hash: saved hash isn't reused", err)
+	}
+	os.Chtimes(file, old, old.Add(time.Second))
+	if ret, _ := c.hash(file); ret == sum {
+		t.Fatal("hash: not changed")
+	}
+	// hashes of removed files are dropped
+	os.Remove(file)
+	if _, err = c.hash(file); err == nil {
+		t.Fatal("hash: no error")
+	}
+	if _, ok := c.hashes.Load(file); ok {
+		t.Fatal("hash: removed file isn't dropped")
+	}
+}
@@ -0,0 +1,248 @@
+/*
+ * Copyright (c) 2026 The XGo Authors (xgo.dev). All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package tool
+
+import (
+	"io/fs"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/goplus/mod/modfile"
+	"github.com/goplus/mod/xgomod"
+	"github.com/goplus/xgo/parser"
+	"github.com/goplus/xgo/token"
+	"github.com/qiniu/x/errors"
+)
+
+// -----------------------------------------------------------------------------
+
+// Package describes a package found by List.
+type Package struct {
+	Dir        string // directory containing package sources
+	ImportPath string `json:",omitempty"` // import path of the package (empty if not in a module)
+	Name       string // package name
+
+	XGoFiles     []string     `json:",omitempty"` // .xgo and .gop source files (excluding tests)
+	GoFiles      []string     `json:",omitempty"` // .go source files (excluding tests and generated files)
+	ClassFiles   []*ClassFile `json:",omitempty"` // classfiles (including tests)
+	TestXGoFiles []string     `json:",omitempty"` // _test.xgo and _test.gop files
+	TestGoFiles  []string     `json:",omitempty"` // _test.go files
+
+	GenFiles []*GenFile `json:",omitempty"` // Go files generated from XGo sources
+}
+
+// ClassFile describes a classfile of a package.
+type ClassFile struct {
+	File     string   // file name
+	Proj     bool     // is a project file or not
+	Class    string   `json:",omitempty"` // base class, eg. "Game", "Sprite"
+	Ext      string   // classfile extension, eg. ".spx", "_cmd.gox"
+	PkgPaths []string `json:",omitempty"` // packages of the classfile framework
+}
+
+// GenFile describes a Go file generated from XGo sources.
+type GenFile struct {
+	File    string   // file name, eg. xgo_autogen.go
+	Sources []string // XGo sources of the file
+}
+
+// List lists packages matched by patterns. A pattern is a directory, or a
+// package path in the module, and it matches packages recursively if it ends
+// with "/...".
+func List(mod *xgomod.Module, patterns ...string) (pkgs []*Package, err error) {
+	if len(patterns) == 0 {
+		patterns = []string{"."}
+	}
+	for _, pattern := range patterns {
+		dir, recursively := pattern, false
+		if strings.HasSuffix(pattern, "/...") {
+			dir, recursively = pattern[:len(pattern)-4], true
+		} else if pattern == "..." {
+			dir, recursively = ".", true
+		}
+		if !isDirPattern(dir) {
+			pkg, e := mod.Lookup(dir)
+			if e != nil {
+				return nil, errors.NewWith(e, `mod.Lookup(dir)`, -2, "(*xgomod.Module).Lookup", dir)
+			}
+			dir = pkg.Dir
+		}
+		if !recursively {
+			pkg, e := listDir(mod, dir)
+			if e != nil {
+				return nil, e
+			}
+			if pkg != nil {
+				pkgs = append(pkgs, pkg)
+			}
+			continue
+		}
+		err = filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+			if err != nil || !d.IsDir() {
+				return err
+			}
+			if path != dir {
+				if name := d.Name(); strings.HasPrefix(name, "_") || strings.HasPrefix(name, ".") || name == "testdata" || hasMod(path) {
+					return filepath.SkipDir
+				}
+			}
+			pkg, err := listDir(mod, path)
+			if pkg != nil {
+				pkgs = append(pkgs, pkg)
+			}
+			return err
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+	return
+}
+
+func isDirPattern(dir string) bool {
+	if dir == "." || dir == ".." || filepath.IsAbs(dir) {
+		return true
+	}
+	return strings.HasPrefix(dir, "./") || strings.HasPrefix(dir, "../") ||
+		strings.HasPrefix(dir, ".\\") || strings.HasPrefix(dir, "..\\")
+}
+
+// listDir describes the package in dir. It returns nil if there are no
+// source files in dir.
+func listDir(mod *xgomod.Module, dir string) (*Package, error) {
+	fset := token.NewFileSet()
+	pkgs, err := parser.ParseDirEx(fset, dir, parser.Config{
+		ClassKind: mod.ClassKind,
+		Mode:      parser.PackageClauseOnly,
+	})
+	if err != nil {
+		return nil, errors.NewWith(err, `parser.ParseDirEx(fset, dir, conf)`, -2, "parser.ParseDirEx", fset, dir)
+	}
+	if len(pkgs) == 0 {
+		return nil, nil
+	}
+	ret := &Package{Dir: dir, ImportPath: importPathOf(mod, dir)}
+	var gen, genTest, gen2Test []string
+	names := make([]string, 0, len(pkgs))
+	for name := range pkgs {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		pkg := pkgs[name]
+		extTest := strings.HasSuffix(name, "_test")
+		if !extTest {
+			ret.Name = name
+		}
+		for file := range pkg.GoFiles {
+			fname := filepath.Base(file)
+			if strings.HasSuffix(fname, "_test.go") {
+				ret.TestGoFiles = append(ret.TestGoFiles, fname)
+			} else {
+				ret.GoFiles = append(ret.GoFiles, fname)
+			}
+		}
+		for file, f := range pkg.Files {
+			fname := filepath.Base(file)
+			isTest := isTestFile(fname)
+			switch {
+			case extTest:
+				gen2Test = append(gen2Test, fname)
+			case isTest:
+				genTest = append(genTest, fname)
+			default:
+				gen = append(gen, fname)
+			}
+			if f.IsClass {
+				ret.ClassFiles = append(ret.ClassFiles, classFileOf(mod, fname, f.IsProj))
+			} else if isTest {
+				ret.TestXGoFiles = append(ret.TestXGoFiles, fname)
+			} else {
+				ret.XGoFiles = append(ret.XGoFiles, fname)
+			}
+		}
+	}
+	if ret.Name == "" { // only external tests
+		ret.Name = strings.TrimSuffix(names[0], "_test")
+	}
+	sort.Strings(ret.XGoFiles)
+	sort.Strings(ret.GoFiles)
+	sort.Strings(ret.TestXGoFiles)
+	sort.Strings(ret.TestGoFiles)
+	sort.Slice(ret.ClassFiles, func(i, j int) bool {
+		return ret.ClassFiles[i].File < ret.ClassFiles[j].File
+	})
+	for _, g := range []struct {
+		file    string
+		sources []string
+	}{{autoGenFile, gen}, {autoGenTestFile, genTest}, {autoGen2TestFile, gen2Test}} {
+		if g.sources != nil {
+			sort.Strings(g.sources)
+			ret.GenFiles = append(ret.GenFiles, &GenFile{File: g.file, Sources: g.sources})
+		}
+	}
+	return ret, nil
+}
+
+func isTestFile(fname string) bool {
+	if pos := strings.Index(fname, "."); pos > 0 {
+		return strings.HasSuffix(fname[:pos], "_test")
+	}
+	return false
+}
+
+func classFileOf(mod *xgomod.Module, fname string, isProj bool) *ClassFile {
+	ext := modfile.ClassExt(fname)
+	ret := &ClassFile{File: fname, Proj: isProj, Ext: ext}
+	if proj, ok := mod.LookupClass(ext); ok {
+		ret.PkgPaths = proj.PkgPaths
+		if isProj {
+			ret.Class = proj.Class
+		} else {
+			for _, w := range proj.Works {
+				if w.Ext == ext {
+					ret.Class = w.Class
+					break
+				}
+			}
+		}
+	}
+	return ret
+}
+
+// importPathOf returns the import path of the package in dir.
+func importPathOf(mod *xgomod.Module, dir string) string {
+	if !mod.HasModfile() {
+		return ""
+	}
+	abs, err := filepath.Abs(dir)
+	if err != nil {
+		return ""
+	}
+	rel, err := filepath.Rel(mod.Root(), abs)
+	if err != nil || strings.HasPrefix(rel, "..") {
+		return ""
+	}
+	if rel == "." {
+		return mod.Path()
+	}
+	return path.Join(mod.Path(), filepath.ToSlash(rel))
+}
+
+// -----------------------------------------------------------------------------
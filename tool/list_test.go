@@ -0,0 +1,83 @@
+/*
+ * Copyright (c) 2026 The XGo Authors (xgo.dev). All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package tool
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestList(t *testing.T) {
+	dir := t.TempDir()
+	files := map[string]string{
+		"foo/foo.xgo":         "echo 1\n",
+		"foo/bar.go":          "package main\n",
+		"foo/foo_test.xgo":    "package main\n",
+		"foo/foo_test.go":     "package main\n",
+		"foo/case_test.gox":   "run \"x\", t => {}\n",
+		"foo/xgo_autogen.go":  "package main\n",
+		"foo/sub/ext_test.go": "package sub_test\n",
+		"foo/_skip/a.xgo":     "echo 1\n",
+		"foo/empty/README":    "",
+		"foo/x.gsh":           "ls\n",
+	}
+	for name, src := range files {
+		file := filepath.Join(dir, name)
+		os.MkdirAll(filepath.Dir(file), 0755)
+		if err := os.WriteFile(file, []byte(src), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	mod, err := LoadMod(dir)
+	if err != nil {
+		t.Fatal("LoadMod:", err)
+	}
+	pkgs, err := List(mod, filepath.Join(dir, "foo")+"/...")
+	if err != nil {
+		t.Fatal("List:", err)
+	}
+	if len(pkgs) != 2 {
+		t.Fatal("List: unexpected packages", len(pkgs))
+	}
+	foo := pkgs[0]
+	if foo.Name != "main" || foo.ImportPath != "" ||
+		!reflect.DeepEqual(foo.XGoFiles, []string{"foo.xgo"}) ||
+		!reflect.DeepEqual(foo.GoFiles, []string{"bar.go"}) ||
+		!reflect.DeepEqual(foo.TestXGoFiles, []string{"foo_test.xgo"}) ||
+		!reflect.DeepEqual(foo.TestGoFiles, []string{"foo_test.go"}) {
+		t.Fatalf("List: unexpected package %+v", foo)
+	}
+	if len(foo.ClassFiles) != 2 {
+		t.Fatal("List: unexpected classfiles", foo.ClassFiles)
+	}
+	if c := foo.ClassFiles[0]; c.File != "case_test.gox" || c.Proj || c.Class != "Case" || c.Ext != "_test.gox" {
+		t.Fatalf("List: unexpected classfile %+v", c)
+	}
+	if c := foo.ClassFiles[1]; c.File != "x.gsh" || !c.Proj || c.Class != "App" || c.PkgPaths[0] != "github.com/qiniu/x/gsh" {
+		t.Fatalf("List: unexpected classfile %+v", c)
+	}
+	if len(foo.GenFiles) != 2 ||
+		foo.GenFiles[0].File != "xgo_autogen.go" || !reflect.DeepEqual(foo.GenFiles[0].Sources, []string{"foo.xgo", "x.gsh"}) ||
+		foo.GenFiles[1].File != "xgo_autogen_test.go" || !reflect.DeepEqual(foo.GenFiles[1].Sources, []string{"case_test.gox", "foo_test.xgo"}) {
+		t.Fatalf("List: unexpected generated files %+v %+v", foo.GenFiles[0], foo.GenFiles[1])
+	}
+	if sub := pkgs[1]; sub.Name != "sub" || sub.GenFiles != nil || !reflect.DeepEqual(sub.TestGoFiles, []string{"ext_test.go"}) {
+		t.Fatalf("List: unexpected package %+v", sub)
+	}
+}
@@ -29,6 +29,7 @@ import (
 	"github.com/goplus/xgo/ast"
 	"github.com/goplus/xgo/cl"
 	"github.com/goplus/xgo/parser"
+	"github.com/goplus/xgo/parser/fsx"
 	"github.com/goplus/xgo/token"
 	"github.com/goplus/xgo/x/gocmd"
 	"github.com/goplus/xgo/x/xgoenv"
@@ -130,10 +131,21 @@ type Config struct {
 	// CacheFile specifies the file path of the cache.
 	CacheFile string
 
+	// If not nil, it is used for caching parsed packages (see LoadDir).
+	ParseCache *ParseCache
+
 	IgnoreNotatedError bool
 	DontUpdateGoMod    bool
 }
 
+// overlay returns the Overlay used by conf.Importer (see Importer.SetOverlay).
+func (conf *Config) overlay() *Overlay {
+	if imp := conf.Importer; imp != nil {
+		return imp.overlay
+	}
+	return nil
+}
+
 // ConfFlags represents configuration flags.
 type ConfFlags int
 
@@ -164,6 +176,7 @@ func NewDefaultConf(dir string, flags ConfFlags, tags ...string) (conf *Config,
 	if flags&ConfFlagNoCacheFile == 0 {
 		conf.CacheFile = imp.CacheFile()
 		imp.Cache().Load(conf.CacheFile)
+		imp.hashes.load(fileHashesFile(conf.CacheFile))
 	}
 	if flags&ConfFlagNoTestFiles != 0 {
 		conf.Filter = FilterNoTestFiles
@@ -187,6 +200,7 @@ func (conf *Config) UpdateCache(verbose ...bool) {
 	if conf.CacheFile != "" {
 		c := conf.Importer.Cache()
 		c.Save(conf.CacheFile)
+		conf.Importer.hashes.save(fileHashesFile(conf.CacheFile))
 		if verbose != nil && verbose[0] {
 			fmt.Println("Times of calling go list:", c.ListTimes())
 		}
@@ -249,11 +263,19 @@ func LoadDir(dir string, conf *Config, genTestPkg bool, promptGenGo ...bool) (ou
 	if fset == nil {
 		fset = token.NewFileSet()
 	}
-	pkgs, err := parser.ParseDirEx(fset, dir, parser.Config{
+	parseConf := parser.Config{
 		ClassKind: mod.ClassKind,
 		Filter:    conf.Filter,
 		Mode:      parser.ParseComments | parser.SaveAbsFile,
-	})
+	}
+	var pkgs map[string]*ast.Package
+	if ov := conf.overlay(); ov != nil {
+		pkgs, err = parser.ParseFSDir(fset, ov, dir, parseConf)
+	} else if pc := conf.ParseCache; pc != nil {
+		pkgs, err = pc.parseDir(fset, dir, parseConf)
+	} else {
+		pkgs, err = parser.ParseDirEx(fset, dir, parseConf)
+	}
 	if err != nil {
 		return
 	}
@@ -309,7 +331,9 @@ func LoadDir(dir string, conf *Config, genTestPkg bool, promptGenGo ...bool) (ou
 	if genTestPkg && pkgTest != nil {
 		test, err = cl.NewPackage("", pkgTest, clConf)
 	}
+	imp.lock() // afterLoad may update the module
 	afterLoad(mod, xgo, out, test, conf)
+	imp.unlock()
 	return
 }
 
@@ -318,6 +342,12 @@ func afterLoad(mod *xgomod.Module, xgo *env.XGo, out, test *gogen.Package, conf
 		return
 	}
 	updateMod := !conf.DontUpdateGoMod && mod.HasModfile()
+	if imp := conf.Importer; imp != nil && imp.vendor != "" { // go.mod is kept as vendor/modules.txt records
+		updateMod = false
+	}
+	if conf.overlay() != nil { // go.mod is never mutated in overlay mode
+		updateMod = false
+	}
 	if updateMod || conf.XGoDeps != nil {
 		flags := checkGopDeps(out)
 		if conf.XGoDeps != nil { // for `xgo run`
@@ -369,7 +399,11 @@ func LoadFiles(dir string, files []string, conf *Config) (out *gogen.Package, er
 	if fset == nil {
 		fset = token.NewFileSet()
 	}
-	pkgs, err := parser.ParseEntries(fset, files, parser.Config{
+	var fsys parser.FileSystem = fsx.Local
+	if ov := conf.overlay(); ov != nil {
+		fsys = ov
+	}
+	pkgs, err := parser.ParseFSEntries(fset, fsys, files, parser.Config{
 		ClassKind: mod.ClassKind,
 		Filter:    conf.Filter,
 		Mode:      parser.ParseComments | parser.SaveAbsFile,
@@ -0,0 +1,233 @@
+/*
+ * Copyright (c) 2026 The XGo Authors (xgo.dev). All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package tool
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"sync"
+
+	"github.com/goplus/gogen"
+	"github.com/goplus/mod/modcache"
+)
+
+// ErrOverlayModCache is returned when writing a file beneath GOMODCACHE into
+// an Overlay, which the go command doesn't allow.
+var ErrOverlayModCache = errors.New("overlay: files beneath GOMODCACHE must not be replaced")
+
+// -----------------------------------------------------------------------------
+
+// Overlay is a virtual file system layered over the disk. Sources can be
+// written into it, and files synthesized while loading XGo packages (eg.
+// xgo_autogen.go) are written into it instead of the disk. So nothing in
+// module directories or the module cache is mutated, which makes it safe for
+// services like playgrounds compiling code of many users.
+//
+// The go command sees files of an Overlay by its -overlay flag. See
+// Importer.SetOverlay.
+type Overlay struct {
+	mu    sync.Mutex
+	dir   string            // holds backing files and the -overlay config file
+	files map[string]string // path => backing file
+}
+
+// NewOverlay creates a new empty Overlay. Close it to remove its backing
+// files when it isn't used.
+func NewOverlay() (*Overlay, error) {
+	dir, err := os.MkdirTemp("", "xgo-overlay")
+	if err != nil {
+		return nil, err
+	}
+	p := &Overlay{dir: dir, files: make(map[string]string)}
+	if err = p.flush(); err != nil {
+		os.RemoveAll(dir)
+		return nil, err
+	}
+	return p, nil
+}
+
+// Close removes backing files of the Overlay.
+func (p *Overlay) Close() error {
+	return os.RemoveAll(p.dir)
+}
+
+// File returns the config file for the -overlay flag of the go command.
+func (p *Overlay) File() string {
+	return filepath.Join(p.dir, "overlay.json")
+}
+
+// WriteFile writes a file into the Overlay.
+func (p *Overlay) WriteFile(name string, data []byte) error {
+	name, err := filepath.Abs(name)
+	if err != nil {
+		return err
+	}
+	if modcache.InPath(name) {
+		return ErrOverlayModCache
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	backing, ok := p.files[name]
+	if !ok {
+		backing = filepath.Join(p.dir, strconv.Itoa(len(p.files))+filepath.Ext(name))
+	}
+	if err = os.WriteFile(backing, data, 0644); err != nil {
+		return err
+	}
+	if !ok {
+		p.files[name] = backing
+		return p.flush()
+	}
+	return nil
+}
+
+func (p *Overlay) writeGoFile(file string, pkg *gogen.Package, fname ...string) error {
+	var buf bytes.Buffer
+	buf.WriteString(gogen.GeneratedHeader)
+	if err := pkg.WriteTo(&buf, fname...); err != nil {
+		return err
+	}
+	return p.WriteFile(file, buf.Bytes())
+}
+
+// flush writes the -overlay config file. It is called with p.mu held.
+func (p *Overlay) flush() error {
+	data, err := json.Marshal(struct{ Replace map[string]string }{p.files})
+	if err != nil {
+		return err
+	}
+	tmp := p.File() + ".tmp"
+	if err = os.WriteFile(tmp, data, 0644); err != nil {
+		return err
+	}
+	return os.Rename(tmp, p.File())
+}
+
+func (p *Overlay) backing(name string) (string, bool) {
+	if name, err := filepath.Abs(name); err == nil {
+		p.mu.Lock()
+		defer p.mu.Unlock()
+		backing, ok := p.files[name]
+		return backing, ok
+	}
+	return "", false
+}
+
+// exists reports whether the file exists in the Overlay or on the disk.
+func (p *Overlay) exists(name string) bool {
+	if _, ok := p.backing(name); ok {
+		return true
+	}
+	_, err := os.Lstat(name)
+	return err == nil
+}
+
+// ReadFile reads a file from the Overlay, or from the disk if it isn't in
+// the Overlay.
+func (p *Overlay) ReadFile(name string) ([]byte, error) {
+	if backing, ok := p.backing(name); ok {
+		return os.ReadFile(backing)
+	}
+	return os.ReadFile(name)
+}
+
+// ReadDir reads a directory merging its files in the Overlay and on the disk.
+func (p *Overlay) ReadDir(dirname string) ([]fs.DirEntry, error) {
+	dir, err := filepath.Abs(dirname)
+	if err != nil {
+		return nil, err
+	}
+	entries := make(map[string]fs.DirEntry)
+	list, err := os.ReadDir(dir)
+	for _, e := range list {
+		entries[e.Name()] = e
+	}
+	p.mu.Lock()
+	for name, backing := range p.files {
+		if filepath.Dir(name) == dir {
+			if fi, e := os.Stat(backing); e == nil {
+				entries[filepath.Base(name)] = fs.FileInfoToDirEntry(overlayInfo{fi, filepath.Base(name)})
+			}
+		}
+	}
+	p.mu.Unlock()
+	if len(entries) == 0 && err != nil {
+		return nil, err
+	}
+	ret := make([]fs.DirEntry, 0, len(entries))
+	for _, e := range entries {
+		ret = append(ret, e)
+	}
+	sort.Slice(ret, func(i, j int) bool { return ret[i].Name() < ret[j].Name() })
+	return ret, nil
+}
+
+func (p *Overlay) Join(elem ...string) string {
+	return filepath.Join(elem...)
+}
+
+func (p *Overlay) Base(filename string) string {
+	return filepath.Base(filename)
+}
+
+func (p *Overlay) Abs(path string) (string, error) {
+	return filepath.Abs(path)
+}
+
+// dirHash returns a hash of files of dir in the Overlay, or "" if there is
+// no such file. It is used for the cache of imported packages.
+func (p *Overlay) dirHash(dir string) string {
+	var names []string
+	p.mu.Lock()
+	for name := range p.files {
+		if filepath.Dir(name) == dir {
+			names = append(names, name)
+		}
+	}
+	p.mu.Unlock()
+	if names == nil {
+		return ""
+	}
+	sort.Strings(names)
+	h := sha256.New()
+	for _, name := range names {
+		data, _ := p.ReadFile(name)
+		sum := sha256.Sum256(data)
+		h.Write([]byte(filepath.Base(name)))
+		h.Write(sum[:])
+	}
+	return "+" + base64.RawURLEncoding.EncodeToString(h.Sum(nil))
+}
+
+type overlayInfo struct {
+	fs.FileInfo
+	name string
+}
+
+func (p overlayInfo) Name() string {
+	return p.name
+}
+
+// -----------------------------------------------------------------------------
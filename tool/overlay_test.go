@@ -0,0 +1,115 @@
+/*
+ * Copyright (c) 2026 The XGo Authors (xgo.dev). All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package tool
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/goplus/mod/env"
+	"github.com/goplus/mod/modcache"
+	"github.com/goplus/mod/xgomod"
+	"github.com/goplus/xgo/token"
+)
+
+func TestOverlay(t *testing.T) {
+	ov, err := NewOverlay()
+	if err != nil {
+		t.Fatal("NewOverlay:", err)
+	}
+	defer ov.Close()
+
+	dir := t.TempDir()
+	os.WriteFile(filepath.Join(dir, "a.xgo"), []byte("echo 1\n"), 0644)
+	os.WriteFile(filepath.Join(dir, "b.xgo"), []byte("echo 2\n"), 0644)
+	if err = ov.WriteFile(filepath.Join(dir, "b.xgo"), []byte("echo 22\n")); err != nil {
+		t.Fatal("WriteFile:", err)
+	}
+	ov.WriteFile(filepath.Join(dir, "c.xgo"), []byte("echo 3\n"))
+	if data, err := ov.ReadFile(filepath.Join(dir, "b.xgo")); err != nil || string(data) != "echo 22\n" {
+		t.Fatal("ReadFile:", string(data), err)
+	}
+	if data, err := ov.ReadFile(filepath.Join(dir, "a.xgo")); err != nil || string(data) != "echo 1\n" {
+		t.Fatal("ReadFile from disk:", string(data), err)
+	}
+	if data, _ := os.ReadFile(filepath.Join(dir, "b.xgo")); string(data) != "echo 2\n" {
+		t.Fatal("disk file is changed:", string(data))
+	}
+	if _, err = os.Lstat(filepath.Join(dir, "c.xgo")); err == nil {
+		t.Fatal("file is written to disk")
+	}
+
+	entries, err := ov.ReadDir(dir)
+	if err != nil || len(entries) != 3 {
+		t.Fatal("ReadDir:", entries, err)
+	}
+	for i, name := range []string{"a.xgo", "b.xgo", "c.xgo"} {
+		if entries[i].Name() != name {
+			t.Fatal("ReadDir:", i, entries[i].Name())
+		}
+	}
+	if entries, err = ov.ReadDir(filepath.Join(dir, "virtual")); err == nil || entries != nil {
+		t.Fatal("ReadDir of a nonexistent dir:", entries, err)
+	}
+
+	var conf struct{ Replace map[string]string }
+	data, err := os.ReadFile(ov.File())
+	if err != nil || json.Unmarshal(data, &conf) != nil || len(conf.Replace) != 2 {
+		t.Fatal("overlay config:", string(data), err)
+	}
+
+	hash := ov.dirHash(dir)
+	if hash == "" || ov.dirHash(t.TempDir()) != "" {
+		t.Fatal("dirHash:", hash)
+	}
+	ov.WriteFile(filepath.Join(dir, "c.xgo"), []byte("echo 33\n"))
+	if ov.dirHash(dir) == hash {
+		t.Fatal("dirHash: not changed")
+	}
+
+	if modcache.GOMODCACHE != "" {
+		if err = ov.WriteFile(filepath.Join(modcache.GOMODCACHE, "foo@v1.0.0", "a.go"), nil); err != ErrOverlayModCache {
+			t.Fatal("WriteFile beneath GOMODCACHE:", err)
+		}
+	}
+}
+
+func TestSetOverlay(t *testing.T) {
+	t.Setenv("GOFLAGS", "")
+	ov, err := NewOverlay()
+	if err != nil {
+		t.Fatal("NewOverlay:", err)
+	}
+	defer ov.Close()
+
+	imp := NewImporter(xgomod.Default, &env.XGo{Version: "v1.0.0"}, token.NewFileSet())
+	imp.SetOverlay(ov)
+	imp.SetOverlay(ov)
+	if v := os.Getenv("GOFLAGS"); v != "" {
+		t.Fatal("GOFLAGS:", v)
+	}
+	if flags := imp.GoFlags(); !reflect.DeepEqual(flags, []string{"-overlay=" + ov.File()}) {
+		t.Fatal("GoFlags:", flags)
+	}
+	conf := &Config{Importer: imp}
+	if ret := conf.goCmdConf(nil); !reflect.DeepEqual(ret.Flags, []string{"-overlay=" + ov.File()}) {
+		t.Fatal("goCmdConf:", ret.Flags)
+	}
+}
@@ -0,0 +1,109 @@
+/*
+ * Copyright (c) 2026 The XGo Authors (xgo.dev). All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package tool
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/goplus/xgo/ast"
+	"github.com/goplus/xgo/parser"
+	"github.com/goplus/xgo/token"
+)
+
+// -----------------------------------------------------------------------------
+
+// ParseCache caches parsed packages of directories, so that a long-running
+// process (eg. `xgo serve`) needn't parse unchanged source files again. A
+// directory is parsed again when any of its source files is added, removed or
+// modified.
+//
+// A ParseCache must not be shared by Configs with different Fset or Filter.
+type ParseCache struct {
+	mu   sync.Mutex
+	dirs map[string]*parsedDir
+}
+
+type parsedDir struct {
+	stamp string
+	pkgs  map[string]*ast.Package
+}
+
+// NewParseCache creates a new ParseCache.
+func NewParseCache() *ParseCache {
+	return &ParseCache{dirs: make(map[string]*parsedDir)}
+}
+
+// Forget removes the parsed packages of dir from the cache.
+func (p *ParseCache) Forget(dir string) {
+	if dir, err := filepath.Abs(dir); err == nil {
+		p.mu.Lock()
+		delete(p.dirs, dir)
+		p.mu.Unlock()
+	}
+}
+
+func (p *ParseCache) parseDir(fset *token.FileSet, dir string, conf parser.Config) (map[string]*ast.Package, error) {
+	absDir, err := filepath.Abs(dir)
+	if err != nil {
+		return nil, err
+	}
+	stamp, err := dirStamp(absDir)
+	if err != nil {
+		return nil, err
+	}
+	p.mu.Lock()
+	cached, ok := p.dirs[absDir]
+	p.mu.Unlock()
+	if ok && cached.stamp == stamp {
+		return cached.pkgs, nil
+	}
+	pkgs, err := parser.ParseDirEx(fset, absDir, conf)
+	if err == nil {
+		p.mu.Lock()
+		p.dirs[absDir] = &parsedDir{stamp: stamp, pkgs: pkgs}
+		p.mu.Unlock()
+	}
+	return pkgs, err
+}
+
+// dirStamp returns a string that changes if any file (except autogen files)
+// of dir is added, removed or modified.
+func dirStamp(dir string) (string, error) {
+	list, err := os.ReadDir(dir)
+	if err != nil {
+		return "", err
+	}
+	var b strings.Builder
+	for _, d := range list {
+		fname := d.Name()
+		if d.IsDir() || strings.HasPrefix(fname, "xgo_autogen") || strings.HasPrefix(fname, "gop_autogen") {
+			continue
+		}
+		fi, err := d.Info()
+		if err != nil {
+			return "", err
+		}
+		fmt.Fprintf(&b, "%s:%d:%d\n", fname, fi.Size(), fi.ModTime().UnixNano())
+	}
+	return b.String(), nil
+}
+
+// -----------------------------------------------------------------------------
@@ -0,0 +1,58 @@
+/*
+ * Copyright (c) 2026 The XGo Authors (xgo.dev). All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package tool
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/goplus/xgo/parser"
+	"github.com/goplus/xgo/token"
+)
+
+func TestParseCache(t *testing.T) {
+	dir := t.TempDir()
+	file := filepath.Join(dir, "a.xgo")
+	os.WriteFile(file, []byte("echo 1\n"), 0644)
+
+	fset := token.NewFileSet()
+	pc := NewParseCache()
+	conf := parser.Config{Mode: parser.ParseComments | parser.SaveAbsFile}
+	pkgs1, err := pc.parseDir(fset, dir, conf)
+	if err != nil || pkgs1["main"] == nil {
+		t.Fatal("parseDir:", pkgs1, err)
+	}
+
+	os.WriteFile(filepath.Join(dir, autoGenFile), []byte("package main\n"), 0644)
+	pkgs2, err := pc.parseDir(fset, dir, conf)
+	if err != nil || pkgs2["main"] != pkgs1["main"] {
+		t.Fatal("parseDir: autogen file should be ignored", err)
+	}
+
+	os.WriteFile(file, []byte("echo 12\n"), 0644)
+	pkgs3, err := pc.parseDir(fset, dir, conf)
+	if err != nil || pkgs3["main"] == pkgs1["main"] {
+		t.Fatal("parseDir: changed file should be parsed again", err)
+	}
+
+	pc.Forget(dir)
+	pkgs4, err := pc.parseDir(fset, dir, conf)
+	if err != nil || pkgs4["main"] == pkgs3["main"] {
+		t.Fatal("parseDir: forgotten dir should be parsed again", err)
+	}
+}
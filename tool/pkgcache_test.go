@@ -0,0 +1,83 @@
+/*
+ * Copyright (c) 2026 The XGo Authors (xgo.dev). All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package tool
+
+import (
+	"io"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestGolistArgs(t *testing.T) {
+	args := golistArgs([]string{"fmt"}, "dev", []string{"-mod=vendor"})
+	want := []string{"list", "-f={{.ImportPath}}\t{{.Export}}\t{{.Deps}}", "-tags=dev", "-mod=vendor", "-export", "fmt"}
+	if !reflect.DeepEqual(args, want) {
+		t.Fatal("golistArgs:", args)
+	}
+}
+
+func TestPkgCache(t *testing.T) {
+	dir := t.TempDir()
+	expfile := filepath.Join(dir, "foo.a")
+	os.WriteFile(expfile, []byte("export"), 0644)
+	hashes := map[string]string{"foo": "h1", "bar": "h2", "fmt": ""}
+	h := func(pkgPath string, self bool) string {
+		return hashes[pkgPath]
+	}
+	cacheFile := filepath.Join(dir, "cache")
+	os.WriteFile(cacheFile, []byte("foo\t"+expfile+"\th1\t1\n\tbar\th2\n"), 0644)
+
+	c := newPkgCache(h, func() []string { return nil })
+	if err := c.Load(cacheFile); err != nil {
+		t.Fatal("Load:", err)
+	}
+	f, err := c.Find(dir, "foo")
+	if err != nil {
+		t.Fatal("Find:", err)
+	}
+	b, _ := io.ReadAll(f)
+	f.Close()
+	if string(b) != "export" || c.ListTimes() != 0 {
+		t.Fatal("Find:", string(b), c.ListTimes())
+	}
+
+	c.nlist = 1 // make it dirty
+	os.Remove(cacheFile)
+	if err = c.Save(cacheFile); err != nil {
+		t.Fatal("Save:", err)
+	}
+	c2 := newPkgCache(h, nil)
+	if err = c2.Load(cacheFile); err != nil {
+		t.Fatal("Load:", err)
+	}
+	if v, ok := c2.cache.Load("foo"); !ok || !reflect.DeepEqual(v, &pkgCache{expfile, "h1", []depPkg{{"bar", "h2"}}}) {
+		t.Fatal("Save:", v)
+	}
+
+	os.WriteFile(cacheFile, []byte("foo\t"+expfile+"\th1\t2\n\tbar\th2\n"), 0644)
+	if err = c2.Load(cacheFile); err != errInvalidFormat {
+		t.Fatal("Load invalid:", err)
+	}
+	if _, err = parseExports("foo"); err != errInvalidFormat {
+		t.Fatal("parseExports:", err)
+	}
+	if ret, err := parseExports("foo\t/a.a\t[bar fmt]\n"); err != nil || !reflect.DeepEqual(ret, []exportPkg{{"foo", "/a.a", []string{"bar", "fmt"}}}) {
+		t.Fatal("parseExports:", ret, err)
+	}
+}
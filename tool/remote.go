@@ -0,0 +1,132 @@
+/*
+ * Copyright (c) 2026 The XGo Authors (xgo.dev). All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package tool
+
+import (
+	"bufio"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+)
+
+// -----------------------------------------------------------------------------
+
+// FetchScript downloads a remote script (http:// or https://) into the module
+// cache and returns the local file. Like versions of modules, a URL is treated
+// as immutable: once fetched, the cached copy is used. The checksum of the
+// script is recorded when it is fetched the first time, and verified each time
+// it is used.
+func FetchScript(scriptURL string) (file string, err error) {
+	return fetchScript(scriptCacheDir(), scriptURL, http.Get)
+}
+
+func fetchScript(cacheDir, scriptURL string, get func(string) (*http.Response, error)) (file string, err error) {
+	u, err := url.Parse(scriptURL)
+	if err != nil {
+		return
+	}
+	name := path.Base(u.Path)
+	if path.Ext(name) == "" {
+		name = "main.xgo"
+	}
+	h := sha256.Sum256([]byte(scriptURL))
+	dir := filepath.Join(cacheDir, "scripts", hex.EncodeToString(h[:12]))
+	file = filepath.Join(dir, name)
+	sumFile := filepath.Join(cacheDir, "scripts.sum")
+	sum, recorded := lookupSum(sumFile, scriptURL)
+	if data, e := os.ReadFile(file); e == nil && recorded {
+		if sum != scriptSum(data) {
+			return "", fmt.Errorf("%s: checksum mismatch\n\tcached:   %s\n\trecorded: %s", scriptURL, scriptSum(data), sum)
+		}
+		return
+	}
+
+	resp, err := get(scriptURL)
+	if err != nil {
+		return
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("%s: %s", scriptURL, resp.Status)
+	}
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return
+	}
+	if recorded {
+		if sum != scriptSum(data) {
+			return "", fmt.Errorf("%s: checksum mismatch\n\tdownloaded: %s\n\trecorded:   %s", scriptURL, scriptSum(data), sum)
+		}
+	}
+	if err = os.MkdirAll(dir, 0755); err != nil {
+		return
+	}
+	tmp := file + ".tmp"
+	if err = os.WriteFile(tmp, data, 0644); err != nil {
+		return
+	}
+	if err = os.Rename(tmp, file); err != nil {
+		return
+	}
+	if !recorded {
+		err = recordSum(sumFile, scriptURL, scriptSum(data))
+	}
+	return
+}
+
+func scriptSum(data []byte) string {
+	h := sha256.Sum256(data)
+	return "sha256:" + hex.EncodeToString(h[:])
+}
+
+// lookupSum looks up the recorded checksum of a script. Each line of the sum
+// file is in the form of "url checksum".
+func lookupSum(sumFile, scriptURL string) (sum string, ok bool) {
+	f, err := os.Open(sumFile)
+	if err != nil {
+		return
+	}
+	defer f.Close()
+	s := bufio.NewScanner(f)
+	for s.Scan() {
+		if u, v, found := strings.Cut(s.Text(), " "); found && u == scriptURL {
+			return v, true
+		}
+	}
+	return
+}
+
+func recordSum(sumFile, scriptURL, sum string) error {
+	f, err := os.OpenFile(sumFile, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0644)
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintf(f, "%s %s\n", scriptURL, sum)
+	if e := f.Close(); err == nil {
+		err = e
+	}
+	return err
+}
+
+// -----------------------------------------------------------------------------
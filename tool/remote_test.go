@@ -0,0 +1,74 @@
+/*
+ * Copyright (c) 2026 The XGo Authors (xgo.dev). All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package tool
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestFetchScript(t *testing.T) {
+	dir := t.TempDir()
+	content, fetched := "echo \"hi\"\n", 0
+	get := func(url string) (*http.Response, error) {
+		fetched++
+		switch url {
+		case "https://example.com/hi.xgo", "https://example.com/run":
+			return &http.Response{StatusCode: 200, Status: "200 OK", Body: io.NopCloser(strings.NewReader(content))}, nil
+		case "https://example.com/err.xgo":
+			return nil, errors.New("network is unreachable")
+		}
+		return &http.Response{StatusCode: 404, Status: "404 Not Found", Body: io.NopCloser(strings.NewReader(""))}, nil
+	}
+	file, err := fetchScript(dir, "https://example.com/hi.xgo", get)
+	if err != nil || filepath.Base(file) != "hi.xgo" {
+		t.Fatal("fetchScript:", file, err)
+	}
+	if b, _ := os.ReadFile(file); string(b) != content {
+		t.Fatal("fetchScript: unexpected content", string(b))
+	}
+	if file2, err := fetchScript(dir, "https://example.com/hi.xgo", get); err != nil || file2 != file || fetched != 1 {
+		t.Fatal("fetchScript: cache not used", file2, err, fetched)
+	}
+	if file, err := fetchScript(dir, "https://example.com/run", get); err != nil || filepath.Base(file) != "main.xgo" {
+		t.Fatal("fetchScript:", file, err)
+	}
+
+	// the cached copy is changed
+	os.WriteFile(file, []byte("echo \"bad\"\n"), 0644)
+	if _, err := fetchScript(dir, "https://example.com/hi.xgo", get); err == nil || !strings.Contains(err.Error(), "checksum mismatch") {
+		t.Fatal("fetchScript:", err)
+	}
+	// the remote one is changed
+	os.Remove(file)
+	content = "echo \"changed\"\n"
+	if _, err := fetchScript(dir, "https://example.com/hi.xgo", get); err == nil || !strings.Contains(err.Error(), "checksum mismatch") {
+		t.Fatal("fetchScript:", err)
+	}
+
+	if _, err := fetchScript(dir, "https://example.com/none.xgo", get); err == nil || !strings.Contains(err.Error(), "404") {
+		t.Fatal("fetchScript:", err)
+	}
+	if _, err := fetchScript(dir, "https://example.com/err.xgo", get); err == nil {
+		t.Fatal("fetchScript: no error")
+	}
+}
@@ -0,0 +1,71 @@
+/*
+ * Copyright (c) 2026 The XGo Authors (xgo.dev). All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package tool
+
+import (
+	"go/version"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/goplus/mod/xgomod"
+)
+
+// -----------------------------------------------------------------------------
+
+// vendorDir returns the vendor directory of the module if packages of depended
+// modules are loaded from it, or "" otherwise. It is decided like the go
+// command does: `-mod=vendor` (specified by modFlag or GOFLAGS) enables it,
+// other -mod flags disable it. By default it is enabled if vendor/modules.txt
+// exists and the module requires go 1.14 or later.
+func vendorDir(mod *xgomod.Module, modFlag string) string {
+	if !mod.HasModfile() {
+		return ""
+	}
+	if modFlag == "" {
+		modFlag = goflagsMod()
+	}
+	dir := filepath.Join(mod.Root(), "vendor")
+	switch modFlag {
+	case "vendor":
+		return dir
+	case "":
+	default:
+		return ""
+	}
+	if _, err := os.Stat(filepath.Join(dir, "modules.txt")); err != nil {
+		return ""
+	}
+	if f := mod.File; f == nil || f.Go == nil || version.Compare("go"+f.Go.Version, "go1.14") < 0 {
+		return ""
+	}
+	return dir
+}
+
+// goflagsMod returns value of the -mod flag in GOFLAGS.
+func goflagsMod() (mod string) {
+	for _, f := range strings.Fields(os.Getenv("GOFLAGS")) {
+		if v, ok := strings.CutPrefix(f, "-mod="); ok {
+			mod = v
+		} else if v, ok := strings.CutPrefix(f, "--mod="); ok {
+			mod = v
+		}
+	}
+	return
+}
+
+// -----------------------------------------------------------------------------
@@ -0,0 +1,97 @@
+/*
+ * Copyright (c) 2026 The XGo Authors (xgo.dev). All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package tool
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/goplus/mod/env"
+	"github.com/goplus/mod/xgomod"
+	"github.com/goplus/xgo/token"
+	"github.com/goplus/xgo/x/gocmd"
+)
+
+func TestVendorDir(t *testing.T) {
+	t.Setenv("GOFLAGS", "")
+	dir := t.TempDir()
+	vendor := filepath.Join(dir, "vendor")
+	load := func(goVer string) *xgomod.Module {
+		os.WriteFile(filepath.Join(dir, "go.mod"), []byte("module example.com/foo\n\ngo "+goVer+"\n"), 0644)
+		mod, err := xgomod.Load(dir)
+		if err != nil {
+			t.Fatal("xgomod.Load:", err)
+		}
+		return mod
+	}
+	mod := load("1.21")
+	if ret := vendorDir(mod, ""); ret != "" {
+		t.Fatal("vendorDir without vendor/modules.txt:", ret)
+	}
+	if ret := vendorDir(mod, "vendor"); ret != vendor {
+		t.Fatal("vendorDir -mod=vendor:", ret)
+	}
+
+	os.MkdirAll(vendor, 0755)
+	os.WriteFile(filepath.Join(vendor, "modules.txt"), nil, 0644)
+	if ret := vendorDir(mod, ""); ret != vendor {
+		t.Fatal("vendorDir:", ret)
+	}
+	if ret := vendorDir(mod, "mod"); ret != "" {
+		t.Fatal("vendorDir -mod=mod:", ret)
+	}
+	t.Setenv("GOFLAGS", "-v -mod=readonly")
+	if ret := vendorDir(mod, ""); ret != "" {
+		t.Fatal("vendorDir GOFLAGS=-mod=readonly:", ret)
+	}
+	t.Setenv("GOFLAGS", "")
+	if ret := vendorDir(load("1.13"), ""); ret != "" {
+		t.Fatal("vendorDir go 1.13:", ret)
+	}
+	if ret := vendorDir(xgomod.Default, "vendor"); ret != "" {
+		t.Fatal("vendorDir without go.mod:", ret)
+	}
+}
+
+func TestSetModFlag(t *testing.T) {
+	t.Setenv("GOFLAGS", "-v")
+	dir := t.TempDir()
+	os.WriteFile(filepath.Join(dir, "go.mod"), []byte("module example.com/foo\n\ngo 1.21\n"), 0644)
+	mod, err := xgomod.Load(dir)
+	if err != nil {
+		t.Fatal("xgomod.Load:", err)
+	}
+	imp := NewImporter(mod, &env.XGo{Version: "v1.0.0"}, token.NewFileSet())
+	imp.SetModFlag("vendor")
+	imp.SetModFlag("vendor")
+	if v := os.Getenv("GOFLAGS"); v != "-v" {
+		t.Fatal("GOFLAGS:", v)
+	}
+	if flags := imp.GoFlags(); !reflect.DeepEqual(flags, []string{"-mod=vendor"}) {
+		t.Fatal("GoFlags:", flags)
+	}
+	conf := &Config{Importer: imp}
+	build := &gocmd.BuildConfig{Flags: []string{"-o", "foo"}}
+	if ret := conf.goCmdConf(build); !reflect.DeepEqual(ret.Flags, []string{"-mod=vendor", "-o", "foo"}) || ret == build {
+		t.Fatal("goCmdConf:", ret.Flags)
+	}
+	if ret := (&Config{}).goCmdConf(build); ret != build {
+		t.Fatal("goCmdConf without Importer:", ret)
+	}
+}
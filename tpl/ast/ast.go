@@ -28,13 +28,13 @@ type Node interface {
 	End() token.Pos
 }
 
-// Decl: Rule
+// Decl: Rule, UseDecl, SkipDecl
 type Decl interface {
 	Node
 	declNode()
 }
 
-// Expr: Ident, BasicLit, Choice, Sequence, UnaryExpr, BinaryExpr
+// Expr: Ident, BasicLit, Choice, Sequence, UnaryExpr, BinaryExpr, Predicate
 type Expr interface {
 	Node
 	exprNode()
@@ -68,11 +68,41 @@ func (p *File) End() token.Pos {
 //
 //	IDENT '=' Expr
 //	IDENT '=' Expr => { ... }
+//	IDENT '=' Expr recover STRING, ... => { ... }
 type Rule struct {
 	Name    *Ident
 	TokPos  token.Pos // position of '='
 	Expr    Expr
-	RetProc Node // => { ... } (see xgo/ast.LambdaExpr2) or nil
+	Recover *Recover // recovery clause or nil
+	RetProc Node     // => { ... } (see xgo/ast.LambdaExpr2) or nil
+}
+
+// Predicates returns the semantic predicates of the rule, in the order they
+// appear. The i-th predicate (starting at 0) is named `rule/{i+1}`, like
+// `stmt/1`, to look up its condition function.
+func (p *Rule) Predicates() (ret []*Predicate) {
+	var walk func(e Expr)
+	walk = func(e Expr) {
+		switch e := e.(type) {
+		case *Choice:
+			for _, o := range e.Options {
+				walk(o)
+			}
+		case *Sequence:
+			for _, item := range e.Items {
+				walk(item)
+			}
+		case *UnaryExpr:
+			walk(e.X)
+		case *BinaryExpr:
+			walk(e.X)
+			walk(e.Y)
+		case *Predicate:
+			ret = append(ret, e)
+		}
+	}
+	walk(p.Expr)
+	return
 }
 
 // IsList reports whether the rule is a list rule.
@@ -99,11 +129,58 @@ func (p *Rule) End() token.Pos {
 	if p.RetProc != nil {
 		return p.RetProc.End()
 	}
+	if p.Recover != nil {
+		return p.Recover.End()
+	}
 	return p.Expr.End()
 }
 
 func (p *Rule) declNode() {}
 
+// Recover is a recovery clause of a rule, which lists its sync tokens. If the
+// rule fails after matching some tokens, the error is recorded, and tokens
+// are skipped until a sync token (inclusive), so that matching continues:
+//
+//	recover STRING, ...
+type Recover struct {
+	RecoverPos token.Pos   // position of "recover"
+	Syncs      []*BasicLit // sync tokens, like ";" or "end"
+}
+
+func (p *Recover) Pos() token.Pos { return p.RecoverPos }
+func (p *Recover) End() token.Pos { return p.Syncs[len(p.Syncs)-1].End() }
+
+// -----------------------------------------------------------------------------
+
+// UseDecl imports rules from compiled grammars:
+//
+//	use IDENT, ...
+type UseDecl struct {
+	Use   token.Pos // position of "use"
+	Names []*Ident  // names of the grammars
+}
+
+func (p *UseDecl) Pos() token.Pos { return p.Use }
+func (p *UseDecl) End() token.Pos { return p.Names[len(p.Names)-1].End() }
+
+func (p *UseDecl) declNode() {}
+
+// SkipDecl declares tokens skipped between the tokens matched (like
+// WHITESPACE or COMMENT), or rules inside which nothing is skipped:
+//
+//	skip IDENT, ...
+//	noskip IDENT, ...
+type SkipDecl struct {
+	Skip  token.Pos // position of "skip" or "noskip"
+	No    bool      // noskip
+	Names []*Ident  // names of the tokens (or rules if No)
+}
+
+func (p *SkipDecl) Pos() token.Pos { return p.Skip }
+func (p *SkipDecl) End() token.Pos { return p.Names[len(p.Names)-1].End() }
+
+func (p *SkipDecl) declNode() {}
+
 // -----------------------------------------------------------------------------
 
 // Ident: IDENT
@@ -179,3 +256,21 @@ func (p *BinaryExpr) End() token.Pos { return p.Y.End() }
 func (p *BinaryExpr) exprNode()      {}
 
 // -----------------------------------------------------------------------------
+
+// Predicate: &{ cond } or !{ cond }
+//
+// A semantic predicate matches nothing, and fails if cond is false (or true
+// for !{ cond }). cond is called with the results of the preceding items of
+// the enclosing sequence.
+type Predicate struct {
+	OpPos  token.Pos   // operator position
+	Op     token.Token // operator: token.AND or token.NOT
+	Cond   Node        // cond (see xgo/ast.Expr) or nil
+	Rbrace token.Pos   // position of '}'
+}
+
+func (p *Predicate) Pos() token.Pos { return p.OpPos }
+func (p *Predicate) End() token.Pos { return p.Rbrace + 1 }
+func (p *Predicate) exprNode()      {}
+
+// -----------------------------------------------------------------------------
@@ -0,0 +1,205 @@
+/*
+ * Copyright (c) 2026 The XGo Authors (xgo.dev). All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package ast
+
+import (
+	"encoding/json"
+	"strconv"
+	"strings"
+
+	"github.com/goplus/xgo/tpl/token"
+)
+
+// -----------------------------------------------------------------------------
+
+// EBNF renders the rules of the grammar in EBNF, in the form used by the Go
+// spec (see golang.org/x/exp/ebnf), one production per line:
+//
+//	expr = term { ( "+" | "-" ) term } .
+//
+// `*R`, `+R` and `?R` are rendered as `{ R }`, `R { R }` and `[ R ]`, `R1 % R2`
+// as `R1 { R2 R1 }`, `R1 ++ R2` as `R1 R2`, and char literals as strings.
+// Semantic predicates, RetProcs, use and skip declarations are not rendered.
+func (p *File) EBNF() string {
+	var b strings.Builder
+	for _, decl := range p.Decls {
+		if r, ok := decl.(*Rule); ok {
+			b.WriteString(r.Name.Name)
+			b.WriteString(" = ")
+			writeEBNF(&b, r.Expr, false)
+			b.WriteString(" .\n")
+		}
+	}
+	return b.String()
+}
+
+// writeEBNF writes x, which is enclosed in parentheses if it's a choice in a
+// sequence.
+func writeEBNF(b *strings.Builder, x Expr, inSeq bool) {
+	switch x := x.(type) {
+	case *Ident:
+		b.WriteString(x.Name)
+	case *BasicLit:
+		b.WriteString(literal(x))
+	case *Sequence:
+		n := b.Len()
+		for _, item := range x.Items {
+			if _, ok := item.(*Predicate); ok {
+				continue
+			}
+			if b.Len() > n {
+				b.WriteByte(' ')
+			}
+			writeEBNF(b, item, true)
+		}
+		if b.Len() == n {
+			b.WriteString(`""`)
+		}
+	case *Choice:
+		if inSeq {
+			b.WriteString("( ")
+		}
+		for i, option := range x.Options {
+			if i > 0 {
+				b.WriteString(" | ")
+			}
+			writeEBNF(b, option, false)
+		}
+		if inSeq {
+			b.WriteString(" )")
+		}
+	case *UnaryExpr:
+		switch x.Op {
+		case token.MUL:
+			b.WriteString("{ ")
+			writeEBNF(b, x.X, false)
+			b.WriteString(" }")
+		case token.QUESTION:
+			b.WriteString("[ ")
+			writeEBNF(b, x.X, false)
+			b.WriteString(" ]")
+		default: // token.ADD
+			writeEBNF(b, x.X, true)
+			b.WriteString(" { ")
+			writeEBNF(b, x.X, false)
+			b.WriteString(" }")
+		}
+	case *BinaryExpr:
+		writeEBNF(b, x.X, true)
+		if x.Op == token.REM {
+			b.WriteString(" { ")
+			writeEBNF(b, x.Y, true)
+			b.WriteByte(' ')
+			writeEBNF(b, x.X, true)
+			b.WriteString(" }")
+		} else { // token.INC
+			b.WriteByte(' ')
+			writeEBNF(b, x.Y, true)
+		}
+	case *Predicate:
+		b.WriteString(`""`)
+	}
+}
+
+// literal returns a char literal as a string literal, or a string literal as
+// is.
+func literal(x *BasicLit) string {
+	if x.Kind == token.CHAR {
+		if v, err := strconv.Unquote(x.Value); err == nil {
+			return strconv.Quote(v)
+		}
+	}
+	return x.Value
+}
+
+// -----------------------------------------------------------------------------
+
+// JSON renders the rules of the grammar in JSON, like:
+//
+//	{"rules": [{"name": "expr", "expr": {"type": "sequence", "items": [...]}}]}
+//
+// where an expression is an object of type:
+//   - ident: {"name": "term"}, a rule or a token like INT.
+//   - token: {"value": "+"}, a literal (unquoted).
+//   - sequence: {"items": [...]}.
+//   - choice: {"options": [...]}.
+//   - repeat0, repeat1, optional: {"x": ...}, for `*R`, `+R` and `?R`.
+//   - list: {"x": ..., "sep": ...}, for `R1 % R2`.
+//   - adjoin: {"x": ..., "y": ...}, for `R1 ++ R2`.
+//   - predicate: {"not": false}, for `&{ cond }` (or `!{ cond }`).
+//
+// Used grammars are listed in "uses", if any.
+func (p *File) JSON() ([]byte, error) {
+	rules := []any{}
+	var uses []string
+	for _, decl := range p.Decls {
+		switch decl := decl.(type) {
+		case *Rule:
+			rules = append(rules, map[string]any{"name": decl.Name.Name, "expr": jsonExpr(decl.Expr)})
+		case *UseDecl:
+			for _, name := range decl.Names {
+				uses = append(uses, name.Name)
+			}
+		}
+	}
+	ret := map[string]any{"rules": rules}
+	if uses != nil {
+		ret["uses"] = uses
+	}
+	return json.Marshal(ret)
+}
+
+func jsonExpr(x Expr) map[string]any {
+	switch x := x.(type) {
+	case *Ident:
+		return map[string]any{"type": "ident", "name": x.Name}
+	case *BasicLit:
+		v, _ := strconv.Unquote(literal(x))
+		return map[string]any{"type": "token", "value": v}
+	case *Sequence:
+		return map[string]any{"type": "sequence", "items": jsonExprs(x.Items)}
+	case *Choice:
+		return map[string]any{"type": "choice", "options": jsonExprs(x.Options)}
+	case *UnaryExpr:
+		typ := "optional"
+		switch x.Op {
+		case token.MUL:
+			typ = "repeat0"
+		case token.ADD:
+			typ = "repeat1"
+		}
+		return map[string]any{"type": typ, "x": jsonExpr(x.X)}
+	case *BinaryExpr:
+		if x.Op == token.REM {
+			return map[string]any{"type": "list", "x": jsonExpr(x.X), "sep": jsonExpr(x.Y)}
+		}
+		return map[string]any{"type": "adjoin", "x": jsonExpr(x.X), "y": jsonExpr(x.Y)}
+	case *Predicate:
+		return map[string]any{"type": "predicate", "not": x.Op == token.NOT}
+	}
+	return nil
+}
+
+func jsonExprs(xs []Expr) []any {
+	ret := make([]any, len(xs))
+	for i, x := range xs {
+		ret[i] = jsonExpr(x)
+	}
+	return ret
+}
+
+// -----------------------------------------------------------------------------
@@ -19,7 +19,9 @@ package cl
 import (
 	"fmt"
 	"os"
+	"slices"
 	"strconv"
+	"strings"
 
 	"github.com/goplus/xgo/tpl/ast"
 	"github.com/goplus/xgo/tpl/matcher"
@@ -36,6 +38,15 @@ var (
 type Result struct {
 	Doc   *matcher.Var
 	Rules map[string]*matcher.Var
+
+	// Choices maps matchers of choices to their sources, not including the
+	// ones of used grammars.
+	Choices map[*matcher.Choices]*ast.Choice
+
+	// Skips are tokens skipped between the tokens matched, declared by
+	// `skip` declarations of the grammar and the grammars it uses (see
+	// matcher.Context.Skips).
+	Skips []token.Token
 }
 
 type choice struct {
@@ -44,10 +55,14 @@ type choice struct {
 }
 
 type context struct {
-	rules   map[string]*matcher.Var
-	choices []choice
-	errs    errors.List
-	fset    *token.FileSet
+	rules    map[string]*matcher.Var
+	choices  []choice
+	repeats  []repeat
+	errs     errors.List
+	fset     *token.FileSet
+	retProcs map[string]any
+	preds    map[*ast.Predicate]string // names of predicates
+	skips    []token.Token
 }
 
 func (p *context) newErrorf(pos token.Pos, format string, args ...any) error {
@@ -69,8 +84,16 @@ func New(fset *token.FileSet, files ...*ast.File) (ret Result, err error) {
 
 // Config configures the behavior of the compiler.
 type Config struct {
+	// RetProcs maps names of rules to their RetProcs, `rule/N` to conditions
+	// of predicates (see ast.Rule.Predicates), and `use/NAME` to the grammars
+	// (Result or *Result) used by `use NAME`.
 	RetProcs   map[string]any
 	OnConflict func(fset *token.FileSet, c *ast.Choice, firsts [][]any, i, at int)
+
+	// OnWarning is called for each common grammar bug found, like shadowed
+	// alternatives, repetitions of expressions that can match empty, and
+	// unreachable rules. The default logs the warning to stderr.
+	OnWarning func(fset *token.FileSet, pos token.Pos, msg string)
 }
 
 // NewEx compiles a set of rules from the given files.
@@ -80,7 +103,8 @@ func NewEx(conf *Config, fset *token.FileSet, files ...*ast.File) (ret Result, e
 	}
 	retProcs := conf.RetProcs
 	rules := make(map[string]*matcher.Var)
-	ctx := &context{rules: rules, fset: fset}
+	preds := make(map[*ast.Predicate]string)
+	ctx := &context{rules: rules, fset: fset, retProcs: retProcs, preds: preds}
 	for _, f := range files {
 		for _, decl := range f.Decls {
 			switch decl := decl.(type) {
@@ -95,11 +119,13 @@ func NewEx(conf *Config, fset *token.FileSet, files ...*ast.File) (ret Result, e
 				}
 				v := matcher.NewVar(ident.Pos(), name)
 				rules[name] = v
+			case *ast.UseDecl, *ast.SkipDecl:
 			default:
 				ctx.addError(decl.Pos(), "unknown declaration")
 			}
 		}
 	}
+	used := useGrammars(ctx, files)
 	var doc *matcher.Var
 	for _, f := range files {
 		for _, decl := range f.Decls {
@@ -108,8 +134,14 @@ func NewEx(conf *Config, fset *token.FileSet, files ...*ast.File) (ret Result, e
 				ident := decl.Name
 				name := ident.Name
 				v := rules[name]
+				for i, pred := range decl.Predicates() {
+					preds[pred] = name + "/" + strconv.Itoa(i+1)
+				}
 				if r, ok := compileExpr(decl.Expr, ctx); ok {
 					v.RetProc = retProcs[name]
+					if decl.Recover != nil {
+						v.Syncs = compileSyncs(decl.Recover, ctx)
+					}
 					if e := v.Assign(r); e != nil {
 						ctx.addError(ident.Pos(), e.Error())
 					}
@@ -120,8 +152,14 @@ func NewEx(conf *Config, fset *token.FileSet, files ...*ast.File) (ret Result, e
 			}
 		}
 	}
+	compileSkips(ctx, files)
+	if doc == nil {
+		doc = used
+	}
 	if doc == nil {
-		err = ErrNoDocFound
+		if err = ctx.errs.ToError(); err == nil {
+			err = ErrNoDocFound
+		}
 		return
 	}
 	defer func() {
@@ -135,6 +173,11 @@ func NewEx(conf *Config, fset *token.FileSet, files ...*ast.File) (ret Result, e
 		}
 		err = ctx.errs.ToError()
 	}()
+	for _, v := range rules {
+		if v.Elem != nil {
+			v.First(nil) // marks left recursive rules
+		}
+	}
 	onConflict := conf.OnConflict
 	if onConflict == nil {
 		onConflict = onConflictDefault
@@ -144,7 +187,108 @@ func NewEx(conf *Config, fset *token.FileSet, files ...*ast.File) (ret Result, e
 			onConflict(fset, item.c, firsts, i, at)
 		})
 	}
-	ret = Result{doc, rules}
+	if len(ctx.errs) == 0 {
+		lint(ctx, conf, files, doc)
+	}
+	choices := make(map[*matcher.Choices]*ast.Choice, len(ctx.choices))
+	for _, item := range ctx.choices {
+		choices[item.m] = item.c
+	}
+	ret = Result{doc, rules, choices, ctx.skips}
+	return
+}
+
+// compileSyncs compiles the sync tokens of a recovery clause to elements of
+// first sets (see matcher.Var.Syncs).
+func compileSyncs(recov *ast.Recover, ctx *context) []any {
+	syncs := make([]any, 0, len(recov.Syncs))
+	for _, lit := range recov.Syncs {
+		if r, ok := compileExpr(lit, ctx); ok {
+			first, mayEmpty := r.First(nil)
+			if mayEmpty {
+				ctx.addError(lit.Pos(), "invalid sync token "+lit.Value)
+				continue
+			}
+			syncs = append(syncs, first...)
+		}
+	}
+	return syncs
+}
+
+// compileSkips compiles `skip` and `noskip` declarations.
+func compileSkips(ctx *context, files []*ast.File) {
+	for _, f := range files {
+		for _, decl := range f.Decls {
+			skip, ok := decl.(*ast.SkipDecl)
+			if !ok {
+				continue
+			}
+			for _, ident := range skip.Names {
+				name := ident.Name
+				if skip.No {
+					if v, ok := ctx.rules[name]; ok {
+						v.NoSkip = true
+					} else {
+						ctx.addErrorf(ident.Pos(), "rule `%s` is undefined", name)
+					}
+				} else if tok, ok := idents[name]; ok && tok != token.EOF {
+					ctx.addSkip(tok)
+				} else {
+					ctx.addErrorf(ident.Pos(), "`%s` is not a token", name)
+				}
+			}
+		}
+	}
+}
+
+func (p *context) addSkip(tok token.Token) {
+	if !slices.Contains(p.skips, tok) {
+		p.skips = append(p.skips, tok)
+	}
+}
+
+// useGrammars imports rules of the grammars used by `use` declarations, which
+// aren't defined in files. It returns the document rule of the first grammar.
+func useGrammars(ctx *context, files []*ast.File) (doc *matcher.Var) {
+	imported := make(map[string]string)
+	for _, f := range files {
+		for _, decl := range f.Decls {
+			use, ok := decl.(*ast.UseDecl)
+			if !ok {
+				continue
+			}
+			for _, ident := range use.Names {
+				var g *Result
+				switch v := ctx.retProcs["use/"+ident.Name].(type) {
+				case Result:
+					g = &v
+				case *Result:
+					g = v
+				default:
+					ctx.addErrorf(ident.Pos(), "grammar `%s` is undefined", ident.Name)
+					continue
+				}
+				if doc == nil {
+					doc = g.Doc
+				}
+				for _, tok := range g.Skips {
+					ctx.addSkip(tok)
+				}
+				for name, v := range g.Rules {
+					if from, ok := imported[name]; ok {
+						if ctx.rules[name] != v {
+							ctx.addErrorf(ident.Pos(), "rule `%s` is defined in both `%s` and `%s`", name, from, ident.Name)
+						}
+						continue
+					}
+					if _, ok := ctx.rules[name]; !ok {
+						ctx.rules[name] = v
+						imported[name] = ident.Name
+					}
+				}
+			}
+		}
+	}
 	return
 }
 
@@ -160,22 +304,25 @@ func LogConflict(pos token.Position, firsts [][]any, i, at int) {
 
 var (
 	idents = map[string]token.Token{
-		"EOF":     token.EOF,
-		"COMMENT": token.COMMENT,
-		"IDENT":   token.IDENT,
-		"INT":     token.INT,
-		"FLOAT":   token.FLOAT,
-		"IMAG":    token.IMAG,
-		"CHAR":    token.CHAR,
-		"STRING":  token.STRING,
-		"RAT":     token.RAT,
-		"UNIT":    token.UNIT,
-		"LPAREN":  token.LPAREN,
-		"RPAREN":  token.RPAREN,
-		"LBRACK":  token.LBRACK,
-		"RBRACK":  token.RBRACK,
-		"LBRACE":  token.LBRACE,
-		"RBRACE":  token.RBRACE,
+		"EOF":        token.EOF,
+		"COMMENT":    token.COMMENT,
+		"IDENT":      token.IDENT,
+		"INT":        token.INT,
+		"FLOAT":      token.FLOAT,
+		"IMAG":       token.IMAG,
+		"CHAR":       token.CHAR,
+		"STRING":     token.STRING,
+		"RAT":        token.RAT,
+		"UNIT":       token.UNIT,
+		"KEYWORD":    token.KEYWORD,
+		"OPERATOR":   token.OPERATOR,
+		"WHITESPACE": token.WHITESPACE,
+		"LPAREN":     token.LPAREN,
+		"RPAREN":     token.RPAREN,
+		"LBRACK":     token.LBRACK,
+		"RBRACK":     token.RBRACK,
+		"LBRACE":     token.LBRACE,
+		"RBRACE":     token.RBRACE,
 	}
 )
 
@@ -226,9 +373,12 @@ func compileExpr(expr ast.Expr, ctx *context) (matcher.Matcher, bool) {
 			if c := v[0]; c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c == '_' {
 				return matcher.Literal(token.IDENT, v), true
 			}
-			if t, ok := checkToken(v); ok {
+			if t, ok := checkToken(v); ok && (t.Len() > 0 || !isOperator(v)) {
 				return tokenExpr(t, expr, ctx)
 			}
+			if isOperator(v) { // see scanner.Syntax.Operators
+				return matcher.Literal(token.OPERATOR, v), true
+			}
 			fallthrough
 		default:
 			ctx.addError(expr.Pos(), "invalid literal "+lit)
@@ -261,8 +411,10 @@ func compileExpr(expr ast.Expr, ctx *context) (matcher.Matcher, bool) {
 			case token.QUESTION:
 				return matcher.Repeat01(x), true
 			case token.MUL:
+				ctx.repeats = append(ctx.repeats, repeat{x, expr})
 				return matcher.Repeat0(x), true
 			case token.ADD:
+				ctx.repeats = append(ctx.repeats, repeat{x, expr})
 				return matcher.Repeat1(x), true
 			default:
 				ctx.addErrorf(expr.Pos(), "invalid token %v", expr.Op)
@@ -281,6 +433,12 @@ func compileExpr(expr ast.Expr, ctx *context) (matcher.Matcher, bool) {
 				ctx.addErrorf(expr.Pos(), "invalid token %v", expr.Op)
 			}
 		}
+	case *ast.Predicate:
+		name := ctx.preds[expr]
+		if cond, ok := ctx.retProcs[name].(matcher.Cond); ok {
+			return matcher.Predicate(cond, expr.Op == token.NOT), true
+		}
+		ctx.addErrorf(expr.Pos(), "no condition of predicate `%s`", name)
 	default:
 		ctx.addError(expr.Pos(), "unknown expression")
 	}
@@ -295,6 +453,15 @@ func tokenExpr(tok token.Token, expr *ast.BasicLit, ctx *context) (matcher.Match
 	return nil, false
 }
 
+func isOperator(v string) bool {
+	for i := 0; i < len(v); i++ {
+		if !strings.ContainsRune("!#$%&*+-./:<=>?@^|~", rune(v[i])) {
+			return false
+		}
+	}
+	return true
+}
+
 func checkToken(v string) (ret token.Token, ok bool) {
 	if len(v) == 1 {
 		return token.Token(v[0]), true
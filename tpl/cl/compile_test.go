@@ -0,0 +1,225 @@
+/*
+ * Copyright (c) 2026 The XGo Authors (xgo.dev). All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cl_test
+
+import (
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/goplus/xgo/tpl"
+	"github.com/goplus/xgo/tpl/ast"
+	"github.com/goplus/xgo/tpl/cl"
+	"github.com/goplus/xgo/tpl/parser"
+	"github.com/goplus/xgo/tpl/token"
+)
+
+func compile(t *testing.T, src string) (cl.Result, error) {
+	t.Helper()
+	fset := token.NewFileSet()
+	f, err := parser.ParseFile(fset, "a.tpl", src, nil)
+	if err != nil {
+		t.Fatal("ParseFile:", err)
+	}
+	return cl.New(fset, f)
+}
+
+func TestNew(t *testing.T) {
+	ret, err := compile(t, `
+doc = item % ","
+item = INT | IDENT
+`)
+	if err != nil {
+		t.Fatal("New:", err)
+	}
+	if ret.Doc != ret.Rules["doc"] || len(ret.Rules) != 2 {
+		t.Fatal("New:", ret)
+	}
+
+	const errs = `
+doc = item item2
+item = INT
+item = FLOAT
+`
+	if _, err = compile(t, errs); err == nil {
+		t.Fatal("New: no error")
+	} else if msg := err.Error(); !strings.Contains(msg, "duplicate rule `item`") || !strings.Contains(msg, "`item2` is undefined") {
+		t.Fatal("New:", msg)
+	}
+	if _, err = compile(t, ""); err != cl.ErrNoDocFound {
+		t.Fatal("New:", err)
+	}
+}
+
+func TestRetProc(t *testing.T) {
+	c, err := tpl.New(`expr = INT % "+"`, "expr", func(self []any) any {
+		sum := 0
+		for _, v := range tpl.List(self) {
+			if t, ok := v.(*tpl.Token); ok && t.Tok == token.INT {
+				sum += len(t.Lit)
+			}
+		}
+		return sum
+	})
+	if err != nil {
+		t.Fatal("tpl.New:", err)
+	}
+	ret, err := c.ParseExpr("1 + 22 + 333", nil)
+	if err != nil || ret != 6 {
+		t.Fatal("ParseExpr:", ret, err)
+	}
+}
+
+func TestLeftRecursion(t *testing.T) {
+	eval := func(op *tpl.Token, x, y any) any {
+		switch op.Tok {
+		case token.ADD:
+			return x.(int) + y.(int)
+		case token.SUB:
+			return x.(int) - y.(int)
+		case token.MUL:
+			return x.(int) * y.(int)
+		default:
+			return x.(int) / y.(int)
+		}
+	}
+	binary := func(self []any) any {
+		return eval(self[1].(*tpl.Token), self[0], self[2])
+	}
+	c, err := tpl.New(`
+expr = expr ("+" | "-") term | term
+term = term ("*" | "/") factor | factor
+factor = INT | "(" expr ")"
+`, "expr", func(self any) any {
+		if v, ok := self.([]any); ok {
+			return binary(v)
+		}
+		return self
+	}, "term", func(self any) any {
+		if v, ok := self.([]any); ok {
+			return binary(v)
+		}
+		return self
+	}, "factor", func(self any) any {
+		if v, ok := self.([]any); ok {
+			return v[1]
+		}
+		n, _ := strconv.Atoi(self.(*tpl.Token).Lit)
+		return n
+	})
+	if err != nil {
+		t.Fatal("tpl.New:", err)
+	}
+	for src, want := range map[string]int{"7": 7, "10-2-3": 5, "2*3+4*5": 26, "100/10/5": 2, "2*(3+4)-1": 13} {
+		if ret, err := c.ParseExpr(src, nil); err != nil || ret != want {
+			t.Fatal("ParseExpr:", src, ret, err)
+		}
+	}
+	if _, err = c.ParseExpr("1+", nil); err == nil {
+		t.Fatal("ParseExpr: no error")
+	}
+
+	// indirect left recursion
+	c, err = tpl.New(`
+a = b "x" | "y"
+b = a "z"
+`)
+	if err != nil {
+		t.Fatal("tpl.New:", err)
+	}
+	if _, err = c.ParseExpr("y z x z x", nil); err != nil {
+		t.Fatal("ParseExpr:", err)
+	}
+}
+
+func TestLint(t *testing.T) {
+	fset := token.NewFileSet()
+	f, err := parser.ParseFile(fset, "a.tpl", `
+doc = *stmt
+stmt = opt | INT ";" | FLOAT
+opt = ?INT | "" | IDENT
+expr = INT | INT "." INT | INT ("+" | "-") INT | &{ true } FLOAT | &{ false } FLOAT
+list = *(?INT) % ","
+`, nil)
+	if err != nil {
+		t.Fatal("ParseFile:", err)
+	}
+	var warns []string
+	conf := &cl.Config{
+		RetProcs: map[string]any{"expr/1": func([]any) bool { return true }, "expr/2": func([]any) bool { return true }},
+		OnWarning: func(fset *token.FileSet, pos token.Pos, msg string) {
+			warns = append(warns, fset.Position(pos).String()+": "+msg)
+		},
+		OnConflict: func(fset *token.FileSet, c *ast.Choice, firsts [][]any, i, at int) {},
+	}
+	if _, err = cl.NewEx(conf, fset, f); err != nil {
+		t.Fatal("NewEx:", err)
+	}
+	want := []string{
+		"a.tpl:3:14: alternative `INT \";\"` is unreachable, since `opt` before it always matches",
+		"a.tpl:3:24: alternative `FLOAT` is unreachable, since `opt` before it always matches",
+		"a.tpl:4:14: alternative `\"\"` is unreachable, since `?INT` before it always matches",
+		"a.tpl:4:19: alternative `IDENT` is unreachable, since `?INT` before it always matches",
+		"a.tpl:5:14: alternative `INT \".\" INT` is shadowed by `INT` before it",
+		"a.tpl:5:28: alternative `INT (\"+\" | \"-\") INT` is shadowed by `INT` before it",
+		"a.tpl:2:7: `*stmt` repeats an expression that can match empty",
+		"a.tpl:6:8: `*(?INT)` repeats an expression that can match empty",
+		"a.tpl:5:1: rule `expr` is unreachable",
+		"a.tpl:6:1: rule `list` is unreachable",
+	}
+	if got := strings.Join(warns, "\n"); got != strings.Join(want, "\n") {
+		t.Fatal("warnings:\n" + got)
+	}
+}
+
+func TestLeftRecursionConflict(t *testing.T) {
+	conflicts := func(src string) (ret []string) {
+		t.Helper()
+		fset := token.NewFileSet()
+		f, err := parser.ParseFile(fset, "a.tpl", src, nil)
+		if err != nil {
+			t.Fatal("ParseFile:", err)
+		}
+		conf := &cl.Config{
+			OnConflict: func(fset *token.FileSet, c *ast.Choice, firsts [][]any, i, at int) {
+				ret = append(ret, fset.Position(c.Options[i].Pos()).String())
+			},
+		}
+		if _, err = cl.NewEx(conf, fset, f); err != nil {
+			t.Fatal("NewEx:", err)
+		}
+		return
+	}
+	if ret := conflicts(`
+expr = expr ("+" | "-") term | term
+term = term ("*" | "/") factor | factor
+factor = INT | "(" expr ")"
+`); ret != nil {
+		t.Fatal("conflicts of left recursive rules:", ret)
+	}
+	if ret := conflicts(`
+a = b "x" | "y"
+b = a "z"
+`); ret != nil {
+		t.Fatal("conflicts of indirect left recursive rules:", ret)
+	}
+	if ret := conflicts(`
+expr = expr "+" INT | INT "-" INT | INT
+`); len(ret) != 1 || ret[0] != "a.tpl:2:23" {
+		t.Fatal("conflicts:", ret)
+	}
+}
@@ -0,0 +1,315 @@
+/*
+ * Copyright (c) 2026 The XGo Authors (xgo.dev). All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cl
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/goplus/xgo/tpl/ast"
+	"github.com/goplus/xgo/tpl/matcher"
+	"github.com/goplus/xgo/tpl/token"
+)
+
+// -----------------------------------------------------------------------------
+
+type repeat struct {
+	x matcher.Matcher // the repeated expression
+	r *ast.UnaryExpr
+}
+
+type linter struct {
+	fset   *token.FileSet
+	rules  map[string]*ast.Rule // rules defined in files
+	always map[string]bool      // rules that always match
+	warn   func(fset *token.FileSet, pos token.Pos, msg string)
+}
+
+// lint reports common grammar bugs, which are:
+//   - alternatives shadowed by earlier ones, which always match or match
+//     their prefixes (a choice takes the first alternative that matches).
+//   - repetitions of expressions that can match empty, which never end.
+//   - rules that are unreachable from the document rule.
+func lint(ctx *context, conf *Config, files []*ast.File, doc *matcher.Var) {
+	warn := conf.OnWarning
+	if warn == nil {
+		warn = onWarningDefault
+	}
+	p := &linter{fset: ctx.fset, rules: make(map[string]*ast.Rule), always: make(map[string]bool), warn: warn}
+	var docRule *ast.Rule
+	for _, f := range files {
+		for _, decl := range f.Decls {
+			if r, ok := decl.(*ast.Rule); ok {
+				p.rules[r.Name.Name] = r
+				if ctx.rules[r.Name.Name] == doc && docRule == nil {
+					docRule = r
+				}
+			}
+		}
+	}
+	for _, item := range ctx.choices {
+		p.checkChoice(item.c)
+	}
+	for _, item := range ctx.repeats {
+		if _, mayEmpty := item.x.First(nil); mayEmpty {
+			p.warn(p.fset, item.r.Pos(), fmt.Sprintf("`%s` repeats an expression that can match empty", exprString(item.r)))
+		}
+	}
+	reachable := make(map[string]bool)
+	if docRule != nil {
+		p.reach(docRule.Expr, reachable)
+		reachable[docRule.Name.Name] = true
+	}
+	for _, f := range files {
+		for _, decl := range f.Decls {
+			if r, ok := decl.(*ast.Rule); ok && !reachable[r.Name.Name] {
+				p.warn(p.fset, r.Pos(), fmt.Sprintf("rule `%s` is unreachable", r.Name.Name))
+			}
+		}
+	}
+}
+
+func (p *linter) checkChoice(c *ast.Choice) {
+	options := c.Options
+	for i, x := range options {
+		if p.alwaysMatches(x) {
+			for _, y := range options[i+1:] {
+				p.warn(p.fset, y.Pos(), fmt.Sprintf(
+					"alternative `%s` is unreachable, since `%s` before it always matches", exprString(y), exprString(x)))
+			}
+			return
+		}
+	}
+	shadowed := make([]bool, len(options))
+	for i, x := range options {
+		if shadowed[i] {
+			continue
+		}
+		for j := i + 1; j < len(options); j++ {
+			if !shadowed[j] && isPrefix(options[i], options[j]) {
+				shadowed[j] = true
+				y := options[j]
+				p.warn(p.fset, y.Pos(), fmt.Sprintf(
+					"alternative `%s` is shadowed by `%s` before it", exprString(y), exprString(x)))
+			}
+		}
+	}
+}
+
+// alwaysMatches reports whether x matches any input (maybe empty).
+func (p *linter) alwaysMatches(x ast.Expr) bool {
+	switch x := x.(type) {
+	case *ast.Ident:
+		r, ok := p.rules[x.Name]
+		if !ok {
+			return false
+		}
+		always, ok := p.always[x.Name]
+		if !ok {
+			p.always[x.Name] = false // assume false in recursion
+			always = p.alwaysMatches(r.Expr)
+			p.always[x.Name] = always
+		}
+		return always
+	case *ast.BasicLit:
+		return x.Kind == token.STRING && (x.Value == `""` || x.Value == "``")
+	case *ast.Sequence:
+		for _, item := range x.Items {
+			if !p.alwaysMatches(item) {
+				return false
+			}
+		}
+		return true
+	case *ast.Choice:
+		for _, option := range x.Options {
+			if p.alwaysMatches(option) {
+				return true
+			}
+		}
+	case *ast.UnaryExpr:
+		return x.Op != token.ADD || p.alwaysMatches(x.X)
+	case *ast.BinaryExpr:
+		return x.Op == token.REM && p.alwaysMatches(x.X)
+	}
+	return false
+}
+
+// isPrefix reports whether y starts with all items of x.
+func isPrefix(x, y ast.Expr) bool {
+	xs, ys := seqItems(x), seqItems(y)
+	if len(xs) > len(ys) {
+		return false
+	}
+	return sameExprs(xs, ys[:len(xs)])
+}
+
+// sameExpr reports whether x and y are the same expression. Predicates are
+// never the same.
+func sameExpr(x, y ast.Expr) bool {
+	switch x := x.(type) {
+	case *ast.Ident:
+		y, ok := y.(*ast.Ident)
+		return ok && x.Name == y.Name
+	case *ast.BasicLit:
+		y, ok := y.(*ast.BasicLit)
+		return ok && x.Kind == y.Kind && x.Value == y.Value
+	case *ast.Sequence:
+		y, ok := y.(*ast.Sequence)
+		return ok && sameExprs(x.Items, y.Items)
+	case *ast.Choice:
+		y, ok := y.(*ast.Choice)
+		return ok && sameExprs(x.Options, y.Options)
+	case *ast.UnaryExpr:
+		y, ok := y.(*ast.UnaryExpr)
+		return ok && x.Op == y.Op && sameExpr(x.X, y.X)
+	case *ast.BinaryExpr:
+		y, ok := y.(*ast.BinaryExpr)
+		return ok && x.Op == y.Op && sameExpr(x.X, y.X) && sameExpr(x.Y, y.Y)
+	}
+	return false
+}
+
+func sameExprs(xs, ys []ast.Expr) bool {
+	if len(xs) != len(ys) {
+		return false
+	}
+	for i, x := range xs {
+		if !sameExpr(x, ys[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+func seqItems(x ast.Expr) []ast.Expr {
+	if seq, ok := x.(*ast.Sequence); ok {
+		return seq.Items
+	}
+	return []ast.Expr{x}
+}
+
+func (p *linter) reach(x ast.Expr, reachable map[string]bool) {
+	switch x := x.(type) {
+	case *ast.Ident:
+		if r, ok := p.rules[x.Name]; ok && !reachable[x.Name] {
+			reachable[x.Name] = true
+			p.reach(r.Expr, reachable)
+		}
+	case *ast.Sequence:
+		for _, item := range x.Items {
+			p.reach(item, reachable)
+		}
+	case *ast.Choice:
+		for _, option := range x.Options {
+			p.reach(option, reachable)
+		}
+	case *ast.UnaryExpr:
+		p.reach(x.X, reachable)
+	case *ast.BinaryExpr:
+		p.reach(x.X, reachable)
+		p.reach(x.Y, reachable)
+	}
+}
+
+// exprString returns the source form of x.
+func exprString(x ast.Expr) string {
+	var b strings.Builder
+	writeExpr(&b, x, precChoice)
+	return b.String()
+}
+
+// precedences of expressions, see README.md.
+const (
+	precChoice = iota
+	precSequence
+	precList   // %
+	precAdjoin // ++
+	precUnary
+	precOperand
+)
+
+func exprPrec(x ast.Expr) int {
+	switch x := x.(type) {
+	case *ast.Choice:
+		return precChoice
+	case *ast.Sequence:
+		return precSequence
+	case *ast.BinaryExpr:
+		if x.Op == token.REM {
+			return precList
+		}
+		return precAdjoin
+	case *ast.UnaryExpr:
+		return precUnary
+	}
+	return precOperand
+}
+
+// writeExpr writes x, which is enclosed in parentheses if its precedence is
+// lower than prec.
+func writeExpr(b *strings.Builder, x ast.Expr, prec int) {
+	xprec := exprPrec(x)
+	if xprec < prec {
+		b.WriteByte('(')
+		defer b.WriteByte(')')
+	}
+	switch x := x.(type) {
+	case *ast.Ident:
+		b.WriteString(x.Name)
+	case *ast.BasicLit:
+		b.WriteString(x.Value)
+	case *ast.Sequence:
+		writeList(b, x.Items, " ", precList)
+	case *ast.Choice:
+		writeList(b, x.Options, " | ", precSequence)
+	case *ast.UnaryExpr:
+		b.WriteString(x.Op.String())
+		writeExpr(b, x.X, precOperand)
+	case *ast.BinaryExpr:
+		writeExpr(b, x.X, xprec+1)
+		if x.Op == token.REM {
+			b.WriteString(" % ")
+		} else {
+			b.WriteString(" ++ ")
+		}
+		writeExpr(b, x.Y, xprec+1)
+	case *ast.Predicate:
+		b.WriteString(x.Op.String())
+		b.WriteString("{...}")
+	}
+}
+
+func writeList(b *strings.Builder, xs []ast.Expr, sep string, prec int) {
+	for i, x := range xs {
+		if i > 0 {
+			b.WriteString(sep)
+		}
+		writeExpr(b, x, prec)
+	}
+}
+
+func onWarningDefault(fset *token.FileSet, pos token.Pos, msg string) {
+	LogWarning(fset.Position(pos), msg)
+}
+
+// LogWarning logs a warning of a grammar.
+func LogWarning(pos token.Position, msg string) {
+	fmt.Fprintf(os.Stderr, "%v: [WARN] %s\n", pos, msg)
+}
+
+// -----------------------------------------------------------------------------
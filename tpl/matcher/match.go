@@ -20,6 +20,8 @@ import (
 	"errors"
 	"fmt"
 	"log"
+	"slices"
+	"strings"
 
 	"github.com/goplus/xgo/tpl/token"
 	"github.com/goplus/xgo/tpl/types"
@@ -61,10 +63,18 @@ type Error struct {
 
 	// is a runtime error
 	Dyn bool
+
+	// Expected is the set of tokens expected at Pos, and Stack is the stack
+	// of rules being matched there (from the outermost). See FarthestError.
+	Expected []string
+	Stack    []string
 }
 
 func (p *Error) Error() string {
 	pos := p.Fset.Position(p.Pos)
+	if len(p.Stack) > 0 {
+		return fmt.Sprintf("%v: %s (in %s)", pos, p.Msg, strings.Join(p.Stack, " > "))
+	}
 	return fmt.Sprintf("%v: %s", pos, p.Msg)
 }
 
@@ -94,6 +104,59 @@ type Context struct {
 
 	Left    int
 	LastErr error
+
+	// MemoSize is the maximum number of memoized matches of variables at
+	// positions, which makes matching run in linear time even if there is
+	// heavy backtracking. The memo is cleared if it's full. Zero (default)
+	// disables memoization. Don't enable it if RetProcs have side effects,
+	// because a memoized match doesn't call them again.
+	MemoSize int
+
+	// OnMatch is called, if not nil, when a variable matches (with i = -1),
+	// or the i-th option of a choice matches. It's used to measure which
+	// rules and alternatives of a grammar are exercised.
+	OnMatch func(m Matcher, i int)
+
+	// Tracer traces matching of variables if not nil, to see why an input
+	// fails to match (see NewTracer).
+	Tracer Tracer
+
+	// Errors are the errors of variables recovered by skipping tokens to
+	// their sync tokens (see Var.Syncs).
+	Errors []error
+
+	// Skips are tokens (like WHITESPACE or COMMENT) skipped before matching
+	// a token, except in variables with NoSkip set.
+	Skips []token.Token
+
+	recovered map[varKey]bool       // recovered variables, to record errors once
+	memo      map[varKey]*varResult // memoized matches of variables
+	seeds     map[varKey]*varResult // seeds of left recursive variables
+	noSkip    int                   // depth of variables with NoSkip set
+
+	stack    []string // rules being matched
+	expLeft  int      // tokens left at the farthest failure
+	expected []string // tokens expected at the farthest failure
+	expStack []string // rules being matched at the farthest failure
+}
+
+// DefaultMemoSize is a suggested value of Context.MemoSize.
+const DefaultMemoSize = 1 << 16
+
+// varKey identifies a variable matching at a position, which is the number
+// of tokens left, in or out of a variable with NoSkip set.
+type varKey struct {
+	v      *Var
+	left   int
+	noSkip bool
+}
+
+// varResult represents a match of a variable, or the longest match of a left
+// recursive variable so far (a seed).
+type varResult struct {
+	n      int
+	result any
+	err    error
 }
 
 // NewContext creates a new matching context.
@@ -103,6 +166,7 @@ func NewContext(fset *token.FileSet, fileEnd token.Pos, toks []*types.Token) *Co
 		FileEnd: fileEnd,
 		toks:    toks,
 		Left:    len(toks),
+		expLeft: len(toks) + 1,
 	}
 }
 
@@ -113,14 +177,72 @@ func (p *Context) SetLastError(left int, err error) {
 	}
 }
 
+// expect records that a token described by what is expected when left tokens
+// are left, to report the farthest failure.
+func (p *Context) expect(left int, what string) {
+	if left < p.expLeft {
+		p.expLeft, p.expected = left, nil
+		p.expStack = slices.Clone(p.stack)
+	}
+	if left == p.expLeft && !slices.Contains(p.expected, what) {
+		p.expected = append(p.expected, what)
+	}
+}
+
+// FarthestError returns an error at the farthest position where matching
+// failed, with the set of tokens expected there and the rule stack. It
+// returns nil if no token is expected.
+func (p *Context) FarthestError() *Error {
+	if p.expected == nil {
+		return nil
+	}
+	pos, got := p.FileEnd, "EOF"
+	if p.expLeft > 0 {
+		t := p.toks[len(p.toks)-p.expLeft]
+		pos, got = t.Pos, t.String()
+	}
+	n := len(p.expected)
+	want := "`" + strings.Join(p.expected[:n-1], "`, `")
+	if n > 1 {
+		want += "` or `"
+	}
+	want += p.expected[n-1] + "`"
+	return &Error{
+		Fset:     p.Fset,
+		Pos:      pos,
+		Msg:      fmt.Sprintf("expect %s, but got `%s`", want, got),
+		Expected: p.expected,
+		Stack:    p.expStack,
+	}
+}
+
+// Skipped returns the number of tokens in Skips at the beginning of src, or 0
+// in a variable with NoSkip set.
+func (p *Context) Skipped(src []*types.Token) int {
+	if p.noSkip > 0 || p.Skips == nil {
+		return 0
+	}
+	n := 0
+	for n < len(src) && slices.Contains(p.Skips, src[n].Tok) {
+		n++
+	}
+	return n
+}
+
+// FarthestLeft returns the number of tokens left at the farthest position
+// where matching failed. See FarthestError.
+func (p *Context) FarthestLeft() int {
+	return p.expLeft
+}
+
 // NewError creates a new error.
 func (p *Context) NewError(pos token.Pos, msg string) *Error {
-	return &Error{p.Fset, pos, msg, false}
+	return &Error{Fset: p.Fset, Pos: pos, Msg: msg}
 }
 
 // NewErrorf creates a new error with a format string.
 func (p *Context) NewErrorf(pos token.Pos, format string, args ...any) error {
-	return &Error{p.Fset, pos, fmt.Sprintf(format, args...), false}
+	return &Error{Fset: p.Fset, Pos: pos, Msg: fmt.Sprintf(format, args...)}
 }
 
 // -----------------------------------------------------------------------------
@@ -187,9 +309,9 @@ func hasConflict(me []any, next []any) bool {
 	return false
 }
 
-func conflictWith(me []any, next [][]any, from int) int {
+func conflictWith(me []any, next [][]any, from int, skips []bool) int {
 	for i, n := from, len(next); i < n; i++ {
-		if hasConflict(me, next[i]) {
+		if !skips[i] && hasConflict(me, next[i]) {
 			return i
 		}
 	}
@@ -227,6 +349,9 @@ func True() Matcher {
 type gWS struct{}
 
 func (p gWS) Match(src []*types.Token, ctx *Context) (n int, result any, err error) {
+	if ctx.Skipped(src) > 0 {
+		return 0, nil, nil
+	}
 	if left := len(src); left > 0 {
 		toks := ctx.toks
 		if n := len(toks); n > left {
@@ -253,14 +378,17 @@ func WhiteSpace() Matcher {
 type gString byte
 
 func (p gString) Match(src []*types.Token, ctx *Context) (n int, result any, err error) {
-	if len(src) == 0 {
+	n = ctx.Skipped(src)
+	if len(src) == n {
+		ctx.expect(0, stringType(p))
 		return 0, nil, ctx.NewErrorf(ctx.FileEnd, "expect `%s`, but got EOF", stringType(p))
 	}
-	t := src[0]
+	t := src[n]
 	if t.Tok != token.STRING || t.Lit[0] != byte(p) {
+		ctx.expect(len(src)-n, stringType(p))
 		return 0, nil, ctx.NewErrorf(t.Pos, "expect `%s`, but got `%v`", stringType(p), t)
 	}
-	return 1, t, nil
+	return n + 1, t, nil
 }
 
 func (p gString) First(in []any) (first []any, mayEmpty bool) {
@@ -286,14 +414,17 @@ type gToken struct {
 }
 
 func (p *gToken) Match(src []*types.Token, ctx *Context) (n int, result any, err error) {
-	if len(src) == 0 {
+	n = ctx.Skipped(src)
+	if len(src) == n {
+		ctx.expect(0, p.tok.String())
 		return 0, nil, ctx.NewErrorf(ctx.FileEnd, "expect `%s`, but got EOF", p.tok)
 	}
-	t := src[0]
+	t := src[n]
 	if t.Tok != p.tok {
+		ctx.expect(len(src)-n, p.tok.String())
 		return 0, nil, ctx.NewErrorf(t.Pos, "expect `%s`, but got `%s`", p.tok, t.Tok)
 	}
-	return 1, t, nil
+	return n + 1, t, nil
 }
 
 func (p *gToken) First(in []any) (first []any, mayEmpty bool) {
@@ -310,21 +441,25 @@ func Token(tok token.Token) Matcher {
 type gLiteral MatchToken
 
 func (p *gLiteral) Match(src []*types.Token, ctx *Context) (n int, result any, err error) {
-	if len(src) == 0 {
+	n = ctx.Skipped(src)
+	if len(src) == n {
+		ctx.expect(0, p.Lit)
 		return 0, nil, ctx.NewErrorf(ctx.FileEnd, "expect `%s`, but got EOF", p.Lit)
 	}
-	t := src[0]
-	if t.Tok != p.Tok || t.Lit != p.Lit {
+	t := src[n]
+	if t.Lit != p.Lit || t.Tok != p.Tok && !(p.Tok == token.IDENT && t.Tok == token.KEYWORD) {
+		ctx.expect(len(src)-n, p.Lit)
 		return 0, nil, ctx.NewErrorf(t.Pos, "expect `%s`, but got `%v`", p.Lit, t)
 	}
-	return 1, t, nil
+	return n + 1, t, nil
 }
 
 func (p *gLiteral) First(in []any) (first []any, mayEmpty bool) {
 	return append(in, (*MatchToken)(p)), false
 }
 
-// Literal: "abc", 'a', 123, 1.23, etc.
+// Literal: "abc", 'a', 123, 1.23, etc. An IDENT literal also matches a
+// KEYWORD token.
 func Literal(tok token.Token, lit string) Matcher {
 	return &gLiteral{tok, lit}
 }
@@ -335,18 +470,32 @@ func Literal(tok token.Token, lit string) Matcher {
 type Choices struct {
 	options []Matcher
 	stops   []bool
+
+	checking bool // CheckConflicts is computing first sets of options
+	leftRec  bool // an option being checked is left recursive
 }
 
+// CheckConflicts calls conflict if first sets of two options conflict. Left
+// recursive options (like `expr "+" term` in `expr = expr "+" term | term`)
+// aren't checked, because they always conflict with the other options.
 func (p *Choices) CheckConflicts(conflict func(firsts [][]any, i, at int)) {
 	options := p.options
 	n := len(options)
 	firsts := make([][]any, n)
+	leftRecs := make([]bool, n)
+	p.checking = true
 	for i, g := range options {
+		p.leftRec = false
 		firsts[i], _ = g.First(nil)
+		leftRecs[i] = p.leftRec
 	}
+	p.checking = false
 	stops := make([]bool, n)
 	for i, me := range firsts {
-		at := conflictWith(me, firsts, i+1)
+		at := -1
+		if !leftRecs[i] {
+			at = conflictWith(me, firsts, i+1, leftRecs)
+		}
 		if at >= 0 {
 			conflict(firsts, i, at)
 		} else {
@@ -364,6 +513,9 @@ func (p *Choices) Match(src []*types.Token, ctx *Context) (n int, result any, er
 	stops := p.stops // be set by CheckConflicts
 	for i, g := range p.options {
 		if n, result, err = g.Match(src, ctx); err == nil || (n > 0 && stops[i]) {
+			if err == nil && ctx.OnMatch != nil {
+				ctx.OnMatch(p, i)
+			}
 			return
 		}
 		if n >= nMax {
@@ -381,6 +533,10 @@ func (p *Choices) Match(src []*types.Token, ctx *Context) (n int, result any, er
 }
 
 func (p *Choices) First(in []any) (first []any, mayEmpty bool) {
+	if p.checking { // left recursion: it adds nothing to first
+		p.leftRec = true
+		return in, false
+	}
 	for _, g := range p.options {
 		var me bool
 		if in, me = g.First(in); me {
@@ -394,7 +550,7 @@ func (p *Choices) First(in []any) (first []any, mayEmpty bool) {
 // Choice: R1 | R2 | ... | Rn
 // Should be used with CheckConflicts.
 func Choice(options ...Matcher) *Choices {
-	return &Choices{options, nil}
+	return &Choices{options: options}
 }
 
 // -----------------------------------------------------------------------------
@@ -407,6 +563,12 @@ func (p *gSequence) Match(src []*types.Token, ctx *Context) (n int, result any,
 	nitems := len(p.items)
 	rets := make([]any, nitems)
 	for i, g := range p.items {
+		if pred, ok := g.(*gPredicate); ok {
+			if err1 := pred.check(rets[:i], src[n:], ctx); err1 != nil {
+				return n, nil, err1
+			}
+			continue
+		}
 		n1, ret1, err1 := g.Match(src[n:], ctx)
 		if err1 != nil {
 			if isDyn(err1) {
@@ -440,6 +602,45 @@ func Sequence(items ...Matcher) Matcher {
 
 // -----------------------------------------------------------------------------
 
+// Cond represents the condition of a semantic predicate. It's called with the
+// results of the preceding items of the enclosing sequence (nil if the
+// predicate isn't in a sequence).
+type Cond = func(self []any) bool
+
+type gPredicate struct {
+	cond Cond
+	not  bool
+}
+
+func (p *gPredicate) Match(src []*types.Token, ctx *Context) (n int, result any, err error) {
+	return 0, nil, p.check(nil, src, ctx)
+}
+
+func (p *gPredicate) check(self []any, src []*types.Token, ctx *Context) error {
+	if p.cond(self) != p.not {
+		return nil
+	}
+	pos := ctx.FileEnd
+	if len(src) > 0 {
+		pos = src[0].Pos
+	}
+	return ctx.NewError(pos, "predicate failed")
+}
+
+func (p *gPredicate) First(in []any) (first []any, mayEmpty bool) {
+	return in, true
+}
+
+// Predicate: &{ cond } or !{ cond } (if not is true)
+// It matches nothing, and fails if cond is false (or true if not is true).
+// Predicates depending on states other than their arguments require
+// memoization to be disabled (see Context.MemoSize).
+func Predicate(cond Cond, not bool) Matcher {
+	return &gPredicate{cond, not}
+}
+
+// -----------------------------------------------------------------------------
+
 type gRepeat0 struct {
 	r Matcher
 }
@@ -570,7 +771,7 @@ func (p *gAdjoin) Match(src []*types.Token, ctx *Context) (n int, result any, er
 		err = errAdjoinEmpty
 		return
 	}
-	if src[n-1].End() != src[n].Pos {
+	if src[n-1].End() != src[n].Pos || ctx.Skipped(src[n:]) > 0 {
 		err = ctx.NewError(src[n].Pos, "not adjoin")
 		return
 	}
@@ -594,24 +795,124 @@ func Adjoin(a, b Matcher) Matcher {
 type RetProc = func(any) any
 type ListRetProc = func([]any) any
 
+// SpanRetProc and ListSpanRetProc are RetProcs that also take the source span
+// of the match, to report errors pointing into the source.
+type SpanRetProc = func(any, Span) any
+type ListSpanRetProc = func([]any, Span) any
+
+// Span represents the source span [Pos, End) of a match. Pos == End if the
+// match is empty.
+type Span struct {
+	Pos, End token.Pos
+}
+
+func span(src []*types.Token, n int, ctx *Context) Span {
+	if n > 0 {
+		return Span{src[0].Pos, src[n-1].End()}
+	}
+	if len(src) > 0 {
+		return Span{src[0].Pos, src[0].Pos}
+	}
+	return Span{ctx.FileEnd, ctx.FileEnd}
+}
+
 type Var struct {
 	Elem Matcher
 	Name string
 	Pos  token.Pos
 
 	RetProc any
+
+	// Syncs are the sync tokens of a recovery clause (see ast.Recover), which
+	// are elements of first sets (see Matcher.First). If they aren't nil and
+	// the variable fails after matching some tokens, the error is recorded in
+	// Context.Errors, and tokens are skipped until a sync token (inclusive),
+	// so that the variable matches with the error as its result.
+	Syncs []any
+
+	// NoSkip makes tokens in Context.Skips not skipped from the beginning of
+	// this variable to its end, like the indentation of a line.
+	NoSkip bool
+
+	// LeftRec reports whether this variable is left recursive, like
+	// `expr = expr "+" term | term`. It's set by First.
+	LeftRec bool
 }
 
 func (p *Var) Match(src []*types.Token, ctx *Context) (n int, result any, err error) {
+	if tr := ctx.Tracer; tr != nil {
+		tr.Enter(p, src, ctx)
+		defer func() {
+			tr.Exit(p, src, n, err, ctx)
+		}()
+	}
+	memo := ctx.MemoSize > 0
+	key := varKey{p, len(src), ctx.noSkip > 0}
+	if memo {
+		if r, ok := ctx.memo[key]; ok {
+			return r.n, r.result, r.err
+		}
+	}
+	if p.LeftRec {
+		n, result, err = p.growSeed(src, ctx)
+		memo = memo && len(ctx.seeds) == 0 // it may depend on other seeds
+	} else {
+		n, result, err = p.match(src, ctx)
+	}
+	if memo {
+		if ctx.memo == nil || len(ctx.memo) >= ctx.MemoSize {
+			ctx.memo = make(map[varKey]*varResult)
+		}
+		ctx.memo[key] = &varResult{n, result, err}
+	}
+	return
+}
+
+// growSeed matches a left recursive variable by growing the seed: a recursive
+// call at the same position returns the longest match so far (failing at
+// first), and the variable is matched again until the match can't be longer.
+func (p *Var) growSeed(src []*types.Token, ctx *Context) (n int, result any, err error) {
+	key := varKey{p, len(src), ctx.noSkip > 0}
+	if s, ok := ctx.seeds[key]; ok {
+		return s.n, s.result, s.err
+	}
+	if ctx.seeds == nil {
+		ctx.seeds = make(map[varKey]*varResult)
+	}
+	s := &varResult{err: errMultiMismatch}
+	ctx.seeds[key] = s
+	defer delete(ctx.seeds, key)
+	for {
+		n1, ret1, err1 := p.match(src, ctx)
+		if err1 != nil || (s.err == nil && n1 <= s.n) {
+			if s.err != nil { // no match
+				return n1, ret1, err1
+			}
+			return s.n, s.result, nil
+		}
+		s.n, s.result, s.err = n1, ret1, nil
+	}
+}
+
+func (p *Var) match(src []*types.Token, ctx *Context) (n int, result any, err error) {
 	g := p.Elem
 	if g == nil {
 		return 0, nil, ctx.NewErrorf(p.Pos, "variable `%s` not assigned", p.Name)
 	}
+	if p.NoSkip {
+		ctx.noSkip++
+		defer func() { ctx.noSkip-- }()
+	}
 	if enableMatchVar && len(src) > 0 {
 		log.Println("==> Match", p.Name, src[0])
 	}
+	ctx.stack = append(ctx.stack, p.Name)
 	n, result, err = g.Match(src, ctx)
+	ctx.stack = ctx.stack[:len(ctx.stack)-1]
 	if err == nil {
+		if ctx.OnMatch != nil {
+			ctx.OnMatch(p, -1)
+		}
 		if retProc := p.RetProc; retProc != nil {
 			defer func() {
 				if e := recover(); e != nil {
@@ -633,33 +934,87 @@ func (p *Var) Match(src []*types.Token, ctx *Context) (n int, result any, err er
 					}
 				}
 			}()
-			if listRetPorc, ok := retProc.(ListRetProc); ok {
-				result = listRetPorc(result.([]any))
-			} else {
+			switch retProc := retProc.(type) {
+			case ListRetProc:
+				result = retProc(result.([]any))
+			case SpanRetProc:
+				result = retProc(result, span(src, n, ctx))
+			case ListSpanRetProc:
+				result = retProc(result.([]any), span(src, n, ctx))
+			default:
 				result = retProc.(RetProc)(result)
 			}
 		}
-	} else if err == errMultiMismatch {
-		var posErr token.Pos
-		var tokErr any
-		if len(src) > 0 {
-			posErr, tokErr = src[0].Pos, src[0]
-		} else {
-			posErr, tokErr = ctx.FileEnd, "EOF"
+	} else {
+		if err == errMultiMismatch {
+			var posErr token.Pos
+			var tokErr any
+			if len(src) > 0 {
+				posErr, tokErr = src[0].Pos, src[0]
+			} else {
+				posErr, tokErr = ctx.FileEnd, "EOF"
+			}
+			err = ctx.NewErrorf(posErr, "expect `%s`, but got `%s`", p.Name, tokErr)
+		}
+		if p.Syncs != nil && n > 0 && !isDyn(err) {
+			return p.recover(src, n, err, ctx)
 		}
-		err = ctx.NewErrorf(posErr, "expect `%s`, but got `%s`", p.Name, tokErr)
 	}
 	return
 }
 
+// recover records the error of a failed match of n tokens, and skips tokens
+// until a sync token (inclusive), or to the end of src.
+func (p *Var) recover(src []*types.Token, n int, err error, ctx *Context) (int, any, error) {
+	end := n
+	for end < len(src) {
+		t := src[end]
+		end++
+		if isSync(p.Syncs, t) {
+			break
+		}
+	}
+	if left := ctx.FarthestLeft(); left <= len(src)-n && left >= len(src)-end {
+		if e := ctx.FarthestError(); e != nil {
+			err = e // more specific if it's in the skipped tokens
+		}
+	}
+	key := varKey{p, len(src), ctx.noSkip > 0}
+	if !ctx.recovered[key] {
+		if ctx.recovered == nil {
+			ctx.recovered = make(map[varKey]bool)
+		}
+		ctx.recovered[key] = true
+		ctx.Errors = append(ctx.Errors, err)
+	}
+	return end, err, nil
+}
+
+func isSync(syncs []any, t *types.Token) bool {
+	for _, sync := range syncs {
+		switch sync := sync.(type) {
+		case token.Token:
+			if t.Tok == sync {
+				return true
+			}
+		case *MatchToken:
+			if t.Lit == sync.Lit && (t.Tok == sync.Tok || sync.Tok == token.IDENT && t.Tok == token.KEYWORD) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
 func (p *Var) First(in []any) (first []any, mayEmpty bool) {
 	elem := p.Elem
 	if elem != nil {
 		p.Elem = nil // to stop recursion
 		first, mayEmpty = elem.First(in)
 		p.Elem = elem
-	} else {
-		panic(RecursiveError{p})
+	} else { // left recursion: it adds nothing to first
+		p.LeftRec = true
+		first = in
 	}
 	return
 }
@@ -0,0 +1,96 @@
+/*
+ * Copyright (c) 2026 The XGo Authors (xgo.dev). All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package matcher
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/goplus/xgo/tpl/types"
+)
+
+// -----------------------------------------------------------------------------
+
+// A Tracer traces matching of variables (rules), see Context.Tracer.
+type Tracer interface {
+	// Enter is called when v starts to match src.
+	Enter(v *Var, src []*types.Token, ctx *Context)
+
+	// Exit is called when v ends matching src, with n tokens matched and
+	// the error if v fails.
+	Exit(v *Var, src []*types.Token, n int, err error, ctx *Context)
+}
+
+type treeTracer struct {
+	w     io.Writer
+	fold  bool
+	depth int
+}
+
+// NewTracer creates a Tracer which writes matching of variables to w as a
+// tree, one line per entry or exit, like:
+//
+//	expr at 1:1 `x`
+//	  term at 1:1 `x`
+//	  term: ok, 1 token(s)
+//	expr: fail, expect `=`, but got `;`
+//
+// If fold is true, the line of an entry ends with '{' and the line of an
+// exit starts with '}', so the tree can be folded by editors.
+func NewTracer(w io.Writer, fold bool) Tracer {
+	return &treeTracer{w: w, fold: fold}
+}
+
+func (p *treeTracer) Enter(v *Var, src []*types.Token, ctx *Context) {
+	var where string
+	if len(src) > 0 {
+		where = fmt.Sprintf("%v `%v`", ctx.Fset.Position(src[0].Pos), src[0])
+	} else {
+		where = fmt.Sprintf("%v EOF", ctx.Fset.Position(ctx.FileEnd))
+	}
+	p.line(v.Name+" at "+where, " {")
+	p.depth++
+}
+
+func (p *treeTracer) Exit(v *Var, src []*types.Token, n int, err error, ctx *Context) {
+	p.depth--
+	var outcome string
+	if err == nil {
+		outcome = fmt.Sprintf("%s: ok, %d token(s)", v.Name, n)
+	} else {
+		msg := err.Error()
+		if e, ok := err.(*Error); ok {
+			msg = e.Msg
+		}
+		outcome = v.Name + ": fail, " + msg
+	}
+	if p.fold {
+		outcome = "} " + outcome
+	}
+	p.line(outcome, "")
+}
+
+func (p *treeTracer) line(s, foldSuffix string) {
+	indent := strings.Repeat("  ", p.depth)
+	if p.fold {
+		s += foldSuffix
+	}
+	fmt.Fprintln(p.w, indent+s)
+}
+
+// -----------------------------------------------------------------------------
@@ -77,6 +77,11 @@ type parser struct {
 	// Callback to parse RetProc.
 	parseRetProc RetProcParser
 
+	// Recovery clause of the rule being parsed, and nesting level of
+	// parentheses.
+	recover *ast.Recover
+	level   int
+
 	// Error handling
 	errors scanner.ErrorList
 }
@@ -135,11 +140,11 @@ func (p *parser) parseFile() *ast.File {
 	}
 
 	for p.tok != token.EOF {
-		rule := p.parseRule()
-		if rule == nil {
+		decl := p.parseDecl()
+		if decl == nil {
 			break
 		}
-		file.Decls = append(file.Decls, rule)
+		file.Decls = append(file.Decls, decl)
 	}
 
 	return file
@@ -157,26 +162,65 @@ func (p *parser) parseIdent() *ast.Ident {
 	return &ast.Ident{NamePos: pos, Name: name}
 }
 
-// parseRule parses a rule:
-//
-//	IDENT '=' expr ';'
-//	IDENT '=' expr => { ... } ';'
-func (p *parser) parseRule() *ast.Rule {
+// parseDecl parses a rule, a use declaration or a skip declaration.
+func (p *parser) parseDecl() ast.Decl {
 	if p.tok != token.IDENT {
 		p.errorExpected(p.pos, "'IDENT'")
 		return nil
 	}
 
 	name := p.parseIdent()
+	if name.Name == "use" && p.tok == token.IDENT {
+		return p.parseUse(name.NamePos)
+	}
+	if (name.Name == "skip" || name.Name == "noskip") && p.tok == token.IDENT {
+		names := p.parseIdentList()
+		return &ast.SkipDecl{Skip: name.NamePos, No: name.Name == "noskip", Names: names}
+	}
+	if rule := p.parseRule(name); rule != nil {
+		return rule
+	}
+	return nil
+}
+
+// parseUse parses a use declaration:
+//
+//	"use" IDENT % ',' ';'
+func (p *parser) parseUse(pos token.Pos) *ast.UseDecl {
+	return &ast.UseDecl{Use: pos, Names: p.parseIdentList()}
+}
+
+// parseIdentList parses identifiers of a declaration:
+//
+//	IDENT % ',' ';'
+func (p *parser) parseIdentList() []*ast.Ident {
+	names := []*ast.Ident{p.parseIdent()}
+	for p.tok == token.COMMA {
+		p.next()
+		names = append(names, p.parseIdent())
+	}
+	p.expect(token.SEMICOLON)
+	return names
+}
+
+// parseRule parses a rule:
+//
+//	IDENT '=' expr ';'
+//	IDENT '=' expr => { ... } ';'
+//	IDENT '=' expr recover (CHAR | STRING) % ',' [=> { ... }] ';'
+func (p *parser) parseRule(name *ast.Ident) *ast.Rule {
 	tokPos := p.expect(token.ASSIGN)
 	expr := p.parseExpr()
+	recov := p.recover
+	p.recover = nil
 	if expr == nil {
 		return nil
 	}
 
 	var retProc ast.Node
 	if p.tok == token.DRARROW { // => { ... }
-		if off, end, ok := p.lambdaExpr(); ok {
+		off, end, ok := p.lambdaExpr()
+		if ok {
 			if p.parseRetProc != nil {
 				file := p.file
 				base := file.Base()
@@ -188,6 +232,8 @@ func (p *parser) parseRule() *ast.Rule {
 					p.errors = append(p.errors, err...)
 				}
 			}
+		} else { // unclosed RetProc reaches EOF
+			return &ast.Rule{Name: name, TokPos: tokPos, Expr: expr, Recover: recov}
 		}
 	}
 
@@ -196,10 +242,37 @@ func (p *parser) parseRule() *ast.Rule {
 		Name:    name,
 		TokPos:  tokPos,
 		Expr:    expr,
+		Recover: recov,
 		RetProc: retProc,
 	}
 }
 
+// parseRecover parses a recovery clause after "recover", which must be at
+// the end of a rule:
+//
+//	"recover" (CHAR | STRING) % ','
+func (p *parser) parseRecover(pos token.Pos) {
+	recov := &ast.Recover{RecoverPos: pos}
+	for {
+		if p.tok != token.CHAR && p.tok != token.STRING {
+			p.errorExpected(p.pos, "sync token")
+			break
+		}
+		recov.Syncs = append(recov.Syncs, &ast.BasicLit{ValuePos: p.pos, Kind: p.tok, Value: p.lit})
+		p.next()
+		if p.tok != token.COMMA {
+			break
+		}
+		p.next()
+	}
+	if p.level > 0 || (p.tok != token.SEMICOLON && p.tok != token.DRARROW && p.tok != token.EOF) {
+		p.error(pos, "recovery clause must be at the end of a rule")
+	}
+	if len(recov.Syncs) > 0 && p.recover == nil {
+		p.recover = recov
+	}
+}
+
 func (p *parser) lambdaExpr() (start, end token.Pos, ok bool) {
 	start = p.pos // => {
 	p.next()
@@ -217,6 +290,7 @@ func (p *parser) lambdaExpr() (start, end token.Pos, ok bool) {
 		case token.LBRACE:
 			level++
 		case token.EOF:
+			p.errorExpected(p.pos, "'}'")
 			return
 		}
 		p.next()
@@ -311,7 +385,7 @@ func (p *parser) parseTerm2() (ast.Expr, bool) {
 	return x, true
 }
 
-// parseFactor: IDENT | CHAR | STRING | ('*' | '+' | '?') factor | '(' expr ')'
+// parseFactor: IDENT | CHAR | STRING | ('*' | '+' | '?') factor | '(' expr ')' | ('&' | '!') '{' cond '}'
 func (p *parser) parseFactor() (ast.Expr, bool) {
 	switch tok := p.tok; tok {
 	case token.IDENT:
@@ -320,6 +394,10 @@ func (p *parser) parseFactor() (ast.Expr, bool) {
 			Name:    p.lit,
 		}
 		p.next()
+		if ident.Name == "recover" && (p.tok == token.CHAR || p.tok == token.STRING) {
+			p.parseRecover(ident.NamePos)
+			return nil, false
+		}
 		return ident, true
 
 	case token.CHAR, token.STRING:
@@ -348,13 +426,60 @@ func (p *parser) parseFactor() (ast.Expr, bool) {
 
 	case token.LPAREN:
 		p.next()
+		p.level++
 		expr := p.parseExpr()
+		p.level--
 		p.expect(token.RPAREN)
 		return expr, true
 
+	case token.AND, token.NOT:
+		return p.parsePredicate(), true
+
 	default:
 		return nil, false
 	}
 }
 
+// parsePredicate parses a semantic predicate: ('&' | '!') '{' cond '}'
+func (p *parser) parsePredicate() *ast.Predicate {
+	pred := &ast.Predicate{OpPos: p.pos, Op: p.tok}
+	p.next()
+	if p.tok != token.LBRACE {
+		p.errorExpected(p.pos, "'{'")
+		pred.Rbrace = p.pos
+		return pred
+	}
+	lbrace := p.pos
+	p.next()
+	level := 1
+	for {
+		switch p.tok {
+		case token.LBRACE:
+			level++
+		case token.RBRACE:
+			if level--; level == 0 {
+				pred.Rbrace = p.pos
+				p.next()
+				if p.parseRetProc != nil {
+					file := p.file
+					base := file.Base()
+					src := p.scanner.CodeTo(int(pred.Rbrace) - base)
+					cond, err := p.parseRetProc(file, src, int(lbrace)+1-base)
+					if err == nil {
+						pred.Cond = cond
+					} else {
+						p.errors = append(p.errors, err...)
+					}
+				}
+				return pred
+			}
+		case token.EOF:
+			p.errorExpected(p.pos, "'}'")
+			pred.Rbrace = p.pos
+			return pred
+		}
+		p.next()
+	}
+}
+
 // -----------------------------------------------------------------------------
@@ -80,3 +80,19 @@ func testFromDir(t *testing.T, sel, relDir string) {
 func TestFromTestdata(t *testing.T) {
 	testFromDir(t, "", "./_testdata")
 }
+
+func TestUnclosedRetProc(t *testing.T) {
+	_, err := parser.ParseFile(token.NewFileSet(), "a.tpl", "a = b => { x", nil)
+	if err == nil || !strings.HasPrefix(err.Error(), "a.tpl:1:13: expected '}', found 'EOF'") {
+		t.Fatal("ParseFile:", err)
+	}
+}
+
+func TestRecoverNotAtEnd(t *testing.T) {
+	for _, src := range []string{`a = (b recover ";")`, `a = b recover ";" | c`, `a = b recover ";" c`} {
+		_, err := parser.ParseFile(token.NewFileSet(), "a.tpl", src, nil)
+		if err == nil || !strings.Contains(err.Error(), "recovery clause must be at the end of a rule") {
+			t.Fatal("ParseFile:", src, err)
+		}
+	}
+}
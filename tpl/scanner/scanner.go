@@ -53,9 +53,17 @@ type Scanner struct {
 	nParen     int
 	unitVal    string
 	insertSemi bool // insert a semicolon before next newline
+	keywords   map[string]string
+	ops        []string
+	terms      []terminal
+	spaces     string // see Syntax.KeepSpaces
 
 	// public state - ok to modify
 	ErrorCount int // number of errors encountered
+
+	// Syntax customizes the lexical rules if not nil. It takes effect
+	// when Init is called.
+	Syntax *Syntax
 }
 
 const bom = 0xFEFF // byte order mark, only permitted as very first character
@@ -151,6 +159,7 @@ func (s *Scanner) InitEx(file *token.File, src []byte, offset int, err ErrorHand
 	s.lineOffset = 0
 	s.insertSemi = false
 	s.ErrorCount = 0
+	s.initSyntax()
 
 	s.next()
 	if s.ch == bom {
@@ -667,6 +676,9 @@ func (s *Scanner) scanRawString() string {
 
 func (s *Scanner) skipWhitespace() {
 	for s.ch == ' ' || s.ch == '\t' || s.ch == '\n' && !s.insertSemi || s.ch == '\r' {
+		if s.keepSpace(s.ch) {
+			return
+		}
 		s.next()
 	}
 }
@@ -713,6 +725,16 @@ func (s *Scanner) switch4(tok0, tok1 token.Token, ch2 rune, tok2, tok3 token.Tok
 	return tok0
 }
 
+func (s *Scanner) illegal(t *types.Token, ch rune) (insertSemi bool) {
+	// next reports unexpected BOMs - don't repeat
+	if ch != bom {
+		s.error(s.file.Offset(t.Pos), fmt.Sprintf("illegal character %#U", ch))
+	}
+	t.Tok = token.ILLEGAL
+	t.Lit = string(ch)
+	return s.insertSemi // preserve insertSemi info
+}
+
 func (s *Scanner) tokSEMICOLON() token.Token {
 	s.nParen = 0
 	return token.SEMICOLON
@@ -761,11 +783,31 @@ scanAgain:
 		s.unitVal = ""
 		goto done
 	}
+	if s.Syntax != nil && s.scanSyntax(&t) {
+		switch t.Tok {
+		case token.SEMICOLON:
+			return
+		case token.COMMENT:
+			if s.mode&ScanComments == 0 {
+				goto scanAgain // skip comment
+			}
+			insertSemi = s.insertSemi // preserve insertSemi info
+		case token.OPERATOR:
+		case token.WHITESPACE:
+			insertSemi = s.insertSemi
+		default:
+			insertSemi = true
+		}
+		goto done
+	}
 	switch ch := s.ch; {
 	case isLetter(ch):
 		insertSemi = true
 		t.Lit = s.scanIdentifier()
 		t.Tok = token.IDENT
+		if kw, ok := s.keyword(t.Lit); ok {
+			t.Tok, t.Lit = token.KEYWORD, kw
+		}
 	case isDecimal(ch) || ch == '.' && isDecimal(rune(s.peek())):
 		insertSemi = true
 		t.Tok, t.Lit = s.scanNumber()
@@ -787,14 +829,26 @@ scanAgain:
 			t.Tok, t.Lit = s.tokSEMICOLON(), "\n"
 			return
 		case '"':
+			if !s.defaultQuotes() {
+				insertSemi = s.illegal(&t, ch)
+				break
+			}
 			insertSemi = true
 			t.Tok = token.STRING
 			t.Lit = s.scanString()
 		case '\'':
+			if !s.defaultQuotes() {
+				insertSemi = s.illegal(&t, ch)
+				break
+			}
 			insertSemi = true
 			t.Tok = token.CHAR
 			t.Lit = s.scanRune()
 		case '`':
+			if !s.defaultQuotes() {
+				insertSemi = s.illegal(&t, ch)
+				break
+			}
 			insertSemi = true
 			t.Tok = token.STRING
 			t.Lit = s.scanRawString()
@@ -852,7 +906,7 @@ scanAgain:
 		case '*':
 			t.Tok = s.switch2(token.MUL, token.MUL_ASSIGN)
 		case '/':
-			if s.ch == '/' || s.ch == '*' {
+			if (s.ch == '/' || s.ch == '*') && s.defaultComments() {
 				// comment
 				if s.insertSemi && s.findLineEnd() {
 					// reset position to the beginning of the comment
@@ -875,6 +929,10 @@ scanAgain:
 				t.Tok = s.switch2(token.QUO, token.QUO_ASSIGN)
 			}
 		case '#':
+			if !s.defaultComments() {
+				insertSemi = s.illegal(&t, ch)
+				break
+			}
 			if s.insertSemi {
 				s.ch = '#'
 				s.offset = s.file.Offset(t.Pos)
@@ -932,18 +990,12 @@ scanAgain:
 		case '@':
 			t.Tok = token.AT
 		default:
-			// next reports unexpected BOMs - don't repeat
-			if ch != bom {
-				s.error(s.file.Offset(t.Pos), fmt.Sprintf("illegal character %#U", ch))
-			}
-			insertSemi = s.insertSemi // preserve insertSemi info
-			t.Tok = token.ILLEGAL
-			t.Lit = string(ch)
+			insertSemi = s.illegal(&t, ch)
 		}
 	}
 
 done:
-	if s.mode&NoInsertSemis == 0 {
+	if s.mode&NoInsertSemis == 0 && !s.keepSpace('\n') {
 		s.insertSemi = insertSemi
 	}
 	return
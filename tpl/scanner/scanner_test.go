@@ -17,6 +17,8 @@
 package scanner
 
 import (
+	"fmt"
+	"strings"
 	"testing"
 
 	"github.com/goplus/xgo/tpl/token"
@@ -196,3 +198,180 @@ world !=
 		t.Fatalf("len(expected) != i: %d, %d\n", len(expected), i)
 	}
 }
+
+func TestSyntax(t *testing.T) {
+	const src = `select a::int, "b c", 'it''s' -- comment
+from t /* x */ where a->>'k' # 1
+/* multi
+line */ $$raw
+$$`
+	expected := []tokenTest{
+		{1, token.KEYWORD, `SELECT`},
+		{8, token.IDENT, `a`},
+		{9, token.OPERATOR, `::`},
+		{11, token.IDENT, `int`},
+		{14, ',', ``},
+		{16, token.IDENT, `"b c"`},
+		{21, ',', ``},
+		{23, token.STRING, `'it''s'`},
+		{31, ';', "\n"},
+		{31, token.COMMENT, `-- comment`},
+		{42, token.KEYWORD, `FROM`},
+		{47, token.IDENT, `t`},
+		{49, token.COMMENT, `/* x */`},
+		{57, token.KEYWORD, `WHERE`},
+		{63, token.IDENT, `a`},
+		{64, token.OPERATOR, `->>`},
+		{67, token.STRING, `'k'`},
+		{71, token.ILLEGAL, `#`},
+		{73, token.INT, `1`},
+		{74, ';', "\n"},
+		{75, token.COMMENT, "/* multi\nline */"},
+		{92, token.STRING, "$$raw\n$$"},
+		{100, ';', "\n"},
+	}
+	s := Scanner{Syntax: &Syntax{
+		Keywords:   []string{"SELECT", "FROM", "WHERE"},
+		IgnoreCase: true,
+		Operators:  []string{"::", "->>", "->"},
+		Comments:   []Comment{{Start: "--"}, {Start: "/*", End: "*/"}},
+		Quotes: []Quote{
+			{Open: "'", Escape: '\''},
+			{Open: `"`, Tok: token.IDENT},
+			{Open: "$$", Multiline: true},
+		},
+	}}
+	fset := token.NewFileSet()
+	file := fset.AddFile("", -1, len(src))
+	nerr := 0
+	s.Init(file, []byte(src), func(pos token.Position, msg string) { nerr++ }, ScanComments)
+	for i := 0; ; i++ {
+		c := s.Scan()
+		if c.Tok == token.EOF {
+			if i != len(expected) {
+				t.Fatalf("len(expected) != i: %d, %d\n", len(expected), i)
+			}
+			break
+		}
+		expect := Token{Tok: expected[i].Kind, Pos: expected[i].Pos, Lit: expected[i].Literal}
+		if c != expect {
+			t.Fatal("Scan failed:", c, expect)
+		}
+	}
+	if nerr != 1 {
+		t.Fatal("errors:", nerr)
+	}
+
+	const bad = `'a
+"b /* c`
+	s.Syntax.Quotes[1].Escape = '\\'
+	file = fset.AddFile("", -1, len(bad))
+	var errs []string
+	s.Init(file, []byte(bad), func(pos token.Position, msg string) {
+		errs = append(errs, fmt.Sprintf("%d:%d: %s", pos.Line, pos.Column, msg))
+	}, 0)
+	for s.Scan().Tok != token.EOF {
+	}
+	if strings.Join(errs, "; ") != "1:1: literal not terminated; 2:1: literal not terminated" {
+		t.Fatal("errors:", errs)
+	}
+}
+
+func TestTerminals(t *testing.T) {
+	const src = "let नमस्ते = १२३ + a_1\n"
+	expected := []tokenTest{
+		{1, token.KEYWORD, `let`},
+		{5, token.IDENT, `नमस्ते`},
+		{24, '=', ``},
+		{26, token.INT, `१२३`},
+		{36, '+', ``},
+		{38, token.IDENT, `a_1`},
+		{41, ';', "\n"},
+	}
+	s := Scanner{Syntax: &Syntax{
+		Keywords: []string{"let"},
+		Terminals: []Terminal{
+			{First: `\p{L}_`, Rest: `\p{L}\p{M}\p{Nd}_`},
+			{Tok: token.INT, First: `\x{0966}-\x{096f}`, Rest: `\x{0966}-\x{096f}`},
+		},
+	}}
+	fset := token.NewFileSet()
+	file := fset.AddFile("", -1, len(src))
+	s.Init(file, []byte(src), nil, 0)
+	for i := 0; ; i++ {
+		c := s.Scan()
+		if c.Tok == token.EOF {
+			if i != len(expected) {
+				t.Fatalf("len(expected) != i: %d, %d\n", len(expected), i)
+			}
+			break
+		}
+		expect := Token{Tok: expected[i].Kind, Pos: expected[i].Pos, Lit: expected[i].Literal}
+		if c != expect {
+			t.Fatal("Scan failed:", c, expect)
+		}
+	}
+
+	classes := []struct {
+		class string
+		in    string
+		notIn string
+	}{
+		{"a", "a", "b"},
+		{`\d`, "09", "a٣"},
+		{`^a`, "b\n中", "a"},
+		{`\p{Han}a-c`, "中bc", "d"},
+		{`.`, ".", "a"},
+	}
+	for _, c := range classes {
+		cc := newCharClass(c.class)
+		for _, ch := range c.in {
+			if !cc.has(ch) {
+				t.Fatalf("newCharClass(%q) doesn't have %q", c.class, ch)
+			}
+		}
+		for _, ch := range c.notIn {
+			if cc.has(ch) {
+				t.Fatalf("newCharClass(%q) has %q", c.class, ch)
+			}
+		}
+	}
+	defer func() {
+		if e := recover(); e == nil {
+			t.Fatal("newCharClass: no panic")
+		}
+	}()
+	newCharClass(`\p{Unknown}`)
+}
+
+func TestKeepSpaces(t *testing.T) {
+	const src = "a:\n  b // c\n\tc\n"
+	expected := []tokenTest{
+		{1, token.IDENT, `a`},
+		{2, ':', ``},
+		{3, token.WHITESPACE, "\n  "},
+		{6, token.IDENT, `b`},
+		{7, token.WHITESPACE, ` `},
+		{8, token.COMMENT, `// c`},
+		{12, token.WHITESPACE, "\n\t"},
+		{14, token.IDENT, `c`},
+		{15, token.WHITESPACE, "\n"},
+	}
+	s := Scanner{Syntax: &Syntax{KeepSpaces: " \t\n"}}
+	fset := token.NewFileSet()
+	file := fset.AddFile("", -1, len(src))
+	s.Init(file, []byte(src), nil, ScanComments)
+	for i := 0; ; i++ {
+		c := s.Scan()
+		if c.Tok == token.EOF {
+			if i != len(expected) {
+				t.Fatalf("len(expected) != i: %d, %d\n", len(expected), i)
+			}
+			break
+		}
+		expect := Token{Tok: expected[i].Kind, Pos: expected[i].Pos, Lit: expected[i].Literal}
+		if c != expect {
+			t.Fatal("Scan failed:", c, expect)
+		}
+	}
+}
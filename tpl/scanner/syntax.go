@@ -0,0 +1,365 @@
+/*
+ * Copyright (c) 2026 The XGo Authors (xgo.dev). All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package scanner
+
+import (
+	"bytes"
+	"regexp/syntax"
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/goplus/xgo/tpl/token"
+	"github.com/goplus/xgo/tpl/types"
+)
+
+// -----------------------------------------------------------------------------
+
+// Syntax customizes the lexical rules of a Scanner, which mirror Go by
+// default. For example, a SQL-ish syntax can be:
+//
+//	&scanner.Syntax{
+//		Keywords:   []string{"SELECT", "FROM", "WHERE"},
+//		IgnoreCase: true,
+//		Operators:  []string{"::"},
+//		Comments:   []scanner.Comment{{Start: "--"}, {Start: "/*", End: "*/"}},
+//		Quotes: []scanner.Quote{
+//			{Open: "'", Escape: '\''},
+//			{Open: `"`, Tok: token.IDENT},
+//		},
+//	}
+type Syntax struct {
+	// Keywords are identifiers scanned as KEYWORD tokens instead of IDENT.
+	Keywords []string
+
+	// IgnoreCase makes Keywords case-insensitive, and the literal of a
+	// KEYWORD token is its spelling in Keywords.
+	IgnoreCase bool
+
+	// Operators are additional operators (like "::" or "->>"), scanned as
+	// OPERATOR tokens. Longer operators take precedence over shorter ones
+	// (including the default ones), and the default ones are ignored.
+	Operators []string
+
+	// Comments replace the default comment styles (//, # and /* */) if not
+	// nil.
+	Comments []Comment
+
+	// Quotes replace the default string and char literal syntaxes ("", ``
+	// and '') if not nil.
+	Quotes []Quote
+
+	// Terminals are tokens made of characters in character classes, like
+	// identifiers of a non-ASCII language. They take precedence over the
+	// default identifiers and numbers, and the earlier ones take precedence
+	// over the later ones.
+	Terminals []Terminal
+
+	// KeepSpaces are whitespace characters (like " \t" for an indentation
+	// sensitive syntax) scanned as WHITESPACE tokens instead of being
+	// skipped, where a run of them is a token. No semicolons are inserted
+	// if KeepSpaces contains newlines.
+	KeepSpaces string
+}
+
+// A Comment describes a comment style.
+type Comment struct {
+	Start string // like "--" or "/*"
+	End   string // like "*/", or "" if the comment ends at the end of line
+}
+
+// A Quote describes a literal enclosed in quotes, like a string literal. Its
+// token literal is the source text including the quotes.
+type Quote struct {
+	Open  string      // opening quote, like "'" or "$$"
+	Close string      // closing quote, the same as Open if empty
+	Tok   token.Token // token of the literals, STRING if zero
+
+	// Escape is the character (like '\\') that escapes the next character,
+	// or 0 if there is none. If Escape is the first character of the
+	// closing quote (like '\'' in SQL), a doubled closing quote stands for
+	// itself instead.
+	Escape byte
+
+	// Multiline allows the literals to span lines.
+	Multiline bool
+}
+
+// A Terminal describes a token made of characters in character classes. A
+// character class is written like a bracket expression of a regular
+// expression without the brackets, where Unicode classes and ranges of runes
+// are allowed, like `\p{L}_`, `\p{L}\p{M}\p{Nd}_` or `a-z\x{4e00}-\x{9fff}`.
+// See https://pkg.go.dev/regexp/syntax.
+type Terminal struct {
+	Tok   token.Token // token of the terminals, IDENT if zero
+	First string      // class of the first character
+	Rest  string      // class of the other characters, "" if there is none
+}
+
+type terminal struct {
+	tok   token.Token
+	first charClass
+	rest  charClass
+}
+
+// charClass is a sorted list of rune ranges [lo, hi] in pairs.
+type charClass []rune
+
+func newCharClass(class string) charClass {
+	if class == "" {
+		return nil
+	}
+	re, err := syntax.Parse("["+class+"]", syntax.Perl)
+	if err != nil {
+		panic("tpl/scanner: invalid character class `" + class + "`: " + err.Error())
+	}
+	switch re.Op {
+	case syntax.OpCharClass:
+		return re.Rune
+	case syntax.OpLiteral:
+		return charClass{re.Rune[0], re.Rune[0]}
+	case syntax.OpAnyCharNotNL:
+		return charClass{0, '\n' - 1, '\n' + 1, unicode.MaxRune}
+	case syntax.OpAnyChar:
+		return charClass{0, unicode.MaxRune}
+	}
+	panic("tpl/scanner: invalid character class `" + class + "`")
+}
+
+func (p charClass) has(ch rune) bool {
+	n := len(p) / 2
+	i := sort.Search(n, func(i int) bool {
+		return p[2*i+1] >= ch
+	})
+	return i < n && p[2*i] <= ch
+}
+
+func (s *Scanner) initSyntax() {
+	syn := s.Syntax
+	s.keywords, s.ops, s.terms, s.spaces = nil, nil, nil, ""
+	if syn == nil {
+		return
+	}
+	s.spaces = syn.KeepSpaces
+	if syn.Keywords != nil {
+		s.keywords = make(map[string]string, len(syn.Keywords))
+		for _, kw := range syn.Keywords {
+			if syn.IgnoreCase {
+				s.keywords[strings.ToLower(kw)] = kw
+			} else {
+				s.keywords[kw] = kw
+			}
+		}
+	}
+	for _, op := range syn.Operators {
+		if !isDefaultOp(op) {
+			s.ops = append(s.ops, op)
+		}
+	}
+	sort.SliceStable(s.ops, func(i, j int) bool {
+		return len(s.ops[i]) > len(s.ops[j])
+	})
+	for _, term := range syn.Terminals {
+		tok := term.Tok
+		if tok == 0 {
+			tok = token.IDENT
+		}
+		s.terms = append(s.terms, terminal{tok, newCharClass(term.First), newCharClass(term.Rest)})
+	}
+}
+
+func isDefaultOp(op string) (ret bool) {
+	if len(op) == 1 {
+		return token.Token(op[0]).Len() > 0
+	}
+	token.ForEach(0, func(tok token.Token, lit string) int {
+		if lit == op {
+			ret = true
+			return token.Break
+		}
+		return 0
+	})
+	return
+}
+
+func (s *Scanner) defaultComments() bool {
+	return s.Syntax == nil || s.Syntax.Comments == nil
+}
+
+func (s *Scanner) defaultQuotes() bool {
+	return s.Syntax == nil || s.Syntax.Quotes == nil
+}
+
+// keyword returns the keyword of the identifier lit, if it is one.
+func (s *Scanner) keyword(lit string) (kw string, ok bool) {
+	if s.keywords != nil {
+		if s.Syntax.IgnoreCase {
+			lit = strings.ToLower(lit)
+		}
+		kw, ok = s.keywords[lit]
+	}
+	return
+}
+
+func (s *Scanner) hasPrefix(prefix string) bool {
+	return prefix != "" && bytes.HasPrefix(s.src[s.offset:], []byte(prefix))
+}
+
+// skip skips n bytes.
+func (s *Scanner) skip(n int) {
+	for end := s.offset + n; s.offset < end && s.ch >= 0; {
+		s.next()
+	}
+}
+
+// scanSyntax scans kept spaces, a comment, a quoted literal, a terminal or an
+// operator of s.Syntax at the current position. It returns false if there is
+// none. A comment isn't scanned but a semicolon is returned if a semicolon
+// should be inserted before it.
+func (s *Scanner) scanSyntax(t *types.Token) bool {
+	syn := s.Syntax
+	if s.keepSpace(s.ch) {
+		offs := s.offset
+		for s.keepSpace(s.ch) {
+			s.next()
+		}
+		t.Tok, t.Lit = token.WHITESPACE, string(s.src[offs:s.offset])
+		return true
+	}
+	for _, c := range syn.Comments {
+		if s.hasPrefix(c.Start) {
+			if s.insertSemi && s.commentHasNewline(c) {
+				s.insertSemi = false // newline consumed
+				t.Tok, t.Lit = s.tokSEMICOLON(), "\n"
+				return true
+			}
+			t.Tok, t.Lit = token.COMMENT, s.scanSyntaxComment(c)
+			return true
+		}
+	}
+	for i := range syn.Quotes {
+		q := &syn.Quotes[i]
+		if s.hasPrefix(q.Open) {
+			t.Tok = q.Tok
+			if t.Tok == 0 {
+				t.Tok = token.STRING
+			}
+			t.Lit = s.scanQuote(q)
+			return true
+		}
+	}
+	for i := range s.terms {
+		if term := &s.terms[i]; term.first.has(s.ch) {
+			t.Tok, t.Lit = term.tok, s.scanTerminal(term)
+			if t.Tok == token.IDENT {
+				if kw, ok := s.keyword(t.Lit); ok {
+					t.Tok, t.Lit = token.KEYWORD, kw
+				}
+			}
+			return true
+		}
+	}
+	for _, op := range s.ops {
+		if s.hasPrefix(op) {
+			s.skip(len(op))
+			t.Tok, t.Lit = token.OPERATOR, op
+			return true
+		}
+	}
+	return false
+}
+
+// commentHasNewline reports whether the comment c at the current position
+// contains a newline, or isn't terminated.
+func (s *Scanner) commentHasNewline(c Comment) bool {
+	if c.End == "" {
+		return true
+	}
+	text := s.src[s.offset+len(c.Start):]
+	if i := bytes.Index(text, []byte(c.End)); i >= 0 {
+		return bytes.IndexByte(text[:i], '\n') >= 0
+	}
+	return true
+}
+
+func (s *Scanner) scanSyntaxComment(c Comment) string {
+	offs := s.offset
+	s.skip(len(c.Start))
+	if c.End == "" {
+		for s.ch != '\n' && s.ch >= 0 {
+			s.next()
+		}
+		return string(stripCR(s.src[offs:s.offset]))
+	}
+	for !s.hasPrefix(c.End) {
+		if s.ch < 0 {
+			s.error(offs, "comment not terminated")
+			return string(stripCR(s.src[offs:s.offset]))
+		}
+		s.next()
+	}
+	s.skip(len(c.End))
+	return string(stripCR(s.src[offs:s.offset]))
+}
+
+func (s *Scanner) keepSpace(ch rune) bool {
+	return s.spaces != "" && ch >= 0 && strings.ContainsRune(s.spaces, ch)
+}
+
+func (s *Scanner) scanTerminal(term *terminal) string {
+	offs := s.offset
+	s.next()
+	for term.rest.has(s.ch) {
+		s.next()
+	}
+	return string(s.src[offs:s.offset])
+}
+
+func (s *Scanner) scanQuote(q *Quote) string {
+	offs := s.offset
+	close := q.Close
+	if close == "" {
+		close = q.Open
+	}
+	s.skip(len(q.Open))
+	for {
+		if s.hasPrefix(close) {
+			s.skip(len(close))
+			if q.Escape == 0 || q.Escape != close[0] || !s.hasPrefix(close) {
+				break
+			}
+			s.skip(len(close)) // doubled closing quote
+			continue
+		}
+		ch := s.ch
+		if ch < 0 || ch == '\n' && !q.Multiline {
+			s.error(offs, "literal not terminated")
+			break
+		}
+		s.next()
+		if q.Escape != 0 && ch == rune(q.Escape) && q.Escape != close[0] && s.ch >= 0 {
+			s.next()
+		}
+	}
+	lit := s.src[offs:s.offset]
+	if q.Multiline {
+		lit = stripCR(lit)
+	}
+	return string(lit)
+}
+
+// -----------------------------------------------------------------------------
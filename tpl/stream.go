@@ -0,0 +1,200 @@
+/*
+ * Copyright (c) 2026 The XGo Authors (xgo.dev). All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package tpl
+
+import (
+	"bufio"
+	"bytes"
+	"io"
+
+	"github.com/goplus/xgo/tpl/matcher"
+	"github.com/goplus/xgo/tpl/scanner"
+	"github.com/goplus/xgo/tpl/token"
+	"github.com/qiniu/x/errors"
+)
+
+// -----------------------------------------------------------------------------
+
+// streamChunkSize is the minimum size of the input read (and tokenized) at a
+// time by a Stream, which is made of whole lines.
+const streamChunkSize = 4096
+
+// A Stream matches records in an input stream one by one, where a record is
+// what the root rule (Doc) of the grammar matches. The input is read and
+// tokenized on demand, so it is never buffered entirely, which makes it
+// possible to parse large log streams or network protocols:
+//
+//	s := calc.NewStream("access.log", f, nil)
+//	for {
+//		rec, err := s.Next()
+//		if err == io.EOF {
+//			break
+//		}
+//		...
+//	}
+//
+// Input is read by whole lines, so a token can't span the lines read at
+// different times (like a multi-line raw string longer than 4KB).
+type Stream struct {
+	doc      *matcher.Var
+	skips    []token.Token
+	conf     *Config
+	s        Scanner
+	r        *bufio.Reader
+	fset     *token.FileSet
+	filename string
+
+	files []*token.File // files of the input chunks having unmatched tokens
+	toks  []*Token      // unmatched tokens
+	end   token.Pos     // end of the input read
+	line  int           // line of the next input chunk
+	eof   bool
+	err   error
+}
+
+// NewStream creates a Stream to match records read from r.
+func (p *Compiler) NewStream(filename string, r io.Reader, conf *Config) *Stream {
+	if conf == nil {
+		conf = &Config{}
+	}
+	s := conf.Scanner
+	if s == nil {
+		s = &scanner.Scanner{Syntax: conf.Syntax}
+	}
+	fset := conf.Fset
+	if fset == nil {
+		fset = token.NewFileSet()
+	}
+	return &Stream{
+		doc: p.Doc, skips: p.Skips, conf: conf, s: s, r: bufio.NewReader(r),
+		fset: fset, filename: filename, line: 1,
+	}
+}
+
+// Fset returns the file set of positions of tokens. Positions of tokens in a
+// record are valid until more input is read by Next.
+func (p *Stream) Fset() *token.FileSet {
+	return p.fset
+}
+
+// Next matches the next record. It returns io.EOF if there are no more
+// records, and the error of reading or matching if any, after which the
+// Stream can't be used any longer. Errors recovered by rules with recovery
+// clauses (see ast.Recover) are returned along with the record instead, and
+// the Stream can be used after them.
+func (p *Stream) Next() (result any, err error) {
+	if p.err != nil {
+		return nil, p.err
+	}
+	for {
+		if len(p.toks) > 0 {
+			var n int
+			var done bool
+			var recovered []error
+			if n, result, err, recovered, done = p.match(); done {
+				if err == nil && n == 0 {
+					t := p.toks[0]
+					err = &Error{Fset: p.fset, Pos: t.Pos, Msg: "unexpected token: " + t.String()}
+				}
+				if err != nil {
+					p.err = err
+					return nil, err
+				}
+				p.toks = p.toks[n:]
+				return result, errors.List(recovered).ToError()
+			}
+		} else if p.eof {
+			return nil, io.EOF
+		}
+		if err = p.read(); err != nil {
+			p.err = err
+			return nil, err
+		}
+	}
+}
+
+// match matches a record in the unmatched tokens. It isn't done if more
+// tokens may change the result.
+func (p *Stream) match() (n int, result any, err error, recovered []error, done bool) {
+	toks := p.toks
+	ctx := matcher.NewContext(p.fset, p.end, toks)
+	ctx.MemoSize = p.conf.MemoSize
+	ctx.OnMatch, ctx.Tracer, ctx.Skips = p.conf.OnMatch, p.conf.Tracer, p.skips
+	n, result, err = p.doc.Match(toks, ctx)
+	if err == nil {
+		n += ctx.Skipped(toks[n:])
+	}
+	if e, ok := err.(*Error); ok && e.Dyn {
+		return n, nil, err, nil, true
+	}
+	if !p.eof && (ctx.FarthestLeft() == 0 || err == nil && n == len(toks)) {
+		return // no more tokens were seen
+	}
+	if err != nil {
+		if e := ctx.FarthestError(); e != nil {
+			err = e
+		}
+	}
+	return n, result, err, ctx.Errors, true
+}
+
+// read reads and tokenizes the next input chunk.
+func (p *Stream) read() error {
+	var b []byte
+	for len(b) < streamChunkSize {
+		line, err := p.r.ReadBytes('\n')
+		b = append(b, line...)
+		if err != nil {
+			if err != io.EOF {
+				return err
+			}
+			p.eof = true
+			break
+		}
+	}
+	p.removeFiles()
+	f := p.fset.AddFile(p.filename, -1, len(b))
+	f.AddLineColumnInfo(0, p.filename, p.line, 1)
+	p.line += bytes.Count(b, []byte{'\n'})
+	p.files = append(p.files, f)
+	p.end = token.Pos(f.Base() + len(b))
+	p.s.Init(f, b, p.conf.ScanErrorHandler, p.conf.ScanMode)
+	for {
+		t := p.s.Scan()
+		if t.Tok == token.EOF {
+			break
+		}
+		p.toks = append(p.toks, &t)
+	}
+	return nil
+}
+
+// removeFiles removes files of the input chunks whose tokens are all matched
+// from the file set.
+func (p *Stream) removeFiles() {
+	i := 0
+	for _, f := range p.files {
+		if len(p.toks) > 0 && p.toks[0].Pos <= token.Pos(f.Base()+f.Size()) {
+			break
+		}
+		p.fset.RemoveFile(f)
+		i++
+	}
+	p.files = p.files[i:]
+}
+
+// -----------------------------------------------------------------------------
@@ -63,6 +63,10 @@ const (
 	STRING // "abc"
 	RAT    // 3r, 3.4r
 	UNIT   // 1m, 2.3s, 3ms, 4us, 5ns, 6.5m, 7h, 8d, 9w, 10y
+
+	KEYWORD    // select (see scanner.Syntax.Keywords)
+	OPERATOR   // :: (see scanner.Syntax.Operators)
+	WHITESPACE // \t\t (see scanner.Syntax.KeepSpaces)
 	literal_end
 
 	ADD = '+'
@@ -154,6 +158,10 @@ var tokens = [...]string{
 	RAT:    "RAT",
 	UNIT:   "UNIT",
 
+	KEYWORD:    "KEYWORD",
+	OPERATOR:   "OPERATOR",
+	WHITESPACE: "WHITESPACE",
+
 	ADD: "+",
 	SUB: "-",
 	MUL: "*",
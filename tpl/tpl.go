@@ -46,6 +46,9 @@ func ShowConflict(f bool) int {
 func onConflictHidden(fset *token.FileSet, c *ast.Choice, firsts [][]any, i, at int) {
 }
 
+func onWarningHidden(fset *token.FileSet, pos token.Pos, msg string) {
+}
+
 // -----------------------------------------------------------------------------
 
 func relocatePos(ePos *token.Position, filename string, line, col int) {
@@ -85,16 +88,24 @@ func Relocate(err error, filename string, line, col int) error {
 // Compiler represents a TPL compiler.
 type Compiler struct {
 	cl.Result
+
+	// Grammar is the source of the grammar, which can be rendered in EBNF
+	// or JSON (see ast.File.EBNF and ast.File.JSON).
+	Grammar *ast.File
 }
 
 // New creates a new TPL compiler.
 // params: ruleName1, retProc1, ..., ruleNameN, retProcN
+// A condition of semantic predicates is also passed as a retProc, whose name
+// is `rule/N` for the N-th predicate of the rule (see ast.Rule.Predicates),
+// and so is a Compiler used by `use NAME`, whose name is `use/NAME`.
 func New(src any, params ...any) (ret Compiler, err error) {
 	conf := &cl.Config{
 		RetProcs: retProcs(params),
 	}
 	if !showConflict {
 		conf.OnConflict = onConflictHidden
+		conf.OnWarning = onWarningHidden
 	}
 	return FromFile(nil, "", src, conf)
 }
@@ -113,8 +124,16 @@ func NewEx(src any, filename string, line, col int, params ...any) (ret Compiler
 				cl.LogConflict(pos, firsts, i, at)
 			}
 		}
+		conf.OnWarning = func(fset *token.FileSet, pos token.Pos, msg string) {
+			if showConflict {
+				ePos := fset.Position(pos)
+				relocatePos(&ePos, filename, line, col)
+				cl.LogWarning(ePos, msg)
+			}
+		}
 	} else {
 		conf.OnConflict = onConflictHidden
+		conf.OnWarning = onWarningHidden
 	}
 	ret, err = FromFile(nil, "", src, conf)
 	if err != nil {
@@ -134,6 +153,7 @@ func FromFile(fset *token.FileSet, filename string, src any, conf *cl.Config) (r
 		return
 	}
 	ret.Result, err = cl.NewEx(conf, fset, f)
+	ret.Grammar = f
 	return
 }
 
@@ -147,7 +167,14 @@ func retProcs(params []any) map[string]any {
 	}
 	ret := make(map[string]any, n>>1)
 	for i := 0; i < n; i += 2 {
-		ret[params[i].(string)] = params[i+1]
+		v := params[i+1]
+		switch g := v.(type) {
+		case Compiler:
+			v = g.Result
+		case *Compiler:
+			v = &g.Result
+		}
+		ret[params[i].(string)] = v
 	}
 	return ret
 }
@@ -160,6 +187,10 @@ type Error = matcher.Error
 // A Token is a lexical unit returned by Scan.
 type Token = types.Token
 
+// Span represents the source span of a match, which is passed to RetProcs
+// taking it (see matcher.SpanRetProc).
+type Span = matcher.Span
+
 // Scanner represents a TPL scanner.
 type Scanner interface {
 	Scan() Token
@@ -172,6 +203,22 @@ type Config struct {
 	ScanErrorHandler scanner.ErrorHandler
 	ScanMode         scanner.Mode
 	Fset             *token.FileSet
+
+	// Syntax customizes the lexical rules of the default scanner (see
+	// scanner.Syntax). It is ignored if Scanner is not nil.
+	Syntax *scanner.Syntax
+
+	// MemoSize is the maximum number of memoized matches of rules (see
+	// matcher.Context.MemoSize), like matcher.DefaultMemoSize. Zero disables
+	// memoization.
+	MemoSize int
+
+	// OnMatch is called, if not nil, when a rule or an alternative matches
+	// (see matcher.Context.OnMatch).
+	OnMatch func(m matcher.Matcher, i int)
+
+	// Tracer traces matching of rules if not nil (see matcher.NewTracer).
+	Tracer matcher.Tracer
 }
 
 // ParseExpr parses an expression.
@@ -182,26 +229,26 @@ func (p *Compiler) ParseExpr(x string, conf *Config) (result any, err error) {
 // ParseExprFrom parses an expression from a file.
 func (p *Compiler) ParseExprFrom(filename string, src any, conf *Config) (result any, err error) {
 	ms, result, err := p.Match(filename, src, conf)
-	if err != nil {
+	if ms.Toks == nil { // failed
 		return
 	}
 	if len(ms.Toks) == ms.N || isEOL(ms.Toks[ms.N].Tok) {
 		return
 	}
-	t := ms.Next()
-	err = ms.Ctx.NewErrorf(t.Pos, "unexpected token: %v", t)
+	err = ms.recovered(ms.unexpected())
 	return
 }
 
-// Parse parses a source file.
+// Parse parses a source file. Errors recovered by rules with recovery
+// clauses (see ast.Recover) are returned as an errors.List if there are
+// more than one, along with the result.
 func (p *Compiler) Parse(filename string, src any, conf *Config) (result any, err error) {
 	ms, result, err := p.Match(filename, src, conf)
-	if err != nil {
+	if ms.Toks == nil { // failed
 		return
 	}
 	if len(ms.Toks) > ms.N {
-		t := ms.Next()
-		err = ms.Ctx.NewErrorf(t.Pos, "unexpected token: %v", t)
+		err = ms.recovered(ms.unexpected())
 	}
 	return
 }
@@ -213,6 +260,26 @@ type MatchState struct {
 	N    int
 }
 
+// farthestError returns the error at the farthest position where matching
+// failed (see matcher.Context.FarthestError), if it isn't before the
+// position where matching stopped. total is the number of tokens.
+func (p *MatchState) farthestError(total int) error {
+	ctx := p.Ctx
+	if e := ctx.FarthestError(); e != nil && ctx.FarthestLeft() <= total-p.N {
+		return e
+	}
+	return nil
+}
+
+// unexpected returns the error of the unexpected tokens after the matched ones.
+func (p *MatchState) unexpected() error {
+	if e := p.farthestError(len(p.Toks)); e != nil {
+		return e
+	}
+	t := p.Next()
+	return p.Ctx.NewErrorf(t.Pos, "unexpected token: %v", t)
+}
+
 // Next returns the next token.
 func (p *MatchState) Next() *Token {
 	n := p.Ctx.Left
@@ -233,7 +300,7 @@ func (p *Compiler) Match(filename string, src any, conf *Config) (ms MatchState,
 	}
 	s := conf.Scanner
 	if s == nil {
-		s = new(scanner.Scanner)
+		s = &scanner.Scanner{Syntax: conf.Syntax}
 	}
 	fset := conf.Fset
 	if fset == nil {
@@ -254,15 +321,42 @@ func (p *Compiler) Match(filename string, src any, conf *Config) (ms MatchState,
 		toks = append(toks, &t)
 	}
 	ms.Ctx = matcher.NewContext(fset, token.Pos(f.Base()+len(b)), toks)
+	ms.Ctx.MemoSize = conf.MemoSize
+	ms.Ctx.OnMatch, ms.Ctx.Tracer, ms.Ctx.Skips = conf.OnMatch, conf.Tracer, p.Skips
 	ms.N, result, err = p.Doc.Match(toks, ms.Ctx)
+	if err == nil {
+		ms.N += ms.Ctx.Skipped(toks[ms.N:])
+	}
 	ms.Ctx.SetLastError(len(toks)-ms.N, err)
 	if err != nil {
+		if e, ok := err.(*Error); !ok || !e.Dyn {
+			if e := ms.farthestError(len(toks)); e != nil {
+				err = e
+			}
+		}
+		err = ms.recovered(err)
 		return
 	}
 	ms.Toks = toks
+	err = ms.recovered(nil)
 	return
 }
 
+// recovered returns the errors recovered by rules with recovery clauses (see
+// matcher.Context.Errors), followed by err if it isn't nil.
+func (p *MatchState) recovered(err error) error {
+	errs := p.Ctx.Errors
+	if len(errs) == 0 {
+		return err
+	}
+	list := make(errors.List, len(errs), len(errs)+1)
+	copy(list, errs)
+	if err != nil {
+		list = append(list, err)
+	}
+	return list.ToError()
+}
+
 // -----------------------------------------------------------------------------
 
 func isEOL(tok token.Token) bool {
@@ -334,21 +428,21 @@ retry:
 			}
 		}
 		if isPlain(result) {
-			fmt.Print(prefix, "[")
+			fmt.Fprint(w, prefix, "[")
 			for i, v := range result {
 				if i > 0 {
-					fmt.Print(" ")
+					fmt.Fprint(w, " ")
 				}
 				v, _ = scalar(v)
 				fmt.Fprint(w, v)
 			}
-			fmt.Print("]\n")
+			fmt.Fprint(w, "]\n")
 		} else {
-			fmt.Print(prefix, "[\n")
+			fmt.Fprint(w, prefix, "[\n")
 			for _, v := range result {
 				Fdump(w, v, prefix+indent, indent, omitSemi)
 			}
-			fmt.Print(prefix, "]\n")
+			fmt.Fprint(w, prefix, "]\n")
 		}
 	case nil:
 		fmt.Fprint(w, prefix, "nil\n")
@@ -480,6 +574,108 @@ func BinaryOpNR(in []any, fn func(op *Token, x, y any) any) any {
 	return ret
 }
 
+// OpLevel represents binary operators of the same precedence.
+type OpLevel struct {
+	Ops   []string
+	Right bool // right associative
+}
+
+// Left returns a level of left associative binary operators, like "+" and "-".
+func Left(ops ...string) OpLevel {
+	return OpLevel{Ops: ops}
+}
+
+// Right returns a level of right associative binary operators, like "^".
+func Right(ops ...string) OpLevel {
+	return OpLevel{Ops: ops, Right: true}
+}
+
+type opInfo struct {
+	prec  int
+	right bool
+}
+
+// Precedence represents a precedence table of binary operators. It converts
+// the matching result of a flat (X % op) as if it were matched by layered
+// rules of the operators:
+//
+//	prec := tpl.NewPrecedence(tpl.Left("+", "-"), tpl.Left("*", "/"), tpl.Right("^"))
+//	cl := tpl`
+//	expr = operand % ("+" | "-" | "*" | "/" | "^") => {
+//		return prec.BinaryExpr(self)
+//	}
+//	operand = INT => {
+//		return tpl.BasicLit(self)
+//	}
+//	`!
+type Precedence struct {
+	ops map[string]opInfo
+}
+
+// NewPrecedence creates a precedence table from levels of binary operators,
+// from the lowest precedence to the highest.
+func NewPrecedence(levels ...OpLevel) *Precedence {
+	ops := make(map[string]opInfo)
+	for i, level := range levels {
+		for _, op := range level.Ops {
+			ops[op] = opInfo{i + 1, level.Right}
+		}
+	}
+	return &Precedence{ops}
+}
+
+// BinaryOp converts the matching result of (X % op) by calling fn on each
+// binary operation, in the order given by the precedence table.
+func (p *Precedence) BinaryOp(in []any, fn func(op *Token, x, y any) any) any {
+	next := in[1].([]any)
+	xs := make([]any, len(next)+1)
+	ops := make([]*Token, len(next))
+	xs[0] = in[0]
+	for i, v := range next {
+		v := v.([]any)
+		ops[i], xs[i+1] = v[0].(*Token), v[1]
+	}
+	k := 0
+	return p.climb(xs, ops, &k, 1, fn)
+}
+
+// climb folds the operations from the k-th operand whose operators have
+// precedence not lower than minPrec.
+func (p *Precedence) climb(xs []any, ops []*Token, k *int, minPrec int, fn func(op *Token, x, y any) any) any {
+	ret := xs[*k]
+	for *k < len(ops) {
+		op := ops[*k]
+		info, ok := p.ops[op.String()]
+		if !ok {
+			panic(toErr("unknown binary operator "+op.String(), op))
+		}
+		if info.prec < minPrec {
+			break
+		}
+		*k++
+		prec := info.prec + 1
+		if info.right {
+			prec = info.prec
+		}
+		y := p.climb(xs, ops, k, prec, fn)
+		ret = fncall(fn, op, ret, y)
+	}
+	return ret
+}
+
+// BinaryExpr converts the matching result of (X % op) to a binary expression
+// by the precedence table.
+func (p *Precedence) BinaryExpr(in []any) ast.Expr {
+	return p.BinaryOp(in, func(op *Token, x, y any) any {
+		return &ast.BinaryExpr{
+			X:     x.(ast.Expr),
+			OpPos: op.Pos,
+			Op:    op.Tok,
+			Y:     y.(ast.Expr),
+		}
+	}).(ast.Expr)
+}
+
 func fncall(fn func(op *Token, x, y any) any, op *Token, x, y any) any {
 	defer func() {
 		if e := recover(); e != nil {
@@ -0,0 +1,485 @@
+/*
+ * Copyright (c) 2026 The XGo Authors (xgo.dev). All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package tpl_test
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"testing"
+	"testing/iotest"
+
+	"github.com/goplus/xgo/tpl"
+	"github.com/goplus/xgo/tpl/ast"
+	"github.com/goplus/xgo/tpl/matcher"
+	"github.com/goplus/xgo/tpl/scanner"
+	"github.com/goplus/xgo/tpl/token"
+)
+
+func exprString(e ast.Expr) string {
+	switch e := e.(type) {
+	case *ast.BinaryExpr:
+		return "(" + exprString(e.X) + " " + e.Op.String() + " " + exprString(e.Y) + ")"
+	case *ast.BasicLit:
+		return e.Value
+	}
+	return "?"
+}
+
+func TestPrecedence(t *testing.T) {
+	prec := tpl.NewPrecedence(tpl.Left("=="), tpl.Left("+", "-"), tpl.Left("*", "/"), tpl.Right("^"))
+	c, err := tpl.New(`
+expr = operand % ("==" | "+" | "-" | "*" | "/" | "^")
+operand = INT
+`, "expr", func(self []any) any {
+		return prec.BinaryExpr(self)
+	}, "operand", func(self any) any {
+		return tpl.BasicLit(self)
+	})
+	if err != nil {
+		t.Fatal("tpl.New:", err)
+	}
+	for src, want := range map[string]string{
+		"1":                 "1",
+		"1 - 2 - 3":         "((1 - 2) - 3)",
+		"1 + 2 * 3 == 7":    "((1 + (2 * 3)) == 7)",
+		"2 ^ 3 ^ 2":         "(2 ^ (3 ^ 2))",
+		"1 * 2 ^ 3 ^ 4 - 5": "((1 * (2 ^ (3 ^ 4))) - 5)",
+	} {
+		ret, err := c.ParseExpr(src, nil)
+		if err != nil {
+			t.Fatal("ParseExpr:", src, err)
+		}
+		if got := exprString(ret.(ast.Expr)); got != want {
+			t.Fatal("ParseExpr:", src, got)
+		}
+	}
+
+	var ops bytes.Buffer
+	ret := tpl.NewPrecedence(tpl.Left("+"), tpl.Left("*")).BinaryOp([]any{1, []any{
+		[]any{&tpl.Token{Tok: token.ADD}, 2},
+		[]any{&tpl.Token{Tok: token.MUL}, 3},
+	}}, func(op *tpl.Token, x, y any) any {
+		ops.WriteString(op.String())
+		if op.Tok == token.ADD {
+			return x.(int) + y.(int)
+		}
+		return x.(int) * y.(int)
+	})
+	if ret != 7 || ops.String() != "*+" {
+		t.Fatal("BinaryOp:", ret, ops.String())
+	}
+}
+
+func TestPrecedenceUnknownOp(t *testing.T) {
+	prec := tpl.NewPrecedence(tpl.Left("+"))
+	c, err := tpl.New(`expr = operand % ("+" | "-")
+operand = INT
+`, "expr", func(self []any) any {
+		return prec.BinaryExpr(self)
+	}, "operand", func(self any) any {
+		return tpl.BasicLit(self)
+	})
+	if err != nil {
+		t.Fatal("tpl.New:", err)
+	}
+	if _, err = c.ParseExpr("1 - 2", nil); err == nil || err.Error() != "1:3: unknown binary operator -" {
+		t.Fatal("ParseExpr:", err)
+	}
+}
+
+func TestMatchError(t *testing.T) {
+	c, err := tpl.New(`
+expr = term % "+"
+term = INT | "(" expr ")"
+`)
+	if err != nil {
+		t.Fatal("tpl.New:", err)
+	}
+	for src, want := range map[string]string{
+		"1 + (2 + )": "1:10: expect `INT` or `(`, but got `)` (in expr > term > expr > term)",
+		"1 + (2 3)":  "1:8: expect `+` or `)`, but got `3` (in expr > term > expr)",
+		"1 +":        "1:4: expect `INT` or `(`, but got `EOF` (in expr > term)",
+	} {
+		_, err := c.ParseExpr(src, nil)
+		if err == nil || err.Error() != want {
+			t.Fatal("ParseExpr:", src, err)
+		}
+		if e, ok := err.(*tpl.Error); !ok || len(e.Expected) != 2 {
+			t.Fatal("ParseExpr:", src, err)
+		}
+	}
+}
+
+func TestMemo(t *testing.T) {
+	calls := 0
+	c, err := tpl.New(`
+expr = term "+" expr | term "-" expr | term
+term = "(" expr ")" | INT
+`, "term", func(self any) any {
+		calls++
+		return self
+	})
+	if err != nil {
+		t.Fatal("tpl.New:", err)
+	}
+	const depth = 8
+	src := strings.Repeat("(", depth) + "1" + strings.Repeat(")", depth)
+	if _, err = c.ParseExpr(src, nil); err != nil {
+		t.Fatal("ParseExpr:", err)
+	}
+	if calls < 1000 {
+		t.Fatal("no memo:", calls)
+	}
+	calls = 0
+	if _, err = c.ParseExpr(src, &tpl.Config{MemoSize: matcher.DefaultMemoSize}); err != nil {
+		t.Fatal("ParseExpr:", err)
+	}
+	if calls != depth+1 {
+		t.Fatal("memo:", calls)
+	}
+}
+
+func TestPredicate(t *testing.T) {
+	c, err := tpl.New(`
+doc = +item
+item = INT !{ false } &{ true } ":" INT &{ len(self) > 0 }
+`, "item/1", func(self []any) bool {
+		return self[0].(*tpl.Token).Lit == "0"
+	}, "item/2", func(self []any) bool {
+		return len(self) == 2
+	}, "item/3", func(self []any) bool {
+		return self[4].(*tpl.Token).Lit != "0"
+	})
+	if err != nil {
+		t.Fatal("tpl.New:", err)
+	}
+	if _, err = c.ParseExpr("1: 2 3: 4", nil); err != nil {
+		t.Fatal("ParseExpr:", err)
+	}
+	if _, err = c.ParseExpr("1: 0", nil); err == nil || err.Error() != "1:5: predicate failed" {
+		t.Fatal("ParseExpr:", err)
+	}
+
+	if _, err = tpl.New(`doc = INT &{ true }`); err == nil || err.Error() != "1:11: no condition of predicate `doc/1`" {
+		t.Fatal("tpl.New:", err)
+	}
+}
+
+func TestUse(t *testing.T) {
+	base, err := tpl.New(`
+expr = term % "+"
+term = INT
+`)
+	if err != nil {
+		t.Fatal("tpl.New:", err)
+	}
+	c, err := tpl.New(`
+use base
+term = FLOAT
+`, "use/base", base)
+	if err != nil {
+		t.Fatal("tpl.New:", err)
+	}
+	// doc is the local term, and expr of base keeps using the term of base
+	if _, err = c.ParseExpr("1.5", nil); err != nil {
+		t.Fatal("ParseExpr:", err)
+	}
+	if c.Rules["expr"] != base.Rules["expr"] || c.Rules["term"] == base.Rules["term"] {
+		t.Fatal("rules:", c.Rules)
+	}
+
+	c, err = tpl.New(`use base`, "use/base", &base)
+	if err != nil || c.Doc != base.Doc {
+		t.Fatal("tpl.New:", err)
+	}
+	if _, err = c.ParseExpr("1 + 2", nil); err != nil {
+		t.Fatal("ParseExpr:", err)
+	}
+
+	other, _ := tpl.New(`term = IDENT`)
+	if _, err = tpl.New(`use base, other`, "use/base", base, "use/other", other); err == nil ||
+		err.Error() != "1:11: rule `term` is defined in both `base` and `other`" {
+		t.Fatal("tpl.New:", err)
+	}
+	if _, err = tpl.New(`use base`); err == nil || err.Error() != "1:5: grammar `base` is undefined" {
+		t.Fatal("tpl.New:", err)
+	}
+}
+
+func TestSyntax(t *testing.T) {
+	c, err := tpl.New(`
+doc = "SELECT" (IDENT % ",") "FROM" IDENT ?("WHERE" IDENT "::" IDENT "=" STRING)
+`)
+	if err != nil {
+		t.Fatal("tpl.New:", err)
+	}
+	conf := &tpl.Config{ScanMode: scanner.NoInsertSemis, Syntax: &scanner.Syntax{
+		Keywords:   []string{"SELECT", "FROM", "WHERE"},
+		IgnoreCase: true,
+		Operators:  []string{"::"},
+		Comments:   []scanner.Comment{{Start: "--"}},
+		Quotes:     []scanner.Quote{{Open: "'", Escape: '\''}, {Open: `"`, Tok: token.IDENT}},
+	}}
+	ret, err := c.ParseExpr(`select a, "b c" from t -- comment
+where a::text = 'it''s'`, conf)
+	if err != nil {
+		t.Fatal("ParseExpr:", err)
+	}
+	if s := fmt.Sprint(ret); s != `[SELECT [a [[, "b c"]]] FROM t [WHERE a :: text = 'it''s']]` {
+		t.Fatal("ParseExpr:", s)
+	}
+	// keywords are not identifiers
+	if _, err = c.ParseExpr(`select from from t`, conf); err == nil || err.Error() != "1:8: expect `IDENT`, but got `FROM` (in doc)" {
+		t.Fatal("ParseExpr:", err)
+	}
+}
+
+func TestStream(t *testing.T) {
+	c, err := tpl.New(`
+doc = "begin" ";" *(INT ";") "end" ";" | INT INT ";" | INT ";"
+`)
+	if err != nil {
+		t.Fatal("tpl.New:", err)
+	}
+	var b strings.Builder
+	for i := range 2000 {
+		fmt.Fprintf(&b, "%d\n", i)
+	}
+	b.WriteString("begin\n")
+	for i := range 2000 {
+		fmt.Fprintf(&b, "%d\n", i)
+	}
+	b.WriteString("end\n1 2\n3 4 5\n")
+
+	s := c.NewStream("a.log", iotest.OneByteReader(strings.NewReader(b.String())), nil)
+	for i := range 2000 {
+		ret, err := s.Next()
+		if err != nil {
+			t.Fatal("Next:", i, err)
+		}
+		if lit := ret.([]any)[0].(*tpl.Token).Lit; lit != strconv.Itoa(i) {
+			t.Fatal("Next:", i, lit)
+		}
+	}
+	nfile := 0
+	s.Fset().Iterate(func(f *token.File) bool {
+		nfile++
+		return true
+	})
+	if nfile > 2 {
+		t.Fatal("files of matched tokens are not removed:", nfile)
+	}
+	ret, err := s.Next()
+	if err != nil || len(ret.([]any)[2].([]any)) != 2000 {
+		t.Fatal("Next:", err)
+	}
+	if ret, err = s.Next(); err != nil || len(ret.([]any)) != 3 {
+		t.Fatal("Next:", ret, err)
+	}
+	if _, err = s.Next(); err == nil || err.Error() != "a.log:4004:5: expect `;`, but got `5` (in doc)" {
+		t.Fatal("Next:", err)
+	}
+	if _, err2 := s.Next(); err2 != err {
+		t.Fatal("Next:", err2)
+	}
+
+	s = c.NewStream("", strings.NewReader("1\n2"), nil)
+	for range 2 {
+		if _, err = s.Next(); err != nil {
+			t.Fatal("Next:", err)
+		}
+	}
+	if _, err = s.Next(); err != io.EOF {
+		t.Fatal("Next:", err)
+	}
+}
+
+func TestEBNF(t *testing.T) {
+	base, _ := tpl.New(`term = INT`)
+	c, err := tpl.New(`
+use base
+
+doc = stmt % ";"
+stmt = ?"-" +term &{ true } | IDENT ++ '(' (INT | FLOAT) ')' | *("a" "b")
+`, "use/base", base, "stmt/1", func([]any) bool { return true })
+	if err != nil {
+		t.Fatal("tpl.New:", err)
+	}
+	if s := c.Grammar.EBNF(); s != `doc = stmt { ";" stmt } .
+stmt = [ "-" ] term { term } | IDENT "(" ( INT | FLOAT ) ")" | { "a" "b" } .
+` {
+		t.Fatal("EBNF:", s)
+	}
+	b, err := c.Grammar.JSON()
+	if err != nil {
+		t.Fatal("JSON:", err)
+	}
+	if s := string(b); !strings.HasPrefix(s, `{"rules":[{"expr":{"sep":{"type":"token","value":";"},"type":"list","x":{"name":"stmt","type":"ident"}},"name":"doc"}`) ||
+		!strings.Contains(s, `{"not":false,"type":"predicate"}`) || !strings.HasSuffix(s, `"uses":["base"]}`) {
+		t.Fatal("JSON:", s)
+	}
+}
+
+func TestSpan(t *testing.T) {
+	var spans []tpl.Span
+	c, err := tpl.New(`
+expr = term % "+"
+term = INT | "(" expr ")"
+`, "term", func(self any, span tpl.Span) any {
+		spans = append(spans, span)
+		return self
+	}, "expr", func(self []any, span tpl.Span) any {
+		if len(self[1].([]any)) > 1 {
+			tpl.Panic(span.End, "too many operands")
+		}
+		return self
+	})
+	if err != nil {
+		t.Fatal("tpl.New:", err)
+	}
+	if _, err = c.ParseExpr("1 + (2 + 3)", nil); err != nil {
+		t.Fatal("ParseExpr:", err)
+	}
+	// positions are 1-based offsets (file base is 1)
+	if s := fmt.Sprint(spans); s != "[{1 2} {6 7} {10 11} {5 12}]" {
+		t.Fatal("spans:", s)
+	}
+	if _, err = c.ParseExpr("1 + 2 + 3", nil); err == nil || err.Error() != "1:10: too many operands" {
+		t.Fatal("ParseExpr:", err)
+	}
+}
+
+func TestRecover(t *testing.T) {
+	c, err := tpl.New(`
+stmts = *stmt
+
+stmt = IDENT "=" INT ";" recover ";"
+`, "stmts", func(self []any) any {
+		var names []string
+		for _, stmt := range self {
+			if e, ok := stmt.(*tpl.Error); ok {
+				names = append(names, "<"+e.Msg+">")
+			} else {
+				names = append(names, stmt.([]any)[0].(*tpl.Token).Lit)
+			}
+		}
+		return names
+	})
+	if err != nil {
+		t.Fatal("tpl.New:", err)
+	}
+	ret, err := c.Parse("a.txt", "a = 1\nb = c d\nc = 3\nd 4\ne = 5\nf =", nil)
+	if err == nil || err.Error() != `a.txt:2:5: expect `+"`INT`, but got `c`"+` (in stmts > stmt)
+a.txt:4:3: expect `+"`=`, but got `4`"+` (in stmts > stmt)
+a.txt:6:4: expect `+"`INT`, but got `EOF`"+` (in stmts > stmt)` {
+		t.Fatal("Parse:", err)
+	}
+	if fmt.Sprint(ret) != "[a <expect `INT`, but got `c`> c <expect `=`, but got `4`> e <expect `INT`, but got `EOF`>]" {
+		t.Fatal("Parse:", ret)
+	}
+
+	// a rule failing without matching any tokens isn't recovered
+	if _, err = c.Parse("", "a = 1\n1", nil); err == nil || err.Error() != "2:1: expect `IDENT`, but got `1` (in stmts > stmt)" {
+		t.Fatal("Parse:", err)
+	}
+
+	if _, err = tpl.New(`doc = INT recover ""`); err == nil || err.Error() != `1:19: invalid sync token ""` {
+		t.Fatal("tpl.New:", err)
+	}
+}
+
+func TestTrace(t *testing.T) {
+	c, err := tpl.New(`
+stmt = IDENT "=" expr
+
+expr = INT | IDENT
+`)
+	if err != nil {
+		t.Fatal("tpl.New:", err)
+	}
+	var b strings.Builder
+	_, err = c.ParseExpr("x = ;", &tpl.Config{Tracer: matcher.NewTracer(&b, false)})
+	if err == nil {
+		t.Fatal("ParseExpr: no error")
+	}
+	if ret := b.String(); ret != "stmt at 1:1 `x`\n  expr at 1:5 `;`\n  expr: fail, expect `expr`, but got `;`\nstmt: fail, expect `expr`, but got `;`\n" {
+		t.Fatal("trace:", ret)
+	}
+	b.Reset()
+	if _, err = c.ParseExpr("x = 1", &tpl.Config{Tracer: matcher.NewTracer(&b, true)}); err != nil {
+		t.Fatal("ParseExpr:", err)
+	}
+	if ret := b.String(); ret != "stmt at 1:1 `x` {\n  expr at 1:5 `1` {\n  } expr: ok, 1 token(s)\n} stmt: ok, 3 token(s)\n" {
+		t.Fatal("trace:", ret)
+	}
+}
+
+func TestSkip(t *testing.T) {
+	c, err := tpl.New(`
+skip WHITESPACE
+noskip indent
+
+doc = *(indent stmt ";")
+
+indent = ?WHITESPACE
+
+stmt = IDENT "=" INT % "+"
+
+name = IDENT ++ "!"
+`, "doc", func(self []any) any {
+		var stmts []string
+		for _, item := range self {
+			item := item.([]any)
+			stmts = append(stmts, fmt.Sprint(item[0], ":", item[1]))
+		}
+		return stmts
+	}, "indent", func(self any) any {
+		if t, ok := self.(*tpl.Token); ok {
+			return len(t.Lit)
+		}
+		return 0
+	}, "stmt", func(self []any) any {
+		return self[0].(*tpl.Token).Lit
+	})
+	if err != nil {
+		t.Fatal("tpl.New:", err)
+	}
+	conf := &tpl.Config{Syntax: &scanner.Syntax{KeepSpaces: " \t"}}
+	ret, err := c.Parse("", "a = 1 + 2\n  b=3\n\tc =  4 + 5 + 6 \n", conf)
+	if err != nil || fmt.Sprint(ret) != "[0:a 2:b 1:c]" {
+		t.Fatal("Parse:", ret, err)
+	}
+	if _, err = c.Parse("", "a = 1\n  b\n", conf); err == nil || err.Error() != "2:4: expect `=`, but got `\n` (in doc > stmt)" {
+		t.Fatal("Parse:", err)
+	}
+
+	name := c
+	name.Doc = c.Rules["name"]
+	if _, err = name.ParseExpr("a!", conf); err != nil {
+		t.Fatal("ParseExpr:", err)
+	}
+	if _, err = name.ParseExpr("a !", conf); err == nil || err.Error() != "1:2: not adjoin" {
+		t.Fatal("ParseExpr:", err)
+	}
+
+	if _, err = tpl.New("skip SEMI\nnoskip x\ndoc = INT"); err == nil || err.Error() != "1:6: `SEMI` is not a token\n2:8: rule `x` is undefined" {
+		t.Fatal("tpl.New:", err)
+	}
+}
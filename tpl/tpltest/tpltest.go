@@ -0,0 +1,400 @@
+/*
+ * Copyright (c) 2026 The XGo Authors (xgo.dev). All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package tpltest provides helpers to test grammars: table-driven matching
+// cases, golden files of matching results, and coverage of rules and
+// alternatives.
+package tpltest
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/goplus/xgo/tpl"
+	"github.com/goplus/xgo/tpl/ast"
+	"github.com/goplus/xgo/tpl/matcher"
+	"github.com/goplus/xgo/tpl/token"
+	"github.com/qiniu/x/test"
+)
+
+// -----------------------------------------------------------------------------
+
+// Match matches src with a rule of the grammar, or the document rule if rule
+// is "". Unlike Compiler.ParseExpr, no tokens but semicolons may follow the
+// matched ones.
+func Match(c *tpl.Compiler, rule, filename string, src any, conf *tpl.Config) (result any, err error) {
+	if rule != "" {
+		v, ok := c.Rules[rule]
+		if !ok {
+			return nil, fmt.Errorf("rule `%s` is undefined", rule)
+		}
+		c1 := *c
+		c1.Doc = v
+		c = &c1
+	}
+	ms, result, err := c.Match(filename, src, conf)
+	if ms.Toks == nil { // failed
+		return
+	}
+	for _, t := range ms.Toks[ms.N:] {
+		if t.Tok != token.SEMICOLON && ms.Ctx.Skipped([]*tpl.Token{t}) == 0 {
+			return nil, ms.Ctx.NewErrorf(t.Pos, "unexpected token: %v", t)
+		}
+	}
+	return
+}
+
+// Dump is an expected matching result in the form printed by Fprint, which
+// is compared with leading and trailing white space trimmed.
+type Dump string
+
+// Case represents a test case of a grammar.
+type Case struct {
+	Name  string // name of the subtest, Input if empty
+	Rule  string // rule to match, the document rule if empty
+	Input string
+
+	// Want is the expected result, which is compared by reflect.DeepEqual,
+	// or in the form printed by Fprint if it's a Dump. It's ignored if Err
+	// isn't empty.
+	Want any
+
+	// Err is the expected error message, if matching should fail.
+	Err string
+}
+
+// Run runs test cases of a grammar, each as a subtest.
+func Run(t *testing.T, c *tpl.Compiler, conf *tpl.Config, cases []Case) {
+	t.Helper()
+	for _, tc := range cases {
+		name := tc.Name
+		if name == "" {
+			name = tc.Input
+		}
+		t.Run(name, func(t *testing.T) {
+			t.Helper()
+			result, err := Match(c, tc.Rule, "", tc.Input, conf)
+			if tc.Err != "" {
+				if err == nil || err.Error() != tc.Err {
+					t.Fatalf("got error %v, want %s", err, tc.Err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal("Match failed:", err)
+			}
+			if want, ok := tc.Want.(Dump); ok {
+				if got := Sprint(result); strings.TrimSpace(got) != strings.TrimSpace(string(want)) {
+					t.Fatalf("got:\n%s\nwant:\n%s", got, want)
+				}
+			} else if !reflect.DeepEqual(result, tc.Want) {
+				t.Fatalf("got %#v, want %#v", result, tc.Want)
+			}
+		})
+	}
+}
+
+// ExpectFile matches the file infile with a rule of the grammar (the document
+// rule if rule is ""), and compares the result printed by Fprint with the
+// golden file outfile. If they differ, the result is written to outfile (see
+// test.Diff), so a golden file can be generated by running the test.
+func ExpectFile(t *testing.T, c *tpl.Compiler, conf *tpl.Config, rule, infile, outfile string) {
+	t.Helper()
+	src, err := os.ReadFile(infile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	result, err := Match(c, rule, infile, src, conf)
+	if err != nil {
+		t.Fatal("Match failed:", err)
+	}
+	expected, _ := os.ReadFile(outfile)
+	if test.Diff(t, outfile, []byte(Sprint(result)), expected) {
+		t.Fatal("tpltest.ExpectFile: unexpected result of", infile)
+	}
+}
+
+// -----------------------------------------------------------------------------
+
+// Fprint prints a matching result: tokens and lists as tpl.Fdump does, and
+// other values (like nodes returned by RetProcs) field by field, where
+// positions are omitted.
+func Fprint(w io.Writer, result any) {
+	fprint(w, "", reflect.ValueOf(result), "  ")
+}
+
+// Sprint returns a matching result printed by Fprint.
+func Sprint(result any) string {
+	var b bytes.Buffer
+	Fprint(&b, result)
+	return b.String()
+}
+
+var (
+	tyPos = reflect.TypeOf(token.Pos(0))
+)
+
+func fprint(w io.Writer, prefix string, v reflect.Value, indent string) {
+	if !v.IsValid() {
+		fmt.Fprint(w, prefix, "nil\n")
+		return
+	}
+	switch x := v.Interface().(type) {
+	case *tpl.Token:
+		if x.Tok == token.SEMICOLON {
+			fmt.Fprint(w, prefix, ";\n")
+		} else {
+			fmt.Fprint(w, prefix, x, "\n")
+		}
+		return
+	case []any:
+		fprintList(w, prefix, x, indent)
+		return
+	}
+	switch t := v.Type(); t.Kind() {
+	case reflect.Interface, reflect.Pointer:
+		if v.IsNil() {
+			fmt.Fprint(w, prefix, "nil\n")
+		} else if t.Kind() == reflect.Pointer && t.Elem().Kind() == reflect.Struct {
+			fprintStruct(w, prefix, v.Elem(), indent)
+		} else {
+			fprint(w, prefix, v.Elem(), indent)
+		}
+	case reflect.Struct:
+		fprintStruct(w, prefix, v, indent)
+	case reflect.Slice, reflect.Array:
+		fmt.Fprint(w, prefix, t, ":\n")
+		for i, n := 0, v.Len(); i < n; i++ {
+			fprint(w, prefix+indent, v.Index(i), indent)
+		}
+	default:
+		fmt.Fprint(w, prefix, scalar(v.Interface()), "\n")
+	}
+}
+
+// fprintList prints a list in one line if its elements are tokens or values
+// of basic types, and a list of two elements ending with a semicolon (or an
+// empty list) as its first element, as tpl.Fdump does.
+func fprintList(w io.Writer, prefix string, list []any, indent string) {
+	if len(list) == 2 && isVoid(list[1]) {
+		fprint(w, prefix, reflect.ValueOf(list[0]), indent)
+		return
+	}
+	for _, v := range list {
+		if !isScalar(v) {
+			fmt.Fprint(w, prefix, "[\n")
+			for _, v := range list {
+				fprint(w, prefix+indent, reflect.ValueOf(v), indent)
+			}
+			fmt.Fprint(w, prefix, "]\n")
+			return
+		}
+	}
+	fmt.Fprint(w, prefix, "[")
+	for i, v := range list {
+		if i > 0 {
+			fmt.Fprint(w, " ")
+		}
+		fmt.Fprint(w, scalar(v))
+	}
+	fmt.Fprint(w, "]\n")
+}
+
+func isVoid(v any) bool {
+	switch v := v.(type) {
+	case nil:
+		return true
+	case *tpl.Token:
+		return v.Tok == token.SEMICOLON || v.Tok == token.EOF
+	case []any:
+		return len(v) == 0
+	}
+	return false
+}
+
+func isScalar(v any) bool {
+	switch v := v.(type) {
+	case nil, *tpl.Token:
+		return true
+	case []any:
+		return len(v) == 0
+	}
+	switch reflect.TypeOf(v).Kind() {
+	case reflect.Bool, reflect.String, reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr,
+		reflect.Float32, reflect.Float64, reflect.Complex64, reflect.Complex128:
+		return true
+	}
+	return false
+}
+
+// scalar returns the printed form of a scalar value, where strings are
+// quoted.
+func scalar(v any) any {
+	switch v := v.(type) {
+	case nil:
+		return "nil"
+	case string:
+		return fmt.Sprintf("%q", v)
+	}
+	return v
+}
+
+func fprintStruct(w io.Writer, prefix string, v reflect.Value, indent string) {
+	t := v.Type()
+	fmt.Fprint(w, prefix, t, ":\n")
+	prefix += indent
+	for i, n := 0, t.NumField(); i < n; i++ {
+		sf := t.Field(i)
+		if !sf.IsExported() || sf.Type == tyPos {
+			continue
+		}
+		fv := v.Field(i)
+		switch fv.Kind() {
+		case reflect.Interface, reflect.Pointer, reflect.Slice, reflect.Map, reflect.Func:
+			if fv.IsNil() {
+				continue
+			}
+		}
+		fmt.Fprint(w, prefix, sf.Name, ":\n")
+		fprint(w, prefix+indent, fv, indent)
+	}
+}
+
+// -----------------------------------------------------------------------------
+
+// Coverage records which rules and alternatives of a grammar are matched. Set
+// Config.OnMatch to its OnMatch method to measure the coverage of matching.
+type Coverage struct {
+	c       *tpl.Compiler
+	rules   map[*matcher.Var]bool
+	options map[*matcher.Choices]map[int]bool
+}
+
+// NewCoverage creates a Coverage of a grammar.
+func NewCoverage(c *tpl.Compiler) *Coverage {
+	return &Coverage{
+		c:       c,
+		rules:   make(map[*matcher.Var]bool),
+		options: make(map[*matcher.Choices]map[int]bool),
+	}
+}
+
+// OnMatch records a match of a rule (i = -1) or the i-th alternative of a
+// choice (see matcher.Context.OnMatch).
+func (p *Coverage) OnMatch(m matcher.Matcher, i int) {
+	switch m := m.(type) {
+	case *matcher.Var:
+		p.rules[m] = true
+	case *matcher.Choices:
+		options := p.options[m]
+		if options == nil {
+			options = make(map[int]bool)
+			p.options[m] = options
+		}
+		options[i] = true
+	}
+}
+
+// Uncovered returns the rules and alternatives of the grammar (not including
+// used grammars) which are never matched, like:
+//
+//	rule `stmt`
+//	alternative 2 of `operand`
+//	alternative 1 of choice 2 in `expr`
+func (p *Coverage) Uncovered() []string {
+	var ret []string
+	p.walk(func(what string, covered bool) {
+		if !covered {
+			ret = append(ret, what)
+		}
+	})
+	return ret
+}
+
+// Percent returns the percentage of the rules and alternatives which are
+// matched.
+func (p *Coverage) Percent() float64 {
+	var n, covered int
+	p.walk(func(what string, ok bool) {
+		n++
+		if ok {
+			covered++
+		}
+	})
+	if n == 0 {
+		return 100
+	}
+	return float64(covered) * 100 / float64(n)
+}
+
+func (p *Coverage) walk(f func(what string, covered bool)) {
+	g := p.c.Grammar
+	if g == nil {
+		return
+	}
+	choices := make(map[*ast.Choice]*matcher.Choices, len(p.c.Choices))
+	for m, c := range p.c.Choices {
+		choices[c] = m
+	}
+	for _, decl := range g.Decls {
+		r, ok := decl.(*ast.Rule)
+		if !ok {
+			continue
+		}
+		name := r.Name.Name
+		f("rule `"+name+"`", p.rules[p.c.Rules[name]])
+		cs := choicesOf(r.Expr, nil)
+		for k, c := range cs {
+			options := p.options[choices[c]]
+			for i := range c.Options {
+				if len(cs) == 1 {
+					f(fmt.Sprintf("alternative %d of `%s`", i+1, name), options[i])
+				} else {
+					f(fmt.Sprintf("alternative %d of choice %d in `%s`", i+1, k+1, name), options[i])
+				}
+			}
+		}
+	}
+}
+
+// choicesOf appends choices in x to cs, in the order of their positions.
+func choicesOf(x ast.Expr, cs []*ast.Choice) []*ast.Choice {
+	switch x := x.(type) {
+	case *ast.Choice:
+		cs = append(cs, x)
+		for _, option := range x.Options {
+			cs = choicesOf(option, cs)
+		}
+	case *ast.Sequence:
+		for _, item := range x.Items {
+			cs = choicesOf(item, cs)
+		}
+	case *ast.UnaryExpr:
+		cs = choicesOf(x.X, cs)
+	case *ast.BinaryExpr:
+		cs = choicesOf(x.X, cs)
+		cs = choicesOf(x.Y, cs)
+	}
+	return cs
+}
+
+// -----------------------------------------------------------------------------
@@ -0,0 +1,97 @@
+/*
+ * Copyright (c) 2026 The XGo Authors (xgo.dev). All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package tpltest_test
+
+import (
+	"testing"
+
+	"github.com/goplus/xgo/tpl"
+	"github.com/goplus/xgo/tpl/token"
+	"github.com/goplus/xgo/tpl/tpltest"
+)
+
+const grammar = `
+stmts = *(stmt ";")
+
+stmt = IDENT "=" expr
+
+expr = operand % ("+" | "-")
+
+operand = INT | IDENT | "(" expr ")"
+`
+
+func TestRun(t *testing.T) {
+	c, err := tpl.New(grammar, "operand", func(self any) any {
+		if t, ok := self.(*tpl.Token); ok {
+			return t.Lit
+		}
+		return self.([]any)[1]
+	})
+	if err != nil {
+		t.Fatal("tpl.New failed:", err)
+	}
+	cov := tpltest.NewCoverage(&c)
+	conf := &tpl.Config{OnMatch: cov.OnMatch}
+	tpltest.Run(t, &c, conf, []tpltest.Case{
+		{Rule: "operand", Input: "1", Want: "1"},
+		{Rule: "operand", Input: "(x)", Want: []any{"x", []any{}}},
+		{Rule: "expr", Input: "1 + 2", Want: tpltest.Dump(`
+[
+  "1"
+  [
+    [+ "2"]
+  ]
+]`)},
+		{Name: "trailing", Rule: "expr", Input: "1 2", Err: "1:3: unexpected token: 2"},
+		{Name: "undefined", Rule: "term", Input: "1", Err: "rule `term` is undefined"},
+	})
+	if got := cov.Uncovered(); len(got) != 3 || got[0] != "rule `stmts`" || got[2] != "alternative 2 of `expr`" {
+		t.Fatal("Uncovered:", got)
+	}
+	tpltest.ExpectFile(t, &c, conf, "", "testdata/assign.in", "testdata/assign.out")
+	if got := cov.Uncovered(); len(got) != 0 {
+		t.Fatal("Uncovered:", got)
+	}
+	if cov.Percent() != 100 {
+		t.Fatal("Percent:", cov.Percent())
+	}
+}
+
+type node struct {
+	Op    string
+	X, Y  any
+	OpPos token.Pos
+}
+
+func TestSprint(t *testing.T) {
+	ret := tpltest.Sprint([]any{&node{Op: "+", X: 1, Y: []string{"a"}}, nil, true})
+	if ret != `[
+  tpltest_test.node:
+    Op:
+      "+"
+    X:
+      1
+    Y:
+      []string:
+        "a"
+  nil
+  true
+]
+` {
+		t.Fatal("Sprint:", ret)
+	}
+}
@@ -142,6 +142,49 @@ func ChgValue(name string, chgval func(string, func(oldv any) any), chg func(old
 	}
 }
 
+// Var delays to get the value of a name in the current environment (see
+// variant.CurrentEnv).
+func Var(name string) any {
+	return func() any {
+		v, ok := variant.CurrentEnv().Lookup(name)
+		if !ok {
+			panic(name + " is undefined")
+		}
+		return v
+	}
+}
+
+// Define delays to bind a name to a value in the current environment.
+func Define(name string, expr any) any {
+	return func() any {
+		variant.CurrentEnv().Define(name, Eval(expr))
+		return nil
+	}
+}
+
+// Assign delays to change the value of a name in the current environment.
+func Assign(name string, expr any) any {
+	return func() any {
+		variant.CurrentEnv().Assign(name, Eval(expr))
+		return nil
+	}
+}
+
+// Func delays to create a user-defined function with the body, which captures
+// the current environment. The function is also bound to name in the current
+// environment if name isn't "" (so it can call itself), otherwise it is an
+// anonymous function.
+func Func(name string, params []string, body any) any {
+	return func() any {
+		env := variant.CurrentEnv()
+		fn := variant.NewFunc(name, params, body, env)
+		if name != "" {
+			env.Define(name, fn)
+		}
+		return fn
+	}
+}
+
 // -----------------------------------------------------------------------------
 
 // StmtList delays a statement list.
@@ -0,0 +1,126 @@
+/*
+ * Copyright (c) 2026 The XGo Authors (xgo.dev). All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package variant
+
+// -----------------------------------------------------------------------------
+
+// Env represents an environment, which binds names to values in a lexical
+// scope. Names not found in an environment and its parents are looked up in
+// the universe.
+type Env struct {
+	parent *Env
+	vars   map[string]any
+}
+
+// NewEnv creates a new environment in the parent one (nil for the global
+// environment).
+func NewEnv(parent *Env) *Env {
+	return &Env{parent: parent, vars: make(map[string]any)}
+}
+
+// Parent returns the parent environment.
+func (p *Env) Parent() *Env {
+	return p.parent
+}
+
+// Lookup looks up a name in the environment, its parents and the universe.
+func (p *Env) Lookup(name string) (v any, ok bool) {
+	for env := p; env != nil; env = env.parent {
+		if v, ok = env.vars[name]; ok {
+			return
+		}
+	}
+	v, ok = universe.objs[name]
+	return
+}
+
+// Define binds a name to a value in the environment.
+func (p *Env) Define(name string, v any) {
+	if _, ok := p.vars[name]; ok {
+		panic(name + " redeclared")
+	}
+	p.vars[name] = v
+}
+
+// Assign changes the value of a name in the nearest environment (the
+// environment itself or one of its parents) defining it.
+func (p *Env) Assign(name string, v any) {
+	for env := p; env != nil; env = env.parent {
+		if _, ok := env.vars[name]; ok {
+			env.vars[name] = v
+			return
+		}
+	}
+	panic(name + " is undefined")
+}
+
+var (
+	curEnv = NewEnv(nil)
+)
+
+// CurrentEnv returns the environment in which names are looked up, which is
+// the global environment, or the environment of the user-defined function
+// being called.
+func CurrentEnv() *Env {
+	return curEnv
+}
+
+// -----------------------------------------------------------------------------
+
+// Callable represents a function object other than func(...any) any, like a
+// user-defined function.
+type Callable interface {
+	Call(args ...any) any
+}
+
+// Func represents a user-defined function (or a closure), whose body is a
+// delayed value evaluated in a new environment binding its parameters to
+// arguments. The new environment is created in the environment where the
+// function is defined, so the function captures names in it lexically.
+type Func struct {
+	Name   string // "" for an anonymous function
+	Params []string
+	Body   any
+	Env    *Env
+}
+
+// NewFunc creates a user-defined function in the specified environment.
+func NewFunc(name string, params []string, body any, env *Env) *Func {
+	return &Func{Name: name, Params: params, Body: body, Env: env}
+}
+
+// Call calls the function.
+func (p *Func) Call(args ...any) any {
+	if len(args) != len(p.Params) {
+		if p.Name != "" {
+			panic(p.Name + ": arity mismatch")
+		}
+		panic("function call: arity mismatch")
+	}
+	env := NewEnv(p.Env)
+	for i, name := range p.Params {
+		env.vars[name] = Eval(args[i])
+	}
+	old := curEnv
+	curEnv = env
+	defer func() {
+		curEnv = old
+	}()
+	return Eval(p.Body)
+}
+
+// -----------------------------------------------------------------------------
@@ -68,18 +68,12 @@ var (
 	}
 )
 
-// Call calls a function.
+// Call calls a function, which is looked up in the current environment (see
+// CurrentEnv) and the universe.
 func Call(needList bool, name string, arglist any) any {
-	if o, ok := universe.objs[name]; ok {
-		if fn, ok := o.(func(...any) any); ok {
-			var args []any
-			if arglist != nil {
-				args = arglist.([]any)
-				if needList {
-					args = tpl.List(args)
-				}
-			}
-			return fn(args...)
+	if o, ok := curEnv.Lookup(name); ok {
+		if ret, ok := callFn(needList, o, arglist); ok {
+			return ret
 		}
 		panic("not a function: " + name)
 	}
@@ -88,19 +82,29 @@ func Call(needList bool, name string, arglist any) any {
 
 // CallObject calls a function object.
 func CallObject(needList bool, fn any, arglist any) any {
-	if fn, ok := Eval(fn).(func(...any) any); ok {
-		var args []any
-		if arglist != nil {
-			args = arglist.([]any)
-			if needList {
-				args = tpl.List(args)
-			}
-		}
-		return fn(args...)
+	if ret, ok := callFn(needList, Eval(fn), arglist); ok {
+		return ret
 	}
 	panic("call of non function")
 }
 
+func callFn(needList bool, fn any, arglist any) (ret any, ok bool) {
+	var args []any
+	if arglist != nil {
+		args = arglist.([]any)
+		if needList {
+			args = tpl.List(args)
+		}
+	}
+	switch fn := fn.(type) {
+	case func(...any) any:
+		return fn(args...), true
+	case Callable:
+		return fn.Call(args...), true
+	}
+	return
+}
+
 // -----------------------------------------------------------------------------
 
 // InitUniverse initializes the universe module with the specified modules.
@@ -0,0 +1,51 @@
+/*
+ * Copyright (c) 2026 The XGo Authors (xgo.dev). All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package os
+
+import (
+	"os"
+
+	"github.com/goplus/xgo/tpl/variant"
+)
+
+// -----------------------------------------------------------------------------
+
+// Getenv returns the value of an environment variable, or "" if it isn't
+// present.
+func Getenv(args ...any) any {
+	if len(args) != 1 {
+		panic("getenv: arity mismatch")
+	}
+	return os.Getenv(variant.String(args[0]))
+}
+
+// Args returns the command-line arguments as a []string, starting with the
+// program name.
+func Args(args ...any) any {
+	if len(args) > 0 {
+		panic("args: arity mismatch")
+	}
+	return os.Args
+}
+
+func init() {
+	mod := variant.NewModule("os")
+	mod.Insert("getenv", Getenv)
+	mod.Insert("args", Args)
+}
+
+// -----------------------------------------------------------------------------
@@ -0,0 +1,195 @@
+/*
+ * Copyright (c) 2026 The XGo Authors (xgo.dev). All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package strings
+
+import (
+	"strings"
+
+	"github.com/goplus/xgo/tpl/variant"
+)
+
+// -----------------------------------------------------------------------------
+
+type strings1 struct {
+	name string
+	fn   func(s string) string
+}
+
+type strings2 struct {
+	name string
+	fn   func(s, t string) string
+}
+
+type stringsBool struct {
+	name string
+	fn   func(s, substr string) bool
+}
+
+type stringsInt struct {
+	name string
+	fn   func(s, substr string) int
+}
+
+func string1(args []any) string {
+	if len(args) != 1 {
+		panic("function call: arity mismatch")
+	}
+	return variant.String(args[0])
+}
+
+func string2(args []any) (string, string) {
+	if len(args) != 2 {
+		panic("function call: arity mismatch")
+	}
+	return variant.String(args[0]), variant.String(args[1])
+}
+
+// -----------------------------------------------------------------------------
+
+// Split splits a string into a []string of substrings separated by sep.
+// A []string (rather than a []any) is returned since []any values are
+// matching results of sequences.
+func Split(args ...any) any {
+	return strings.Split(string2(args))
+}
+
+// Join concatenates a []string with sep between them.
+func Join(args ...any) any {
+	if len(args) != 2 {
+		panic("join: arity mismatch")
+	}
+	elems, ok := variant.Eval(args[0]).([]string)
+	if !ok {
+		panic("join: not a list of strings")
+	}
+	return strings.Join(elems, variant.String(args[1]))
+}
+
+// Trim returns a string with leading and trailing white space removed, or
+// trim(s, cutset) with leading and trailing characters in cutset removed.
+func Trim(args ...any) any {
+	switch len(args) {
+	case 1:
+		return strings.TrimSpace(variant.String(args[0]))
+	case 2:
+		return strings.Trim(string2(args))
+	}
+	panic("trim: arity mismatch")
+}
+
+// Replace replaces all occurrences of old in a string with new.
+func Replace(args ...any) any {
+	if len(args) != 3 {
+		panic("replace: arity mismatch")
+	}
+	s, old, new := variant.String(args[0]), variant.String(args[1]), variant.String(args[2])
+	return strings.ReplaceAll(s, old, new)
+}
+
+// Repeat returns a string consisting of n copies of a string.
+func Repeat(args ...any) any {
+	if len(args) != 2 {
+		panic("repeat: arity mismatch")
+	}
+	return strings.Repeat(variant.String(args[0]), variant.Int(args[1]))
+}
+
+// Len returns the length of a string in bytes, or the length of a []string.
+func Len(args ...any) any {
+	if len(args) != 1 {
+		panic("len: arity mismatch")
+	}
+	switch v := variant.Eval(args[0]).(type) {
+	case string:
+		return len(v)
+	case []string:
+		return len(v)
+	}
+	panic("len: not a string or a list of strings")
+}
+
+// -----------------------------------------------------------------------------
+
+var fnsStrings1 = [...]strings1{
+	{"toLower", strings.ToLower},
+	{"toUpper", strings.ToUpper},
+	{"trimSpace", strings.TrimSpace},
+}
+
+var fnsStrings2 = [...]strings2{
+	{"trimLeft", strings.TrimLeft},
+	{"trimRight", strings.TrimRight},
+	{"trimPrefix", strings.TrimPrefix},
+	{"trimSuffix", strings.TrimSuffix},
+}
+
+var fnsStringsBool = [...]stringsBool{
+	{"contains", strings.Contains},
+	{"hasPrefix", strings.HasPrefix},
+	{"hasSuffix", strings.HasSuffix},
+}
+
+var fnsStringsInt = [...]stringsInt{
+	{"count", strings.Count},
+	{"index", strings.Index},
+	{"lastIndex", strings.LastIndex},
+}
+
+var fnsNamed = [...]struct {
+	name string
+	fn   func(...any) any
+}{
+	{"split", Split},
+	{"join", Join},
+	{"trim", Trim},
+	{"replace", Replace},
+	{"repeat", Repeat},
+	{"len", Len},
+}
+
+func init() {
+	mod := variant.NewModule("strings")
+	for _, m := range fnsStrings1 {
+		fn := m.fn
+		mod.Insert(m.name, func(args ...any) any {
+			return fn(string1(args))
+		})
+	}
+	for _, m := range fnsStrings2 {
+		fn := m.fn
+		mod.Insert(m.name, func(args ...any) any {
+			return fn(string2(args))
+		})
+	}
+	for _, m := range fnsStringsBool {
+		fn := m.fn
+		mod.Insert(m.name, func(args ...any) any {
+			return fn(string2(args))
+		})
+	}
+	for _, m := range fnsStringsInt {
+		fn := m.fn
+		mod.Insert(m.name, func(args ...any) any {
+			return fn(string2(args))
+		})
+	}
+	for _, m := range fnsNamed {
+		mod.Insert(m.name, m.fn)
+	}
+}
+
+// -----------------------------------------------------------------------------
@@ -87,6 +87,14 @@ func Int(v any) int {
 	panic("not an int")
 }
 
+// String ensures a value is string.
+func String(v any) string {
+	if v, ok := Eval(v).(string); ok {
+		return v
+	}
+	panic("not a string")
+}
+
 // -----------------------------------------------------------------------------
 
 func cmpInt(op token.Token, x, y int) bool {
@@ -158,6 +158,11 @@ func formatExpr(ctx *formatCtx, expr ast.Expr, ref *ast.Expr) {
 			for _, arg := range lit.Args {
 				markExprImports(ctx, arg)
 			}
+			for _, part := range lit.Parts {
+				if e, ok := part.(ast.Expr); ok {
+					markExprImports(ctx, e)
+				}
+			}
 		case *tplast.File:
 			markTplRetProcImports(ctx, lit)
 		}
@@ -207,11 +212,14 @@ func markTplRetProcImports(ctx *formatCtx, file *tplast.File) {
 		if !ok {
 			continue
 		}
-		expr, ok := rule.RetProc.(ast.Expr)
-		if !ok {
-			continue
+		if expr, ok := rule.RetProc.(ast.Expr); ok {
+			markExprImports(ctx, expr)
+		}
+		for _, pred := range rule.Predicates() {
+			if cond, ok := pred.Cond.(ast.Expr); ok {
+				markExprImports(ctx, cond)
+			}
 		}
-		markExprImports(ctx, expr)
 	}
 }
 
@@ -22,6 +22,7 @@ import (
 	"os/exec"
 
 	"github.com/goplus/mod/env"
+	xenv "github.com/goplus/xgo/env"
 	"github.com/goplus/xgo/x/xgoenv"
 )
 
@@ -31,7 +32,18 @@ type Config struct {
 	XGo   *XGoEnv
 	GoCmd string
 	Flags []string
+	Env   []string // additional environment variables, e.g. GOOS=js
 	Run   func(cmd *exec.Cmd) error
+
+	// ProfDir, if not empty, makes RunDir/RunFiles profile the program and
+	// write cpu.pprof and mem.pprof into it.
+	ProfDir string
+
+	// Targets, if not empty, makes Build build for each of the targets in
+	// parallel. The output file of a target is named by Target.Output(Output),
+	// and Output defaults to the name `go build` chooses.
+	Targets []Target
+	Output  string
 }
 
 // -----------------------------------------------------------------------------
@@ -51,6 +63,16 @@ func doWithArgs(dir, op string, conf *Config, args ...string) (err error) {
 	exargs = append(exargs, args...)
 	cmd := exec.Command(goCmd, exargs...)
 	cmd.Dir = dir
+	if conf.Env != nil {
+		cmd.Env = append(os.Environ(), conf.Env...)
+	}
+	if op == "build" && len(conf.Targets) > 0 {
+		output := conf.Output
+		if output == "" {
+			output = defaultOutput(dir, args)
+		}
+		return buildTargets(cmd, conf, output)
+	}
 	run := conf.Run
 	if run == nil {
 		run = runCmd
@@ -91,10 +113,10 @@ func appendLdflags(exargs []string, env *XGoEnv) []string {
 // -----------------------------------------------------------------------------
 
 // Name returns name of the go command.
-// It returns value of environment variable `XGO_GOCMD` if not empty.
-// If not found, it returns `go`.
+// It returns value of environment variable `XGO_GOCMD` (or the setting
+// written by `xgo env -w`) if not empty. If not found, it returns `go`.
 func Name() string {
-	goCmd := os.Getenv("XGO_GOCMD")
+	goCmd := xenv.Getenv("XGO_GOCMD")
 	if goCmd == "" {
 		goCmd = "go"
 	}
@@ -0,0 +1,165 @@
+/*
+ * Copyright (c) 2026 The XGo Authors (xgo.dev). All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package gocmd
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"os"
+	"path/filepath"
+)
+
+// -----------------------------------------------------------------------------
+
+const profMain = `// Code generated by xgo (XGo); DO NOT EDIT.
+
+package main
+
+import (
+	_xgo_os "os"
+	_xgo_filepath "path/filepath"
+	_xgo_runtime "runtime"
+	_xgo_pprof "runtime/pprof"
+)
+
+func main() {
+	const dir = %q
+	cpu, err := _xgo_os.Create(_xgo_filepath.Join(dir, "cpu.pprof"))
+	if err == nil {
+		err = _xgo_pprof.StartCPUProfile(cpu)
+	}
+	if err != nil {
+		println("xgo: start CPU profile:", err.Error())
+	}
+	defer func() {
+		_xgo_pprof.StopCPUProfile()
+		if cpu != nil {
+			cpu.Close()
+		}
+		f, err := _xgo_os.Create(_xgo_filepath.Join(dir, "mem.pprof"))
+		if err == nil {
+			_xgo_runtime.GC()
+			err = _xgo_pprof.WriteHeapProfile(f)
+			f.Close()
+		}
+		if err != nil {
+			println("xgo: write memory profile:", err.Error())
+		}
+	}()
+	_xgo_main()
+}
+`
+
+// profFiles injects profiling around main of a program by an overlay, see
+// `go help build`: the file declaring main is replaced by a copy that renames
+// it to _xgo_main, and a new file declares a main that starts and stops the
+// profiling around _xgo_main.
+//
+// Note that profiles are not written if the program calls os.Exit.
+func profFiles(files []string, conf *Config) (_ []string, _ *Config, cleanup func(), err error) {
+	profDir, err := filepath.Abs(conf.ProfDir)
+	if err != nil {
+		return
+	}
+	if err = os.MkdirAll(profDir, 0755); err != nil {
+		return
+	}
+	tmpDir, err := os.MkdirTemp("", "xgo-prof")
+	if err != nil {
+		return
+	}
+	cleanup = func() { os.RemoveAll(tmpDir) }
+	defer func() {
+		if err != nil {
+			cleanup()
+		}
+	}()
+
+	replace := make(map[string]string)
+	for i, file := range files {
+		var src []byte
+		if src, err = os.ReadFile(file); err != nil {
+			return
+		}
+		if src, ok := renameMain(file, src); ok {
+			var absFile string
+			if absFile, err = filepath.Abs(file); err != nil {
+				return
+			}
+			newFile := filepath.Join(tmpDir, fmt.Sprintf("%d.go", i))
+			if err = os.WriteFile(newFile, src, 0644); err != nil {
+				return
+			}
+			replace[absFile] = newFile
+			break
+		}
+	}
+	if len(replace) == 0 {
+		return nil, nil, nil, errors.New("profile: func main not found")
+	}
+
+	mainFile := filepath.Join(tmpDir, "main.go")
+	if err = os.WriteFile(mainFile, fmt.Appendf(nil, profMain, profDir), 0644); err != nil {
+		return
+	}
+	profFile := filepath.Join(filepath.Dir(files[0]), "xgo_autogen_prof.go")
+	absProfFile, err := filepath.Abs(profFile)
+	if err != nil {
+		return
+	}
+	replace[absProfFile] = mainFile
+
+	overlay, err := json.Marshal(map[string]any{"Replace": replace})
+	if err != nil {
+		return
+	}
+	overlayFile := filepath.Join(tmpDir, "overlay.json")
+	if err = os.WriteFile(overlayFile, overlay, 0644); err != nil {
+		return
+	}
+
+	newConf := *conf
+	newConf.Flags = append(conf.Flags[:len(conf.Flags):len(conf.Flags)], "-overlay="+overlayFile)
+	files = append(files[:len(files):len(files)], profFile)
+	return files, &newConf, cleanup, nil
+}
+
+// renameMain renames func main declared in src to _xgo_main.
+func renameMain(file string, src []byte) ([]byte, bool) {
+	fset := token.NewFileSet()
+	f, err := parser.ParseFile(fset, file, src, parser.SkipObjectResolution)
+	if err != nil || f.Name.Name != "main" {
+		return nil, false
+	}
+	for _, decl := range f.Decls {
+		if fn, ok := decl.(*ast.FuncDecl); ok && fn.Recv == nil && fn.Name.Name == "main" {
+			offset := fset.Position(fn.Name.Pos()).Offset
+			ret := make([]byte, 0, len(src)+5)
+			ret = append(ret, src[:offset]...)
+			ret = append(ret, "_xgo_main"...)
+			ret = append(ret, src[offset+len("main"):]...)
+			return ret, true
+		}
+	}
+	return nil, false
+}
+
+// -----------------------------------------------------------------------------
@@ -61,6 +61,13 @@ func RunFiles(buildDir string, files []string, args []string, conf *RunConfig) (
 	if len(files) == 0 {
 		return syscall.ENOENT
 	}
+	if conf != nil && conf.ProfDir != "" {
+		var cleanup func()
+		if files, conf, cleanup, err = profFiles(files, conf); err != nil {
+			return
+		}
+		defer cleanup()
+	}
 	if buildDir == "" {
 		args = append(files, args...)
 		return doWithArgs("", "run", conf, args...)
@@ -86,6 +93,9 @@ func RunFiles(buildDir string, files []string, args []string, conf *RunConfig) (
 	}
 
 	cmd := exec.Command(tempf, args...)
+	if conf != nil && conf.Run != nil {
+		return conf.Run(cmd)
+	}
 	return runCmd(cmd)
 }
 
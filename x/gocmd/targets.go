@@ -0,0 +1,171 @@
+/*
+ * Copyright (c) 2026 The XGo Authors (xgo.dev). All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package gocmd
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"sync"
+)
+
+// Target represents a GOOS/GOARCH pair to cross-compile for.
+type Target struct {
+	GOOS   string
+	GOARCH string
+}
+
+func (t Target) String() string {
+	return t.GOOS + "/" + t.GOARCH
+}
+
+// Output returns the output file name of the target for the base name: for
+// example, `hello_windows_amd64.exe` for `hello`.
+func (t Target) Output(base string) string {
+	base = strings.TrimSuffix(base, ".exe")
+	ret := base + "_" + t.GOOS + "_" + t.GOARCH
+	if t.GOOS == "windows" {
+		ret += ".exe"
+	}
+	return ret
+}
+
+// ParseTargets parses a comma-separated list of targets, such as
+// `linux/amd64,darwin/arm64,windows/amd64`.
+func ParseTargets(s string) (targets []Target, err error) {
+	for _, v := range strings.Split(s, ",") {
+		if v = strings.TrimSpace(v); v == "" {
+			continue
+		}
+		goos, goarch, ok := strings.Cut(v, "/")
+		if !ok || goos == "" || goarch == "" || strings.Contains(goarch, "/") {
+			return nil, fmt.Errorf("invalid target %q: want GOOS/GOARCH", v)
+		}
+		targets = append(targets, Target{GOOS: goos, GOARCH: goarch})
+	}
+	if len(targets) == 0 {
+		return nil, errors.New("no target specified")
+	}
+	return
+}
+
+// -----------------------------------------------------------------------------
+
+// buildTargets runs `go build` for each target in parallel. The output
+// file of a target is named by Target.Output(output).
+func buildTargets(cmd *exec.Cmd, conf *Config, output string) error {
+	run := conf.Run
+	if run == nil {
+		run = runCmdBuffered
+	}
+	errs := make([]error, len(conf.Targets))
+	sem := make(chan struct{}, runtime.GOMAXPROCS(0))
+	var wg sync.WaitGroup
+	for i, t := range conf.Targets {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			sem <- struct{}{}
+			defer func() { <-sem }()
+
+			args := make([]string, 0, len(cmd.Args)+2)
+			args = append(args, cmd.Args[1], "-o", t.Output(output))
+			args = append(args, cmd.Args[2:]...)
+			c := exec.Command(cmd.Path, args...)
+			c.Dir = cmd.Dir
+			c.Env = append(os.Environ(), "GOOS="+t.GOOS, "GOARCH="+t.GOARCH)
+			if err := run(c); err != nil {
+				errs[i] = fmt.Errorf("%v: %w", t, err)
+			}
+		}()
+	}
+	wg.Wait()
+	return errors.Join(errs...)
+}
+
+var muOutput sync.Mutex
+
+// runCmdBuffered runs a command, and writes its output at once to avoid
+// interleaving with outputs of other commands running in parallel.
+func runCmdBuffered(cmd *exec.Cmd) error {
+	var buf bytes.Buffer
+	cmd.Stdout = &buf
+	cmd.Stderr = &buf
+	err := cmd.Run()
+	if buf.Len() > 0 {
+		muOutput.Lock()
+		fmt.Fprintf(os.Stderr, "# %s\n", targetOf(cmd.Env))
+		os.Stderr.Write(buf.Bytes())
+		muOutput.Unlock()
+	}
+	return err
+}
+
+func targetOf(env []string) string {
+	var goos, goarch string
+	for _, v := range env {
+		if s, ok := strings.CutPrefix(v, "GOOS="); ok {
+			goos = s
+		} else if s, ok := strings.CutPrefix(v, "GOARCH="); ok {
+			goarch = s
+		}
+	}
+	return goos + "/" + goarch
+}
+
+// defaultOutput returns the default output name of a `go build` of args,
+// like the go command does.
+func defaultOutput(dir string, args []string) string {
+	if len(args) == 1 && !strings.HasSuffix(args[0], ".go") {
+		arg := args[0]
+		if arg == "." || filepath.IsAbs(arg) || strings.HasPrefix(arg, "./") || strings.HasPrefix(arg, "../") {
+			if abs, err := filepath.Abs(filepath.Join(dir, arg)); err == nil {
+				arg = abs
+			}
+		}
+		return elemName(filepath.ToSlash(arg))
+	}
+	for _, arg := range args {
+		if strings.HasSuffix(arg, ".go") {
+			return strings.TrimSuffix(filepath.Base(arg), ".go")
+		}
+	}
+	return "a.out"
+}
+
+// elemName returns the last element of a path, skipping a major version
+// suffix like `/v2`.
+func elemName(path string) string {
+	elem := pathBase(path)
+	if len(elem) > 1 && elem[0] == 'v' && strings.Trim(elem[1:], "0123456789") == "" {
+		if parent := pathBase(strings.TrimSuffix(path, "/"+elem)); parent != "" {
+			return parent
+		}
+	}
+	return elem
+}
+
+func pathBase(path string) string {
+	return path[strings.LastIndexByte(path, '/')+1:]
+}
+
+// -----------------------------------------------------------------------------
@@ -0,0 +1,199 @@
+/*
+ * Copyright (c) 2026 The XGo Authors (xgo.dev). All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package interp
+
+import (
+	"fmt"
+	"go/ast"
+	"go/token"
+	"go/types"
+	"os"
+	"reflect"
+)
+
+// -----------------------------------------------------------------------------
+
+// builtin returns the builtin function called by call, if any.
+func (p *Interp) builtin(call *ast.CallExpr) (*types.Builtin, bool) {
+	var id *ast.Ident
+	switch fun := unparen(call.Fun).(type) {
+	case *ast.Ident:
+		id = fun
+	case *ast.SelectorExpr: // unsafe.XXX
+		id = fun.Sel
+	default:
+		return nil, false
+	}
+	b, ok := p.info.Uses[id].(*types.Builtin)
+	return b, ok
+}
+
+// evalBuiltinArgs evaluates arguments of a builtin call. Arguments that are
+// types are left invalid.
+func (p *Interp) evalBuiltinArgs(fr *frame, call *ast.CallExpr) []reflect.Value {
+	args := make([]reflect.Value, len(call.Args))
+	for i, arg := range call.Args {
+		if !p.info.Types[arg].IsType() {
+			args[i] = copyVal(p.eval(fr, arg))
+		}
+	}
+	return args
+}
+
+// callBuiltinWith calls builtin function b with evaluated args.
+func (p *Interp) callBuiltinWith(fr *frame, b *types.Builtin, call *ast.CallExpr, args []reflect.Value) []reflect.Value {
+	one := func(v reflect.Value) []reflect.Value { return []reflect.Value{v} }
+	switch b.Name() {
+	case "len", "cap":
+		x := args[0]
+		if x.Kind() == reflect.Pointer {
+			x = x.Elem()
+		}
+		if !x.IsValid() {
+			return one(reflect.ValueOf(0))
+		}
+		if b.Name() == "len" {
+			return one(reflect.ValueOf(x.Len()))
+		}
+		return one(reflect.ValueOf(x.Cap()))
+	case "append":
+		st := p.typeOf(call)
+		s := args[0]
+		if !s.IsValid() {
+			s = reflect.Zero(p.rtype(st))
+		}
+		if call.Ellipsis.IsValid() {
+			y := args[1]
+			if !y.IsValid() {
+				return one(s)
+			}
+			if y.Kind() == reflect.String {
+				y = y.Convert(s.Type())
+			}
+			return one(reflect.AppendSlice(s, y))
+		}
+		elem := st.Underlying().(*types.Slice).Elem()
+		elems := make([]reflect.Value, len(args)-1)
+		for i, v := range args[1:] {
+			elems[i] = p.convertFrom(v, p.typeOf(call.Args[i+1]), elem)
+		}
+		return one(reflect.Append(s, elems...))
+	case "copy":
+		dst, src := args[0], args[1]
+		if src.Kind() == reflect.String {
+			src = src.Convert(reflect.TypeOf([]byte(nil)))
+		}
+		return one(reflect.ValueOf(reflect.Copy(dst, src)))
+	case "delete":
+		m := args[0]
+		if !m.IsNil() {
+			k := p.convertFrom(args[1], p.typeOf(call.Args[1]), p.typeOf(call.Args[0]).Underlying().(*types.Map).Key())
+			m.SetMapIndex(k, reflect.Value{})
+		}
+		return nil
+	case "make":
+		t := p.typeOf(call.Args[0])
+		rt := p.rtype(t)
+		switch t.Underlying().(type) {
+		case *types.Slice:
+			n := toInt(args[1])
+			c := n
+			if len(args) > 2 {
+				c = toInt(args[2])
+			}
+			if n < 0 || c < n {
+				panic(runtimeError("makeslice: len out of range"))
+			}
+			return one(reflect.MakeSlice(rt, n, c))
+		case *types.Map:
+			n := 0
+			if len(args) > 1 {
+				n = toInt(args[1])
+			}
+			return one(reflect.MakeMapWithSize(rt, n))
+		default:
+			n := 0
+			if len(args) > 1 {
+				n = toInt(args[1])
+			}
+			return one(reflect.MakeChan(rt, n))
+		}
+	case "new":
+		return one(reflect.New(p.rtype(p.typeOf(call.Args[0]))))
+	case "panic":
+		v := p.convertFrom(args[0], p.typeOf(call.Args[0]), types.Universe.Lookup("any").Type())
+		if v.IsNil() {
+			panic(nil)
+		}
+		panic(v.Interface())
+	case "recover":
+		return one(p.recover(fr))
+	case "print", "println":
+		vals := make([]any, len(args))
+		for i, v := range args {
+			if v.IsValid() {
+				vals[i] = v.Interface()
+			}
+		}
+		if b.Name() == "println" {
+			fmt.Fprintln(os.Stderr, vals...)
+		} else {
+			fmt.Fprint(os.Stderr, vals...)
+		}
+		return nil
+	case "min", "max":
+		rt := p.rtype(p.typeOf(call))
+		op := token.LSS
+		if b.Name() == "max" {
+			op = token.GTR
+		}
+		ret := args[0].Convert(rt)
+		for _, v := range args[1:] {
+			if v = v.Convert(rt); compare(op, v, ret) {
+				ret = v
+			}
+		}
+		return one(ret)
+	case "clear":
+		x := args[0]
+		switch x.Kind() {
+		case reflect.Map:
+			x.Clear()
+		case reflect.Slice:
+			zero := reflect.Zero(x.Type().Elem())
+			for i := 0; i < x.Len(); i++ {
+				x.Index(i).Set(zero)
+			}
+		}
+		return nil
+	case "close":
+		args[0].Close()
+		return nil
+	case "complex":
+		rt := p.rtype(p.typeOf(call))
+		return one(reflect.ValueOf(complex(args[0].Float(), args[1].Float())).Convert(rt))
+	case "real":
+		rt := p.rtype(p.typeOf(call))
+		return one(reflect.ValueOf(real(args[0].Complex())).Convert(rt))
+	case "imag":
+		rt := p.rtype(p.typeOf(call))
+		return one(reflect.ValueOf(imag(args[0].Complex())).Convert(rt))
+	}
+	panic(p.unsupported(call, "builtin "+b.Name()))
+}
+
+// -----------------------------------------------------------------------------
@@ -0,0 +1,531 @@
+/*
+ * Copyright (c) 2026 The XGo Authors (xgo.dev). All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package interp
+
+import (
+	"go/ast"
+	"go/types"
+	"reflect"
+)
+
+// -----------------------------------------------------------------------------
+
+// function is a function declared in interpreted code.
+type function struct {
+	name string
+	sig  *types.Signature
+	typ  *ast.FuncType
+	body *ast.BlockStmt
+	recv *ast.FieldList
+
+	env map[types.Object]reflect.Value // captured variables of a closure
+}
+
+// frame is the activation record of a function call.
+type frame struct {
+	vars    map[types.Object]reflect.Value
+	sig     *types.Signature
+	results []reflect.Value
+	defers  []func()
+	label   string // label of a pending break, continue or goto
+
+	deferOf *frame // the frame whose deferred calls are running, for recover
+	panic   *panicState
+}
+
+type panicState struct {
+	val       any
+	recovered bool
+}
+
+func (p *Interp) newFrame(env map[types.Object]reflect.Value) *frame {
+	vars := make(map[types.Object]reflect.Value, len(env)+8)
+	for k, v := range env {
+		vars[k] = v
+	}
+	return &frame{vars: vars}
+}
+
+// lookup returns the variable cell of obj.
+func (p *Interp) lookup(fr *frame, obj types.Object) reflect.Value {
+	if v, ok := fr.vars[obj]; ok {
+		return v
+	}
+	if v, ok := p.globals[obj]; ok {
+		return v
+	}
+	panic("interp: undefined variable " + obj.Name())
+}
+
+// declare creates a variable cell of obj with an initial value.
+func (p *Interp) declare(fr *frame, obj types.Object, v reflect.Value) reflect.Value {
+	var cell reflect.Value
+	if rt, ok := p.tryRType(obj.Type()); ok {
+		cell = reflect.New(rt).Elem()
+		if v.IsValid() {
+			cell.Set(p.convert(v, obj.Type()))
+		}
+	} else if v.IsValid() { // an unexported native type
+		cell = reflect.New(v.Type()).Elem()
+		cell.Set(v)
+	} else {
+		cell = reflect.New(p.rtype(obj.Type())).Elem()
+	}
+	if obj.Name() != "_" {
+		fr.vars[obj] = cell
+	}
+	return cell
+}
+
+// -----------------------------------------------------------------------------
+
+// call calls an interpreted function. args are converted to types of the
+// parameters, and the variadic arguments are packed into a slice. deferOf is
+// the frame running deferred calls if fn is called by defer.
+func (p *Interp) call(fn *function, recv *reflect.Value, args []reflect.Value, deferOf *frame) []reflect.Value {
+	fr := p.newFrame(fn.env)
+	fr.sig, fr.deferOf = fn.sig, deferOf
+	if fn.recv != nil && len(fn.recv.List) > 0 {
+		if names := fn.recv.List[0].Names; len(names) > 0 {
+			p.declare(fr, p.info.Defs[names[0]], *recv)
+		}
+	}
+	i := 0
+	for _, field := range fn.typ.Params.List {
+		if len(field.Names) == 0 {
+			i++
+			continue
+		}
+		for _, name := range field.Names {
+			p.declare(fr, p.info.Defs[name], args[i])
+			i++
+		}
+	}
+	results := fn.sig.Results()
+	fr.results = make([]reflect.Value, results.Len())
+	if fn.typ.Results != nil {
+		i = 0
+		for _, field := range fn.typ.Results.List {
+			if len(field.Names) == 0 {
+				fr.results[i] = reflect.New(p.rtype(results.At(i).Type())).Elem()
+				i++
+				continue
+			}
+			for _, name := range field.Names {
+				if obj := p.info.Defs[name]; obj != nil && name.Name != "_" {
+					fr.results[i] = p.declare(fr, obj, reflect.Value{})
+				} else {
+					fr.results[i] = reflect.New(p.rtype(results.At(i).Type())).Elem()
+				}
+				i++
+			}
+		}
+	}
+	p.run(fr, fn.body)
+	return fr.results
+}
+
+// run executes the body of a function and its deferred calls.
+func (p *Interp) run(fr *frame, body *ast.BlockStmt) {
+	var ps *panicState
+	func() {
+		defer func() {
+			if len(fr.defers) == 0 {
+				return
+			}
+			if e := recover(); e != nil {
+				ps = &panicState{val: e}
+			}
+		}()
+		p.execList(fr, body.List)
+	}()
+	for len(fr.defers) > 0 {
+		n := len(fr.defers) - 1
+		d := fr.defers[n]
+		fr.defers = fr.defers[:n]
+		fr.panic = ps
+		func() {
+			defer func() {
+				if e := recover(); e != nil {
+					ps = &panicState{val: e}
+				}
+			}()
+			d()
+		}()
+		if ps != nil && ps.recovered {
+			ps = nil
+		}
+	}
+	if ps != nil {
+		panic(ps.val)
+	}
+}
+
+// recover implements the builtin function recover.
+func (p *Interp) recover(fr *frame) reflect.Value {
+	ret := reflect.New(tyAny).Elem()
+	if d := fr.deferOf; d != nil && d.panic != nil && !d.panic.recovered {
+		d.panic.recovered = true
+		if d.panic.val != nil {
+			ret.Set(reflect.ValueOf(d.panic.val))
+		}
+	}
+	return ret
+}
+
+// makeFunc creates a function value of fn.
+func (p *Interp) makeFunc(fn *function) reflect.Value {
+	return reflect.MakeFunc(p.funcType(fn.sig), func(args []reflect.Value) []reflect.Value {
+		return p.call(fn, nil, args, nil)
+	})
+}
+
+// closure creates a function value of a function literal.
+func (p *Interp) closure(fr *frame, lit *ast.FuncLit) reflect.Value {
+	sig := p.info.Types[lit].Type.(*types.Signature)
+	env := make(map[types.Object]reflect.Value)
+	for _, obj := range p.freeVars(lit) {
+		if v, ok := fr.vars[obj]; ok {
+			env[obj] = v
+		}
+	}
+	return p.makeFunc(&function{name: "func", sig: sig, typ: lit.Type, body: lit.Body, env: env})
+}
+
+// closureFunc is like closure, but returns the function instead of its value.
+func (p *Interp) closureFunc(fr *frame, lit *ast.FuncLit) *function {
+	sig := p.info.Types[lit].Type.(*types.Signature)
+	env := make(map[types.Object]reflect.Value)
+	for _, obj := range p.freeVars(lit) {
+		if v, ok := fr.vars[obj]; ok {
+			env[obj] = v
+		}
+	}
+	return &function{name: "func", sig: sig, typ: lit.Type, body: lit.Body, env: env}
+}
+
+// freeVars returns local variables used but not declared by a function literal.
+func (p *Interp) freeVars(lit *ast.FuncLit) []types.Object {
+	if v, ok := p.frees.Load(lit); ok {
+		return v.([]types.Object)
+	}
+	var ret []types.Object
+	seen := make(map[types.Object]bool)
+	ast.Inspect(lit.Body, func(n ast.Node) bool {
+		if id, ok := n.(*ast.Ident); ok {
+			if obj, ok := p.info.Uses[id].(*types.Var); ok && !seen[obj] {
+				seen[obj] = true
+				if obj.Parent() != p.pkg.Scope() && !obj.IsField() && !within(obj, lit) {
+					ret = append(ret, obj)
+				}
+			}
+		}
+		return true
+	})
+	p.frees.Store(lit, ret)
+	return ret
+}
+
+func within(obj types.Object, lit *ast.FuncLit) bool {
+	pos := obj.Pos()
+	return pos >= lit.Pos() && pos < lit.End()
+}
+
+// -----------------------------------------------------------------------------
+
+// callValue calls a function value with args converted to types of the
+// parameters, and the variadic arguments packed into a slice.
+func (p *Interp) callValue(fn reflect.Value, sig *types.Signature, args []reflect.Value) []reflect.Value {
+	if fn.IsNil() {
+		panic(runtimeError("invalid memory address or nil pointer dereference"))
+	}
+	if sig.Variadic() {
+		return fn.CallSlice(args)
+	}
+	return fn.Call(args)
+}
+
+// callNative calls a native function with args converted to types of the
+// parameters.
+func (p *Interp) callNative(fn reflect.Value, sig *types.Signature, args []reflect.Value) []reflect.Value {
+	ft := fn.Type()
+	for i, arg := range args {
+		args[i] = toNative(arg, ft.In(i))
+	}
+	var ret []reflect.Value
+	if ft.IsVariadic() {
+		ret = fn.CallSlice(args)
+	} else {
+		ret = fn.Call(args)
+	}
+	results := sig.Results()
+	for i, v := range ret {
+		if i < results.Len() {
+			p.learn(results.At(i).Type(), v.Type())
+		}
+	}
+	return ret
+}
+
+// callMethodByName calls a method of a value of type t.
+func (p *Interp) callMethodByName(recv reflect.Value, t types.Type, name string, args []reflect.Value) []reflect.Value {
+	obj, index, _ := types.LookupFieldOrMethod(t, true, p.pkg, name)
+	fn, ok := obj.(*types.Func)
+	if !ok {
+		panic("interp: method not found: " + name)
+	}
+	return p.callMethod(recv, t, fn, index, args)
+}
+
+// callMethod calls method fn of recv, which is selected by index from a value
+// of type t (see types.Selection).
+func (p *Interp) callMethod(recv reflect.Value, t types.Type, fn *types.Func, index []int, args []reflect.Value) []reflect.Value {
+	recv, t = p.embedded(recv, t, index[:len(index)-1])
+	sig := fn.Type().(*types.Signature)
+	if isInterface(t) {
+		return p.callDynamic(recv, fn.Name(), sig, args)
+	}
+	if f, ok := p.funcs[fn]; ok {
+		recv = p.methodRecv(recv, sig.Recv().Type())
+		return p.call(f, &recv, args, nil)
+	}
+	m := recv.MethodByName(fn.Name())
+	if !m.IsValid() {
+		if recv.CanAddr() {
+			m = recv.Addr().MethodByName(fn.Name())
+		} else {
+			ptr := reflect.New(recv.Type())
+			ptr.Elem().Set(recv)
+			m = ptr.MethodByName(fn.Name())
+		}
+	}
+	return p.callNative(m, sig, args)
+}
+
+// methodRecv adjusts recv for a receiver of type t.
+func (p *Interp) methodRecv(recv reflect.Value, t types.Type) reflect.Value {
+	_, isPtr := t.(*types.Pointer)
+	switch {
+	case isPtr && recv.Kind() != reflect.Pointer:
+		if recv.CanAddr() {
+			return recv.Addr()
+		}
+		ptr := reflect.New(recv.Type())
+		ptr.Elem().Set(recv)
+		return ptr
+	case !isPtr && recv.Kind() == reflect.Pointer && p.rtype(t).Kind() != reflect.Pointer:
+		if recv.IsNil() {
+			panic(runtimeError("invalid memory address or nil pointer dereference"))
+		}
+		return recv.Elem()
+	}
+	return recv
+}
+
+// embedded selects an embedded field by index.
+func (p *Interp) embedded(v reflect.Value, t types.Type, index []int) (reflect.Value, types.Type) {
+	for _, i := range index {
+		if pt, ok := t.Underlying().(*types.Pointer); ok {
+			if v.IsNil() {
+				panic(runtimeError("invalid memory address or nil pointer dereference"))
+			}
+			v, t = v.Elem(), pt.Elem()
+		}
+		fld := t.Underlying().(*types.Struct).Field(i)
+		v, t = p.field(v, i, fld.Type()), fld.Type()
+	}
+	return v, t
+}
+
+// field returns field i of struct v, which is of type t.
+func (p *Interp) field(v reflect.Value, i int, t types.Type) reflect.Value {
+	f := v.Field(i)
+	if f.Kind() == reflect.Interface && !isInterface(t) { // a recursive field
+		if f.IsNil() {
+			return reflect.Zero(p.rtype(t))
+		}
+		return f.Elem()
+	}
+	return f
+}
+
+// callDynamic calls a method of an interface value.
+func (p *Interp) callDynamic(x reflect.Value, name string, sig *types.Signature, args []reflect.Value) []reflect.Value {
+	if x.Kind() == reflect.Interface {
+		if x.IsNil() {
+			panic(runtimeError("invalid memory address or nil pointer dereference"))
+		}
+		x = x.Elem()
+	}
+	if o, ok := asObject(x); ok {
+		return p.callMethodByName(reflect.ValueOf(o.v), o.typ(), name, args)
+	}
+	return p.callNative(x.MethodByName(name), sig, args)
+}
+
+// -----------------------------------------------------------------------------
+
+// runtimeError is a run-time panic raised by the interpreter.
+type runtimeError string
+
+func (e runtimeError) Error() string {
+	return "runtime error: " + string(e)
+}
+
+func (e runtimeError) RuntimeError() {}
+
+// -----------------------------------------------------------------------------
+
+// callExpr evaluates a call expression, which may be a conversion or a call
+// of a builtin function.
+func (p *Interp) callExpr(fr *frame, call *ast.CallExpr) []reflect.Value {
+	if tv := p.info.Types[call.Fun]; tv.IsType() {
+		arg := call.Args[0]
+		return []reflect.Value{p.conversion(p.eval(fr, arg), p.typeOf(arg), tv.Type)}
+	}
+	if b, ok := p.builtin(call); ok {
+		return p.callBuiltinWith(fr, b, call, p.evalBuiltinArgs(fr, call))
+	}
+	f := p.callee(fr, call)
+	return f.invoke(p.evalArgs(fr, call, f.sig), nil)
+}
+
+// conversion converts v of type from to type t, i.e. T(v).
+func (p *Interp) conversion(v reflect.Value, from, t types.Type) reflect.Value {
+	if isInterface(t) {
+		return p.convertFrom(v, from, t)
+	}
+	rt := p.rtype(t)
+	if !v.IsValid() {
+		return reflect.Zero(rt)
+	}
+	if v.Type() == rt {
+		return v
+	}
+	return v.Convert(rt)
+}
+
+// callee is the function called by a call expression.
+type callee struct {
+	p   *Interp
+	sig *types.Signature
+
+	fn *function // an interpreted function
+
+	method   *types.Func // a method call
+	recv     reflect.Value
+	recvType types.Type
+	index    []int
+
+	val    reflect.Value // a function value
+	native bool
+}
+
+func (p *Interp) callee(fr *frame, call *ast.CallExpr) *callee {
+	fun := unparen(call.Fun)
+	switch f := fun.(type) {
+	case *ast.Ident:
+		if obj, ok := p.info.Uses[f].(*types.Func); ok {
+			if fn, ok := p.funcs[obj]; ok {
+				return &callee{p: p, sig: fn.sig, fn: fn}
+			}
+		}
+	case *ast.SelectorExpr:
+		if sel, ok := p.info.Selections[f]; ok {
+			if sel.Kind() == types.MethodVal {
+				fn := sel.Obj().(*types.Func)
+				return &callee{
+					p: p, sig: fn.Type().(*types.Signature), method: fn,
+					recv: p.eval(fr, f.X), recvType: p.typeOf(f.X), index: sel.Index(),
+				}
+			}
+		} else if _, ok := p.info.Uses[f.Sel].(*types.Func); ok {
+			sig := p.typeOf(fun).(*types.Signature)
+			return &callee{p: p, sig: sig, val: p.nativeSym(f.Sel), native: true}
+		}
+	}
+	sig := p.typeOf(fun).Underlying().(*types.Signature)
+	return &callee{p: p, sig: sig, val: p.eval(fr, fun)}
+}
+
+// invoke calls the callee with args prepared by evalArgs. deferOf is the frame
+// that defers the call, if any.
+func (c *callee) invoke(args []reflect.Value, deferOf *frame) []reflect.Value {
+	p := c.p
+	switch {
+	case c.fn != nil:
+		return p.call(c.fn, nil, args, deferOf)
+	case c.method != nil:
+		return p.callMethod(c.recv, c.recvType, c.method, c.index, args)
+	case c.native:
+		return p.callNative(c.val, c.sig, args)
+	}
+	if !c.val.IsValid() {
+		panic(runtimeError("invalid memory address or nil pointer dereference"))
+	}
+	return p.callValue(c.val, c.sig, args)
+}
+
+// evalArgs evaluates arguments of a call, converts them to types of the
+// parameters and packs the variadic arguments into a slice.
+func (p *Interp) evalArgs(fr *frame, call *ast.CallExpr, sig *types.Signature) []reflect.Value {
+	params := sig.Params()
+	n := params.Len()
+	var vals []reflect.Value
+	var typs []types.Type
+	var tuple *types.Tuple
+	if len(call.Args) == 1 {
+		tuple, _ = p.typeOf(call.Args[0]).(*types.Tuple)
+	}
+	if tuple != nil { // f(g())
+		vals = p.evalMulti(fr, call.Args[0])
+		for i := range vals {
+			typs = append(typs, tuple.At(i).Type())
+		}
+	} else {
+		for _, arg := range call.Args {
+			vals = append(vals, p.eval(fr, arg))
+			typs = append(typs, p.typeOf(arg))
+		}
+	}
+	if !sig.Variadic() || call.Ellipsis.IsValid() {
+		args := make([]reflect.Value, len(vals))
+		for i, v := range vals {
+			args[i] = p.convertFrom(copyVal(v), typs[i], params.At(i).Type())
+		}
+		return args
+	}
+	args := make([]reflect.Value, n)
+	for i := 0; i < n-1; i++ {
+		args[i] = p.convertFrom(copyVal(vals[i]), typs[i], params.At(i).Type())
+	}
+	st := params.At(n - 1).Type()
+	elem := st.(*types.Slice).Elem()
+	rest := vals[n-1:]
+	if len(rest) == 0 {
+		args[n-1] = reflect.Zero(p.rtype(st))
+		return args
+	}
+	slice := reflect.MakeSlice(p.rtype(st), len(rest), len(rest))
+	for i, v := range rest {
+		slice.Index(i).Set(p.convertFrom(v, typs[n-1+i], elem))
+	}
+	args[n-1] = slice
+	return args
+}
+
+// -----------------------------------------------------------------------------
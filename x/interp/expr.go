@@ -0,0 +1,726 @@
+/*
+ * Copyright (c) 2026 The XGo Authors (xgo.dev). All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package interp
+
+import (
+	"fmt"
+	"go/ast"
+	"go/constant"
+	"go/token"
+	"go/types"
+	"reflect"
+)
+
+// -----------------------------------------------------------------------------
+
+func (p *Interp) typeOf(e ast.Expr) types.Type {
+	return p.info.TypeOf(e)
+}
+
+func unparen(e ast.Expr) ast.Expr {
+	return ast.Unparen(e)
+}
+
+// eval evaluates an expression of a single value. It returns an invalid value
+// for an untyped nil. Values of variables are addressable.
+func (p *Interp) eval(fr *frame, e ast.Expr) reflect.Value {
+	tv := p.info.Types[e]
+	if tv.Value != nil {
+		if v, ok := p.consts.Load(e); ok {
+			return v.(reflect.Value)
+		}
+		v := p.constValue(tv.Value, tv.Type)
+		p.consts.Store(e, v)
+		return v
+	}
+	if tv.IsNil() {
+		return reflect.Value{}
+	}
+	switch e := e.(type) {
+	case *ast.Ident:
+		return p.evalIdent(fr, e)
+	case *ast.ParenExpr:
+		return p.eval(fr, e.X)
+	case *ast.CompositeLit:
+		return p.compositeLit(fr, e, tv.Type)
+	case *ast.FuncLit:
+		return p.closure(fr, e)
+	case *ast.SelectorExpr:
+		return p.selector(fr, e)
+	case *ast.IndexExpr:
+		v, _ := p.index(fr, e)
+		return v
+	case *ast.SliceExpr:
+		return p.slice(fr, e)
+	case *ast.TypeAssertExpr:
+		x := p.eval(fr, e.X)
+		v, ok := p.typeAssert(x, tv.Type)
+		if !ok {
+			panic(p.assertError(x, p.typeOf(e.X), tv.Type))
+		}
+		return v
+	case *ast.CallExpr:
+		if rets := p.callExpr(fr, e); len(rets) > 0 {
+			return rets[0]
+		}
+		return reflect.Value{}
+	case *ast.StarExpr:
+		x := p.eval(fr, e.X)
+		if x.IsNil() {
+			panic(runtimeError("invalid memory address or nil pointer dereference"))
+		}
+		return x.Elem()
+	case *ast.UnaryExpr:
+		return p.unary(fr, e, tv.Type)
+	case *ast.BinaryExpr:
+		return p.binary(fr, e, tv.Type)
+	}
+	panic(p.unsupported(e, fmt.Sprintf("expression %T", e)))
+}
+
+// evalAs evaluates an expression and converts it to type t.
+func (p *Interp) evalAs(fr *frame, e ast.Expr, t types.Type) reflect.Value {
+	return p.convertFrom(p.eval(fr, e), p.typeOf(e), t)
+}
+
+// evalMulti evaluates an expression that may have multiple values, i.e. a
+// function call or a comma-ok expression.
+func (p *Interp) evalMulti(fr *frame, e ast.Expr) []reflect.Value {
+	e = unparen(e)
+	if call, ok := e.(*ast.CallExpr); ok {
+		return p.callExpr(fr, call)
+	}
+	if _, ok := p.typeOf(e).(*types.Tuple); ok { // comma-ok
+		switch e := e.(type) {
+		case *ast.IndexExpr:
+			v, ok := p.index(fr, e)
+			return []reflect.Value{v, reflect.ValueOf(ok)}
+		case *ast.TypeAssertExpr:
+			v, ok := p.typeAssert(p.eval(fr, e.X), p.typeOf(e).(*types.Tuple).At(0).Type())
+			return []reflect.Value{v, reflect.ValueOf(ok)}
+		case *ast.UnaryExpr:
+			v, ok := p.eval(fr, e.X).Recv()
+			return []reflect.Value{v, reflect.ValueOf(ok)}
+		}
+	}
+	return []reflect.Value{p.eval(fr, e)}
+}
+
+func (p *Interp) evalIdent(fr *frame, e *ast.Ident) reflect.Value {
+	switch obj := p.info.Uses[e].(type) {
+	case *types.Var:
+		return p.lookup(fr, obj)
+	case *types.Func:
+		if fn, ok := p.funcs[obj]; ok {
+			return p.makeFunc(fn)
+		}
+	}
+	panic(p.unsupported(e, "identifier "+e.Name))
+}
+
+// copyVal copies an addressable value, so that it won't be changed by later
+// assignments.
+func copyVal(v reflect.Value) reflect.Value {
+	if v.IsValid() && v.CanAddr() {
+		ret := reflect.New(v.Type()).Elem()
+		ret.Set(v)
+		return ret
+	}
+	return v
+}
+
+// -----------------------------------------------------------------------------
+
+func (p *Interp) compositeLit(fr *frame, e *ast.CompositeLit, t types.Type) reflect.Value {
+	ptr := false
+	if pt, ok := t.Underlying().(*types.Pointer); ok { // elided &T
+		t, ptr = pt.Elem(), true
+	}
+	rt := p.rtype(t)
+	var v reflect.Value
+	switch u := t.Underlying().(type) {
+	case *types.Struct:
+		v = reflect.New(rt).Elem()
+		for i, elt := range e.Elts {
+			idx := i
+			if kv, ok := elt.(*ast.KeyValueExpr); ok {
+				idx = fieldIndex(u, kv.Key.(*ast.Ident).Name)
+				elt = kv.Value
+			}
+			v.Field(idx).Set(p.evalAs(fr, elt, u.Field(idx).Type()))
+		}
+	case *types.Array:
+		v = reflect.New(rt).Elem()
+		p.fillElems(fr, v, e.Elts, u.Elem())
+	case *types.Slice:
+		n := 0
+		for i, idx := 0, 0; i < len(e.Elts); i++ {
+			if kv, ok := e.Elts[i].(*ast.KeyValueExpr); ok {
+				idx = p.constInt(kv.Key)
+			}
+			idx++
+			n = max(n, idx)
+		}
+		v = reflect.New(rt).Elem()
+		v.Set(reflect.MakeSlice(rt, n, n))
+		p.fillElems(fr, v, e.Elts, u.Elem())
+	case *types.Map:
+		v = reflect.New(rt).Elem()
+		v.Set(reflect.MakeMapWithSize(rt, len(e.Elts)))
+		for _, elt := range e.Elts {
+			kv := elt.(*ast.KeyValueExpr)
+			v.SetMapIndex(p.evalAs(fr, kv.Key, u.Key()), p.evalAs(fr, kv.Value, u.Elem()))
+		}
+	default:
+		panic(p.unsupported(e, "composite literal of "+t.String()))
+	}
+	if ptr {
+		return v.Addr()
+	}
+	return v
+}
+
+func (p *Interp) fillElems(fr *frame, v reflect.Value, elts []ast.Expr, elem types.Type) {
+	idx := 0
+	for _, elt := range elts {
+		if kv, ok := elt.(*ast.KeyValueExpr); ok {
+			idx = p.constInt(kv.Key)
+			elt = kv.Value
+		}
+		v.Index(idx).Set(p.evalAs(fr, elt, elem))
+		idx++
+	}
+}
+
+func (p *Interp) constInt(e ast.Expr) int {
+	x, _ := constant.Int64Val(constant.ToInt(p.info.Types[e].Value))
+	return int(x)
+}
+
+func fieldIndex(t *types.Struct, name string) int {
+	for i := 0; i < t.NumFields(); i++ {
+		if t.Field(i).Name() == name {
+			return i
+		}
+	}
+	panic("interp: field not found: " + name)
+}
+
+// -----------------------------------------------------------------------------
+
+func (p *Interp) selector(fr *frame, e *ast.SelectorExpr) reflect.Value {
+	sel, ok := p.info.Selections[e]
+	if !ok { // qualified identifier
+		return p.nativeSym(e.Sel)
+	}
+	switch sel.Kind() {
+	case types.FieldVal:
+		v, _ := p.embedded(p.eval(fr, e.X), p.typeOf(e.X), sel.Index())
+		return v
+	case types.MethodVal:
+		recv := copyVal(p.eval(fr, e.X))
+		recvType := p.typeOf(e.X)
+		fn := sel.Obj().(*types.Func)
+		sig := p.info.Types[e].Type.(*types.Signature)
+		index := sel.Index()
+		return reflect.MakeFunc(p.funcType(sig), func(args []reflect.Value) []reflect.Value {
+			return p.callMethod(recv, recvType, fn, index, args)
+		})
+	default: // MethodExpr
+		fn := sel.Obj().(*types.Func)
+		sig := p.info.Types[e].Type.(*types.Signature)
+		recvType := sel.Recv()
+		index := sel.Index()
+		return reflect.MakeFunc(p.funcType(sig), func(args []reflect.Value) []reflect.Value {
+			return p.callMethod(args[0], recvType, fn, index, args[1:])
+		})
+	}
+}
+
+// nativeSym returns a function or a variable of a native package.
+func (p *Interp) nativeSym(id *ast.Ident) reflect.Value {
+	obj := p.info.Uses[id]
+	sym, ok := lookupSym(obj.Pkg().Path(), obj.Name())
+	if !ok {
+		panic(p.unsupported(id, obj.Pkg().Path()+"."+obj.Name()+" (not registered)"))
+	}
+	v := reflect.ValueOf(sym)
+	if _, ok := obj.(*types.Var); ok {
+		return v.Elem()
+	}
+	return v
+}
+
+func (p *Interp) index(fr *frame, e *ast.IndexExpr) (reflect.Value, bool) {
+	if _, ok := p.typeOf(e.X).(*types.Signature); ok {
+		panic(p.unsupported(e, "generic function instance"))
+	}
+	x := p.eval(fr, e.X)
+	switch t := p.typeOf(e.X).Underlying().(type) {
+	case *types.Map:
+		k := p.evalAs(fr, e.Index, t.Key())
+		if v := x.MapIndex(k); v.IsValid() {
+			return v, true
+		}
+		return reflect.Zero(p.rtype(t.Elem())), false
+	case *types.Pointer:
+		if x.IsNil() {
+			panic(runtimeError("invalid memory address or nil pointer dereference"))
+		}
+		x = x.Elem()
+	}
+	i := toInt(p.eval(fr, e.Index))
+	if i < 0 || i >= x.Len() {
+		panic(runtimeError(fmt.Sprintf("index out of range [%d] with length %d", i, x.Len())))
+	}
+	return x.Index(i), true
+}
+
+func (p *Interp) slice(fr *frame, e *ast.SliceExpr) reflect.Value {
+	x := p.eval(fr, e.X)
+	switch p.typeOf(e.X).Underlying().(type) {
+	case *types.Pointer:
+		if x.IsNil() {
+			panic(runtimeError("invalid memory address or nil pointer dereference"))
+		}
+		x = x.Elem()
+	case *types.Array:
+		x = copyVal(x) // the array must be addressable
+		if !x.CanAddr() {
+			c := reflect.New(x.Type()).Elem()
+			c.Set(x)
+			x = c
+		}
+	}
+	lo, hi, max := 0, x.Len(), -1
+	if x.Kind() != reflect.String {
+		hi = x.Len()
+	}
+	if e.Low != nil {
+		lo = toInt(p.eval(fr, e.Low))
+	}
+	if e.High != nil {
+		hi = toInt(p.eval(fr, e.High))
+	}
+	if e.Max != nil {
+		max = toInt(p.eval(fr, e.Max))
+	}
+	limit := x.Len()
+	if x.Kind() != reflect.String {
+		limit = x.Cap()
+	}
+	if max >= 0 {
+		if lo < 0 || hi < lo || max < hi || max > limit {
+			panic(runtimeError(fmt.Sprintf("slice bounds out of range [%d:%d:%d] with capacity %d", lo, hi, max, limit)))
+		}
+		return x.Slice3(lo, hi, max)
+	}
+	if lo < 0 || hi < lo || hi > limit {
+		panic(runtimeError(fmt.Sprintf("slice bounds out of range [%d:%d] with capacity %d", lo, hi, limit)))
+	}
+	return x.Slice(lo, hi)
+}
+
+func toInt(v reflect.Value) int {
+	switch v.Kind() {
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return int(v.Uint())
+	}
+	return int(v.Int())
+}
+
+// -----------------------------------------------------------------------------
+
+func (p *Interp) unary(fr *frame, e *ast.UnaryExpr, t types.Type) reflect.Value {
+	switch e.Op {
+	case token.AND:
+		if lit, ok := unparen(e.X).(*ast.CompositeLit); ok {
+			return p.compositeLit(fr, lit, p.typeOf(lit)).Addr()
+		}
+		x := p.eval(fr, e.X)
+		if !x.CanAddr() {
+			panic(p.unsupported(e, "taking address of the expression"))
+		}
+		return x.Addr()
+	case token.ARROW:
+		v, _ := p.eval(fr, e.X).Recv()
+		return v
+	}
+	x := p.eval(fr, e.X)
+	ret := reflect.New(p.rtype(t)).Elem()
+	switch e.Op {
+	case token.ADD:
+		ret.Set(x)
+	case token.NOT:
+		ret.SetBool(!x.Bool())
+	case token.SUB:
+		switch x.Kind() {
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			ret.SetInt(-x.Int())
+		case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+			ret.SetUint(-x.Uint())
+		case reflect.Float32, reflect.Float64:
+			ret.SetFloat(-x.Float())
+		case reflect.Complex64, reflect.Complex128:
+			ret.SetComplex(-x.Complex())
+		}
+	case token.XOR:
+		switch x.Kind() {
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			ret.SetInt(^x.Int())
+		default:
+			ret.SetUint(^x.Uint())
+		}
+	default:
+		panic(p.unsupported(e, "operator "+e.Op.String()))
+	}
+	return ret
+}
+
+func (p *Interp) binary(fr *frame, e *ast.BinaryExpr, t types.Type) reflect.Value {
+	switch e.Op {
+	case token.LAND:
+		return reflect.ValueOf(p.eval(fr, e.X).Bool() && p.eval(fr, e.Y).Bool()).Convert(p.rtype(t))
+	case token.LOR:
+		return reflect.ValueOf(p.eval(fr, e.X).Bool() || p.eval(fr, e.Y).Bool()).Convert(p.rtype(t))
+	}
+	x, y := p.eval(fr, e.X), p.eval(fr, e.Y)
+	switch e.Op {
+	case token.EQL:
+		return reflect.ValueOf(p.equal(x, p.typeOf(e.X), y, p.typeOf(e.Y))).Convert(p.rtype(t))
+	case token.NEQ:
+		return reflect.ValueOf(!p.equal(x, p.typeOf(e.X), y, p.typeOf(e.Y))).Convert(p.rtype(t))
+	case token.LSS, token.LEQ, token.GTR, token.GEQ:
+		return reflect.ValueOf(compare(e.Op, x, y)).Convert(p.rtype(t))
+	case token.SHL, token.SHR:
+		return shiftOp(e.Op, x, y).Convert(p.rtype(t))
+	}
+	return binaryOp(e.Op, x, y, p.rtype(t))
+}
+
+// equal reports whether x == y.
+func (p *Interp) equal(x reflect.Value, xt types.Type, y reflect.Value, yt types.Type) bool {
+	if !x.IsValid() || !y.IsValid() {
+		if !x.IsValid() && !y.IsValid() {
+			return true
+		}
+		if !x.IsValid() {
+			x = y
+		}
+		return isNil(x)
+	}
+	if isInterface(xt) && !isInterface(yt) {
+		y = p.convertFrom(y, yt, xt)
+	} else if isInterface(yt) && !isInterface(xt) {
+		x = p.convertFrom(x, xt, yt)
+	}
+	return x.Interface() == y.Interface()
+}
+
+func isNil(v reflect.Value) bool {
+	switch v.Kind() {
+	case reflect.Pointer, reflect.Map, reflect.Slice, reflect.Func, reflect.Chan, reflect.Interface, reflect.UnsafePointer:
+		return v.IsNil()
+	}
+	return false
+}
+
+func compare(op token.Token, x, y reflect.Value) bool {
+	var c int
+	switch x.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		c = cmp3(x.Int(), y.Int())
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		c = cmp3(x.Uint(), y.Uint())
+	case reflect.Float32, reflect.Float64:
+		a, b := x.Float(), y.Float()
+		switch op { // NaN is not ordered
+		case token.LSS:
+			return a < b
+		case token.LEQ:
+			return a <= b
+		case token.GTR:
+			return a > b
+		default:
+			return a >= b
+		}
+	case reflect.String:
+		c = cmp3(x.String(), y.String())
+	default:
+		panic("interp: can't compare values of " + x.Type().String())
+	}
+	switch op {
+	case token.LSS:
+		return c < 0
+	case token.LEQ:
+		return c <= 0
+	case token.GTR:
+		return c > 0
+	default:
+		return c >= 0
+	}
+}
+
+func cmp3[T int64 | uint64 | string](a, b T) int {
+	if a < b {
+		return -1
+	}
+	if a > b {
+		return 1
+	}
+	return 0
+}
+
+func shiftOp(op token.Token, x, y reflect.Value) reflect.Value {
+	var n uint64
+	switch y.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		if y.Int() < 0 {
+			panic(runtimeError("negative shift amount"))
+		}
+		n = uint64(y.Int())
+	default:
+		n = y.Uint()
+	}
+	ret := reflect.New(x.Type()).Elem()
+	switch x.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		a := x.Int()
+		if op == token.SHL {
+			if n >= 64 {
+				a = 0
+			} else {
+				a <<= n
+			}
+		} else {
+			a >>= min(n, 63)
+		}
+		ret.SetInt(a)
+	default:
+		a := x.Uint()
+		if n >= 64 {
+			a = 0
+		} else if op == token.SHL {
+			a <<= n
+		} else {
+			a >>= n
+		}
+		ret.SetUint(a)
+	}
+	return ret
+}
+
+// binaryOp computes x op y for arithmetic and bitwise operators.
+func binaryOp(op token.Token, x, y reflect.Value, rt reflect.Type) reflect.Value {
+	ret := reflect.New(rt).Elem()
+	switch x.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		a, b := x.Int(), y.Int()
+		var r int64
+		switch op {
+		case token.ADD:
+			r = a + b
+		case token.SUB:
+			r = a - b
+		case token.MUL:
+			r = a * b
+		case token.QUO:
+			if b == 0 {
+				panic(runtimeError("integer divide by zero"))
+			}
+			r = a / b
+		case token.REM:
+			if b == 0 {
+				panic(runtimeError("integer divide by zero"))
+			}
+			r = a % b
+		case token.AND:
+			r = a & b
+		case token.OR:
+			r = a | b
+		case token.XOR:
+			r = a ^ b
+		case token.AND_NOT:
+			r = a &^ b
+		}
+		ret.SetInt(r)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		a, b := x.Uint(), y.Uint()
+		var r uint64
+		switch op {
+		case token.ADD:
+			r = a + b
+		case token.SUB:
+			r = a - b
+		case token.MUL:
+			r = a * b
+		case token.QUO:
+			if b == 0 {
+				panic(runtimeError("integer divide by zero"))
+			}
+			r = a / b
+		case token.REM:
+			if b == 0 {
+				panic(runtimeError("integer divide by zero"))
+			}
+			r = a % b
+		case token.AND:
+			r = a & b
+		case token.OR:
+			r = a | b
+		case token.XOR:
+			r = a ^ b
+		case token.AND_NOT:
+			r = a &^ b
+		}
+		ret.SetUint(r)
+	case reflect.Float32, reflect.Float64:
+		a, b := x.Float(), y.Float()
+		var r float64
+		switch op {
+		case token.ADD:
+			r = a + b
+		case token.SUB:
+			r = a - b
+		case token.MUL:
+			r = a * b
+		case token.QUO:
+			r = a / b
+		}
+		ret.SetFloat(r)
+	case reflect.Complex64, reflect.Complex128:
+		a, b := x.Complex(), y.Complex()
+		var r complex128
+		switch op {
+		case token.ADD:
+			r = a + b
+		case token.SUB:
+			r = a - b
+		case token.MUL:
+			r = a * b
+		case token.QUO:
+			r = a / b
+		}
+		ret.SetComplex(r)
+	case reflect.String:
+		ret.SetString(x.String() + y.String())
+	default:
+		panic("interp: unexpected operand of " + op.String() + ": " + x.Type().String())
+	}
+	return ret
+}
+
+// -----------------------------------------------------------------------------
+
+// lvalue represents an assignable location.
+type lvalue interface {
+	load() reflect.Value
+	store(v reflect.Value)
+}
+
+type cellLV struct{ v reflect.Value }
+
+func (lv cellLV) load() reflect.Value   { return lv.v }
+func (lv cellLV) store(v reflect.Value) { lv.v.Set(v) }
+
+type mapLV struct {
+	m, k reflect.Value
+	zero reflect.Value
+}
+
+func (lv mapLV) load() reflect.Value {
+	if v := lv.m.MapIndex(lv.k); v.IsValid() {
+		return v
+	}
+	return lv.zero
+}
+
+func (lv mapLV) store(v reflect.Value) {
+	if lv.m.IsNil() {
+		panic(plainError("assignment to entry in nil map"))
+	}
+	lv.m.SetMapIndex(lv.k, v)
+}
+
+type blankLV struct{}
+
+func (blankLV) load() reflect.Value   { return reflect.Value{} }
+func (blankLV) store(v reflect.Value) {}
+
+func (p *Interp) lvalue(fr *frame, e ast.Expr) lvalue {
+	switch e := unparen(e).(type) {
+	case *ast.Ident:
+		if e.Name == "_" {
+			return blankLV{}
+		}
+		obj := p.info.Uses[e]
+		if obj == nil {
+			obj = p.info.Defs[e]
+		}
+		return cellLV{p.lookup(fr, obj)}
+	case *ast.IndexExpr:
+		if t, ok := p.typeOf(e.X).Underlying().(*types.Map); ok {
+			m := p.eval(fr, e.X)
+			k := p.evalAs(fr, e.Index, t.Key())
+			return mapLV{m: m, k: k, zero: reflect.Zero(p.rtype(t.Elem()))}
+		}
+		v, _ := p.index(fr, e)
+		return cellLV{v}
+	case *ast.SelectorExpr:
+		sel, ok := p.info.Selections[e]
+		if !ok {
+			return cellLV{p.nativeSym(e.Sel)}
+		}
+		index := sel.Index()
+		x, t := p.embedded(p.eval(fr, e.X), p.typeOf(e.X), index[:len(index)-1])
+		if _, ok := t.Underlying().(*types.Pointer); ok {
+			if x.IsNil() {
+				panic(runtimeError("invalid memory address or nil pointer dereference"))
+			}
+			x = x.Elem()
+		}
+		return cellLV{x.Field(index[len(index)-1])}
+	case *ast.StarExpr:
+		x := p.eval(fr, e.X)
+		if x.IsNil() {
+			panic(runtimeError("invalid memory address or nil pointer dereference"))
+		}
+		return cellLV{x.Elem()}
+	}
+	panic(p.unsupported(e, "assignment to the expression"))
+}
+
+// plainError is a run-time panic without the "runtime error: " prefix.
+type plainError string
+
+func (e plainError) Error() string { return string(e) }
+
+func (e plainError) RuntimeError() {}
+
+func (p *Interp) assertError(x reflect.Value, xt, t types.Type) error {
+	dyn := "nil"
+	if x.IsValid() && !x.IsNil() {
+		if o, ok := asObject(x); ok {
+			dyn = o.typ().String()
+		} else {
+			dyn = x.Elem().Type().String()
+		}
+	}
+	return plainError(fmt.Sprintf("interface conversion: %v is %s, not %v", xt, dyn, t))
+}
+
+// -----------------------------------------------------------------------------
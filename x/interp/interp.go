@@ -0,0 +1,292 @@
+/*
+ * Copyright (c) 2026 The XGo Authors (xgo.dev). All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package interp implements an interpreter of Go packages, e.g. ones generated
+// by XGo, so that XGo programs can run without being built by the Go toolchain.
+//
+// Interpreted code can only import native packages registered by
+// RegisterPackage (see package github.com/goplus/xgo/x/interp/stdlib). Values
+// are represented by reflect.Value, so native functions can be called and can
+// call back functions of interpreted code.
+//
+// Limitations:
+//   - generic functions and types declared in interpreted code are not supported;
+//   - native code can't call methods of interpreted types, except those of
+//     error and fmt.Stringer;
+//   - recover only works in deferred functions called directly.
+package interp
+
+import (
+	"errors"
+	"fmt"
+	"go/ast"
+	"go/token"
+	"go/types"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// -----------------------------------------------------------------------------
+
+var (
+	regMutex sync.RWMutex
+	registry = make(map[string]map[string]any)
+)
+
+// RegisterPackage registers a native package so that interpreted code can
+// import it. Each symbol is a function, a pointer to a variable, or a
+// reflect.Type for a type. Constants needn't to be registered.
+func RegisterPackage(pkgPath string, syms map[string]any) {
+	regMutex.Lock()
+	defer regMutex.Unlock()
+	if old, ok := registry[pkgPath]; ok {
+		for name, sym := range syms {
+			old[name] = sym
+		}
+		return
+	}
+	registry[pkgPath] = syms
+}
+
+// Registered reports whether a native package is registered.
+func Registered(pkgPath string) bool {
+	regMutex.RLock()
+	defer regMutex.RUnlock()
+	_, ok := registry[pkgPath]
+	return ok
+}
+
+func lookupSym(pkgPath, name string) (any, bool) {
+	regMutex.RLock()
+	defer regMutex.RUnlock()
+	sym, ok := registry[pkgPath][name]
+	return sym, ok
+}
+
+// -----------------------------------------------------------------------------
+
+// Interp interprets a Go package.
+type Interp struct {
+	fset  *token.FileSet
+	files []*ast.File
+	pkg   *types.Package
+	info  *types.Info
+
+	globals map[types.Object]reflect.Value
+	funcs   map[*types.Func]*function
+
+	mutex   sync.Mutex
+	named   map[*types.Named]reflect.Type // rtypes of named types
+	learned map[*types.TypeName]reflect.Type
+	inProg  map[*types.Named]bool
+	consts  sync.Map // ast.Expr => reflect.Value
+	frees   sync.Map // *ast.FuncLit => []types.Object
+}
+
+// New type-checks files of a package and prepares to interpret it.
+func New(fset *token.FileSet, files []*ast.File, imp types.Importer) (*Interp, error) {
+	if len(files) == 0 {
+		return nil, errors.New("interp: no Go files")
+	}
+	if err := checkImports(files); err != nil {
+		return nil, err
+	}
+	info := &types.Info{
+		Types:      make(map[ast.Expr]types.TypeAndValue),
+		Defs:       make(map[*ast.Ident]types.Object),
+		Uses:       make(map[*ast.Ident]types.Object),
+		Implicits:  make(map[ast.Node]types.Object),
+		Selections: make(map[*ast.SelectorExpr]*types.Selection),
+		Scopes:     make(map[ast.Node]*types.Scope),
+		Instances:  make(map[*ast.Ident]types.Instance),
+	}
+	conf := &types.Config{Importer: imp}
+	pkg, err := conf.Check(files[0].Name.Name, fset, files, info)
+	if err != nil {
+		return nil, err
+	}
+	p := &Interp{
+		fset:    fset,
+		files:   files,
+		pkg:     pkg,
+		info:    info,
+		globals: make(map[types.Object]reflect.Value),
+		funcs:   make(map[*types.Func]*function),
+		named:   make(map[*types.Named]reflect.Type),
+		learned: make(map[*types.TypeName]reflect.Type),
+		inProg:  make(map[*types.Named]bool),
+	}
+	if err = p.load(); err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+// checkImports checks all imported packages are registered.
+func checkImports(files []*ast.File) error {
+	var missing []string
+	seen := make(map[string]bool)
+	for _, f := range files {
+		for _, imp := range f.Imports {
+			pkgPath := strings.Trim(imp.Path.Value, "`\"")
+			if !seen[pkgPath] && !Registered(pkgPath) {
+				seen[pkgPath] = true
+				missing = append(missing, pkgPath)
+			}
+		}
+	}
+	if missing != nil {
+		sort.Strings(missing)
+		return fmt.Errorf("interp: packages not supported by the interpreter: %s", strings.Join(missing, ", "))
+	}
+	return nil
+}
+
+// load collects functions and checks declarations of the package.
+func (p *Interp) load() (err error) {
+	defer func() {
+		if e := recover(); e != nil {
+			if ue, ok := e.(*UnsupportedError); ok {
+				err = ue
+				return
+			}
+			panic(e)
+		}
+	}()
+	for _, f := range p.files {
+		for _, decl := range f.Decls {
+			switch d := decl.(type) {
+			case *ast.FuncDecl:
+				fn := p.info.Defs[d.Name].(*types.Func)
+				sig := fn.Type().(*types.Signature)
+				if sig.TypeParams() != nil || sig.RecvTypeParams() != nil {
+					return p.unsupported(d, "generic function")
+				}
+				p.funcs[fn] = &function{name: fn.FullName(), sig: sig, typ: d.Type, body: d.Body, recv: d.Recv}
+			case *ast.GenDecl:
+				if d.Tok != token.TYPE {
+					continue
+				}
+				for _, spec := range d.Specs {
+					if ts := spec.(*ast.TypeSpec); ts.TypeParams != nil {
+						return p.unsupported(ts, "generic type")
+					}
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// UnsupportedError reports code not supported by the interpreter.
+type UnsupportedError struct {
+	Pos token.Position
+	Msg string
+}
+
+func (e *UnsupportedError) Error() string {
+	return fmt.Sprintf("%v: interp: %s is not supported", e.Pos, e.Msg)
+}
+
+func (p *Interp) unsupported(node ast.Node, what string) error {
+	return &UnsupportedError{Pos: p.fset.Position(node.Pos()), Msg: what}
+}
+
+// PanicError reports a panic of interpreted code that is not recovered.
+type PanicError struct {
+	Value any
+}
+
+func (e *PanicError) Error() string {
+	if err, ok := e.Value.(error); ok {
+		return "panic: " + err.Error()
+	}
+	return fmt.Sprintf("panic: %v", e.Value)
+}
+
+// -----------------------------------------------------------------------------
+
+// Run initializes the package and calls its main function.
+func (p *Interp) Run() error {
+	if err := p.Init(); err != nil {
+		return err
+	}
+	main, ok := p.pkg.Scope().Lookup("main").(*types.Func)
+	if !ok {
+		return errors.New("interp: function main is undeclared in the main package")
+	}
+	return p.protect(func() {
+		p.call(p.funcs[main], nil, nil, nil)
+	})
+}
+
+// Init initializes package-level variables and calls init functions.
+func (p *Interp) Init() error {
+	return p.protect(func() {
+		scope := p.pkg.Scope()
+		for _, name := range scope.Names() {
+			if v, ok := scope.Lookup(name).(*types.Var); ok {
+				p.globals[v] = reflect.New(p.rtype(v.Type())).Elem()
+			}
+		}
+		fr := p.newFrame(nil)
+		for _, init := range p.info.InitOrder {
+			if len(init.Lhs) == 1 {
+				p.assignTo(init.Lhs[0], p.eval(fr, init.Rhs), p.typeOf(init.Rhs))
+				continue
+			}
+			vals := p.evalMulti(fr, init.Rhs)
+			tuple := p.typeOf(init.Rhs).(*types.Tuple)
+			for i, v := range init.Lhs {
+				p.assignTo(v, vals[i], tuple.At(i).Type())
+			}
+		}
+		for _, f := range p.files {
+			for _, decl := range f.Decls {
+				if d, ok := decl.(*ast.FuncDecl); ok && d.Recv == nil && d.Name.Name == "init" {
+					p.call(p.funcs[p.info.Defs[d.Name].(*types.Func)], nil, nil, nil)
+				}
+			}
+		}
+	})
+}
+
+func (p *Interp) assignTo(v *types.Var, val reflect.Value, from types.Type) {
+	if v.Name() == "_" {
+		return
+	}
+	p.globals[v].Set(p.convertFrom(val, from, v.Type()))
+}
+
+// protect runs f and converts a panic to an error.
+func (p *Interp) protect(f func()) (err error) {
+	defer func() {
+		if e := recover(); e != nil {
+			switch e := e.(type) {
+			case *UnsupportedError:
+				err = e
+			default:
+				err = &PanicError{Value: e}
+			}
+		}
+	}()
+	f()
+	return
+}
+
+// -----------------------------------------------------------------------------
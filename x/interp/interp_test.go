@@ -0,0 +1,340 @@
+/*
+ * Copyright (c) 2026 The XGo Authors (xgo.dev). All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package interp_test
+
+import (
+	"bytes"
+	"go/ast"
+	"go/importer"
+	"go/parser"
+	"go/token"
+	"io"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/goplus/xgo/x/interp"
+	_ "github.com/goplus/xgo/x/interp/stdlib"
+)
+
+var imp = importer.ForCompiler(token.NewFileSet(), "source", nil)
+
+func run(t *testing.T, src string) (string, error) {
+	t.Helper()
+	fset := token.NewFileSet()
+	f, err := parser.ParseFile(fset, "main.go", src, 0)
+	if err != nil {
+		t.Fatal("parser.ParseFile:", err)
+	}
+	p, err := interp.New(fset, []*ast.File{f}, imp)
+	if err != nil {
+		return "", err
+	}
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+	stdout := os.Stdout
+	os.Stdout = w
+	done := make(chan string)
+	go func() {
+		var b bytes.Buffer
+		io.Copy(&b, r)
+		done <- b.String()
+	}()
+	err = p.Run()
+	os.Stdout = stdout
+	w.Close()
+	return <-done, err
+}
+
+func testRun(t *testing.T, src, want string) {
+	t.Helper()
+	out, err := run(t, src)
+	if err != nil {
+		t.Fatal("Run:", err)
+	}
+	if out != want {
+		t.Fatalf("got:\n%s\nwant:\n%s", out, want)
+	}
+}
+
+// -----------------------------------------------------------------------------
+
+func TestBasic(t *testing.T) {
+	testRun(t, `package main
+
+import "fmt"
+
+const n = 5
+
+var total = sum(1, 2, 3)
+
+func sum(args ...int) (ret int) {
+	for _, v := range args {
+		ret += v
+	}
+	return
+}
+
+func fib(n int) int {
+	if n < 2 {
+		return n
+	}
+	return fib(n-1) + fib(n-2)
+}
+
+func main() {
+	fmt.Println(total, fib(10), n<<2, 7/2, 7.0/2, -7%3)
+	a, b := 1, 2
+	a, b = b, a
+	fmt.Println(a, b, a < b && b > 0, "x"+"y")
+	var x uint8 = 250
+	x += 10
+	fmt.Println(x, ^x, x>>1)
+	s := "héllo"
+	for i, r := range s {
+		fmt.Print(i, ":", string(r), " ")
+	}
+	fmt.Println(len(s), s[1:3] == "\xc3\xa9")
+	for i := range 3 {
+		defer fmt.Println("defer", i)
+	}
+}
+`, "6 55 20 3 3.5 -1\n2 1 false xy\n4 251 2\n0:h 1:é 3:l 4:l 5:o 6 true\ndefer 2\ndefer 1\ndefer 0\n")
+}
+
+func TestCollections(t *testing.T) {
+	testRun(t, `package main
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+func main() {
+	a := []int{5, 2, 8}
+	a = append(a, 1)
+	sort.Ints(a)
+	b := make([]int, 2, 10)
+	n := copy(b, a)
+	fmt.Println(a, b, n, len(a), cap(b), a[1:3])
+	m := map[string]int{"a": 1}
+	m["b"] += 2
+	v, ok := m["c"]
+	delete(m, "a")
+	fmt.Println(m, v, ok, len(m))
+	arr := [...]string{2: "c", 0: "a"}
+	p := &arr
+	p[1] = "b"
+	fmt.Println(strings.Join(arr[:], ","), len(arr))
+	words := strings.Fields(" x  y z ")
+	sort.Slice(words, func(i, j int) bool { return words[i] > words[j] })
+	fmt.Println(words, min(3, 1, 2), max(1.5, 2))
+}
+`, "[1 2 5 8] [1 2] 2 4 10 [2 5]\nmap[b:2] 0 false 1\na,b,c 3\n[z y x] 1 2\n")
+}
+
+func TestTypes(t *testing.T) {
+	testRun(t, `package main
+
+import (
+	"errors"
+	"fmt"
+)
+
+type Shape interface {
+	Area() float64
+}
+
+type Rect struct {
+	W, H float64
+}
+
+func (r Rect) Area() float64 { return r.W * r.H }
+
+type Square struct {
+	Rect
+	name string
+}
+
+func (s *Square) Scale(k float64) { s.W *= k; s.H *= k }
+
+func (s *Square) String() string { return fmt.Sprint("square ", s.W) }
+
+type node struct {
+	val  int
+	next *node
+}
+
+type myErr struct{ code int }
+
+func (e *myErr) Error() string { return fmt.Sprint("code ", e.code) }
+
+func find(code int) error {
+	if code == 0 {
+		return nil
+	}
+	return &myErr{code}
+}
+
+func main() {
+	sq := &Square{Rect{2, 2}, "sq"}
+	sq.Scale(2)
+	var s Shape = sq
+	fmt.Println(s.Area(), sq.W, sq)
+	if _, ok := s.(*Square); ok {
+		fmt.Println("is square")
+	}
+	switch v := s.(type) {
+	case Rect:
+		fmt.Println("rect", v.W)
+	case fmt.Stringer:
+		fmt.Println("stringer", v.String())
+	}
+	var list *node
+	for i := 0; i < 3; i++ {
+		list = &node{i, list}
+	}
+	for n := list; n != nil; n = n.next {
+		fmt.Print(n.val)
+	}
+	fmt.Println()
+	err := find(42)
+	me, ok := err.(*myErr)
+	fmt.Println(err, find(0) == nil, ok, me.code, errors.Unwrap(err))
+	area := Rect.Area
+	f := sq.Scale
+	f(0.5)
+	fmt.Println(area(Rect{3, 4}), sq.W)
+}
+`, "16 4 square 4\nis square\nstringer square 4\n210\ncode 42 true true 42 <nil>\n12 2\n")
+}
+
+func TestClosure(t *testing.T) {
+	testRun(t, `package main
+
+import "fmt"
+
+func counter() func() int {
+	n := 0
+	return func() int {
+		n++
+		return n
+	}
+}
+
+func main() {
+	c := counter()
+	c()
+	c()
+	fmt.Println(c())
+	var fns []func() int
+	for i := 0; i < 3; i++ {
+		fns = append(fns, func() int { return i * i })
+	}
+	for _, f := range fns {
+		fmt.Print(f(), " ")
+	}
+	fmt.Println()
+	ch := make(chan int)
+	go func() {
+		defer close(ch)
+		for i := 1; i <= 3; i++ {
+			ch <- i
+		}
+	}()
+	sum := 0
+	for v := range ch {
+		sum += v
+	}
+	fmt.Println(sum)
+outer:
+	for i := 0; i < 3; i++ {
+		for j := 0; j < 3; j++ {
+			switch {
+			case j == 2:
+				continue outer
+			case i == 2:
+				break outer
+			}
+			fmt.Print(i, j, " ")
+		}
+	}
+	fmt.Println()
+}
+`, "3\n0 1 4 \n6\n0 0 0 1 1 0 1 1 \n")
+}
+
+func TestPanic(t *testing.T) {
+	testRun(t, `package main
+
+import "fmt"
+
+func div(a, b int) (ret int, err error) {
+	defer func() {
+		if e := recover(); e != nil {
+			err = fmt.Errorf("%v", e)
+		}
+	}()
+	return a / b, nil
+}
+
+func main() {
+	fmt.Println(div(6, 3))
+	fmt.Println(div(1, 0))
+}
+`, "2 <nil>\n0 runtime error: integer divide by zero\n")
+
+	_, err := run(t, `package main
+
+func main() {
+	var a []int
+	_ = a[3]
+}
+`)
+	if err == nil || err.Error() != "panic: runtime error: index out of range [3] with length 0" {
+		t.Fatal("Run:", err)
+	}
+}
+
+func TestUnsupported(t *testing.T) {
+	_, err := run(t, `package main
+
+import "net/http"
+
+func main() {
+	http.ListenAndServe(":80", nil)
+}
+`)
+	if err == nil || !strings.Contains(err.Error(), "net/http") {
+		t.Fatal("New:", err)
+	}
+	_, err = run(t, `package main
+
+func Map[T any](v T) T { return v }
+
+func main() {
+}
+`)
+	if _, ok := err.(*interp.UnsupportedError); !ok {
+		t.Fatal("New:", err)
+	}
+}
+
+// -----------------------------------------------------------------------------
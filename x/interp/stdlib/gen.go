@@ -0,0 +1,201 @@
+//go:build ignore
+
+/*
+ * Copyright (c) 2026 The XGo Authors (xgo.dev). All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// gen generates bindings of native packages for the interpreter.
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"go/build"
+	"go/format"
+	"go/importer"
+	"go/token"
+	"go/types"
+	"log"
+	"os"
+	"os/exec"
+	"path"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+var pkgs = []string{
+	"bufio",
+	"bytes",
+	"encoding/json",
+	"errors",
+	"fmt",
+	"io",
+	"math",
+	"math/rand",
+	"os",
+	"path",
+	"path/filepath",
+	"reflect",
+	"regexp",
+	"sort",
+	"strconv",
+	"strings",
+	"sync",
+	"time",
+	"unicode",
+	"unicode/utf8",
+	"github.com/qiniu/x/errors",
+	"github.com/qiniu/x/osx",
+	"github.com/qiniu/x/stringslice",
+	"github.com/qiniu/x/stringutil",
+	"github.com/qiniu/x/xgo",
+	"github.com/qiniu/x/xgo/ng",
+}
+
+func main() {
+	api, err := stdAPI()
+	if err != nil {
+		log.Fatalln(err)
+	}
+	fset := token.NewFileSet()
+	imp := importer.ForCompiler(fset, "source", nil)
+	for _, pkgPath := range pkgs {
+		pkg, err := imp.Import(pkgPath)
+		if err != nil {
+			log.Fatalln(err)
+		}
+		if err = gen(pkg, api); err != nil {
+			log.Fatalln(pkgPath, err)
+		}
+	}
+}
+
+// stdAPI returns symbols ("pkgPath.Name") of the Go standard library listed
+// in $GOROOT/api/go1.N.txt up to the go version of go.mod, so bindings don't
+// depend on the Go toolchain generating them.
+func stdAPI() (map[string]bool, error) {
+	out, err := exec.Command("go", "list", "-m", "-f", "{{.GoVersion}}").Output()
+	if err != nil {
+		return nil, err
+	}
+	ver := strings.Split(strings.TrimSpace(string(out)), ".")
+	if len(ver) < 2 {
+		return nil, fmt.Errorf("invalid go version: %s", out)
+	}
+	minor, err := strconv.Atoi(ver[1])
+	if err != nil {
+		return nil, err
+	}
+	api := make(map[string]bool)
+	for i := 0; i <= minor; i++ {
+		name := "go1.txt"
+		if i > 0 {
+			name = "go1." + strconv.Itoa(i) + ".txt"
+		}
+		if err = loadAPI(api, filepath.Join(build.Default.GOROOT, "api", name)); err != nil {
+			return nil, err
+		}
+	}
+	return api, nil
+}
+
+// loadAPI adds symbols of an api file, whose lines are like:
+//
+//	pkg strings, func Cut(string, string) (string, string, bool)
+//	pkg syscall (linux-386), const AF_INET = 2
+func loadAPI(api map[string]bool, file string) error {
+	f, err := os.Open(file)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	s := bufio.NewScanner(f)
+	for s.Scan() {
+		pkg, decl, ok := strings.Cut(strings.TrimPrefix(s.Text(), "pkg "), ", ")
+		if !ok {
+			continue
+		}
+		pkg, _, _ = strings.Cut(pkg, " ")
+		kind, decl, _ := strings.Cut(decl, " ")
+		switch kind {
+		case "func", "var", "type", "const":
+			if i := strings.IndexAny(decl, " (,["); i > 0 {
+				decl = decl[:i]
+			}
+			api[pkg+"."+decl] = true
+		}
+	}
+	return s.Err()
+}
+
+func isStd(pkgPath string) bool {
+	elem, _, _ := strings.Cut(pkgPath, "/")
+	return !strings.Contains(elem, ".")
+}
+
+func gen(pkg *types.Package, api map[string]bool) error {
+	name := "_" + strings.NewReplacer("/", "_", ".", "_").Replace(pkg.Path())
+	std := isStd(pkg.Path())
+	var syms []string
+	var hasTypes bool
+	scope := pkg.Scope()
+	for _, sym := range scope.Names() {
+		if !token.IsExported(sym) || std && !api[pkg.Path()+"."+sym] {
+			continue
+		}
+		ref := name + "." + sym
+		switch o := scope.Lookup(sym).(type) {
+		case *types.Func:
+			if o.Type().(*types.Signature).TypeParams() != nil {
+				continue
+			}
+			syms = append(syms, fmt.Sprintf("%q: %s,", sym, ref))
+		case *types.Var:
+			syms = append(syms, fmt.Sprintf("%q: &%s,", sym, ref))
+		case *types.TypeName:
+			if named, ok := o.Type().(*types.Named); ok && named.TypeParams() != nil {
+				continue
+			}
+			if alias, ok := o.Type().(*types.Alias); ok && alias.TypeParams() != nil {
+				continue
+			}
+			hasTypes = true
+			syms = append(syms, fmt.Sprintf("%q: reflect.TypeOf((*%s)(nil)).Elem(),", sym, ref))
+		}
+	}
+	sort.Strings(syms)
+
+	var b bytes.Buffer
+	fmt.Fprintf(&b, "// Code generated by gen.go; DO NOT EDIT.\n\npackage stdlib\n\nimport (\n")
+	fmt.Fprintf(&b, "\t%s %q\n", name, pkg.Path())
+	if hasTypes {
+		fmt.Fprintf(&b, "\t\"reflect\"\n")
+	}
+	fmt.Fprintf(&b, "\n\t\"github.com/goplus/xgo/x/interp\"\n)\n\n")
+	fmt.Fprintf(&b, "func init() {\n\tinterp.RegisterPackage(%q, map[string]any{\n", pkg.Path())
+	for _, sym := range syms {
+		fmt.Fprintf(&b, "\t\t%s\n", sym)
+	}
+	fmt.Fprintf(&b, "\t})\n}\n")
+	src, err := format.Source(b.Bytes())
+	if err != nil {
+		return err
+	}
+	file := "z_" + strings.NewReplacer("/", "_", ".", "_").Replace(path.Clean(pkg.Path())) + ".go"
+	return os.WriteFile(file, src, 0644)
+}
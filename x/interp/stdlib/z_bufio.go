@@ -0,0 +1,39 @@
+// Code generated by gen.go; DO NOT EDIT.
+
+package stdlib
+
+import (
+	_bufio "bufio"
+	"reflect"
+
+	"github.com/goplus/xgo/x/interp"
+)
+
+func init() {
+	interp.RegisterPackage("bufio", map[string]any{
+		"ErrAdvanceTooFar":     &_bufio.ErrAdvanceTooFar,
+		"ErrBadReadCount":      &_bufio.ErrBadReadCount,
+		"ErrBufferFull":        &_bufio.ErrBufferFull,
+		"ErrFinalToken":        &_bufio.ErrFinalToken,
+		"ErrInvalidUnreadByte": &_bufio.ErrInvalidUnreadByte,
+		"ErrInvalidUnreadRune": &_bufio.ErrInvalidUnreadRune,
+		"ErrNegativeAdvance":   &_bufio.ErrNegativeAdvance,
+		"ErrNegativeCount":     &_bufio.ErrNegativeCount,
+		"ErrTooLong":           &_bufio.ErrTooLong,
+		"NewReadWriter":        _bufio.NewReadWriter,
+		"NewReader":            _bufio.NewReader,
+		"NewReaderSize":        _bufio.NewReaderSize,
+		"NewScanner":           _bufio.NewScanner,
+		"NewWriter":            _bufio.NewWriter,
+		"NewWriterSize":        _bufio.NewWriterSize,
+		"ReadWriter":           reflect.TypeOf((*_bufio.ReadWriter)(nil)).Elem(),
+		"Reader":               reflect.TypeOf((*_bufio.Reader)(nil)).Elem(),
+		"ScanBytes":            _bufio.ScanBytes,
+		"ScanLines":            _bufio.ScanLines,
+		"ScanRunes":            _bufio.ScanRunes,
+		"ScanWords":            _bufio.ScanWords,
+		"Scanner":              reflect.TypeOf((*_bufio.Scanner)(nil)).Elem(),
+		"SplitFunc":            reflect.TypeOf((*_bufio.SplitFunc)(nil)).Elem(),
+		"Writer":               reflect.TypeOf((*_bufio.Writer)(nil)).Elem(),
+	})
+}
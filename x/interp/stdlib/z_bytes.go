@@ -0,0 +1,78 @@
+// Code generated by gen.go; DO NOT EDIT.
+
+package stdlib
+
+import (
+	_bytes "bytes"
+	"reflect"
+
+	"github.com/goplus/xgo/x/interp"
+)
+
+func init() {
+	interp.RegisterPackage("bytes", map[string]any{
+		"Buffer":          reflect.TypeOf((*_bytes.Buffer)(nil)).Elem(),
+		"Clone":           _bytes.Clone,
+		"Compare":         _bytes.Compare,
+		"Contains":        _bytes.Contains,
+		"ContainsAny":     _bytes.ContainsAny,
+		"ContainsFunc":    _bytes.ContainsFunc,
+		"ContainsRune":    _bytes.ContainsRune,
+		"Count":           _bytes.Count,
+		"Cut":             _bytes.Cut,
+		"CutPrefix":       _bytes.CutPrefix,
+		"CutSuffix":       _bytes.CutSuffix,
+		"Equal":           _bytes.Equal,
+		"EqualFold":       _bytes.EqualFold,
+		"ErrTooLarge":     &_bytes.ErrTooLarge,
+		"Fields":          _bytes.Fields,
+		"FieldsFunc":      _bytes.FieldsFunc,
+		"FieldsFuncSeq":   _bytes.FieldsFuncSeq,
+		"FieldsSeq":       _bytes.FieldsSeq,
+		"HasPrefix":       _bytes.HasPrefix,
+		"HasSuffix":       _bytes.HasSuffix,
+		"Index":           _bytes.Index,
+		"IndexAny":        _bytes.IndexAny,
+		"IndexByte":       _bytes.IndexByte,
+		"IndexFunc":       _bytes.IndexFunc,
+		"IndexRune":       _bytes.IndexRune,
+		"Join":            _bytes.Join,
+		"LastIndex":       _bytes.LastIndex,
+		"LastIndexAny":    _bytes.LastIndexAny,
+		"LastIndexByte":   _bytes.LastIndexByte,
+		"LastIndexFunc":   _bytes.LastIndexFunc,
+		"Lines":           _bytes.Lines,
+		"Map":             _bytes.Map,
+		"NewBuffer":       _bytes.NewBuffer,
+		"NewBufferString": _bytes.NewBufferString,
+		"NewReader":       _bytes.NewReader,
+		"Reader":          reflect.TypeOf((*_bytes.Reader)(nil)).Elem(),
+		"Repeat":          _bytes.Repeat,
+		"Replace":         _bytes.Replace,
+		"ReplaceAll":      _bytes.ReplaceAll,
+		"Runes":           _bytes.Runes,
+		"Split":           _bytes.Split,
+		"SplitAfter":      _bytes.SplitAfter,
+		"SplitAfterN":     _bytes.SplitAfterN,
+		"SplitAfterSeq":   _bytes.SplitAfterSeq,
+		"SplitN":          _bytes.SplitN,
+		"SplitSeq":        _bytes.SplitSeq,
+		"Title":           _bytes.Title,
+		"ToLower":         _bytes.ToLower,
+		"ToLowerSpecial":  _bytes.ToLowerSpecial,
+		"ToTitle":         _bytes.ToTitle,
+		"ToTitleSpecial":  _bytes.ToTitleSpecial,
+		"ToUpper":         _bytes.ToUpper,
+		"ToUpperSpecial":  _bytes.ToUpperSpecial,
+		"ToValidUTF8":     _bytes.ToValidUTF8,
+		"Trim":            _bytes.Trim,
+		"TrimFunc":        _bytes.TrimFunc,
+		"TrimLeft":        _bytes.TrimLeft,
+		"TrimLeftFunc":    _bytes.TrimLeftFunc,
+		"TrimPrefix":      _bytes.TrimPrefix,
+		"TrimRight":       _bytes.TrimRight,
+		"TrimRightFunc":   _bytes.TrimRightFunc,
+		"TrimSpace":       _bytes.TrimSpace,
+		"TrimSuffix":      _bytes.TrimSuffix,
+	})
+}
@@ -0,0 +1,40 @@
+// Code generated by gen.go; DO NOT EDIT.
+
+package stdlib
+
+import (
+	_encoding_json "encoding/json"
+	"reflect"
+
+	"github.com/goplus/xgo/x/interp"
+)
+
+func init() {
+	interp.RegisterPackage("encoding/json", map[string]any{
+		"Compact":               _encoding_json.Compact,
+		"Decoder":               reflect.TypeOf((*_encoding_json.Decoder)(nil)).Elem(),
+		"Delim":                 reflect.TypeOf((*_encoding_json.Delim)(nil)).Elem(),
+		"Encoder":               reflect.TypeOf((*_encoding_json.Encoder)(nil)).Elem(),
+		"HTMLEscape":            _encoding_json.HTMLEscape,
+		"Indent":                _encoding_json.Indent,
+		"InvalidUTF8Error":      reflect.TypeOf((*_encoding_json.InvalidUTF8Error)(nil)).Elem(),
+		"InvalidUnmarshalError": reflect.TypeOf((*_encoding_json.InvalidUnmarshalError)(nil)).Elem(),
+		"Marshal":               _encoding_json.Marshal,
+		"MarshalIndent":         _encoding_json.MarshalIndent,
+		"Marshaler":             reflect.TypeOf((*_encoding_json.Marshaler)(nil)).Elem(),
+		"MarshalerError":        reflect.TypeOf((*_encoding_json.MarshalerError)(nil)).Elem(),
+		"NewDecoder":            _encoding_json.NewDecoder,
+		"NewEncoder":            _encoding_json.NewEncoder,
+		"Number":                reflect.TypeOf((*_encoding_json.Number)(nil)).Elem(),
+		"RawMessage":            reflect.TypeOf((*_encoding_json.RawMessage)(nil)).Elem(),
+		"SyntaxError":           reflect.TypeOf((*_encoding_json.SyntaxError)(nil)).Elem(),
+		"Token":                 reflect.TypeOf((*_encoding_json.Token)(nil)).Elem(),
+		"Unmarshal":             _encoding_json.Unmarshal,
+		"UnmarshalFieldError":   reflect.TypeOf((*_encoding_json.UnmarshalFieldError)(nil)).Elem(),
+		"UnmarshalTypeError":    reflect.TypeOf((*_encoding_json.UnmarshalTypeError)(nil)).Elem(),
+		"Unmarshaler":           reflect.TypeOf((*_encoding_json.Unmarshaler)(nil)).Elem(),
+		"UnsupportedTypeError":  reflect.TypeOf((*_encoding_json.UnsupportedTypeError)(nil)).Elem(),
+		"UnsupportedValueError": reflect.TypeOf((*_encoding_json.UnsupportedValueError)(nil)).Elem(),
+		"Valid":                 _encoding_json.Valid,
+	})
+}
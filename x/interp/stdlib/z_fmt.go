@@ -0,0 +1,44 @@
+// Code generated by gen.go; DO NOT EDIT.
+
+package stdlib
+
+import (
+	_fmt "fmt"
+	"reflect"
+
+	"github.com/goplus/xgo/x/interp"
+)
+
+func init() {
+	interp.RegisterPackage("fmt", map[string]any{
+		"Append":       _fmt.Append,
+		"Appendf":      _fmt.Appendf,
+		"Appendln":     _fmt.Appendln,
+		"Errorf":       _fmt.Errorf,
+		"FormatString": _fmt.FormatString,
+		"Formatter":    reflect.TypeOf((*_fmt.Formatter)(nil)).Elem(),
+		"Fprint":       _fmt.Fprint,
+		"Fprintf":      _fmt.Fprintf,
+		"Fprintln":     _fmt.Fprintln,
+		"Fscan":        _fmt.Fscan,
+		"Fscanf":       _fmt.Fscanf,
+		"Fscanln":      _fmt.Fscanln,
+		"GoStringer":   reflect.TypeOf((*_fmt.GoStringer)(nil)).Elem(),
+		"Print":        _fmt.Print,
+		"Printf":       _fmt.Printf,
+		"Println":      _fmt.Println,
+		"Scan":         _fmt.Scan,
+		"ScanState":    reflect.TypeOf((*_fmt.ScanState)(nil)).Elem(),
+		"Scanf":        _fmt.Scanf,
+		"Scanln":       _fmt.Scanln,
+		"Scanner":      reflect.TypeOf((*_fmt.Scanner)(nil)).Elem(),
+		"Sprint":       _fmt.Sprint,
+		"Sprintf":      _fmt.Sprintf,
+		"Sprintln":     _fmt.Sprintln,
+		"Sscan":        _fmt.Sscan,
+		"Sscanf":       _fmt.Sscanf,
+		"Sscanln":      _fmt.Sscanln,
+		"State":        reflect.TypeOf((*_fmt.State)(nil)).Elem(),
+		"Stringer":     reflect.TypeOf((*_fmt.Stringer)(nil)).Elem(),
+	})
+}
@@ -0,0 +1,32 @@
+// Code generated by gen.go; DO NOT EDIT.
+
+package stdlib
+
+import (
+	_github_com_qiniu_x_errors "github.com/qiniu/x/errors"
+	"reflect"
+
+	"github.com/goplus/xgo/x/interp"
+)
+
+func init() {
+	interp.RegisterPackage("github.com/qiniu/x/errors", map[string]any{
+		"As":         _github_com_qiniu_x_errors.As,
+		"CallDetail": _github_com_qiniu_x_errors.CallDetail,
+		"Detail":     _github_com_qiniu_x_errors.Detail,
+		"Err":        _github_com_qiniu_x_errors.Err,
+		"ErrorInfo":  reflect.TypeOf((*_github_com_qiniu_x_errors.ErrorInfo)(nil)).Elem(),
+		"Frame":      reflect.TypeOf((*_github_com_qiniu_x_errors.Frame)(nil)).Elem(),
+		"Info":       _github_com_qiniu_x_errors.Info,
+		"InfoEx":     _github_com_qiniu_x_errors.InfoEx,
+		"Is":         _github_com_qiniu_x_errors.Is,
+		"IsNotFound": _github_com_qiniu_x_errors.IsNotFound,
+		"List":       reflect.TypeOf((*_github_com_qiniu_x_errors.List)(nil)).Elem(),
+		"New":        _github_com_qiniu_x_errors.New,
+		"NewFrame":   _github_com_qiniu_x_errors.NewFrame,
+		"NewWith":    _github_com_qiniu_x_errors.NewWith,
+		"NotFound":   reflect.TypeOf((*_github_com_qiniu_x_errors.NotFound)(nil)).Elem(),
+		"Summary":    _github_com_qiniu_x_errors.Summary,
+		"Unwrap":     _github_com_qiniu_x_errors.Unwrap,
+	})
+}
@@ -0,0 +1,24 @@
+// Code generated by gen.go; DO NOT EDIT.
+
+package stdlib
+
+import (
+	_github_com_qiniu_x_osx "github.com/qiniu/x/osx"
+	"reflect"
+
+	"github.com/goplus/xgo/x/interp"
+)
+
+func init() {
+	interp.RegisterPackage("github.com/qiniu/x/osx", map[string]any{
+		"BLineIter":  reflect.TypeOf((*_github_com_qiniu_x_osx.BLineIter)(nil)).Elem(),
+		"BLines":     _github_com_qiniu_x_osx.BLines,
+		"Check":      _github_com_qiniu_x_osx.Check,
+		"EnumBLines": _github_com_qiniu_x_osx.EnumBLines,
+		"EnumLines":  _github_com_qiniu_x_osx.EnumLines,
+		"Errorln":    _github_com_qiniu_x_osx.Errorln,
+		"Fatal":      _github_com_qiniu_x_osx.Fatal,
+		"LineIter":   reflect.TypeOf((*_github_com_qiniu_x_osx.LineIter)(nil)).Elem(),
+		"Lines":      _github_com_qiniu_x_osx.Lines,
+	})
+}
@@ -0,0 +1,27 @@
+// Code generated by gen.go; DO NOT EDIT.
+
+package stdlib
+
+import (
+	_github_com_qiniu_x_stringslice "github.com/qiniu/x/stringslice"
+
+	"github.com/goplus/xgo/x/interp"
+)
+
+func init() {
+	interp.RegisterPackage("github.com/qiniu/x/stringslice", map[string]any{
+		"Capitalize": _github_com_qiniu_x_stringslice.Capitalize,
+		"Repeat":     _github_com_qiniu_x_stringslice.Repeat,
+		"Replace":    _github_com_qiniu_x_stringslice.Replace,
+		"ReplaceAll": _github_com_qiniu_x_stringslice.ReplaceAll,
+		"ToLower":    _github_com_qiniu_x_stringslice.ToLower,
+		"ToTitle":    _github_com_qiniu_x_stringslice.ToTitle,
+		"ToUpper":    _github_com_qiniu_x_stringslice.ToUpper,
+		"Trim":       _github_com_qiniu_x_stringslice.Trim,
+		"TrimLeft":   _github_com_qiniu_x_stringslice.TrimLeft,
+		"TrimPrefix": _github_com_qiniu_x_stringslice.TrimPrefix,
+		"TrimRight":  _github_com_qiniu_x_stringslice.TrimRight,
+		"TrimSpace":  _github_com_qiniu_x_stringslice.TrimSpace,
+		"TrimSuffix": _github_com_qiniu_x_stringslice.TrimSuffix,
+	})
+}
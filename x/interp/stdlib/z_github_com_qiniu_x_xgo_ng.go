@@ -0,0 +1,84 @@
+// Code generated by gen.go; DO NOT EDIT.
+
+package stdlib
+
+import (
+	_github_com_qiniu_x_xgo_ng "github.com/qiniu/x/xgo/ng"
+	"reflect"
+
+	"github.com/goplus/xgo/x/interp"
+)
+
+func init() {
+	interp.RegisterPackage("github.com/qiniu/x/xgo/ng", map[string]any{
+		"Bigfloat":                reflect.TypeOf((*_github_com_qiniu_x_xgo_ng.Bigfloat)(nil)).Elem(),
+		"Bigint":                  reflect.TypeOf((*_github_com_qiniu_x_xgo_ng.Bigint)(nil)).Elem(),
+		"Bigint_Cast__0":          _github_com_qiniu_x_xgo_ng.Bigint_Cast__0,
+		"Bigint_Cast__1":          _github_com_qiniu_x_xgo_ng.Bigint_Cast__1,
+		"Bigint_Cast__2":          _github_com_qiniu_x_xgo_ng.Bigint_Cast__2,
+		"Bigint_Cast__3":          _github_com_qiniu_x_xgo_ng.Bigint_Cast__3,
+		"Bigint_Cast__4":          _github_com_qiniu_x_xgo_ng.Bigint_Cast__4,
+		"Bigint_Cast__5":          _github_com_qiniu_x_xgo_ng.Bigint_Cast__5,
+		"Bigint_Cast__6":          _github_com_qiniu_x_xgo_ng.Bigint_Cast__6,
+		"Bigint_Cast__7":          _github_com_qiniu_x_xgo_ng.Bigint_Cast__7,
+		"Bigint_Init__0":          _github_com_qiniu_x_xgo_ng.Bigint_Init__0,
+		"Bigint_Init__1":          _github_com_qiniu_x_xgo_ng.Bigint_Init__1,
+		"Bigint_Init__2":          _github_com_qiniu_x_xgo_ng.Bigint_Init__2,
+		"Bigrat":                  reflect.TypeOf((*_github_com_qiniu_x_xgo_ng.Bigrat)(nil)).Elem(),
+		"Bigrat_Cast__0":          _github_com_qiniu_x_xgo_ng.Bigrat_Cast__0,
+		"Bigrat_Cast__1":          _github_com_qiniu_x_xgo_ng.Bigrat_Cast__1,
+		"Bigrat_Cast__2":          _github_com_qiniu_x_xgo_ng.Bigrat_Cast__2,
+		"Bigrat_Cast__3":          _github_com_qiniu_x_xgo_ng.Bigrat_Cast__3,
+		"Bigrat_Cast__4":          _github_com_qiniu_x_xgo_ng.Bigrat_Cast__4,
+		"Bigrat_Cast__5":          _github_com_qiniu_x_xgo_ng.Bigrat_Cast__5,
+		"Bigrat_Cast__6":          _github_com_qiniu_x_xgo_ng.Bigrat_Cast__6,
+		"Bigrat_Init__0":          _github_com_qiniu_x_xgo_ng.Bigrat_Init__0,
+		"Bigrat_Init__1":          _github_com_qiniu_x_xgo_ng.Bigrat_Init__1,
+		"Bigrat_Init__2":          _github_com_qiniu_x_xgo_ng.Bigrat_Init__2,
+		"FormatInt128":            _github_com_qiniu_x_xgo_ng.FormatInt128,
+		"FormatUint128":           _github_com_qiniu_x_xgo_ng.FormatUint128,
+		"Int128":                  reflect.TypeOf((*_github_com_qiniu_x_xgo_ng.Int128)(nil)).Elem(),
+		"Int128_Cast__0":          _github_com_qiniu_x_xgo_ng.Int128_Cast__0,
+		"Int128_Cast__1":          _github_com_qiniu_x_xgo_ng.Int128_Cast__1,
+		"Int128_Cast__2":          _github_com_qiniu_x_xgo_ng.Int128_Cast__2,
+		"Int128_Cast__3":          _github_com_qiniu_x_xgo_ng.Int128_Cast__3,
+		"Int128_Cast__4":          _github_com_qiniu_x_xgo_ng.Int128_Cast__4,
+		"Int128_Cast__5":          _github_com_qiniu_x_xgo_ng.Int128_Cast__5,
+		"Int128_Cast__6":          _github_com_qiniu_x_xgo_ng.Int128_Cast__6,
+		"Int128_Cast__7":          _github_com_qiniu_x_xgo_ng.Int128_Cast__7,
+		"Int128_Cast__8":          _github_com_qiniu_x_xgo_ng.Int128_Cast__8,
+		"Int128_Cast__9":          _github_com_qiniu_x_xgo_ng.Int128_Cast__9,
+		"Int128_Cast__a":          _github_com_qiniu_x_xgo_ng.Int128_Cast__a,
+		"Int128_Init__0":          _github_com_qiniu_x_xgo_ng.Int128_Init__0,
+		"Int128_Init__1":          _github_com_qiniu_x_xgo_ng.Int128_Init__1,
+		"ParseInt128":             _github_com_qiniu_x_xgo_ng.ParseInt128,
+		"ParseUint128":            _github_com_qiniu_x_xgo_ng.ParseUint128,
+		"Uint128":                 reflect.TypeOf((*_github_com_qiniu_x_xgo_ng.Uint128)(nil)).Elem(),
+		"Uint128_Cast__0":         _github_com_qiniu_x_xgo_ng.Uint128_Cast__0,
+		"Uint128_Cast__1":         _github_com_qiniu_x_xgo_ng.Uint128_Cast__1,
+		"Uint128_Cast__2":         _github_com_qiniu_x_xgo_ng.Uint128_Cast__2,
+		"Uint128_Cast__3":         _github_com_qiniu_x_xgo_ng.Uint128_Cast__3,
+		"Uint128_Cast__4":         _github_com_qiniu_x_xgo_ng.Uint128_Cast__4,
+		"Uint128_Cast__5":         _github_com_qiniu_x_xgo_ng.Uint128_Cast__5,
+		"Uint128_Cast__6":         _github_com_qiniu_x_xgo_ng.Uint128_Cast__6,
+		"Uint128_Cast__7":         _github_com_qiniu_x_xgo_ng.Uint128_Cast__7,
+		"Uint128_Cast__8":         _github_com_qiniu_x_xgo_ng.Uint128_Cast__8,
+		"Uint128_Cast__9":         _github_com_qiniu_x_xgo_ng.Uint128_Cast__9,
+		"Uint128_Cast__a":         _github_com_qiniu_x_xgo_ng.Uint128_Cast__a,
+		"Uint128_Cast__b":         _github_com_qiniu_x_xgo_ng.Uint128_Cast__b,
+		"Uint128_Cast__c":         _github_com_qiniu_x_xgo_ng.Uint128_Cast__c,
+		"Uint128_Init__0":         _github_com_qiniu_x_xgo_ng.Uint128_Init__0,
+		"Uint128_Init__1":         _github_com_qiniu_x_xgo_ng.Uint128_Init__1,
+		"UntypedBigfloat":         reflect.TypeOf((*_github_com_qiniu_x_xgo_ng.UntypedBigfloat)(nil)).Elem(),
+		"UntypedBigfloat_Default": reflect.TypeOf((*_github_com_qiniu_x_xgo_ng.UntypedBigfloat_Default)(nil)).Elem(),
+		"UntypedBigint":           reflect.TypeOf((*_github_com_qiniu_x_xgo_ng.UntypedBigint)(nil)).Elem(),
+		"UntypedBigint_Default":   reflect.TypeOf((*_github_com_qiniu_x_xgo_ng.UntypedBigint_Default)(nil)).Elem(),
+		"UntypedBigint_Init__0":   _github_com_qiniu_x_xgo_ng.UntypedBigint_Init__0,
+		"UntypedBigrat":           reflect.TypeOf((*_github_com_qiniu_x_xgo_ng.UntypedBigrat)(nil)).Elem(),
+		"UntypedBigrat_Default":   reflect.TypeOf((*_github_com_qiniu_x_xgo_ng.UntypedBigrat_Default)(nil)).Elem(),
+		"UntypedBigrat_Init__0":   _github_com_qiniu_x_xgo_ng.UntypedBigrat_Init__0,
+		"UntypedBigrat_Init__1":   _github_com_qiniu_x_xgo_ng.UntypedBigrat_Init__1,
+		"XGo_istmp":               _github_com_qiniu_x_xgo_ng.XGo_istmp,
+		"XGo_ninteger":            reflect.TypeOf((*_github_com_qiniu_x_xgo_ng.XGo_ninteger)(nil)).Elem(),
+	})
+}
@@ -0,0 +1,64 @@
+// Code generated by gen.go; DO NOT EDIT.
+
+package stdlib
+
+import (
+	_io "io"
+	"reflect"
+
+	"github.com/goplus/xgo/x/interp"
+)
+
+func init() {
+	interp.RegisterPackage("io", map[string]any{
+		"ByteReader":       reflect.TypeOf((*_io.ByteReader)(nil)).Elem(),
+		"ByteScanner":      reflect.TypeOf((*_io.ByteScanner)(nil)).Elem(),
+		"ByteWriter":       reflect.TypeOf((*_io.ByteWriter)(nil)).Elem(),
+		"Closer":           reflect.TypeOf((*_io.Closer)(nil)).Elem(),
+		"Copy":             _io.Copy,
+		"CopyBuffer":       _io.CopyBuffer,
+		"CopyN":            _io.CopyN,
+		"Discard":          &_io.Discard,
+		"EOF":              &_io.EOF,
+		"ErrClosedPipe":    &_io.ErrClosedPipe,
+		"ErrNoProgress":    &_io.ErrNoProgress,
+		"ErrShortBuffer":   &_io.ErrShortBuffer,
+		"ErrShortWrite":    &_io.ErrShortWrite,
+		"ErrUnexpectedEOF": &_io.ErrUnexpectedEOF,
+		"LimitReader":      _io.LimitReader,
+		"LimitedReader":    reflect.TypeOf((*_io.LimitedReader)(nil)).Elem(),
+		"MultiReader":      _io.MultiReader,
+		"MultiWriter":      _io.MultiWriter,
+		"NewOffsetWriter":  _io.NewOffsetWriter,
+		"NewSectionReader": _io.NewSectionReader,
+		"NopCloser":        _io.NopCloser,
+		"OffsetWriter":     reflect.TypeOf((*_io.OffsetWriter)(nil)).Elem(),
+		"Pipe":             _io.Pipe,
+		"PipeReader":       reflect.TypeOf((*_io.PipeReader)(nil)).Elem(),
+		"PipeWriter":       reflect.TypeOf((*_io.PipeWriter)(nil)).Elem(),
+		"ReadAll":          _io.ReadAll,
+		"ReadAtLeast":      _io.ReadAtLeast,
+		"ReadCloser":       reflect.TypeOf((*_io.ReadCloser)(nil)).Elem(),
+		"ReadFull":         _io.ReadFull,
+		"ReadSeekCloser":   reflect.TypeOf((*_io.ReadSeekCloser)(nil)).Elem(),
+		"ReadSeeker":       reflect.TypeOf((*_io.ReadSeeker)(nil)).Elem(),
+		"ReadWriteCloser":  reflect.TypeOf((*_io.ReadWriteCloser)(nil)).Elem(),
+		"ReadWriteSeeker":  reflect.TypeOf((*_io.ReadWriteSeeker)(nil)).Elem(),
+		"ReadWriter":       reflect.TypeOf((*_io.ReadWriter)(nil)).Elem(),
+		"Reader":           reflect.TypeOf((*_io.Reader)(nil)).Elem(),
+		"ReaderAt":         reflect.TypeOf((*_io.ReaderAt)(nil)).Elem(),
+		"ReaderFrom":       reflect.TypeOf((*_io.ReaderFrom)(nil)).Elem(),
+		"RuneReader":       reflect.TypeOf((*_io.RuneReader)(nil)).Elem(),
+		"RuneScanner":      reflect.TypeOf((*_io.RuneScanner)(nil)).Elem(),
+		"SectionReader":    reflect.TypeOf((*_io.SectionReader)(nil)).Elem(),
+		"Seeker":           reflect.TypeOf((*_io.Seeker)(nil)).Elem(),
+		"StringWriter":     reflect.TypeOf((*_io.StringWriter)(nil)).Elem(),
+		"TeeReader":        _io.TeeReader,
+		"WriteCloser":      reflect.TypeOf((*_io.WriteCloser)(nil)).Elem(),
+		"WriteSeeker":      reflect.TypeOf((*_io.WriteSeeker)(nil)).Elem(),
+		"WriteString":      _io.WriteString,
+		"Writer":           reflect.TypeOf((*_io.Writer)(nil)).Elem(),
+		"WriterAt":         reflect.TypeOf((*_io.WriterAt)(nil)).Elem(),
+		"WriterTo":         reflect.TypeOf((*_io.WriterTo)(nil)).Elem(),
+	})
+}
@@ -0,0 +1,81 @@
+// Code generated by gen.go; DO NOT EDIT.
+
+package stdlib
+
+import (
+	_math "math"
+
+	"github.com/goplus/xgo/x/interp"
+)
+
+func init() {
+	interp.RegisterPackage("math", map[string]any{
+		"Abs":             _math.Abs,
+		"Acos":            _math.Acos,
+		"Acosh":           _math.Acosh,
+		"Asin":            _math.Asin,
+		"Asinh":           _math.Asinh,
+		"Atan":            _math.Atan,
+		"Atan2":           _math.Atan2,
+		"Atanh":           _math.Atanh,
+		"Cbrt":            _math.Cbrt,
+		"Ceil":            _math.Ceil,
+		"Copysign":        _math.Copysign,
+		"Cos":             _math.Cos,
+		"Cosh":            _math.Cosh,
+		"Dim":             _math.Dim,
+		"Erf":             _math.Erf,
+		"Erfc":            _math.Erfc,
+		"Erfcinv":         _math.Erfcinv,
+		"Erfinv":          _math.Erfinv,
+		"Exp":             _math.Exp,
+		"Exp2":            _math.Exp2,
+		"Expm1":           _math.Expm1,
+		"FMA":             _math.FMA,
+		"Float32bits":     _math.Float32bits,
+		"Float32frombits": _math.Float32frombits,
+		"Float64bits":     _math.Float64bits,
+		"Float64frombits": _math.Float64frombits,
+		"Floor":           _math.Floor,
+		"Frexp":           _math.Frexp,
+		"Gamma":           _math.Gamma,
+		"Hypot":           _math.Hypot,
+		"Ilogb":           _math.Ilogb,
+		"Inf":             _math.Inf,
+		"IsInf":           _math.IsInf,
+		"IsNaN":           _math.IsNaN,
+		"J0":              _math.J0,
+		"J1":              _math.J1,
+		"Jn":              _math.Jn,
+		"Ldexp":           _math.Ldexp,
+		"Lgamma":          _math.Lgamma,
+		"Log":             _math.Log,
+		"Log10":           _math.Log10,
+		"Log1p":           _math.Log1p,
+		"Log2":            _math.Log2,
+		"Logb":            _math.Logb,
+		"Max":             _math.Max,
+		"Min":             _math.Min,
+		"Mod":             _math.Mod,
+		"Modf":            _math.Modf,
+		"NaN":             _math.NaN,
+		"Nextafter":       _math.Nextafter,
+		"Nextafter32":     _math.Nextafter32,
+		"Pow":             _math.Pow,
+		"Pow10":           _math.Pow10,
+		"Remainder":       _math.Remainder,
+		"Round":           _math.Round,
+		"RoundToEven":     _math.RoundToEven,
+		"Signbit":         _math.Signbit,
+		"Sin":             _math.Sin,
+		"Sincos":          _math.Sincos,
+		"Sinh":            _math.Sinh,
+		"Sqrt":            _math.Sqrt,
+		"Tan":             _math.Tan,
+		"Tanh":            _math.Tanh,
+		"Trunc":           _math.Trunc,
+		"Y0":              _math.Y0,
+		"Y1":              _math.Y1,
+		"Yn":              _math.Yn,
+	})
+}
@@ -0,0 +1,38 @@
+// Code generated by gen.go; DO NOT EDIT.
+
+package stdlib
+
+import (
+	_math_rand "math/rand"
+	"reflect"
+
+	"github.com/goplus/xgo/x/interp"
+)
+
+func init() {
+	interp.RegisterPackage("math/rand", map[string]any{
+		"ExpFloat64":  _math_rand.ExpFloat64,
+		"Float32":     _math_rand.Float32,
+		"Float64":     _math_rand.Float64,
+		"Int":         _math_rand.Int,
+		"Int31":       _math_rand.Int31,
+		"Int31n":      _math_rand.Int31n,
+		"Int63":       _math_rand.Int63,
+		"Int63n":      _math_rand.Int63n,
+		"Intn":        _math_rand.Intn,
+		"New":         _math_rand.New,
+		"NewSource":   _math_rand.NewSource,
+		"NewZipf":     _math_rand.NewZipf,
+		"NormFloat64": _math_rand.NormFloat64,
+		"Perm":        _math_rand.Perm,
+		"Rand":        reflect.TypeOf((*_math_rand.Rand)(nil)).Elem(),
+		"Read":        _math_rand.Read,
+		"Seed":        _math_rand.Seed,
+		"Shuffle":     _math_rand.Shuffle,
+		"Source":      reflect.TypeOf((*_math_rand.Source)(nil)).Elem(),
+		"Source64":    reflect.TypeOf((*_math_rand.Source64)(nil)).Elem(),
+		"Uint32":      _math_rand.Uint32,
+		"Uint64":      _math_rand.Uint64,
+		"Zipf":        reflect.TypeOf((*_math_rand.Zipf)(nil)).Elem(),
+	})
+}
@@ -0,0 +1,104 @@
+// Code generated by gen.go; DO NOT EDIT.
+
+package stdlib
+
+import (
+	_os "os"
+	"reflect"
+
+	"github.com/goplus/xgo/x/interp"
+)
+
+func init() {
+	interp.RegisterPackage("os", map[string]any{
+		"Args":                &_os.Args,
+		"Chdir":               _os.Chdir,
+		"Chmod":               _os.Chmod,
+		"Chown":               _os.Chown,
+		"Chtimes":             _os.Chtimes,
+		"Clearenv":            _os.Clearenv,
+		"CopyFS":              _os.CopyFS,
+		"Create":              _os.Create,
+		"CreateTemp":          _os.CreateTemp,
+		"DirEntry":            reflect.TypeOf((*_os.DirEntry)(nil)).Elem(),
+		"DirFS":               _os.DirFS,
+		"Environ":             _os.Environ,
+		"ErrClosed":           &_os.ErrClosed,
+		"ErrDeadlineExceeded": &_os.ErrDeadlineExceeded,
+		"ErrExist":            &_os.ErrExist,
+		"ErrInvalid":          &_os.ErrInvalid,
+		"ErrNoDeadline":       &_os.ErrNoDeadline,
+		"ErrNotExist":         &_os.ErrNotExist,
+		"ErrPermission":       &_os.ErrPermission,
+		"ErrProcessDone":      &_os.ErrProcessDone,
+		"Executable":          _os.Executable,
+		"Exit":                _os.Exit,
+		"Expand":              _os.Expand,
+		"ExpandEnv":           _os.ExpandEnv,
+		"File":                reflect.TypeOf((*_os.File)(nil)).Elem(),
+		"FileInfo":            reflect.TypeOf((*_os.FileInfo)(nil)).Elem(),
+		"FileMode":            reflect.TypeOf((*_os.FileMode)(nil)).Elem(),
+		"FindProcess":         _os.FindProcess,
+		"Getegid":             _os.Getegid,
+		"Getenv":              _os.Getenv,
+		"Geteuid":             _os.Geteuid,
+		"Getgid":              _os.Getgid,
+		"Getgroups":           _os.Getgroups,
+		"Getpagesize":         _os.Getpagesize,
+		"Getpid":              _os.Getpid,
+		"Getppid":             _os.Getppid,
+		"Getuid":              _os.Getuid,
+		"Getwd":               _os.Getwd,
+		"Hostname":            _os.Hostname,
+		"Interrupt":           &_os.Interrupt,
+		"IsExist":             _os.IsExist,
+		"IsNotExist":          _os.IsNotExist,
+		"IsPathSeparator":     _os.IsPathSeparator,
+		"IsPermission":        _os.IsPermission,
+		"IsTimeout":           _os.IsTimeout,
+		"Kill":                &_os.Kill,
+		"Lchown":              _os.Lchown,
+		"Link":                _os.Link,
+		"LinkError":           reflect.TypeOf((*_os.LinkError)(nil)).Elem(),
+		"LookupEnv":           _os.LookupEnv,
+		"Lstat":               _os.Lstat,
+		"Mkdir":               _os.Mkdir,
+		"MkdirAll":            _os.MkdirAll,
+		"MkdirTemp":           _os.MkdirTemp,
+		"NewFile":             _os.NewFile,
+		"NewSyscallError":     _os.NewSyscallError,
+		"Open":                _os.Open,
+		"OpenFile":            _os.OpenFile,
+		"OpenInRoot":          _os.OpenInRoot,
+		"OpenRoot":            _os.OpenRoot,
+		"PathError":           reflect.TypeOf((*_os.PathError)(nil)).Elem(),
+		"Pipe":                _os.Pipe,
+		"ProcAttr":            reflect.TypeOf((*_os.ProcAttr)(nil)).Elem(),
+		"Process":             reflect.TypeOf((*_os.Process)(nil)).Elem(),
+		"ProcessState":        reflect.TypeOf((*_os.ProcessState)(nil)).Elem(),
+		"ReadDir":             _os.ReadDir,
+		"ReadFile":            _os.ReadFile,
+		"Readlink":            _os.Readlink,
+		"Remove":              _os.Remove,
+		"RemoveAll":           _os.RemoveAll,
+		"Rename":              _os.Rename,
+		"Root":                reflect.TypeOf((*_os.Root)(nil)).Elem(),
+		"SameFile":            _os.SameFile,
+		"Setenv":              _os.Setenv,
+		"Signal":              reflect.TypeOf((*_os.Signal)(nil)).Elem(),
+		"StartProcess":        _os.StartProcess,
+		"Stat":                _os.Stat,
+		"Stderr":              &_os.Stderr,
+		"Stdin":               &_os.Stdin,
+		"Stdout":              &_os.Stdout,
+		"Symlink":             _os.Symlink,
+		"SyscallError":        reflect.TypeOf((*_os.SyscallError)(nil)).Elem(),
+		"TempDir":             _os.TempDir,
+		"Truncate":            _os.Truncate,
+		"Unsetenv":            _os.Unsetenv,
+		"UserCacheDir":        _os.UserCacheDir,
+		"UserConfigDir":       _os.UserConfigDir,
+		"UserHomeDir":         _os.UserHomeDir,
+		"WriteFile":           _os.WriteFile,
+	})
+}
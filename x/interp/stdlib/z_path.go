@@ -0,0 +1,23 @@
+// Code generated by gen.go; DO NOT EDIT.
+
+package stdlib
+
+import (
+	_path "path"
+
+	"github.com/goplus/xgo/x/interp"
+)
+
+func init() {
+	interp.RegisterPackage("path", map[string]any{
+		"Base":          _path.Base,
+		"Clean":         _path.Clean,
+		"Dir":           _path.Dir,
+		"ErrBadPattern": &_path.ErrBadPattern,
+		"Ext":           _path.Ext,
+		"IsAbs":         _path.IsAbs,
+		"Join":          _path.Join,
+		"Match":         _path.Match,
+		"Split":         _path.Split,
+	})
+}
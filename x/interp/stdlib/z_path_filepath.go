@@ -0,0 +1,40 @@
+// Code generated by gen.go; DO NOT EDIT.
+
+package stdlib
+
+import (
+	_path_filepath "path/filepath"
+	"reflect"
+
+	"github.com/goplus/xgo/x/interp"
+)
+
+func init() {
+	interp.RegisterPackage("path/filepath", map[string]any{
+		"Abs":           _path_filepath.Abs,
+		"Base":          _path_filepath.Base,
+		"Clean":         _path_filepath.Clean,
+		"Dir":           _path_filepath.Dir,
+		"ErrBadPattern": &_path_filepath.ErrBadPattern,
+		"EvalSymlinks":  _path_filepath.EvalSymlinks,
+		"Ext":           _path_filepath.Ext,
+		"FromSlash":     _path_filepath.FromSlash,
+		"Glob":          _path_filepath.Glob,
+		"HasPrefix":     _path_filepath.HasPrefix,
+		"IsAbs":         _path_filepath.IsAbs,
+		"IsLocal":       _path_filepath.IsLocal,
+		"Join":          _path_filepath.Join,
+		"Localize":      _path_filepath.Localize,
+		"Match":         _path_filepath.Match,
+		"Rel":           _path_filepath.Rel,
+		"SkipAll":       &_path_filepath.SkipAll,
+		"SkipDir":       &_path_filepath.SkipDir,
+		"Split":         _path_filepath.Split,
+		"SplitList":     _path_filepath.SplitList,
+		"ToSlash":       _path_filepath.ToSlash,
+		"VolumeName":    _path_filepath.VolumeName,
+		"Walk":          _path_filepath.Walk,
+		"WalkDir":       _path_filepath.WalkDir,
+		"WalkFunc":      reflect.TypeOf((*_path_filepath.WalkFunc)(nil)).Elem(),
+	})
+}
@@ -0,0 +1,55 @@
+// Code generated by gen.go; DO NOT EDIT.
+
+package stdlib
+
+import (
+	"reflect"
+	_reflect "reflect"
+
+	"github.com/goplus/xgo/x/interp"
+)
+
+func init() {
+	interp.RegisterPackage("reflect", map[string]any{
+		"Append":          _reflect.Append,
+		"AppendSlice":     _reflect.AppendSlice,
+		"ArrayOf":         _reflect.ArrayOf,
+		"ChanDir":         reflect.TypeOf((*_reflect.ChanDir)(nil)).Elem(),
+		"ChanOf":          _reflect.ChanOf,
+		"Copy":            _reflect.Copy,
+		"DeepEqual":       _reflect.DeepEqual,
+		"FuncOf":          _reflect.FuncOf,
+		"Indirect":        _reflect.Indirect,
+		"Kind":            reflect.TypeOf((*_reflect.Kind)(nil)).Elem(),
+		"MakeChan":        _reflect.MakeChan,
+		"MakeFunc":        _reflect.MakeFunc,
+		"MakeMap":         _reflect.MakeMap,
+		"MakeMapWithSize": _reflect.MakeMapWithSize,
+		"MakeSlice":       _reflect.MakeSlice,
+		"MapIter":         reflect.TypeOf((*_reflect.MapIter)(nil)).Elem(),
+		"MapOf":           _reflect.MapOf,
+		"Method":          reflect.TypeOf((*_reflect.Method)(nil)).Elem(),
+		"New":             _reflect.New,
+		"NewAt":           _reflect.NewAt,
+		"PointerTo":       _reflect.PointerTo,
+		"PtrTo":           _reflect.PtrTo,
+		"Select":          _reflect.Select,
+		"SelectCase":      reflect.TypeOf((*_reflect.SelectCase)(nil)).Elem(),
+		"SelectDir":       reflect.TypeOf((*_reflect.SelectDir)(nil)).Elem(),
+		"SliceAt":         _reflect.SliceAt,
+		"SliceHeader":     reflect.TypeOf((*_reflect.SliceHeader)(nil)).Elem(),
+		"SliceOf":         _reflect.SliceOf,
+		"StringHeader":    reflect.TypeOf((*_reflect.StringHeader)(nil)).Elem(),
+		"StructField":     reflect.TypeOf((*_reflect.StructField)(nil)).Elem(),
+		"StructOf":        _reflect.StructOf,
+		"StructTag":       reflect.TypeOf((*_reflect.StructTag)(nil)).Elem(),
+		"Swapper":         _reflect.Swapper,
+		"Type":            reflect.TypeOf((*_reflect.Type)(nil)).Elem(),
+		"TypeOf":          _reflect.TypeOf,
+		"Value":           reflect.TypeOf((*_reflect.Value)(nil)).Elem(),
+		"ValueError":      reflect.TypeOf((*_reflect.ValueError)(nil)).Elem(),
+		"ValueOf":         _reflect.ValueOf,
+		"VisibleFields":   _reflect.VisibleFields,
+		"Zero":            _reflect.Zero,
+	})
+}
@@ -0,0 +1,24 @@
+// Code generated by gen.go; DO NOT EDIT.
+
+package stdlib
+
+import (
+	"reflect"
+	_regexp "regexp"
+
+	"github.com/goplus/xgo/x/interp"
+)
+
+func init() {
+	interp.RegisterPackage("regexp", map[string]any{
+		"Compile":          _regexp.Compile,
+		"CompilePOSIX":     _regexp.CompilePOSIX,
+		"Match":            _regexp.Match,
+		"MatchReader":      _regexp.MatchReader,
+		"MatchString":      _regexp.MatchString,
+		"MustCompile":      _regexp.MustCompile,
+		"MustCompilePOSIX": _regexp.MustCompilePOSIX,
+		"QuoteMeta":        _regexp.QuoteMeta,
+		"Regexp":           reflect.TypeOf((*_regexp.Regexp)(nil)).Elem(),
+	})
+}
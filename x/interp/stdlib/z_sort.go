@@ -0,0 +1,37 @@
+// Code generated by gen.go; DO NOT EDIT.
+
+package stdlib
+
+import (
+	"reflect"
+	_sort "sort"
+
+	"github.com/goplus/xgo/x/interp"
+)
+
+func init() {
+	interp.RegisterPackage("sort", map[string]any{
+		"Find":              _sort.Find,
+		"Float64Slice":      reflect.TypeOf((*_sort.Float64Slice)(nil)).Elem(),
+		"Float64s":          _sort.Float64s,
+		"Float64sAreSorted": _sort.Float64sAreSorted,
+		"IntSlice":          reflect.TypeOf((*_sort.IntSlice)(nil)).Elem(),
+		"Interface":         reflect.TypeOf((*_sort.Interface)(nil)).Elem(),
+		"Ints":              _sort.Ints,
+		"IntsAreSorted":     _sort.IntsAreSorted,
+		"IsSorted":          _sort.IsSorted,
+		"Reverse":           _sort.Reverse,
+		"Search":            _sort.Search,
+		"SearchFloat64s":    _sort.SearchFloat64s,
+		"SearchInts":        _sort.SearchInts,
+		"SearchStrings":     _sort.SearchStrings,
+		"Slice":             _sort.Slice,
+		"SliceIsSorted":     _sort.SliceIsSorted,
+		"SliceStable":       _sort.SliceStable,
+		"Sort":              _sort.Sort,
+		"Stable":            _sort.Stable,
+		"StringSlice":       reflect.TypeOf((*_sort.StringSlice)(nil)).Elem(),
+		"Strings":           _sort.Strings,
+		"StringsAreSorted":  _sort.StringsAreSorted,
+	})
+}
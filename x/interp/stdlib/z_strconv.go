@@ -0,0 +1,52 @@
+// Code generated by gen.go; DO NOT EDIT.
+
+package stdlib
+
+import (
+	"reflect"
+	_strconv "strconv"
+
+	"github.com/goplus/xgo/x/interp"
+)
+
+func init() {
+	interp.RegisterPackage("strconv", map[string]any{
+		"AppendBool":               _strconv.AppendBool,
+		"AppendFloat":              _strconv.AppendFloat,
+		"AppendInt":                _strconv.AppendInt,
+		"AppendQuote":              _strconv.AppendQuote,
+		"AppendQuoteRune":          _strconv.AppendQuoteRune,
+		"AppendQuoteRuneToASCII":   _strconv.AppendQuoteRuneToASCII,
+		"AppendQuoteRuneToGraphic": _strconv.AppendQuoteRuneToGraphic,
+		"AppendQuoteToASCII":       _strconv.AppendQuoteToASCII,
+		"AppendQuoteToGraphic":     _strconv.AppendQuoteToGraphic,
+		"AppendUint":               _strconv.AppendUint,
+		"Atoi":                     _strconv.Atoi,
+		"CanBackquote":             _strconv.CanBackquote,
+		"ErrRange":                 &_strconv.ErrRange,
+		"ErrSyntax":                &_strconv.ErrSyntax,
+		"FormatBool":               _strconv.FormatBool,
+		"FormatComplex":            _strconv.FormatComplex,
+		"FormatFloat":              _strconv.FormatFloat,
+		"FormatInt":                _strconv.FormatInt,
+		"FormatUint":               _strconv.FormatUint,
+		"IsGraphic":                _strconv.IsGraphic,
+		"IsPrint":                  _strconv.IsPrint,
+		"Itoa":                     _strconv.Itoa,
+		"NumError":                 reflect.TypeOf((*_strconv.NumError)(nil)).Elem(),
+		"ParseBool":                _strconv.ParseBool,
+		"ParseComplex":             _strconv.ParseComplex,
+		"ParseFloat":               _strconv.ParseFloat,
+		"ParseInt":                 _strconv.ParseInt,
+		"ParseUint":                _strconv.ParseUint,
+		"Quote":                    _strconv.Quote,
+		"QuoteRune":                _strconv.QuoteRune,
+		"QuoteRuneToASCII":         _strconv.QuoteRuneToASCII,
+		"QuoteRuneToGraphic":       _strconv.QuoteRuneToGraphic,
+		"QuoteToASCII":             _strconv.QuoteToASCII,
+		"QuoteToGraphic":           _strconv.QuoteToGraphic,
+		"QuotedPrefix":             _strconv.QuotedPrefix,
+		"Unquote":                  _strconv.Unquote,
+		"UnquoteChar":              _strconv.UnquoteChar,
+	})
+}
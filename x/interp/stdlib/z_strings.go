@@ -0,0 +1,75 @@
+// Code generated by gen.go; DO NOT EDIT.
+
+package stdlib
+
+import (
+	"reflect"
+	_strings "strings"
+
+	"github.com/goplus/xgo/x/interp"
+)
+
+func init() {
+	interp.RegisterPackage("strings", map[string]any{
+		"Builder":        reflect.TypeOf((*_strings.Builder)(nil)).Elem(),
+		"Clone":          _strings.Clone,
+		"Compare":        _strings.Compare,
+		"Contains":       _strings.Contains,
+		"ContainsAny":    _strings.ContainsAny,
+		"ContainsFunc":   _strings.ContainsFunc,
+		"ContainsRune":   _strings.ContainsRune,
+		"Count":          _strings.Count,
+		"Cut":            _strings.Cut,
+		"CutPrefix":      _strings.CutPrefix,
+		"CutSuffix":      _strings.CutSuffix,
+		"EqualFold":      _strings.EqualFold,
+		"Fields":         _strings.Fields,
+		"FieldsFunc":     _strings.FieldsFunc,
+		"FieldsFuncSeq":  _strings.FieldsFuncSeq,
+		"FieldsSeq":      _strings.FieldsSeq,
+		"HasPrefix":      _strings.HasPrefix,
+		"HasSuffix":      _strings.HasSuffix,
+		"Index":          _strings.Index,
+		"IndexAny":       _strings.IndexAny,
+		"IndexByte":      _strings.IndexByte,
+		"IndexFunc":      _strings.IndexFunc,
+		"IndexRune":      _strings.IndexRune,
+		"Join":           _strings.Join,
+		"LastIndex":      _strings.LastIndex,
+		"LastIndexAny":   _strings.LastIndexAny,
+		"LastIndexByte":  _strings.LastIndexByte,
+		"LastIndexFunc":  _strings.LastIndexFunc,
+		"Lines":          _strings.Lines,
+		"Map":            _strings.Map,
+		"NewReader":      _strings.NewReader,
+		"NewReplacer":    _strings.NewReplacer,
+		"Reader":         reflect.TypeOf((*_strings.Reader)(nil)).Elem(),
+		"Repeat":         _strings.Repeat,
+		"Replace":        _strings.Replace,
+		"ReplaceAll":     _strings.ReplaceAll,
+		"Replacer":       reflect.TypeOf((*_strings.Replacer)(nil)).Elem(),
+		"Split":          _strings.Split,
+		"SplitAfter":     _strings.SplitAfter,
+		"SplitAfterN":    _strings.SplitAfterN,
+		"SplitAfterSeq":  _strings.SplitAfterSeq,
+		"SplitN":         _strings.SplitN,
+		"SplitSeq":       _strings.SplitSeq,
+		"Title":          _strings.Title,
+		"ToLower":        _strings.ToLower,
+		"ToLowerSpecial": _strings.ToLowerSpecial,
+		"ToTitle":        _strings.ToTitle,
+		"ToTitleSpecial": _strings.ToTitleSpecial,
+		"ToUpper":        _strings.ToUpper,
+		"ToUpperSpecial": _strings.ToUpperSpecial,
+		"ToValidUTF8":    _strings.ToValidUTF8,
+		"Trim":           _strings.Trim,
+		"TrimFunc":       _strings.TrimFunc,
+		"TrimLeft":       _strings.TrimLeft,
+		"TrimLeftFunc":   _strings.TrimLeftFunc,
+		"TrimPrefix":     _strings.TrimPrefix,
+		"TrimRight":      _strings.TrimRight,
+		"TrimRightFunc":  _strings.TrimRightFunc,
+		"TrimSpace":      _strings.TrimSpace,
+		"TrimSuffix":     _strings.TrimSuffix,
+	})
+}
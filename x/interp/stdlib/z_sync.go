@@ -0,0 +1,25 @@
+// Code generated by gen.go; DO NOT EDIT.
+
+package stdlib
+
+import (
+	"reflect"
+	_sync "sync"
+
+	"github.com/goplus/xgo/x/interp"
+)
+
+func init() {
+	interp.RegisterPackage("sync", map[string]any{
+		"Cond":      reflect.TypeOf((*_sync.Cond)(nil)).Elem(),
+		"Locker":    reflect.TypeOf((*_sync.Locker)(nil)).Elem(),
+		"Map":       reflect.TypeOf((*_sync.Map)(nil)).Elem(),
+		"Mutex":     reflect.TypeOf((*_sync.Mutex)(nil)).Elem(),
+		"NewCond":   _sync.NewCond,
+		"Once":      reflect.TypeOf((*_sync.Once)(nil)).Elem(),
+		"OnceFunc":  _sync.OnceFunc,
+		"Pool":      reflect.TypeOf((*_sync.Pool)(nil)).Elem(),
+		"RWMutex":   reflect.TypeOf((*_sync.RWMutex)(nil)).Elem(),
+		"WaitGroup": reflect.TypeOf((*_sync.WaitGroup)(nil)).Elem(),
+	})
+}
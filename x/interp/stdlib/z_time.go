@@ -0,0 +1,44 @@
+// Code generated by gen.go; DO NOT EDIT.
+
+package stdlib
+
+import (
+	"reflect"
+	_time "time"
+
+	"github.com/goplus/xgo/x/interp"
+)
+
+func init() {
+	interp.RegisterPackage("time", map[string]any{
+		"After":                  _time.After,
+		"AfterFunc":              _time.AfterFunc,
+		"Date":                   _time.Date,
+		"Duration":               reflect.TypeOf((*_time.Duration)(nil)).Elem(),
+		"FixedZone":              _time.FixedZone,
+		"LoadLocation":           _time.LoadLocation,
+		"LoadLocationFromTZData": _time.LoadLocationFromTZData,
+		"Local":                  &_time.Local,
+		"Location":               reflect.TypeOf((*_time.Location)(nil)).Elem(),
+		"Month":                  reflect.TypeOf((*_time.Month)(nil)).Elem(),
+		"NewTicker":              _time.NewTicker,
+		"NewTimer":               _time.NewTimer,
+		"Now":                    _time.Now,
+		"Parse":                  _time.Parse,
+		"ParseDuration":          _time.ParseDuration,
+		"ParseError":             reflect.TypeOf((*_time.ParseError)(nil)).Elem(),
+		"ParseInLocation":        _time.ParseInLocation,
+		"Since":                  _time.Since,
+		"Sleep":                  _time.Sleep,
+		"Tick":                   _time.Tick,
+		"Ticker":                 reflect.TypeOf((*_time.Ticker)(nil)).Elem(),
+		"Time":                   reflect.TypeOf((*_time.Time)(nil)).Elem(),
+		"Timer":                  reflect.TypeOf((*_time.Timer)(nil)).Elem(),
+		"UTC":                    &_time.UTC,
+		"Unix":                   _time.Unix,
+		"UnixMicro":              _time.UnixMicro,
+		"UnixMilli":              _time.UnixMilli,
+		"Until":                  _time.Until,
+		"Weekday":                reflect.TypeOf((*_time.Weekday)(nil)).Elem(),
+	})
+}
@@ -0,0 +1,296 @@
+// Code generated by gen.go; DO NOT EDIT.
+
+package stdlib
+
+import (
+	"reflect"
+	_unicode "unicode"
+
+	"github.com/goplus/xgo/x/interp"
+)
+
+func init() {
+	interp.RegisterPackage("unicode", map[string]any{
+		"ASCII_Hex_Digit":                    &_unicode.ASCII_Hex_Digit,
+		"Adlam":                              &_unicode.Adlam,
+		"Ahom":                               &_unicode.Ahom,
+		"Anatolian_Hieroglyphs":              &_unicode.Anatolian_Hieroglyphs,
+		"Arabic":                             &_unicode.Arabic,
+		"Armenian":                           &_unicode.Armenian,
+		"Avestan":                            &_unicode.Avestan,
+		"AzeriCase":                          &_unicode.AzeriCase,
+		"Balinese":                           &_unicode.Balinese,
+		"Bamum":                              &_unicode.Bamum,
+		"Bassa_Vah":                          &_unicode.Bassa_Vah,
+		"Batak":                              &_unicode.Batak,
+		"Bengali":                            &_unicode.Bengali,
+		"Bhaiksuki":                          &_unicode.Bhaiksuki,
+		"Bidi_Control":                       &_unicode.Bidi_Control,
+		"Bopomofo":                           &_unicode.Bopomofo,
+		"Brahmi":                             &_unicode.Brahmi,
+		"Braille":                            &_unicode.Braille,
+		"Buginese":                           &_unicode.Buginese,
+		"Buhid":                              &_unicode.Buhid,
+		"C":                                  &_unicode.C,
+		"Canadian_Aboriginal":                &_unicode.Canadian_Aboriginal,
+		"Carian":                             &_unicode.Carian,
+		"CaseRange":                          reflect.TypeOf((*_unicode.CaseRange)(nil)).Elem(),
+		"CaseRanges":                         &_unicode.CaseRanges,
+		"Categories":                         &_unicode.Categories,
+		"Caucasian_Albanian":                 &_unicode.Caucasian_Albanian,
+		"Cc":                                 &_unicode.Cc,
+		"Cf":                                 &_unicode.Cf,
+		"Chakma":                             &_unicode.Chakma,
+		"Cham":                               &_unicode.Cham,
+		"Cherokee":                           &_unicode.Cherokee,
+		"Chorasmian":                         &_unicode.Chorasmian,
+		"Co":                                 &_unicode.Co,
+		"Common":                             &_unicode.Common,
+		"Coptic":                             &_unicode.Coptic,
+		"Cs":                                 &_unicode.Cs,
+		"Cuneiform":                          &_unicode.Cuneiform,
+		"Cypriot":                            &_unicode.Cypriot,
+		"Cypro_Minoan":                       &_unicode.Cypro_Minoan,
+		"Cyrillic":                           &_unicode.Cyrillic,
+		"Dash":                               &_unicode.Dash,
+		"Deprecated":                         &_unicode.Deprecated,
+		"Deseret":                            &_unicode.Deseret,
+		"Devanagari":                         &_unicode.Devanagari,
+		"Diacritic":                          &_unicode.Diacritic,
+		"Digit":                              &_unicode.Digit,
+		"Dives_Akuru":                        &_unicode.Dives_Akuru,
+		"Dogra":                              &_unicode.Dogra,
+		"Duployan":                           &_unicode.Duployan,
+		"Egyptian_Hieroglyphs":               &_unicode.Egyptian_Hieroglyphs,
+		"Elbasan":                            &_unicode.Elbasan,
+		"Elymaic":                            &_unicode.Elymaic,
+		"Ethiopic":                           &_unicode.Ethiopic,
+		"Extender":                           &_unicode.Extender,
+		"FoldCategory":                       &_unicode.FoldCategory,
+		"FoldScript":                         &_unicode.FoldScript,
+		"Georgian":                           &_unicode.Georgian,
+		"Glagolitic":                         &_unicode.Glagolitic,
+		"Gothic":                             &_unicode.Gothic,
+		"Grantha":                            &_unicode.Grantha,
+		"GraphicRanges":                      &_unicode.GraphicRanges,
+		"Greek":                              &_unicode.Greek,
+		"Gujarati":                           &_unicode.Gujarati,
+		"Gunjala_Gondi":                      &_unicode.Gunjala_Gondi,
+		"Gurmukhi":                           &_unicode.Gurmukhi,
+		"Han":                                &_unicode.Han,
+		"Hangul":                             &_unicode.Hangul,
+		"Hanifi_Rohingya":                    &_unicode.Hanifi_Rohingya,
+		"Hanunoo":                            &_unicode.Hanunoo,
+		"Hatran":                             &_unicode.Hatran,
+		"Hebrew":                             &_unicode.Hebrew,
+		"Hex_Digit":                          &_unicode.Hex_Digit,
+		"Hiragana":                           &_unicode.Hiragana,
+		"Hyphen":                             &_unicode.Hyphen,
+		"IDS_Binary_Operator":                &_unicode.IDS_Binary_Operator,
+		"IDS_Trinary_Operator":               &_unicode.IDS_Trinary_Operator,
+		"Ideographic":                        &_unicode.Ideographic,
+		"Imperial_Aramaic":                   &_unicode.Imperial_Aramaic,
+		"In":                                 _unicode.In,
+		"Inherited":                          &_unicode.Inherited,
+		"Inscriptional_Pahlavi":              &_unicode.Inscriptional_Pahlavi,
+		"Inscriptional_Parthian":             &_unicode.Inscriptional_Parthian,
+		"Is":                                 _unicode.Is,
+		"IsControl":                          _unicode.IsControl,
+		"IsDigit":                            _unicode.IsDigit,
+		"IsGraphic":                          _unicode.IsGraphic,
+		"IsLetter":                           _unicode.IsLetter,
+		"IsLower":                            _unicode.IsLower,
+		"IsMark":                             _unicode.IsMark,
+		"IsNumber":                           _unicode.IsNumber,
+		"IsOneOf":                            _unicode.IsOneOf,
+		"IsPrint":                            _unicode.IsPrint,
+		"IsPunct":                            _unicode.IsPunct,
+		"IsSpace":                            _unicode.IsSpace,
+		"IsSymbol":                           _unicode.IsSymbol,
+		"IsTitle":                            _unicode.IsTitle,
+		"IsUpper":                            _unicode.IsUpper,
+		"Javanese":                           &_unicode.Javanese,
+		"Join_Control":                       &_unicode.Join_Control,
+		"Kaithi":                             &_unicode.Kaithi,
+		"Kannada":                            &_unicode.Kannada,
+		"Katakana":                           &_unicode.Katakana,
+		"Kawi":                               &_unicode.Kawi,
+		"Kayah_Li":                           &_unicode.Kayah_Li,
+		"Kharoshthi":                         &_unicode.Kharoshthi,
+		"Khitan_Small_Script":                &_unicode.Khitan_Small_Script,
+		"Khmer":                              &_unicode.Khmer,
+		"Khojki":                             &_unicode.Khojki,
+		"Khudawadi":                          &_unicode.Khudawadi,
+		"L":                                  &_unicode.L,
+		"Lao":                                &_unicode.Lao,
+		"Latin":                              &_unicode.Latin,
+		"Lepcha":                             &_unicode.Lepcha,
+		"Letter":                             &_unicode.Letter,
+		"Limbu":                              &_unicode.Limbu,
+		"Linear_A":                           &_unicode.Linear_A,
+		"Linear_B":                           &_unicode.Linear_B,
+		"Lisu":                               &_unicode.Lisu,
+		"Ll":                                 &_unicode.Ll,
+		"Lm":                                 &_unicode.Lm,
+		"Lo":                                 &_unicode.Lo,
+		"Logical_Order_Exception":            &_unicode.Logical_Order_Exception,
+		"Lower":                              &_unicode.Lower,
+		"Lt":                                 &_unicode.Lt,
+		"Lu":                                 &_unicode.Lu,
+		"Lycian":                             &_unicode.Lycian,
+		"Lydian":                             &_unicode.Lydian,
+		"M":                                  &_unicode.M,
+		"Mahajani":                           &_unicode.Mahajani,
+		"Makasar":                            &_unicode.Makasar,
+		"Malayalam":                          &_unicode.Malayalam,
+		"Mandaic":                            &_unicode.Mandaic,
+		"Manichaean":                         &_unicode.Manichaean,
+		"Marchen":                            &_unicode.Marchen,
+		"Mark":                               &_unicode.Mark,
+		"Masaram_Gondi":                      &_unicode.Masaram_Gondi,
+		"Mc":                                 &_unicode.Mc,
+		"Me":                                 &_unicode.Me,
+		"Medefaidrin":                        &_unicode.Medefaidrin,
+		"Meetei_Mayek":                       &_unicode.Meetei_Mayek,
+		"Mende_Kikakui":                      &_unicode.Mende_Kikakui,
+		"Meroitic_Cursive":                   &_unicode.Meroitic_Cursive,
+		"Meroitic_Hieroglyphs":               &_unicode.Meroitic_Hieroglyphs,
+		"Miao":                               &_unicode.Miao,
+		"Mn":                                 &_unicode.Mn,
+		"Modi":                               &_unicode.Modi,
+		"Mongolian":                          &_unicode.Mongolian,
+		"Mro":                                &_unicode.Mro,
+		"Multani":                            &_unicode.Multani,
+		"Myanmar":                            &_unicode.Myanmar,
+		"N":                                  &_unicode.N,
+		"Nabataean":                          &_unicode.Nabataean,
+		"Nag_Mundari":                        &_unicode.Nag_Mundari,
+		"Nandinagari":                        &_unicode.Nandinagari,
+		"Nd":                                 &_unicode.Nd,
+		"New_Tai_Lue":                        &_unicode.New_Tai_Lue,
+		"Newa":                               &_unicode.Newa,
+		"Nko":                                &_unicode.Nko,
+		"Nl":                                 &_unicode.Nl,
+		"No":                                 &_unicode.No,
+		"Noncharacter_Code_Point":            &_unicode.Noncharacter_Code_Point,
+		"Number":                             &_unicode.Number,
+		"Nushu":                              &_unicode.Nushu,
+		"Nyiakeng_Puachue_Hmong":             &_unicode.Nyiakeng_Puachue_Hmong,
+		"Ogham":                              &_unicode.Ogham,
+		"Ol_Chiki":                           &_unicode.Ol_Chiki,
+		"Old_Hungarian":                      &_unicode.Old_Hungarian,
+		"Old_Italic":                         &_unicode.Old_Italic,
+		"Old_North_Arabian":                  &_unicode.Old_North_Arabian,
+		"Old_Permic":                         &_unicode.Old_Permic,
+		"Old_Persian":                        &_unicode.Old_Persian,
+		"Old_Sogdian":                        &_unicode.Old_Sogdian,
+		"Old_South_Arabian":                  &_unicode.Old_South_Arabian,
+		"Old_Turkic":                         &_unicode.Old_Turkic,
+		"Old_Uyghur":                         &_unicode.Old_Uyghur,
+		"Oriya":                              &_unicode.Oriya,
+		"Osage":                              &_unicode.Osage,
+		"Osmanya":                            &_unicode.Osmanya,
+		"Other":                              &_unicode.Other,
+		"Other_Alphabetic":                   &_unicode.Other_Alphabetic,
+		"Other_Default_Ignorable_Code_Point": &_unicode.Other_Default_Ignorable_Code_Point,
+		"Other_Grapheme_Extend":              &_unicode.Other_Grapheme_Extend,
+		"Other_ID_Continue":                  &_unicode.Other_ID_Continue,
+		"Other_ID_Start":                     &_unicode.Other_ID_Start,
+		"Other_Lowercase":                    &_unicode.Other_Lowercase,
+		"Other_Math":                         &_unicode.Other_Math,
+		"Other_Uppercase":                    &_unicode.Other_Uppercase,
+		"P":                                  &_unicode.P,
+		"Pahawh_Hmong":                       &_unicode.Pahawh_Hmong,
+		"Palmyrene":                          &_unicode.Palmyrene,
+		"Pattern_Syntax":                     &_unicode.Pattern_Syntax,
+		"Pattern_White_Space":                &_unicode.Pattern_White_Space,
+		"Pau_Cin_Hau":                        &_unicode.Pau_Cin_Hau,
+		"Pc":                                 &_unicode.Pc,
+		"Pd":                                 &_unicode.Pd,
+		"Pe":                                 &_unicode.Pe,
+		"Pf":                                 &_unicode.Pf,
+		"Phags_Pa":                           &_unicode.Phags_Pa,
+		"Phoenician":                         &_unicode.Phoenician,
+		"Pi":                                 &_unicode.Pi,
+		"Po":                                 &_unicode.Po,
+		"Prepended_Concatenation_Mark":       &_unicode.Prepended_Concatenation_Mark,
+		"PrintRanges":                        &_unicode.PrintRanges,
+		"Properties":                         &_unicode.Properties,
+		"Ps":                                 &_unicode.Ps,
+		"Psalter_Pahlavi":                    &_unicode.Psalter_Pahlavi,
+		"Punct":                              &_unicode.Punct,
+		"Quotation_Mark":                     &_unicode.Quotation_Mark,
+		"Radical":                            &_unicode.Radical,
+		"Range16":                            reflect.TypeOf((*_unicode.Range16)(nil)).Elem(),
+		"Range32":                            reflect.TypeOf((*_unicode.Range32)(nil)).Elem(),
+		"RangeTable":                         reflect.TypeOf((*_unicode.RangeTable)(nil)).Elem(),
+		"Regional_Indicator":                 &_unicode.Regional_Indicator,
+		"Rejang":                             &_unicode.Rejang,
+		"Runic":                              &_unicode.Runic,
+		"S":                                  &_unicode.S,
+		"STerm":                              &_unicode.STerm,
+		"Samaritan":                          &_unicode.Samaritan,
+		"Saurashtra":                         &_unicode.Saurashtra,
+		"Sc":                                 &_unicode.Sc,
+		"Scripts":                            &_unicode.Scripts,
+		"Sentence_Terminal":                  &_unicode.Sentence_Terminal,
+		"Sharada":                            &_unicode.Sharada,
+		"Shavian":                            &_unicode.Shavian,
+		"Siddham":                            &_unicode.Siddham,
+		"SignWriting":                        &_unicode.SignWriting,
+		"SimpleFold":                         _unicode.SimpleFold,
+		"Sinhala":                            &_unicode.Sinhala,
+		"Sk":                                 &_unicode.Sk,
+		"Sm":                                 &_unicode.Sm,
+		"So":                                 &_unicode.So,
+		"Soft_Dotted":                        &_unicode.Soft_Dotted,
+		"Sogdian":                            &_unicode.Sogdian,
+		"Sora_Sompeng":                       &_unicode.Sora_Sompeng,
+		"Soyombo":                            &_unicode.Soyombo,
+		"Space":                              &_unicode.Space,
+		"SpecialCase":                        reflect.TypeOf((*_unicode.SpecialCase)(nil)).Elem(),
+		"Sundanese":                          &_unicode.Sundanese,
+		"Syloti_Nagri":                       &_unicode.Syloti_Nagri,
+		"Symbol":                             &_unicode.Symbol,
+		"Syriac":                             &_unicode.Syriac,
+		"Tagalog":                            &_unicode.Tagalog,
+		"Tagbanwa":                           &_unicode.Tagbanwa,
+		"Tai_Le":                             &_unicode.Tai_Le,
+		"Tai_Tham":                           &_unicode.Tai_Tham,
+		"Tai_Viet":                           &_unicode.Tai_Viet,
+		"Takri":                              &_unicode.Takri,
+		"Tamil":                              &_unicode.Tamil,
+		"Tangsa":                             &_unicode.Tangsa,
+		"Tangut":                             &_unicode.Tangut,
+		"Telugu":                             &_unicode.Telugu,
+		"Terminal_Punctuation":               &_unicode.Terminal_Punctuation,
+		"Thaana":                             &_unicode.Thaana,
+		"Thai":                               &_unicode.Thai,
+		"Tibetan":                            &_unicode.Tibetan,
+		"Tifinagh":                           &_unicode.Tifinagh,
+		"Tirhuta":                            &_unicode.Tirhuta,
+		"Title":                              &_unicode.Title,
+		"To":                                 _unicode.To,
+		"ToLower":                            _unicode.ToLower,
+		"ToTitle":                            _unicode.ToTitle,
+		"ToUpper":                            _unicode.ToUpper,
+		"Toto":                               &_unicode.Toto,
+		"TurkishCase":                        &_unicode.TurkishCase,
+		"Ugaritic":                           &_unicode.Ugaritic,
+		"Unified_Ideograph":                  &_unicode.Unified_Ideograph,
+		"Upper":                              &_unicode.Upper,
+		"Vai":                                &_unicode.Vai,
+		"Variation_Selector":                 &_unicode.Variation_Selector,
+		"Vithkuqi":                           &_unicode.Vithkuqi,
+		"Wancho":                             &_unicode.Wancho,
+		"Warang_Citi":                        &_unicode.Warang_Citi,
+		"White_Space":                        &_unicode.White_Space,
+		"Yezidi":                             &_unicode.Yezidi,
+		"Yi":                                 &_unicode.Yi,
+		"Z":                                  &_unicode.Z,
+		"Zanabazar_Square":                   &_unicode.Zanabazar_Square,
+		"Zl":                                 &_unicode.Zl,
+		"Zp":                                 &_unicode.Zp,
+		"Zs":                                 &_unicode.Zs,
+	})
+}
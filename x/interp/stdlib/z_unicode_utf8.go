@@ -0,0 +1,29 @@
+// Code generated by gen.go; DO NOT EDIT.
+
+package stdlib
+
+import (
+	_unicode_utf8 "unicode/utf8"
+
+	"github.com/goplus/xgo/x/interp"
+)
+
+func init() {
+	interp.RegisterPackage("unicode/utf8", map[string]any{
+		"AppendRune":             _unicode_utf8.AppendRune,
+		"DecodeLastRune":         _unicode_utf8.DecodeLastRune,
+		"DecodeLastRuneInString": _unicode_utf8.DecodeLastRuneInString,
+		"DecodeRune":             _unicode_utf8.DecodeRune,
+		"DecodeRuneInString":     _unicode_utf8.DecodeRuneInString,
+		"EncodeRune":             _unicode_utf8.EncodeRune,
+		"FullRune":               _unicode_utf8.FullRune,
+		"FullRuneInString":       _unicode_utf8.FullRuneInString,
+		"RuneCount":              _unicode_utf8.RuneCount,
+		"RuneCountInString":      _unicode_utf8.RuneCountInString,
+		"RuneLen":                _unicode_utf8.RuneLen,
+		"RuneStart":              _unicode_utf8.RuneStart,
+		"Valid":                  _unicode_utf8.Valid,
+		"ValidRune":              _unicode_utf8.ValidRune,
+		"ValidString":            _unicode_utf8.ValidString,
+	})
+}
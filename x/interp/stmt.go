@@ -0,0 +1,668 @@
+/*
+ * Copyright (c) 2026 The XGo Authors (xgo.dev). All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package interp
+
+import (
+	"go/ast"
+	"go/token"
+	"go/types"
+	"reflect"
+)
+
+// ctrl represents how a statement completes.
+type ctrl int
+
+const (
+	ctrlNone ctrl = iota
+	ctrlBreak
+	ctrlContinue
+	ctrlReturn
+	ctrlGoto
+	ctrlFallthrough
+)
+
+// execList executes a list of statements, and resolves goto statements to
+// labels in the list.
+func (p *Interp) execList(fr *frame, list []ast.Stmt) ctrl {
+	for i := 0; i < len(list); i++ {
+		c := p.exec(fr, list[i])
+		if c == ctrlNone {
+			continue
+		}
+		if c == ctrlGoto {
+			if j := findLabel(list, fr.label); j >= 0 {
+				fr.label = ""
+				i = j - 1
+				continue
+			}
+		}
+		return c
+	}
+	return ctrlNone
+}
+
+func findLabel(list []ast.Stmt, label string) int {
+	for i, stmt := range list {
+		if ls, ok := stmt.(*ast.LabeledStmt); ok && ls.Label.Name == label {
+			return i
+		}
+	}
+	return -1
+}
+
+func (p *Interp) exec(fr *frame, stmt ast.Stmt) ctrl {
+	switch s := stmt.(type) {
+	case *ast.ExprStmt:
+		p.evalMulti(fr, s.X)
+	case *ast.AssignStmt:
+		p.execAssign(fr, s)
+	case *ast.IncDecStmt:
+		lv := p.lvalue(fr, s.X)
+		op := token.ADD
+		if s.Tok == token.DEC {
+			op = token.SUB
+		}
+		x := lv.load()
+		one := reflect.New(x.Type()).Elem()
+		setOne(one)
+		lv.store(binaryOp(op, x, one, x.Type()))
+	case *ast.DeclStmt:
+		p.execDecl(fr, s.Decl.(*ast.GenDecl))
+	case *ast.ReturnStmt:
+		p.execReturn(fr, s)
+		return ctrlReturn
+	case *ast.BlockStmt:
+		return p.execList(fr, s.List)
+	case *ast.IfStmt:
+		if s.Init != nil {
+			p.exec(fr, s.Init)
+		}
+		if p.eval(fr, s.Cond).Bool() {
+			return p.execList(fr, s.Body.List)
+		} else if s.Else != nil {
+			return p.exec(fr, s.Else)
+		}
+	case *ast.ForStmt:
+		return p.execFor(fr, s, "")
+	case *ast.RangeStmt:
+		return p.execRange(fr, s, "")
+	case *ast.SwitchStmt:
+		return p.execSwitch(fr, s, "")
+	case *ast.TypeSwitchStmt:
+		return p.execTypeSwitch(fr, s, "")
+	case *ast.SelectStmt:
+		return p.execSelect(fr, s, "")
+	case *ast.LabeledStmt:
+		return p.execLabeled(fr, s)
+	case *ast.BranchStmt:
+		if s.Label != nil {
+			fr.label = s.Label.Name
+		}
+		switch s.Tok {
+		case token.BREAK:
+			return ctrlBreak
+		case token.CONTINUE:
+			return ctrlContinue
+		case token.GOTO:
+			return ctrlGoto
+		case token.FALLTHROUGH:
+			return ctrlFallthrough
+		}
+	case *ast.GoStmt:
+		f := p.deferredCall(fr, s.Call)
+		go f()
+	case *ast.DeferStmt:
+		fr.defers = append(fr.defers, p.deferredCall(fr, s.Call))
+	case *ast.SendStmt:
+		ch := p.eval(fr, s.Chan)
+		v := p.evalAs(fr, s.Value, p.info.Types[s.Chan].Type.Underlying().(*types.Chan).Elem())
+		ch.Send(v)
+	case *ast.EmptyStmt:
+	default:
+		panic(p.unsupported(stmt, "statement"))
+	}
+	return ctrlNone
+}
+
+func setOne(v reflect.Value) {
+	switch v.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		v.SetInt(1)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		v.SetUint(1)
+	case reflect.Float32, reflect.Float64:
+		v.SetFloat(1)
+	case reflect.Complex64, reflect.Complex128:
+		v.SetComplex(1)
+	}
+}
+
+func (p *Interp) execLabeled(fr *frame, s *ast.LabeledStmt) ctrl {
+	label := s.Label.Name
+	var c ctrl
+	switch loop := s.Stmt.(type) {
+	case *ast.ForStmt:
+		c = p.execFor(fr, loop, label)
+	case *ast.RangeStmt:
+		c = p.execRange(fr, loop, label)
+	case *ast.SwitchStmt:
+		c = p.execSwitch(fr, loop, label)
+	case *ast.TypeSwitchStmt:
+		c = p.execTypeSwitch(fr, loop, label)
+	case *ast.SelectStmt:
+		c = p.execSelect(fr, loop, label)
+	default:
+		return p.exec(fr, s.Stmt)
+	}
+	if c == ctrlBreak && fr.label == label {
+		fr.label = ""
+		return ctrlNone
+	}
+	return c
+}
+
+// loopCtrl handles the completion of a loop body. It reports whether the loop
+// should exit, and how the loop completes in that case.
+func loopCtrl(fr *frame, c ctrl, label string) (exit bool, ret ctrl) {
+	switch c {
+	case ctrlBreak:
+		if fr.label == "" || fr.label == label {
+			fr.label = ""
+			return true, ctrlNone
+		}
+		return true, c
+	case ctrlContinue:
+		if fr.label == "" || fr.label == label {
+			fr.label = ""
+			return false, ctrlNone
+		}
+		return true, c
+	case ctrlReturn, ctrlGoto:
+		return true, c
+	}
+	return false, ctrlNone
+}
+
+// -----------------------------------------------------------------------------
+
+func (p *Interp) execAssign(fr *frame, s *ast.AssignStmt) {
+	switch s.Tok {
+	case token.ASSIGN, token.DEFINE:
+	default: // op=
+		lv := p.lvalue(fr, s.Lhs[0])
+		x := lv.load()
+		y := p.eval(fr, s.Rhs[0])
+		op := assignOps[s.Tok]
+		if op == token.SHL || op == token.SHR {
+			lv.store(shiftOp(op, x, y))
+		} else {
+			lv.store(binaryOp(op, x, p.convertFrom(y, p.typeOf(s.Rhs[0]), p.typeOf(s.Lhs[0])), x.Type()))
+		}
+		return
+	}
+
+	var vals []reflect.Value
+	if len(s.Lhs) != len(s.Rhs) {
+		vals = p.evalMulti(fr, s.Rhs[0])
+	} else {
+		vals = make([]reflect.Value, len(s.Rhs))
+		for i, rhs := range s.Rhs {
+			if vals[i] = p.eval(fr, rhs); len(s.Rhs) > 1 {
+				vals[i] = copyVal(vals[i]) // a, b = b, a
+			}
+		}
+	}
+	if s.Tok == token.DEFINE {
+		for i, lhs := range s.Lhs {
+			id := lhs.(*ast.Ident)
+			if obj := p.info.Defs[id]; obj != nil {
+				p.declare(fr, obj, vals[i])
+			} else if id.Name != "_" {
+				cell := p.lookup(fr, p.info.Uses[id])
+				cell.Set(p.convertFrom(vals[i], p.rhsType(s, i), p.info.Uses[id].Type()))
+			}
+		}
+		return
+	}
+	if len(s.Lhs) == 1 {
+		p.lvalue(fr, s.Lhs[0]).store(p.convertFrom(vals[0], p.rhsType(s, 0), p.typeOf(s.Lhs[0])))
+		return
+	}
+	lvs := make([]lvalue, len(s.Lhs))
+	for i, lhs := range s.Lhs {
+		lvs[i] = p.lvalue(fr, lhs)
+	}
+	for i, lv := range lvs {
+		lv.store(p.convertFrom(vals[i], p.rhsType(s, i), p.typeOf(s.Lhs[i])))
+	}
+}
+
+// rhsType returns the static type of the i-th value of the right hand side.
+func (p *Interp) rhsType(s *ast.AssignStmt, i int) types.Type {
+	if len(s.Lhs) == len(s.Rhs) {
+		return p.typeOf(s.Rhs[i])
+	}
+	if tuple, ok := p.typeOf(s.Rhs[0]).(*types.Tuple); ok {
+		return tuple.At(i).Type()
+	}
+	return nil
+}
+
+var assignOps = map[token.Token]token.Token{
+	token.ADD_ASSIGN:     token.ADD,
+	token.SUB_ASSIGN:     token.SUB,
+	token.MUL_ASSIGN:     token.MUL,
+	token.QUO_ASSIGN:     token.QUO,
+	token.REM_ASSIGN:     token.REM,
+	token.AND_ASSIGN:     token.AND,
+	token.OR_ASSIGN:      token.OR,
+	token.XOR_ASSIGN:     token.XOR,
+	token.SHL_ASSIGN:     token.SHL,
+	token.SHR_ASSIGN:     token.SHR,
+	token.AND_NOT_ASSIGN: token.AND_NOT,
+}
+
+func (p *Interp) execDecl(fr *frame, d *ast.GenDecl) {
+	if d.Tok != token.VAR {
+		return
+	}
+	for _, spec := range d.Specs {
+		vs := spec.(*ast.ValueSpec)
+		var vals []reflect.Value
+		if len(vs.Values) == 1 && len(vs.Names) > 1 {
+			vals = p.evalMulti(fr, vs.Values[0])
+		} else {
+			for _, v := range vs.Values {
+				vals = append(vals, p.eval(fr, v))
+			}
+		}
+		for i, name := range vs.Names {
+			var v reflect.Value
+			if vals != nil {
+				v = vals[i]
+				if len(vs.Values) == len(vs.Names) {
+					v = p.convertFrom(v, p.typeOf(vs.Values[i]), p.info.Defs[name].Type())
+				}
+			}
+			p.declare(fr, p.info.Defs[name], v)
+		}
+	}
+}
+
+func (p *Interp) execReturn(fr *frame, s *ast.ReturnStmt) {
+	if len(s.Results) == 0 {
+		return
+	}
+	results := fr.sig.Results()
+	if len(s.Results) != len(fr.results) {
+		tuple, _ := p.typeOf(s.Results[0]).(*types.Tuple)
+		for i, v := range p.evalMulti(fr, s.Results[0]) {
+			var from types.Type
+			if tuple != nil {
+				from = tuple.At(i).Type()
+			}
+			fr.results[i].Set(p.convertFrom(v, from, results.At(i).Type()))
+		}
+		return
+	}
+	vals := make([]reflect.Value, len(s.Results))
+	for i, e := range s.Results {
+		vals[i] = p.eval(fr, e)
+	}
+	for i, v := range vals {
+		fr.results[i].Set(p.convertFrom(v, p.typeOf(s.Results[i]), results.At(i).Type()))
+	}
+}
+
+// -----------------------------------------------------------------------------
+
+func (p *Interp) execFor(fr *frame, s *ast.ForStmt, label string) ctrl {
+	if s.Init != nil {
+		p.exec(fr, s.Init)
+	}
+	var loopVars []types.Object
+	if as, ok := s.Init.(*ast.AssignStmt); ok && as.Tok == token.DEFINE {
+		for _, lhs := range as.Lhs {
+			if obj := p.info.Defs[lhs.(*ast.Ident)]; obj != nil {
+				loopVars = append(loopVars, obj)
+			}
+		}
+	}
+	for {
+		if s.Cond != nil && !p.eval(fr, s.Cond).Bool() {
+			return ctrlNone
+		}
+		c := p.execList(fr, s.Body.List)
+		if exit, ret := loopCtrl(fr, c, label); exit {
+			return ret
+		}
+		for _, obj := range loopVars { // each iteration has its own variables
+			old := fr.vars[obj]
+			cell := reflect.New(old.Type()).Elem()
+			cell.Set(old)
+			fr.vars[obj] = cell
+		}
+		if s.Post != nil {
+			p.exec(fr, s.Post)
+		}
+	}
+}
+
+func (p *Interp) execRange(fr *frame, s *ast.RangeStmt, label string) ctrl {
+	x := p.eval(fr, s.X)
+	xt := p.typeOf(s.X).Underlying()
+	var assign func(k, v reflect.Value)
+	if s.Tok == token.DEFINE {
+		assign = func(k, v reflect.Value) {
+			if s.Key != nil {
+				p.declare(fr, p.info.Defs[s.Key.(*ast.Ident)], k)
+			}
+			if s.Value != nil {
+				p.declare(fr, p.info.Defs[s.Value.(*ast.Ident)], v)
+			}
+		}
+	} else {
+		assign = func(k, v reflect.Value) {
+			if s.Key != nil {
+				if lv := p.lvalue(fr, s.Key); lv != nil {
+					lv.store(p.convert(k, p.typeOf(s.Key)))
+				}
+			}
+			if s.Value != nil {
+				if lv := p.lvalue(fr, s.Value); lv != nil {
+					lv.store(p.convert(v, p.typeOf(s.Value)))
+				}
+			}
+		}
+	}
+	body := func(k, v reflect.Value) (exit bool, ret ctrl) {
+		assign(k, v)
+		return loopCtrl(fr, p.execList(fr, s.Body.List), label)
+	}
+
+	switch t := xt.(type) {
+	case *types.Pointer: // pointer to array
+		x, xt = x.Elem(), t.Elem().Underlying()
+	case *types.Array: // ranging over an array evaluates a copy of it
+		if s.Value != nil {
+			x = copyVal(x)
+		}
+	}
+	switch t := xt.(type) {
+	case *types.Basic:
+		if t.Info()&types.IsString != 0 {
+			for i, r := range x.String() {
+				if exit, ret := body(reflect.ValueOf(i), reflect.ValueOf(r)); exit {
+					return ret
+				}
+			}
+			return ctrlNone
+		}
+		n := x.Int()
+		if t.Info()&types.IsUnsigned != 0 {
+			n = int64(x.Uint())
+		}
+		for i := int64(0); i < n; i++ {
+			k := reflect.New(x.Type()).Elem()
+			if t.Info()&types.IsUnsigned != 0 {
+				k.SetUint(uint64(i))
+			} else {
+				k.SetInt(i)
+			}
+			if exit, ret := body(k, reflect.Value{}); exit {
+				return ret
+			}
+		}
+	case *types.Slice, *types.Array:
+		for i, n := 0, x.Len(); i < n; i++ {
+			var v reflect.Value
+			if s.Value != nil {
+				v = x.Index(i)
+			}
+			if exit, ret := body(reflect.ValueOf(i), v); exit {
+				return ret
+			}
+		}
+	case *types.Map:
+		iter := x.MapRange()
+		for iter.Next() {
+			if exit, ret := body(iter.Key(), iter.Value()); exit {
+				return ret
+			}
+		}
+	case *types.Chan:
+		for {
+			v, ok := x.Recv()
+			if !ok {
+				break
+			}
+			if exit, ret := body(v, reflect.Value{}); exit {
+				return ret
+			}
+		}
+	case *types.Signature:
+		return p.rangeFunc(fr, x, t, body)
+	default:
+		panic(p.unsupported(s, "range over "+xt.String()))
+	}
+	return ctrlNone
+}
+
+// rangeFunc ranges over an iterator function.
+func (p *Interp) rangeFunc(fr *frame, x reflect.Value, sig *types.Signature, body func(k, v reflect.Value) (bool, ctrl)) ctrl {
+	yieldType := x.Type().In(0)
+	var ret ctrl
+	done := false
+	yield := reflect.MakeFunc(yieldType, func(args []reflect.Value) []reflect.Value {
+		if done {
+			panic(runtimeError("range function continued iteration after function for loop body returned false"))
+		}
+		var k, v reflect.Value
+		if len(args) > 0 {
+			k = args[0]
+		}
+		if len(args) > 1 {
+			v = args[1]
+		}
+		exit, c := body(k, v)
+		if exit {
+			done, ret = true, c
+		}
+		return []reflect.Value{reflect.ValueOf(!exit)}
+	})
+	x.Call([]reflect.Value{yield})
+	return ret
+}
+
+func (p *Interp) execSwitch(fr *frame, s *ast.SwitchStmt, label string) ctrl {
+	if s.Init != nil {
+		p.exec(fr, s.Init)
+	}
+	var tag reflect.Value
+	var tagType types.Type
+	if s.Tag != nil {
+		tag, tagType = p.eval(fr, s.Tag), p.typeOf(s.Tag)
+	}
+	clauses := s.Body.List
+	matched := -1
+	for i, stmt := range clauses {
+		cc := stmt.(*ast.CaseClause)
+		if cc.List == nil {
+			continue
+		}
+		for _, e := range cc.List {
+			v := p.eval(fr, e)
+			if s.Tag == nil {
+				if v.Bool() {
+					matched = i
+				}
+			} else if p.equal(tag, tagType, v, p.typeOf(e)) {
+				matched = i
+			}
+			if matched >= 0 {
+				break
+			}
+		}
+		if matched >= 0 {
+			break
+		}
+	}
+	if matched < 0 {
+		for i, stmt := range clauses {
+			if stmt.(*ast.CaseClause).List == nil {
+				matched = i
+			}
+		}
+		if matched < 0 {
+			return ctrlNone
+		}
+	}
+	for i := matched; i < len(clauses); i++ {
+		c := p.execList(fr, clauses[i].(*ast.CaseClause).Body)
+		if c == ctrlFallthrough {
+			continue
+		}
+		return switchCtrl(fr, c, label)
+	}
+	return ctrlNone
+}
+
+func switchCtrl(fr *frame, c ctrl, label string) ctrl {
+	if c == ctrlBreak && (fr.label == "" || fr.label == label) {
+		fr.label = ""
+		return ctrlNone
+	}
+	return c
+}
+
+func (p *Interp) execTypeSwitch(fr *frame, s *ast.TypeSwitchStmt, label string) ctrl {
+	if s.Init != nil {
+		p.exec(fr, s.Init)
+	}
+	var x ast.Expr
+	var bind *ast.Ident
+	switch a := s.Assign.(type) {
+	case *ast.AssignStmt:
+		bind = a.Lhs[0].(*ast.Ident)
+		x = a.Rhs[0].(*ast.TypeAssertExpr).X
+	case *ast.ExprStmt:
+		x = a.X.(*ast.TypeAssertExpr).X
+	}
+	v := p.eval(fr, x)
+	var matched *ast.CaseClause
+	var mv reflect.Value
+	single := false
+	for _, stmt := range s.Body.List {
+		cc := stmt.(*ast.CaseClause)
+		for _, e := range cc.List {
+			if id, ok := e.(*ast.Ident); ok && id.Name == "nil" && p.info.Types[e].IsNil() {
+				if v.IsNil() {
+					matched, mv = cc, v
+				}
+			} else if r, ok := p.typeAssert(v, p.typeOf(e)); ok {
+				matched, mv, single = cc, r, len(cc.List) == 1
+			}
+			if matched != nil {
+				break
+			}
+		}
+		if matched != nil {
+			break
+		}
+	}
+	if matched == nil {
+		for _, stmt := range s.Body.List {
+			if cc := stmt.(*ast.CaseClause); cc.List == nil {
+				matched, mv = cc, v
+			}
+		}
+		if matched == nil {
+			return ctrlNone
+		}
+	}
+	if bind != nil {
+		if obj := p.info.Implicits[matched]; obj != nil {
+			if !single {
+				mv = v
+			}
+			p.declare(fr, obj, mv)
+		}
+	}
+	return switchCtrl(fr, p.execList(fr, matched.Body), label)
+}
+
+func (p *Interp) execSelect(fr *frame, s *ast.SelectStmt, label string) ctrl {
+	var cases []reflect.SelectCase
+	var clauses []*ast.CommClause
+	for _, stmt := range s.Body.List {
+		cc := stmt.(*ast.CommClause)
+		var sc reflect.SelectCase
+		switch comm := cc.Comm.(type) {
+		case nil:
+			sc.Dir = reflect.SelectDefault
+		case *ast.SendStmt:
+			sc.Dir = reflect.SelectSend
+			sc.Chan = p.eval(fr, comm.Chan)
+			sc.Send = p.evalAs(fr, comm.Value, p.typeOf(comm.Chan).Underlying().(*types.Chan).Elem())
+		case *ast.ExprStmt:
+			sc.Dir = reflect.SelectRecv
+			sc.Chan = p.eval(fr, unparen(comm.X).(*ast.UnaryExpr).X)
+		case *ast.AssignStmt:
+			sc.Dir = reflect.SelectRecv
+			sc.Chan = p.eval(fr, unparen(comm.Rhs[0]).(*ast.UnaryExpr).X)
+		}
+		cases = append(cases, sc)
+		clauses = append(clauses, cc)
+	}
+	chosen, recv, recvOK := reflect.Select(cases)
+	cc := clauses[chosen]
+	if as, ok := cc.Comm.(*ast.AssignStmt); ok {
+		vals := []reflect.Value{recv, reflect.ValueOf(recvOK)}
+		for i, lhs := range as.Lhs {
+			if as.Tok == token.DEFINE {
+				if obj := p.info.Defs[lhs.(*ast.Ident)]; obj != nil {
+					p.declare(fr, obj, vals[i])
+				}
+			} else if lv := p.lvalue(fr, lhs); lv != nil {
+				lv.store(p.convert(vals[i], p.typeOf(lhs)))
+			}
+		}
+	}
+	return switchCtrl(fr, p.execList(fr, cc.Body), label)
+}
+
+// -----------------------------------------------------------------------------
+
+// deferredCall evaluates the function value and arguments of a go or defer
+// statement, and returns a function to make the call.
+func (p *Interp) deferredCall(fr *frame, call *ast.CallExpr) func() {
+	fun := unparen(call.Fun)
+	if b, ok := p.builtin(call); ok {
+		args := p.evalBuiltinArgs(fr, call)
+		return func() { p.callBuiltinWith(fr, b, call, args) }
+	}
+	if lit, ok := fun.(*ast.FuncLit); ok {
+		fn := p.closureFunc(fr, lit)
+		args := p.evalArgs(fr, call, fn.sig)
+		return func() { p.call(fn, nil, args, fr) }
+	}
+	f := p.callee(fr, call)
+	args := p.evalArgs(fr, call, f.sig)
+	return func() { f.invoke(args, fr) }
+}
+
+// -----------------------------------------------------------------------------
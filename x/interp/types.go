@@ -0,0 +1,279 @@
+/*
+ * Copyright (c) 2026 The XGo Authors (xgo.dev). All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package interp
+
+import (
+	"fmt"
+	"go/token"
+	"go/types"
+	"reflect"
+	"unsafe"
+)
+
+// -----------------------------------------------------------------------------
+
+var (
+	tyAny   = reflect.TypeOf((*any)(nil)).Elem()
+	tyError = reflect.TypeOf((*error)(nil)).Elem()
+)
+
+var basicTypes = [...]reflect.Type{
+	types.Bool:          reflect.TypeOf(false),
+	types.Int:           reflect.TypeOf(int(0)),
+	types.Int8:          reflect.TypeOf(int8(0)),
+	types.Int16:         reflect.TypeOf(int16(0)),
+	types.Int32:         reflect.TypeOf(int32(0)),
+	types.Int64:         reflect.TypeOf(int64(0)),
+	types.Uint:          reflect.TypeOf(uint(0)),
+	types.Uint8:         reflect.TypeOf(uint8(0)),
+	types.Uint16:        reflect.TypeOf(uint16(0)),
+	types.Uint32:        reflect.TypeOf(uint32(0)),
+	types.Uint64:        reflect.TypeOf(uint64(0)),
+	types.Uintptr:       reflect.TypeOf(uintptr(0)),
+	types.Float32:       reflect.TypeOf(float32(0)),
+	types.Float64:       reflect.TypeOf(float64(0)),
+	types.Complex64:     reflect.TypeOf(complex64(0)),
+	types.Complex128:    reflect.TypeOf(complex128(0)),
+	types.String:        reflect.TypeOf(""),
+	types.UnsafePointer: reflect.TypeOf(unsafe.Pointer(nil)),
+
+	types.UntypedBool:    reflect.TypeOf(false),
+	types.UntypedInt:     reflect.TypeOf(int(0)),
+	types.UntypedRune:    reflect.TypeOf(rune(0)),
+	types.UntypedFloat:   reflect.TypeOf(float64(0)),
+	types.UntypedComplex: reflect.TypeOf(complex128(0)),
+	types.UntypedString:  reflect.TypeOf(""),
+	types.UntypedNil:     tyAny,
+}
+
+// errRecursive is raised when a named type refers to itself while its
+// reflect.Type is being created.
+type errRecursive struct{}
+
+// rtype returns the reflect.Type representing values of t.
+//
+// Interpreted named types are represented by their underlying types, and
+// interface types other than native named ones are represented by any. Fields
+// of a struct type referring to the struct type itself are represented by any
+// too, because reflect can't create recursive types.
+func (p *Interp) rtype(t types.Type) reflect.Type {
+	switch t := t.(type) {
+	case *types.Basic:
+		return basicTypes[t.Kind()]
+	case *types.Pointer:
+		return reflect.PointerTo(p.rtype(t.Elem()))
+	case *types.Slice:
+		return reflect.SliceOf(p.rtype(t.Elem()))
+	case *types.Array:
+		return reflect.ArrayOf(int(t.Len()), p.rtype(t.Elem()))
+	case *types.Map:
+		return reflect.MapOf(p.rtype(t.Key()), p.rtype(t.Elem()))
+	case *types.Chan:
+		dir := reflect.BothDir
+		switch t.Dir() {
+		case types.SendOnly:
+			dir = reflect.SendDir
+		case types.RecvOnly:
+			dir = reflect.RecvDir
+		}
+		return reflect.ChanOf(dir, p.rtype(t.Elem()))
+	case *types.Signature:
+		return p.funcType(t)
+	case *types.Struct:
+		return p.structType(t)
+	case *types.Interface:
+		return tyAny
+	case *types.Named:
+		return p.namedType(t)
+	case *types.Alias:
+		return p.rtype(types.Unalias(t))
+	case *types.TypeParam:
+		panic(&UnsupportedError{Msg: "type parameter " + t.String()})
+	}
+	panic(fmt.Sprintf("interp: unexpected type %v", t))
+}
+
+func (p *Interp) funcType(sig *types.Signature) reflect.Type {
+	params := sig.Params()
+	in := make([]reflect.Type, params.Len())
+	for i := range in {
+		in[i] = p.rtype(params.At(i).Type())
+	}
+	results := sig.Results()
+	out := make([]reflect.Type, results.Len())
+	for i := range out {
+		out[i] = p.rtype(results.At(i).Type())
+	}
+	return reflect.FuncOf(in, out, sig.Variadic())
+}
+
+// structType creates a struct type. Unexported fields are renamed with a "X_"
+// prefix because reflect can't create unexported fields, and they are ignored
+// by encoding/json like unexported fields of native types.
+func (p *Interp) structType(t *types.Struct) reflect.Type {
+	fields := make([]reflect.StructField, t.NumFields())
+	for i := range fields {
+		fld := t.Field(i)
+		name, tag := fld.Name(), reflect.StructTag(t.Tag(i))
+		if name == "_" {
+			name = fmt.Sprintf("X_%d", i)
+		} else if !token.IsExported(name) {
+			name = "X_" + name
+			if tag == "" {
+				tag = `json:"-"`
+			}
+		}
+		fields[i] = reflect.StructField{Name: name, Type: p.fieldType(fld.Type()), Tag: tag}
+	}
+	return reflect.StructOf(fields)
+}
+
+func (p *Interp) fieldType(t types.Type) (ret reflect.Type) {
+	defer func() {
+		if e := recover(); e != nil {
+			if _, ok := e.(errRecursive); !ok {
+				panic(e)
+			}
+			ret = tyAny
+		}
+	}()
+	return p.rtype(t)
+}
+
+func (p *Interp) namedType(t *types.Named) reflect.Type {
+	obj := t.Obj()
+	if obj.Pkg() == nil { // error
+		return tyError
+	}
+	if t.TypeArgs() != nil && obj.Pkg() == p.pkg {
+		panic(&UnsupportedError{Msg: "generic type " + t.String()})
+	}
+	p.mutex.Lock()
+	if rt, ok := p.named[t]; ok {
+		p.mutex.Unlock()
+		return rt
+	}
+	if p.inProg[t] {
+		p.mutex.Unlock()
+		panic(errRecursive{})
+	}
+	p.mutex.Unlock()
+
+	var rt reflect.Type
+	if obj.Pkg() == p.pkg {
+		p.mutex.Lock()
+		p.inProg[t] = true
+		p.mutex.Unlock()
+		defer func() {
+			p.mutex.Lock()
+			delete(p.inProg, t)
+			p.mutex.Unlock()
+		}()
+		rt = p.rtype(t.Underlying())
+	} else {
+		rt = p.nativeType(t)
+	}
+	p.mutex.Lock()
+	p.named[t] = rt
+	p.mutex.Unlock()
+	return rt
+}
+
+func (p *Interp) nativeType(t *types.Named) reflect.Type {
+	obj := t.Obj()
+	if t.TypeArgs() != nil {
+		panic(&UnsupportedError{Msg: "instance of generic type " + t.String()})
+	}
+	if sym, ok := lookupSym(obj.Pkg().Path(), obj.Name()); ok {
+		if rt, ok := sym.(reflect.Type); ok {
+			return rt
+		}
+	}
+	p.mutex.Lock()
+	rt, ok := p.learned[obj]
+	p.mutex.Unlock()
+	if ok {
+		return rt
+	}
+	if _, ok := t.Underlying().(*types.Interface); ok {
+		return tyAny
+	}
+	panic(&UnsupportedError{Msg: "native type " + t.String() + " (not registered)"})
+}
+
+// learn records reflect types of unexported native named types by values
+// returned from native code.
+func (p *Interp) learn(t types.Type, rt reflect.Type) {
+	switch t := t.(type) {
+	case *types.Pointer:
+		if rt.Kind() == reflect.Pointer {
+			p.learn(t.Elem(), rt.Elem())
+		}
+	case *types.Named:
+		obj := t.Obj()
+		if obj.Pkg() == nil || obj.Pkg() == p.pkg || obj.Exported() || t.TypeArgs() != nil {
+			return
+		}
+		if _, ok := t.Underlying().(*types.Interface); ok {
+			return
+		}
+		p.mutex.Lock()
+		if _, ok := p.learned[obj]; !ok {
+			p.learned[obj] = rt
+		}
+		p.mutex.Unlock()
+	}
+}
+
+// tryRType is like rtype but reports false instead of panicking if t can't be
+// represented.
+func (p *Interp) tryRType(t types.Type) (rt reflect.Type, ok bool) {
+	defer func() {
+		if e := recover(); e != nil {
+			if _, isUnsupported := e.(*UnsupportedError); !isUnsupported {
+				panic(e)
+			}
+			ok = false
+		}
+	}()
+	return p.rtype(t), true
+}
+
+// -----------------------------------------------------------------------------
+
+func isInterface(t types.Type) bool {
+	return types.IsInterface(t)
+}
+
+// interpNamed returns the interpreted named type of t or *t.
+func (p *Interp) interpNamed(t types.Type) (named *types.Named, ptr bool) {
+	if t == nil {
+		return
+	}
+	t = types.Unalias(t)
+	if pt, ok := t.(*types.Pointer); ok {
+		t, ptr = pt.Elem(), true
+	}
+	if n, ok := types.Unalias(t).(*types.Named); ok && n.Obj().Pkg() == p.pkg {
+		if _, isIntf := n.Underlying().(*types.Interface); !isIntf {
+			return n, ptr
+		}
+	}
+	return nil, false
+}
+
+// -----------------------------------------------------------------------------
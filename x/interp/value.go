@@ -0,0 +1,308 @@
+/*
+ * Copyright (c) 2026 The XGo Authors (xgo.dev). All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package interp
+
+import (
+	"go/constant"
+	"go/types"
+	"reflect"
+)
+
+// -----------------------------------------------------------------------------
+
+// object is a value of an interpreted named type (or a pointer to it) stored
+// in an interface, so that its dynamic type is kept.
+type object struct {
+	in  *Interp
+	t   *types.Named
+	ptr bool // the dynamic type is *t
+	v   any
+}
+
+// errorObject is an object whose type implements error.
+type errorObject struct{ object }
+
+// stringerObject is an object whose type implements fmt.Stringer.
+type stringerObject struct{ object }
+
+func (o errorObject) Error() string {
+	return o.callString("Error")
+}
+
+func (o stringerObject) String() string {
+	return o.callString("String")
+}
+
+func (o object) typ() types.Type {
+	if o.ptr {
+		return types.NewPointer(o.t)
+	}
+	return o.t
+}
+
+func (o object) callString(name string) string {
+	return o.in.callMethodByName(reflect.ValueOf(o.v), o.typ(), name, nil)[0].String()
+}
+
+var (
+	tyObject         = reflect.TypeOf(object{})
+	tyErrorObject    = reflect.TypeOf(errorObject{})
+	tyStringerObject = reflect.TypeOf(stringerObject{})
+)
+
+// asObject returns the object in an interface value.
+func asObject(v reflect.Value) (object, bool) {
+	if v.Kind() == reflect.Interface {
+		if v.IsNil() {
+			return object{}, false
+		}
+		v = v.Elem()
+	}
+	switch v.Type() {
+	case tyObject:
+		return v.Interface().(object), true
+	case tyErrorObject:
+		return v.Interface().(errorObject).object, true
+	case tyStringerObject:
+		return v.Interface().(stringerObject).object, true
+	}
+	return object{}, false
+}
+
+// box converts v of type from to an interface value. Values of interpreted
+// named types are wrapped into objects.
+func (p *Interp) box(v reflect.Value, from types.Type, rt reflect.Type) reflect.Value {
+	ret := reflect.New(rt).Elem()
+	if named, ptr := p.interpNamed(from); named != nil {
+		o := object{in: p, t: named, ptr: ptr, v: v.Interface()}
+		var x any = o
+		mset := p.methodSet(o.typ())
+		if hasStringMethod(mset, "Error") {
+			x = errorObject{o}
+		} else if hasStringMethod(mset, "String") {
+			x = stringerObject{o}
+		}
+		xv := reflect.ValueOf(x)
+		if !xv.Type().AssignableTo(rt) {
+			panic(&UnsupportedError{Msg: "using " + from.String() + " as native interface " + rt.String()})
+		}
+		ret.Set(xv)
+		return ret
+	}
+	if v.IsValid() {
+		ret.Set(v)
+	}
+	return ret
+}
+
+func hasStringMethod(mset *types.MethodSet, name string) bool {
+	for i := 0; i < mset.Len(); i++ {
+		fn := mset.At(i).Obj().(*types.Func)
+		if fn.Name() == name {
+			sig := fn.Type().(*types.Signature)
+			return sig.Params().Len() == 0 && sig.Results().Len() == 1 &&
+				types.Identical(sig.Results().At(0).Type(), types.Typ[types.String])
+		}
+	}
+	return false
+}
+
+func (p *Interp) methodSet(t types.Type) *types.MethodSet {
+	return types.NewMethodSet(t)
+}
+
+// convert converts v to a value of type t for an assignment. v is invalid for
+// an untyped nil.
+func (p *Interp) convert(v reflect.Value, t types.Type) reflect.Value {
+	return p.convertFrom(v, nil, t)
+}
+
+// convertFrom is like convert, and from is the static type of v if known.
+func (p *Interp) convertFrom(v reflect.Value, from, t types.Type) reflect.Value {
+	rt := p.rtype(t)
+	if !v.IsValid() {
+		return reflect.Zero(rt)
+	}
+	if v.Type() == rt {
+		return v
+	}
+	if isInterface(t) {
+		if v.Kind() == reflect.Interface {
+			if v.IsNil() {
+				return reflect.Zero(rt)
+			}
+			ret := reflect.New(rt).Elem()
+			ret.Set(v.Elem())
+			return ret
+		}
+		return p.box(v, from, rt)
+	}
+	if v.Kind() == reflect.Interface && rt.Kind() != reflect.Interface {
+		if v.IsNil() {
+			return reflect.Zero(rt)
+		}
+		v = v.Elem()
+		if o, ok := asObject(v); ok {
+			v = reflect.ValueOf(o.v)
+		}
+		if v.Type() == rt {
+			return v
+		}
+	}
+	if v.Type().ConvertibleTo(rt) {
+		return v.Convert(rt)
+	}
+	return v
+}
+
+// toNative converts v to a value passed to native code as type rt. Objects
+// that implement neither error nor fmt.Stringer are unwrapped, so native code
+// like fmt.Println sees their underlying values.
+func toNative(v reflect.Value, rt reflect.Type) reflect.Value {
+	if rt.Kind() == reflect.Interface {
+		if v.Kind() == reflect.Interface && !v.IsNil() && v.Elem().Type() == tyObject {
+			ret := reflect.New(rt).Elem()
+			ret.Set(reflect.ValueOf(v.Elem().Interface().(object).v))
+			return ret
+		}
+		return v
+	}
+	if rt.Kind() == reflect.Slice && rt.Elem().Kind() == reflect.Interface && v.Kind() == reflect.Slice {
+		var ret reflect.Value
+		for i, n := 0, v.Len(); i < n; i++ {
+			e := v.Index(i)
+			if !e.IsNil() && e.Elem().Type() == tyObject {
+				if !ret.IsValid() {
+					ret = reflect.MakeSlice(v.Type(), n, n)
+					reflect.Copy(ret, v)
+				}
+				ret.Index(i).Set(toNative(e, rt.Elem()))
+			}
+		}
+		if ret.IsValid() {
+			return ret
+		}
+	}
+	return v
+}
+
+// -----------------------------------------------------------------------------
+
+// constValue returns the value of a constant of type t.
+func (p *Interp) constValue(val constant.Value, t types.Type) reflect.Value {
+	if b, ok := t.Underlying().(*types.Basic); ok && b.Info()&types.IsUntyped != 0 {
+		t = types.Default(t)
+	}
+	rt := p.rtype(t)
+	if isInterface(t) { // e.g. var x any = 1
+		return p.box(p.constValue(val, types.Default(constType(val))), nil, rt)
+	}
+	ret := reflect.New(rt).Elem()
+	switch rt.Kind() {
+	case reflect.Bool:
+		ret.SetBool(constant.BoolVal(val))
+	case reflect.String:
+		ret.SetString(constant.StringVal(val))
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		x, _ := constant.Int64Val(constant.ToInt(val))
+		ret.SetInt(x)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		x, _ := constant.Uint64Val(constant.ToInt(val))
+		ret.SetUint(x)
+	case reflect.Float32, reflect.Float64:
+		x, _ := constant.Float64Val(constant.ToFloat(val))
+		ret.SetFloat(x)
+	case reflect.Complex64, reflect.Complex128:
+		c := constant.ToComplex(val)
+		re, _ := constant.Float64Val(constant.Real(c))
+		im, _ := constant.Float64Val(constant.Imag(c))
+		ret.SetComplex(complex(re, im))
+	default:
+		panic("interp: unexpected constant type " + t.String())
+	}
+	return ret
+}
+
+func constType(val constant.Value) types.Type {
+	switch val.Kind() {
+	case constant.Bool:
+		return types.Typ[types.UntypedBool]
+	case constant.String:
+		return types.Typ[types.UntypedString]
+	case constant.Int:
+		return types.Typ[types.UntypedInt]
+	case constant.Float:
+		return types.Typ[types.UntypedFloat]
+	default:
+		return types.Typ[types.UntypedComplex]
+	}
+}
+
+// -----------------------------------------------------------------------------
+
+// typeAssert asserts the interface value x has type t.
+func (p *Interp) typeAssert(x reflect.Value, t types.Type) (reflect.Value, bool) {
+	rt := p.rtype(t)
+	if x.Kind() != reflect.Interface || x.IsNil() {
+		return reflect.Zero(rt), false
+	}
+	e := x.Elem()
+	if o, ok := asObject(e); ok {
+		dyn := o.typ()
+		if iface, ok := t.Underlying().(*types.Interface); ok {
+			if !types.Implements(dyn, iface) {
+				return reflect.Zero(rt), false
+			}
+			ret := reflect.New(rt).Elem()
+			if !e.Type().AssignableTo(rt) {
+				return reflect.Zero(rt), false
+			}
+			ret.Set(e)
+			return ret, true
+		}
+		if !types.Identical(dyn, t) {
+			return reflect.Zero(rt), false
+		}
+		return reflect.ValueOf(o.v).Convert(rt), true
+	}
+	if iface, ok := t.Underlying().(*types.Interface); ok {
+		if rt != tyAny {
+			if !e.Type().Implements(rt) {
+				return reflect.Zero(rt), false
+			}
+		} else {
+			for i := 0; i < iface.NumMethods(); i++ {
+				m := iface.Method(i)
+				if _, ok := e.Type().MethodByName(m.Name()); !ok || !m.Exported() {
+					return reflect.Zero(rt), false
+				}
+			}
+		}
+		ret := reflect.New(rt).Elem()
+		ret.Set(e)
+		return ret, true
+	}
+	if named, _ := p.interpNamed(t); named != nil {
+		return reflect.Zero(rt), false
+	}
+	if e.Type() != rt {
+		return reflect.Zero(rt), false
+	}
+	return e, true
+}
+
+// -----------------------------------------------------------------------------
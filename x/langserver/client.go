@@ -27,6 +27,9 @@ import (
 const (
 	methodGenGo   = "gengo"
 	methodChanged = "changed"
+	methodCheck   = "check"
+	methodBuild   = "build"
+	methodRun     = "run"
 )
 
 // -----------------------------------------------------------------------------
@@ -71,4 +74,24 @@ func (p Client) Changed(ctx context.Context, files ...string) (err error) {
 	return p.conn.Notify(ctx, methodChanged, files)
 }
 
+// Check compiles the package in directory params.Dir without generating Go
+// files, and returns the compile errors (if any) as ret.Output.
+func (p Client) Check(ctx context.Context, params *CmdParams) (ret CmdResult, err error) {
+	err = p.conn.Call(ctx, methodCheck, params).Await(ctx, &ret)
+	return
+}
+
+// Build builds the package in directory params.Dir by the server.
+func (p Client) Build(ctx context.Context, params *CmdParams) (ret CmdResult, err error) {
+	err = p.conn.Call(ctx, methodBuild, params).Await(ctx, &ret)
+	return
+}
+
+// Run runs the application of the package in directory params.Dir by the
+// server. Outputs of the application are returned when it exits.
+func (p Client) Run(ctx context.Context, params *CmdParams) (ret CmdResult, err error) {
+	err = p.conn.Call(ctx, methodRun, params).Await(ctx, &ret)
+	return
+}
+
 // -----------------------------------------------------------------------------
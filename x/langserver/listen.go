@@ -0,0 +1,99 @@
+/*
+ * Copyright (c) 2026 The XGo Authors (xgo.dev). All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package langserver
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net"
+	"strings"
+)
+
+// ErrNonLocalAddr is returned by Listen if a TCP address isn't a loopback one.
+var ErrNonLocalAddr = errors.New("only loopback addresses can be listened on")
+
+// -----------------------------------------------------------------------------
+
+// ParseAddr parses an address of the form `unix;path` or `[host]:port`, and
+// returns its network and address.
+func ParseAddr(addr string) (network, address string) {
+	if path, ok := strings.CutPrefix(addr, "unix;"); ok {
+		return "unix", path
+	}
+	return "tcp", addr
+}
+
+// Listen returns a Listener accepting connections on the network address, so
+// that clients (eg. editors and `xgo watch`) can share a long-running server.
+// The server builds and runs code for its clients without authentication, so
+// TCP addresses must be loopback ones (eg. localhost:7777 or 127.0.0.1:7777).
+func Listen(network, address string) (Listener, error) {
+	if network == "tcp" && !isLoopback(address) {
+		return nil, &net.OpError{Op: "listen", Net: network, Err: ErrNonLocalAddr}
+	}
+	ln, err := net.Listen(network, address)
+	if err != nil {
+		return nil, err
+	}
+	return netListener{ln}, nil
+}
+
+func isLoopback(address string) bool {
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return false
+	}
+	if host == "localhost" {
+		return true
+	}
+	ip := net.ParseIP(host)
+	return ip != nil && ip.IsLoopback()
+}
+
+type netListener struct {
+	ln net.Listener
+}
+
+func (p netListener) Accept(context.Context) (io.ReadWriteCloser, error) {
+	return p.ln.Accept()
+}
+
+func (p netListener) Close() error {
+	return p.ln.Close()
+}
+
+func (p netListener) Dialer() Dialer {
+	addr := p.ln.Addr()
+	return NetDialer(addr.Network(), addr.String())
+}
+
+// NetDialer returns a Dialer connecting to a server on the network address.
+func NetDialer(network, address string) Dialer {
+	return netDialer{network, address}
+}
+
+type netDialer struct {
+	network, address string
+}
+
+func (p netDialer) Dial(ctx context.Context) (io.ReadWriteCloser, error) {
+	var d net.Dialer
+	return d.DialContext(ctx, p.network, p.address)
+}
+
+// -----------------------------------------------------------------------------
@@ -19,7 +19,10 @@ package langserver
 import (
 	"context"
 	"encoding/json"
+	"errors"
+	"os"
 	"path/filepath"
+	"strings"
 	"sync"
 	"time"
 
@@ -41,11 +44,18 @@ type Config struct {
 	// Framer allows control over the message framing and encoding.
 	// If nil, HeaderFramer will be used.
 	Framer jsonrpc2.Framer
+
+	// CmdTimeout limits the time of a check, build or run request. The go
+	// command and the program it runs are killed after it. 0 means no limit.
+	CmdTimeout time.Duration
 }
 
 // NewServer creates a new LangServer and returns it.
 func NewServer(ctx context.Context, listener Listener, conf *Config) (ret *Server) {
 	h := newHandle()
+	if conf != nil {
+		h.cmdTimeout = conf.CmdTimeout
+	}
 	ret = jsonrpc2.NewServer(ctx, listener, jsonrpc2.BinderFunc(
 		func(ctx context.Context, c *jsonrpc2.Connection) (ret jsonrpc2.ConnectionOptions) {
 			if conf != nil {
@@ -65,15 +75,19 @@ func NewServer(ctx context.Context, listener Listener, conf *Config) (ret *Serve
 type none = struct{}
 
 type handler struct {
-	mutex sync.Mutex
-	dirty map[string]none
+	mutex    sync.Mutex
+	dirty    map[string]none
+	sessions map[sessionKey]*session
+
+	cmdTimeout time.Duration
 
 	server *Server
 }
 
 func newHandle() *handler {
 	return &handler{
-		dirty: make(map[string]none),
+		dirty:    make(map[string]none),
+		sessions: make(map[sessionKey]*session),
 	}
 }
 
@@ -99,10 +113,24 @@ func (p *handler) runLoop() {
 			time.Sleep(duration)
 			continue
 		}
-		tool.GenGoEx(dir, nil, true, tool.GenFlagPrompt)
+		p.genGo(dir, tool.GenFlagPrompt)
 	}
 }
 
+// genGo generates Go files of the package in dir with a warm configuration.
+func (p *handler) genGo(dir string, flags tool.GenFlags) (err error) {
+	s := p.session(dir, 0)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	conf, err := p.useConf(s)
+	if err != nil {
+		return
+	}
+	defer conf.UpdateCache()
+	_, _, err = tool.GenGoEx(dir, conf, true, flags)
+	return
+}
+
 func (p *handler) Changed(files []string) {
 	p.mutex.Lock()
 	defer p.mutex.Unlock()
@@ -111,6 +139,7 @@ func (p *handler) Changed(files []string) {
 		dir := filepath.Dir(file)
 		p.dirty[dir] = none{}
 	}
+	p.changedFiles(files)
 }
 
 func (p *handler) Handle(ctx context.Context, req *jsonrpc2.Request) (result any, err error) {
@@ -128,11 +157,53 @@ func (p *handler) Handle(ctx context.Context, req *jsonrpc2.Request) (result any
 		if err != nil {
 			return
 		}
-		err = GenGo(pattern...)
+		err = p.GenGo(pattern...)
+	case methodCheck, methodBuild, methodRun:
+		var params CmdParams
+		err = json.Unmarshal(req.Params, &params)
+		if err != nil {
+			return
+		}
+		if p.cmdTimeout > 0 {
+			var cancel context.CancelFunc
+			ctx, cancel = context.WithTimeout(ctx, p.cmdTimeout)
+			defer cancel()
+		}
+		switch req.Method {
+		case methodCheck:
+			result = p.Check(ctx, &params)
+		case methodBuild:
+			result = p.Build(ctx, &params)
+		default:
+			result = p.Run(ctx, &params)
+		}
 	}
 	return
 }
 
+// GenGo generates Go files of packages matching pattern. Packages of absolute
+// directories are generated with warm configurations, and errors of them are
+// returned.
+func (p *handler) GenGo(pattern ...string) error {
+	var errs []error
+	var others []string
+	for _, pat := range pattern {
+		if filepath.IsAbs(pat) && !strings.HasSuffix(pat, "/...") {
+			if fi, e := os.Stat(pat); e == nil && fi.IsDir() {
+				if e = p.genGo(pat, 0); e != nil {
+					errs = append(errs, e)
+				}
+				continue
+			}
+		}
+		others = append(others, pat)
+	}
+	if others != nil {
+		errs = append(errs, GenGo(others...))
+	}
+	return errors.Join(errs...)
+}
+
 func GenGo(pattern ...string) (err error) {
 	projs, err := xgoprojs.ParseAll(pattern...)
 	if err != nil {
@@ -0,0 +1,58 @@
+/*
+ * Copyright (c) 2026 The XGo Authors (xgo.dev). All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package langserver
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func init() {
+	if os.Getenv("XGOROOT") == "" {
+		dir, _ := os.Getwd()
+		os.Setenv("XGOROOT", filepath.Clean(filepath.Join(dir, "./../..")))
+	}
+}
+
+func TestGenGoError(t *testing.T) {
+	ln, err := Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal("Listen:", err)
+	}
+	ctx := context.Background()
+	server := NewServer(ctx, ln, nil)
+	defer server.Shutdown()
+
+	c, err := Open(ctx, ln.Dialer(), nil)
+	if err != nil {
+		t.Fatal("Open:", err)
+	}
+	defer c.Close()
+
+	dir := t.TempDir()
+	os.WriteFile(filepath.Join(dir, "go.mod"), []byte("module foo\n\ngo 1.24\n"), 0644)
+	os.WriteFile(filepath.Join(dir, "main.xgo"), []byte("echo undefinedVar\n"), 0644)
+	if err = c.GenGo(ctx, dir); err == nil {
+		t.Fatal("GenGo: no error")
+	}
+	os.WriteFile(filepath.Join(dir, "main.xgo"), []byte("echo 1\n"), 0644)
+	if err = c.GenGo(ctx, dir); err != nil {
+		t.Fatal("GenGo:", err)
+	}
+}
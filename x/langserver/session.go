@@ -0,0 +1,264 @@
+/*
+ * Copyright (c) 2026 The XGo Authors (xgo.dev). All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package langserver
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/goplus/xgo/tool"
+	"github.com/goplus/xgo/x/gocmd"
+	"github.com/qiniu/x/errors"
+)
+
+// -----------------------------------------------------------------------------
+
+// CmdParams represents parameters of check, build and run requests.
+type CmdParams struct {
+	Dir   string   `json:"dir"`             // directory of the package, must be absolute
+	Flags []string `json:"flags,omitempty"` // flags passed to the go command, see allowedFlags
+	Args  []string `json:"args,omitempty"`  // arguments of the program to run
+}
+
+// CmdResult represents the result of check, build and run requests.
+type CmdResult struct {
+	Output   string `json:"output"`
+	ExitCode int    `json:"exitCode"`
+}
+
+// -----------------------------------------------------------------------------
+
+// session keeps a configuration of a module warm, so that parsed files and
+// imported packages are reused between requests.
+type session struct {
+	mu    sync.Mutex // serializes requests using the session
+	root  string
+	flags tool.ConfFlags
+	conf  *tool.Config
+
+	changed []string // files changed since the session was used, protected by handler.mutex
+}
+
+type sessionKey struct {
+	root  string
+	flags tool.ConfFlags
+}
+
+// session returns the session of the module containing dir. flags is used to
+// create configurations of the session.
+func (p *handler) session(dir string, flags tool.ConfFlags) *session {
+	root := modRoot(dir)
+	key := sessionKey{root, flags}
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	s, ok := p.sessions[key]
+	if !ok {
+		s = &session{root: root, flags: flags}
+		p.sessions[key] = s
+	}
+	return s
+}
+
+// useConf returns the configuration of a session. It must be called with
+// s.mu held. The configuration is created again if the module files or any
+// package imported by it have been changed.
+func (p *handler) useConf(s *session) (conf *tool.Config, err error) {
+	p.mutex.Lock()
+	changed := s.changed
+	s.changed = nil
+	p.mutex.Unlock()
+	if s.conf != nil {
+		for _, file := range changed {
+			switch filepath.Base(file) {
+			case "go.mod", "gop.mod", "gox.mod":
+				s.conf = nil
+			default:
+				if s.conf.Importer.Imported(filepath.Dir(file)) {
+					s.conf = nil
+				}
+			}
+			if s.conf == nil {
+				break
+			}
+		}
+	}
+	if s.conf == nil {
+		if conf, err = tool.NewDefaultConf(s.root, s.flags); err != nil {
+			return
+		}
+		conf.ParseCache = tool.NewParseCache()
+		if !conf.Mod.HasModfile() { // if no go.mod, check XGoDeps
+			conf.XGoDeps = new(int)
+		}
+		s.conf = conf
+	}
+	return s.conf, nil
+}
+
+func (p *handler) changedFiles(files []string) {
+	for _, s := range p.sessions {
+		s.changed = append(s.changed, files...)
+	}
+}
+
+// modRoot returns the root directory of the module containing dir, or dir
+// itself if it isn't in a module.
+func modRoot(dir string) string {
+	dir = filepath.Clean(dir)
+	for d := dir; ; {
+		for _, name := range [...]string{"go.mod", "gop.mod", "gox.mod"} {
+			if _, err := os.Stat(filepath.Join(d, name)); err == nil {
+				return d
+			}
+		}
+		parent := filepath.Dir(d)
+		if parent == d {
+			return dir
+		}
+		d = parent
+	}
+}
+
+// -----------------------------------------------------------------------------
+
+// allowedFlags are go command flags that clients can pass. Flags running other
+// programs (eg. -toolexec, -exec, -ldflags=-extld=...) or writing files
+// elsewhere (eg. -o, -overlay) are rejected. The value is true if the flag
+// takes a value.
+var allowedFlags = map[string]bool{
+	"a":         false,
+	"asan":      false,
+	"cover":     false,
+	"covermode": true,
+	"mod":       true,
+	"msan":      false,
+	"n":         false,
+	"p":         true,
+	"race":      false,
+	"tags":      true,
+	"trimpath":  false,
+	"v":         false,
+	"x":         false,
+}
+
+// checkFlags returns an error if flags contain a flag not in allowedFlags.
+func checkFlags(flags []string) error {
+	for i := 0; i < len(flags); i++ {
+		flag := flags[i]
+		name, _, hasValue := strings.Cut(strings.TrimPrefix(strings.TrimPrefix(flag, "-"), "-"), "=")
+		takesValue, ok := allowedFlags[name]
+		if !ok || !strings.HasPrefix(flag, "-") {
+			return fmt.Errorf("flag not allowed: %s", flag)
+		}
+		if takesValue && !hasValue {
+			i++ // the value is the next argument
+		}
+	}
+	return nil
+}
+
+// Check compiles the package in dir without generating Go files.
+func (p *handler) Check(ctx context.Context, params *CmdParams) CmdResult {
+	return p.doCmd(ctx, params, 0, func(conf *tool.Config, _ *gocmd.Config) error {
+		_, _, err := tool.GenGoEx(params.Dir, conf, true, tool.GenFlagCheckOnly)
+		return err
+	})
+}
+
+// Build builds the package in dir. The output file is written into dir.
+func (p *handler) Build(ctx context.Context, params *CmdParams) CmdResult {
+	return p.doCmd(ctx, params, tool.ConfFlagNoTestFiles, func(conf *tool.Config, confCmd *gocmd.Config) error {
+		return tool.BuildDir(params.Dir, conf, confCmd)
+	})
+}
+
+// Run runs the application of the package in dir.
+func (p *handler) Run(ctx context.Context, params *CmdParams) CmdResult {
+	return p.doCmd(ctx, params, tool.ConfFlagNoTestFiles, func(conf *tool.Config, confCmd *gocmd.Config) error {
+		return tool.RunDir(params.Dir, params.Args, conf, confCmd)
+	})
+}
+
+// doCmd runs a request. The go command and the program it runs are killed if
+// ctx is done (eg. the request is canceled or the client disconnects).
+func (p *handler) doCmd(ctx context.Context, params *CmdParams, flags tool.ConfFlags, do func(conf *tool.Config, confCmd *gocmd.Config) error) (ret CmdResult) {
+	if !filepath.IsAbs(params.Dir) {
+		return CmdResult{Output: "dir must be an absolute path: " + params.Dir + "\n", ExitCode: 2}
+	}
+	if err := checkFlags(params.Flags); err != nil {
+		return CmdResult{Output: err.Error() + "\n", ExitCode: 2}
+	}
+	s := p.session(params.Dir, flags)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var out bytes.Buffer
+	conf, err := p.useConf(s)
+	if err == nil {
+		confCmd := conf.NewGoCmdConf()
+		confCmd.Flags = params.Flags
+		confCmd.Run = func(cmd *exec.Cmd) error {
+			cmd.Dir = params.Dir
+			cmd.Stdout = &out
+			cmd.Stderr = &out
+			return runCmd(ctx, cmd)
+		}
+		if err = ctx.Err(); err == nil {
+			err = do(conf, confCmd)
+			conf.UpdateCache()
+		}
+	}
+	if err != nil {
+		var ee *exec.ExitError
+		if errors.As(err, &ee) {
+			ret.ExitCode = ee.ExitCode()
+		} else {
+			out.WriteString(errors.Summary(err) + "\n")
+			ret.ExitCode = 1
+		}
+	}
+	ret.Output = out.String()
+	return
+}
+
+// -----------------------------------------------------------------------------
+
+// runCmd runs cmd, and kills it if ctx is done before it exits.
+func runCmd(ctx context.Context, cmd *exec.Cmd) error {
+	startGroup(cmd)
+	cmd.WaitDelay = time.Second // don't wait for orphans holding the output
+	if err := cmd.Start(); err != nil {
+		return err
+	}
+	stop := context.AfterFunc(ctx, func() {
+		killGroup(cmd)
+	})
+	err := cmd.Wait()
+	if !stop() && ctx.Err() != nil {
+		return ctx.Err()
+	}
+	return err
+}
+
+// -----------------------------------------------------------------------------
@@ -0,0 +1,278 @@
+/*
+ * Copyright (c) 2026 The XGo Authors (xgo.dev). All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package modproxy
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"golang.org/x/mod/modfile"
+	"golang.org/x/mod/module"
+	"golang.org/x/mod/sumdb/dirhash"
+)
+
+// Sum returns the hash of a module recorded in go.sum, downloading its zip
+// file if it isn't in the module cache.
+func (p *Fetcher) Sum(ctx context.Context, mod module.Version) (hash string, err error) {
+	dlDir, escVer, err := p.downloadDir(mod)
+	if err != nil {
+		return
+	}
+	base := filepath.Join(dlDir, escVer)
+	if data, e := os.ReadFile(base + ".ziphash"); e == nil {
+		return strings.TrimSpace(string(data)), nil
+	}
+	zipFile := base + ".zip"
+	if _, e := os.Stat(zipFile); e == nil {
+		if hash, err = dirhash.HashZip(zipFile, dirhash.DefaultHash); err == nil {
+			writeFile(base+".ziphash", []byte(hash))
+		}
+		return
+	}
+	if err = os.MkdirAll(dlDir, 0777); err != nil {
+		return
+	}
+	if err = p.downloadZip(ctx, mod, zipFile); err != nil {
+		return
+	}
+	data, err := os.ReadFile(base + ".ziphash")
+	return string(data), err
+}
+
+// AddSums adds hashes of mods and modules they require to a go.sum file if
+// they aren't there, as `go get` does for mods.
+func (p *Fetcher) AddSums(ctx context.Context, gosum string, mods ...module.Version) error {
+	sums, err := readSums(gosum)
+	if err != nil {
+		return err
+	}
+	n := len(sums)
+	for _, mod := range mods {
+		if err = p.addSum(ctx, sums, mod, true); err != nil {
+			return err
+		}
+		if err = p.addDepSums(ctx, sums, nil, mod, true); err != nil {
+			return err
+		}
+	}
+	if len(sums) == n {
+		return nil
+	}
+	return writeSums(gosum, sums)
+}
+
+// Tidy adds requirements of modules providing imports that aren't provided
+// by the module of a go.mod file or modules it requires, at their latest
+// versions, and then adds missing hashes to go.sum (see TidySums). Unlike
+// `go mod tidy`, it never removes requirements.
+func (p *Fetcher) Tidy(ctx context.Context, gomod string, imports []string) error {
+	data, err := os.ReadFile(gomod)
+	if err != nil {
+		return err
+	}
+	f, err := modfile.Parse(gomod, data, nil)
+	if err != nil {
+		return err
+	}
+	added := false
+	for _, imp := range imports {
+		if isStd(imp) || provides(f, imp) {
+			continue
+		}
+		mod, _, err := p.GetPkg(ctx, imp)
+		if err != nil {
+			return err
+		}
+		if err = f.AddRequire(mod.Path, mod.Version); err != nil {
+			return err
+		}
+		added = true
+	}
+	if added {
+		f.Cleanup()
+		if data, err = f.Format(); err != nil {
+			return err
+		}
+		if err = writeFile(gomod, data); err != nil {
+			return err
+		}
+	}
+	return p.TidySums(ctx, gomod)
+}
+
+// isStd reports whether pkgPath is a standard package: its first element
+// has no dot.
+func isStd(pkgPath string) bool {
+	elem, _, _ := strings.Cut(pkgPath, "/")
+	return !strings.Contains(elem, ".")
+}
+
+// provides reports whether the module of a go.mod file or a module it
+// requires may provide the package pkgPath.
+func provides(f *modfile.File, pkgPath string) bool {
+	in := func(modPath string) bool {
+		return pkgPath == modPath || strings.HasPrefix(pkgPath, modPath+"/")
+	}
+	if f.Module != nil && in(f.Module.Mod.Path) {
+		return true
+	}
+	for _, r := range f.Require {
+		if in(r.Mod.Path) {
+			return true
+		}
+	}
+	return false
+}
+
+// TidySums adds missing hashes to the go.sum file alongside a go.mod file,
+// as `go mod tidy` does for modules already required: hashes of required
+// modules and their go.mod files, and hashes of go.mod files they require.
+func (p *Fetcher) TidySums(ctx context.Context, gomod string) error {
+	data, err := os.ReadFile(gomod)
+	if err != nil {
+		return err
+	}
+	f, err := modfile.Parse(gomod, data, nil)
+	if err != nil {
+		return err
+	}
+	gosum := filepath.Join(filepath.Dir(gomod), "go.sum")
+	sums, err := readSums(gosum)
+	if err != nil {
+		return err
+	}
+	n := len(sums)
+	for _, r := range f.Require {
+		mod, ok := replaced(f, r.Mod)
+		if !ok {
+			continue
+		}
+		if err = p.addSum(ctx, sums, mod, true); err != nil {
+			return err
+		}
+		if err = p.addDepSums(ctx, sums, f, mod, false); err != nil {
+			return err
+		}
+	}
+	if len(sums) == n {
+		return nil
+	}
+	return writeSums(gosum, sums)
+}
+
+// addDepSums adds hashes of modules required by mod. Requirements are
+// replaced as main (the main go.mod file) specifies if it isn't nil.
+func (p *Fetcher) addDepSums(ctx context.Context, sums map[string]bool, main *modfile.File, mod module.Version, withZip bool) error {
+	data, err := p.GoMod(ctx, mod)
+	if err != nil {
+		return err
+	}
+	f, err := modfile.ParseLax(mod.String()+"/go.mod", data, nil)
+	if err != nil {
+		return err
+	}
+	for _, r := range f.Require {
+		dep, ok := r.Mod, true
+		if main != nil {
+			dep, ok = replaced(main, dep)
+		}
+		if ok {
+			if err = p.addSum(ctx, sums, dep, withZip); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// replaced returns the module replacing mod in a go.mod file. It returns
+// false if mod is replaced by a local directory.
+func replaced(f *modfile.File, mod module.Version) (module.Version, bool) {
+	for _, r := range f.Replace {
+		if r.Old.Path == mod.Path && (r.Old.Version == "" || r.Old.Version == mod.Version) {
+			return r.New, r.New.Version != ""
+		}
+	}
+	return mod, true
+}
+
+func (p *Fetcher) addSum(ctx context.Context, sums map[string]bool, mod module.Version, withZip bool) error {
+	if withZip && !hasSum(sums, mod.Path+" "+mod.Version+" ") {
+		hash, err := p.Sum(ctx, mod)
+		if err != nil {
+			return err
+		}
+		sums[mod.Path+" "+mod.Version+" "+hash] = true
+	}
+	if !hasSum(sums, mod.Path+" "+mod.Version+"/go.mod ") {
+		data, err := p.GoMod(ctx, mod)
+		if err != nil {
+			return err
+		}
+		sums[mod.Path+" "+mod.Version+"/go.mod "+GoModHash(data)] = true
+	}
+	return nil
+}
+
+func hasSum(sums map[string]bool, prefix string) bool {
+	for line := range sums {
+		if strings.HasPrefix(line, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+func readSums(gosum string) (sums map[string]bool, err error) {
+	sums = make(map[string]bool)
+	data, err := os.ReadFile(gosum)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			err = nil
+		}
+		return
+	}
+	s := bufio.NewScanner(bytes.NewReader(data))
+	for s.Scan() {
+		if line := strings.TrimSpace(s.Text()); line != "" {
+			sums[line] = true
+		}
+	}
+	return sums, s.Err()
+}
+
+func writeSums(gosum string, sums map[string]bool) error {
+	lines := make([]string, 0, len(sums))
+	for line := range sums {
+		lines = append(lines, line)
+	}
+	sort.Strings(lines)
+	var b bytes.Buffer
+	for _, line := range lines {
+		b.WriteString(line)
+		b.WriteByte('\n')
+	}
+	return writeFile(gosum, b.Bytes())
+}
+
+// -----------------------------------------------------------------------------
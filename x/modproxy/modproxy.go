@@ -0,0 +1,539 @@
+/*
+ * Copyright (c) 2026 The XGo Authors (xgo.dev). All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package modproxy downloads modules into the module cache by the GOPROXY
+// protocol, without running the go command. Modules are laid out in the cache
+// and verified against the checksum database just like the go command does,
+// so the go command uses them as if it downloaded them itself.
+package modproxy
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"net/http"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/goplus/mod/modcache"
+	xenv "github.com/goplus/xgo/env"
+	"golang.org/x/mod/module"
+	"golang.org/x/mod/semver"
+	"golang.org/x/mod/sumdb/dirhash"
+	modzip "golang.org/x/mod/zip"
+)
+
+var (
+	// ErrNotFound is returned if a module or version isn't found by the proxies.
+	ErrNotFound = errors.New("not found")
+
+	// ErrNoProxy is returned if no proxy can serve a module: GOPROXY has no
+	// proxies, the module matches GONOPROXY, or the proxies don't have the
+	// module and GOPROXY has `direct`. Callers should use the go command then.
+	ErrNoProxy = errors.New("no module proxy serves the module")
+)
+
+// -----------------------------------------------------------------------------
+
+// Fetcher downloads modules from module proxies.
+type Fetcher struct {
+	// Proxies is the list of proxy URLs, tried in order. A proxy is tried
+	// after its previous one fails with ErrNotFound, or with any error if
+	// its FallBack is true.
+	Proxies []Proxy
+
+	// Direct reports whether modules not found by the proxies are fetched
+	// directly from their repositories (`direct` in GOPROXY). The Fetcher
+	// can't, so it returns ErrNoProxy for them.
+	Direct bool
+
+	// NoProxy is a list of module path prefix patterns (GONOPROXY) that are
+	// fetched directly from their repositories.
+	NoProxy string
+
+	// ModCache is the module cache directory (GOMODCACHE).
+	ModCache string
+
+	// SumDB verifies downloaded modules. If nil, they aren't verified.
+	SumDB *SumDB
+
+	// Client is the HTTP client. If nil, http.DefaultClient is used.
+	Client *http.Client
+
+	// Verbose makes the Fetcher print downloading modules to stderr.
+	Verbose bool
+}
+
+// Proxy represents a module proxy.
+type Proxy struct {
+	URL      string
+	FallBack bool // fall back to the next proxy on any error (`|` in GOPROXY)
+}
+
+// New creates a Fetcher configured by environment variables like the go
+// command: GOPROXY, GONOPROXY, GOSUMDB, GONOSUMDB, GOPRIVATE, GOMODCACHE. Checksum
+// verification is disabled by GOSUMDB=off, or by GONOSUMCHECK=1 and
+// GOFLAGS=-insecure for compatibility with old go releases.
+func New() *Fetcher {
+	p := &Fetcher{ModCache: getenv("GOMODCACHE"), Verbose: true}
+	if p.ModCache == "" {
+		p.ModCache = modcache.GOMODCACHE
+	}
+	proxy := getenv("GOPROXY")
+	if proxy == "" {
+		proxy = "https://proxy.golang.org,direct"
+	}
+	p.Proxies = ParseProxies(proxy)
+	p.Direct = strings.Contains(","+strings.ReplaceAll(strings.ReplaceAll(proxy, " ", ""), "|", ",")+",", ",direct,")
+	if p.NoProxy = getenv("GONOPROXY"); p.NoProxy == "" {
+		p.NoProxy = getenv("GOPRIVATE")
+	}
+	if getenv("GONOSUMCHECK") != "1" && !strings.Contains(" "+getenv("GOFLAGS")+" ", " -insecure ") {
+		p.SumDB = newSumDB(getenv("GOSUMDB"), p.Proxies, p.ModCache)
+		if p.SumDB != nil {
+			noSum := getenv("GONOSUMDB")
+			if noSum == "" {
+				noSum = getenv("GOPRIVATE")
+			}
+			p.SumDB.NoSumDB = noSum
+		}
+	}
+	return p
+}
+
+// getenv returns value of an environment variable, or the setting written by
+// `xgo env -w` if it isn't set.
+func getenv(key string) string {
+	return xenv.Getenv(key)
+}
+
+// ParseProxies parses a GOPROXY value. `direct` and `off` are skipped because
+// they can't be served without the go command.
+func ParseProxies(goproxy string) (proxies []Proxy) {
+	for goproxy != "" {
+		var url string
+		fallBack := false
+		if i := strings.IndexAny(goproxy, ",|"); i >= 0 {
+			url, fallBack, goproxy = goproxy[:i], goproxy[i] == '|', goproxy[i+1:]
+		} else {
+			url, goproxy = goproxy, ""
+		}
+		switch url = strings.TrimSpace(url); url {
+		case "", "direct", "off":
+			continue
+		}
+		proxies = append(proxies, Proxy{URL: strings.TrimSuffix(url, "/"), FallBack: fallBack})
+	}
+	return
+}
+
+// -----------------------------------------------------------------------------
+
+// RevInfo describes a version of a module.
+type RevInfo struct {
+	Version string
+}
+
+// Query resolves a query (`latest`, a version, or a revision like a branch
+// name) of a module to its canonical version.
+func (p *Fetcher) Query(ctx context.Context, modPath, query string) (mod module.Version, err error) {
+	escPath, err := module.EscapePath(modPath)
+	if err != nil {
+		return
+	}
+	if query != "" && semver.Canonical(query) == query && !strings.Contains(query, "+") { // a version, maybe cached
+		mod = module.Version{Path: modPath, Version: query}
+		if dlDir, escVer, e := p.downloadDir(mod); e == nil {
+			if _, e = os.Stat(filepath.Join(dlDir, escVer+".info")); e == nil {
+				return
+			}
+		}
+	}
+	var data []byte
+	if query == "latest" {
+		data, err = p.get(ctx, escPath+"/@latest")
+		if errors.Is(err, ErrNotFound) {
+			return p.latestFromList(ctx, modPath, escPath)
+		}
+	} else {
+		var escQuery string
+		if escQuery, err = module.EscapeVersion(query); err != nil {
+			return
+		}
+		data, err = p.get(ctx, escPath+"/@v/"+escQuery+".info")
+	}
+	if err != nil {
+		return
+	}
+	var info RevInfo
+	if err = json.Unmarshal(data, &info); err != nil {
+		return
+	}
+	return module.Version{Path: modPath, Version: info.Version}, nil
+}
+
+func (p *Fetcher) latestFromList(ctx context.Context, modPath, escPath string) (mod module.Version, err error) {
+	data, err := p.get(ctx, escPath+"/@v/list")
+	if err != nil {
+		return
+	}
+	latest := ""
+	for _, v := range strings.Fields(string(data)) {
+		if semver.IsValid(v) && (latest == "" || betterVersion(v, latest)) {
+			latest = v
+		}
+	}
+	if latest == "" {
+		return mod, p.notFound(fmt.Errorf("%s@latest: %w", modPath, ErrNotFound))
+	}
+	return module.Version{Path: modPath, Version: latest}, nil
+}
+
+// betterVersion reports whether v is preferred to old as the latest version:
+// releases are preferred to prereleases.
+func betterVersion(v, old string) bool {
+	if (semver.Prerelease(v) == "") != (semver.Prerelease(old) == "") {
+		return semver.Prerelease(v) == ""
+	}
+	return semver.Compare(v, old) > 0
+}
+
+// Download downloads a module into the module cache if it isn't there, and
+// returns the directory it is extracted to.
+func (p *Fetcher) Download(ctx context.Context, mod module.Version) (dir string, err error) {
+	dir, err = p.modDir(mod)
+	if err != nil {
+		return
+	}
+	if fi, e := os.Stat(dir); e == nil && fi.IsDir() {
+		return
+	}
+	dlDir, escVer, err := p.downloadDir(mod)
+	if err != nil {
+		return
+	}
+	if err = os.MkdirAll(dlDir, 0777); err != nil {
+		return
+	}
+	if p.Verbose {
+		fmt.Fprintln(os.Stderr, "xgo: downloading", mod.Path, mod.Version)
+	}
+	base := filepath.Join(dlDir, escVer)
+	if _, err = p.GoMod(ctx, mod); err != nil {
+		return
+	}
+	zipFile := base + ".zip"
+	if _, e := os.Stat(zipFile); e != nil {
+		if err = p.downloadZip(ctx, mod, zipFile); err != nil {
+			return
+		}
+	}
+	if err = p.saveInfo(ctx, mod, base+".info"); err != nil {
+		return
+	}
+	return dir, extract(dir, mod, zipFile)
+}
+
+// GoMod returns the go.mod file of a module, downloading it into the module
+// cache if it isn't there.
+func (p *Fetcher) GoMod(ctx context.Context, mod module.Version) (data []byte, err error) {
+	dlDir, escVer, err := p.downloadDir(mod)
+	if err != nil {
+		return
+	}
+	modFile := filepath.Join(dlDir, escVer+".mod")
+	if data, err = os.ReadFile(modFile); err == nil {
+		return
+	}
+	escPath, _ := module.EscapePath(mod.Path)
+	if data, err = p.get(ctx, escPath+"/@v/"+escVer+".mod"); err != nil {
+		return
+	}
+	if p.SumDB != nil {
+		if err = p.SumDB.Check(mod.Path, mod.Version+"/go.mod", GoModHash(data)); err != nil {
+			return
+		}
+	}
+	if err = os.MkdirAll(dlDir, 0777); err != nil {
+		return
+	}
+	return data, writeFile(modFile, data)
+}
+
+// GoModHash returns the hash of a go.mod file recorded in go.sum.
+func GoModHash(data []byte) string {
+	h, _ := dirhash.Hash1([]string{"go.mod"}, func(string) (io.ReadCloser, error) {
+		return io.NopCloser(strings.NewReader(string(data))), nil
+	})
+	return h
+}
+
+func (p *Fetcher) downloadZip(ctx context.Context, mod module.Version, zipFile string) (err error) {
+	escPath, _ := module.EscapePath(mod.Path)
+	escVer, _ := module.EscapeVersion(mod.Version)
+	body, err := p.open(ctx, escPath+"/@v/"+escVer+".zip")
+	if err != nil {
+		return
+	}
+	defer body.Close()
+	f, err := os.CreateTemp(filepath.Dir(zipFile), filepath.Base(zipFile)+".tmp*")
+	if err != nil {
+		return
+	}
+	tmp := f.Name()
+	defer os.Remove(tmp)
+	_, err = io.Copy(f, body)
+	if e := f.Close(); err == nil {
+		err = e
+	}
+	if err != nil {
+		return
+	}
+	hash, err := dirhash.HashZip(tmp, dirhash.DefaultHash)
+	if err != nil {
+		return
+	}
+	if p.SumDB != nil {
+		if err = p.SumDB.Check(mod.Path, mod.Version, hash); err != nil {
+			return
+		}
+	}
+	if err = os.Rename(tmp, zipFile); err != nil {
+		return
+	}
+	return writeFile(strings.TrimSuffix(zipFile, ".zip")+".ziphash", []byte(hash))
+}
+
+func (p *Fetcher) saveInfo(ctx context.Context, mod module.Version, infoFile string) error {
+	if _, err := os.Stat(infoFile); err == nil {
+		return nil
+	}
+	escPath, _ := module.EscapePath(mod.Path)
+	escVer, _ := module.EscapeVersion(mod.Version)
+	data, err := p.get(ctx, escPath+"/@v/"+escVer+".info")
+	if err != nil {
+		return err
+	}
+	return writeFile(infoFile, data)
+}
+
+// extract extracts a module zip file into dir, and makes it read-only like
+// the go command does.
+func extract(dir string, mod module.Version, zipFile string) (err error) {
+	if err = os.MkdirAll(filepath.Dir(dir), 0777); err != nil {
+		return
+	}
+	tmp, err := os.MkdirTemp(filepath.Dir(dir), filepath.Base(dir)+".tmp*")
+	if err != nil {
+		return
+	}
+	defer func() {
+		if err != nil {
+			makeWritable(tmp)
+			os.RemoveAll(tmp)
+		}
+	}()
+	if err = os.Remove(tmp); err != nil { // Unzip requires dir not existing or empty
+		return
+	}
+	if err = modzip.Unzip(tmp, mod, zipFile); err != nil {
+		return
+	}
+	if err = os.Rename(tmp, dir); err != nil {
+		if fi, e := os.Stat(dir); e == nil && fi.IsDir() { // extracted by another process
+			err = nil
+			makeWritable(tmp)
+			os.RemoveAll(tmp)
+		}
+		return
+	}
+	makeReadonly(dir)
+	return
+}
+
+func makeReadonly(dir string) {
+	filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err == nil {
+			if d.IsDir() {
+				defer os.Chmod(path, 0555) // after walking its entries
+			} else {
+				os.Chmod(path, 0444)
+			}
+		}
+		return nil
+	})
+}
+
+func makeWritable(dir string) {
+	filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err == nil && d.IsDir() {
+			os.Chmod(path, 0777)
+		}
+		return nil
+	})
+}
+
+// writeFile writes a file atomically.
+func writeFile(name string, data []byte) error {
+	f, err := os.CreateTemp(filepath.Dir(name), filepath.Base(name)+".tmp*")
+	if err != nil {
+		return err
+	}
+	tmp := f.Name()
+	_, err = f.Write(data)
+	if err == nil {
+		err = f.Chmod(0644)
+	}
+	if e := f.Close(); err == nil {
+		err = e
+	}
+	if err == nil {
+		err = os.Rename(tmp, name)
+	}
+	if err != nil {
+		os.Remove(tmp)
+	}
+	return err
+}
+
+func (p *Fetcher) modDir(mod module.Version) (string, error) {
+	escPath, err := module.EscapePath(mod.Path)
+	if err != nil {
+		return "", err
+	}
+	escVer, err := module.EscapeVersion(mod.Version)
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(p.ModCache, escPath+"@"+escVer), nil
+}
+
+func (p *Fetcher) downloadDir(mod module.Version) (dir, escVer string, err error) {
+	escPath, err := module.EscapePath(mod.Path)
+	if err != nil {
+		return
+	}
+	if escVer, err = module.EscapeVersion(mod.Version); err != nil {
+		return
+	}
+	return filepath.Join(p.ModCache, "cache", "download", escPath, "@v"), escVer, nil
+}
+
+// -----------------------------------------------------------------------------
+
+// GetPkg downloads the module containing a package specified by `pkgPath`
+// or `pkgPath@query` (`latest` if not specified), and returns the module and
+// the path of the package relative to the module.
+func (p *Fetcher) GetPkg(ctx context.Context, pkgPathQuery string) (mod module.Version, relPath string, err error) {
+	pkgPath, query, ok := strings.Cut(pkgPathQuery, "@")
+	if !ok {
+		query = "latest"
+	}
+	err = fmt.Errorf("module of %s: %w", pkgPath, ErrNotFound)
+	for modPath := pkgPath; modPath != "."; modPath = path.Dir(modPath) {
+		m, e := p.Query(ctx, modPath, query)
+		if e != nil {
+			if !errors.Is(e, ErrNotFound) {
+				return mod, "", e
+			}
+			continue
+		}
+		dir, e := p.Download(ctx, m)
+		if e != nil {
+			return mod, "", e
+		}
+		rel := strings.TrimPrefix(pkgPath[len(modPath):], "/")
+		if fi, e := os.Stat(filepath.Join(dir, rel)); e == nil && fi.IsDir() {
+			return m, rel, nil
+		}
+	}
+	return
+}
+
+// -----------------------------------------------------------------------------
+
+func (p *Fetcher) get(ctx context.Context, path string) ([]byte, error) {
+	body, err := p.open(ctx, path)
+	if err != nil {
+		return nil, err
+	}
+	defer body.Close()
+	return io.ReadAll(body)
+}
+
+// open requests a path (`<escaped module path>/@...`) of the proxies in order.
+func (p *Fetcher) open(ctx context.Context, path string) (body io.ReadCloser, err error) {
+	escPath, _, _ := strings.Cut(path, "/@")
+	if modPath, e := module.UnescapePath(escPath); e != nil {
+		return nil, e
+	} else if len(p.Proxies) == 0 || module.MatchPrefixPatterns(p.NoProxy, modPath) {
+		return nil, fmt.Errorf("%s: %w", modPath, ErrNoProxy)
+	}
+	client := p.Client
+	if client == nil {
+		client = http.DefaultClient
+	}
+	for _, proxy := range p.Proxies {
+		c := client
+		if strings.HasPrefix(proxy.URL, "file://") {
+			c = fileClient
+		}
+		body, err = openURL(ctx, c, proxy.URL+"/"+path)
+		if err == nil || !(proxy.FallBack || errors.Is(err, ErrNotFound)) {
+			return
+		}
+	}
+	return nil, p.notFound(err)
+}
+
+// notFound makes err also an ErrNoProxy if it is an ErrNotFound and modules
+// not found by the proxies are fetched directly.
+func (p *Fetcher) notFound(err error) error {
+	if p.Direct && errors.Is(err, ErrNotFound) {
+		return fmt.Errorf("%w: %w", ErrNoProxy, err)
+	}
+	return err
+}
+
+// fileClient serves file:// proxies (a directory laid out as GOMODCACHE's
+// cache/download) like the go command does.
+var fileClient = &http.Client{Transport: http.NewFileTransport(http.Dir("/"))}
+
+func openURL(ctx context.Context, client *http.Client, url string) (io.ReadCloser, error) {
+	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode == http.StatusOK {
+		return resp.Body, nil
+	}
+	resp.Body.Close()
+	if resp.StatusCode == http.StatusNotFound || resp.StatusCode == http.StatusGone {
+		return nil, fmt.Errorf("%s: %w", url, ErrNotFound)
+	}
+	return nil, fmt.Errorf("%s: %s", url, resp.Status)
+}
+
+// -----------------------------------------------------------------------------
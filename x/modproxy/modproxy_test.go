@@ -0,0 +1,377 @@
+/*
+ * Copyright (c) 2026 The XGo Authors (xgo.dev). All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package modproxy
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"golang.org/x/mod/module"
+	"golang.org/x/mod/sumdb"
+	"golang.org/x/mod/sumdb/note"
+	modzip "golang.org/x/mod/zip"
+)
+
+type memFile struct {
+	path string
+	data string
+}
+
+func (f memFile) Path() string                 { return f.path }
+func (f memFile) Lstat() (os.FileInfo, error)  { return memInfo{f}, nil }
+func (f memFile) Open() (io.ReadCloser, error) { return io.NopCloser(strings.NewReader(f.data)), nil }
+
+type memInfo struct{ memFile }
+
+func (fi memInfo) Name() string           { return filepath.Base(fi.path) }
+func (fi memInfo) Size() int64            { return int64(len(fi.data)) }
+func (fi memInfo) Mode() os.FileMode      { return 0644 }
+func (fi memInfo) ModTime() (t time.Time) { return }
+func (fi memInfo) IsDir() bool            { return false }
+func (fi memInfo) Sys() any               { return nil }
+
+// fakeProxy serves modules by the GOPROXY protocol.
+type fakeProxy struct {
+	mods map[string]map[string]string // path@version => files
+	zips map[string][]byte
+	reqs []string
+}
+
+func newFakeProxy(t *testing.T, mods map[string]map[string]string) *fakeProxy {
+	p := &fakeProxy{mods: mods, zips: make(map[string][]byte)}
+	for id, files := range mods {
+		path, ver, _ := strings.Cut(id, "@")
+		var list []modzip.File
+		for name, data := range files {
+			list = append(list, memFile{name, data})
+		}
+		var b bytes.Buffer
+		if err := modzip.Create(&b, module.Version{Path: path, Version: ver}, list); err != nil {
+			t.Fatal("modzip.Create:", err)
+		}
+		p.zips[id] = b.Bytes()
+	}
+	return p
+}
+
+func (p *fakeProxy) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	p.reqs = append(p.reqs, r.URL.Path)
+	escPath, file, ok := strings.Cut(strings.TrimPrefix(r.URL.Path, "/"), "/@v/")
+	if !ok {
+		http.NotFound(w, r)
+		return
+	}
+	path, _ := module.UnescapePath(escPath)
+	if file == "list" {
+		for id := range p.mods {
+			if strings.HasPrefix(id, path+"@") {
+				io.WriteString(w, id[len(path)+1:]+"\n")
+			}
+		}
+		return
+	}
+	ext := filepath.Ext(file)
+	ver, _ := module.UnescapeVersion(strings.TrimSuffix(file, ext))
+	files, ok := p.mods[path+"@"+ver]
+	if !ok {
+		http.Error(w, "not found", http.StatusGone)
+		return
+	}
+	switch ext {
+	case ".info":
+		io.WriteString(w, `{"Version":"`+ver+`"}`)
+	case ".mod":
+		io.WriteString(w, files["go.mod"])
+	case ".zip":
+		w.Write(p.zips[path+"@"+ver])
+	default:
+		http.NotFound(w, r)
+	}
+}
+
+var testMods = map[string]map[string]string{
+	"example.com/foo@v1.0.0": {
+		"go.mod":     "module example.com/foo\n",
+		"foo.go":     "package foo\n",
+		"bar/bar.go": "package bar\n",
+	},
+	"example.com/foo@v1.1.0": {
+		"go.mod":     "module example.com/foo\n\nrequire example.com/dep v1.0.0\n",
+		"foo.go":     "package foo // v1.1.0\n",
+		"bar/bar.go": "package bar // v1.1.0\n",
+	},
+	"example.com/foo@v1.2.0-pre": {
+		"go.mod": "module example.com/foo\n",
+		"foo.go": "package foo // v1.2.0-pre\n",
+	},
+	"example.com/dep@v1.0.0": {
+		"go.mod": "module example.com/dep\n",
+		"dep.go": "package dep\n",
+	},
+}
+
+func newTestFetcher(t *testing.T, h http.Handler) (*Fetcher, *httptest.Server) {
+	srv := httptest.NewServer(h)
+	t.Cleanup(srv.Close)
+	modCache := filepath.Join(t.TempDir(), "mod")
+	t.Cleanup(func() { makeWritable(modCache) })
+	return &Fetcher{Proxies: ParseProxies(srv.URL), ModCache: modCache}, srv
+}
+
+// -----------------------------------------------------------------------------
+
+func TestParseProxies(t *testing.T) {
+	ret := ParseProxies("https://a.example/, direct|https://b.example|https://c.example,off")
+	want := []Proxy{{"https://a.example", false}, {"https://b.example", true}, {"https://c.example", false}}
+	if len(ret) != len(want) {
+		t.Fatal("ParseProxies:", ret)
+	}
+	for i, p := range ret {
+		if p != want[i] {
+			t.Fatal("ParseProxies:", ret)
+		}
+	}
+	if ret := ParseProxies("off"); ret != nil {
+		t.Fatal("ParseProxies off:", ret)
+	}
+}
+
+func TestProxyFallBack(t *testing.T) {
+	var bad int
+	fail := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		bad++
+		http.Error(w, "oops", http.StatusInternalServerError)
+	}))
+	defer fail.Close()
+	p, srv := newTestFetcher(t, newFakeProxy(t, testMods))
+	ctx := context.Background()
+	p.Proxies = ParseProxies(fail.URL + "," + srv.URL)
+	if _, err := p.Query(ctx, "example.com/foo", "v1.0.0"); err == nil || errors.Is(err, ErrNotFound) {
+		t.Fatal("Query: no fallback on errors expected, got", err)
+	}
+	p.Proxies = ParseProxies(fail.URL + "|" + srv.URL)
+	if mod, err := p.Query(ctx, "example.com/foo", "v1.0.0"); err != nil || mod.Version != "v1.0.0" {
+		t.Fatal("Query:", mod, err)
+	}
+	if bad != 2 {
+		t.Fatal("bad proxy requests:", bad)
+	}
+	p.Proxies = nil
+	if _, err := p.Query(ctx, "example.com/foo", "v1.0.0"); !errors.Is(err, ErrNoProxy) {
+		t.Fatal("Query without proxies:", err)
+	}
+}
+
+func TestDownload(t *testing.T) {
+	p, srv := newTestFetcher(t, newFakeProxy(t, testMods))
+	ctx := context.Background()
+	mod, err := p.Query(ctx, "example.com/foo", "latest")
+	if err != nil || mod.Version != "v1.1.0" {
+		t.Fatal("Query latest:", mod, err)
+	}
+	dir, err := p.Download(ctx, mod)
+	if err != nil {
+		t.Fatal("Download:", err)
+	}
+	if dir != filepath.Join(p.ModCache, "example.com", "foo@v1.1.0") {
+		t.Fatal("Download dir:", dir)
+	}
+	if data, err := os.ReadFile(filepath.Join(dir, "bar", "bar.go")); string(data) != "package bar // v1.1.0\n" {
+		t.Fatal("bar.go:", string(data), err)
+	}
+	if fi, err := os.Stat(filepath.Join(dir, "foo.go")); err != nil || fi.Mode().Perm()&0222 != 0 {
+		t.Fatal("foo.go isn't read-only:", fi.Mode(), err)
+	}
+	dlDir := filepath.Join(p.ModCache, "cache", "download", "example.com", "foo", "@v")
+	for _, ext := range []string{".info", ".mod", ".zip", ".ziphash"} {
+		if _, err := os.Stat(filepath.Join(dlDir, "v1.1.0"+ext)); err != nil {
+			t.Fatal("Download:", err)
+		}
+	}
+	srv.Close()
+	if dir2, err := p.Download(ctx, mod); err != nil || dir2 != dir {
+		t.Fatal("Download from cache:", dir2, err)
+	}
+	if hash, err := p.Sum(ctx, mod); err != nil || !strings.HasPrefix(hash, "h1:") {
+		t.Fatal("Sum from cache:", hash, err)
+	}
+
+	fileProxy := "file://" + filepath.ToSlash(filepath.Join(p.ModCache, "cache", "download"))
+	p2 := &Fetcher{Proxies: ParseProxies(fileProxy), ModCache: t.TempDir()}
+	t.Cleanup(func() { makeWritable(p2.ModCache) })
+	if dir, err := p2.Download(ctx, mod); err != nil || !strings.HasPrefix(dir, p2.ModCache) {
+		t.Fatal("Download from file proxy:", dir, err)
+	}
+}
+
+func TestGetPkg(t *testing.T) {
+	p, _ := newTestFetcher(t, newFakeProxy(t, testMods))
+	ctx := context.Background()
+	mod, rel, err := p.GetPkg(ctx, "example.com/foo/bar@v1.0.0")
+	if err != nil || mod.Version != "v1.0.0" || rel != "bar" {
+		t.Fatal("GetPkg:", mod, rel, err)
+	}
+	if _, _, err = p.GetPkg(ctx, "example.com/foo/baz"); !errors.Is(err, ErrNotFound) {
+		t.Fatal("GetPkg baz:", err)
+	}
+}
+
+func TestTidySums(t *testing.T) {
+	p, _ := newTestFetcher(t, newFakeProxy(t, testMods))
+	ctx := context.Background()
+	dir := t.TempDir()
+	gomod := filepath.Join(dir, "go.mod")
+	os.WriteFile(gomod, []byte(`module example.com/app
+
+require (
+	example.com/foo v1.1.0
+	example.com/local v0.0.0
+)
+
+replace example.com/local => ../local
+`), 0644)
+	if err := p.TidySums(ctx, gomod); err != nil {
+		t.Fatal("TidySums:", err)
+	}
+	data, err := os.ReadFile(filepath.Join(dir, "go.sum"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	hash, _ := p.Sum(ctx, module.Version{Path: "example.com/foo", Version: "v1.1.0"})
+	want := "example.com/dep v1.0.0/go.mod " + GoModHash([]byte(testMods["example.com/dep@v1.0.0"]["go.mod"])) + "\n" +
+		"example.com/foo v1.1.0 " + hash + "\n" +
+		"example.com/foo v1.1.0/go.mod " + GoModHash([]byte(testMods["example.com/foo@v1.1.0"]["go.mod"])) + "\n"
+	if string(data) != want {
+		t.Fatalf("go.sum:\n%s\nwant:\n%s", data, want)
+	}
+	if fi, err := os.Stat(filepath.Join(dir, "go.sum")); err != nil || fi.Mode().Perm() != 0644 {
+		t.Fatal("go.sum mode:", fi.Mode(), err)
+	}
+}
+
+func TestTidy(t *testing.T) {
+	p, _ := newTestFetcher(t, newFakeProxy(t, testMods))
+	ctx := context.Background()
+	dir := t.TempDir()
+	gomod := filepath.Join(dir, "go.mod")
+	os.WriteFile(gomod, []byte("module example.com/app\n\ngo 1.21\n"), 0644)
+	imports := []string{"fmt", "example.com/app/sub", "example.com/foo/bar"}
+	if err := p.Tidy(ctx, gomod, imports); err != nil {
+		t.Fatal("Tidy:", err)
+	}
+	data, _ := os.ReadFile(gomod)
+	if want := "module example.com/app\n\ngo 1.21\n\nrequire example.com/foo v1.1.0\n"; string(data) != want {
+		t.Fatalf("go.mod:\n%s\nwant:\n%s", data, want)
+	}
+	data, _ = os.ReadFile(filepath.Join(dir, "go.sum"))
+	if !strings.Contains(string(data), "example.com/foo v1.1.0 h1:") || !strings.Contains(string(data), "example.com/dep v1.0.0/go.mod h1:") {
+		t.Fatal("go.sum:", string(data))
+	}
+	if _, err := os.Stat(filepath.Join(p.ModCache, "example.com", "foo@v1.1.0")); err != nil {
+		t.Fatal("Tidy: module isn't downloaded:", err)
+	}
+}
+
+func TestNoProxy(t *testing.T) {
+	p, _ := newTestFetcher(t, newFakeProxy(t, testMods))
+	ctx := context.Background()
+	if _, err := p.Query(ctx, "example.com/bar", "latest"); errors.Is(err, ErrNoProxy) || !errors.Is(err, ErrNotFound) {
+		t.Fatal("Query:", err)
+	}
+	p.Direct = true
+	if _, err := p.Query(ctx, "example.com/bar", "latest"); !errors.Is(err, ErrNoProxy) || !errors.Is(err, ErrNotFound) {
+		t.Fatal("Query with direct:", err)
+	}
+	if _, rel, err := p.GetPkg(ctx, "example.com/foo/bar"); err != nil || rel != "bar" {
+		t.Fatal("GetPkg with direct:", rel, err)
+	}
+	p.NoProxy = "example.com/foo"
+	if _, err := p.Download(ctx, module.Version{Path: "example.com/foo", Version: "v1.0.0"}); !errors.Is(err, ErrNoProxy) {
+		t.Fatal("Download with GONOPROXY:", err)
+	}
+	if _, err := p.Download(ctx, module.Version{Path: "example.com/foo", Version: "v1.1.0"}); err != nil {
+		t.Fatal("Download cached with GONOPROXY:", err)
+	}
+}
+
+func TestSumDB(t *testing.T) {
+	proxy := newFakeProxy(t, testMods)
+	p, psrv := newTestFetcher(t, proxy)
+	ctx := context.Background()
+	sums := make(map[string]string) // path@version => zip hash
+	for id := range testMods {
+		p2 := &Fetcher{Proxies: ParseProxies(psrv.URL), ModCache: t.TempDir()}
+		path, ver, _ := strings.Cut(id, "@")
+		hash, err := p2.Sum(ctx, module.Version{Path: path, Version: ver})
+		if err != nil {
+			t.Fatal("Sum:", err)
+		}
+		sums[id] = hash
+	}
+	sums["example.com/dep@v1.0.0"] = "h1:bad"
+
+	skey, vkey, err := note.GenerateKey(rand.Reader, "sum.example")
+	if err != nil {
+		t.Fatal(err)
+	}
+	gosum := func(path, vers string) ([]byte, error) {
+		id := path + "@" + vers
+		files, ok := testMods[id]
+		if !ok {
+			return nil, os.ErrNotExist
+		}
+		return []byte(path + " " + vers + " " + sums[id] + "\n" +
+			path + " " + vers + "/go.mod " + GoModHash([]byte(files["go.mod"])) + "\n"), nil
+	}
+	srv := sumdb.NewServer(sumdb.NewTestServer(skey, gosum))
+	mux := http.NewServeMux()
+	for _, path := range sumdb.ServerPaths {
+		mux.Handle(path, srv)
+	}
+	db := httptest.NewServer(mux)
+	defer db.Close()
+
+	p.SumDB = NewSumDB(vkey+" "+db.URL, p.ModCache)
+	if _, err = p.Download(ctx, module.Version{Path: "example.com/foo", Version: "v1.0.0"}); err != nil {
+		t.Fatal("Download:", err)
+	}
+	_, err = p.Download(ctx, module.Version{Path: "example.com/dep", Version: "v1.0.0"})
+	if !errors.Is(err, ErrChecksumMismatch) {
+		t.Fatal("Download: checksum mismatch expected, got", err)
+	}
+	if _, err = os.Stat(filepath.Join(p.ModCache, "example.com", "dep@v1.0.0")); err == nil {
+		t.Fatal("Download: module is extracted after checksum mismatch")
+	}
+
+	p.SumDB = NewSumDB(vkey+" "+db.URL, p.ModCache)
+	p.SumDB.NoSumDB = "example.com/dep"
+	if _, err = p.Download(ctx, module.Version{Path: "example.com/dep", Version: "v1.0.0"}); err != nil {
+		t.Fatal("Download with GONOSUMDB:", err)
+	}
+}
+
+// -----------------------------------------------------------------------------
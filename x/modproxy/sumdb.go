@@ -0,0 +1,222 @@
+/*
+ * Copyright (c) 2026 The XGo Authors (xgo.dev). All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package modproxy
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"golang.org/x/mod/module"
+	"golang.org/x/mod/sumdb"
+)
+
+var knownSumDBs = map[string]string{
+	"sum.golang.org":       "sum.golang.org+033de0ae+Ac4zctda0e5eza+HJyk9SxEdh+s3Ux18htTTAD8OuAn8",
+	"sum.golang.google.cn": "sum.golang.org+033de0ae+Ac4zctda0e5eza+HJyk9SxEdh+s3Ux18htTTAD8OuAn8",
+}
+
+// ErrChecksumMismatch is returned if a downloaded module doesn't match its
+// checksum in the checksum database.
+var ErrChecksumMismatch = errors.New("checksum mismatch")
+
+// SumDB verifies modules by a checksum database.
+type SumDB struct {
+	// Key is the verifier key of the checksum database.
+	Key string
+
+	// URL is the URL of the checksum database. If empty, it is accessed via
+	// the proxies if they support it, or directly by its name otherwise.
+	URL string
+
+	// NoSumDB is a list of module path prefix patterns (GONOSUMDB) that
+	// aren't verified.
+	NoSumDB string
+
+	// Client is the HTTP client. If nil, http.DefaultClient is used.
+	Client *http.Client
+
+	name      string
+	proxies   []Proxy
+	configDir string
+	cacheDir  string
+
+	once   sync.Once
+	client *sumdb.Client
+	mu     sync.Mutex
+}
+
+// NewSumDB creates a SumDB by a GOSUMDB value (`name`, `key` or `key url`).
+// Its configuration and cache are stored alongside modCache like the go
+// command does. It returns nil if gosumdb is `off`.
+func NewSumDB(gosumdb string, modCache string) *SumDB {
+	return newSumDB(gosumdb, nil, modCache)
+}
+
+func newSumDB(gosumdb string, proxies []Proxy, modCache string) *SumDB {
+	if gosumdb == "" {
+		gosumdb = "sum.golang.org"
+	}
+	if gosumdb == "off" {
+		return nil
+	}
+	key, url, _ := strings.Cut(gosumdb, " ")
+	if known, ok := knownSumDBs[key]; ok {
+		if url == "" && key != "sum.golang.org" {
+			url = "https://" + key
+		}
+		key = known
+	}
+	name, _, _ := strings.Cut(key, "+")
+	return &SumDB{
+		Key:       key,
+		URL:       strings.TrimSuffix(strings.TrimSpace(url), "/"),
+		name:      name,
+		proxies:   proxies,
+		configDir: filepath.Join(filepath.Dir(modCache), "sumdb"),
+		cacheDir:  filepath.Join(modCache, "cache", "download", "sumdb"),
+	}
+}
+
+// Check verifies that hash is the checksum of `path@vers` (vers ends with
+// `/go.mod` for the checksum of a go.mod file).
+func (p *SumDB) Check(path, vers, hash string) error {
+	if module.MatchPrefixPatterns(p.NoSumDB, path) {
+		return nil
+	}
+	p.once.Do(func() {
+		p.client = sumdb.NewClient(p)
+	})
+	lines, err := p.client.Lookup(path, vers)
+	if err != nil {
+		return fmt.Errorf("verifying %s@%s: %w", path, vers, err)
+	}
+	prefix := path + " " + vers + " "
+	for _, line := range lines {
+		if line == prefix+hash {
+			return nil
+		}
+		if strings.HasPrefix(line, prefix+"h1:") {
+			return fmt.Errorf("verifying %s@%s: %w\n\tdownloaded: %s\n\t%s: %s",
+				path, vers, ErrChecksumMismatch, hash, p.name, line[len(prefix):])
+		}
+	}
+	return fmt.Errorf("verifying %s@%s: no checksum in %s", path, vers, p.name)
+}
+
+// -----------------------------------------------------------------------------
+
+// ReadRemote implements sumdb.ClientOps.
+func (p *SumDB) ReadRemote(path string) ([]byte, error) {
+	client := p.Client
+	if client == nil {
+		client = http.DefaultClient
+	}
+	p.mu.Lock()
+	if p.URL == "" {
+		p.URL = p.baseURL(client)
+	}
+	url := p.URL
+	p.mu.Unlock()
+	body, err := openURL(context.Background(), client, url+path)
+	if err != nil {
+		return nil, err
+	}
+	defer body.Close()
+	return io.ReadAll(body)
+}
+
+// baseURL returns the URL of the first proxy supporting the checksum
+// database, or its direct URL if there isn't one.
+func (p *SumDB) baseURL(client *http.Client) string {
+	for _, proxy := range p.proxies {
+		url := proxy.URL + "/sumdb/" + p.name
+		body, err := openURL(context.Background(), client, url+"/supported")
+		if err == nil {
+			body.Close()
+			return url
+		}
+		if !(proxy.FallBack || errors.Is(err, ErrNotFound)) {
+			break
+		}
+	}
+	return "https://" + p.name
+}
+
+// ReadConfig implements sumdb.ClientOps.
+func (p *SumDB) ReadConfig(file string) ([]byte, error) {
+	if file == "key" {
+		return []byte(p.Key), nil
+	}
+	data, err := os.ReadFile(filepath.Join(p.configDir, file))
+	if errors.Is(err, os.ErrNotExist) {
+		return []byte{}, nil
+	}
+	return data, err
+}
+
+// WriteConfig implements sumdb.ClientOps.
+func (p *SumDB) WriteConfig(file string, old, new []byte) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	name := filepath.Join(p.configDir, file)
+	data, err := os.ReadFile(name)
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+	if !bytes.Equal(data, old) {
+		return sumdb.ErrWriteConflict
+	}
+	if err = os.MkdirAll(filepath.Dir(name), 0777); err != nil {
+		return err
+	}
+	return writeFile(name, new)
+}
+
+// ReadCache implements sumdb.ClientOps.
+func (p *SumDB) ReadCache(file string) ([]byte, error) {
+	return os.ReadFile(filepath.Join(p.cacheDir, file))
+}
+
+// WriteCache implements sumdb.ClientOps.
+func (p *SumDB) WriteCache(file string, data []byte) {
+	name := filepath.Join(p.cacheDir, file)
+	if os.MkdirAll(filepath.Dir(name), 0777) == nil {
+		writeFile(name, data)
+	}
+}
+
+// Log implements sumdb.ClientOps.
+func (p *SumDB) Log(msg string) {
+	log.Println(msg)
+}
+
+// SecurityError implements sumdb.ClientOps. The client returns
+// sumdb.ErrSecurity after it, which fails the download.
+func (p *SumDB) SecurityError(msg string) {
+	fmt.Fprintln(os.Stderr, msg)
+}
+
+// -----------------------------------------------------------------------------
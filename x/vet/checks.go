@@ -0,0 +1,221 @@
+/*
+ * Copyright (c) 2026 The XGo Authors (xgo.dev). All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package vet
+
+import (
+	"go/types"
+
+	"github.com/goplus/xgo/ast"
+	"github.com/goplus/xgo/token"
+	"github.com/goplus/xgo/x/typesutil"
+)
+
+// -----------------------------------------------------------------------------
+
+// ErrWrap checks for unused default values of error-wrapping expressions.
+var ErrWrap = &Analyzer{
+	Name: "errwrap",
+	Doc: `check for unused default values of expr?:default
+
+An expression statement of the form expr?:default discards the value of expr
+or default, so default is useless there.`,
+	Run: runErrWrap,
+}
+
+func runErrWrap(pass *Pass) {
+	for _, f := range pass.Files {
+		ast.Inspect(f, func(n ast.Node) bool {
+			if stmt, ok := n.(*ast.ExprStmt); ok {
+				if x, ok := stmt.X.(*ast.ErrWrapExpr); ok && x.Tok == token.QUESTION && x.Default != nil {
+					pass.Reportf(x.Default.Pos(), "default value of %s? is unused", typesutil.ExprString(x.X))
+				}
+			}
+			return true
+		})
+	}
+}
+
+// -----------------------------------------------------------------------------
+
+// commands are the builtin functions usually called in command style, like
+// `echo "Hello"` (see cl/builtin.go).
+var commands = map[string]bool{
+	"echo": true, "print": true, "println": true, "printf": true, "errorf": true,
+	"fprint": true, "fprintln": true, "fprintf": true,
+	"sprint": true, "sprintln": true, "sprintf": true,
+	"open": true, "create": true,
+	"lines": true, "blines": true, "errorln": true, "fatal": true,
+}
+
+// ShadowCmd checks for declarations shadowing builtin commands.
+var ShadowCmd = &Analyzer{
+	Name: "shadowcmd",
+	Doc: `check for declarations shadowing builtin commands
+
+A declaration named as a builtin command, like echo or println, changes the
+meaning of the commands in its scope. Methods and fields shadow commands only
+in classfiles, where they are used without a receiver.`,
+	Run: runShadowCmd,
+}
+
+func runShadowCmd(pass *Pass) {
+	for _, f := range pass.Files {
+		ast.Inspect(f, func(n ast.Node) bool {
+			ident, ok := n.(*ast.Ident)
+			if !ok || !commands[ident.Name] {
+				return true
+			}
+			switch obj := pass.Info.Defs[ident].(type) {
+			case nil:
+				return true
+			case *types.Func:
+				if obj.Type().(*types.Signature).Recv() != nil && !f.IsClass {
+					return true
+				}
+			case *types.Var:
+				if obj.IsField() && !f.IsClass {
+					return true
+				}
+			}
+			pass.Reportf(ident.Pos(), "declaration of %s shadows the builtin command", ident.Name)
+			return true
+		})
+	}
+}
+
+// -----------------------------------------------------------------------------
+
+// AutoProp checks for suspicious calls of auto-properties.
+var AutoProp = &Analyzer{
+	Name: "autoprop",
+	Doc: `check for suspicious calls of auto-properties
+
+An auto-property x.foo calls the method Foo of x without arguments. It's
+suspicious to discard its result, or to use it as a value if Foo has no
+result.`,
+	Run: runAutoProp,
+}
+
+func runAutoProp(pass *Pass) {
+	for _, f := range pass.Files {
+		notValue := make(map[ast.Expr]bool)
+		ast.Inspect(f, func(n ast.Node) bool {
+			switch n := n.(type) {
+			case *ast.ExprStmt:
+				if sel, ok := n.X.(*ast.SelectorExpr); ok {
+					notValue[sel] = true
+					if fn := autoProp(pass, sel); fn != nil && hasValues(fn.Type().(*types.Signature).Results()) {
+						pass.Reportf(sel.Pos(), "result of auto-property %s is unused", typesutil.ExprString(sel))
+					}
+				}
+			case *ast.CallExpr:
+				notValue[unparen(n.Fun)] = true
+			case *ast.AssignStmt:
+				for _, x := range n.Lhs {
+					notValue[x] = true
+				}
+			case *ast.SelectorExpr:
+				if notValue[n] {
+					break
+				}
+				if fn := autoProp(pass, n); fn != nil && fn.Type().(*types.Signature).Results().Len() == 0 {
+					pass.Reportf(n.Pos(), "auto-property %s has no result", typesutil.ExprString(n))
+				}
+			}
+			return true
+		})
+	}
+}
+
+// autoProp returns the method called by sel if it's an auto-property.
+func autoProp(pass *Pass, sel *ast.SelectorExpr) *types.Func {
+	fn, ok := pass.Info.Uses[sel.Sel].(*types.Func)
+	if !ok || fn.Name() == sel.Sel.Name {
+		return nil
+	}
+	if sig := fn.Type().(*types.Signature); sig.Recv() == nil || sig.Params().Len() != 0 {
+		return nil
+	}
+	return fn
+}
+
+func unparen(e ast.Expr) ast.Expr {
+	for {
+		p, ok := e.(*ast.ParenExpr)
+		if !ok {
+			return e
+		}
+		e = p.X
+	}
+}
+
+// hasValues reports whether results has a value other than a final error.
+func hasValues(results *types.Tuple) bool {
+	n := results.Len()
+	if n > 0 && types.Identical(results.At(n-1).Type(), types.Universe.Lookup("error").Type()) {
+		n--
+	}
+	return n > 0
+}
+
+// -----------------------------------------------------------------------------
+
+// ClassField checks for declarations shadowing fields in classfiles.
+var ClassField = &Analyzer{
+	Name: "classfield",
+	Doc: `check for declarations shadowing fields in classfiles
+
+In a classfile, fields are used without a receiver, so a local variable or a
+parameter named as a field hides it, which is often a mistake like writing
+x := 1 for x = 1.`,
+	Run: runClassField,
+}
+
+func runClassField(pass *Pass) {
+	for _, f := range pass.Files {
+		decl := f.ClassFieldsDecl()
+		if decl == nil {
+			continue
+		}
+		fields := make(map[string]bool)
+		for _, spec := range decl.Specs {
+			for _, name := range spec.(*ast.ValueSpec).Names {
+				fields[name.Name] = true
+			}
+		}
+		for _, decl := range f.Decls {
+			fn, ok := decl.(*ast.FuncDecl)
+			if !ok {
+				continue
+			}
+			check := func(n ast.Node) bool {
+				if ident, ok := n.(*ast.Ident); ok && fields[ident.Name] {
+					if v, ok := pass.Info.Defs[ident].(*types.Var); ok && !v.IsField() {
+						pass.Reportf(ident.Pos(), "declaration of %s shadows the class field", ident.Name)
+					}
+				}
+				return true
+			}
+			ast.Inspect(fn.Type, check)
+			if fn.Body != nil {
+				ast.Inspect(fn.Body, check)
+			}
+		}
+	}
+}
+
+// -----------------------------------------------------------------------------
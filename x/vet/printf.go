@@ -0,0 +1,365 @@
+/*
+ * Copyright (c) 2026 The XGo Authors (xgo.dev). All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package vet
+
+import (
+	"go/constant"
+	"go/types"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/goplus/xgo/ast"
+	"github.com/goplus/xgo/token"
+	"github.com/goplus/xgo/x/typesutil"
+)
+
+// -----------------------------------------------------------------------------
+
+// Printf checks for mismatches of printf format strings and arguments.
+var Printf = &Analyzer{
+	Name: "printf",
+	Doc: `check consistency of printf format strings and arguments
+
+The check applies to calls of printf-like functions, like printf, sprintf,
+errorf and fmt.Printf, or any function whose final parameters are a format
+string and ...any. It also reports calls of print-like functions, like echo
+and println, with a formatting directive in their first argument, and
+arguments of these calls which are func values not called.`,
+	Run: runPrintf,
+}
+
+// printFuncs are the print-like functions.
+var printFuncs = map[string]bool{
+	"fmt.Print": true, "fmt.Println": true,
+	"fmt.Sprint": true, "fmt.Sprintln": true,
+	"fmt.Fprint": true, "fmt.Fprintln": true,
+	"fmt.Append": true, "fmt.Appendln": true,
+	"log.Print": true, "log.Println": true,
+	"log.Fatal": true, "log.Fatalln": true,
+	"log.Panic": true, "log.Panicln": true,
+	"github.com/qiniu/x/osx.Errorln": true,
+	"github.com/qiniu/x/osx.Fatal":   true,
+}
+
+func runPrintf(pass *Pass) {
+	for _, f := range pass.Files {
+		ast.Inspect(f, func(n ast.Node) bool {
+			if call, ok := n.(*ast.CallExpr); ok {
+				checkPrintCall(pass, call)
+			}
+			return true
+		})
+	}
+}
+
+func checkPrintCall(pass *Pass, call *ast.CallExpr) {
+	var ident *ast.Ident
+	switch fn := unparen(call.Fun).(type) {
+	case *ast.Ident:
+		ident = fn
+	case *ast.SelectorExpr:
+		ident = fn.Sel
+	default:
+		return
+	}
+	fn, ok := pass.Info.Uses[ident].(*types.Func)
+	if !ok {
+		return
+	}
+	sig := fn.Type().(*types.Signature)
+	if !sig.Variadic() || call.Ellipsis != token.NoPos {
+		return
+	}
+	name := typesutil.ExprString(call.Fun)
+	if printFuncs[fn.FullName()] {
+		checkPrint(pass, call, name, sig.Params().Len()-1)
+	} else if idx := formatIndex(sig); idx >= 0 {
+		checkPrintf(pass, call, name, idx)
+	}
+}
+
+// formatIndex returns the index of the format parameter of sig, or -1 if sig
+// isn't printf-like.
+func formatIndex(sig *types.Signature) int {
+	params := sig.Params()
+	n := params.Len()
+	if n < 2 {
+		return -1
+	}
+	format := params.At(n - 2)
+	if format.Name() != "format" || !types.Identical(format.Type(), types.Typ[types.String]) {
+		return -1
+	}
+	if s, ok := params.At(n - 1).Type().(*types.Slice); !ok || !isEmptyInterface(s.Elem()) {
+		return -1
+	}
+	return n - 2
+}
+
+func isEmptyInterface(t types.Type) bool {
+	it, ok := t.Underlying().(*types.Interface)
+	return ok && it.Empty()
+}
+
+func checkPrint(pass *Pass, call *ast.CallExpr, name string, idx int) {
+	if idx >= len(call.Args) {
+		return
+	}
+	args := call.Args[idx:]
+	if s, ok := constString(pass, args[0]); ok {
+		if d := findDirective(s); d != "" {
+			pass.Reportf(args[0].Pos(), "%s call has possible formatting directive %s", name, d)
+		}
+	}
+	for _, arg := range args {
+		if isFuncValue(pass, arg) {
+			pass.Reportf(arg.Pos(), "%s arg %s is a func value, not called", name, typesutil.ExprString(arg))
+		}
+	}
+}
+
+func checkPrintf(pass *Pass, call *ast.CallExpr, name string, idx int) {
+	if idx >= len(call.Args) {
+		return
+	}
+	format, ok := constString(pass, call.Args[idx])
+	if !ok {
+		return
+	}
+	args := call.Args[idx+1:]
+	argNum := 0
+	for i := 0; i < len(format); i++ {
+		if format[i] != '%' {
+			continue
+		}
+		d := parseDirective(format[i:])
+		i += len(d.text) - 1
+		if d.indexed { // argument indexes aren't checked
+			return
+		}
+		switch d.verb {
+		case '%':
+			continue
+		case utf8.RuneError:
+			pass.Reportf(call.Pos(), "%s format %s is missing verb at end of string", name, d.text)
+			return
+		}
+		kinds, ok := verbs[d.verb]
+		if !ok {
+			pass.Reportf(call.Pos(), "%s format %s has unknown verb %c", name, d.text, d.verb)
+			return
+		}
+		argNum += d.stars
+		if argNum >= len(args) {
+			pass.Reportf(call.Pos(), "%s format %s reads arg #%d, but call has %s", name, d.text, argNum+1, count(len(args), "arg"))
+			return
+		}
+		arg := args[argNum]
+		argNum++
+		if d.verb != 'p' && d.verb != 'T' && isFuncValue(pass, arg) {
+			pass.Reportf(arg.Pos(), "%s format %s arg %s is a func value, not called", name, d.text, typesutil.ExprString(arg))
+		} else if t := argType(pass, arg); t != nil && !matchArg(kinds, t) {
+			pass.Reportf(arg.Pos(), "%s format %s has arg %s of wrong type %s", name, d.text, typesutil.ExprString(arg), t)
+		}
+	}
+	if argNum < len(args) {
+		pass.Reportf(call.Pos(), "%s call needs %s but has %s", name, count(argNum, "arg"), count(len(args), "arg"))
+	}
+}
+
+func count(n int, what string) string {
+	if n == 1 {
+		return "1 " + what
+	}
+	return strconv.Itoa(n) + " " + what + "s"
+}
+
+func constString(pass *Pass, e ast.Expr) (string, bool) {
+	tv := pass.Info.Types[e]
+	if tv.Value == nil || tv.Value.Kind() != constant.String {
+		return "", false
+	}
+	return constant.StringVal(tv.Value), true
+}
+
+// -----------------------------------------------------------------------------
+
+// A directive is a formatting directive of a printf format string, like %-8.2f.
+type directive struct {
+	text    string // source of the directive
+	verb    rune   // utf8.RuneError if it's missing
+	stars   int    // number of * for the width and the precision
+	indexed bool   // if it has an argument index, like %[1]d
+}
+
+// parseDirective parses the directive at the beginning of s, which starts
+// with %.
+func parseDirective(s string) (d directive) {
+	i := 1
+	for i < len(s) && strings.IndexByte("+-# 0", s[i]) >= 0 {
+		i++
+	}
+	for ; i < len(s); i++ {
+		c := s[i]
+		if c == '[' {
+			d.indexed = true
+			if end := strings.IndexByte(s[i:], ']'); end > 0 {
+				i += end
+			}
+		} else if c == '*' {
+			d.stars++
+		} else if c != '.' && (c < '0' || c > '9') {
+			break
+		}
+	}
+	d.verb = utf8.RuneError
+	if i < len(s) {
+		r, size := utf8.DecodeRuneInString(s[i:])
+		d.verb, i = r, i+size
+	}
+	d.text = s[:i]
+	return
+}
+
+// findDirective returns the first formatting directive with a known verb in s,
+// or "" if there is none.
+func findDirective(s string) string {
+	for i := 0; i < len(s); i++ {
+		if s[i] != '%' {
+			continue
+		}
+		d := parseDirective(s[i:])
+		if _, ok := verbs[d.verb]; ok {
+			return d.text
+		}
+		i += len(d.text) - 1
+	}
+	return ""
+}
+
+// -----------------------------------------------------------------------------
+
+// argKinds is a set of kinds of arguments accepted by a verb.
+type argKinds int
+
+const (
+	argBool argKinds = 1 << iota
+	argInt
+	argFloat
+	argComplex
+	argString
+	argPointer
+
+	argAny argKinds = -1
+)
+
+var verbs = map[rune]argKinds{
+	'b': argInt | argFloat | argComplex | argPointer,
+	'c': argInt,
+	'U': argInt,
+	'd': argInt | argPointer,
+	'o': argInt | argPointer,
+	'O': argInt | argPointer,
+	'x': argInt | argFloat | argComplex | argString | argPointer,
+	'X': argInt | argFloat | argComplex | argString | argPointer,
+	'e': argFloat | argComplex,
+	'E': argFloat | argComplex,
+	'f': argFloat | argComplex,
+	'F': argFloat | argComplex,
+	'g': argFloat | argComplex,
+	'G': argFloat | argComplex,
+	's': argString,
+	'q': argInt | argString,
+	't': argBool,
+	'p': argPointer,
+	'v': argAny,
+	'T': argAny,
+	'w': argAny,
+}
+
+// matchArg reports whether an argument of type t may be formatted by a verb
+// accepting kinds. Only arguments of basic types are checked.
+func matchArg(kinds argKinds, t types.Type) bool {
+	if kinds == argAny || hasMethod(t, "Format") {
+		return true
+	}
+	if kinds&argString != 0 && (hasMethod(t, "String") || hasMethod(t, "Error")) {
+		return true
+	}
+	u, ok := t.Underlying().(*types.Basic)
+	if !ok {
+		return true
+	}
+	info := u.Info()
+	switch {
+	case info&types.IsBoolean != 0:
+		return kinds&argBool != 0
+	case info&types.IsInteger != 0:
+		return kinds&argInt != 0
+	case info&types.IsFloat != 0:
+		return kinds&argFloat != 0
+	case info&types.IsComplex != 0:
+		return kinds&argComplex != 0
+	case info&types.IsString != 0:
+		return kinds&argString != 0
+	case u.Kind() == types.UnsafePointer:
+		return kinds&argPointer != 0
+	}
+	return true
+}
+
+func hasMethod(t types.Type, name string) bool {
+	sel := types.NewMethodSet(t).Lookup(nil, name)
+	return sel != nil && sel.Kind() == types.MethodVal
+}
+
+// argType returns the type of argument arg, which is the result type of the
+// method called if arg is an auto-property. It returns nil for other members
+// mapped to methods, like the attribute access x.$name.
+func argType(pass *Pass, arg ast.Expr) types.Type {
+	if sel, ok := arg.(*ast.SelectorExpr); ok {
+		if fn, ok := pass.Info.Uses[sel.Sel].(*types.Func); ok && fn.Name() != sel.Sel.Name {
+			if autoProp(pass, sel) != nil {
+				if results := fn.Type().(*types.Signature).Results(); results.Len() == 1 {
+					return results.At(0).Type()
+				}
+			}
+			return nil
+		}
+	}
+	return pass.TypeOf(arg)
+}
+
+// isFuncValue reports whether arg is a func value, like x.Len without a call.
+// Methods used without a receiver in classfiles aren't func values.
+func isFuncValue(pass *Pass, arg ast.Expr) bool {
+	switch e := unparen(arg).(type) {
+	case *ast.Ident:
+		if fn, ok := pass.Info.Uses[e].(*types.Func); ok && fn.Type().(*types.Signature).Recv() != nil {
+			return false
+		}
+	}
+	t := argType(pass, arg)
+	if t == nil {
+		return false
+	}
+	_, ok := t.Underlying().(*types.Signature)
+	return ok
+}
+
+// -----------------------------------------------------------------------------
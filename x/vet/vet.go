@@ -0,0 +1,129 @@
+/*
+ * Copyright (c) 2026 The XGo Authors (xgo.dev). All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package vet examines XGo source code and reports suspicious constructs,
+// such as printf calls whose arguments don't align with the format string.
+// It is a small analysis framework on top of x/typesutil: each check is an
+// Analyzer, which runs on a type-checked package through a Pass.
+package vet
+
+import (
+	"fmt"
+	"go/types"
+	"sort"
+
+	"github.com/goplus/xgo/ast"
+	"github.com/goplus/xgo/token"
+	"github.com/goplus/xgo/x/typesutil"
+)
+
+// -----------------------------------------------------------------------------
+
+// An Analyzer describes a check of XGo source code.
+type Analyzer struct {
+	// Name is the name of the check, which is used as the category of the
+	// diagnostics reported.
+	Name string
+
+	// Doc is the documentation of the check. Its first line is a summary.
+	Doc string
+
+	// Run examines the package of pass, and reports diagnostics by pass.Report.
+	Run func(pass *Pass)
+}
+
+// A Diagnostic is a message associated with a source location.
+type Diagnostic struct {
+	Pos      token.Pos
+	Category string // name of the analyzer that reports the diagnostic
+	Message  string
+}
+
+// A Pass provides information of a package to the Run function of an
+// Analyzer.
+type Pass struct {
+	Analyzer *Analyzer
+	Fset     *token.FileSet
+	Files    []*ast.File
+	Pkg      *types.Package
+	Info     *typesutil.Info
+
+	// Report reports a diagnostic.
+	Report func(d Diagnostic)
+}
+
+// Reportf reports a diagnostic at pos with a formatted message.
+func (p *Pass) Reportf(pos token.Pos, format string, args ...any) {
+	p.Report(Diagnostic{Pos: pos, Category: p.Analyzer.Name, Message: fmt.Sprintf(format, args...)})
+}
+
+// TypeOf returns the type of expression e, or nil if it's unknown.
+func (p *Pass) TypeOf(e ast.Expr) types.Type {
+	if tv, ok := p.Info.Types[e]; ok {
+		return tv.Type
+	}
+	if ident, ok := e.(*ast.Ident); ok {
+		if obj := p.ObjectOf(ident); obj != nil {
+			return obj.Type()
+		}
+	}
+	return nil
+}
+
+// ObjectOf returns the object denoted by ident, or nil if it's unknown.
+func (p *Pass) ObjectOf(ident *ast.Ident) types.Object {
+	if obj := p.Info.Defs[ident]; obj != nil {
+		return obj
+	}
+	return p.Info.Uses[ident]
+}
+
+// -----------------------------------------------------------------------------
+
+// Analyzers are all the checks of this package.
+var Analyzers = []*Analyzer{
+	ErrWrap,
+	ShadowCmd,
+	AutoProp,
+	ClassField,
+	Printf,
+}
+
+// Run runs analyzers on files of package pkg, which have been type-checked
+// with info as the result, and returns the diagnostics reported sorted by
+// position.
+func Run(fset *token.FileSet, pkg *types.Package, files []*ast.File, info *typesutil.Info, analyzers []*Analyzer) []Diagnostic {
+	var diags []Diagnostic
+	report := func(d Diagnostic) {
+		diags = append(diags, d)
+	}
+	for _, a := range analyzers {
+		a.Run(&Pass{
+			Analyzer: a,
+			Fset:     fset,
+			Files:    files,
+			Pkg:      pkg,
+			Info:     info,
+			Report:   report,
+		})
+	}
+	sort.SliceStable(diags, func(i, j int) bool {
+		return diags[i].Pos < diags[j].Pos
+	})
+	return diags
+}
+
+// -----------------------------------------------------------------------------
@@ -0,0 +1,214 @@
+/*
+ * Copyright (c) 2026 The XGo Authors (xgo.dev). All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package vet_test
+
+import (
+	"fmt"
+	"go/types"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/goplus/mod/env"
+	"github.com/goplus/mod/xgomod"
+	"github.com/goplus/xgo/ast"
+	"github.com/goplus/xgo/parser"
+	"github.com/goplus/xgo/token"
+	"github.com/goplus/xgo/tool"
+	"github.com/goplus/xgo/x/typesutil"
+	"github.com/goplus/xgo/x/vet"
+)
+
+func init() {
+	if os.Getenv("XGOROOT") == "" {
+		dir, _ := os.Getwd()
+		os.Setenv("XGOROOT", filepath.Clean(filepath.Join(dir, "./../..")))
+	}
+}
+
+// testVet checks files (by name) of a package with analyzer a, and compares
+// the diagnostics reported with expected.
+func testVet(t *testing.T, a *vet.Analyzer, files map[string]string, expected string) {
+	t.Helper()
+	fset := token.NewFileSet()
+	var xgofiles []*ast.File
+	names := make([]string, 0, len(files))
+	for name := range files {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	for _, name := range names {
+		f, err := parser.ParseEntry(fset, name, files[name], parser.Config{})
+		if err != nil {
+			t.Fatal("parser.ParseEntry:", err)
+		}
+		xgofiles = append(xgofiles, f)
+	}
+	conf := &types.Config{
+		Importer: tool.NewImporter(nil, &env.XGo{Root: "../..", Version: "1.0"}, fset),
+		Error: func(err error) {
+			t.Error("typecheck:", err)
+		},
+	}
+	pkg := types.NewPackage("main", "main")
+	info := &typesutil.Info{
+		Types:      make(map[ast.Expr]types.TypeAndValue),
+		Defs:       make(map[*ast.Ident]types.Object),
+		Uses:       make(map[*ast.Ident]types.Object),
+		Implicits:  make(map[ast.Node]types.Object),
+		Selections: make(map[*ast.SelectorExpr]*types.Selection),
+		Scopes:     make(map[ast.Node]*types.Scope),
+		Overloads:  make(map[*ast.Ident]types.Object),
+	}
+	chk := typesutil.NewChecker(conf, &typesutil.Config{Types: pkg, Fset: fset, Mod: xgomod.Default}, nil, info)
+	chk.Files(nil, xgofiles)
+	var b strings.Builder
+	for _, d := range vet.Run(fset, pkg, xgofiles, info, []*vet.Analyzer{a}) {
+		fmt.Fprintf(&b, "%v: %s: %s\n", fset.Position(d.Pos), d.Category, d.Message)
+	}
+	if ret := b.String(); ret != expected {
+		t.Fatalf("testVet:\n%s\nexpected:\n%s", ret, expected)
+	}
+}
+
+func TestErrWrap(t *testing.T) {
+	testVet(t, vet.ErrWrap, map[string]string{"main.xgo": `import "strconv"
+
+strconv.Atoi("1")!
+strconv.Atoi("1")?:0
+n := strconv.Atoi("2")?:0
+echo n
+`}, `main.xgo:4:20: errwrap: default value of strconv.Atoi("1")? is unused
+`)
+}
+
+func TestShadowCmd(t *testing.T) {
+	testVet(t, vet.ShadowCmd, map[string]string{"main.xgo": `type T struct {
+	print int
+}
+
+func (T) echo() {}
+
+func println(a ...any) {}
+
+func f(printf string) {
+	_ = printf
+}
+
+println "Hi"
+`, "Foo.gox": `var (
+	echo int
+)
+
+func sprint() {}
+`}, `Foo.gox:2:2: shadowcmd: declaration of echo shadows the builtin command
+Foo.gox:5:6: shadowcmd: declaration of sprint shadows the builtin command
+main.xgo:7:6: shadowcmd: declaration of println shadows the builtin command
+main.xgo:9:8: shadowcmd: declaration of printf shadows the builtin command
+`)
+}
+
+func TestAutoProp(t *testing.T) {
+	testVet(t, vet.AutoProp, map[string]string{"main.xgo": `type T struct{}
+
+func (T) Len() int       { return 1 }
+func (T) Close() error   { return nil }
+func (T) Reset()         {}
+func (T) Read() (int, error) { return 0, nil }
+
+x := T{}
+x.len
+x.close
+x.reset
+x.read
+a := x.len
+b := x.reset
+echo a
+`}, `main.xgo:9:1: autoprop: result of auto-property x.len is unused
+main.xgo:12:1: autoprop: result of auto-property x.read is unused
+main.xgo:14:6: autoprop: auto-property x.reset has no result
+`)
+}
+
+func TestClassField(t *testing.T) {
+	testVet(t, vet.ClassField, map[string]string{"Foo.gox": `var (
+	name string
+	n    int
+)
+
+func setName(name string) {
+	this.name = name
+}
+
+func inc() {
+	n := n + 1
+	echo n
+	type T struct {
+		name string
+	}
+}
+
+name = "x"
+`}, `Foo.gox:6:14: classfield: declaration of name shadows the class field
+Foo.gox:11:2: classfield: declaration of n shadows the class field
+`)
+}
+
+func TestPrintf(t *testing.T) {
+	testVet(t, vet.Printf, map[string]string{"main.xgo": `import "fmt"
+
+type T struct{}
+
+func (T) Len() int { return 1 }
+
+type Name string
+
+func (n Name) String() string { return string(n) }
+
+func logf(format string, args ...any) {}
+
+x := T{}
+printf "%d %s\n", 1, "a"
+printf "%d\n", "a"
+printf "%s %v\n", 1
+printf "%d\n", 1, 2
+printf "%*d %%\n", 2, 1
+printf "%[1]d %[1]s\n", 1
+printf "%s %d\n", Name("a"), x.len
+printf "%d\n", x.Len
+printf "%y\n", 1
+s := sprintf("%.2f", 1)
+fmt.Printf("%t\n", s)
+logf "%s", 1.0
+echo "%d", 1
+echo "100%", x.Len
+errorf "%w %d", fmt.Errorf("e"), 1
+`}, `main.xgo:15:16: printf: printf format %d has arg "a" of wrong type untyped string
+main.xgo:16:1: printf: printf format %v reads arg #2, but call has 1 arg
+main.xgo:16:19: printf: printf format %s has arg 1 of wrong type untyped int
+main.xgo:17:1: printf: printf call needs 1 arg but has 2 args
+main.xgo:21:16: printf: printf format %d arg x.Len is a func value, not called
+main.xgo:22:1: printf: printf format %y has unknown verb y
+main.xgo:23:22: printf: sprintf format %.2f has arg 1 of wrong type untyped int
+main.xgo:24:20: printf: fmt.Printf format %t has arg s of wrong type string
+main.xgo:25:12: printf: logf format %s has arg 1.0 of wrong type untyped float
+main.xgo:26:6: printf: echo call has possible formatting directive %d
+main.xgo:27:14: printf: echo arg x.Len is a func value, not called
+`)
+}
@@ -23,6 +23,7 @@ import (
 	"path/filepath"
 	"strings"
 	"sync"
+	"time"
 
 	"github.com/goplus/mod/xgomod"
 )
@@ -52,6 +53,7 @@ type Changes struct {
 	mods    map[string]*module
 	mutex   sync.Mutex
 	cond    sync.Cond
+	seq     int // number of changes so far
 
 	root string
 }
@@ -120,19 +122,55 @@ func (p *Changes) Fetch(fullPath bool) (dir string) {
 	return
 }
 
+// FetchAll waits for changes, and then until there are no more changes for
+// delay, so that changes made together (like saving all files in an editor)
+// are fetched at a time. It returns the directories changed.
+func (p *Changes) FetchAll(fullPath bool, delay time.Duration) (dirs []string) {
+	p.mutex.Lock()
+	for len(p.changed) == 0 {
+		p.cond.Wait()
+	}
+	for {
+		seq := p.seq
+		p.mutex.Unlock()
+		time.Sleep(delay)
+		p.mutex.Lock()
+		if p.seq == seq {
+			break
+		}
+	}
+	for dir := range p.changed {
+		if fullPath {
+			dir = p.root + dir
+		}
+		dirs = append(dirs, dir)
+	}
+	clear(p.changed)
+	p.mutex.Unlock()
+	return
+}
+
 func (p *Changes) Ignore(name string, isDir bool) bool {
 	dir, fname := path.Split(name)
 	if strings.HasPrefix(fname, "_") {
 		return true
 	}
+	if !isDir && isModfile(fname) {
+		return false
+	}
 	return !isDir && (isHiddenTemp(fname) || isAutogen(fname) || p.lookupMod(dir).ignore(fname))
 }
 
 func (p *Changes) FileChanged(name string) {
-	dir := path.Dir(name)
+	dir, fname := path.Split(name)
+	dir = path.Clean(dir)
 	p.mutex.Lock()
+	if isModfile(fname) { // classfiles of modules may change
+		clear(p.mods)
+	}
 	n := len(p.changed)
 	p.changed[dir] = none{}
+	p.seq++
 	p.mutex.Unlock()
 	if n == 0 {
 		p.cond.Broadcast()
@@ -162,6 +200,10 @@ func (p *Changes) DirAdded(name string) {
 	})
 }
 
+func isModfile(fname string) bool {
+	return fname == "go.mod" || fname == "gop.mod" || fname == "gox.mod"
+}
+
 // pattern: xgo_autogen*.go
 func isAutogen(fname string) bool {
 	return strings.HasPrefix(fname, "xgo_autogen") && strings.HasSuffix(fname, ".go")
@@ -18,6 +18,8 @@
 package watcher
 
 import (
+	"time"
+
 	"github.com/goplus/xgo/x/fsnotify"
 )
 
@@ -51,6 +53,10 @@ func (p Runner) Fetch(fullPath bool) (dir string) {
 	return p.c.Fetch(fullPath)
 }
 
+func (p Runner) FetchAll(fullPath bool, delay time.Duration) (dirs []string) {
+	return p.c.FetchAll(fullPath, delay)
+}
+
 func (p Runner) Run() error {
 	root := p.c.root
 	root = root[:len(root)-1]
@@ -20,6 +20,7 @@ import (
 	"errors"
 	"os"
 	"path/filepath"
+	"strings"
 	"syscall"
 )
 
@@ -45,9 +46,19 @@ type DirProj struct {
 	Dir string
 }
 
+// URLProj represents a project with a remote script (http:// or https://)
+type URLProj struct {
+	URL string
+}
+
+// ErrURLProj is returned by commands that don't support remote scripts: only
+// `xgo run` does.
+var ErrURLProj = errors.New("remote scripts are only supported by `xgo run`")
+
 func (p *FilesProj) projObj()   {}
 func (p *PkgPathProj) projObj() {}
 func (p *DirProj) projObj()     {}
+func (p *URLProj) projObj()     {}
 
 // -----------------------------------------------------------------------------
 
@@ -58,7 +69,9 @@ func ParseOne(args ...string) (proj Proj, next []string, err error) {
 		return nil, nil, syscall.ENOENT
 	}
 	arg := args[0]
-	if isFile(arg) {
+	if isURL(arg) {
+		return &URLProj{URL: arg}, args[1:], nil
+	} else if isFile(arg) {
 		n := 1
 		for n < len(args) && isFile(args[n]) {
 			n++
@@ -67,7 +80,21 @@ func ParseOne(args ...string) (proj Proj, next []string, err error) {
 	} else if isLocal(arg) {
 		return &DirProj{Dir: arg}, args[1:], nil
 	}
-	return &PkgPathProj{Path: arg}, args[1:], nil
+	return &PkgPathProj{Path: pkgPathVer(arg)}, args[1:], nil
+}
+
+func isURL(arg string) bool {
+	return strings.HasPrefix(arg, "https://") || strings.HasPrefix(arg, "http://")
+}
+
+// pkgPathVer converts modPath@version/dir to modPath/dir@version.
+func pkgPathVer(arg string) string {
+	if pos := strings.IndexByte(arg, '@'); pos > 0 {
+		if n := strings.IndexByte(arg[pos:], '/'); n > 0 {
+			return arg[:pos] + arg[pos+n:] + arg[pos:pos+n]
+		}
+	}
+	return arg
 }
 
 func isFile(fname string) bool {
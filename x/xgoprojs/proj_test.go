@@ -94,6 +94,30 @@ func TestParseAll_multiProjs(t *testing.T) {
 	}
 }
 
+func TestParseOne_remote(t *testing.T) {
+	proj, next, err := ParseOne("https://example.com/hello.xgo", "abc")
+	if err != nil || len(next) != 1 || next[0] != "abc" {
+		t.Fatal("ParseOne failed:", proj, next, err)
+	}
+	if proj, ok := proj.(*URLProj); !ok || proj.URL != "https://example.com/hello.xgo" {
+		t.Fatal("ParseOne failed:", proj)
+	}
+	proj.projObj()
+	for _, c := range [][2]string{
+		{"github.com/foo/bar@v1.2.0/cmd/hi", "github.com/foo/bar/cmd/hi@v1.2.0"},
+		{"github.com/foo/bar/cmd/hi@latest", "github.com/foo/bar/cmd/hi@latest"},
+		{"github.com/foo/bar", "github.com/foo/bar"},
+	} {
+		proj, _, err := ParseOne(c[0])
+		if err != nil {
+			t.Fatal("ParseOne failed:", err)
+		}
+		if proj, ok := proj.(*PkgPathProj); !ok || proj.Path != c[1] {
+			t.Fatal("ParseOne failed:", proj)
+		}
+	}
+}
+
 func TestParseAllErr(t *testing.T) {
 	_, err := ParseAll("a/...", "./a/...", "/a", "proj_test.go")
 	if err != ErrMixedFilesProj {